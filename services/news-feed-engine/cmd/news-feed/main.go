@@ -4,6 +4,7 @@ package main
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"net/http"
 	"os"
@@ -16,14 +17,28 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/kushin77/elevatedIQ/pkg/metrics"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/audit"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/auth"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/cache"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/config"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database/migrations"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/embeddings"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/events"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/handlers"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/healthhttp"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/ingestion"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/integrations"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/integrations/videosource"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/kafka"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/middleware"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/preferences"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/process"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/ratelimit"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/telemetry"
 )
 
 const (
@@ -32,6 +47,11 @@ const (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrateCLI(os.Args[2:])
+		return
+	}
+
 	// Initialize logger
 	logger, err := initLogger()
 	if err != nil {
@@ -48,6 +68,12 @@ func main() {
 	// Initialize metrics and health checks
 	healthRegistry := metrics.NewHealthCheckRegistry()
 
+	// runner coordinates the lifecycle of every long-running subsystem
+	// (HTTP server, Kafka producer, metrics pusher, ...) so they start in
+	// a consistent order, shut down in the reverse order on SIGTERM, and
+	// contribute to /ready instead of being wired ad hoc.
+	runner := process.NewRunner(logger)
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -66,6 +92,11 @@ func main() {
 
 	logger.Info("Database connected successfully")
 
+	if err := migrations.EnsureSchema(context.Background(), db.SQLDB()); err != nil {
+		logger.Fatal("Failed to apply database migrations", zap.Error(err))
+	}
+	logger.Info("Database schema up to date")
+
 	// Initialize Kafka producer
 	skipKafka := os.Getenv("SKIP_KAFKA_INIT") == "true"
 	var kafkaProducer *kafka.Producer
@@ -92,24 +123,98 @@ func main() {
 			)
 		}
 	}
-	if kafkaProducer != nil {
-		defer kafkaProducer.Close()
+	// kafkaProducer.Close() is now called by the "kafka-producer" process's
+	// Shutdown hook, registered with runner below, rather than deferred
+	// here, so it closes in the runner's coordinated shutdown order.
+
+	// Register Kafka health check - a real broker/topic metadata probe
+	// (see metrics.KafkaChecker) rather than a fixed "healthy" for any
+	// producer that merely exists. Noop producers (SKIP_KAFKA_INIT) have
+	// no client to probe, so they're reported plainly instead.
+	if client := kafkaProducer.Client(); client != nil {
+		healthRegistry.Register("kafka", metrics.CreateKafkaHealthChecker(client,
+			cfg.KafkaRawTopic, cfg.KafkaProcessedTopic, cfg.KafkaVideoTopic, cfg.KafkaEventsTopic))
+	} else {
+		healthRegistry.Register("kafka", func(ctx context.Context) metrics.HealthCheckResult {
+			return metrics.HealthCheckResult{
+				Name:      "kafka",
+				Status:    metrics.HealthStatusHealthy,
+				Message:   "noop producer, no broker configured",
+				Timestamp: time.Now(),
+			}
+		})
 	}
-
-	// Register Kafka health check
-	healthRegistry.Register("kafka", metrics.NewHealthyChecker("kafka"))
+	runner.Register(&process.FuncProcess{
+		NameFn: "kafka-producer",
+		HealthFn: func() error {
+			if kafkaProducer == nil {
+				return fmt.Errorf("kafka producer not initialized")
+			}
+			return nil
+		},
+		ShutdownFn: func(ctx context.Context) error { return kafkaProducer.Close() },
+	})
 
 	// Register service health check
 	healthRegistry.Register("news-feed-engine", metrics.NewHealthyChecker("news-feed-engine"))
 
-	// Initialize tracing (optional)
-	jaegerEndpoint := os.Getenv("JAEGER_ENDPOINT")
-	if jaegerEndpoint == "" {
-		jaegerEndpoint = "127.0.0.1:6831"
+	// Push metrics to an OTLP collector if one is configured; otherwise
+	// this process is a no-op that just reports healthy.
+	otlpEndpoint := os.Getenv("OTLP_METRICS_ENDPOINT")
+	var otlpExporter *metrics.OTLPExporter
+	if otlpEndpoint != "" {
+		otlpExporter = metrics.NewOTLPExporter(metrics.GlobalRegistry, otlpEndpoint, 15*time.Second, map[string]string{
+			"service.name": serviceName,
+			"environment":  cfg.Environment,
+		})
 	}
-
-	tracingEnabled := os.Getenv("TRACING_ENABLED") == "true"
-	if err := metrics.InitializeTracingProvider(serviceName, serviceVersion, cfg.Environment, jaegerEndpoint, tracingEnabled); err != nil {
+	runner.Register(&process.FuncProcess{
+		NameFn: "metrics-pusher",
+		RunFn: func(ctx context.Context) error {
+			if otlpExporter == nil {
+				<-ctx.Done()
+				return nil
+			}
+			otlpExporter.Start(ctx)
+			<-ctx.Done()
+			return nil
+		},
+		ShutdownFn: func(ctx context.Context) error {
+			if otlpExporter != nil {
+				otlpExporter.Stop()
+			}
+			return nil
+		},
+		HealthFn: func() error {
+			if otlpExporter == nil {
+				return nil
+			}
+			return otlpExporter.LastError()
+		},
+	})
+
+	// Fold the runner's own process health into the registry so /ready
+	// reflects real subsystem state instead of a fixed checklist.
+	healthRegistry.Register("processes", runner.AsHealthChecker("processes"))
+
+	// Initialize tracing (optional). Built directly from
+	// metrics.TracingConfig rather than through
+	// metrics.InitializeTracingProvider, which never set Exporter and so
+	// always fell back to the disabled tracer regardless of
+	// TracingEnabled.
+	traceEndpoint := cfg.OTLPTraceEndpoint
+	if cfg.TracingExporter == "jaeger" {
+		traceEndpoint = cfg.JaegerEndpoint
+	}
+	if err := metrics.InitGlobalTracingProvider(metrics.TracingConfig{
+		ServiceName:    serviceName,
+		Version:        serviceVersion,
+		Environment:    cfg.Environment,
+		Enabled:        cfg.TracingEnabled,
+		Exporter:       cfg.TracingExporter,
+		JaegerEndpoint: traceEndpoint,
+		Insecure:       cfg.Environment != "production",
+	}); err != nil {
 		logger.Warn("Failed to initialize tracing", zap.Error(err))
 	}
 
@@ -117,22 +222,329 @@ func main() {
 	contentRepo := database.NewContentRepository(db)
 	videoRepo := database.NewVideoRepository(db)
 	creatorRepo := database.NewCreatorRepository(db)
+
+	// Anonymized creator telemetry is opt-in: it only runs if both
+	// TELEMETRY_ENDPOINT is set and TELEMETRY_DISABLE isn't.
+	if endpoint := os.Getenv("TELEMETRY_ENDPOINT"); endpoint != "" && !telemetry.Disabled() {
+		telemetryReporter, err := telemetry.NewHTTPReporter(telemetry.Options{Endpoint: endpoint})
+		if err != nil {
+			logger.Warn("Failed to initialize telemetry reporter", zap.Error(err))
+		} else {
+			creatorRepo.WithTelemetry(telemetryReporter)
+			runner.Register(&process.FuncProcess{
+				NameFn: "telemetry-reporter",
+				RunFn: func(ctx context.Context) error {
+					telemetryReporter.Start(ctx)
+					<-ctx.Done()
+					return nil
+				},
+				ShutdownFn: func(ctx context.Context) error {
+					telemetryReporter.Stop()
+					return nil
+				},
+				HealthFn: telemetryReporter.LastError,
+			})
+		}
+	}
+
 	configRepo := database.NewConfigRepository(db)
-	sourceRepo := database.NewSourceRepository(db)
-	templateRepo := database.NewTemplateRepository(db)
+	auditRepo := database.NewAuditRepository(db)
+	sourceRepo := database.NewSourceRepository(db, auditRepo)
+	templateRepo := database.NewTemplateRepository(db, auditRepo)
 	analyticsRepo := database.NewAnalyticsRepository(db)
+	preferencesRepo := database.NewPreferencesRepository(db)
+	preferencesService := preferences.NewService(preferencesRepo, configRepo)
+	auditLogger := audit.NewPostgresLogger(auditRepo, logger, audit.Options{})
+	runner.Register(&process.FuncProcess{
+		NameFn: "audit-retention",
+		RunFn:  audit.NewRetentionJob(auditRepo, configRepo, nil, logger).Run,
+	})
 
 	// Initialize embedding service
 	embeddingService := embeddings.NewOpenAIService(cfg.OpenAIAPIKey)
 
+	// Upstream checkers for external APIs the service depends on,
+	// cached so a readiness probe scraped every second or so doesn't
+	// hammer them on every request.
+	var upstreamCheckers []metrics.HealthChecker
+	if cfg.OpenAIAPIKey != "" {
+		upstreamCheckers = append(upstreamCheckers, metrics.CreateCachingHealthChecker(
+			"openai",
+			metrics.CreateServiceAvailabilityChecker("openai", "https://api.openai.com/v1/models"),
+			30*time.Second,
+		))
+	}
+
+	// Initialize content response cache. "memory" is a per-replica LRU, so
+	// an invalidation consumer is registered below to keep replicas in
+	// sync; "redis" is already shared and needs no such consumer.
+	var contentCache cache.Store
+	var cacheRedisClient *redis.Client
+	switch cfg.CacheBackend {
+	case "redis":
+		redisStore, err := cache.NewRedisStore(cfg.RedisURL)
+		if err != nil {
+			logger.Warn("Failed to initialize Redis cache, content caching disabled", zap.Error(err))
+		} else {
+			contentCache = cache.WithSingleflight(redisStore)
+			cacheRedisClient = redisStore.Client()
+			healthRegistry.Register("redis", metrics.CreateRedisHealthChecker(cacheRedisClient))
+		}
+	default:
+		contentCache = cache.WithSingleflight(cache.NewLRUStore(cfg.CacheMaxBytes))
+	}
+
+	// Idempotency-Key records reuse the same cache backend as the
+	// response cache; if that failed to initialize, fall back to a
+	// dedicated in-process store so retry-safety still degrades to
+	// single-replica rather than disappearing entirely.
+	idempotencyCache := contentCache
+	if idempotencyCache == nil {
+		idempotencyCache = cache.NewLRUStore(cfg.CacheMaxBytes)
+	}
+	idempotencyStore := middleware.NewCacheIdempotencyStore(idempotencyCache)
+	const idempotencyTTL = 24 * time.Hour
+
+	// Session subsystem (internal/auth) reuses the same cache backend
+	// for its jti revocation list as Idempotency-Key records, since both
+	// are short-lived, replica-shared, self-expiring entries.
+	sessionRepo := database.NewSessionRepository(db)
+	sessionManager := auth.NewManager(cfg.JWTSecret, sessionRepo, idempotencyCache, cfg.SessionAccessTTL, cfg.SessionRefreshTTL)
+
 	// Initialize handlers
 	contentHandler := handlers.NewContentHandler(contentRepo, kafkaProducer, embeddingService, cfg.KafkaRawTopic, cfg.KafkaProcessedTopic)
-	videoHandler := handlers.NewVideoHandler(videoRepo, kafkaProducer, cfg.KafkaVideoTopic)
-	creatorHandler := handlers.NewCreatorHandler(creatorRepo, contentRepo)
-	adminHandler := handlers.NewAdminHandler(configRepo, sourceRepo, templateRepo, analyticsRepo)
-	webhookHandler := handlers.NewWebhookHandler(kafkaProducer, cfg.KafkaRawTopic, cfg.YouTubeWebhookSecret, cfg.TwitterConsumerSecret)
-	whitelabelHandler := handlers.NewWhitelabelHandler(configRepo)
-	healthCheck := handlers.NewHealthCheck(db, kafkaProducer)
+	contentHandler = contentHandler.WithPreferences(preferencesService)
+	contentHandler = contentHandler.WithAudit(auditLogger)
+	if contentCache != nil {
+		contentHandler = contentHandler.WithCache(contentCache, cfg.CacheInvalidationTopic)
+		if cfg.CacheBackend != "redis" {
+			invalidationConsumer, err := kafka.NewConsumer(cfg.KafkaBrokers, cfg.KafkaConsumerGroup+"-cache-invalidation", cfg.CacheInvalidationTopic, logger)
+			if err != nil {
+				logger.Warn("Failed to initialize cache invalidation consumer", zap.Error(err))
+			} else {
+				runner.Register(&process.FuncProcess{
+					NameFn: "cache-invalidation-consumer",
+					RunFn: func(ctx context.Context) error {
+						return cache.RunInvalidationConsumer(ctx, invalidationConsumer, contentCache, logger)
+					},
+					ShutdownFn: func(ctx context.Context) error { return invalidationConsumer.Close() },
+				})
+			}
+		}
+	}
+	// Initialize the job-event SSE hub. Its consumer group is shared
+	// across replicas but each replica fans out independently, so every
+	// replica sees every event regardless of which one a given SSE client
+	// is connected to.
+	eventsHub := events.NewHub()
+	contentHandler = contentHandler.WithEvents(eventsHub, cfg.KafkaEventsTopic)
+	eventsConsumer, err := kafka.NewConsumer(cfg.KafkaBrokers, cfg.KafkaConsumerGroup+"-job-events", cfg.KafkaEventsTopic, logger)
+	if err != nil {
+		logger.Warn("Failed to initialize job events consumer, SSE stream will see no events", zap.Error(err))
+	} else {
+		runner.Register(&process.FuncProcess{
+			NameFn: "job-events-consumer",
+			RunFn: func(ctx context.Context) error {
+				return events.RunConsumer(ctx, eventsConsumer, eventsHub, logger)
+			},
+			ShutdownFn: func(ctx context.Context) error { return eventsConsumer.Close() },
+		})
+	}
+
+	var youtubeIntegration *integrations.YouTubeIntegration
+	if cfg.YouTubeAPIKey != "" {
+		youtubeKeys := append([]string{cfg.YouTubeAPIKey}, cfg.YouTubeAPIKeyPool...)
+		youtubeIntegration = integrations.NewYouTubeIntegrationWithPool(youtubeKeys, cfg.YouTubeDailyQuotaBudget, logger)
+		if cfg.EnableYTDLPEnrichment {
+			youtubeIntegration.WithYTDLPFetcher(integrations.NewYTDLPFetcher(
+				cfg.YTDLPBinaryPath, cfg.YTDLPMaxWorkers, cfg.RateLimitRequests, cfg.RateLimitWindow, logger,
+			))
+		}
+
+		cursorRepo := database.NewChannelCursorRepository(db)
+		backfillRunner := ingestion.NewBackfillRunner(cursorRepo, kafkaProducer, cfg.KafkaRawTopic, logger)
+		readers := map[string]ingestion.ChannelReader{
+			"youtube": ingestion.NewYouTubeChannelReader(youtubeIntegration),
+			"rss":     ingestion.NewRSSChannelReader(integrations.NewRSSIntegration(logger)),
+		}
+		contentHandler = contentHandler.WithBackfill(backfillRunner, readers, cursorRepo)
+
+		upstreamCheckers = append(upstreamCheckers, metrics.CreateCachingHealthChecker(
+			"youtube",
+			metrics.CreateServiceAvailabilityChecker("youtube", "https://www.googleapis.com/youtube/v3/i18nLanguages?key="+cfg.YouTubeAPIKey),
+			30*time.Second,
+		))
+
+		subscriptionRepo := database.NewSubscriptionRepository(db)
+		youtubeIntegration.WithPubSub(subscriptionRepo, cfg.PublicBaseURL+"/api/v1/webhooks/youtube")
+		runner.Register(&process.FuncProcess{
+			NameFn: "youtube-pubsub-renewer",
+			RunFn: func(ctx context.Context) error {
+				ticker := time.NewTicker(cfg.YouTubePubSubRenewPeriod)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ctx.Done():
+						return nil
+					case <-ticker.C:
+						if renewed, err := youtubeIntegration.RenewExpiring(ctx, cfg.YouTubePubSubRenewBefore); err != nil {
+							logger.Warn("Failed to renew expiring pubsubhubbub subscriptions", zap.Error(err))
+						} else if renewed > 0 {
+							logger.Info("Renewed expiring pubsubhubbub subscriptions", zap.Int("count", renewed))
+						}
+					}
+				}
+			},
+		})
+	}
+	if len(upstreamCheckers) > 0 {
+		healthRegistry.Register("upstream", metrics.CreateCompositeHealthChecker("upstream", upstreamCheckers...))
+	}
+	// Video generation admission control (internal/ratelimit) needs a
+	// shared Redis client regardless of CacheBackend, since it must agree
+	// across replicas even when content caching runs in-process; reuse
+	// cacheRedisClient if the cache already gave us one rather than
+	// opening a second connection pool.
+	videoLimiterRedis := cacheRedisClient
+	if videoLimiterRedis == nil {
+		if redisStore, err := cache.NewRedisStore(cfg.RedisURL); err != nil {
+			logger.Warn("Failed to initialize Redis for video queue rate limiting, admission control disabled", zap.Error(err))
+		} else {
+			videoLimiterRedis = redisStore.Client()
+			healthRegistry.Register("redis", metrics.CreateRedisHealthChecker(videoLimiterRedis))
+		}
+	}
+	var videoLimiter *ratelimit.VideoLimiter
+	if videoLimiterRedis != nil {
+		videoLimiter = ratelimit.NewVideoLimiter(videoLimiterRedis, ratelimit.Quota{
+			MaxInFlight: cfg.VideoDefaultMaxInFlight,
+			MaxPerHour:  cfg.VideoDefaultMaxPerHour,
+		}, cfg.VideoLeaseDuration)
+	}
+
+	// HTTP rate limiting (internal/ratelimit) shares the same Redis
+	// client so the sliding window agrees across replicas; it falls back
+	// to an in-memory no-op (fail open, see middleware.RateLimiter) when
+	// Redis isn't reachable rather than blocking every request.
+	var httpLimiter *ratelimit.HTTPLimiter
+	if videoLimiterRedis != nil {
+		httpLimiter = ratelimit.NewHTTPLimiter(videoLimiterRedis, configRepo, ratelimit.RateLimitPolicy{
+			MaxRequests: cfg.RateLimitRequests,
+			Window:      cfg.RateLimitWindow,
+			Scope:       "public",
+		})
+	}
+
+	videoHandler := handlers.NewVideoHandler(videoRepo, kafkaProducer, cfg.KafkaVideoTopic, videoLimiter)
+	runner.Register(&process.FuncProcess{
+		NameFn: "video-lease-reaper",
+		RunFn: func(ctx context.Context) error {
+			ticker := time.NewTicker(cfg.VideoLeaseReapPeriod)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+					if reaped, err := videoRepo.ReapExpiredLeases(ctx); err != nil {
+						logger.Warn("Failed to reap expired video leases", zap.Error(err))
+					} else if reaped > 0 {
+						logger.Info("Reaped expired video leases", zap.Int("count", reaped))
+					}
+				}
+			}
+		},
+	})
+
+	runner.Register(&process.FuncProcess{
+		NameFn: "session-pruner",
+		RunFn: func(ctx context.Context) error {
+			const sessionPrunePeriod = 24 * time.Hour
+			ticker := time.NewTicker(sessionPrunePeriod)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+					if pruned, err := sessionRepo.Prune(ctx); err != nil {
+						logger.Warn("Failed to prune expired sessions", zap.Error(err))
+					} else if pruned > 0 {
+						logger.Info("Pruned expired sessions", zap.Int("count", pruned))
+					}
+				}
+			}
+		},
+	})
+
+	runner.Register(&process.FuncProcess{
+		NameFn: "analytics-rollup-refresher",
+		RunFn: func(ctx context.Context) error {
+			ticker := time.NewTicker(cfg.AnalyticsRollupPeriod)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case now := <-ticker.C:
+					tenantIDs, err := configRepo.ListTenantIDs(ctx)
+					if err != nil {
+						logger.Warn("Failed to list tenants for analytics rollup refresh", zap.Error(err))
+						continue
+					}
+					from := now.Add(-cfg.AnalyticsRollupPeriod - time.Minute)
+					for _, tenantID := range tenantIDs {
+						if err := analyticsRepo.RefreshRollups(ctx, tenantID, from, now); err != nil {
+							logger.Warn("Failed to refresh analytics rollups", zap.String("tenant_id", tenantID), zap.Error(err))
+						}
+					}
+				}
+			}
+		},
+	})
+	verificationRepo := database.NewVerificationRequestRepository(db)
+	creatorHandler := handlers.NewCreatorHandler(creatorRepo, contentRepo).
+		WithCreatorImport(kafkaProducer, cfg.KafkaCreatorImportTopic).
+		WithVerification(verificationRepo, kafkaProducer, eventsHub, cfg.KafkaEventsTopic).
+		WithAudit(auditLogger)
+	if youtubeIntegration != nil {
+		backfillCheckpointRepo := database.NewBackfillCheckpointRepository(db)
+		creatorHandler = creatorHandler.WithBackfill(youtubeIntegration, backfillCheckpointRepo, kafkaProducer, cfg.KafkaRawTopic)
+	}
+	adminHandler := handlers.NewAdminHandler(configRepo, sourceRepo, templateRepo, analyticsRepo, videoLimiter).WithAudit(auditLogger)
+	authHandler := handlers.NewAuthHandler(sessionManager, sessionRepo)
+	feedsHandler := handlers.NewFeedsHandler(sourceRepo)
+	auditHandler := handlers.NewAuditHandler(auditRepo)
+	webhookHandler := handlers.NewWebhookHandler(kafkaProducer, cfg.KafkaRawTopic, youtubeIntegration, cfg.YouTubeWebhookSecret, cfg.TwitterConsumerSecret, cfg.MastodonWebhookSecret)
+	// Chain the Data API with Invidious/Piped fallbacks (whichever are
+	// configured) so a quota exhaustion or single-instance outage doesn't
+	// drop video metadata hydration on an incoming PubSubHubbub push.
+	var videoSources []videosource.VideoSource
+	if youtubeIntegration != nil {
+		videoSources = append(videoSources, youtubeIntegration)
+	}
+	if len(cfg.InvidiousInstances) > 0 {
+		videoSources = append(videoSources, videosource.NewInvidiousSource(cfg.InvidiousInstances, logger))
+	}
+	if len(cfg.PipedInstances) > 0 {
+		videoSources = append(videoSources, videosource.NewPipedSource(cfg.PipedInstances, logger))
+	}
+	if len(videoSources) > 1 {
+		webhookHandler = webhookHandler.WithVideoSource(videosource.NewFallbackSourceWithThresholds(
+			cfg.VideoSourceFailureThreshold, cfg.VideoSourceCooldown, videoSources...,
+		))
+	}
+	whitelabelHandler := handlers.NewWhitelabelHandler(configRepo, kafkaProducer, cfg.KafkaWhitelabelTopic)
+	healthCheck := handlers.NewHealthCheck(db, kafkaProducer).
+		WithRunner(runner).
+		WithKafkaTopics(cfg.KafkaRawTopic, cfg.KafkaProcessedTopic, cfg.KafkaVideoTopic, cfg.KafkaEventsTopic)
+	if cacheRedisClient != nil {
+		healthCheck = healthCheck.WithRedis(cacheRedisClient)
+	}
+	for _, checker := range upstreamCheckers {
+		healthCheck = healthCheck.WithUpstreamChecker(checker)
+	}
+	healthCheck = healthCheck.WithAuditLogger(auditLogger)
 	// ensure variable is used to avoid unused var compile error (handlers register routes elsewhere)
 	_ = healthCheck
 
@@ -156,20 +568,29 @@ func main() {
 		MaxAge:           12 * time.Hour,
 	}))
 
-	// Add tracing middleware if enabled
-	if tracingEnabled {
-		router.Use(metrics.TracingMiddleware())
+	router.Use(middleware.TenantMiddleware())
+
+	// Tracing runs after TenantMiddleware so its tenant.id span
+	// attribute reflects the resolved tenant rather than "".
+	if cfg.TracingEnabled {
+		router.Use(middleware.TracingMiddleware(metrics.GetGlobalTracingProvider()))
 	}
 
-	router.Use(middleware.TenantMiddleware())
-	router.Use(middleware.RateLimiter(cfg.RateLimitRequests, cfg.RateLimitWindow))
+	if httpLimiter != nil {
+		router.Use(middleware.RateLimiter(httpLimiter, "public"))
+	}
 
 	// Health and metrics endpoints
-	healthHandler := metrics.NewHealthCheckHandler(healthRegistry)
-	router.GET("/health", healthHandler.GetHealthCheckHandler())
-	router.GET("/health/live", healthHandler.GetLivenessHandler())
-	router.GET("/health/ready", healthHandler.GetReadinessHandler())
-	router.GET("/ready", healthHandler.GetReadinessHandler()) // Keep for backward compatibility
+	healthTaggedRegistry := healthhttp.NewRegistry(healthRegistry)
+	healthTaggedRegistry.Tag("database", "critical")
+	healthTaggedRegistry.Tag("kafka", "critical")
+	healthHandler := healthhttp.New(healthTaggedRegistry, healthhttp.Options{ReadinessTags: []string{"critical"}})
+	router.GET("/healthz", healthHandler.Healthz)
+	router.GET("/readyz", healthHandler.Readyz)
+	router.GET("/health", healthHandler.Health)
+	router.GET("/health/live", healthHandler.Healthz) // Keep for backward compatibility
+	router.GET("/health/ready", healthHandler.Readyz) // Keep for backward compatibility
+	router.GET("/ready", healthHandler.Readyz)        // Keep for backward compatibility
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
 	// API v1 routes
@@ -180,16 +601,19 @@ func main() {
 		{
 			content.GET("", contentHandler.ListContent)
 			content.GET("/:id", contentHandler.GetContent)
+			content.PATCH("/:id", contentHandler.PatchContent)
 			content.GET("/category/:category", contentHandler.GetContentByCategory)
 			content.GET("/geo/:classification", contentHandler.GetContentByGeo)
 			content.GET("/trending", contentHandler.GetTrendingContent)
 			content.GET("/search", contentHandler.SearchContent)
+			content.GET("/events", contentHandler.StreamEvents)
 		}
 
 		// Creator endpoints
 		creators := v1.Group("/creators")
 		{
 			creators.GET("", creatorHandler.ListCreators)
+			creators.GET("/search", creatorHandler.SearchCreators)
 			creators.GET("/:id", creatorHandler.GetCreator)
 			creators.GET("/tier/:tier", creatorHandler.GetCreatorsByTier)
 			creators.GET("/:id/content", creatorHandler.GetCreatorContent)
@@ -201,43 +625,102 @@ func main() {
 			videos.GET("", videoHandler.ListVideos)
 			videos.GET("/:id", videoHandler.GetVideo)
 			videos.GET("/:id/transcript", videoHandler.GetVideoTranscript)
+			videos.POST("/:id/retry", videoHandler.RetryVideo)
+			videos.POST("/:id/cancel", videoHandler.CancelVideo)
+		}
+
+		// Session subsystem. Refresh takes a refresh token in the body
+		// rather than a bearer access token, so it's unauthenticated at
+		// the middleware level; logout/sessions act on the caller's own
+		// access token/sessions, so they sit behind AuthMiddleware.
+		authGroup := v1.Group("/auth")
+		{
+			authGroup.POST("/refresh", authHandler.Refresh)
+			authed := authGroup.Group("")
+			authed.Use(middleware.AuthMiddleware(cfg.JWTSecret, sessionManager))
+			authed.POST("/logout", authHandler.Logout)
+			authed.GET("/sessions", authHandler.ListSessions)
+			authed.DELETE("/sessions/:id", authHandler.RevokeSession)
+		}
+
+		// Feed subscription bulk import/export (OPML), requires auth
+		// since it writes content sources the same as admin/config/sources.
+		feeds := v1.Group("/feeds")
+		feeds.Use(middleware.AuthMiddleware(cfg.JWTSecret, sessionManager))
+		{
+			feeds.POST("/import", middleware.Idempotency(idempotencyStore, idempotencyTTL), feedsHandler.ImportFeeds)
+			feeds.GET("/export", feedsHandler.ExportFeeds)
 		}
 
-		// Admin endpoints (requires auth)
+		// Admin endpoints. adminTLSConfig lets an operator require mTLS
+		// for /admin/* (or accept either a client cert or a bearer JWT)
+		// while the public feed endpoints stay JWT-only; see
+		// middleware.MTLSMiddleware.
 		admin := v1.Group("/admin")
-		admin.Use(middleware.AuthMiddleware(cfg.JWTSecret))
+		admin.Use(middleware.MTLSMiddleware(adminTLSConfig(cfg, sessionManager, logger), configRepo))
+		if httpLimiter != nil {
+			// Its own scope so an operator can set a tighter admin policy
+			// via PUT /admin/config's settings.rate_limit_policies without
+			// affecting the public feed endpoints' limit.
+			admin.Use(middleware.RateLimiter(httpLimiter, "admin"))
+		}
+		admin.Use(middleware.DeadlineMiddleware(adminRouteDeadlines, configRepo))
 		{
 			// Content management
 			admin.POST("/content/ingest", contentHandler.TriggerIngestion)
+			admin.GET("/ingestion/jobs/:id", contentHandler.GetIngestionJob)
 			admin.POST("/content/:id/process", contentHandler.ProcessContent)
 			admin.DELETE("/content/:id", contentHandler.DeleteContent)
 
 			// Creator management
 			admin.POST("/creators", creatorHandler.CreateCreator)
+			admin.POST("/creators/bulk", creatorHandler.BulkImportCreators)
 			admin.PUT("/creators/:id", creatorHandler.UpdateCreator)
 			admin.DELETE("/creators/:id", creatorHandler.DeleteCreator)
 			admin.POST("/creators/:id/verify", creatorHandler.VerifyCreator)
+			admin.POST("/creators/:id/verification-requests", creatorHandler.CreateVerificationRequest)
+			admin.GET("/creators/:id/verification-requests", creatorHandler.ListVerificationRequests)
+			admin.POST("/verification-requests/:id/approve", middleware.RequireRole("creator:verify"), creatorHandler.ApproveVerificationRequest)
+			admin.POST("/verification-requests/:id/reject", middleware.RequireRole("creator:verify"), creatorHandler.RejectVerificationRequest)
+			admin.POST("/creators/:id/backfill", creatorHandler.BackfillCreator)
+			admin.GET("/creators/:id/backfill/status", creatorHandler.GetCreatorBackfillStatus)
 
 			// Video generation
-			admin.POST("/videos/generate", videoHandler.GenerateVideo)
+			admin.POST("/videos/generate", middleware.Idempotency(idempotencyStore, idempotencyTTL), videoHandler.GenerateVideo)
 			admin.GET("/videos/queue", videoHandler.GetVideoQueue)
+			admin.PATCH("/tenants/:id/quota", adminHandler.UpdateTenantQuota)
 
 			// Configuration (Appsmith integration)
 			admin.GET("/config", adminHandler.GetConfig)
-			admin.PUT("/config", adminHandler.UpdateConfig)
+			admin.PUT("/config", middleware.Idempotency(idempotencyStore, idempotencyTTL), adminHandler.UpdateConfig)
 			admin.GET("/config/sources", adminHandler.GetSourcesConfig)
-			admin.PUT("/config/sources", adminHandler.UpdateSourcesConfig)
+			admin.PUT("/config/sources", middleware.Idempotency(idempotencyStore, idempotencyTTL), adminHandler.UpdateSourcesConfig)
+			admin.POST("/config/sources/rollback/:change_id", middleware.Idempotency(idempotencyStore, idempotencyTTL), adminHandler.RollbackSourcesChange)
 			admin.GET("/config/templates", adminHandler.GetVideoTemplates)
-			admin.PUT("/config/templates", adminHandler.UpdateVideoTemplates)
+			admin.PUT("/config/templates", middleware.Idempotency(idempotencyStore, idempotencyTTL), adminHandler.UpdateVideoTemplates)
+			admin.POST("/config/templates/rollback/:change_id", middleware.Idempotency(idempotencyStore, idempotencyTTL), adminHandler.RollbackTemplatesChange)
+
+			// Tenant bundle (config + sources + templates) export/import
+			admin.GET("/bundle/export", adminHandler.ExportTenantBundle)
+			admin.POST("/bundle/import", middleware.Idempotency(idempotencyStore, idempotencyTTL), adminHandler.ImportTenantBundle)
+
+			// Audit log
+			admin.GET("/audit", auditHandler.ListEvents)
 
 			// Analytics
 			admin.GET("/analytics/overview", adminHandler.GetAnalyticsOverview)
 			admin.GET("/analytics/content", adminHandler.GetContentAnalytics)
 			admin.GET("/analytics/creators", adminHandler.GetCreatorAnalytics)
+			admin.POST("/analytics/rollups/rebuild", adminHandler.RebuildAnalyticsRollups)
 
 			// White-label configuration
 			admin.GET("/whitelabel", whitelabelHandler.GetWhitelabelConfig)
-			admin.PUT("/whitelabel", whitelabelHandler.UpdateWhitelabelConfig)
+			admin.GET("/whitelabel/schema", whitelabelHandler.GetWhitelabelSchema)
+			admin.PUT("/whitelabel", middleware.Idempotency(idempotencyStore, idempotencyTTL), whitelabelHandler.UpdateWhitelabelConfig)
+			admin.GET("/whitelabel/history", whitelabelHandler.GetWhitelabelHistory)
+			admin.GET("/whitelabel/history/:version", whitelabelHandler.GetWhitelabelVersion)
+			admin.GET("/whitelabel/diff", whitelabelHandler.GetWhitelabelDiff)
+			admin.POST("/whitelabel/rollback/:version", middleware.Idempotency(idempotencyStore, idempotencyTTL), whitelabelHandler.RollbackWhitelabelConfig)
 		}
 
 		// Webhook endpoints for platform integrations
@@ -248,6 +731,7 @@ func main() {
 			webhooks.POST("/twitter", webhookHandler.TwitterWebhook)
 			webhooks.GET("/twitter", webhookHandler.TwitterWebhook)
 			webhooks.POST("/reddit", webhookHandler.RedditWebhook)
+			webhooks.POST("/mastodon", webhookHandler.MastodonWebhook)
 		}
 	}
 
@@ -262,30 +746,48 @@ func main() {
 		MaxHeaderBytes:    1 << 20, // 1 MB
 	}
 
-	// Start server in goroutine
-	go func() {
-		logger.Info("Starting HTTP server",
-			zap.String("port", cfg.Port),
-			zap.String("environment", cfg.Environment),
-		)
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logger.Fatal("Failed to start server", zap.Error(err))
-		}
-	}()
-
-	// Wait for interrupt signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-
-	logger.Info("Shutting down server...")
+	runner.Register(&process.FuncProcess{
+		NameFn: "http-server",
+		RunFn: func(ctx context.Context) error {
+			logger.Info("Starting HTTP server",
+				zap.String("port", cfg.Port),
+				zap.String("environment", cfg.Environment),
+			)
+			if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				return err
+			}
+			return nil
+		},
+		ShutdownFn: func(ctx context.Context) error {
+			return srv.Shutdown(ctx)
+		},
+		HealthFn: func() error { return nil },
+	})
+
+	// The webhook handler has no background work of its own, but
+	// registering it lets /ready report whether its Kafka producer is
+	// actually usable rather than only the standalone "kafka" check.
+	runner.Register(&process.FuncProcess{
+		NameFn: "webhook-handler",
+		RunFn: func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		},
+		HealthFn: func() error {
+			if kafkaProducer == nil {
+				return fmt.Errorf("webhook handler has no kafka producer")
+			}
+			return nil
+		},
+	})
 
-	// Graceful shutdown with 30 second timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	// SIGTERM/SIGINT cancel this context, which the runner fans out to
+	// every registered process's Run so they can stop in lockstep.
+	signalCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	if err := srv.Shutdown(ctx); err != nil {
-		logger.Error("Server forced to shutdown", zap.Error(err))
+	if err := runner.Start(signalCtx); err != nil {
+		logger.Error("runner exited with error", zap.Error(err))
 	}
 
 	logger.Info("Server exited properly")
@@ -298,3 +800,54 @@ func initLogger() (*zap.Logger, error) {
 	}
 	return zap.NewDevelopment()
 }
+
+// adminRouteDeadlines bounds how long each /admin/* route group may run
+// before middleware.DeadlineMiddleware cancels its context server-side.
+// Analytics queries can scan a wide date range, so they get the longest
+// default; most admin endpoints are simple row reads/writes and get a
+// tight one. A tenant can raise an entry via ConfigRepository.DeadlinePolicy
+// (PUT /admin/config's settings.deadline_overrides) without a redeploy.
+var adminRouteDeadlines = map[string]time.Duration{
+	"/api/v1/admin/analytics/*": 10 * time.Second,
+	"/api/v1/admin/config":      2 * time.Second,
+	"/api/v1/admin/config/*":    2 * time.Second,
+	"/api/v1/admin/bundle/*":    15 * time.Second,
+}
+
+// adminTLSConfig builds the middleware.TLSAuthConfig the /admin route
+// group enforces, per cfg.AdminAuthMode. The CA bundle is only loaded
+// for the modes that actually validate a client certificate, so a
+// deployment running AdminAuthMode "password" doesn't need
+// AdminClientCAFile set at all.
+func adminTLSConfig(cfg *config.Config, sessions middleware.SessionChecker, logger *zap.Logger) middleware.TLSAuthConfig {
+	tlsCfg := middleware.TLSAuthConfig{
+		Mode:      middleware.AuthType(cfg.AdminAuthMode),
+		JWTSecret: cfg.JWTSecret,
+		Sessions:  sessions,
+	}
+
+	switch tlsCfg.Mode {
+	case middleware.AuthTypeCert, middleware.AuthTypeCertOrPassword:
+		pool, err := loadCAPool(cfg.AdminClientCAFile)
+		if err != nil {
+			logger.Fatal("Failed to load admin client CA bundle", zap.Error(err))
+		}
+		tlsCfg.CAPool = pool
+	}
+
+	return tlsCfg
+}
+
+// loadCAPool reads a PEM bundle of CA certificates from path.
+func loadCAPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle %s: %w", path, err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no valid certificates found in CA bundle %s", path)
+	}
+	return pool, nil
+}
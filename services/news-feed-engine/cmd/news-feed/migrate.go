@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/config"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database/migrations"
+)
+
+// runMigrateCLI handles `news-feed migrate up|down|status|create <name>`,
+// connecting to the same Postgres instance the server would (via
+// config.Load) rather than the server itself.
+func runMigrateCLI(args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: news-feed migrate up|down|status|create <name>")
+		os.Exit(2)
+	}
+
+	if args[0] == "create" {
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "usage: news-feed migrate create <name>")
+			os.Exit(2)
+		}
+		if err := migrations.Create(args[1]); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to create migration: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	db, err := database.Connect(cfg.PostgresDSN)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to database: %v\n", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		err = migrations.EnsureSchema(ctx, db.SQLDB())
+	case "down":
+		err = migrations.Down(ctx, db.SQLDB())
+	case "status":
+		err = migrations.Status(ctx, db.SQLDB())
+	default:
+		fmt.Fprintf(os.Stderr, "unknown migrate subcommand %q\n", args[0])
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "migrate %s failed: %v\n", args[0], err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,210 @@
+// Package audit records who changed what, when, and from what value to
+// what value, across tenant-owned mutable state (creators, content,
+// tenant config, content sources, video templates). Logger is the
+// narrow interface mutation call sites depend on; PostgresLogger is the
+// only implementation, batching writes through a bounded channel so a
+// request handler's Log call never waits on a database round trip.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+// ToMap renders v (typically a *models.Creator, *models.Content, ... or
+// nil for "didn't exist yet") as a plain map via a JSON round trip, so
+// call sites can hand it to Logger.Log as AuditEvent.Before/After
+// without each needing its own struct-to-map conversion. It returns nil
+// if v is nil or doesn't marshal.
+func ToMap(v interface{}) map[string]interface{} {
+	if v == nil {
+		return nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil
+	}
+	return m
+}
+
+// Logger records an audit event. Log must not block its caller on a
+// database round trip - PostgresLogger enqueues onto a bounded buffer
+// and drains it from a background goroutine instead.
+type Logger interface {
+	Log(ctx context.Context, event models.AuditEvent)
+}
+
+// Options configures a PostgresLogger's buffering. The thresholds its
+// health checker reports against are CreateAuditHealthChecker's own
+// arguments, not fields here.
+type Options struct {
+	// QueueSize bounds how many events Log can have buffered ahead of
+	// the background flusher; once full, Log drops (and counts) the
+	// event rather than blocking the caller.
+	QueueSize int
+	// BatchSize and FlushInterval bound how long an event can sit
+	// buffered before a batch INSERT, whichever comes first.
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+func (o Options) withDefaults() Options {
+	if o.QueueSize <= 0 {
+		o.QueueSize = 10000
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 100
+	}
+	if o.FlushInterval <= 0 {
+		o.FlushInterval = 2 * time.Second
+	}
+	return o
+}
+
+// PostgresLogger buffers audit events in memory and flushes them to
+// AuditRepository in batches from a background goroutine. Log is
+// non-blocking: a full buffer drops the event (counted, and logged)
+// rather than stalling the request that triggered it.
+type PostgresLogger struct {
+	repo   *database.AuditRepository
+	logger *zap.Logger
+	opts   Options
+
+	queue chan models.AuditEvent
+
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+	closed    chan struct{}
+
+	dropped      atomic.Int64
+	failingSince atomic.Value // time.Time; zero value means writes are currently healthy
+}
+
+// NewPostgresLogger creates a PostgresLogger backed by repo and starts
+// its background flusher.
+func NewPostgresLogger(repo *database.AuditRepository, logger *zap.Logger, opts Options) *PostgresLogger {
+	opts = opts.withDefaults()
+	if logger == nil {
+		logger, _ = zap.NewDevelopment()
+	}
+
+	l := &PostgresLogger{
+		repo:   repo,
+		logger: logger,
+		opts:   opts,
+		queue:  make(chan models.AuditEvent, opts.QueueSize),
+		closed: make(chan struct{}),
+	}
+	l.failingSince.Store(time.Time{})
+
+	l.wg.Add(1)
+	go l.run()
+	return l
+}
+
+// Log enqueues event for asynchronous persistence, filling in ID and
+// CreatedAt if the caller left them zero-valued. It never blocks: if
+// the buffer is full, the event is dropped and counted (surfaced via
+// the audit_log health check) rather than stalling the request.
+func (l *PostgresLogger) Log(ctx context.Context, event models.AuditEvent) {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	select {
+	case l.queue <- event:
+	default:
+		l.dropped.Add(1)
+		l.logger.Warn("audit event dropped, buffer full",
+			zap.String("action", event.Action), zap.String("target_type", event.TargetType), zap.String("target_id", event.TargetID))
+	}
+}
+
+// QueueDepth returns how many events are currently buffered, waiting
+// for the next flush.
+func (l *PostgresLogger) QueueDepth() int {
+	return len(l.queue)
+}
+
+// Dropped returns the number of events Log has discarded because the
+// buffer was full, since this PostgresLogger was created.
+func (l *PostgresLogger) Dropped() int64 {
+	return l.dropped.Load()
+}
+
+// FailingSince returns when writes started failing continuously, or the
+// zero time if the most recent flush (if any) succeeded.
+func (l *PostgresLogger) FailingSince() time.Time {
+	return l.failingSince.Load().(time.Time)
+}
+
+// Close flushes any buffered events and stops the background flusher.
+func (l *PostgresLogger) Close() {
+	l.closeOnce.Do(func() { close(l.closed) })
+	l.wg.Wait()
+}
+
+func (l *PostgresLogger) run() {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(l.opts.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]models.AuditEvent, 0, l.opts.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		err := l.repo.InsertBatch(ctx, batch)
+		cancel()
+
+		if err != nil {
+			if l.failingSince.Load().(time.Time).IsZero() {
+				l.failingSince.Store(time.Now())
+			}
+			l.logger.Error("audit batch insert failed", zap.Error(err), zap.Int("batch_size", len(batch)))
+		} else {
+			l.failingSince.Store(time.Time{})
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case event := <-l.queue:
+			batch = append(batch, event)
+			if len(batch) >= l.opts.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-l.closed:
+			for {
+				select {
+				case event := <-l.queue:
+					batch = append(batch, event)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
+)
+
+// defaultDegradedQueueDepth is the buffered-event count above which
+// CreateAuditHealthChecker reports metrics.HealthStatusDegraded: the
+// background flusher is falling behind and audit events are at risk of
+// being dropped once the buffer fills.
+const defaultDegradedQueueDepth = 5000
+
+// defaultUnhealthyAfter is how long batch inserts must have been
+// failing continuously before CreateAuditHealthChecker reports
+// metrics.HealthStatusUnhealthy.
+const defaultUnhealthyAfter = 2 * time.Minute
+
+// CreateAuditHealthChecker returns a metrics.HealthChecker named
+// "audit_log" reporting:
+//   - HealthStatusUnhealthy once writes have been failing continuously
+//     for longer than unhealthyAfter (zero uses defaultUnhealthyAfter),
+//   - HealthStatusDegraded once the buffered-event backlog exceeds
+//     degradedQueueDepth (zero uses defaultDegradedQueueDepth),
+//   - HealthStatusHealthy otherwise,
+//
+// so operators can see audit-loss risk on /health before it becomes
+// outright data loss.
+func CreateAuditHealthChecker(logger *PostgresLogger, degradedQueueDepth int, unhealthyAfter time.Duration) metrics.HealthChecker {
+	if degradedQueueDepth <= 0 {
+		degradedQueueDepth = defaultDegradedQueueDepth
+	}
+	if unhealthyAfter <= 0 {
+		unhealthyAfter = defaultUnhealthyAfter
+	}
+
+	return func(ctx context.Context) metrics.HealthCheckResult {
+		depth := logger.QueueDepth()
+		dropped := logger.Dropped()
+		failingSince := logger.FailingSince()
+
+		if !failingSince.IsZero() && time.Since(failingSince) > unhealthyAfter {
+			return metrics.HealthCheckResult{
+				Name:      "audit_log",
+				Status:    metrics.HealthStatusUnhealthy,
+				Message:   fmt.Sprintf("audit writes failing for %s, queue depth %d", time.Since(failingSince).Round(time.Second), depth),
+				Timestamp: time.Now(),
+			}
+		}
+		if depth > degradedQueueDepth {
+			return metrics.HealthCheckResult{
+				Name:      "audit_log",
+				Status:    metrics.HealthStatusDegraded,
+				Message:   fmt.Sprintf("audit write buffer backed up: %d events queued, %d dropped", depth, dropped),
+				Timestamp: time.Now(),
+			}
+		}
+
+		return metrics.HealthCheckResult{
+			Name:      "audit_log",
+			Status:    metrics.HealthStatusHealthy,
+			Message:   fmt.Sprintf("queue depth %d, %d dropped", depth, dropped),
+			Timestamp: time.Now(),
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
+)
+
+// defaultRetentionInterval is how often RetentionJob re-checks for a
+// partition to create or drop.
+const defaultRetentionInterval = 24 * time.Hour
+
+// defaultRetentionDays is how long a tenant's audit events are kept
+// when TenantPolicy returns zero for it (no explicit per-tenant policy).
+const defaultRetentionDays = 365
+
+// TenantPolicy returns how long tenantID's audit events should be kept.
+// A zero Duration falls back to defaultRetentionDays.
+type TenantPolicy func(ctx context.Context, tenantID string) (time.Duration, error)
+
+// RetentionJob keeps the partitioned audit_events table bounded: it
+// creates next month's partition ahead of time so writes never hit a
+// missing partition, and drops whole-month partitions once every tenant
+// that has ever written to them would have had that data expire under
+// its own TenantPolicy. A partition is dropped conservatively - only
+// once it's older than the longest retention window in effect - so a
+// shared monthly partition never deletes one tenant's events before
+// that tenant's own policy says to.
+type RetentionJob struct {
+	repo     *database.AuditRepository
+	tenants  *database.ConfigRepository
+	policy   TenantPolicy
+	logger   *zap.Logger
+	interval time.Duration
+}
+
+// NewRetentionJob creates a RetentionJob. policy may be nil, in which
+// case every tenant uses defaultRetentionDays.
+func NewRetentionJob(repo *database.AuditRepository, tenants *database.ConfigRepository, policy TenantPolicy, logger *zap.Logger) *RetentionJob {
+	if logger == nil {
+		logger, _ = zap.NewDevelopment()
+	}
+	return &RetentionJob{
+		repo:     repo,
+		tenants:  tenants,
+		policy:   policy,
+		logger:   logger,
+		interval: defaultRetentionInterval,
+	}
+}
+
+// WithInterval overrides how often Run re-checks partitions.
+func (j *RetentionJob) WithInterval(interval time.Duration) *RetentionJob {
+	j.interval = interval
+	return j
+}
+
+// Run ensures the next partition exists and sweeps for expired ones
+// once immediately, then on every tick, until ctx is cancelled.
+func (j *RetentionJob) Run(ctx context.Context) error {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.tick(ctx); err != nil {
+			j.logger.Error("audit retention tick failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *RetentionJob) tick(ctx context.Context) error {
+	now := time.Now().UTC()
+	if err := j.repo.EnsurePartition(ctx, now.AddDate(0, 1, 0)); err != nil {
+		return err
+	}
+
+	oldest, err := j.repo.OldestPartitionMonth(ctx)
+	if err != nil {
+		return err
+	}
+	if oldest.IsZero() {
+		return nil
+	}
+
+	retention := j.maxRetention(ctx)
+	cutoff := now.Add(-retention)
+
+	for month := oldest; month.Before(cutoff) && !sameMonth(month, now); month = month.AddDate(0, 1, 0) {
+		// A partition's last possible row is the instant before next
+		// month starts; only drop it once even that instant has expired.
+		if month.AddDate(0, 1, 0).After(cutoff) {
+			break
+		}
+		if err := j.repo.DropPartitionBefore(ctx, month); err != nil {
+			return err
+		}
+		j.logger.Info("dropped expired audit partition", zap.Time("month", month))
+	}
+	return nil
+}
+
+// maxRetention returns the longest retention window any known tenant
+// policy grants, so a shared monthly partition is never dropped before
+// the tenant with the strictest retention requirement needs it kept.
+func (j *RetentionJob) maxRetention(ctx context.Context) time.Duration {
+	longest := time.Duration(defaultRetentionDays) * 24 * time.Hour
+	if j.policy == nil || j.tenants == nil {
+		return longest
+	}
+
+	tenantIDs, err := j.tenants.ListTenantIDs(ctx)
+	if err != nil {
+		j.logger.Warn("failed to list tenants for audit retention policy", zap.Error(err))
+		return longest
+	}
+
+	for _, tenantID := range tenantIDs {
+		d, err := j.policy(ctx, tenantID)
+		if err != nil {
+			j.logger.Warn("failed to resolve audit retention policy", zap.String("tenant_id", tenantID), zap.Error(err))
+			continue
+		}
+		if d <= 0 {
+			d = time.Duration(defaultRetentionDays) * 24 * time.Hour
+		}
+		if d > longest {
+			longest = d
+		}
+	}
+	return longest
+}
+
+func sameMonth(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month()
+}
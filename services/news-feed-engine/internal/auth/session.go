@@ -0,0 +1,212 @@
+// Package auth issues and validates JWT sessions: a short-lived access
+// token plus a long-lived refresh token, with per-session revocation.
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/cache"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+// TokenPair is what IssueTokens and Refresh hand back to the caller.
+type TokenPair struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// ErrSessionTerminated is returned by Refresh when the session a
+// refresh token names has been revoked or has expired.
+var ErrSessionTerminated = fmt.Errorf("session has been terminated")
+
+// Manager issues, refreshes, and revokes sessions.
+type Manager struct {
+	secret      string
+	sessions    *database.SessionRepository
+	revocations cache.Store
+	accessTTL   time.Duration
+	refreshTTL  time.Duration
+}
+
+// NewManager creates a Manager backed by sessions and revocations.
+func NewManager(secret string, sessions *database.SessionRepository, revocations cache.Store, accessTTL, refreshTTL time.Duration) *Manager {
+	return &Manager{secret: secret, sessions: sessions, revocations: revocations, accessTTL: accessTTL, refreshTTL: refreshTTL}
+}
+
+// IssueTokens creates a new session for userID and returns its initial
+// access/refresh token pair.
+func (m *Manager) IssueTokens(ctx context.Context, tenantID, userID string, roles []string, userAgent, ip string) (*TokenPair, error) {
+	session := &models.Session{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		UserID:    userID,
+		JTI:       uuid.New().String(),
+		Roles:     roles,
+		UserAgent: userAgent,
+		IP:        ip,
+		ExpiresAt: time.Now().Add(m.refreshTTL),
+	}
+	if err := m.sessions.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	return m.signPair(session)
+}
+
+// Refresh exchanges a still-valid refresh token for a new access token,
+// rotating the session's jti and revoking the previous one so the old
+// access token stops authenticating immediately instead of lingering
+// until it naturally expires.
+func (m *Manager) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	claims, err := m.parse(refreshToken, "refresh")
+	if err != nil {
+		return nil, err
+	}
+
+	tenantID, _ := claims["tenant_id"].(string)
+	sid, _ := claims["sid"].(string)
+	sessionID, err := uuid.Parse(sid)
+	if err != nil {
+		return nil, fmt.Errorf("refresh token missing session id: %w", err)
+	}
+
+	session, err := m.sessions.Get(ctx, tenantID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil || session.RevokedAt != nil || time.Now().After(session.ExpiresAt) {
+		return nil, ErrSessionTerminated
+	}
+
+	oldJTI := session.JTI
+	newJTI := uuid.New().String()
+	if err := m.sessions.RotateJTI(ctx, tenantID, sessionID, newJTI); err != nil {
+		return nil, err
+	}
+	session.JTI = newJTI
+
+	if oldJTI != "" {
+		if err := m.revocations.Set(ctx, revokedJTIKey(tenantID, oldJTI), []byte("1"), m.accessTTL); err != nil {
+			return nil, fmt.Errorf("failed to revoke previous access token: %w", err)
+		}
+	}
+
+	return m.signPair(session)
+}
+
+// Logout terminates tenantID's session named by sid and revokes jti so
+// its still-unexpired access token stops working immediately.
+func (m *Manager) Logout(ctx context.Context, tenantID, sid, jti string) error {
+	sessionID, err := uuid.Parse(sid)
+	if err != nil {
+		return fmt.Errorf("invalid session id: %w", err)
+	}
+	if err := m.sessions.Revoke(ctx, tenantID, sessionID); err != nil {
+		return err
+	}
+	if jti == "" {
+		return nil
+	}
+	return m.revocations.Set(ctx, revokedJTIKey(tenantID, jti), []byte("1"), m.accessTTL)
+}
+
+// IsRevoked implements middleware.SessionChecker.
+func (m *Manager) IsRevoked(ctx context.Context, tenantID, jti string) (bool, error) {
+	if jti == "" {
+		return false, nil
+	}
+	_, ok, err := m.revocations.Get(ctx, revokedJTIKey(tenantID, jti))
+	return ok, err
+}
+
+// SessionTerminated implements middleware.SessionChecker.
+func (m *Manager) SessionTerminated(ctx context.Context, tenantID, sid string) (bool, error) {
+	if sid == "" {
+		return false, nil
+	}
+	sessionID, err := uuid.Parse(sid)
+	if err != nil {
+		return true, nil
+	}
+	session, err := m.sessions.Get(ctx, tenantID, sessionID)
+	if err != nil {
+		return false, err
+	}
+	if session == nil {
+		return true, nil
+	}
+	return session.RevokedAt != nil || time.Now().After(session.ExpiresAt), nil
+}
+
+func revokedJTIKey(tenantID, jti string) string {
+	return "revoked_jti:" + tenantID + ":" + jti
+}
+
+// signPair signs the access/refresh JWTs for session.
+func (m *Manager) signPair(session *models.Session) (*TokenPair, error) {
+	now := time.Now()
+	accessExpiry := now.Add(m.accessTTL)
+
+	roles := make([]interface{}, len(session.Roles))
+	for i, role := range session.Roles {
+		roles[i] = role
+	}
+
+	access := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":       session.UserID,
+		"tenant_id": session.TenantID,
+		"sid":       session.ID.String(),
+		"jti":       session.JTI,
+		"roles":     roles,
+		"typ":       "access",
+		"exp":       accessExpiry.Unix(),
+		"iat":       now.Unix(),
+	})
+	accessToken, err := access.SignedString([]byte(m.secret))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign access token: %w", err)
+	}
+
+	refresh := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":       session.UserID,
+		"tenant_id": session.TenantID,
+		"sid":       session.ID.String(),
+		"typ":       "refresh",
+		"exp":       session.ExpiresAt.Unix(),
+		"iat":       now.Unix(),
+	})
+	refreshToken, err := refresh.SignedString([]byte(m.secret))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign refresh token: %w", err)
+	}
+
+	return &TokenPair{AccessToken: accessToken, RefreshToken: refreshToken, ExpiresAt: accessExpiry}, nil
+}
+
+// parse validates token's signature and expiry and checks its "typ"
+// claim equals want ("access" or "refresh").
+func (m *Manager) parse(token, want string) (jwt.MapClaims, error) {
+	parsed, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(m.secret), nil
+	})
+	if err != nil || !parsed.Valid {
+		return nil, fmt.Errorf("invalid or expired token")
+	}
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, fmt.Errorf("invalid token claims")
+	}
+	if typ, _ := claims["typ"].(string); typ != want {
+		return nil, fmt.Errorf("expected a %s token", want)
+	}
+	return claims, nil
+}
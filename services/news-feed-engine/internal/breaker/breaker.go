@@ -0,0 +1,309 @@
+// Package breaker wraps a metrics.HealthChecker with a Closed/Open/
+// Half-Open circuit breaker over a rolling window of its own outcomes,
+// so a flaky dependency's health check stops being probed at full rate
+// the moment it's already known to be failing. It generalizes the
+// ad-hoc failure-count breaker videosource.FallbackSource keeps per
+// VideoSource into something any HealthChecker can be wrapped in,
+// exposing a Snapshot for admin APIs to show the state machine directly
+// instead of just the last Check result.
+package breaker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
+)
+
+// State is a CircuitBreaker's Closed/Open/Half-Open lifecycle position.
+type State int32
+
+const (
+	Closed State = iota
+	Open
+	HalfOpen
+)
+
+// String renders State the way it appears in Snapshot and log lines.
+func (s State) String() string {
+	switch s {
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// outcome is a ring buffer cell. Cells start empty so a freshly reset
+// breaker's window doesn't count nonexistent past failures toward
+// MinRequestVolume.
+type outcome int32
+
+const (
+	outcomeEmpty outcome = iota
+	outcomeSuccess
+	outcomeFailure
+)
+
+// Snapshot is a point-in-time view of a CircuitBreaker's state, for
+// admin APIs that want to show more than a single HealthCheckResult.
+type Snapshot struct {
+	Name         string    `json:"name"`
+	State        string    `json:"state"`
+	Requests     int       `json:"requests"`
+	Failures     int       `json:"failures"`
+	FailureRatio float64   `json:"failure_ratio"`
+	OpenedAt     time.Time `json:"opened_at,omitempty"`
+}
+
+// CircuitBreaker wraps inner, short-circuiting it while Open. State
+// transitions and outcome recording are lock-free (an atomic state word
+// plus a fixed-size ring buffer of outcomes written via atomic stores);
+// the only path that takes a lock is coordinating the single in-flight
+// probe a Half-Open breaker allows, which many concurrent CheckAll
+// callers must share rather than each re-probe inner.
+type CircuitBreaker struct {
+	name  string
+	inner metrics.HealthChecker
+
+	windowSize       int
+	failureRatio     float64
+	minRequestVolume int
+	cooldown         time.Duration
+
+	state    atomic.Int32
+	openedAt atomic.Int64 // UnixNano; zero means not open
+
+	cursor   atomic.Uint64
+	outcomes []int32 // outcome values, accessed via atomic load/store
+
+	probeMu       sync.Mutex
+	probeInFlight bool
+	probeDone     chan struct{}
+	probeResult   metrics.HealthCheckResult
+}
+
+// defaultWindowSize/defaultFailureRatio/defaultMinRequestVolume/
+// defaultCooldown are New's defaults, overridable via the WithXxx
+// methods below.
+const (
+	defaultWindowSize       = 50
+	defaultFailureRatio     = 0.5
+	defaultMinRequestVolume = 10
+	defaultCooldown         = time.Minute
+)
+
+// New wraps inner in a CircuitBreaker named name (used as the
+// HealthCheckResult.Name Check returns), using package defaults for the
+// window size, failure ratio, minimum request volume, and cooldown.
+func New(name string, inner metrics.HealthChecker) *CircuitBreaker {
+	return &CircuitBreaker{
+		name:             name,
+		inner:            inner,
+		windowSize:       defaultWindowSize,
+		failureRatio:     defaultFailureRatio,
+		minRequestVolume: defaultMinRequestVolume,
+		cooldown:         defaultCooldown,
+		outcomes:         make([]int32, defaultWindowSize),
+	}
+}
+
+// WithWindow overrides the rolling window's size (number of past
+// invocations it remembers).
+func (cb *CircuitBreaker) WithWindow(size int) *CircuitBreaker {
+	if size > 0 {
+		cb.windowSize = size
+		cb.outcomes = make([]int32, size)
+	}
+	return cb
+}
+
+// WithFailureRatio overrides the fraction of the window's requests that
+// must have failed before the breaker trips Open.
+func (cb *CircuitBreaker) WithFailureRatio(ratio float64) *CircuitBreaker {
+	cb.failureRatio = ratio
+	return cb
+}
+
+// WithMinRequestVolume overrides how many requests must have landed in
+// the window before FailureRatio is evaluated at all, so one failure out
+// of one request doesn't trip the breaker.
+func (cb *CircuitBreaker) WithMinRequestVolume(minVolume int) *CircuitBreaker {
+	cb.minRequestVolume = minVolume
+	return cb
+}
+
+// WithCooldown overrides how long the breaker stays Open before allowing
+// a single Half-Open probe.
+func (cb *CircuitBreaker) WithCooldown(cooldown time.Duration) *CircuitBreaker {
+	cb.cooldown = cooldown
+	return cb
+}
+
+// Check runs inner unless the breaker is Open and still cooling down, in
+// which case it short-circuits with a synthesized unhealthy result.
+// While Half-Open, only the first concurrent caller actually invokes
+// inner; every other caller waits for that probe and shares its result,
+// so a burst of concurrent CheckAll invocations never sends more than
+// one probe through a still-recovering dependency.
+func (cb *CircuitBreaker) Check(ctx context.Context) metrics.HealthCheckResult {
+	switch State(cb.state.Load()) {
+	case Open:
+		if time.Since(cb.openedSince()) < cb.cooldown {
+			return cb.shortCircuitResult()
+		}
+		cb.state.CompareAndSwap(int32(Open), int32(HalfOpen))
+		return cb.probe(ctx)
+	case HalfOpen:
+		return cb.probe(ctx)
+	default:
+		return cb.run(ctx)
+	}
+}
+
+// probe performs (or waits for) the single in-flight Half-Open probe.
+func (cb *CircuitBreaker) probe(ctx context.Context) metrics.HealthCheckResult {
+	cb.probeMu.Lock()
+	if cb.probeInFlight {
+		done := cb.probeDone
+		cb.probeMu.Unlock()
+		<-done
+		return cb.probeResult
+	}
+	cb.probeInFlight = true
+	done := make(chan struct{})
+	cb.probeDone = done
+	cb.probeMu.Unlock()
+
+	result := cb.run(ctx)
+
+	cb.probeMu.Lock()
+	cb.probeResult = result
+	cb.probeInFlight = false
+	close(done)
+	cb.probeMu.Unlock()
+
+	return result
+}
+
+// run actually invokes inner and folds its outcome into the rolling
+// window and state machine.
+func (cb *CircuitBreaker) run(ctx context.Context) metrics.HealthCheckResult {
+	result := cb.inner(ctx)
+	cb.record(result.Status == metrics.HealthStatusHealthy)
+	return result
+}
+
+// record stores one outcome in the ring buffer and re-evaluates whether
+// the window's failure ratio now warrants tripping Open, or whether a
+// Half-Open probe's outcome should close or re-open the breaker.
+func (cb *CircuitBreaker) record(success bool) {
+	idx := cb.cursor.Add(1) - 1
+	slot := int(idx % uint64(cb.windowSize))
+	if success {
+		atomic.StoreInt32(&cb.outcomes[slot], int32(outcomeSuccess))
+	} else {
+		atomic.StoreInt32(&cb.outcomes[slot], int32(outcomeFailure))
+	}
+
+	switch State(cb.state.Load()) {
+	case HalfOpen:
+		if success {
+			cb.close()
+		} else {
+			cb.open()
+		}
+	default:
+		requests, failures := cb.counts()
+		if requests >= cb.minRequestVolume && float64(failures)/float64(requests) >= cb.failureRatio {
+			cb.open()
+		}
+	}
+}
+
+func (cb *CircuitBreaker) open() {
+	cb.openedAt.Store(time.Now().UnixNano())
+	cb.state.Store(int32(Open))
+}
+
+func (cb *CircuitBreaker) close() {
+	cb.state.Store(int32(Closed))
+	cb.openedAt.Store(0)
+	for i := range cb.outcomes {
+		atomic.StoreInt32(&cb.outcomes[i], int32(outcomeEmpty))
+	}
+}
+
+func (cb *CircuitBreaker) openedSince() time.Time {
+	nanos := cb.openedAt.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// OpenDuration reports how long the breaker has been continuously Open,
+// or zero if it isn't. Callers (e.g. a per-ContentSource wiring that
+// auto-disables a source once its breaker has been Open "too long") poll
+// this rather than the breaker pushing a notification.
+func (cb *CircuitBreaker) OpenDuration() time.Duration {
+	if State(cb.state.Load()) != Open {
+		return 0
+	}
+	since := cb.openedSince()
+	if since.IsZero() {
+		return 0
+	}
+	return time.Since(since)
+}
+
+func (cb *CircuitBreaker) counts() (requests, failures int) {
+	for i := range cb.outcomes {
+		switch outcome(atomic.LoadInt32(&cb.outcomes[i])) {
+		case outcomeSuccess:
+			requests++
+		case outcomeFailure:
+			requests++
+			failures++
+		}
+	}
+	return requests, failures
+}
+
+func (cb *CircuitBreaker) shortCircuitResult() metrics.HealthCheckResult {
+	return metrics.HealthCheckResult{
+		Name:      cb.name,
+		Status:    metrics.HealthStatusUnhealthy,
+		Message:   fmt.Sprintf("circuit breaker open since %s", cb.openedSince().Format(time.RFC3339)),
+		Timestamp: time.Now(),
+	}
+}
+
+// Snapshot reports the breaker's current state and rolling-window
+// counts, for an admin API to surface directly.
+func (cb *CircuitBreaker) Snapshot() Snapshot {
+	requests, failures := cb.counts()
+	ratio := 0.0
+	if requests > 0 {
+		ratio = float64(failures) / float64(requests)
+	}
+	return Snapshot{
+		Name:         cb.name,
+		State:        State(cb.state.Load()).String(),
+		Requests:     requests,
+		Failures:     failures,
+		FailureRatio: ratio,
+		OpenedAt:     cb.openedSince(),
+	}
+}
+
+// AsHealthChecker adapts cb to a metrics.HealthChecker, ready to pass to
+// metrics.HealthCheckRegistry.Register.
+func (cb *CircuitBreaker) AsHealthChecker() metrics.HealthChecker {
+	return cb.Check
+}
@@ -0,0 +1,146 @@
+package breaker
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
+)
+
+func healthyChecker() metrics.HealthChecker {
+	return func(ctx context.Context) metrics.HealthCheckResult {
+		return metrics.HealthCheckResult{Name: "inner", Status: metrics.HealthStatusHealthy}
+	}
+}
+
+func unhealthyChecker() metrics.HealthChecker {
+	return func(ctx context.Context) metrics.HealthCheckResult {
+		return metrics.HealthCheckResult{Name: "inner", Status: metrics.HealthStatusUnhealthy}
+	}
+}
+
+func TestCircuitBreakerTripsOpenAfterFailureRatio(t *testing.T) {
+	cb := New("test", unhealthyChecker()).WithMinRequestVolume(2).WithFailureRatio(0.5)
+
+	cb.Check(context.Background())
+	if State(cb.state.Load()) != Closed {
+		t.Fatalf("expected breaker to stay closed below min request volume")
+	}
+
+	cb.Check(context.Background())
+	if State(cb.state.Load()) != Open {
+		t.Fatalf("expected breaker to trip open once failure ratio is reached")
+	}
+}
+
+func TestCircuitBreakerShortCircuitsWhileOpen(t *testing.T) {
+	var calls int32
+	inner := func(ctx context.Context) metrics.HealthCheckResult {
+		atomic.AddInt32(&calls, 1)
+		return metrics.HealthCheckResult{Status: metrics.HealthStatusUnhealthy}
+	}
+	cb := New("test", inner).WithMinRequestVolume(1).WithFailureRatio(0.5).WithCooldown(time.Hour)
+
+	cb.Check(context.Background())
+	if State(cb.state.Load()) != Open {
+		t.Fatalf("expected breaker to be open after first failure")
+	}
+
+	result := cb.Check(context.Background())
+	if result.Status != metrics.HealthStatusUnhealthy {
+		t.Fatalf("expected short-circuited result to report unhealthy")
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("expected inner checker not to be called while open, got %d calls", calls)
+	}
+}
+
+func TestCircuitBreakerHalfOpenClosesOnSuccessfulProbe(t *testing.T) {
+	cb := New("test", unhealthyChecker()).WithMinRequestVolume(1).WithFailureRatio(0.5).WithCooldown(time.Millisecond)
+
+	cb.Check(context.Background())
+	if State(cb.state.Load()) != Open {
+		t.Fatalf("expected breaker to be open")
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	cb.inner = healthyChecker()
+	cb.Check(context.Background())
+
+	if State(cb.state.Load()) != Closed {
+		t.Fatalf("expected a successful half-open probe to close the breaker")
+	}
+}
+
+func TestCircuitBreakerHalfOpenReopensOnFailedProbe(t *testing.T) {
+	cb := New("test", unhealthyChecker()).WithMinRequestVolume(1).WithFailureRatio(0.5).WithCooldown(time.Millisecond)
+
+	cb.Check(context.Background())
+	time.Sleep(5 * time.Millisecond)
+	cb.Check(context.Background())
+
+	if State(cb.state.Load()) != Open {
+		t.Fatalf("expected a failed half-open probe to re-open the breaker")
+	}
+}
+
+// TestCircuitBreakerSingleFlightsHalfOpenProbe asserts that many
+// concurrent Check calls landing while the breaker is Half-Open (the
+// shape metrics.HealthCheckRegistry.CheckAll produces when several
+// registered checkers share one breaker, or one checker is probed from
+// overlapping CheckAll invocations) only invoke inner once, with every
+// other caller sharing that single probe's result.
+func TestCircuitBreakerSingleFlightsHalfOpenProbe(t *testing.T) {
+	var inFlight int32
+	var maxInFlight int32
+	var calls int32
+
+	inner := func(ctx context.Context) metrics.HealthCheckResult {
+		atomic.AddInt32(&calls, 1)
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		return metrics.HealthCheckResult{Status: metrics.HealthStatusHealthy}
+	}
+
+	cb := New("test", inner).WithMinRequestVolume(1).WithFailureRatio(0.5).WithCooldown(time.Nanosecond)
+	cb.state.Store(int32(Open))
+	cb.openedAt.Store(time.Now().Add(-time.Hour).UnixNano())
+
+	const concurrency = 20
+	var wg sync.WaitGroup
+	results := make([]metrics.HealthCheckResult, concurrency)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = cb.Check(context.Background())
+		}(i)
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("expected exactly one probe invocation, got %d", calls)
+	}
+	if maxInFlight != 1 {
+		t.Fatalf("expected at most one concurrent probe in flight, saw %d", maxInFlight)
+	}
+	for i, result := range results {
+		if result.Status != metrics.HealthStatusHealthy {
+			t.Fatalf("result %d: expected every caller to share the probe's healthy result, got %s", i, result.Status)
+		}
+	}
+	if State(cb.state.Load()) != Closed {
+		t.Fatalf("expected the shared successful probe to close the breaker")
+	}
+}
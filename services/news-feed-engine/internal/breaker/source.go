@@ -0,0 +1,109 @@
+package breaker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
+)
+
+// sourceStaleAfter is how long since ContentSource.LastIngested counts as
+// unhealthy for RegisterContentSource's inner checker, independent of
+// ErrorCount - a source that has simply gone quiet (e.g. an empty
+// channel) looks the same as one silently failing otherwise.
+const sourceStaleAfter = 24 * time.Hour
+
+// defaultDisableAfter is how long a ContentSource's breaker must stay
+// Open before RegisterContentSource flips ContentSource.Active to false,
+// if the caller didn't override it.
+const defaultDisableAfter = 30 * time.Minute
+
+// RegisterContentSource registers a health check named "source:<id>"
+// into registry for the ContentSource identified by sourceID, wrapping
+// it in a CircuitBreaker so a source that's already known to be failing
+// stops being probed at full rate. Once the breaker has been
+// continuously Open for longer than disableAfter (defaultDisableAfter if
+// <= 0), the source is flipped inactive via sources.Update and onDisable
+// is invoked so the caller can emit a lifecycle event - this package has
+// no kafka.Producer or events.Hub of its own to do that directly.
+func RegisterContentSource(registry *metrics.HealthCheckRegistry, sources *database.SourceRepository, tenantID, sourceID string, disableAfter time.Duration, onDisable func(ctx context.Context, sourceID string)) error {
+	if disableAfter <= 0 {
+		disableAfter = defaultDisableAfter
+	}
+
+	name := fmt.Sprintf("source:%s", sourceID)
+	cb := New(name, func(ctx context.Context) metrics.HealthCheckResult {
+		return checkContentSource(ctx, sources, tenantID, sourceID, name)
+	})
+
+	return registry.Register(name, func(ctx context.Context) metrics.HealthCheckResult {
+		result := cb.Check(ctx)
+
+		if cb.OpenDuration() >= disableAfter {
+			if err := sources.Update(ctx, tenantID, sourceID, database.SourceUpdate{Active: boolPtr(false)}); err == nil && onDisable != nil {
+				onDisable(ctx, sourceID)
+			}
+		}
+
+		return result
+	})
+}
+
+// checkContentSource reports unhealthy once a source's ErrorCount
+// dominates its ItemCount, and degraded once it's gone stale, mirroring
+// platform.checkPlatform's error-ratio/staleness combination but scoped
+// to a single ContentSource rather than a whole platform.
+func checkContentSource(ctx context.Context, sources *database.SourceRepository, tenantID, sourceID, name string) metrics.HealthCheckResult {
+	list, err := sources.List(ctx, tenantID, database.SourceListOptions{})
+	if err != nil {
+		return metrics.HealthCheckResult{
+			Name:      name,
+			Status:    metrics.HealthStatusUnhealthy,
+			Message:   fmt.Sprintf("failed to load content source: %v", err),
+			Timestamp: time.Now(),
+		}
+	}
+
+	for _, source := range list {
+		if source.ID.String() != sourceID {
+			continue
+		}
+
+		if source.LastError != "" && source.ErrorCount > source.ItemCount {
+			return metrics.HealthCheckResult{
+				Name:      name,
+				Status:    metrics.HealthStatusUnhealthy,
+				Message:   fmt.Sprintf("%d errors vs %d items: %s", source.ErrorCount, source.ItemCount, source.LastError),
+				Timestamp: time.Now(),
+			}
+		}
+
+		if source.LastIngested == nil || time.Since(*source.LastIngested) > sourceStaleAfter {
+			return metrics.HealthCheckResult{
+				Name:      name,
+				Status:    metrics.HealthStatusDegraded,
+				Message:   "no ingestion within the staleness window",
+				Timestamp: time.Now(),
+			}
+		}
+
+		return metrics.HealthCheckResult{
+			Name:      name,
+			Status:    metrics.HealthStatusHealthy,
+			Message:   fmt.Sprintf("last ingested %s", source.LastIngested.Format(time.RFC3339)),
+			Timestamp: time.Now(),
+		}
+	}
+
+	return metrics.HealthCheckResult{
+		Name:      name,
+		Status:    metrics.HealthStatusUnhealthy,
+		Message:   "content source not found",
+		Timestamp: time.Now(),
+	}
+}
+
+func boolPtr(b bool) *bool { return &b }
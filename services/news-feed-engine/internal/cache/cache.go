@@ -0,0 +1,121 @@
+// Package cache provides response caching for the read-heavy content
+// endpoints (ListContent, GetContent, GetContentByCategory, GetContentByGeo,
+// GetTrendingContent, SearchContent). Content browsing is paginated and
+// overwhelmingly read-only, and without caching every page view re-runs the
+// same filtered/sorted query against Postgres.
+//
+// Store is implemented by an in-process byte-bounded LRU (lru.go) for
+// single-replica deployments and by Redis (redis.go) for multi-replica
+// deployments that need a shared cache. GetOrCompute additionally coalesces
+// concurrent callers requesting the same key (see WithSingleflight) so a
+// stampede of identical requests - e.g. a trending page going viral - only
+// executes the underlying repository call once.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// TTLs for the endpoints Store backs. List, category, geo and search pages
+// are cached for 30s and trending for 60s; a single item fetched by ID
+// changes least often (edits go through PatchContent/DeleteContent, both of
+// which invalidate explicitly) and is cached longest.
+const (
+	TTLGetByID    = 5 * time.Minute
+	TTLList       = 30 * time.Second
+	TTLTrending   = 60 * time.Second
+	TTLByCategory = 30 * time.Second
+	TTLByGeo      = 30 * time.Second
+	TTLSearch     = 30 * time.Second
+)
+
+// Store is a byte-oriented cache backend. Callers are responsible for
+// encoding/decoding their own values; GetOrCompute does that for them given
+// a compute function that returns the bytes to cache.
+type Store interface {
+	// Get returns the cached value for key, and false if it is absent or
+	// expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key with the given TTL.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// SetNX stores value under key with the given TTL only if key isn't
+	// already present (and not expired), atomically with respect to
+	// concurrent callers. ok is true if this call claimed the key.
+	SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (ok bool, err error)
+	// Delete removes key, if present. Deleting an absent key is not an
+	// error.
+	Delete(ctx context.Context, key string) error
+	// GetOrCompute returns the cached value for key if present, otherwise
+	// calls compute, stores its result under key with ttl, and returns it.
+	GetOrCompute(ctx context.Context, key string, ttl time.Duration, compute func(ctx context.Context) ([]byte, error)) ([]byte, error)
+}
+
+// Key builds a cache key scoped to a tenant and endpoint, so two tenants
+// (or two endpoints) never collide even if their query strings match
+// byte-for-byte. params is canonicalized (sorted by name) before hashing so
+// "?page=1&limit=20" and "?limit=20&page=1" share a cache entry.
+func Key(tenantID, endpoint string, params map[string]string) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s=%s&", name, params[name])
+	}
+
+	return fmt.Sprintf("content:%s:%s:%s", tenantID, endpoint, hex.EncodeToString(h.Sum(nil))[:16])
+}
+
+// singleflightStore wraps a Store so that concurrent GetOrCompute calls for
+// the same key - e.g. N requests hitting an expired trending-content entry
+// at once - execute compute exactly once and share its result, instead of
+// each falling through to the repository independently.
+type singleflightStore struct {
+	Store
+	group singleflight.Group
+}
+
+// WithSingleflight wraps store so its GetOrCompute coalesces concurrent
+// callers for the same key within this process. It does not coalesce
+// across replicas; Redis-backed stores still benefit within each replica.
+func WithSingleflight(store Store) Store {
+	return &singleflightStore{Store: store}
+}
+
+func (s *singleflightStore) GetOrCompute(ctx context.Context, key string, ttl time.Duration, compute func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	if value, ok, err := s.Store.Get(ctx, key); err != nil {
+		return nil, err
+	} else if ok {
+		return value, nil
+	}
+
+	value, err, _ := s.group.Do(key, func() (interface{}, error) {
+		if value, ok, err := s.Store.Get(ctx, key); err != nil {
+			return nil, err
+		} else if ok {
+			return value, nil
+		}
+		computed, err := compute(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.Store.Set(ctx, key, computed, ttl); err != nil {
+			return nil, err
+		}
+		return computed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]byte), nil
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/kafka"
+	"go.uber.org/zap"
+)
+
+// PublishInvalidation publishes a CacheInvalidationMessage for keys so
+// every replica consuming topic drops them from its own Store, not just
+// the replica that served the mutation. Used by handlers.ContentHandler
+// after DeleteContent/ProcessContent.
+func PublishInvalidation(ctx context.Context, producer *kafka.Producer, topic, tenantID string, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return producer.Publish(ctx, kafka.Message{
+		Topic: topic,
+		Key:   tenantID,
+		Value: kafka.CacheInvalidationMessage{
+			TenantID:      tenantID,
+			Keys:          keys,
+			InvalidatedAt: time.Now(),
+		},
+	})
+}
+
+// RunInvalidationConsumer consumes topic and deletes every key named in
+// each CacheInvalidationMessage from store. It blocks until ctx is
+// cancelled, so callers typically run it in its own goroutine or register
+// it with internal/process as a Process whose Run calls this directly.
+func RunInvalidationConsumer(ctx context.Context, consumer *kafka.Consumer, store Store, logger *zap.Logger) error {
+	if logger == nil {
+		logger, _ = zap.NewDevelopment()
+	}
+	return consumer.Run(ctx, func(ctx context.Context, _ string, value json.RawMessage) error {
+		var msg kafka.CacheInvalidationMessage
+		if err := json.Unmarshal(value, &msg); err != nil {
+			return fmt.Errorf("failed to decode cache invalidation message: %w", err)
+		}
+		for _, key := range msg.Keys {
+			if err := store.Delete(ctx, key); err != nil {
+				logger.Warn("failed to invalidate cache key",
+					zap.String("tenant_id", msg.TenantID), zap.String("key", key), zap.Error(err))
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,141 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// LRUStore is an in-process cache bounded by total value size rather than
+// entry count, since cached response bodies vary widely in size (a single
+// content item vs. a page of 100). Eviction is least-recently-used.
+type LRUStore struct {
+	mu       sync.Mutex
+	maxBytes int64
+	curBytes int64
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewLRUStore creates an LRUStore that evicts entries once the combined
+// size of its values exceeds maxBytes.
+func NewLRUStore(maxBytes int64) *LRUStore {
+	return &LRUStore{
+		maxBytes: maxBytes,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get implements Store.
+func (s *LRUStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := elem.Value.(*lruEntry)
+	if time.Now().After(entry.expiresAt) {
+		s.removeElement(elem)
+		return nil, false, nil
+	}
+	s.ll.MoveToFront(elem)
+	return entry.value, true, nil
+}
+
+// Set implements Store.
+func (s *LRUStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.items[key]; ok {
+		s.removeElement(elem)
+	}
+
+	entry := &lruEntry{key: key, value: value, expiresAt: time.Now().Add(ttl)}
+	elem := s.ll.PushFront(entry)
+	s.items[key] = elem
+	s.curBytes += int64(len(value))
+
+	for s.curBytes > s.maxBytes {
+		oldest := s.ll.Back()
+		if oldest == nil {
+			break
+		}
+		s.removeElement(oldest)
+	}
+	return nil
+}
+
+// SetNX implements Store.
+func (s *LRUStore) SetNX(_ context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.items[key]; ok {
+		entry := elem.Value.(*lruEntry)
+		if !time.Now().After(entry.expiresAt) {
+			return false, nil
+		}
+		s.removeElement(elem)
+	}
+
+	entry := &lruEntry{key: key, value: value, expiresAt: time.Now().Add(ttl)}
+	elem := s.ll.PushFront(entry)
+	s.items[key] = elem
+	s.curBytes += int64(len(value))
+
+	for s.curBytes > s.maxBytes {
+		oldest := s.ll.Back()
+		if oldest == nil {
+			break
+		}
+		s.removeElement(oldest)
+	}
+	return true, nil
+}
+
+// Delete implements Store.
+func (s *LRUStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.items[key]; ok {
+		s.removeElement(elem)
+	}
+	return nil
+}
+
+// GetOrCompute implements Store without coalescing; wrap with
+// WithSingleflight for stampede protection.
+func (s *LRUStore) GetOrCompute(ctx context.Context, key string, ttl time.Duration, compute func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	if value, ok, _ := s.Get(ctx, key); ok {
+		return value, nil
+	}
+	value, err := compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Set(ctx, key, value, ttl); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// removeElement evicts elem from both the list and the index. Callers must
+// hold s.mu.
+func (s *LRUStore) removeElement(elem *list.Element) {
+	entry := elem.Value.(*lruEntry)
+	s.ll.Remove(elem)
+	delete(s.items, entry.key)
+	s.curBytes -= int64(len(entry.value))
+}
@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore is a Store backed by a shared Redis instance, so every
+// replica sees the same cache instead of warming its own in-process copy.
+// Use this (rather than LRUStore) once the service runs more than one
+// replica.
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore creates a RedisStore from a redis:// connection string
+// (see config.RedisURL).
+func NewRedisStore(redisURL string) (*RedisStore, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redis URL: %w", err)
+	}
+	return &RedisStore{client: redis.NewClient(opts)}, nil
+}
+
+// Client returns the underlying Redis client, e.g. for a
+// metrics.RedisChecker to ping directly rather than going through Store.
+func (s *RedisStore) Client() *redis.Client {
+	return s.client
+}
+
+// Get implements Store.
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := s.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("redis get failed: %w", err)
+	}
+	return value, true, nil
+}
+
+// Set implements Store.
+func (s *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := s.client.Set(ctx, key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("redis set failed: %w", err)
+	}
+	return nil
+}
+
+// SetNX implements Store.
+func (s *RedisStore) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	ok, err := s.client.SetNX(ctx, key, value, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("redis setnx failed: %w", err)
+	}
+	return ok, nil
+}
+
+// Delete implements Store.
+func (s *RedisStore) Delete(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, key).Err(); err != nil {
+		return fmt.Errorf("redis delete failed: %w", err)
+	}
+	return nil
+}
+
+// GetOrCompute implements Store without coalescing; wrap with
+// WithSingleflight for stampede protection within this replica.
+func (s *RedisStore) GetOrCompute(ctx context.Context, key string, ttl time.Duration, compute func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	if value, ok, err := s.Get(ctx, key); err != nil {
+		return nil, err
+	} else if ok {
+		return value, nil
+	}
+	value, err := compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Set(ctx, key, value, ttl); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
@@ -0,0 +1,162 @@
+package cdn
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec // required by BunnyCDN's token-authentication scheme, not used for security
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bunnyAPIBase is Bunny.net's control-plane API root; kept as a var
+// rather than inlined so tests can point it at a fake server.
+var bunnyAPIBase = "https://api.bunny.net"
+
+// BunnyProvider purges and mirrors through Bunny.net's pull zone and
+// storage zone APIs, and signs URLs with Bunny's token-authentication
+// scheme.
+type BunnyProvider struct {
+	pullZoneHost string
+	pullZoneID   string
+	storageZone  string
+	accessKey    string
+	securityKey  string
+	httpClient   *http.Client
+}
+
+// NewBunnyProvider creates a BunnyProvider for a pull zone served at
+// pullZoneHost (e.g. "mysite.b-cdn.net") with id pullZoneID, signing
+// URLs with securityKey, and mirroring uploads into storageZone using
+// accessKey.
+func NewBunnyProvider(pullZoneHost, pullZoneID, storageZone, accessKey, securityKey string) *BunnyProvider {
+	return &BunnyProvider{
+		pullZoneHost: pullZoneHost,
+		pullZoneID:   pullZoneID,
+		storageZone:  storageZone,
+		accessKey:    accessKey,
+		securityKey:  securityKey,
+		httpClient:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func init() {
+	Register("bunny", func(u *url.URL) (Provider, error) {
+		q := u.Query()
+		pullZoneID := q.Get("pull_zone_id")
+		if pullZoneID == "" {
+			return nil, fmt.Errorf("cdn: bunny:// dsn requires pull_zone_id")
+		}
+		accessKey := q.Get("access_key")
+		if accessKey == "" {
+			return nil, fmt.Errorf("cdn: bunny:// dsn requires access_key")
+		}
+		return NewBunnyProvider(u.Host, pullZoneID, q.Get("storage_zone"), accessKey, q.Get("security_key")), nil
+	})
+}
+
+// SignedURL builds a Bunny.net token-authenticated URL for path,
+// expiring at expiry. clientIP, if set, is folded into the token so the
+// URL is only valid from that address, matching Bunny's optional
+// "include client IP" token-auth setting.
+func (p *BunnyProvider) SignedURL(path string, expiry time.Time, clientIP string) (string, error) {
+	if p.securityKey == "" {
+		return "", fmt.Errorf("cdn: bunny provider has no security_key configured")
+	}
+
+	cleanPath := "/" + strings.TrimPrefix(path, "/")
+	expiryStr := strconv.FormatInt(expiry.Unix(), 10)
+
+	raw := p.securityKey + cleanPath + expiryStr + clientIP
+	sum := md5.Sum([]byte(raw)) //nolint:gosec // Bunny's token scheme mandates MD5
+	token := base64.StdEncoding.EncodeToString(sum[:])
+	token = strings.NewReplacer("\n", "", "+", "-", "/", "_", "=", "").Replace(token)
+
+	q := url.Values{}
+	q.Set("token", token)
+	q.Set("expires", expiryStr)
+	return fmt.Sprintf("https://%s%s?%s", p.pullZoneHost, cleanPath, q.Encode()), nil
+}
+
+// PurgeURL purges a single cached URL from the pull zone.
+func (p *BunnyProvider) PurgeURL(ctx context.Context, assetURL string) error {
+	endpoint := fmt.Sprintf("%s/purge?url=%s", bunnyAPIBase, url.QueryEscape(assetURL))
+	return p.call(ctx, http.MethodPost, endpoint, nil)
+}
+
+// PurgeByTag purges every cached variant of tag by purging the pull
+// zone's whole tag-prefixed path, since Bunny's purge API is URL/path
+// based rather than tag based; assets are mirrored under /<tag>/...
+// (see Mirror) specifically so this prefix purge covers them all.
+func (p *BunnyProvider) PurgeByTag(ctx context.Context, tag string) error {
+	prefixURL := fmt.Sprintf("https://%s/%s/*", p.pullZoneHost, strings.Trim(tag, "/"))
+	endpoint := fmt.Sprintf("%s/pullzone/%s/purgeCache?url=%s", bunnyAPIBase, p.pullZoneID, url.QueryEscape(prefixURL))
+	return p.call(ctx, http.MethodPost, endpoint, nil)
+}
+
+func (p *BunnyProvider) call(ctx context.Context, method, endpoint string, body io.Reader) error {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("cdn: failed to build bunny request: %w", err)
+	}
+	req.Header.Set("AccessKey", p.accessKey)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("cdn: bunny request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("cdn: bunny request to %s failed with status %d", endpoint, resp.StatusCode)
+	}
+	return nil
+}
+
+// TransformURL maps preset to Bunny Optimizer's query-string convention
+// (?width=&format=).
+func (p *BunnyProvider) TransformURL(originURL string, presetName string) (string, error) {
+	preset, err := lookupPreset(presetName)
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{}
+	if preset.maxDimension > 0 {
+		q.Set("width", strconv.Itoa(preset.maxDimension))
+	}
+	if preset.format != "" {
+		q.Set("format", preset.format)
+	}
+
+	sep := "?"
+	if strings.Contains(originURL, "?") {
+		sep = "&"
+	}
+	return originURL + sep + q.Encode(), nil
+}
+
+// Mirror uploads src to the Bunny storage zone under /<tag>/<path> (so
+// PurgeByTag's prefix purge covers it), and returns the pull zone URL
+// that now serves it.
+func (p *BunnyProvider) Mirror(ctx context.Context, path string, tag string, src io.Reader) (string, error) {
+	if p.storageZone == "" {
+		return "", fmt.Errorf("cdn: bunny provider has no storage_zone configured")
+	}
+
+	storagePath := strings.TrimPrefix(path, "/")
+	if tag != "" {
+		storagePath = strings.Trim(tag, "/") + "/" + storagePath
+	}
+
+	endpoint := fmt.Sprintf("https://storage.bunnycdn.com/%s/%s", p.storageZone, storagePath)
+	if err := p.call(ctx, http.MethodPut, endpoint, src); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("https://%s/%s", p.pullZoneHost, storagePath), nil
+}
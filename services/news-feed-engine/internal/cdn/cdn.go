@@ -0,0 +1,39 @@
+// Package cdn fronts origin media with a pluggable CDN backend.
+// Providers self-register under a DSN scheme via Register, mirroring the
+// embeddings.Register/tts.Register driver idiom, so swapping
+// CloudFront/Cloudflare/Bunny is a config change rather than a code
+// change.
+package cdn
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Provider is a pluggable CDN backend. Paths passed to its methods are
+// origin-relative (e.g. "assets/abc123/original.jpg"); implementations
+// are responsible for resolving them against their own base URL.
+type Provider interface {
+	// SignedURL returns a URL for path that expires at expiry. If
+	// clientIP is non-empty, the URL is additionally restricted to
+	// requests from that address where the provider supports it.
+	SignedURL(path string, expiry time.Time, clientIP string) (string, error)
+
+	// PurgeURL evicts a single cached URL from the CDN's edge caches.
+	PurgeURL(ctx context.Context, url string) error
+
+	// PurgeByTag evicts every object cached under tag (a cache
+	// tag/surrogate key attached when the object was mirrored).
+	PurgeByTag(ctx context.Context, tag string) error
+
+	// TransformURL returns a URL serving originURL through preset (e.g.
+	// "thumb_sm", "webp_1080", "avif_square"). An unknown preset is an
+	// error rather than a silent pass-through, since a typo should fail
+	// loudly rather than serve the untransformed original.
+	TransformURL(originURL string, preset string) (string, error)
+
+	// Mirror uploads src to the CDN at path, tagged with tag for later
+	// PurgeByTag, and returns the resulting CDN URL.
+	Mirror(ctx context.Context, path string, tag string, src io.Reader) (string, error)
+}
@@ -0,0 +1,180 @@
+package cdn
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cloudflareAPIBase is Cloudflare's REST API root; kept as a var rather
+// than inlined so tests can point it at a fake server.
+var cloudflareAPIBase = "https://api.cloudflare.com/client/v4"
+
+// CloudflareProvider purges and mirrors through Cloudflare's zone API,
+// and signs URLs with a shared-secret token query param (Cloudflare's
+// "Token Authentication" feature) rather than a signing key pair.
+type CloudflareProvider struct {
+	zoneID        string
+	apiToken      string
+	signingSecret string
+	originBaseURL string
+	httpClient    *http.Client
+}
+
+// NewCloudflareProvider creates a CloudflareProvider for zoneID,
+// authenticating cache management calls with apiToken, signing
+// distributed URLs with signingSecret, and mirroring uploads to
+// originBaseURL (an origin HTTP endpoint Cloudflare fronts).
+func NewCloudflareProvider(zoneID, apiToken, signingSecret, originBaseURL string) *CloudflareProvider {
+	return &CloudflareProvider{
+		zoneID:        zoneID,
+		apiToken:      apiToken,
+		signingSecret: signingSecret,
+		originBaseURL: strings.TrimSuffix(originBaseURL, "/"),
+		httpClient:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func init() {
+	Register("cloudflare", func(u *url.URL) (Provider, error) {
+		q := u.Query()
+		zoneID := q.Get("zone_id")
+		if zoneID == "" {
+			return nil, fmt.Errorf("cdn: cloudflare:// dsn requires zone_id")
+		}
+		apiToken := q.Get("api_token")
+		if apiToken == "" {
+			return nil, fmt.Errorf("cdn: cloudflare:// dsn requires api_token")
+		}
+		origin := q.Get("origin_base_url")
+		if origin == "" {
+			return nil, fmt.Errorf("cdn: cloudflare:// dsn requires origin_base_url")
+		}
+		return NewCloudflareProvider(zoneID, apiToken, q.Get("signing_secret"), origin), nil
+	})
+}
+
+// SignedURL appends Cloudflare Token Authentication query params
+// (expiry timestamp plus an HMAC-derived token) to path. Cloudflare's
+// token auth verifies the token server-side at the edge, so clientIP is
+// folded into the signed payload when set, matching the "IP-restricted
+// token" variant of the feature.
+func (p *CloudflareProvider) SignedURL(path string, expiry time.Time, clientIP string) (string, error) {
+	if p.signingSecret == "" {
+		return "", fmt.Errorf("cdn: cloudflare provider has no signing_secret configured")
+	}
+
+	expiryStr := strconv.FormatInt(expiry.Unix(), 10)
+	payload := path + expiryStr
+	if clientIP != "" {
+		payload += clientIP
+	}
+	token := hmacHex(p.signingSecret, payload)
+
+	q := url.Values{}
+	q.Set("verify", token)
+	q.Set("expiry", expiryStr)
+	if clientIP != "" {
+		q.Set("ip", clientIP)
+	}
+	return p.originBaseURL + "/" + strings.TrimPrefix(path, "/") + "?" + q.Encode(), nil
+}
+
+func hmacHex(secret, payload string) string {
+	return hex.EncodeToString(hmacSHA256([]byte(secret), payload))
+}
+
+// PurgeURL purges a single URL from Cloudflare's cache.
+func (p *CloudflareProvider) PurgeURL(ctx context.Context, assetURL string) error {
+	return p.purge(ctx, map[string]any{"files": []string{assetURL}})
+}
+
+// PurgeByTag purges every cached object carrying the cache-tag header
+// tag, using Cloudflare's tag-based purge (Enterprise cache-tag
+// feature) rather than the prefix-style purge CloudFront falls back to.
+func (p *CloudflareProvider) PurgeByTag(ctx context.Context, tag string) error {
+	return p.purge(ctx, map[string]any{"tags": []string{tag}})
+}
+
+func (p *CloudflareProvider) purge(ctx context.Context, body map[string]any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("cdn: failed to marshal cloudflare purge request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/zones/%s/purge_cache", cloudflareAPIBase, p.zoneID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("cdn: failed to build cloudflare purge request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.apiToken)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("cdn: cloudflare purge request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("cdn: cloudflare purge failed with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// TransformURL maps preset to Cloudflare Image Resizing's path-based
+// convention (/cdn-cgi/image/<options>/<origin-path>).
+func (p *CloudflareProvider) TransformURL(originURL string, presetName string) (string, error) {
+	preset, err := lookupPreset(presetName)
+	if err != nil {
+		return "", err
+	}
+
+	var opts []string
+	if preset.maxDimension > 0 {
+		opts = append(opts, fmt.Sprintf("width=%d", preset.maxDimension))
+	}
+	if preset.format != "" {
+		opts = append(opts, "format="+preset.format)
+	}
+
+	u, err := url.Parse(originURL)
+	if err != nil {
+		return "", fmt.Errorf("cdn: invalid origin url %q: %w", originURL, err)
+	}
+	return fmt.Sprintf("%s://%s/cdn-cgi/image/%s%s", u.Scheme, u.Host, strings.Join(opts, ","), u.Path), nil
+}
+
+// Mirror uploads src to the Cloudflare-fronted origin at path over
+// HTTP PUT, tagging it with the Cache-Tag header Cloudflare's edge
+// reads for PurgeByTag.
+func (p *CloudflareProvider) Mirror(ctx context.Context, path string, tag string, src io.Reader) (string, error) {
+	endpoint := p.originBaseURL + "/" + strings.TrimPrefix(path, "/")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, src)
+	if err != nil {
+		return "", fmt.Errorf("cdn: failed to build origin upload request: %w", err)
+	}
+	if tag != "" {
+		req.Header.Set("Cache-Tag", tag)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("cdn: origin upload request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("cdn: origin upload failed with status %d", resp.StatusCode)
+	}
+	return endpoint, nil
+}
@@ -0,0 +1,31 @@
+package cdn
+
+import "fmt"
+
+// preset describes an on-the-fly transform by its output dimension (0
+// means unconstrained) and format (empty means keep the source format).
+type preset struct {
+	maxDimension int
+	format       string
+}
+
+// presets is the shared table every provider maps through TransformURL,
+// so "thumb_sm" etc. mean the same thing regardless of which CDN is
+// configured.
+var presets = map[string]preset{
+	"thumb_sm":    {maxDimension: 150},
+	"thumb_md":    {maxDimension: 400},
+	"webp_1080":   {maxDimension: 1080, format: "webp"},
+	"avif_square": {maxDimension: 1080, format: "avif"},
+}
+
+// lookupPreset returns the named preset, or an error naming it if it
+// isn't in the shared table - callers should fail loudly on an unknown
+// preset rather than silently serving the original asset.
+func lookupPreset(name string) (preset, error) {
+	p, ok := presets[name]
+	if !ok {
+		return preset{}, fmt.Errorf("cdn: unknown transform preset %q", name)
+	}
+	return p, nil
+}
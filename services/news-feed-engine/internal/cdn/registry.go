@@ -0,0 +1,51 @@
+package cdn
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+)
+
+// Factory constructs a Provider from a DSN's parsed form. The scheme
+// ("cloudfront", "cloudflare", "bunny") selects the factory; Open passes
+// the rest of the DSN through unparsed so each provider can pull
+// whatever query params or host it needs, the same split tts.Factory
+// makes.
+type Factory func(dsn *url.URL) (Provider, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+// Register adds factory under scheme for Open to dispatch to. Intended
+// to be called from each provider's init(); a duplicate registration is
+// a programming error worth panicking on rather than silently shadowing
+// one provider with another.
+func Register(scheme string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := registry[scheme]; exists {
+		panic(fmt.Sprintf("cdn: Register called twice for scheme %q", scheme))
+	}
+	registry[scheme] = factory
+}
+
+// Open constructs a Provider from a URL-like DSN, e.g.
+// "cloudfront://d123.cloudfront.net?key_pair_id=...&private_key_path=..."
+// or "cloudflare://?zone_id=...&api_token=...".
+func Open(dsn string) (Provider, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("cdn: invalid dsn %q: %w", dsn, err)
+	}
+
+	registryMu.RLock()
+	factory, ok := registry[u.Scheme]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("cdn: no provider registered for scheme %q", u.Scheme)
+	}
+
+	return factory(u)
+}
@@ -0,0 +1,43 @@
+package cdn
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestOpenDispatchesByScheme(t *testing.T) {
+	calls := 0
+	Register("cdn-test-scheme", func(u *url.URL) (Provider, error) {
+		calls++
+		return nil, nil
+	})
+
+	if _, err := Open("cdn-test-scheme://host?foo=bar"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected factory to be called once, got %d", calls)
+	}
+}
+
+func TestOpenUnknownSchemeFails(t *testing.T) {
+	if _, err := Open("cdn-test-unregistered://host"); err == nil {
+		t.Fatal("expected an error for an unregistered scheme")
+	}
+}
+
+func TestLookupPresetUnknownFails(t *testing.T) {
+	if _, err := lookupPreset("not-a-real-preset"); err == nil {
+		t.Fatal("expected an error for an unknown preset")
+	}
+}
+
+func TestLookupPresetKnownSucceeds(t *testing.T) {
+	p, err := lookupPreset("thumb_sm")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.maxDimension != 150 {
+		t.Fatalf("expected thumb_sm maxDimension 150, got %d", p.maxDimension)
+	}
+}
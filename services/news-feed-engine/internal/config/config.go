@@ -9,8 +9,7 @@ import (
 	"strings"
 	"time"
 
-	secretmanager "cloud.google.com/go/secretmanager/apiv1"
-	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/secrets"
 )
 
 // Config holds all service configuration
@@ -45,9 +44,16 @@ type Config struct {
 	// Webhook secrets
 	YouTubeWebhookSecret  string
 	TwitterConsumerSecret string
+	MastodonWebhookSecret string
 
 	// Auth settings
 	JWTSecret string
+	// SessionAccessTTL/SessionRefreshTTL bound the session subsystem's
+	// (internal/auth) issued token lifetimes: how long an access token
+	// is valid before it must be exchanged via /auth/refresh, and how
+	// long a session may be refreshed before the user must log in again.
+	SessionAccessTTL  time.Duration
+	SessionRefreshTTL time.Duration
 
 	// CORS settings
 	CORSAllowedOrigins []string
@@ -61,9 +67,30 @@ type Config struct {
 	MaxContentLimit     int
 	IngestionInterval   time.Duration
 
+	// AnalyticsRollupPeriod is how often the background worker refreshes
+	// analytics_daily/creator_tier_daily for "today", so the admin
+	// analytics endpoints stay within a refresh period of live content
+	// without every request re-scanning it.
+	AnalyticsRollupPeriod time.Duration
+
+	// Content cache settings
+	CacheBackend           string // "memory" (default) or "redis"
+	CacheMaxBytes          int64  // bound for the "memory" backend
+	CacheInvalidationTopic string
+
+	// Job event streaming settings (see internal/events)
+	KafkaEventsTopic string
+
 	// Video generation settings
-	VideoOutputDir      string
-	MaxConcurrentVideos int
+	VideoOutputDir       string
+	MaxConcurrentVideos  int
+	VideoLeaseDuration   time.Duration // how long a worker holds a claimed job before the reaper reclaims it
+	VideoLeaseReapPeriod time.Duration // how often the reaper looks for expired leases
+
+	// Per-tenant video queue admission control (internal/ratelimit),
+	// overridable at runtime via PATCH /admin/tenants/:id/quota
+	VideoDefaultMaxInFlight int
+	VideoDefaultMaxPerHour  int
 
 	// White-label settings
 	EnableWhiteLabel    bool
@@ -71,8 +98,129 @@ type Config struct {
 	DefaultBrandingLogo string
 	DefaultBrandingName string
 
+	// KafkaWhitelabelTopic is where WhitelabelConfigChangedMessage is
+	// published after every white-label config update or rollback (see
+	// internal/kafka.WhitelabelConfigChangedMessage).
+	KafkaWhitelabelTopic string
+
+	// KafkaCreatorImportTopic is where CreatorImportedMessage is published
+	// for every row POST /admin/creators/bulk accepts, so content
+	// ingestion/enrichment can start crawling a freshly onboarded creator
+	// immediately (see internal/kafka.CreatorImportedMessage).
+	KafkaCreatorImportTopic string
+
+	// PublicBaseURL is this service's externally-reachable base URL, used
+	// to build the hub.callback PubSubHubbub push notifications are sent
+	// to (see integrations.YouTubeIntegration.SubscribeChannel).
+	PublicBaseURL string
+	// YouTubePubSubRenewPeriod is how often the renewer checks for
+	// subscriptions nearing lease expiry.
+	YouTubePubSubRenewPeriod time.Duration
+	// YouTubePubSubRenewBefore re-subscribes a channel once its lease has
+	// less than this long remaining.
+	YouTubePubSubRenewBefore time.Duration
+	// YouTubeAPIKeyPool lists additional YouTube Data API keys, beyond
+	// YouTubeAPIKey, that ytapi.Client pools with round-robin failover.
+	// Unlike YouTubeAPIKey these aren't expected in Secret Manager, since
+	// they're an optional throughput overlay rather than the primary
+	// credential.
+	YouTubeAPIKeyPool []string
+	// YouTubeDailyQuotaBudget caps each pooled key's YouTube Data API
+	// unit consumption per UTC day (see ytapi.QuotaTracker). 0 means
+	// unlimited.
+	YouTubeDailyQuotaBudget int
+	// EnableYTDLPEnrichment turns on integrations.YTDLPFetcher, which
+	// shells out to yt-dlp to enrich GetVideoDetails and source
+	// GetCaptions' transcript. Off by default since it requires yt-dlp
+	// to be installed on the host and is far slower than a Data API call.
+	EnableYTDLPEnrichment bool
+	// YTDLPBinaryPath is the yt-dlp executable to run, resolved via PATH
+	// if not absolute.
+	YTDLPBinaryPath string
+	// YTDLPMaxWorkers bounds how many yt-dlp processes may run at once.
+	YTDLPMaxWorkers int
+
+	// InvidiousInstances and PipedInstances list self-hosted/public
+	// mirror base URLs (e.g. "https://yewtu.be") that back
+	// videosource.InvidiousSource/PipedSource. Empty means that source is
+	// left out of the fallback chain entirely.
+	InvidiousInstances []string
+	PipedInstances     []string
+	// VideoSourceFailureThreshold and VideoSourceCooldown configure the
+	// circuit breaker videosource.FallbackSource uses to skip a source
+	// after repeated failures: a source is skipped once it has failed
+	// this many times in a row, and retried again after cooldown elapses.
+	VideoSourceFailureThreshold int
+	VideoSourceCooldown         time.Duration
+
+	// Tracing settings (internal/middleware.TracingMiddleware, backed by
+	// pkg/metrics.TracingProvider)
+	TracingEnabled bool
+	// TracingExporter selects the span exporter: "otlp-grpc", "otlp-http",
+	// "jaeger", "stdout", or "none" (same as TracingEnabled=false).
+	TracingExporter string
+	// OTLPTraceEndpoint is the collector address used when TracingExporter
+	// is "otlp-grpc" or "otlp-http".
+	OTLPTraceEndpoint string
+	// JaegerEndpoint is the collector address used when TracingExporter
+	// is "jaeger".
+	JaegerEndpoint string
+
 	// GCP settings
 	GCPProjectID string
+
+	// AdminAuthMode selects middleware.MTLSMiddleware's enforcement for
+	// the /admin route group: "none", "password" (the pre-mTLS default,
+	// bearer JWT only), "cert", or "cert_or_password". See
+	// middleware.AuthType.
+	AdminAuthMode string
+	// AdminClientCAFile is a PEM bundle of CAs trusted to sign admin
+	// client certificates, required when AdminAuthMode is "cert" or
+	// "cert_or_password".
+	AdminClientCAFile string
+
+	// TwitterBackend selects how integrations.SocialMediaHub reads
+	// tweets: "api" (TwitterAPIKey only), "nitter" (NitterInstances
+	// only), or "auto" (try the API, fall back to Nitter scraping on a
+	// 429/401). Defaults to "auto" so a hub configured with both still
+	// degrades gracefully.
+	TwitterBackend string
+	// NitterInstances are the Nitter mirror hosts (e.g. "nitter.net")
+	// integrations.TwitterScraperBackend round-robins across.
+	NitterInstances []string
+
+	// SecretBackend selects the secrets.Provider loadSecrets builds:
+	// "gcp" (secrets.CachedSecretManager), "file" (secrets.FileProvider,
+	// reading SecretsDir), or "env" (secrets.EnvProvider). Empty resolves
+	// to "env" in development and "gcp" otherwise, same as the old
+	// USE_SECRET_MANAGER toggle.
+	SecretBackend string
+	// SecretsDir is where the "file" SecretBackend reads individual
+	// secret files from, one file per secret name.
+	SecretsDir string
+	// SecretCacheTTL bounds how long the "gcp" SecretBackend trusts a
+	// cached secret value before re-fetching it.
+	SecretCacheTTL time.Duration
+	// SecretRotationPollInterval is how often the "gcp" SecretBackend
+	// re-reads versions/latest for every unpinned secret it has loaded,
+	// publishing a secrets.Change on SecretChanges() when one rotates.
+	SecretRotationPollInterval time.Duration
+
+	// secretProvider is whatever loadSecrets built, kept around so
+	// SecretChanges can expose its rotation channel if it has one.
+	secretProvider secrets.Provider
+}
+
+// SecretChanges returns the channel the active secrets.Provider
+// publishes rotations on, or nil if it isn't a secrets.Watchable (the
+// "env" and "file" backends aren't; only "gcp" is). Callers - e.g.
+// integrations.TwitterIntegration - can select on it to swap credentials
+// without a restart.
+func (c *Config) SecretChanges() <-chan secrets.Change {
+	if watchable, ok := c.secretProvider.(secrets.Watchable); ok {
+		return watchable.Watch()
+	}
+	return nil
 }
 
 // Load loads configuration from environment variables and GCP Secret Manager
@@ -101,14 +249,33 @@ func Load() (*Config, error) {
 		RateLimitRequests: getEnvInt("RATE_LIMIT_REQUESTS", 100),
 		RateLimitWindow:   time.Duration(getEnvInt("RATE_LIMIT_WINDOW_SECONDS", 60)) * time.Second,
 
+		// Session subsystem
+		SessionAccessTTL:  time.Duration(getEnvInt("SESSION_ACCESS_TTL_MINUTES", 15)) * time.Minute,
+		SessionRefreshTTL: time.Duration(getEnvInt("SESSION_REFRESH_TTL_HOURS", 24*30)) * time.Hour,
+
 		// Content settings
 		DefaultContentLimit: getEnvInt("DEFAULT_CONTENT_LIMIT", 20),
 		MaxContentLimit:     getEnvInt("MAX_CONTENT_LIMIT", 100),
 		IngestionInterval:   time.Duration(getEnvInt("INGESTION_INTERVAL_MINUTES", 15)) * time.Minute,
 
+		AnalyticsRollupPeriod: time.Duration(getEnvInt("ANALYTICS_ROLLUP_PERIOD_MINUTES", 10)) * time.Minute,
+
+		// Content cache settings
+		CacheBackend:           getEnv("CACHE_BACKEND", "memory"),
+		CacheMaxBytes:          int64(getEnvInt("CACHE_MAX_BYTES", 64*1024*1024)),
+		CacheInvalidationTopic: getEnv("KAFKA_CACHE_INVALIDATION_TOPIC", "news-feed-cache-invalidation"),
+
+		// Job event streaming settings
+		KafkaEventsTopic: getEnv("KAFKA_EVENTS_TOPIC", "news-feed-job-events"),
+
 		// Video settings
-		VideoOutputDir:      getEnv("VIDEO_OUTPUT_DIR", "/data/videos"),
-		MaxConcurrentVideos: getEnvInt("MAX_CONCURRENT_VIDEOS", 3),
+		VideoOutputDir:       getEnv("VIDEO_OUTPUT_DIR", "/data/videos"),
+		MaxConcurrentVideos:  getEnvInt("MAX_CONCURRENT_VIDEOS", 3),
+		VideoLeaseDuration:   time.Duration(getEnvInt("VIDEO_LEASE_DURATION_SECONDS", 300)) * time.Second,
+		VideoLeaseReapPeriod: time.Duration(getEnvInt("VIDEO_LEASE_REAP_SECONDS", 30)) * time.Second,
+
+		VideoDefaultMaxInFlight: getEnvInt("VIDEO_TENANT_MAX_IN_FLIGHT", 3),
+		VideoDefaultMaxPerHour:  getEnvInt("VIDEO_TENANT_MAX_PER_HOUR", 50),
 
 		// White-label settings
 		EnableWhiteLabel:    getEnvBool("ENABLE_WHITE_LABEL", true),
@@ -116,97 +283,136 @@ func Load() (*Config, error) {
 		DefaultBrandingLogo: getEnv("DEFAULT_BRANDING_LOGO", "/assets/logo.png"),
 		DefaultBrandingName: getEnv("DEFAULT_BRANDING_NAME", "ElevatedIQ News"),
 
+		KafkaWhitelabelTopic: getEnv("KAFKA_WHITELABEL_TOPIC", "news-feed-whitelabel-changes"),
+
+		KafkaCreatorImportTopic: getEnv("KAFKA_CREATOR_IMPORT_TOPIC", "news-feed-creator-imports"),
+
+		PublicBaseURL:            getEnv("PUBLIC_BASE_URL", "https://news-feed.elevatediq.ai"),
+		YouTubePubSubRenewPeriod: time.Duration(getEnvInt("YOUTUBE_PUBSUB_RENEW_PERIOD_MINUTES", 60)) * time.Minute,
+		YouTubePubSubRenewBefore: time.Duration(getEnvInt("YOUTUBE_PUBSUB_RENEW_BEFORE_HOURS", 24)) * time.Hour,
+		YouTubeAPIKeyPool:        splitNonEmpty(getEnv("YOUTUBE_API_KEY_POOL", "")),
+		YouTubeDailyQuotaBudget:  getEnvInt("YOUTUBE_DAILY_QUOTA_BUDGET", 10000),
+		EnableYTDLPEnrichment:    getEnvBool("ENABLE_YTDLP_ENRICHMENT", false),
+		YTDLPBinaryPath:          getEnv("YTDLP_BINARY_PATH", "yt-dlp"),
+		YTDLPMaxWorkers:          getEnvInt("YTDLP_MAX_WORKERS", 2),
+
+		InvidiousInstances:          splitNonEmpty(getEnv("INVIDIOUS_INSTANCES", "")),
+		PipedInstances:              splitNonEmpty(getEnv("PIPED_INSTANCES", "")),
+		VideoSourceFailureThreshold: getEnvInt("VIDEO_SOURCE_FAILURE_THRESHOLD", 5),
+		VideoSourceCooldown:         time.Duration(getEnvInt("VIDEO_SOURCE_COOLDOWN_SECONDS", 30)) * time.Second,
+
+		TracingEnabled:    getEnvBool("TRACING_ENABLED", false),
+		TracingExporter:   getEnv("TRACING_EXPORTER", "otlp-grpc"),
+		OTLPTraceEndpoint: getEnv("OTLP_TRACE_ENDPOINT", "127.0.0.1:4317"),
+		JaegerEndpoint:    getEnv("JAEGER_ENDPOINT", "127.0.0.1:6831"),
+
 		// GCP settings
 		GCPProjectID: getEnv("GCP_PROJECT_ID", "elevatediq-production"),
+
+		AdminAuthMode:     getEnv("ADMIN_AUTH_MODE", "password"),
+		AdminClientCAFile: getEnv("ADMIN_CLIENT_CA_FILE", ""),
+
+		TwitterBackend:  getEnv("TWITTER_BACKEND", "auto"),
+		NitterInstances: splitNonEmpty(getEnv("NITTER_INSTANCES", "")),
+
+		SecretBackend:              getEnv("SECRET_BACKEND", ""),
+		SecretsDir:                 getEnv("SECRETS_DIR", "/var/run/secrets/news-feed-engine"),
+		SecretCacheTTL:             time.Duration(getEnvInt("SECRET_CACHE_TTL_SECONDS", 300)) * time.Second,
+		SecretRotationPollInterval: time.Duration(getEnvInt("SECRET_ROTATION_POLL_SECONDS", 60)) * time.Second,
 	}
 
-	// Load secrets from GCP Secret Manager or environment
-	// Skip Secret Manager in development if credentials not available
-	useSecretManager := getEnv("USE_SECRET_MANAGER", "false") == "true"
-
-	if cfg.Environment == "development" && !useSecretManager {
-		// In development without Secret Manager, load from environment
-		cfg.loadSecretsFromEnv()
-	} else {
-		// Production or development with Secret Manager enabled
-		if err := cfg.loadSecrets(); err != nil {
-			// Fall back to environment variables if Secret Manager fails
-			if cfg.Environment == "development" {
-				cfg.loadSecretsFromEnv()
-			} else {
+	if cfg.SecretBackend == "" {
+		if cfg.Environment == "development" && getEnv("USE_SECRET_MANAGER", "false") != "true" {
+			cfg.SecretBackend = "env"
+		} else {
+			cfg.SecretBackend = "gcp"
+		}
+	}
+
+	if err := cfg.loadSecrets(); err != nil {
+		// Fall back to environment variables if the configured backend
+		// fails - typically "gcp" without local ADC credentials.
+		if cfg.Environment == "development" && cfg.SecretBackend != "env" {
+			cfg.SecretBackend = "env"
+			if err := cfg.loadSecrets(); err != nil {
 				return nil, fmt.Errorf("failed to load secrets: %w", err)
 			}
+		} else {
+			return nil, fmt.Errorf("failed to load secrets: %w", err)
 		}
 	}
 
 	return cfg, nil
 }
 
-// loadSecrets loads API keys from GCP Secret Manager
+// secretNames lists every Secret Manager-style name loadSecrets resolves
+// into a Config field below, regardless of which backend is active.
+var secretNames = []string{
+	"news-feed-youtube-api-key",
+	"news-feed-twitter-api-key",
+	"news-feed-twitter-api-secret",
+	"news-feed-reddit-client-id",
+	"news-feed-reddit-client-secret",
+	"news-feed-claude-api-key",
+	"news-feed-openai-api-key",
+	"news-feed-elevenlabs-api-key",
+	"news-feed-did-api-key",
+	"news-feed-jwt-secret",
+	"news-feed-youtube-webhook-secret",
+	"news-feed-twitter-consumer-secret",
+	"news-feed-mastodon-webhook-secret",
+}
+
+// loadSecrets builds the secrets.Provider named by c.SecretBackend and
+// loads every name in secretNames through it concurrently, assigning
+// results to their corresponding Config fields. For "gcp" it also starts
+// rotation polling, so a later key rotation arrives on SecretChanges()
+// instead of requiring a restart.
 func (c *Config) loadSecrets() error {
 	ctx := context.Background()
-	client, err := secretmanager.NewClient(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to create secret manager client: %w", err)
-	}
-	defer client.Close()
-
-	secrets := map[string]*string{
-		"news-feed-youtube-api-key":         &c.YouTubeAPIKey,
-		"news-feed-twitter-api-key":         &c.TwitterAPIKey,
-		"news-feed-twitter-api-secret":      &c.TwitterAPISecret,
-		"news-feed-reddit-client-id":        &c.RedditClientID,
-		"news-feed-reddit-client-secret":    &c.RedditClientSec,
-		"news-feed-claude-api-key":          &c.ClaudeAPIKey,
-		"news-feed-openai-api-key":          &c.OpenAIAPIKey,
-		"news-feed-elevenlabs-api-key":      &c.ElevenLabsAPIKey,
-		"news-feed-did-api-key":             &c.DIDAPIKey,
-		"news-feed-jwt-secret":              &c.JWTSecret,
-		"news-feed-youtube-webhook-secret":  &c.YouTubeWebhookSecret,
-		"news-feed-twitter-consumer-secret": &c.TwitterConsumerSecret,
-	}
 
-	for secretName, target := range secrets {
-		value, err := c.accessSecret(ctx, client, secretName)
+	var provider secrets.Provider
+	switch c.SecretBackend {
+	case "env":
+		provider = secrets.NewEnvProvider()
+	case "file":
+		provider = secrets.NewFileProvider(c.SecretsDir)
+	case "gcp":
+		manager, err := secrets.NewCachedSecretManager(ctx, c.GCPProjectID, c.SecretCacheTTL, nil)
 		if err != nil {
-			return fmt.Errorf("failed to access secret %s: %w", secretName, err)
+			return fmt.Errorf("failed to create secret manager provider: %w", err)
 		}
-		*target = value
-	}
-
-	return nil
-}
-
-// accessSecret retrieves a secret value from GCP Secret Manager
-func (c *Config) accessSecret(ctx context.Context, client *secretmanager.Client, secretID string) (string, error) {
-	name := fmt.Sprintf("projects/%s/secrets/%s/versions/latest", c.GCPProjectID, secretID)
-
-	req := &secretmanagerpb.AccessSecretVersionRequest{
-		Name: name,
+		manager.WatchRotation(ctx, c.SecretRotationPollInterval)
+		provider = manager
+	default:
+		return fmt.Errorf("unknown secret backend %q", c.SecretBackend)
 	}
+	c.secretProvider = provider
 
-	result, err := client.AccessSecretVersion(ctx, req)
+	values, err := secrets.LoadAll(ctx, provider, secretNames)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	return string(result.Payload.Data), nil
-}
+	c.YouTubeAPIKey = values["news-feed-youtube-api-key"]
+	c.TwitterAPIKey = values["news-feed-twitter-api-key"]
+	c.TwitterAPISecret = values["news-feed-twitter-api-secret"]
+	c.RedditClientID = values["news-feed-reddit-client-id"]
+	c.RedditClientSec = values["news-feed-reddit-client-secret"]
+	c.ClaudeAPIKey = values["news-feed-claude-api-key"]
+	c.OpenAIAPIKey = values["news-feed-openai-api-key"]
+	c.ElevenLabsAPIKey = values["news-feed-elevenlabs-api-key"]
+	c.DIDAPIKey = values["news-feed-did-api-key"]
+	c.JWTSecret = values["news-feed-jwt-secret"]
+	c.YouTubeWebhookSecret = values["news-feed-youtube-webhook-secret"]
+	c.TwitterConsumerSecret = values["news-feed-twitter-consumer-secret"]
+	c.MastodonWebhookSecret = values["news-feed-mastodon-webhook-secret"]
+
+	if c.SecretBackend == "env" && c.JWTSecret == "" {
+		c.JWTSecret = "development-secret-key"
+	}
 
-// loadSecretsFromEnv loads secrets from environment variables (for development)
-func (c *Config) loadSecretsFromEnv() {
-	c.YouTubeAPIKey = getEnv("YOUTUBE_API_KEY", "")
-	c.TwitterAPIKey = getEnv("TWITTER_API_KEY", "")
-	c.TwitterAPISecret = getEnv("TWITTER_API_SECRET", "")
-	c.RedditClientID = getEnv("REDDIT_CLIENT_ID", "")
-	c.RedditClientSec = getEnv("REDDIT_CLIENT_SECRET", "")
-	c.ClaudeAPIKey = getEnv("CLAUDE_API_KEY", "")
-	c.OpenAIAPIKey = getEnv("OPENAI_API_KEY", "")
-	c.ElevenLabsAPIKey = getEnv("ELEVENLABS_API_KEY", "")
-	c.DIDAPIKey = getEnv("DID_API_KEY", "")
-	c.JWTSecret = getEnv("JWT_SECRET", "development-secret-key")
-	c.YouTubeWebhookSecret = getEnv("YOUTUBE_WEBHOOK_SECRET", "")
-	c.TwitterConsumerSecret = getEnv("TWITTER_CONSUMER_SECRET", "")
+	return nil
 }
 
 // Helper functions
@@ -234,3 +440,19 @@ func getEnvBool(key string, defaultValue bool) bool {
 	}
 	return defaultValue
 }
+
+// splitNonEmpty splits a comma-separated env value into its non-empty
+// entries, returning nil for an empty/whitespace-only input rather than
+// a slice containing "" (unlike strings.Split("", ",")).
+func splitNonEmpty(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	var out []string
+	for _, part := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			out = append(out, trimmed)
+		}
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+// Package database provides the ActivityPub follower repository
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/integrations"
+)
+
+// ActivityPubRepository persists federation followers in the
+// ap_followers table and implements integrations.ActivityPubStore.
+type ActivityPubRepository struct {
+	db *DB
+}
+
+// NewActivityPubRepository creates a new ActivityPub follower repository.
+func NewActivityPubRepository(db *DB) *ActivityPubRepository {
+	return &ActivityPubRepository{db: db}
+}
+
+// AddFollower upserts a follower, so a repeated Follow (e.g. after the
+// remote instance retries) doesn't produce duplicate rows.
+func (r *ActivityPubRepository) AddFollower(ctx context.Context, f integrations.ActivityPubFollower) error {
+	query := `
+		INSERT INTO ap_followers (blog, actor, inbox, shared_inbox, followed_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (blog, actor) DO UPDATE
+		SET inbox = EXCLUDED.inbox, shared_inbox = EXCLUDED.shared_inbox`
+
+	if _, err := r.db.ExecContext(ctx, query, f.Blog, f.Actor, f.Inbox, f.SharedInbox, f.FollowedAt); err != nil {
+		return fmt.Errorf("failed to add activitypub follower: %w", err)
+	}
+	return nil
+}
+
+// RemoveFollower deletes a follower, e.g. on Undo{Follow} or a tombstoned actor.
+func (r *ActivityPubRepository) RemoveFollower(ctx context.Context, blog, actor string) error {
+	query := `DELETE FROM ap_followers WHERE blog = $1 AND actor = $2`
+	if _, err := r.db.ExecContext(ctx, query, blog, actor); err != nil {
+		return fmt.Errorf("failed to remove activitypub follower: %w", err)
+	}
+	return nil
+}
+
+// ListFollowers returns every follower of blog.
+func (r *ActivityPubRepository) ListFollowers(ctx context.Context, blog string) ([]integrations.ActivityPubFollower, error) {
+	query := `SELECT blog, actor, inbox, shared_inbox, followed_at FROM ap_followers WHERE blog = $1`
+
+	var followers []integrations.ActivityPubFollower
+	if err := r.db.SelectContext(ctx, &followers, query, blog); err != nil {
+		return nil, fmt.Errorf("failed to list activitypub followers: %w", err)
+	}
+	return followers, nil
+}
@@ -4,10 +4,17 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database/sqlcgen"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/middleware"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/ratelimit"
 )
 
 // SourceListOptions configures source listing
@@ -68,25 +75,40 @@ func NewConfigRepository(db *DB) *ConfigRepository {
 	return &ConfigRepository{db: db}
 }
 
-// Get retrieves tenant configuration
-func (r *ConfigRepository) Get(ctx context.Context, tenantID string) (*models.TenantConfig, error) {
-	query := `
-		SELECT id, tenant_id, display_name, logo_url, favicon_url, primary_color, secondary_color,
-		       accent_color, font_family, custom_css, custom_domain, enabled_platforms,
-		       enabled_categories, default_voice_id, video_watermark, analytics_id,
-		       settings, active, created_at, updated_at
-		FROM tenant_configs
-		WHERE tenant_id = $1
-	`
+const tenantConfigColumns = `id, tenant_id, display_name, logo_url, favicon_url, primary_color, secondary_color,
+	       accent_color, font_family, custom_css, custom_domain, enabled_platforms,
+	       enabled_categories, default_voice_id, video_watermark, analytics_id,
+	       settings, active, created_at, updated_at`
 
-	var cfg models.TenantConfig
-	err := r.db.QueryRowContext(ctx, query, tenantID).Scan(
+// tenantConfigRow scans a tenant_configs row in column order shared by
+// Get and Update (the latter via RETURNING), so the two never drift.
+func tenantConfigRow(cfg *models.TenantConfig) []interface{} {
+	return []interface{}{
 		&cfg.ID, &cfg.TenantID, &cfg.DisplayName, &cfg.LogoURL, &cfg.FaviconURL,
 		&cfg.PrimaryColor, &cfg.SecondaryColor, &cfg.AccentColor, &cfg.FontFamily,
 		&cfg.CustomCSS, &cfg.CustomDomain, &cfg.EnabledPlatforms, &cfg.EnabledCategories,
 		&cfg.DefaultVoiceID, &cfg.VideoWatermark, &cfg.AnalyticsID, &cfg.Settings,
 		&cfg.Active, &cfg.CreatedAt, &cfg.UpdatedAt,
-	)
+	}
+}
+
+// ListTenantIDs returns every tenant with a tenant_configs row, for
+// background jobs (e.g. audit.RetentionJob) that need to iterate over
+// all known tenants rather than operate on just one.
+func (r *ConfigRepository) ListTenantIDs(ctx context.Context) ([]string, error) {
+	var ids []string
+	if err := r.db.SelectContext(ctx, &ids, `SELECT tenant_id FROM tenant_configs`); err != nil {
+		return nil, fmt.Errorf("failed to list tenant ids: %w", err)
+	}
+	return ids, nil
+}
+
+// Get retrieves tenant configuration
+func (r *ConfigRepository) Get(ctx context.Context, tenantID string) (*models.TenantConfig, error) {
+	query := `SELECT ` + tenantConfigColumns + ` FROM tenant_configs WHERE tenant_id = $1`
+
+	var cfg models.TenantConfig
+	err := r.db.QueryRowContext(ctx, query, tenantID).Scan(tenantConfigRow(&cfg)...)
 
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("tenant config not found")
@@ -98,56 +120,617 @@ func (r *ConfigRepository) Get(ctx context.Context, tenantID string) (*models.Te
 	return &cfg, nil
 }
 
-// Update updates tenant configuration
-func (r *ConfigRepository) Update(ctx context.Context, tenantID string, updates map[string]interface{}) error {
+// rateLimitPoliciesSettingsKey is the key under tenant_configs.settings
+// where per-scope ratelimit.RateLimitPolicy overrides live, e.g.
+// {"rate_limit_policies": {"admin": {"max_requests": 50, "window_seconds": 60}}}.
+const rateLimitPoliciesSettingsKey = "rate_limit_policies"
+
+// RateLimitPolicy implements ratelimit.PolicyStore by reading tenantID's
+// override for scope out of tenant_configs.settings, so an admin can
+// raise or lower a tenant's HTTP rate limit via UpdateConfig without a
+// redeploy. Returns (nil, nil) when the tenant has no config row or no
+// override for scope, so the caller falls back to its built-in default.
+func (r *ConfigRepository) RateLimitPolicy(ctx context.Context, tenantID, scope string) (*ratelimit.RateLimitPolicy, error) {
+	cfg, err := r.Get(ctx, tenantID)
+	if err != nil {
+		return nil, nil
+	}
+
+	policies, ok := cfg.Settings[rateLimitPoliciesSettingsKey].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	raw, ok := policies[scope]
+	if !ok {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rate limit policy override: %w", err)
+	}
+
+	var override struct {
+		MaxRequests int `json:"max_requests"`
+		WindowSecs  int `json:"window_seconds"`
+	}
+	if err := json.Unmarshal(b, &override); err != nil {
+		return nil, fmt.Errorf("failed to decode rate limit policy override: %w", err)
+	}
+
+	return &ratelimit.RateLimitPolicy{
+		MaxRequests: override.MaxRequests,
+		Window:      time.Duration(override.WindowSecs) * time.Second,
+		Scope:       scope,
+	}, nil
+}
+
+// clientCertsSettingsKey is the key under tenant_configs.settings where a
+// tenant's mTLS client certificate allowlist lives, e.g.
+// {"tls_client_certs": {"ops-laptop.internal": {"role": "admin"}}}, keyed
+// by certificate CN (or first SAN DNS name if CN is empty).
+const clientCertsSettingsKey = "tls_client_certs"
+
+// ClientCertMap implements middleware.ClientCertStore by reading
+// tenantID's client certificate allowlist out of tenant_configs.settings,
+// so an admin can grant or revoke a certificate via UpdateConfig without
+// a redeploy. Returns (nil, nil) when the tenant has no config row or no
+// allowlist configured, so MTLSMiddleware treats every certificate as
+// unrecognized.
+func (r *ConfigRepository) ClientCertMap(ctx context.Context, tenantID string) (map[string]middleware.ClientCertIdentity, error) {
+	cfg, err := r.Get(ctx, tenantID)
+	if err != nil {
+		return nil, nil
+	}
+
+	raw, ok := cfg.Settings[clientCertsSettingsKey]
+	if !ok {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal client cert map: %w", err)
+	}
+
+	var entries map[string]struct {
+		Role string `json:"role"`
+	}
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode client cert map: %w", err)
+	}
+
+	certs := make(map[string]middleware.ClientCertIdentity, len(entries))
+	for identity, entry := range entries {
+		certs[identity] = middleware.ClientCertIdentity{TenantID: tenantID, Role: entry.Role}
+	}
+	return certs, nil
+}
+
+// deadlineOverridesSettingsKey is the key under tenant_configs.settings
+// where per-route middleware.DeadlineMiddleware overrides live, e.g.
+// {"deadline_overrides": {"/api/v1/admin/analytics/*": 30}} (seconds),
+// keyed by the same route pattern DeadlineMiddleware's defaults map uses.
+const deadlineOverridesSettingsKey = "deadline_overrides"
+
+// DeadlinePolicy implements middleware.DeadlineStore by reading
+// tenantID's override for route out of tenant_configs.settings, so e.g.
+// a premium tenant can get a longer analytics window via UpdateConfig
+// without a redeploy. Returns (nil, nil) when the tenant has no config
+// row or no override for route, so the caller falls back to its
+// built-in default.
+func (r *ConfigRepository) DeadlinePolicy(ctx context.Context, tenantID, route string) (*time.Duration, error) {
+	cfg, err := r.Get(ctx, tenantID)
+	if err != nil {
+		return nil, nil
+	}
+
+	overrides, ok := cfg.Settings[deadlineOverridesSettingsKey].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	raw, ok := overrides[route]
+	if !ok {
+		return nil, nil
+	}
+
+	seconds, ok := raw.(float64)
+	if !ok {
+		return nil, fmt.Errorf("deadline override for %q must be a number of seconds", route)
+	}
+
+	limit := time.Duration(seconds * float64(time.Second))
+	return &limit, nil
+}
+
+// ConfigVersion is one immutable revision of a tenant's white-label
+// configuration, recorded by ConfigRepository.Update every time the
+// configuration changes. Config holds the full configuration as of this
+// revision, not just the changed fields, so GetVersion and Rollback never
+// need to replay history from version 1 to reconstruct a snapshot.
+type ConfigVersion struct {
+	ID        uuid.UUID       `json:"id" db:"id"`
+	TenantID  string          `json:"tenant_id" db:"tenant_id"`
+	Version   int             `json:"version" db:"version"`
+	Config    models.JSONB    `json:"config" db:"config"`
+	Patch     json.RawMessage `json:"patch" db:"patch"`
+	UpdatedBy string          `json:"updated_by" db:"updated_by"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+// ConfigVersionSummary is the lightweight view ListVersions returns - every
+// field but the full config snapshot and patch, since a history list page
+// needs neither and a tenant's config can grow arbitrarily large.
+type ConfigVersionSummary struct {
+	Version   int       `json:"version" db:"version"`
+	UpdatedBy string    `json:"updated_by" db:"updated_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Update updates tenant configuration, recording the change as a new
+// immutable ConfigVersion diffed against the configuration as it stood
+// immediately before this call. updatedBy identifies the caller (see
+// middleware.GetUserID) for the version's audit trail.
+func (r *ConfigRepository) Update(ctx context.Context, tenantID string, updates map[string]interface{}, updatedBy string) (*ConfigVersion, error) {
 	if len(updates) == 0 {
+		return nil, fmt.Errorf("no fields to update")
+	}
+
+	params, err := tenantConfigUpdateParams(tenantID, updates)
+	if err != nil {
+		return nil, err
+	}
+
+	var version *ConfigVersion
+	err = r.db.WithTransaction(ctx, func(tx *sqlx.Tx) error {
+		q := sqlcgen.New(tx)
+
+		before, err := q.GetTenantConfigForUpdate(ctx, tenantID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("tenant config not found")
+			}
+			return fmt.Errorf("failed to load current config: %w", err)
+		}
+
+		after, err := q.UpdateTenantConfig(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to update tenant config: %w", err)
+		}
+
+		beforeMap, err := tenantConfigToMap(&before)
+		if err != nil {
+			return fmt.Errorf("failed to encode current config: %w", err)
+		}
+		afterMap, err := tenantConfigToMap(&after)
+		if err != nil {
+			return fmt.Errorf("failed to encode updated config: %w", err)
+		}
+
+		patch, err := json.Marshal(buildJSONPatch(beforeMap, afterMap))
+		if err != nil {
+			return fmt.Errorf("failed to encode config patch: %w", err)
+		}
+
+		var nextVersion int
+		if err := tx.QueryRowContext(ctx,
+			"SELECT COALESCE(MAX(version), 0) + 1 FROM config_versions WHERE tenant_id = $1",
+			tenantID,
+		).Scan(&nextVersion); err != nil {
+			return fmt.Errorf("failed to allocate config version: %w", err)
+		}
+
+		cv := ConfigVersion{
+			TenantID:  tenantID,
+			Version:   nextVersion,
+			Config:    afterMap,
+			Patch:     patch,
+			UpdatedBy: updatedBy,
+		}
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO config_versions (tenant_id, version, config, patch, updated_by)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id, created_at
+		`, cv.TenantID, cv.Version, cv.Config, []byte(cv.Patch), cv.UpdatedBy).Scan(&cv.ID, &cv.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to record config version: %w", err)
+		}
+
+		version = &cv
 		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// ListVersions returns a paginated, newest-first page of configuration
+// version summaries for a tenant.
+func (r *ConfigRepository) ListVersions(ctx context.Context, tenantID string, page, limit int) ([]ConfigVersionSummary, int, error) {
+	offset := (page - 1) * limit
+
+	var total int
+	if err := r.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM config_versions WHERE tenant_id = $1", tenantID,
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count config versions: %w", err)
 	}
 
-	query := "UPDATE tenant_configs SET "
-	args := []interface{}{}
-	argCount := 0
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT version, updated_by, created_at
+		FROM config_versions
+		WHERE tenant_id = $1
+		ORDER BY version DESC
+		LIMIT $2 OFFSET $3
+	`, tenantID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list config versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []ConfigVersionSummary
+	for rows.Next() {
+		var v ConfigVersionSummary
+		if err := rows.Scan(&v.Version, &v.UpdatedBy, &v.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan config version: %w", err)
+		}
+		versions = append(versions, v)
+	}
+
+	return versions, total, nil
+}
+
+// GetVersion retrieves a single full configuration snapshot by version number.
+func (r *ConfigRepository) GetVersion(ctx context.Context, tenantID string, version int) (*ConfigVersion, error) {
+	var cv ConfigVersion
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, tenant_id, version, config, patch, updated_by, created_at
+		FROM config_versions
+		WHERE tenant_id = $1 AND version = $2
+	`, tenantID, version).Scan(
+		&cv.ID, &cv.TenantID, &cv.Version, &cv.Config, &cv.Patch, &cv.UpdatedBy, &cv.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("config version not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config version: %w", err)
+	}
+	return &cv, nil
+}
+
+// Diff computes the RFC 6902 JSON Patch needed to turn the configuration at
+// version `from` into the configuration at version `to`. It is computed
+// live between the two stored snapshots rather than stitched together out
+// of each version's stored Patch, so it works for any pair of versions, not
+// just adjacent ones.
+func (r *ConfigRepository) Diff(ctx context.Context, tenantID string, from, to int) (json.RawMessage, error) {
+	fromVersion, err := r.GetVersion(ctx, tenantID, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load version %d: %w", from, err)
+	}
+	toVersion, err := r.GetVersion(ctx, tenantID, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load version %d: %w", to, err)
+	}
+
+	patch, err := json.Marshal(buildJSONPatch(fromVersion.Config, toVersion.Config))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode diff: %w", err)
+	}
+	return patch, nil
+}
+
+// Rollback creates a new configuration version equal to the snapshot
+// recorded at `version`, by replaying it through Update. That keeps a
+// rollback on the normal versioning/diff path instead of treating it as a
+// special case - it produces just another auditable version.
+func (r *ConfigRepository) Rollback(ctx context.Context, tenantID string, version int, updatedBy string) (*ConfigVersion, error) {
+	target, err := r.GetVersion(ctx, tenantID, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load version %d: %w", version, err)
+	}
+
+	updates := make(map[string]interface{}, len(target.Config))
+	for field, value := range target.Config {
+		switch field {
+		case "id", "tenant_id", "created_at", "updated_at":
+			continue
+		}
+		updates[field] = value
+	}
+
+	return r.Update(ctx, tenantID, updates, updatedBy)
+}
+
+// tenantConfigUpdatableFields allowlists the tenant_configs columns Update
+// may touch. Every key in the caller-supplied updates map must appear
+// here - this is what keeps an arbitrary JSON request body from ever
+// reaching SQL text, since unlike the old map-iteration builder, a
+// field name never flows into the query itself.
+var tenantConfigUpdatableFields = map[string]struct{}{
+	"display_name": {}, "logo_url": {}, "favicon_url": {}, "primary_color": {},
+	"secondary_color": {}, "accent_color": {}, "font_family": {}, "custom_css": {},
+	"custom_domain": {}, "enabled_platforms": {}, "enabled_categories": {},
+	"default_voice_id": {}, "video_watermark": {}, "analytics_id": {}, "settings": {},
+	"active": {},
+}
+
+// tenantConfigUpdateParams converts the public map-based Update request
+// into a sqlcgen.UpdateTenantConfigParams, rejecting any key outside
+// tenantConfigUpdatableFields. JSON-decoded values are type-asserted
+// against the column's Go type; a mismatch is reported as a bad field
+// rather than silently dropped.
+func tenantConfigUpdateParams(tenantID string, updates map[string]interface{}) (sqlcgen.UpdateTenantConfigParams, error) {
+	params := sqlcgen.UpdateTenantConfigParams{TenantID: tenantID}
 
 	for field, value := range updates {
-		argCount++
-		if argCount > 1 {
-			query += ", "
+		if _, ok := tenantConfigUpdatableFields[field]; !ok {
+			return params, fmt.Errorf("tenant config field %q cannot be updated", field)
+		}
+
+		switch field {
+		case "display_name":
+			s, err := asString(field, value)
+			if err != nil {
+				return params, err
+			}
+			params.DisplayName = &s
+		case "logo_url":
+			s, err := asString(field, value)
+			if err != nil {
+				return params, err
+			}
+			params.LogoURL = &s
+		case "favicon_url":
+			s, err := asString(field, value)
+			if err != nil {
+				return params, err
+			}
+			params.FaviconURL = &s
+		case "primary_color":
+			s, err := asString(field, value)
+			if err != nil {
+				return params, err
+			}
+			params.PrimaryColor = &s
+		case "secondary_color":
+			s, err := asString(field, value)
+			if err != nil {
+				return params, err
+			}
+			params.SecondaryColor = &s
+		case "accent_color":
+			s, err := asString(field, value)
+			if err != nil {
+				return params, err
+			}
+			params.AccentColor = &s
+		case "font_family":
+			s, err := asString(field, value)
+			if err != nil {
+				return params, err
+			}
+			params.FontFamily = &s
+		case "custom_css":
+			s, err := asString(field, value)
+			if err != nil {
+				return params, err
+			}
+			params.CustomCSS = &s
+		case "custom_domain":
+			s, err := asString(field, value)
+			if err != nil {
+				return params, err
+			}
+			params.CustomDomain = &s
+		case "enabled_platforms":
+			s, err := asStringSlice(field, value)
+			if err != nil {
+				return params, err
+			}
+			params.EnabledPlatforms = &s
+		case "enabled_categories":
+			s, err := asStringSlice(field, value)
+			if err != nil {
+				return params, err
+			}
+			params.EnabledCategories = &s
+		case "default_voice_id":
+			s, err := asString(field, value)
+			if err != nil {
+				return params, err
+			}
+			params.DefaultVoiceID = &s
+		case "video_watermark":
+			s, err := asString(field, value)
+			if err != nil {
+				return params, err
+			}
+			params.VideoWatermark = &s
+		case "analytics_id":
+			s, err := asString(field, value)
+			if err != nil {
+				return params, err
+			}
+			params.AnalyticsID = &s
+		case "settings":
+			m, ok := value.(map[string]interface{})
+			if !ok {
+				return params, fmt.Errorf("tenant config field %q must be an object", field)
+			}
+			settings := models.JSONB(m)
+			params.Settings = &settings
+		case "active":
+			b, err := asBool(field, value)
+			if err != nil {
+				return params, err
+			}
+			params.Active = &b
+		}
+	}
+
+	return params, nil
+}
+
+// asString and friends type-assert a decoded JSON value for one
+// allowlisted field, so a malformed request body fails with a field
+// name instead of panicking on a bad type assertion.
+func asString(field string, value interface{}) (string, error) {
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("tenant config field %q must be a string", field)
+	}
+	return s, nil
+}
+
+func asBool(field string, value interface{}) (bool, error) {
+	b, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("tenant config field %q must be a boolean", field)
+	}
+	return b, nil
+}
+
+func asStringSlice(field string, value interface{}) ([]string, error) {
+	raw, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("tenant config field %q must be an array", field)
+	}
+	out := make([]string, len(raw))
+	for i, v := range raw {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("tenant config field %q must be an array of strings", field)
+		}
+		out[i] = s
+	}
+	return out, nil
+}
+
+// tenantConfigToMap round-trips a TenantConfig through JSON to get a plain
+// field-name-keyed map, so buildJSONPatch can diff it the same way it diffs
+// two stored ConfigVersion.Config snapshots.
+func tenantConfigToMap(cfg *models.TenantConfig) (models.JSONB, error) {
+	return structToJSONB(cfg)
+}
+
+// structToJSONB round-trips any struct through JSON to get a plain
+// field-name-keyed map, so buildJSONPatch can diff it regardless of the
+// concrete entity type (ContentSource, VideoTemplate, ...).
+func structToJSONB(v interface{}) (models.JSONB, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	m := make(models.JSONB)
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// buildJSONPatch returns an RFC 6902 JSON Patch (one add/remove/replace
+// operation per top-level field) describing how to turn before into after.
+// A top-level diff is enough here because neither a TenantConfig snapshot
+// nor its Settings blob is ever addressed below the field level by any
+// consumer (GetVersion, Diff, Rollback all operate on whole field values).
+func buildJSONPatch(before, after models.JSONB) []map[string]interface{} {
+	var ops []map[string]interface{}
+
+	for field, afterValue := range after {
+		beforeValue, existed := before[field]
+		if !existed {
+			ops = append(ops, map[string]interface{}{"op": "add", "path": "/" + field, "value": afterValue})
+			continue
+		}
+		if !reflect.DeepEqual(beforeValue, afterValue) {
+			ops = append(ops, map[string]interface{}{"op": "replace", "path": "/" + field, "value": afterValue})
+		}
+	}
+
+	for field := range before {
+		if _, exists := after[field]; !exists {
+			ops = append(ops, map[string]interface{}{"op": "remove", "path": "/" + field})
 		}
-		query += fmt.Sprintf("%s = $%d", field, argCount)
-		args = append(args, value)
 	}
 
-	argCount++
-	query += fmt.Sprintf(", updated_at = NOW() WHERE tenant_id = $%d", argCount)
-	args = append(args, tenantID)
+	return ops
+}
+
+// recordBulkChange writes one config_changes row capturing preImages - the
+// full pre-update snapshot of every entity a bulk update touched - keyed
+// by a freshly generated change_id, so RollbackChange can restore the
+// whole batch in one call instead of replaying one audit_revisions entry
+// at a time.
+func recordBulkChange(ctx context.Context, tx *sqlx.Tx, tenantID, entityType, actorID string, preImages interface{}) (uuid.UUID, error) {
+	b, err := json.Marshal(preImages)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to encode pre-image: %w", err)
+	}
 
-	result, err := r.db.ExecContext(ctx, query, args...)
+	changeID := uuid.New()
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO config_changes (tenant_id, change_id, entity_type, actor_id, pre_image)
+		VALUES ($1, $2, $3, $4, $5)
+	`, tenantID, changeID, entityType, actorID, []byte(b))
 	if err != nil {
-		return fmt.Errorf("failed to update tenant config: %w", err)
+		return uuid.Nil, fmt.Errorf("failed to record bulk change: %w", err)
 	}
+	return changeID, nil
+}
 
-	rows, err := result.RowsAffected()
+// getBulkChange loads the pre-image recorded at changeID for entityType
+// and marks it rolled back, so a change_id can only be rolled back once.
+// Locks the row FOR UPDATE so two concurrent rollback requests for the
+// same change_id can't both succeed.
+func getBulkChange(ctx context.Context, tx *sqlx.Tx, tenantID, entityType string, changeID uuid.UUID) (json.RawMessage, error) {
+	var preImage json.RawMessage
+	var rolledBackAt sql.NullTime
+	err := tx.QueryRowContext(ctx, `
+		SELECT pre_image, rolled_back_at FROM config_changes
+		WHERE tenant_id = $1 AND change_id = $2 AND entity_type = $3
+		FOR UPDATE
+	`, tenantID, changeID, entityType).Scan(&preImage, &rolledBackAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("change not found")
+	}
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return nil, fmt.Errorf("failed to load bulk change: %w", err)
+	}
+	if rolledBackAt.Valid {
+		return nil, fmt.Errorf("change already rolled back")
 	}
 
-	if rows == 0 {
-		return fmt.Errorf("tenant config not found")
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE config_changes SET rolled_back_at = NOW() WHERE tenant_id = $1 AND change_id = $2`,
+		tenantID, changeID,
+	); err != nil {
+		return nil, fmt.Errorf("failed to mark change rolled back: %w", err)
 	}
 
-	return nil
+	return preImage, nil
 }
 
 // SourceRepository handles content source database operations
 type SourceRepository struct {
-	db *DB
+	db        *DB
+	auditRepo *AuditRepository
 }
 
-// NewSourceRepository creates a new source repository
-func NewSourceRepository(db *DB) *SourceRepository {
-	return &SourceRepository{db: db}
+// NewSourceRepository creates a new source repository. auditRepo records
+// an audit_revisions row alongside every Create/Update/Delete, so
+// source changes can be listed and rolled back the same way
+// ConfigRepository's config_versions lets tenant config changes be.
+func NewSourceRepository(db *DB, auditRepo *AuditRepository) *SourceRepository {
+	return &SourceRepository{db: db, auditRepo: auditRepo}
 }
 
+// entityTypeContentSource is this package's audit_revisions entity_type
+// for content sources.
+const entityTypeContentSource = "content_source"
+
 // List retrieves all content sources for a tenant
 func (r *SourceRepository) List(ctx context.Context, tenantID string, opts SourceListOptions) ([]models.ContentSource, error) {
 	query := `
@@ -197,144 +780,396 @@ func (r *SourceRepository) List(ctx context.Context, tenantID string, opts Sourc
 	return sources, nil
 }
 
-// Update updates an existing content source
-func (r *SourceRepository) Update(ctx context.Context, tenantID string, sourceID string, update SourceUpdate) error {
+// Update updates an existing content source, recording the change as a
+// new audit_revisions entry diffed against the source as it stood
+// immediately before this call. actorID identifies the caller (see
+// middleware.GetUserID) for the revision's audit trail.
+func (r *SourceRepository) Update(ctx context.Context, tenantID string, sourceID string, update SourceUpdate, actorID string) error {
 	id, err := uuid.Parse(sourceID)
 	if err != nil {
 		return fmt.Errorf("invalid source ID: %w", err)
 	}
 
-	updates := make(map[string]interface{})
+	params := sourceUpdateParams(id, tenantID, update)
+	if params == (sqlcgen.UpdateContentSourceParams{ID: id, TenantID: tenantID}) {
+		return nil
+	}
+
+	return r.db.WithTransaction(ctx, func(tx *sqlx.Tx) error {
+		q := sqlcgen.New(tx)
+
+		beforeSource, err := q.GetContentSourceByID(ctx, sqlcgen.GetContentSourceByIDParams{ID: id, TenantID: tenantID})
+		if err != nil {
+			return fmt.Errorf("source not found")
+		}
+
+		rows, err := q.UpdateContentSource(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to update source: %w", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("source not found")
+		}
+
+		after, err := q.GetContentSourceByID(ctx, sqlcgen.GetContentSourceByIDParams{ID: id, TenantID: tenantID})
+		if err != nil {
+			return fmt.Errorf("failed to load updated source: %w", err)
+		}
+
+		return r.recordSourceRevision(ctx, tx, tenantID, id, "update", actorID, &beforeSource, &after)
+	})
+}
+
+// sourceUpdateParams converts update's set fields into a
+// sqlcgen.UpdateContentSourceParams, leaving every unset field (left nil)
+// to COALESCE to its current column value. Shared by Update and BulkUpdate
+// so a single-source update and a batched one build identical params.
+func sourceUpdateParams(id uuid.UUID, tenantID string, update SourceUpdate) sqlcgen.UpdateContentSourceParams {
+	params := sqlcgen.UpdateContentSourceParams{ID: id, TenantID: tenantID}
 	if update.Name != "" {
-		updates["name"] = update.Name
+		params.Name = &update.Name
 	}
 	if update.Platform != "" {
-		updates["platform"] = update.Platform
+		platform := models.Platform(update.Platform)
+		params.Platform = &platform
 	}
 	if update.SourceType != "" {
-		updates["source_type"] = update.SourceType
+		params.SourceType = &update.SourceType
 	}
 	if update.Identifier != "" {
-		updates["identifier"] = update.Identifier
+		params.Identifier = &update.Identifier
 	}
 	if update.Category != "" {
-		updates["category"] = update.Category
+		params.Category = &update.Category
 	}
 	if update.Priority != nil {
-		updates["priority"] = *update.Priority
+		params.Priority = update.Priority
 	}
 	if update.IngestionCron != "" {
-		updates["ingestion_cron"] = update.IngestionCron
+		params.IngestionCron = &update.IngestionCron
 	}
 	if update.Active != nil {
-		updates["active"] = *update.Active
+		params.Active = update.Active
 	}
+	return params
+}
 
-	if len(updates) == 0 {
-		return nil
-	}
+// BulkUpdate applies every update in a single transaction, rolling back
+// all of them if any one fails - unlike calling Update in a loop, which
+// can leave a tenant half-applied when a later item errors. The
+// pre-update state of every touched source is recorded as one
+// config_changes row under a freshly generated change_id, so the whole
+// batch (not just one source) can be restored in a single RollbackChange
+// call.
+func (r *SourceRepository) BulkUpdate(ctx context.Context, tenantID string, updates []SourceUpdate, actorID string) (uuid.UUID, error) {
+	var changeID uuid.UUID
+	err := r.db.WithTransaction(ctx, func(tx *sqlx.Tx) error {
+		q := sqlcgen.New(tx)
+		preImages := make([]models.ContentSource, 0, len(updates))
+
+		for _, update := range updates {
+			id, err := uuid.Parse(update.ID)
+			if err != nil {
+				return fmt.Errorf("invalid source ID %q: %w", update.ID, err)
+			}
+
+			params := sourceUpdateParams(id, tenantID, update)
+			if params == (sqlcgen.UpdateContentSourceParams{ID: id, TenantID: tenantID}) {
+				continue
+			}
+
+			before, err := q.GetContentSourceByID(ctx, sqlcgen.GetContentSourceByIDParams{ID: id, TenantID: tenantID})
+			if err != nil {
+				return fmt.Errorf("source %s not found", update.ID)
+			}
+
+			rows, err := q.UpdateContentSource(ctx, params)
+			if err != nil {
+				return fmt.Errorf("failed to update source %s: %w", update.ID, err)
+			}
+			if rows == 0 {
+				return fmt.Errorf("source %s not found", update.ID)
+			}
+
+			after, err := q.GetContentSourceByID(ctx, sqlcgen.GetContentSourceByIDParams{ID: id, TenantID: tenantID})
+			if err != nil {
+				return fmt.Errorf("failed to load updated source %s: %w", update.ID, err)
+			}
+			if err := r.recordSourceRevision(ctx, tx, tenantID, id, "update", actorID, &before, &after); err != nil {
+				return err
+			}
+
+			preImages = append(preImages, before)
+		}
 
-	query := "UPDATE content_sources SET "
-	args := []interface{}{}
-	argCount := 0
+		if len(preImages) == 0 {
+			return fmt.Errorf("no fields to update")
+		}
 
-	for field, value := range updates {
-		argCount++
-		if argCount > 1 {
-			query += ", "
+		id, err := recordBulkChange(ctx, tx, tenantID, entityTypeContentSource, actorID, preImages)
+		if err != nil {
+			return err
 		}
-		query += fmt.Sprintf("%s = $%d", field, argCount)
-		args = append(args, value)
+		changeID = id
+		return nil
+	})
+	if err != nil {
+		return uuid.Nil, err
 	}
+	return changeID, nil
+}
 
-	argCount++
-	query += fmt.Sprintf(", updated_at = NOW() WHERE id = $%d", argCount)
-	args = append(args, id)
+// RollbackChange restores every source captured in changeID's pre-image to
+// its state immediately before that BulkUpdate, in a single transaction.
+// Unlike Rollback, which replays one audit_revisions snapshot for one
+// source, this restores the whole batch a bulk update touched as a unit.
+func (r *SourceRepository) RollbackChange(ctx context.Context, tenantID string, changeID uuid.UUID, actorID string) error {
+	return r.db.WithTransaction(ctx, func(tx *sqlx.Tx) error {
+		preImage, err := getBulkChange(ctx, tx, tenantID, entityTypeContentSource, changeID)
+		if err != nil {
+			return err
+		}
 
-	argCount++
-	query += fmt.Sprintf(" AND tenant_id = $%d", argCount)
-	args = append(args, tenantID)
+		var sources []models.ContentSource
+		if err := json.Unmarshal(preImage, &sources); err != nil {
+			return fmt.Errorf("failed to decode pre-image: %w", err)
+		}
 
-	result, err := r.db.ExecContext(ctx, query, args...)
-	if err != nil {
-		return fmt.Errorf("failed to update source: %w", err)
+		q := sqlcgen.New(tx)
+		for _, source := range sources {
+			update := SourceUpdate{
+				ID: source.ID.String(), Name: source.Name, Platform: string(source.Platform),
+				SourceType: source.SourceType, Identifier: source.Identifier, Category: source.Category,
+				Priority: &source.Priority, IngestionCron: source.IngestionCron, Active: &source.Active,
+			}
+			params := sourceUpdateParams(source.ID, tenantID, update)
+
+			before, err := q.GetContentSourceByID(ctx, sqlcgen.GetContentSourceByIDParams{ID: source.ID, TenantID: tenantID})
+			if err != nil {
+				return fmt.Errorf("source %s not found", source.ID)
+			}
+			if _, err := q.UpdateContentSource(ctx, params); err != nil {
+				return fmt.Errorf("failed to restore source %s: %w", source.ID, err)
+			}
+			after, err := q.GetContentSourceByID(ctx, sqlcgen.GetContentSourceByIDParams{ID: source.ID, TenantID: tenantID})
+			if err != nil {
+				return fmt.Errorf("failed to load restored source %s: %w", source.ID, err)
+			}
+			if err := r.recordSourceRevision(ctx, tx, tenantID, source.ID, "update", actorID, &before, &after); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// recordSourceRevision diffs before against after (either may be nil,
+// for create/delete) and writes the resulting audit_revisions row via
+// tx, so it commits atomically with the entity change it describes.
+func (r *SourceRepository) recordSourceRevision(ctx context.Context, tx *sqlx.Tx, tenantID string, id uuid.UUID, action, actorID string, before, after *models.ContentSource) error {
+	var beforeMap, afterMap models.JSONB
+	var err error
+	if before != nil {
+		if beforeMap, err = structToJSONB(before); err != nil {
+			return fmt.Errorf("failed to encode previous source: %w", err)
+		}
+	}
+	if after != nil {
+		if afterMap, err = structToJSONB(after); err != nil {
+			return fmt.Errorf("failed to encode updated source: %w", err)
+		}
 	}
 
-	rows, err := result.RowsAffected()
+	diff, err := json.Marshal(buildJSONPatch(beforeMap, afterMap))
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return fmt.Errorf("failed to encode source diff: %w", err)
 	}
 
-	if rows == 0 {
-		return fmt.Errorf("source not found")
+	snapshot := afterMap
+	if snapshot == nil {
+		snapshot = beforeMap
 	}
 
-	return nil
+	_, err = r.auditRepo.RecordRevision(ctx, tx, tenantID, entityTypeContentSource, id.String(), action, actorID, diff, snapshot)
+	return err
 }
 
-// Create creates a new content source
-func (r *SourceRepository) Create(ctx context.Context, source *models.ContentSource) error {
-	query := `
-		INSERT INTO content_sources (
-			id, tenant_id, name, platform, source_type, identifier, category,
-			priority, ingestion_cron, config, active
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		RETURNING created_at, updated_at
-	`
-
+// Create creates a new content source, recording it as audit_revisions
+// revision 1.
+func (r *SourceRepository) Create(ctx context.Context, source *models.ContentSource, actorID string) error {
 	if source.ID == uuid.Nil {
 		source.ID = uuid.New()
 	}
 
-	err := r.db.QueryRowContext(ctx, query,
-		source.ID, source.TenantID, source.Name, source.Platform, source.SourceType,
-		source.Identifier, source.Category, source.Priority, source.IngestionCron,
-		source.Config, source.Active,
-	).Scan(&source.CreatedAt, &source.UpdatedAt)
-
-	if err != nil {
-		return fmt.Errorf("failed to create source: %w", err)
-	}
+	return r.db.WithTransaction(ctx, func(tx *sqlx.Tx) error {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO content_sources (
+				id, tenant_id, name, platform, source_type, identifier, category,
+				priority, ingestion_cron, config, active
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			RETURNING created_at, updated_at
+		`,
+			source.ID, source.TenantID, source.Name, source.Platform, source.SourceType,
+			source.Identifier, source.Category, source.Priority, source.IngestionCron,
+			source.Config, source.Active,
+		).Scan(&source.CreatedAt, &source.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create source: %w", err)
+		}
 
-	return nil
+		return r.recordSourceRevision(ctx, tx, source.TenantID, source.ID, "create", actorID, nil, source)
+	})
 }
 
-// Delete deletes a content source
-func (r *SourceRepository) Delete(ctx context.Context, tenantID string, sourceID string) error {
+// Delete deletes a content source, recording its final state as a
+// "delete" audit_revisions entry before removing the row.
+func (r *SourceRepository) Delete(ctx context.Context, tenantID string, sourceID string, actorID string) error {
 	id, err := uuid.Parse(sourceID)
 	if err != nil {
 		return fmt.Errorf("invalid source ID: %w", err)
 	}
 
-	query := "DELETE FROM content_sources WHERE id = $1 AND tenant_id = $2"
+	return r.db.WithTransaction(ctx, func(tx *sqlx.Tx) error {
+		before, err := sqlcgen.New(tx).GetContentSourceByID(ctx, sqlcgen.GetContentSourceByIDParams{ID: id, TenantID: tenantID})
+		if err != nil {
+			return fmt.Errorf("source not found")
+		}
+
+		result, err := tx.ExecContext(ctx, "DELETE FROM content_sources WHERE id = $1 AND tenant_id = $2", id, tenantID)
+		if err != nil {
+			return fmt.Errorf("failed to delete source: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("source not found")
+		}
+
+		return r.recordSourceRevision(ctx, tx, tenantID, id, "delete", actorID, &before, nil)
+	})
+}
 
-	result, err := r.db.ExecContext(ctx, query, id, tenantID)
+// Rollback restores a content source to the field values recorded at
+// revision, by replaying that snapshot through Update. As with
+// ConfigRepository.Rollback, this keeps a rollback on the normal
+// revisioning path - it produces a new revision rather than rewriting
+// history.
+func (r *SourceRepository) Rollback(ctx context.Context, tenantID, sourceID string, revision int, actorID string) (*EntityRevision, error) {
+	target, err := r.auditRepo.GetRevision(ctx, tenantID, entityTypeContentSource, sourceID, revision)
 	if err != nil {
-		return fmt.Errorf("failed to delete source: %w", err)
+		return nil, fmt.Errorf("failed to load revision %d: %w", revision, err)
 	}
 
-	rows, err := result.RowsAffected()
+	b, err := json.Marshal(target.Snapshot)
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return nil, fmt.Errorf("failed to decode revision snapshot: %w", err)
+	}
+	var update SourceUpdate
+	if err := json.Unmarshal(b, &update); err != nil {
+		return nil, fmt.Errorf("failed to decode revision snapshot: %w", err)
 	}
 
-	if rows == 0 {
-		return fmt.Errorf("source not found")
+	if err := r.Update(ctx, tenantID, sourceID, update, actorID); err != nil {
+		return nil, fmt.Errorf("failed to roll back source: %w", err)
 	}
 
-	return nil
+	revisions, _, err := r.auditRepo.ListRevisions(ctx, tenantID, entityTypeContentSource, sourceID, 1, 1)
+	if err != nil || len(revisions) == 0 {
+		return nil, fmt.Errorf("failed to load resulting revision: %w", err)
+	}
+	return r.auditRepo.GetRevision(ctx, tenantID, entityTypeContentSource, sourceID, revisions[0].Revision)
+}
+
+// claimLeaseDuration bounds how long ClaimDue holds a source before it
+// becomes claimable again, so a worker that crashes mid-ingestion
+// doesn't strand the source past its next legitimate run.
+const claimLeaseDuration = 5 * time.Minute
+
+// ClaimDue selects up to limit active sources for tenantID whose
+// next_ingestion_at has passed (or was never set), and atomically pushes
+// their next_ingestion_at out by claimLeaseDuration so a concurrent
+// scheduler worker won't also claim them. Satisfies
+// ingestion.SourceStore.
+func (r *SourceRepository) ClaimDue(ctx context.Context, tenantID string, now time.Time, limit int) ([]models.ContentSource, error) {
+	var claimed []models.ContentSource
+	err := r.db.WithTransaction(ctx, func(tx *sqlx.Tx) error {
+		q := sqlcgen.New(tx)
+		sources, err := q.ClaimDueSources(ctx, sqlcgen.ClaimDueSourcesParams{TenantID: tenantID, Now: now, Limit: limit})
+		if err != nil {
+			return fmt.Errorf("failed to select due sources: %w", err)
+		}
+		if len(sources) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(sources))
+		for i, s := range sources {
+			ids[i] = s.ID
+		}
+		if _, err := q.MarkSourcesClaimed(ctx, now.Add(claimLeaseDuration), ids); err != nil {
+			return fmt.Errorf("failed to claim due sources: %w", err)
+		}
+
+		claimed = sources
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+// RecentIngestionCounts returns items_found for source's last limit
+// ingestion runs, most recent first. Satisfies ingestion.SourceStore.
+func (r *SourceRepository) RecentIngestionCounts(ctx context.Context, sourceID uuid.UUID, limit int) ([]int, error) {
+	counts, err := sqlcgen.New(r.db).RecentIngestionCounts(ctx, sourceID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ingestion history: %w", err)
+	}
+	return counts, nil
+}
+
+// RecordIngestionOutcome persists the result of one ingestion run for
+// source: its running counters, last_error, and a new next_ingestion_at
+// computed as source.LastIngested (now) plus nextInterval, plus an
+// ingestion_history row for observability. Satisfies
+// ingestion.SourceStore.
+func (r *SourceRepository) RecordIngestionOutcome(ctx context.Context, source models.ContentSource, itemsFound int, ingestErr error, nextInterval time.Duration) error {
+	errMsg := ""
+	if ingestErr != nil {
+		errMsg = ingestErr.Error()
+	}
+
+	return sqlcgen.New(r.db).RecordIngestionOutcome(ctx, sqlcgen.RecordIngestionOutcomeParams{
+		ItemsFound:      itemsFound,
+		HadError:        ingestErr != nil,
+		ErrorMessage:    errMsg,
+		NextIngestionAt: time.Now().Add(nextInterval),
+		ID:              source.ID,
+		TenantID:        source.TenantID,
+		IntervalUsed:    int64(nextInterval),
+	})
 }
 
 // TemplateRepository handles video template database operations
 type TemplateRepository struct {
-	db *DB
+	db        *DB
+	auditRepo *AuditRepository
 }
 
-// NewTemplateRepository creates a new template repository
-func NewTemplateRepository(db *DB) *TemplateRepository {
-	return &TemplateRepository{db: db}
+// NewTemplateRepository creates a new template repository. auditRepo
+// records an audit_revisions row alongside every Create/Update/Delete,
+// the same as SourceRepository does for content sources.
+func NewTemplateRepository(db *DB, auditRepo *AuditRepository) *TemplateRepository {
+	return &TemplateRepository{db: db, auditRepo: auditRepo}
 }
 
+// entityTypeVideoTemplate is this package's audit_revisions entity_type
+// for video templates.
+const entityTypeVideoTemplate = "video_template"
+
 // List retrieves all video templates for a tenant
 func (r *TemplateRepository) List(ctx context.Context, tenantID string, opts TemplateListOptions) ([]models.VideoTemplate, error) {
 	query := `
@@ -384,137 +1219,311 @@ func (r *TemplateRepository) List(ctx context.Context, tenantID string, opts Tem
 	return templates, nil
 }
 
-// Update updates an existing video template
-func (r *TemplateRepository) Update(ctx context.Context, tenantID string, templateID string, update TemplateUpdate) error {
+// Update updates an existing video template, recording the change as a
+// new audit_revisions entry diffed against the template as it stood
+// immediately before this call. actorID identifies the caller (see
+// middleware.GetUserID) for the revision's audit trail.
+func (r *TemplateRepository) Update(ctx context.Context, tenantID string, templateID string, update TemplateUpdate, actorID string) error {
 	id, err := uuid.Parse(templateID)
 	if err != nil {
 		return fmt.Errorf("invalid template ID: %w", err)
 	}
 
-	updates := make(map[string]interface{})
+	params := templateUpdateParams(id, tenantID, update)
+	if params == (sqlcgen.UpdateVideoTemplateParams{ID: id, TenantID: tenantID}) {
+		return nil
+	}
+
+	return r.db.WithTransaction(ctx, func(tx *sqlx.Tx) error {
+		q := sqlcgen.New(tx)
+
+		before, err := q.GetVideoTemplateByID(ctx, sqlcgen.GetVideoTemplateByIDParams{ID: id, TenantID: tenantID})
+		if err != nil {
+			return fmt.Errorf("template not found")
+		}
+
+		rows, err := q.UpdateVideoTemplate(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to update template: %w", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("template not found")
+		}
+
+		after, err := q.GetVideoTemplateByID(ctx, sqlcgen.GetVideoTemplateByIDParams{ID: id, TenantID: tenantID})
+		if err != nil {
+			return fmt.Errorf("failed to load updated template: %w", err)
+		}
+
+		return r.recordTemplateRevision(ctx, tx, tenantID, id, "update", actorID, &before, &after)
+	})
+}
+
+// templateUpdateParams converts update's set fields into a
+// sqlcgen.UpdateVideoTemplateParams, leaving every unset field (left nil)
+// to COALESCE to its current column value. Shared by Update and
+// BulkUpdate so a single-template update and a batched one build
+// identical params.
+func templateUpdateParams(id uuid.UUID, tenantID string, update TemplateUpdate) sqlcgen.UpdateVideoTemplateParams {
+	params := sqlcgen.UpdateVideoTemplateParams{ID: id, TenantID: tenantID}
 	if update.Name != "" {
-		updates["name"] = update.Name
+		params.Name = &update.Name
 	}
 	if update.Description != "" {
-		updates["description"] = update.Description
+		params.Description = &update.Description
 	}
 	if update.Category != "" {
-		updates["category"] = update.Category
+		params.Category = &update.Category
 	}
 	if update.VoiceID != "" {
-		updates["voice_id"] = update.VoiceID
+		params.VoiceID = &update.VoiceID
 	}
 	if update.AvatarID != "" {
-		updates["avatar_id"] = update.AvatarID
+		params.AvatarID = &update.AvatarID
 	}
 	if update.Resolution != "" {
-		updates["resolution"] = update.Resolution
+		params.Resolution = &update.Resolution
 	}
 	if update.Duration != nil {
-		updates["duration"] = *update.Duration
+		params.Duration = update.Duration
 	}
 	if update.IsDefault != nil {
-		updates["is_default"] = *update.IsDefault
+		params.IsDefault = update.IsDefault
 	}
 	if update.Active != nil {
-		updates["active"] = *update.Active
+		params.Active = update.Active
 	}
+	return params
+}
 
-	if len(updates) == 0 {
-		return nil
-	}
+// BulkUpdate applies every update in a single transaction, rolling back
+// all of them if any one fails - unlike calling Update in a loop, which
+// can leave a tenant half-applied when a later item errors. The
+// pre-update state of every touched template is recorded as one
+// config_changes row under a freshly generated change_id, so the whole
+// batch (not just one template) can be restored in a single
+// RollbackChange call.
+func (r *TemplateRepository) BulkUpdate(ctx context.Context, tenantID string, updates []TemplateUpdate, actorID string) (uuid.UUID, error) {
+	var changeID uuid.UUID
+	err := r.db.WithTransaction(ctx, func(tx *sqlx.Tx) error {
+		q := sqlcgen.New(tx)
+		preImages := make([]models.VideoTemplate, 0, len(updates))
+
+		for _, update := range updates {
+			id, err := uuid.Parse(update.ID)
+			if err != nil {
+				return fmt.Errorf("invalid template ID %q: %w", update.ID, err)
+			}
+
+			params := templateUpdateParams(id, tenantID, update)
+			if params == (sqlcgen.UpdateVideoTemplateParams{ID: id, TenantID: tenantID}) {
+				continue
+			}
+
+			before, err := q.GetVideoTemplateByID(ctx, sqlcgen.GetVideoTemplateByIDParams{ID: id, TenantID: tenantID})
+			if err != nil {
+				return fmt.Errorf("template %s not found", update.ID)
+			}
+
+			rows, err := q.UpdateVideoTemplate(ctx, params)
+			if err != nil {
+				return fmt.Errorf("failed to update template %s: %w", update.ID, err)
+			}
+			if rows == 0 {
+				return fmt.Errorf("template %s not found", update.ID)
+			}
+
+			after, err := q.GetVideoTemplateByID(ctx, sqlcgen.GetVideoTemplateByIDParams{ID: id, TenantID: tenantID})
+			if err != nil {
+				return fmt.Errorf("failed to load updated template %s: %w", update.ID, err)
+			}
+			if err := r.recordTemplateRevision(ctx, tx, tenantID, id, "update", actorID, &before, &after); err != nil {
+				return err
+			}
+
+			preImages = append(preImages, before)
+		}
 
-	query := "UPDATE video_templates SET "
-	args := []interface{}{}
-	argCount := 0
+		if len(preImages) == 0 {
+			return fmt.Errorf("no fields to update")
+		}
 
-	for field, value := range updates {
-		argCount++
-		if argCount > 1 {
-			query += ", "
+		id, err := recordBulkChange(ctx, tx, tenantID, entityTypeVideoTemplate, actorID, preImages)
+		if err != nil {
+			return err
 		}
-		query += fmt.Sprintf("%s = $%d", field, argCount)
-		args = append(args, value)
+		changeID = id
+		return nil
+	})
+	if err != nil {
+		return uuid.Nil, err
 	}
+	return changeID, nil
+}
+
+// RollbackChange restores every template captured in changeID's pre-image
+// to its state immediately before that BulkUpdate, in a single
+// transaction. Unlike Rollback, which replays one audit_revisions
+// snapshot for one template, this restores the whole batch a bulk update
+// touched as a unit.
+func (r *TemplateRepository) RollbackChange(ctx context.Context, tenantID string, changeID uuid.UUID, actorID string) error {
+	return r.db.WithTransaction(ctx, func(tx *sqlx.Tx) error {
+		preImage, err := getBulkChange(ctx, tx, tenantID, entityTypeVideoTemplate, changeID)
+		if err != nil {
+			return err
+		}
 
-	argCount++
-	query += fmt.Sprintf(", updated_at = NOW() WHERE id = $%d", argCount)
-	args = append(args, id)
+		var templates []models.VideoTemplate
+		if err := json.Unmarshal(preImage, &templates); err != nil {
+			return fmt.Errorf("failed to decode pre-image: %w", err)
+		}
 
-	argCount++
-	query += fmt.Sprintf(" AND tenant_id = $%d", argCount)
-	args = append(args, tenantID)
+		q := sqlcgen.New(tx)
+		for _, template := range templates {
+			update := TemplateUpdate{
+				ID: template.ID.String(), Name: template.Name, Description: template.Description,
+				Category: template.Category, VoiceID: template.VoiceID, AvatarID: template.AvatarID,
+				Resolution: template.Resolution, Duration: &template.Duration,
+				IsDefault: &template.IsDefault, Active: &template.Active,
+			}
+			params := templateUpdateParams(template.ID, tenantID, update)
+
+			before, err := q.GetVideoTemplateByID(ctx, sqlcgen.GetVideoTemplateByIDParams{ID: template.ID, TenantID: tenantID})
+			if err != nil {
+				return fmt.Errorf("template %s not found", template.ID)
+			}
+			if _, err := q.UpdateVideoTemplate(ctx, params); err != nil {
+				return fmt.Errorf("failed to restore template %s: %w", template.ID, err)
+			}
+			after, err := q.GetVideoTemplateByID(ctx, sqlcgen.GetVideoTemplateByIDParams{ID: template.ID, TenantID: tenantID})
+			if err != nil {
+				return fmt.Errorf("failed to load restored template %s: %w", template.ID, err)
+			}
+			if err := r.recordTemplateRevision(ctx, tx, tenantID, template.ID, "update", actorID, &before, &after); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
 
-	result, err := r.db.ExecContext(ctx, query, args...)
-	if err != nil {
-		return fmt.Errorf("failed to update template: %w", err)
+// recordTemplateRevision diffs before against after (either may be nil,
+// for create/delete) and writes the resulting audit_revisions row via
+// tx, so it commits atomically with the entity change it describes.
+func (r *TemplateRepository) recordTemplateRevision(ctx context.Context, tx *sqlx.Tx, tenantID string, id uuid.UUID, action, actorID string, before, after *models.VideoTemplate) error {
+	var beforeMap, afterMap models.JSONB
+	var err error
+	if before != nil {
+		if beforeMap, err = structToJSONB(before); err != nil {
+			return fmt.Errorf("failed to encode previous template: %w", err)
+		}
+	}
+	if after != nil {
+		if afterMap, err = structToJSONB(after); err != nil {
+			return fmt.Errorf("failed to encode updated template: %w", err)
+		}
 	}
 
-	rows, err := result.RowsAffected()
+	diff, err := json.Marshal(buildJSONPatch(beforeMap, afterMap))
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return fmt.Errorf("failed to encode template diff: %w", err)
 	}
 
-	if rows == 0 {
-		return fmt.Errorf("template not found")
+	snapshot := afterMap
+	if snapshot == nil {
+		snapshot = beforeMap
 	}
 
-	return nil
+	_, err = r.auditRepo.RecordRevision(ctx, tx, tenantID, entityTypeVideoTemplate, id.String(), action, actorID, diff, snapshot)
+	return err
 }
 
-// Create creates a new video template
-func (r *TemplateRepository) Create(ctx context.Context, template *models.VideoTemplate) error {
-	query := `
-		INSERT INTO video_templates (
-			id, tenant_id, name, description, category, voice_id, avatar_id,
-			resolution, duration, intro_script, outro_script, music_track,
-			watermark_url, config, is_default, active
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
-		RETURNING created_at, updated_at
-	`
-
+// Create creates a new video template, recording it as audit_revisions
+// revision 1.
+func (r *TemplateRepository) Create(ctx context.Context, template *models.VideoTemplate, actorID string) error {
 	if template.ID == uuid.Nil {
 		template.ID = uuid.New()
 	}
 
-	err := r.db.QueryRowContext(ctx, query,
-		template.ID, template.TenantID, template.Name, template.Description, template.Category,
-		template.VoiceID, template.AvatarID, template.Resolution, template.Duration,
-		template.IntroScript, template.OutroScript, template.MusicTrack, template.WatermarkURL,
-		template.Config, template.IsDefault, template.Active,
-	).Scan(&template.CreatedAt, &template.UpdatedAt)
-
-	if err != nil {
-		return fmt.Errorf("failed to create template: %w", err)
-	}
+	return r.db.WithTransaction(ctx, func(tx *sqlx.Tx) error {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO video_templates (
+				id, tenant_id, name, description, category, voice_id, avatar_id,
+				resolution, duration, intro_script, outro_script, music_track,
+				watermark_url, config, is_default, active
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+			RETURNING created_at, updated_at
+		`,
+			template.ID, template.TenantID, template.Name, template.Description, template.Category,
+			template.VoiceID, template.AvatarID, template.Resolution, template.Duration,
+			template.IntroScript, template.OutroScript, template.MusicTrack, template.WatermarkURL,
+			template.Config, template.IsDefault, template.Active,
+		).Scan(&template.CreatedAt, &template.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create template: %w", err)
+		}
 
-	return nil
+		return r.recordTemplateRevision(ctx, tx, template.TenantID, template.ID, "create", actorID, nil, template)
+	})
 }
 
-// Delete deletes a video template
-func (r *TemplateRepository) Delete(ctx context.Context, tenantID string, templateID string) error {
+// Delete deletes a video template, recording its final state as a
+// "delete" audit_revisions entry before removing the row.
+func (r *TemplateRepository) Delete(ctx context.Context, tenantID string, templateID string, actorID string) error {
 	id, err := uuid.Parse(templateID)
 	if err != nil {
 		return fmt.Errorf("invalid template ID: %w", err)
 	}
 
-	query := "DELETE FROM video_templates WHERE id = $1 AND tenant_id = $2"
+	return r.db.WithTransaction(ctx, func(tx *sqlx.Tx) error {
+		before, err := sqlcgen.New(tx).GetVideoTemplateByID(ctx, sqlcgen.GetVideoTemplateByIDParams{ID: id, TenantID: tenantID})
+		if err != nil {
+			return fmt.Errorf("template not found")
+		}
+
+		result, err := tx.ExecContext(ctx, "DELETE FROM video_templates WHERE id = $1 AND tenant_id = $2", id, tenantID)
+		if err != nil {
+			return fmt.Errorf("failed to delete template: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("template not found")
+		}
+
+		return r.recordTemplateRevision(ctx, tx, tenantID, id, "delete", actorID, &before, nil)
+	})
+}
 
-	result, err := r.db.ExecContext(ctx, query, id, tenantID)
+// Rollback restores a video template to the field values recorded at
+// revision, by replaying that snapshot through Update. Mirrors
+// SourceRepository.Rollback / ConfigRepository.Rollback.
+func (r *TemplateRepository) Rollback(ctx context.Context, tenantID, templateID string, revision int, actorID string) (*EntityRevision, error) {
+	target, err := r.auditRepo.GetRevision(ctx, tenantID, entityTypeVideoTemplate, templateID, revision)
 	if err != nil {
-		return fmt.Errorf("failed to delete template: %w", err)
+		return nil, fmt.Errorf("failed to load revision %d: %w", revision, err)
 	}
 
-	rows, err := result.RowsAffected()
+	b, err := json.Marshal(target.Snapshot)
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return nil, fmt.Errorf("failed to decode revision snapshot: %w", err)
+	}
+	var update TemplateUpdate
+	if err := json.Unmarshal(b, &update); err != nil {
+		return nil, fmt.Errorf("failed to decode revision snapshot: %w", err)
 	}
 
-	if rows == 0 {
-		return fmt.Errorf("template not found")
+	if err := r.Update(ctx, tenantID, templateID, update, actorID); err != nil {
+		return nil, fmt.Errorf("failed to roll back template: %w", err)
 	}
 
-	return nil
+	revisions, _, err := r.auditRepo.ListRevisions(ctx, tenantID, entityTypeVideoTemplate, templateID, 1, 1)
+	if err != nil || len(revisions) == 0 {
+		return nil, fmt.Errorf("failed to load resulting revision: %w", err)
+	}
+	return r.auditRepo.GetRevision(ctx, tenantID, entityTypeVideoTemplate, templateID, revisions[0].Revision)
 }
 
 // AnalyticsRepository handles analytics database operations
@@ -527,240 +1536,175 @@ func NewAnalyticsRepository(db *DB) *AnalyticsRepository {
 	return &AnalyticsRepository{db: db}
 }
 
-// GetOverview retrieves overview statistics for dashboard
+// GetOverview retrieves overview statistics for dashboard from the
+// analytics_daily/creator_tier_daily rollups rather than scanning
+// content/creators directly, so the dashboard stays fast as a tenant's
+// content table grows. Rollups must be kept current by RefreshRollups;
+// see RebuildRollups for backfilling a range on demand.
 func (r *AnalyticsRepository) GetOverview(ctx context.Context, tenantID string, days int) (map[string]interface{}, error) {
 	stats := make(map[string]interface{})
+	q := sqlcgen.New(r.db)
 
-	// Total content
-	var totalContent int
-	err := r.db.QueryRowContext(ctx,
-		"SELECT COUNT(*) FROM content WHERE tenant_id = $1", tenantID,
-	).Scan(&totalContent)
+	allTime, err := q.ContentTotals(ctx, sqlcgen.ContentTotalsParams{TenantID: tenantID})
 	if err != nil {
-		return nil, fmt.Errorf("failed to count content: %w", err)
+		return nil, fmt.Errorf("failed to total content: %w", err)
 	}
-	stats["total_content"] = totalContent
+	stats["total_content"] = allTime.TotalItems
+	stats["total_views"] = allTime.TotalViews
 
-	// Content in time period
-	var periodContent int
-	err = r.db.QueryRowContext(ctx,
-		"SELECT COUNT(*) FROM content WHERE tenant_id = $1 AND created_at > NOW() - $2::interval",
-		tenantID, fmt.Sprintf("%d days", days),
-	).Scan(&periodContent)
+	since := time.Now().AddDate(0, 0, -days)
+	period, err := q.ContentTotals(ctx, sqlcgen.ContentTotalsParams{TenantID: tenantID, Since: &since})
 	if err != nil {
-		return nil, fmt.Errorf("failed to count period content: %w", err)
+		return nil, fmt.Errorf("failed to total period content: %w", err)
 	}
-	stats["content_this_period"] = periodContent
+	stats["content_this_period"] = period.TotalItems
+	stats["views_this_period"] = period.TotalViews
 
-	// Calculate growth percentage
-	if totalContent > 0 && periodContent > 0 {
-		stats["content_growth"] = float64(periodContent) / float64(totalContent) * 100
+	if allTime.TotalItems > 0 && period.TotalItems > 0 {
+		stats["content_growth"] = float64(period.TotalItems) / float64(allTime.TotalItems) * 100
 	} else {
 		stats["content_growth"] = 0.0
 	}
 
-	// Total creators
+	// Total/verified creators and video counts aren't rolled up - they're
+	// cheap point lookups even at scale, unlike the COUNT(*)/SUM(view_count)
+	// scans over content this repository used to run.
 	var totalCreators int
-	err = r.db.QueryRowContext(ctx,
+	if err := r.db.QueryRowContext(ctx,
 		"SELECT COUNT(*) FROM creators WHERE tenant_id = $1 AND active = true", tenantID,
-	).Scan(&totalCreators)
-	if err != nil {
+	).Scan(&totalCreators); err != nil {
 		return nil, fmt.Errorf("failed to count creators: %w", err)
 	}
 	stats["total_creators"] = totalCreators
 
-	// Verified creators
 	var verifiedCreators int
-	err = r.db.QueryRowContext(ctx,
+	if err := r.db.QueryRowContext(ctx,
 		"SELECT COUNT(*) FROM creators WHERE tenant_id = $1 AND verified_at IS NOT NULL", tenantID,
-	).Scan(&verifiedCreators)
-	if err != nil {
+	).Scan(&verifiedCreators); err != nil {
 		return nil, fmt.Errorf("failed to count verified creators: %w", err)
 	}
 	stats["verified_creators"] = verifiedCreators
 
-	// Total videos
 	var totalVideos int
-	err = r.db.QueryRowContext(ctx,
+	if err := r.db.QueryRowContext(ctx,
 		"SELECT COUNT(*) FROM video_summaries WHERE tenant_id = $1", tenantID,
-	).Scan(&totalVideos)
-	if err != nil {
+	).Scan(&totalVideos); err != nil {
 		return nil, fmt.Errorf("failed to count videos: %w", err)
 	}
 	stats["total_videos"] = totalVideos
 
-	// Videos in time period
 	var periodVideos int
-	err = r.db.QueryRowContext(ctx,
+	if err := r.db.QueryRowContext(ctx,
 		"SELECT COUNT(*) FROM video_summaries WHERE tenant_id = $1 AND created_at > NOW() - $2::interval",
 		tenantID, fmt.Sprintf("%d days", days),
-	).Scan(&periodVideos)
-	if err != nil {
+	).Scan(&periodVideos); err != nil {
 		return nil, fmt.Errorf("failed to count period videos: %w", err)
 	}
 	stats["videos_this_period"] = periodVideos
 
-	// Total views
-	var totalViews sql.NullInt64
-	err = r.db.QueryRowContext(ctx,
-		"SELECT SUM(view_count) FROM content WHERE tenant_id = $1", tenantID,
-	).Scan(&totalViews)
-	if err != nil {
-		return nil, fmt.Errorf("failed to sum views: %w", err)
-	}
-	stats["total_views"] = totalViews.Int64
-
-	// Views in time period
-	var periodViews sql.NullInt64
-	err = r.db.QueryRowContext(ctx,
-		"SELECT SUM(view_count) FROM content WHERE tenant_id = $1 AND created_at > NOW() - $2::interval",
-		tenantID, fmt.Sprintf("%d days", days),
-	).Scan(&periodViews)
-	if err != nil {
-		return nil, fmt.Errorf("failed to sum period views: %w", err)
-	}
-	stats["views_this_period"] = periodViews.Int64
-
-	// Get top categories
 	categories, _ := r.getCategoryBreakdown(ctx, tenantID)
 	stats["top_categories"] = categories
 
-	// Get platform breakdown
 	platforms, _ := r.getPlatformBreakdown(ctx, tenantID)
 	stats["platform_breakdown"] = platforms
 
 	return stats, nil
 }
 
-// getCategoryBreakdown retrieves content count by category
+// getCategoryBreakdown retrieves all-time content count by category from
+// analytics_daily.
 func (r *AnalyticsRepository) getCategoryBreakdown(ctx context.Context, tenantID string) ([]map[string]interface{}, error) {
-	query := `
-		SELECT category, COUNT(*) as count
-		FROM content
-		WHERE tenant_id = $1 AND category IS NOT NULL AND category != ''
-		GROUP BY category
-		ORDER BY count DESC
-		LIMIT 10
-	`
-
-	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	rows, err := sqlcgen.New(r.db).CategoryBreakdown(ctx, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query category breakdown: %w", err)
 	}
-	defer rows.Close()
 
-	var breakdown []map[string]interface{}
-	for rows.Next() {
-		var category string
-		var count int
-		if err := rows.Scan(&category, &count); err != nil {
-			continue
-		}
+	breakdown := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
 		breakdown = append(breakdown, map[string]interface{}{
-			"name":  category,
-			"count": count,
+			"name":  row.Category,
+			"count": row.Count,
 		})
 	}
-
 	return breakdown, nil
 }
 
-// getPlatformBreakdown retrieves content count by platform
+// getPlatformBreakdown retrieves all-time content count by platform from
+// analytics_daily.
 func (r *AnalyticsRepository) getPlatformBreakdown(ctx context.Context, tenantID string) ([]map[string]interface{}, error) {
-	query := `
-		SELECT platform, COUNT(*) as count
-		FROM content
-		WHERE tenant_id = $1
-		GROUP BY platform
-		ORDER BY count DESC
-	`
-
-	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	rows, err := sqlcgen.New(r.db).PlatformBreakdown(ctx, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query platform breakdown: %w", err)
 	}
-	defer rows.Close()
 
-	var breakdown []map[string]interface{}
-	for rows.Next() {
-		var platform string
-		var count int
-		if err := rows.Scan(&platform, &count); err != nil {
-			continue
-		}
+	breakdown := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
 		breakdown = append(breakdown, map[string]interface{}{
-			"platform": platform,
-			"count":    count,
+			"platform": row.Platform,
+			"count":    row.Count,
 		})
 	}
-
 	return breakdown, nil
 }
 
-// GetContentAnalytics retrieves content-specific analytics
+// GetContentAnalytics retrieves content-specific analytics from
+// analytics_daily, with quality/sentiment weighted by each day's item
+// count so combining days doesn't bias toward low-volume days.
 func (r *AnalyticsRepository) GetContentAnalytics(ctx context.Context, tenantID string, opts ContentAnalyticsOptions) (map[string]interface{}, error) {
 	analytics := make(map[string]interface{})
 
-	// Build base query with filters
-	whereClause := "tenant_id = $1"
-	args := []interface{}{tenantID}
-	argCount := 1
-
+	params := sqlcgen.ContentTotalsParams{TenantID: tenantID}
 	if opts.Category != "" {
-		argCount++
-		whereClause += fmt.Sprintf(" AND category = $%d", argCount)
-		args = append(args, opts.Category)
+		params.Category = &opts.Category
 	}
-
 	if opts.Platform != "" {
-		argCount++
-		whereClause += fmt.Sprintf(" AND platform = $%d", argCount)
-		args = append(args, opts.Platform)
+		params.Platform = &opts.Platform
 	}
-
 	if opts.Days > 0 {
-		argCount++
-		whereClause += fmt.Sprintf(" AND created_at > NOW() - $%d::interval", argCount)
-		args = append(args, fmt.Sprintf("%d days", opts.Days))
+		since := time.Now().AddDate(0, 0, -opts.Days)
+		params.Since = &since
 	}
 
-	// Total items
-	var totalItems int
-	err := r.db.QueryRowContext(ctx,
-		fmt.Sprintf("SELECT COUNT(*) FROM content WHERE %s", whereClause), args...,
-	).Scan(&totalItems)
+	totals, err := sqlcgen.New(r.db).ContentTotals(ctx, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to count content: %w", err)
+		return nil, fmt.Errorf("failed to total content analytics: %w", err)
 	}
-	analytics["total_items"] = totalItems
 
-	// Average quality score
-	var avgQuality sql.NullFloat64
-	err = r.db.QueryRowContext(ctx,
-		fmt.Sprintf("SELECT AVG(quality_score) FROM content WHERE %s", whereClause), args...,
-	).Scan(&avgQuality)
-	if err != nil {
-		return nil, fmt.Errorf("failed to avg quality: %w", err)
+	analytics["total_items"] = totals.TotalItems
+	analytics["avg_quality_score"] = totals.AvgQuality
+	analytics["avg_sentiment"] = totals.AvgSentiment
+	analytics["metrics"] = map[string]interface{}{
+		"total_items":       totals.TotalItems,
+		"avg_quality_score": totals.AvgQuality,
+		"avg_sentiment":     totals.AvgSentiment,
 	}
-	analytics["avg_quality_score"] = avgQuality.Float64
 
-	// Average sentiment
-	var avgSentiment sql.NullFloat64
-	err = r.db.QueryRowContext(ctx,
-		fmt.Sprintf("SELECT AVG(sentiment_score) FROM content WHERE %s", whereClause), args...,
-	).Scan(&avgSentiment)
-	if err != nil {
-		return nil, fmt.Errorf("failed to avg sentiment: %w", err)
-	}
-	analytics["avg_sentiment"] = avgSentiment.Float64
+	return analytics, nil
+}
 
-	analytics["metrics"] = map[string]interface{}{
-		"total_items":       totalItems,
-		"avg_quality_score": avgQuality.Float64,
-		"avg_sentiment":     avgSentiment.Float64,
+// RefreshRollups recomputes analytics_daily for content created in
+// [from, to) and takes a fresh creator_tier_daily snapshot for to's day,
+// folding the recomputed buckets into any existing rows via the
+// generated queries' ON CONFLICT upsert. Callers - a periodic background
+// job, or RebuildRollups - decide the window; this does no range
+// bookkeeping of its own.
+func (r *AnalyticsRepository) RefreshRollups(ctx context.Context, tenantID string, from, to time.Time) error {
+	q := sqlcgen.New(r.db)
+	if err := q.RefreshAnalyticsDaily(ctx, sqlcgen.RefreshAnalyticsDailyParams{TenantID: tenantID, From: from, To: to}); err != nil {
+		return fmt.Errorf("failed to refresh analytics_daily: %w", err)
 	}
+	if err := q.RefreshCreatorTierDaily(ctx, tenantID, to); err != nil {
+		return fmt.Errorf("failed to refresh creator_tier_daily: %w", err)
+	}
+	return nil
+}
 
-	return analytics, nil
+// RebuildRollups is the admin-triggered equivalent of RefreshRollups,
+// for backfilling a tenant's rollups over an arbitrary historical range
+// (e.g. after onboarding a tenant with pre-existing content, or
+// recovering from a gap in the background refresh job).
+func (r *AnalyticsRepository) RebuildRollups(ctx context.Context, tenantID string, from, to time.Time) error {
+	return r.RefreshRollups(ctx, tenantID, from, to)
 }
 
 // GetCreatorAnalytics retrieves creator-specific analytics
@@ -800,41 +1744,21 @@ func (r *AnalyticsRepository) GetCreatorAnalytics(ctx context.Context, tenantID
 	return analytics, nil
 }
 
-// getCreatorTierBreakdown retrieves creator count by tier
+// getCreatorTierBreakdown retrieves creator count by tier as of the most
+// recent creator_tier_daily snapshot, rather than grouping the live
+// creators table on every call.
 func (r *AnalyticsRepository) getCreatorTierBreakdown(ctx context.Context, tenantID string) ([]map[string]interface{}, error) {
-	query := `
-		SELECT tier, COUNT(*) as count
-		FROM creators
-		WHERE tenant_id = $1 AND active = true
-		GROUP BY tier
-		ORDER BY
-			CASE tier
-				WHEN 'platinum' THEN 1
-				WHEN 'gold' THEN 2
-				WHEN 'silver' THEN 3
-				WHEN 'bronze' THEN 4
-				ELSE 5
-			END
-	`
-
-	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	rows, err := sqlcgen.New(r.db).LatestCreatorTierBreakdown(ctx, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query tier breakdown: %w", err)
 	}
-	defer rows.Close()
 
-	var breakdown []map[string]interface{}
-	for rows.Next() {
-		var tier string
-		var count int
-		if err := rows.Scan(&tier, &count); err != nil {
-			continue
-		}
+	breakdown := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
 		breakdown = append(breakdown, map[string]interface{}{
-			"tier":  tier,
-			"count": count,
+			"tier":  row.Tier,
+			"count": row.CreatorCount,
 		})
 	}
-
 	return breakdown, nil
 }
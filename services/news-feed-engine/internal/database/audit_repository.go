@@ -0,0 +1,360 @@
+// Package database provides the audit event repository
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+// AuditRepository persists audit_events rows and serves the filtered,
+// cursor-paginated queries operators use to investigate who changed
+// what. The table is partitioned by month (see audit.RetentionJob);
+// that partitioning is transparent to every query here.
+type AuditRepository struct {
+	db *DB
+}
+
+// NewAuditRepository creates a new audit event repository.
+func NewAuditRepository(db *DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// InsertBatch writes events in a single multi-row INSERT. It's built
+// for audit.PostgresLogger's background flusher, which batches many
+// events into one round trip rather than writing them one at a time.
+func (r *AuditRepository) InsertBatch(ctx context.Context, events []models.AuditEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	var query strings.Builder
+	query.WriteString(`INSERT INTO audit_events (id, tenant_id, actor_id, actor_type, action, target_type, target_id, before, after, ip, user_agent, created_at) VALUES `)
+
+	args := make([]interface{}, 0, len(events)*12)
+	for i, event := range events {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		base := i * 12
+		fmt.Fprintf(&query, "($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+			base+1, base+2, base+3, base+4, base+5, base+6, base+7, base+8, base+9, base+10, base+11, base+12)
+		args = append(args, event.ID, event.TenantID, event.ActorID, event.ActorType, event.Action,
+			event.TargetType, event.TargetID, event.Before, event.After, event.IP, event.UserAgent, event.CreatedAt)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query.String(), args...); err != nil {
+		return fmt.Errorf("failed to insert audit events: %w", err)
+	}
+	return nil
+}
+
+// AuditQuery filters AuditRepository.Query. TenantID is required; every
+// other field is unfiltered when left zero-valued. From/To bound
+// CreatedAt. Cursor, when set, continues a previous page (see AuditPage).
+type AuditQuery struct {
+	ActorID    string
+	TargetType string
+	TargetID   string
+	From       time.Time
+	To         time.Time
+	Limit      int
+	Cursor     string
+}
+
+// AuditPage is one page of AuditRepository.Query results, newest first.
+// NextCursor is empty once there's nothing left to fetch.
+type AuditPage struct {
+	Events     []models.AuditEvent
+	NextCursor string
+}
+
+// Query returns tenantID's audit events matching q, newest first,
+// keyset-paginated on (created_at, id) rather than OFFSET - the table
+// is append-only and can grow very large, so a later page should cost
+// the same as an early one instead of scanning and discarding rows.
+func (r *AuditRepository) Query(ctx context.Context, tenantID string, q AuditQuery) (AuditPage, error) {
+	limit := q.Limit
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	where := "tenant_id = $1"
+	args := []interface{}{tenantID}
+	argN := 1
+
+	addCond := func(cond string, val interface{}) {
+		argN++
+		where += fmt.Sprintf(" AND %s $%d", cond, argN)
+		args = append(args, val)
+	}
+	if q.ActorID != "" {
+		addCond("actor_id =", q.ActorID)
+	}
+	if q.TargetType != "" {
+		addCond("target_type =", q.TargetType)
+	}
+	if q.TargetID != "" {
+		addCond("target_id =", q.TargetID)
+	}
+	if !q.From.IsZero() {
+		addCond("created_at >=", q.From)
+	}
+	if !q.To.IsZero() {
+		addCond("created_at <=", q.To)
+	}
+
+	if q.Cursor != "" {
+		createdAt, id, err := decodeAuditCursor(q.Cursor)
+		if err != nil {
+			return AuditPage{}, fmt.Errorf("invalid cursor: %w", err)
+		}
+		where += fmt.Sprintf(" AND (created_at, id) < ($%d, $%d)", argN+1, argN+2)
+		args = append(args, createdAt, id)
+		argN += 2
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, tenant_id, actor_id, actor_type, action, target_type, target_id, before, after, ip, user_agent, created_at
+		FROM audit_events
+		WHERE %s
+		ORDER BY created_at DESC, id DESC
+		LIMIT %d`, where, limit+1)
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return AuditPage{}, fmt.Errorf("failed to query audit events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]models.AuditEvent, 0, limit)
+	for rows.Next() {
+		var event models.AuditEvent
+		if err := rows.StructScan(&event); err != nil {
+			return AuditPage{}, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return AuditPage{}, fmt.Errorf("failed to read audit events: %w", err)
+	}
+
+	var next string
+	if len(events) > limit {
+		last := events[limit-1]
+		next = encodeAuditCursor(last.CreatedAt, last.ID)
+		events = events[:limit]
+	}
+	return AuditPage{Events: events, NextCursor: next}, nil
+}
+
+// encodeAuditCursor packs the sort key of the last row on a page into an
+// opaque, URL-safe token for the caller to pass back as AuditQuery.Cursor.
+func encodeAuditCursor(createdAt time.Time, id uuid.UUID) string {
+	raw := createdAt.UTC().Format(time.RFC3339Nano) + "|" + id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeAuditCursor(cursor string) (time.Time, uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("malformed cursor encoding: %w", err)
+	}
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return time.Time{}, uuid.Nil, fmt.Errorf("malformed cursor")
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("malformed cursor timestamp: %w", err)
+	}
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("malformed cursor id: %w", err)
+	}
+	return createdAt, id, nil
+}
+
+// EnsurePartition creates the monthly audit_events partition covering
+// month (any timestamp within the target month) if it doesn't already
+// exist, so inserts into a month that hasn't started yet don't fail.
+func (r *AuditRepository) EnsurePartition(ctx context.Context, month time.Time) error {
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	partition := fmt.Sprintf("audit_events_%04d_%02d", start.Year(), start.Month())
+
+	query := fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s PARTITION OF audit_events FOR VALUES FROM ($1) TO ($2)`,
+		partition,
+	)
+	if _, err := r.db.ExecContext(ctx, query, start, end); err != nil {
+		return fmt.Errorf("failed to create audit partition %s: %w", partition, err)
+	}
+	return nil
+}
+
+// DropPartitionBefore drops the monthly audit_events partition for month
+// entirely, once RetentionJob has determined every tenant's retention
+// policy has expired for it. It's a no-op if that partition doesn't exist.
+func (r *AuditRepository) DropPartitionBefore(ctx context.Context, month time.Time) error {
+	partition := fmt.Sprintf("audit_events_%04d_%02d", month.Year(), month.Month())
+	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(`DROP TABLE IF EXISTS %s`, partition)); err != nil {
+		return fmt.Errorf("failed to drop audit partition %s: %w", partition, err)
+	}
+	return nil
+}
+
+// OldestPartitionMonth returns the start of the earliest month with any
+// audit_events rows for the service as a whole, or a zero time if the
+// table is empty, so RetentionJob knows where to start considering
+// partitions for removal.
+func (r *AuditRepository) OldestPartitionMonth(ctx context.Context) (time.Time, error) {
+	var oldest *time.Time
+	err := r.db.QueryRowContext(ctx, `SELECT MIN(created_at) FROM audit_events`).Scan(&oldest)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to find oldest audit event: %w", err)
+	}
+	if oldest == nil {
+		return time.Time{}, nil
+	}
+	return time.Date(oldest.Year(), oldest.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+}
+
+// EntityRevision is one immutable audit_revisions row: a full snapshot
+// of an entity plus the diff that produced it, numbered per
+// (tenant, entity_type, entity_id) the same way ConfigVersion numbers
+// tenant_configs changes. It exists for entities that don't have their
+// own versioning table, unlike tenant_configs (see ConfigRepository's
+// config_versions, which predates this and stays as is).
+type EntityRevision struct {
+	ID         uuid.UUID       `json:"id" db:"id"`
+	TenantID   string          `json:"tenant_id" db:"tenant_id"`
+	EntityType string          `json:"entity_type" db:"entity_type"`
+	EntityID   string          `json:"entity_id" db:"entity_id"`
+	Revision   int             `json:"revision" db:"revision"`
+	Action     string          `json:"action" db:"action"`
+	ActorID    string          `json:"actor_id" db:"actor_id"`
+	Diff       json.RawMessage `json:"diff" db:"diff"`
+	Snapshot   models.JSONB    `json:"snapshot" db:"snapshot"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+}
+
+// EntityRevisionSummary is the lightweight view ListRevisions returns,
+// mirroring ConfigVersionSummary.
+type EntityRevisionSummary struct {
+	Revision  int       `json:"revision" db:"revision"`
+	Action    string    `json:"action" db:"action"`
+	ActorID   string    `json:"actor_id" db:"actor_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// revisionExecer is the subset of *sqlx.Tx (or *DB) RecordRevision
+// needs, so a caller already inside its own transaction - SourceRepository
+// and TemplateRepository's Create/Update/Delete - can pass tx straight
+// through and get the revision row written atomically with the entity
+// change it describes.
+type revisionExecer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// RecordRevision allocates the next revision number for
+// (tenantID, entityType, entityID) and inserts the audit_revisions row
+// describing it. Run it inside the same transaction as the entity
+// change itself, so a crash between the two never leaves one without
+// the other.
+func (r *AuditRepository) RecordRevision(ctx context.Context, execer revisionExecer, tenantID, entityType, entityID, action, actorID string, diff json.RawMessage, snapshot models.JSONB) (*EntityRevision, error) {
+	var nextRevision int
+	if err := execer.QueryRowContext(ctx,
+		"SELECT COALESCE(MAX(revision), 0) + 1 FROM audit_revisions WHERE tenant_id = $1 AND entity_type = $2 AND entity_id = $3",
+		tenantID, entityType, entityID,
+	).Scan(&nextRevision); err != nil {
+		return nil, fmt.Errorf("failed to allocate revision: %w", err)
+	}
+
+	rev := EntityRevision{
+		TenantID:   tenantID,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Revision:   nextRevision,
+		Action:     action,
+		ActorID:    actorID,
+		Diff:       diff,
+		Snapshot:   snapshot,
+	}
+	err := execer.QueryRowContext(ctx, `
+		INSERT INTO audit_revisions (tenant_id, entity_type, entity_id, revision, action, actor_id, diff, snapshot)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+	`, rev.TenantID, rev.EntityType, rev.EntityID, rev.Revision, rev.Action, rev.ActorID, []byte(rev.Diff), rev.Snapshot,
+	).Scan(&rev.ID, &rev.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record revision: %w", err)
+	}
+	return &rev, nil
+}
+
+// ListRevisions returns a paginated, newest-first page of revision
+// summaries for one entity.
+func (r *AuditRepository) ListRevisions(ctx context.Context, tenantID, entityType, entityID string, page, limit int) ([]EntityRevisionSummary, int, error) {
+	offset := (page - 1) * limit
+
+	var total int
+	if err := r.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM audit_revisions WHERE tenant_id = $1 AND entity_type = $2 AND entity_id = $3",
+		tenantID, entityType, entityID,
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count revisions: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT revision, action, actor_id, created_at
+		FROM audit_revisions
+		WHERE tenant_id = $1 AND entity_type = $2 AND entity_id = $3
+		ORDER BY revision DESC
+		LIMIT $4 OFFSET $5
+	`, tenantID, entityType, entityID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []EntityRevisionSummary
+	for rows.Next() {
+		var rev EntityRevisionSummary
+		if err := rows.Scan(&rev.Revision, &rev.Action, &rev.ActorID, &rev.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan revision: %w", err)
+		}
+		revisions = append(revisions, rev)
+	}
+
+	return revisions, total, nil
+}
+
+// GetRevision retrieves one entity's full snapshot at a given revision
+// number.
+func (r *AuditRepository) GetRevision(ctx context.Context, tenantID, entityType, entityID string, revision int) (*EntityRevision, error) {
+	var rev EntityRevision
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, tenant_id, entity_type, entity_id, revision, action, actor_id, diff, snapshot, created_at
+		FROM audit_revisions
+		WHERE tenant_id = $1 AND entity_type = $2 AND entity_id = $3 AND revision = $4
+	`, tenantID, entityType, entityID, revision).Scan(
+		&rev.ID, &rev.TenantID, &rev.EntityType, &rev.EntityID, &rev.Revision,
+		&rev.Action, &rev.ActorID, &rev.Diff, &rev.Snapshot, &rev.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("revision not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision: %w", err)
+	}
+	return &rev, nil
+}
@@ -0,0 +1,61 @@
+// Package database provides the YouTube channel backfill checkpoint repository
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/integrations"
+)
+
+// BackfillCheckpointRepository persists integrations.YouTubeIntegration.
+// BackfillChannel progress in the backfill_checkpoints table and
+// implements integrations.BackfillCheckpointRepository.
+type BackfillCheckpointRepository struct {
+	db *DB
+}
+
+// NewBackfillCheckpointRepository creates a new backfill checkpoint repository.
+func NewBackfillCheckpointRepository(db *DB) *BackfillCheckpointRepository {
+	return &BackfillCheckpointRepository{db: db}
+}
+
+// Get returns the checkpoint for channelID, or nil if no backfill job has
+// been started yet for it.
+func (r *BackfillCheckpointRepository) Get(ctx context.Context, channelID string) (*integrations.BackfillCheckpoint, error) {
+	query := `
+		SELECT channel_id, last_page_token, last_video_id, last_published_at, done, updated_at
+		FROM backfill_checkpoints WHERE channel_id = $1`
+
+	var checkpoint integrations.BackfillCheckpoint
+	err := r.db.GetContext(ctx, &checkpoint, query, channelID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load backfill checkpoint: %w", err)
+	}
+	return &checkpoint, nil
+}
+
+// Save upserts the checkpoint's progress.
+func (r *BackfillCheckpointRepository) Save(ctx context.Context, checkpoint *integrations.BackfillCheckpoint) error {
+	query := `
+		INSERT INTO backfill_checkpoints (channel_id, last_page_token, last_video_id, last_published_at, done, updated_at)
+		VALUES ($1, $2, $3, $4, $5, now())
+		ON CONFLICT (channel_id) DO UPDATE SET
+			last_page_token = EXCLUDED.last_page_token,
+			last_video_id = EXCLUDED.last_video_id,
+			last_published_at = EXCLUDED.last_published_at,
+			done = EXCLUDED.done,
+			updated_at = now()`
+
+	_, err := r.db.ExecContext(ctx, query,
+		checkpoint.ChannelID, checkpoint.LastPageToken, checkpoint.LastVideoID,
+		checkpoint.LastPublishedAt, checkpoint.Done)
+	if err != nil {
+		return fmt.Errorf("failed to save backfill checkpoint: %w", err)
+	}
+	return nil
+}
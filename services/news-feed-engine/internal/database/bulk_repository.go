@@ -0,0 +1,160 @@
+// Package database - bulk upsert support for SourceRepository and
+// TemplateRepository, layered on their existing Create/Update so a tenant
+// onboarding/clone/restore can submit many rows in one call instead of one
+// HTTP round trip per row, the same way CreatorHandler.BulkImportCreators
+// lets many creators be imported in one call.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database/sqlcgen"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+// BulkUpsertStatus is the outcome BulkUpsertResult reports for one row.
+type BulkUpsertStatus string
+
+const (
+	BulkUpsertCreated BulkUpsertStatus = "created"
+	BulkUpsertUpdated BulkUpsertStatus = "updated"
+	BulkUpsertSkipped BulkUpsertStatus = "skipped"
+	BulkUpsertError   BulkUpsertStatus = "error"
+)
+
+// BulkUpsertResult reports what happened to one row of a BulkUpsert call,
+// identified by the natural key BulkUpsert matched it on (a content
+// source's identifier, a video template's name) rather than its row
+// index, since rows are processed independently and a caller re-running
+// a failed batch wants to know which named row to look at.
+type BulkUpsertResult struct {
+	Identifier string           `json:"identifier"`
+	Status     BulkUpsertStatus `json:"status"`
+	Error      string           `json:"error,omitempty"`
+}
+
+// BulkUpsert creates or updates each of sources by (tenant_id,
+// identifier), the column content_sources already enforces uniqueness on.
+// Rows are processed independently and in order - one row failing (a bad
+// platform value, a database error) doesn't stop the rest of the batch
+// from being attempted, matching importCreatorRow's per-row semantics.
+// With dryRun, every row is validated and matched against any existing
+// row but nothing is written; its result reports "created" or "updated"
+// as it would without actually doing so.
+func (r *SourceRepository) BulkUpsert(ctx context.Context, tenantID string, sources []models.ContentSource, actorID string, dryRun bool) ([]BulkUpsertResult, error) {
+	results := make([]BulkUpsertResult, 0, len(sources))
+
+	for _, source := range sources {
+		result := BulkUpsertResult{Identifier: source.Identifier}
+
+		if source.Identifier == "" || source.Name == "" || source.Platform == "" {
+			result.Status = BulkUpsertError
+			result.Error = "name, platform, and identifier are required"
+			results = append(results, result)
+			continue
+		}
+
+		existing, err := sqlcgen.New(r.db).GetContentSourceByIdentifier(ctx, sqlcgen.GetContentSourceByIdentifierParams{
+			TenantID: tenantID, Identifier: source.Identifier,
+		})
+		switch {
+		case err == sql.ErrNoRows:
+			if dryRun {
+				result.Status = BulkUpsertCreated
+				break
+			}
+			source.TenantID = tenantID
+			if err := r.Create(ctx, &source, actorID); err != nil {
+				result.Status = BulkUpsertError
+				result.Error = err.Error()
+				break
+			}
+			result.Status = BulkUpsertCreated
+		case err != nil:
+			result.Status = BulkUpsertError
+			result.Error = fmt.Sprintf("failed to look up existing source: %v", err)
+		default:
+			if dryRun {
+				result.Status = BulkUpsertUpdated
+				break
+			}
+			update := SourceUpdate{
+				Name: source.Name, Platform: string(source.Platform), SourceType: source.SourceType,
+				Identifier: source.Identifier, Category: source.Category, Priority: &source.Priority,
+				IngestionCron: source.IngestionCron, Active: &source.Active,
+			}
+			if err := r.Update(ctx, tenantID, existing.ID.String(), update, actorID); err != nil {
+				result.Status = BulkUpsertError
+				result.Error = err.Error()
+				break
+			}
+			result.Status = BulkUpsertUpdated
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// BulkUpsert creates or updates each of templates by (tenant_id, name),
+// the column video_templates already enforces uniqueness on. See
+// SourceRepository.BulkUpsert for the per-row and dryRun semantics, which
+// this mirrors exactly.
+func (r *TemplateRepository) BulkUpsert(ctx context.Context, tenantID string, templates []models.VideoTemplate, actorID string, dryRun bool) ([]BulkUpsertResult, error) {
+	results := make([]BulkUpsertResult, 0, len(templates))
+
+	for _, template := range templates {
+		result := BulkUpsertResult{Identifier: template.Name}
+
+		if template.Name == "" {
+			result.Status = BulkUpsertError
+			result.Error = "name is required"
+			results = append(results, result)
+			continue
+		}
+
+		existing, err := sqlcgen.New(r.db).GetVideoTemplateByName(ctx, sqlcgen.GetVideoTemplateByNameParams{
+			TenantID: tenantID, Name: template.Name,
+		})
+		switch {
+		case err == sql.ErrNoRows:
+			if dryRun {
+				result.Status = BulkUpsertCreated
+				break
+			}
+			template.TenantID = tenantID
+			if err := r.Create(ctx, &template, actorID); err != nil {
+				result.Status = BulkUpsertError
+				result.Error = err.Error()
+				break
+			}
+			result.Status = BulkUpsertCreated
+		case err != nil:
+			result.Status = BulkUpsertError
+			result.Error = fmt.Sprintf("failed to look up existing template: %v", err)
+		default:
+			if dryRun {
+				result.Status = BulkUpsertUpdated
+				break
+			}
+			update := TemplateUpdate{
+				Name: template.Name, Description: template.Description, Category: template.Category,
+				VoiceID: template.VoiceID, AvatarID: template.AvatarID, Resolution: template.Resolution,
+				Duration: &template.Duration, IsDefault: &template.IsDefault, Active: &template.Active,
+			}
+			if err := r.Update(ctx, tenantID, existing.ID.String(), update, actorID); err != nil {
+				result.Status = BulkUpsertError
+				result.Error = err.Error()
+				break
+			}
+			result.Status = BulkUpsertUpdated
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,60 @@
+// Package database provides the channel backfill cursor repository
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/ingestion"
+)
+
+// ChannelCursorRepository persists historical-backfill progress in the
+// channel_cursors table and implements ingestion.ChannelCursorStore.
+type ChannelCursorRepository struct {
+	db *DB
+}
+
+// NewChannelCursorRepository creates a new channel cursor repository.
+func NewChannelCursorRepository(db *DB) *ChannelCursorRepository {
+	return &ChannelCursorRepository{db: db}
+}
+
+// Get returns the cursor for (tenantID, source), or nil if no backfill has
+// been started yet for that pair.
+func (r *ChannelCursorRepository) Get(ctx context.Context, tenantID, source string) (*ingestion.ChannelCursor, error) {
+	query := `
+		SELECT tenant_id, source, platform, token, from_time, pages_fetched, items_enqueued, done, updated_at
+		FROM channel_cursors WHERE tenant_id = $1 AND source = $2`
+
+	var cursor ingestion.ChannelCursor
+	err := r.db.GetContext(ctx, &cursor, query, tenantID, source)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load channel cursor: %w", err)
+	}
+	return &cursor, nil
+}
+
+// Save upserts the cursor's progress.
+func (r *ChannelCursorRepository) Save(ctx context.Context, cursor *ingestion.ChannelCursor) error {
+	query := `
+		INSERT INTO channel_cursors (tenant_id, source, platform, token, from_time, pages_fetched, items_enqueued, done, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, now())
+		ON CONFLICT (tenant_id, source) DO UPDATE SET
+			token = EXCLUDED.token,
+			pages_fetched = EXCLUDED.pages_fetched,
+			items_enqueued = EXCLUDED.items_enqueued,
+			done = EXCLUDED.done,
+			updated_at = now()`
+
+	_, err := r.db.ExecContext(ctx, query,
+		cursor.TenantID, cursor.Source, cursor.Platform, cursor.Token, cursor.From,
+		cursor.PagesFetched, cursor.ItemsEnqueued, cursor.Done)
+	if err != nil {
+		return fmt.Errorf("failed to save channel cursor: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+// Package database provides the cross-platform content search index
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/integrations"
+)
+
+// ContentIndexRepository persists integrations.UnifiedContent in the
+// unified_content_index table for hybrid search and implements
+// integrations.ContentIndexStore. The table carries a generated
+// search_vector tsvector column (title/description/content/tags) with
+// a GIN index, and an embedding pgvector column, mirroring the content
+// table's own search_vector/embedding columns used by ContentRepository.
+type ContentIndexRepository struct {
+	db *DB
+}
+
+// NewContentIndexRepository creates a new content index repository.
+func NewContentIndexRepository(db *DB) *ContentIndexRepository {
+	return &ContentIndexRepository{db: db}
+}
+
+// contentIndexRow is unified_content_index's column shape; tags and
+// metadata are stored as jsonb and marshaled/unmarshaled by hand since
+// UnifiedContent.Metadata is a map[string]interface{} sqlx can't scan
+// directly.
+type contentIndexRow struct {
+	Platform        string          `db:"platform"`
+	ID              string          `db:"id"`
+	ContentType     string          `db:"content_type"`
+	Title           string          `db:"title"`
+	Description     string          `db:"description"`
+	Content         string          `db:"content"`
+	URL             string          `db:"url"`
+	ThumbnailURL    string          `db:"thumbnail_url"`
+	AuthorID        string          `db:"author_id"`
+	AuthorName      string          `db:"author_name"`
+	AuthorAvatarURL string          `db:"author_avatar_url"`
+	PublishedAt     sql.NullTime    `db:"published_at"`
+	Tags            json.RawMessage `db:"tags"`
+	Categories      json.RawMessage `db:"categories"`
+	Metadata        json.RawMessage `db:"metadata"`
+}
+
+func (row contentIndexRow) toUnifiedContent() (integrations.UnifiedContent, error) {
+	content := integrations.UnifiedContent{
+		ID:              row.ID,
+		Platform:        row.Platform,
+		ContentType:     row.ContentType,
+		Title:           row.Title,
+		Description:     row.Description,
+		Content:         row.Content,
+		URL:             row.URL,
+		ThumbnailURL:    row.ThumbnailURL,
+		AuthorID:        row.AuthorID,
+		AuthorName:      row.AuthorName,
+		AuthorAvatarURL: row.AuthorAvatarURL,
+	}
+	if row.PublishedAt.Valid {
+		content.PublishedAt = row.PublishedAt.Time
+	}
+	if len(row.Tags) > 0 {
+		if err := json.Unmarshal(row.Tags, &content.Tags); err != nil {
+			return content, fmt.Errorf("failed to unmarshal tags: %w", err)
+		}
+	}
+	if len(row.Categories) > 0 {
+		if err := json.Unmarshal(row.Categories, &content.Categories); err != nil {
+			return content, fmt.Errorf("failed to unmarshal categories: %w", err)
+		}
+	}
+	if len(row.Metadata) > 0 {
+		if err := json.Unmarshal(row.Metadata, &content.Metadata); err != nil {
+			return content, fmt.Errorf("failed to unmarshal metadata: %w", err)
+		}
+	}
+	return content, nil
+}
+
+// Index implements integrations.ContentIndexStore.
+func (r *ContentIndexRepository) Index(ctx context.Context, tenantID string, item integrations.UnifiedContent, embedding []float32) error {
+	tags, err := json.Marshal(item.Tags)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tags: %w", err)
+	}
+	categories, err := json.Marshal(item.Categories)
+	if err != nil {
+		return fmt.Errorf("failed to marshal categories: %w", err)
+	}
+	metadata, err := json.Marshal(item.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	var embeddingArg interface{}
+	if len(embedding) > 0 {
+		embeddingArg = vectorLiteral(embedding)
+	}
+
+	query := `
+		INSERT INTO unified_content_index (
+			tenant_id, platform, id, content_type, title, description, content, url,
+			thumbnail_url, author_id, author_name, author_avatar_url, published_at,
+			tags, categories, metadata, embedding, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17::vector, now())
+		ON CONFLICT (tenant_id, platform, id) DO UPDATE SET
+			content_type       = EXCLUDED.content_type,
+			title              = EXCLUDED.title,
+			description        = EXCLUDED.description,
+			content            = EXCLUDED.content,
+			url                = EXCLUDED.url,
+			thumbnail_url      = EXCLUDED.thumbnail_url,
+			author_id          = EXCLUDED.author_id,
+			author_name        = EXCLUDED.author_name,
+			author_avatar_url  = EXCLUDED.author_avatar_url,
+			published_at       = EXCLUDED.published_at,
+			tags               = EXCLUDED.tags,
+			categories         = EXCLUDED.categories,
+			metadata           = EXCLUDED.metadata,
+			embedding          = EXCLUDED.embedding,
+			updated_at         = now()`
+
+	_, err = r.db.ExecContext(ctx, query, tenantID, item.Platform, item.ID, item.ContentType,
+		item.Title, item.Description, item.Content, item.URL, item.ThumbnailURL,
+		item.AuthorID, item.AuthorName, item.AuthorAvatarURL, item.PublishedAt,
+		tags, categories, metadata, embeddingArg)
+	if err != nil {
+		return fmt.Errorf("failed to index content: %w", err)
+	}
+	return nil
+}
+
+// SearchFullText implements integrations.ContentIndexStore.
+func (r *ContentIndexRepository) SearchFullText(ctx context.Context, filters integrations.ContentSearchFilters, query string, limit int) ([]integrations.UnifiedContent, error) {
+	whereClauses := []string{"tenant_id = $1", "search_vector @@ websearch_to_tsquery('english', $2)"}
+	args := []interface{}{filters.TenantID, query}
+	argCount := 2
+
+	if filters.Platform != "" {
+		argCount++
+		whereClauses = append(whereClauses, fmt.Sprintf("platform = $%d", argCount))
+		args = append(args, filters.Platform)
+	}
+
+	sqlQuery := fmt.Sprintf(`
+		SELECT platform, id, content_type, title, description, content, url, thumbnail_url,
+			author_id, author_name, author_avatar_url, published_at, tags, categories, metadata
+		FROM unified_content_index
+		WHERE %s
+		ORDER BY ts_rank(search_vector, websearch_to_tsquery('english', $2)) DESC
+		LIMIT $%d`, strings.Join(whereClauses, " AND "), argCount+1)
+	args = append(args, limit)
+
+	var rows []contentIndexRow
+	if err := r.db.SelectContext(ctx, &rows, sqlQuery, args...); err != nil {
+		return nil, fmt.Errorf("failed to full-text search content index: %w", err)
+	}
+	return rowsToContent(rows)
+}
+
+// SearchVector implements integrations.ContentIndexStore.
+func (r *ContentIndexRepository) SearchVector(ctx context.Context, filters integrations.ContentSearchFilters, embedding []float32, limit int) ([]integrations.UnifiedContent, error) {
+	whereClauses := []string{"tenant_id = $1", "embedding IS NOT NULL"}
+	args := []interface{}{filters.TenantID}
+	argCount := 1
+
+	if filters.Platform != "" {
+		argCount++
+		whereClauses = append(whereClauses, fmt.Sprintf("platform = $%d", argCount))
+		args = append(args, filters.Platform)
+	}
+
+	argCount++
+	vectorArgPos := argCount
+	args = append(args, vectorLiteral(embedding))
+
+	sqlQuery := fmt.Sprintf(`
+		SELECT platform, id, content_type, title, description, content, url, thumbnail_url,
+			author_id, author_name, author_avatar_url, published_at, tags, categories, metadata
+		FROM unified_content_index
+		WHERE %s
+		ORDER BY embedding <=> $%d::vector
+		LIMIT $%d`, strings.Join(whereClauses, " AND "), vectorArgPos, argCount+1)
+	args = append(args, limit)
+
+	var rows []contentIndexRow
+	if err := r.db.SelectContext(ctx, &rows, sqlQuery, args...); err != nil {
+		return nil, fmt.Errorf("failed to vector search content index: %w", err)
+	}
+	return rowsToContent(rows)
+}
+
+func rowsToContent(rows []contentIndexRow) ([]integrations.UnifiedContent, error) {
+	content := make([]integrations.UnifiedContent, 0, len(rows))
+	for _, row := range rows {
+		item, err := row.toUnifiedContent()
+		if err != nil {
+			return nil, err
+		}
+		content = append(content, item)
+	}
+	return content, nil
+}
+
+// vectorLiteral formats embedding as a pgvector input literal, e.g.
+// "[0.1,0.2,0.3]", mirroring ContentRepository.SearchSemantic's own
+// embedding-to-vector-literal conversion.
+func vectorLiteral(embedding []float32) string {
+	parts := make([]string, len(embedding))
+	for i, v := range embedding {
+		parts[i] = fmt.Sprintf("%g", v)
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
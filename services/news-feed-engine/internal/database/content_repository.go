@@ -4,8 +4,14 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
@@ -14,6 +20,13 @@ import (
 // ContentRepository handles content database operations
 type ContentRepository struct {
 	db *DB
+
+	// deadlineMu guards queryDeadline/searchDeadline, set via
+	// SetQueryDeadline/SetSearchDeadline and consulted by
+	// withQueryDeadline/withSearchDeadline on every call.
+	deadlineMu     sync.Mutex
+	queryDeadline  time.Time
+	searchDeadline time.Time
 }
 
 // NewContentRepository creates a new content repository
@@ -21,8 +34,141 @@ func NewContentRepository(db *DB) *ContentRepository {
 	return &ContentRepository{db: db}
 }
 
-// List returns paginated content list with filters
+// SetQueryDeadline bounds the plain read methods (List, GetByID,
+// GetTrending, ListByCreator): each derives its working context from
+// context.WithDeadline using whichever of the caller's own deadline and
+// t occurs first, so t only ever tightens a call's time budget, never
+// widens it. Pass the zero Time to clear it and rely solely on the
+// caller's context.
+func (r *ContentRepository) SetQueryDeadline(t time.Time) {
+	r.deadlineMu.Lock()
+	r.queryDeadline = t
+	r.deadlineMu.Unlock()
+}
+
+// SetSearchDeadline is SetQueryDeadline's counterpart for SearchSemantic
+// (and so the vector half of SearchHybrid), whose pgvector scans tend to
+// run longer and want their own, typically more generous, budget.
+// SearchFullText is not bounded by it.
+func (r *ContentRepository) SetSearchDeadline(t time.Time) {
+	r.deadlineMu.Lock()
+	r.searchDeadline = t
+	r.deadlineMu.Unlock()
+}
+
+func (r *ContentRepository) withQueryDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	r.deadlineMu.Lock()
+	configured := r.queryDeadline
+	r.deadlineMu.Unlock()
+	return withEarlierDeadline(ctx, configured)
+}
+
+func (r *ContentRepository) withSearchDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	r.deadlineMu.Lock()
+	configured := r.searchDeadline
+	r.deadlineMu.Unlock()
+	return withEarlierDeadline(ctx, configured)
+}
+
+// withEarlierDeadline derives a context bound by whichever of ctx's
+// existing deadline (if any) and configured (if non-zero) occurs first -
+// borrowed from how net.Conn.SetDeadline narrows an operation's time
+// budget without ever widening one the caller already set.
+func withEarlierDeadline(ctx context.Context, configured time.Time) (context.Context, context.CancelFunc) {
+	if configured.IsZero() {
+		return ctx, func() {}
+	}
+	if existing, ok := ctx.Deadline(); ok && existing.Before(configured) {
+		return ctx, func() {}
+	}
+	return context.WithDeadline(ctx, configured)
+}
+
+// queryTimingsKey is the context key QueryTimings is attached under.
+type queryTimingsKey struct{}
+
+// QueryTimings accumulates per-method query latency samples so a
+// health check handler can surface p50/p99 latency in the /metrics
+// response. Callers that want this visibility attach one to the
+// context passed into repository methods via WithQueryTimings; methods
+// that find none in their context simply skip recording. Safe for
+// concurrent use.
+type QueryTimings struct {
+	mu      sync.Mutex
+	samples map[string][]time.Duration
+}
+
+// NewQueryTimings creates an empty QueryTimings.
+func NewQueryTimings() *QueryTimings {
+	return &QueryTimings{samples: make(map[string][]time.Duration)}
+}
+
+// WithQueryTimings returns a context carrying qt, for repository
+// methods called from it to record into.
+func WithQueryTimings(ctx context.Context, qt *QueryTimings) context.Context {
+	return context.WithValue(ctx, queryTimingsKey{}, qt)
+}
+
+// QueryTimingsFromContext retrieves the QueryTimings attached via
+// WithQueryTimings, if any.
+func QueryTimingsFromContext(ctx context.Context) (*QueryTimings, bool) {
+	qt, ok := ctx.Value(queryTimingsKey{}).(*QueryTimings)
+	return qt, ok
+}
+
+// queryTimingsSampleCap bounds memory per method: once exceeded, the
+// oldest samples are dropped in favor of recent ones, so Percentiles
+// reflects current behavior rather than the repository's entire
+// lifetime.
+const queryTimingsSampleCap = 256
+
+func (qt *QueryTimings) record(method string, d time.Duration) {
+	qt.mu.Lock()
+	defer qt.mu.Unlock()
+	samples := append(qt.samples[method], d)
+	if len(samples) > queryTimingsSampleCap {
+		samples = samples[len(samples)-queryTimingsSampleCap:]
+	}
+	qt.samples[method] = samples
+}
+
+// Percentiles returns the p50/p99 latency observed for method across
+// the samples recorded so far, or zero values if none were recorded.
+func (qt *QueryTimings) Percentiles(method string) (p50, p99 time.Duration) {
+	qt.mu.Lock()
+	samples := append([]time.Duration(nil), qt.samples[method]...)
+	qt.mu.Unlock()
+
+	if len(samples) == 0 {
+		return 0, 0
+	}
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	p50 = samples[(len(samples)*50)/100]
+	p99Idx := (len(samples) * 99) / 100
+	if p99Idx >= len(samples) {
+		p99Idx = len(samples) - 1
+	}
+	p99 = samples[p99Idx]
+	return p50, p99
+}
+
+func recordTiming(ctx context.Context, method string, start time.Time) {
+	if qt, ok := QueryTimingsFromContext(ctx); ok {
+		qt.record(method, time.Since(start))
+	}
+}
+
+// List returns paginated content list with filters. It pages by OFFSET,
+// so opts.Page costs O(offset) for Postgres to skip - fine for the
+// low-numbered pages most UIs show, but deprecated for clients that
+// scroll deeply or indefinitely (e.g. a trending feed); those should
+// call ListKeyset instead.
 func (r *ContentRepository) List(ctx context.Context, tenantID string, opts ListOptions) ([]*models.Content, int, error) {
+	ctx, cancel := r.withQueryDeadline(ctx)
+	defer cancel()
+	defer recordTiming(ctx, "List", time.Now())
+
 	// Build WHERE clause
 	whereClauses := []string{"c.tenant_id = $1"}
 	args := []interface{}{tenantID}
@@ -136,8 +282,158 @@ func (r *ContentRepository) List(ctx context.Context, tenantID string, opts List
 	return contents, total, nil
 }
 
+// ListKeyset is the seek-pagination sibling of List: instead of
+// opts.Page/opts.Limit OFFSET-ing into the result set, it seeks past
+// opts.Cursor (the NextCursor a previous call returned, empty for the
+// first page) with a single indexed (sort_col, id) comparison, so the
+// cost of a page stays constant no matter how deep the caller has
+// scrolled. opts.Page and the returned total are not used; the returned
+// NextCursor is empty once there is no further page.
+func (r *ContentRepository) ListKeyset(ctx context.Context, tenantID string, opts ListOptions) ([]*models.Content, string, error) {
+	whereClauses := []string{"c.tenant_id = $1"}
+	args := []interface{}{tenantID}
+	argCount := 1
+
+	if opts.Category != "" {
+		argCount++
+		whereClauses = append(whereClauses, fmt.Sprintf("c.category = $%d", argCount))
+		args = append(args, opts.Category)
+	}
+
+	if opts.Platform != "" {
+		argCount++
+		whereClauses = append(whereClauses, fmt.Sprintf("c.platform = $%d", argCount))
+		args = append(args, opts.Platform)
+	}
+
+	if opts.GeoClassification != "" {
+		argCount++
+		whereClauses = append(whereClauses, fmt.Sprintf("c.geo_classification = $%d", argCount))
+		args = append(args, opts.GeoClassification)
+	}
+
+	if opts.ProcessingStatus != "" {
+		argCount++
+		whereClauses = append(whereClauses, fmt.Sprintf("c.processing_status = $%d", argCount))
+		args = append(args, opts.ProcessingStatus)
+	}
+
+	sortCol, pgType := keysetSortColumn(opts.SortBy)
+	descending := !(sortCol == "published_at" && opts.Order == "asc")
+
+	if opts.Cursor != "" {
+		kc, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		cmp := "<"
+		if !descending {
+			cmp = ">"
+		}
+		argCount++
+		sortArg := argCount
+		argCount++
+		idArg := argCount
+		whereClauses = append(whereClauses, fmt.Sprintf("(c.%s, c.id) %s ($%d::%s, $%d::uuid)", sortCol, cmp, sortArg, pgType, idArg))
+		args = append(args, kc.SortValue, kc.ID)
+	}
+
+	whereClause := strings.Join(whereClauses, " AND ")
+
+	dir := "DESC"
+	if !descending {
+		dir = "ASC"
+	}
+	orderBy := fmt.Sprintf("c.%s %s, c.id %s", sortCol, dir, dir)
+
+	limit := opts.Limit
+	if limit < 1 {
+		limit = 20
+	}
+	argCount++
+	args = append(args, limit+1)
+
+	query := fmt.Sprintf(`
+		SELECT c.*, cr.name as creator_name, cr.avatar_url as creator_avatar_url
+		FROM content c
+		LEFT JOIN creators cr ON c.creator_id = cr.id
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d
+	`, whereClause, orderBy, argCount)
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query content: %w", err)
+	}
+	defer rows.Close()
+
+	var contents []*models.Content
+	for rows.Next() {
+		var content models.Content
+		var creatorName sql.NullString
+		var creatorAvatarURL sql.NullString
+
+		scanMap := make(map[string]interface{})
+		if err := rows.MapScan(scanMap); err != nil {
+			return nil, "", fmt.Errorf("failed to scan content: %w", err)
+		}
+
+		content.ID = uuid.MustParse(scanMap["id"].(string))
+		content.TenantID = scanMap["tenant_id"].(string)
+		content.Title = scanMap["title"].(string)
+		content.Platform = models.Platform(scanMap["platform"].(string))
+		content.ContentType = models.ContentType(scanMap["content_type"].(string))
+		content.OriginalURL = scanMap["original_url"].(string)
+		if ts, ok := scanMap["published_at"].(time.Time); ok {
+			content.PublishedAt = ts
+		}
+		if v, ok := scanMap["engagement_score"].(float64); ok {
+			content.EngagementScore = v
+		}
+		if v, ok := scanMap["quality_score"].(float64); ok {
+			content.QualityScore = v
+		}
+		if v, ok := scanMap["view_count"].(int64); ok {
+			content.ViewCount = v
+		}
+
+		if name, ok := scanMap["creator_name"].(string); ok {
+			creatorName = sql.NullString{String: name, Valid: true}
+		}
+		if url, ok := scanMap["creator_avatar_url"].(string); ok {
+			creatorAvatarURL = sql.NullString{String: url, Valid: true}
+		}
+		if creatorName.Valid {
+			content.Creator = &models.Creator{
+				Name:      creatorName.String,
+				AvatarURL: creatorAvatarURL.String,
+			}
+		}
+
+		contents = append(contents, &content)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	var nextCursor string
+	if len(contents) > limit {
+		contents = contents[:limit]
+		last := contents[len(contents)-1]
+		nextCursor = encodeCursor(keysetSortValue(last, sortCol), last.ID)
+	}
+
+	return contents, nextCursor, nil
+}
+
 // GetByID retrieves a single content item by ID
 func (r *ContentRepository) GetByID(ctx context.Context, tenantID string, contentID uuid.UUID) (*models.Content, error) {
+	ctx, cancel := r.withQueryDeadline(ctx)
+	defer cancel()
+	defer recordTiming(ctx, "GetByID", time.Now())
+
 	query := `
 		SELECT c.*,
 			   cr.id as "creator.id", cr.name as "creator.name", cr.platform as "creator.platform",
@@ -163,6 +459,10 @@ func (r *ContentRepository) GetByID(ctx context.Context, tenantID string, conten
 
 // SearchSemantic performs semantic search using pgvector
 func (r *ContentRepository) SearchSemantic(ctx context.Context, tenantID string, embedding []float32, opts ListOptions) ([]*models.Content, int, error) {
+	ctx, cancel := r.withSearchDeadline(ctx)
+	defer cancel()
+	defer recordTiming(ctx, "SearchSemantic", time.Now())
+
 	// Convert embedding to PostgreSQL vector format
 	embeddingStr := fmt.Sprintf("[%s]", strings.Trim(strings.Join(strings.Fields(fmt.Sprint(embedding)), ","), "[]"))
 
@@ -247,8 +547,227 @@ func (r *ContentRepository) SearchSemantic(ctx context.Context, tenantID string,
 	return contents, total, nil
 }
 
+// SearchFullText performs lexical search against the generated
+// search_vector tsvector column (title + description + tags, see the
+// news_feed schema migration that adds it alongside a GIN index).
+// ts_rank is Postgres's closest built-in analog to BM25; each result
+// carries it as Metadata["bm25_score"], which SearchHybrid reports as
+// search_bm25_rank.
+func (r *ContentRepository) SearchFullText(ctx context.Context, tenantID, query string, opts ListOptions) ([]*models.Content, int, error) {
+	whereClauses := []string{"c.tenant_id = $1", "c.search_vector @@ websearch_to_tsquery('english', $2)"}
+	args := []interface{}{tenantID, query}
+	argCount := 2
+
+	if opts.Category != "" {
+		argCount++
+		whereClauses = append(whereClauses, fmt.Sprintf("c.category = $%d", argCount))
+		args = append(args, opts.Category)
+	}
+
+	whereClause := strings.Join(whereClauses, " AND ")
+
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM content c WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		total = 1000 // Fallback estimate, mirrors SearchSemantic
+	}
+
+	offset := (opts.Page - 1) * opts.Limit
+
+	rankExpr := "ts_rank(c.search_vector, websearch_to_tsquery('english', $2))"
+	query2 := fmt.Sprintf(`
+		SELECT c.*,
+			   cr.name as creator_name,
+			   cr.avatar_url as creator_avatar_url,
+			   %s as bm25_score
+		FROM content c
+		LEFT JOIN creators cr ON c.creator_id = cr.id
+		WHERE %s
+		ORDER BY bm25_score DESC
+		LIMIT $%d OFFSET $%d
+	`, rankExpr, whereClause, argCount+1, argCount+2)
+
+	args = append(args, opts.Limit, offset)
+
+	rows, err := r.db.QueryxContext(ctx, query2, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to full-text search content: %w", err)
+	}
+	defer rows.Close()
+
+	var contents []*models.Content
+	for rows.Next() {
+		var content models.Content
+		var creatorName sql.NullString
+		var creatorAvatarURL sql.NullString
+		var bm25Score float64
+
+		scanMap := make(map[string]interface{})
+		if err := rows.MapScan(scanMap); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan content: %w", err)
+		}
+
+		content.ID = uuid.MustParse(scanMap["id"].(string))
+		content.TenantID = scanMap["tenant_id"].(string)
+		content.Title = scanMap["title"].(string)
+		bm25Score = scanMap["bm25_score"].(float64)
+
+		if content.Metadata == nil {
+			content.Metadata = make(models.JSONB)
+		}
+		content.Metadata["bm25_score"] = bm25Score
+
+		if creatorName.Valid {
+			content.Creator = &models.Creator{
+				Name:      creatorName.String,
+				AvatarURL: creatorAvatarURL.String,
+			}
+		}
+
+		contents = append(contents, &content)
+	}
+
+	return contents, total, nil
+}
+
+// rrfK is the rank-offset constant from the standard Reciprocal Rank
+// Fusion formula (Cormack et al.), chosen so a single list's top result
+// doesn't dominate the fused score regardless of how the other list
+// ranked it.
+const rrfK = 60
+
+// SearchHybrid runs the pgvector cosine search and the tsvector
+// full-text search concurrently, fuses the two ranked lists with
+// Reciprocal Rank Fusion (score(d) = sum(1 / (rrfK + rank_i(d))) across
+// the lists d appears in), and returns the top opts.Limit documents
+// after fusion. Each returned content's Metadata carries vector_rank and
+// bm25_rank (1-indexed within their source list, 0 meaning "did not
+// appear in that list") and fused_score, plus the raw search_similarity
+// (cosine similarity) and search_bm25_rank (ts_rank score) signals
+// behind those ranks, when the document appeared in the corresponding
+// list, so callers can debug relevance beyond just position.
+func (r *ContentRepository) SearchHybrid(ctx context.Context, tenantID string, embedding []float32, query string, opts ListOptions) ([]*models.Content, int, error) {
+	// Pull a wider candidate pool from each ranked list than the final
+	// page size so fusion has enough material to re-rank across both
+	// signals before truncating to what the caller asked for.
+	poolSize := opts.Limit * 4
+	if poolSize < 40 {
+		poolSize = 40
+	}
+	poolOpts := opts
+	poolOpts.Page = 1
+	poolOpts.Limit = poolSize
+
+	var (
+		vectorResults []*models.Content
+		textResults   []*models.Content
+		total         int
+		vectorErr     error
+		textErr       error
+		wg            sync.WaitGroup
+	)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		vectorResults, total, vectorErr = r.SearchSemantic(ctx, tenantID, embedding, poolOpts)
+	}()
+	go func() {
+		defer wg.Done()
+		textResults, _, textErr = r.SearchFullText(ctx, tenantID, query, poolOpts)
+	}()
+	wg.Wait()
+
+	if vectorErr != nil {
+		return nil, 0, vectorErr
+	}
+	if textErr != nil {
+		return nil, 0, textErr
+	}
+
+	type fused struct {
+		content    *models.Content
+		vectorRank int
+		bm25Rank   int
+		score      float64
+		similarity float64
+		hasSim     bool
+		bm25Score  float64
+		hasBM25    bool
+	}
+
+	byID := make(map[uuid.UUID]*fused)
+	order := make([]uuid.UUID, 0, len(vectorResults)+len(textResults))
+
+	for i, c := range vectorResults {
+		f := &fused{content: c, vectorRank: i + 1}
+		f.score += 1.0 / float64(rrfK+i+1)
+		if sim, ok := c.Metadata["search_similarity"].(float64); ok {
+			f.similarity, f.hasSim = sim, true
+		}
+		byID[c.ID] = f
+		order = append(order, c.ID)
+	}
+	for i, c := range textResults {
+		rank := i + 1
+		bm25, hasBM25 := c.Metadata["bm25_score"].(float64)
+		if f, ok := byID[c.ID]; ok {
+			f.bm25Rank = rank
+			f.score += 1.0 / float64(rrfK+rank)
+			f.bm25Score, f.hasBM25 = bm25, hasBM25
+		} else {
+			f := &fused{content: c, bm25Rank: rank, bm25Score: bm25, hasBM25: hasBM25}
+			f.score += 1.0 / float64(rrfK+rank)
+			byID[c.ID] = f
+			order = append(order, c.ID)
+		}
+	}
+
+	results := make([]*fused, 0, len(order))
+	for _, id := range order {
+		results = append(results, byID[id])
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].score > results[j].score })
+
+	offset := (opts.Page - 1) * opts.Limit
+	if offset > len(results) {
+		offset = len(results)
+	}
+	end := offset + opts.Limit
+	if end > len(results) {
+		end = len(results)
+	}
+
+	page := make([]*models.Content, 0, end-offset)
+	for _, f := range results[offset:end] {
+		if f.content.Metadata == nil {
+			f.content.Metadata = make(models.JSONB)
+		}
+		f.content.Metadata["vector_rank"] = f.vectorRank
+		f.content.Metadata["bm25_rank"] = f.bm25Rank
+		f.content.Metadata["fused_score"] = f.score
+		if f.hasSim {
+			f.content.Metadata["search_similarity"] = f.similarity
+		}
+		if f.hasBM25 {
+			f.content.Metadata["search_bm25_rank"] = f.bm25Score
+		}
+		page = append(page, f.content)
+	}
+
+	if total < len(results) {
+		total = len(results)
+	}
+
+	return page, total, nil
+}
+
 // GetTrending returns trending content based on engagement score
 func (r *ContentRepository) GetTrending(ctx context.Context, tenantID string, timeRange string, limit int) ([]*models.Content, error) {
+	ctx, cancel := r.withQueryDeadline(ctx)
+	defer cancel()
+	defer recordTiming(ctx, "GetTrending", time.Now())
+
 	// Calculate time threshold
 	var timeThreshold string
 	switch timeRange {
@@ -328,6 +847,70 @@ func (r *ContentRepository) SoftDelete(ctx context.Context, tenantID string, con
 	return nil
 }
 
+// ErrVersionMismatch is returned by Patch when ifMatch does not match the
+// row's current updated_at, signalling a lost-update conflict to the caller.
+var ErrVersionMismatch = fmt.Errorf("content has been modified since ifMatch")
+
+// Patch applies a sparse update to content's title/category/geo_classification/
+// tags/metadata, touching only the fields present in `fields`, and returns
+// the row's new updated_at. If ifMatch is non-empty it must equal the row's
+// current updated_at (RFC3339) or Patch returns ErrVersionMismatch without
+// writing, so two concurrent moderator edits can't silently clobber one
+// another.
+func (r *ContentRepository) Patch(ctx context.Context, tenantID string, contentID uuid.UUID, fields map[string]interface{}, ifMatch string) (time.Time, error) {
+	allowed := map[string]bool{
+		"title": true, "category": true, "geo_classification": true, "tags": true, "metadata": true,
+	}
+
+	setClauses := []string{"updated_at = NOW()"}
+	args := []interface{}{tenantID, contentID}
+	argCount := 2
+
+	for field, value := range fields {
+		if !allowed[field] {
+			return time.Time{}, fmt.Errorf("field %q cannot be patched", field)
+		}
+		argCount++
+		setClauses = append(setClauses, fmt.Sprintf("%s = $%d", field, argCount))
+		args = append(args, value)
+	}
+
+	if len(setClauses) == 1 {
+		return time.Time{}, fmt.Errorf("no fields to patch")
+	}
+
+	whereClause := "tenant_id = $1 AND id = $2"
+	if ifMatch != "" {
+		argCount++
+		whereClause += fmt.Sprintf(" AND updated_at = $%d", argCount)
+		args = append(args, ifMatch)
+	}
+
+	query := fmt.Sprintf(
+		"UPDATE content SET %s WHERE %s RETURNING updated_at",
+		strings.Join(setClauses, ", "), whereClause,
+	)
+
+	var updatedAt time.Time
+	err := r.db.GetContext(ctx, &updatedAt, query, args...)
+	if err == sql.ErrNoRows {
+		if ifMatch != "" {
+			// Distinguish "row doesn't exist" from "row was modified" so
+			// the handler can return 404 vs 412 appropriately.
+			var exists bool
+			if checkErr := r.db.GetContext(ctx, &exists, "SELECT EXISTS(SELECT 1 FROM content WHERE tenant_id = $1 AND id = $2)", tenantID, contentID); checkErr == nil && exists {
+				return time.Time{}, ErrVersionMismatch
+			}
+		}
+		return time.Time{}, fmt.Errorf("content not found")
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to patch content: %w", err)
+	}
+
+	return updatedAt, nil
+}
+
 // ListOptions contains filtering and pagination options
 type ListOptions struct {
 	Page              int
@@ -340,10 +923,83 @@ type ListOptions struct {
 	Order             string
 	DateFrom          string
 	DateTo            string
+
+	// Cursor, when set, switches ListKeyset/ListByCreatorKeyset from
+	// offset pagination to keyset (seek) pagination: it is the opaque
+	// NextCursor a previous call returned, and the next page picks up
+	// strictly after the row it encodes rather than re-counting Page
+	// pages of rows. Ignored by List and ListByCreator.
+	Cursor string
 }
 
-// ListByCreator retrieves content for a specific creator
+// keysetCursor is the decoded form of a ListOptions.Cursor: the sort
+// column's value and the id of the last row the caller saw, so the next
+// page's query can seek past it with a single indexed comparison
+// instead of an OFFSET scan.
+type keysetCursor struct {
+	SortValue string    `json:"v"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func encodeCursor(sortValue string, id uuid.UUID) string {
+	raw, _ := json.Marshal(keysetCursor{SortValue: sortValue, ID: id})
+	return base64.URLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(cursor string) (keysetCursor, error) {
+	raw, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return keysetCursor{}, fmt.Errorf("invalid cursor: %w", err)
+	}
+	var kc keysetCursor
+	if err := json.Unmarshal(raw, &kc); err != nil {
+		return keysetCursor{}, fmt.Errorf("invalid cursor: %w", err)
+	}
+	return kc, nil
+}
+
+// keysetSortColumn maps a ListOptions.SortBy value to the content column
+// to seek on and the Postgres type to cast the cursor's encoded value
+// back to, matching the same whitelist List and ListByCreator already
+// sort by.
+func keysetSortColumn(sortBy string) (col, pgType string) {
+	switch sortBy {
+	case "engagement_score":
+		return "engagement_score", "double precision"
+	case "quality_score":
+		return "quality_score", "double precision"
+	case "view_count":
+		return "view_count", "bigint"
+	default:
+		return "published_at", "timestamptz"
+	}
+}
+
+// keysetSortValue formats content's value in its sort column the same
+// way encodeCursor/decodeCursor round-trip it, so the next query's
+// WHERE (col, id) < ($1::type, $2::uuid) comparison sees the type it
+// expects.
+func keysetSortValue(content *models.Content, sortCol string) string {
+	switch sortCol {
+	case "engagement_score":
+		return strconv.FormatFloat(content.EngagementScore, 'f', -1, 64)
+	case "quality_score":
+		return strconv.FormatFloat(content.QualityScore, 'f', -1, 64)
+	case "view_count":
+		return strconv.FormatInt(content.ViewCount, 10)
+	default:
+		return content.PublishedAt.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+// ListByCreator retrieves content for a specific creator, paging by
+// OFFSET. Deprecated for deep/indefinite pagination in favor of
+// ListByCreatorKeyset, for the same reason documented on List.
 func (r *ContentRepository) ListByCreator(ctx context.Context, tenantID string, creatorID uuid.UUID, opts ListOptions) ([]*models.Content, int, error) {
+	ctx, cancel := r.withQueryDeadline(ctx)
+	defer cancel()
+	defer recordTiming(ctx, "ListByCreator", time.Now())
+
 	// Build query
 	query := `
 		SELECT c.id, c.tenant_id, c.creator_id, c.platform, c.platform_content_id, c.content_type,
@@ -422,3 +1078,91 @@ func (r *ContentRepository) ListByCreator(ctx context.Context, tenantID string,
 
 	return contents, total, nil
 }
+
+// ListByCreatorKeyset is the seek-pagination sibling of ListByCreator -
+// see ListKeyset for the pagination model. opts.Page and the returned
+// total are not used; the returned NextCursor is empty once there is no
+// further page.
+func (r *ContentRepository) ListByCreatorKeyset(ctx context.Context, tenantID string, creatorID uuid.UUID, opts ListOptions) ([]*models.Content, string, error) {
+	whereClauses := []string{"c.tenant_id = $1", "c.creator_id = $2", "c.processing_status = 'completed'"}
+	args := []interface{}{tenantID, creatorID}
+	argCount := 2
+
+	if opts.Category != "" {
+		argCount++
+		whereClauses = append(whereClauses, fmt.Sprintf("c.category = $%d", argCount))
+		args = append(args, opts.Category)
+	}
+
+	sortCol, pgType := keysetSortColumn(opts.SortBy)
+	descending := !(sortCol == "published_at" && opts.Order == "asc")
+
+	if opts.Cursor != "" {
+		kc, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		cmp := "<"
+		if !descending {
+			cmp = ">"
+		}
+		argCount++
+		sortArg := argCount
+		argCount++
+		idArg := argCount
+		whereClauses = append(whereClauses, fmt.Sprintf("(c.%s, c.id) %s ($%d::%s, $%d::uuid)", sortCol, cmp, sortArg, pgType, idArg))
+		args = append(args, kc.SortValue, kc.ID)
+	}
+
+	dir := "DESC"
+	if !descending {
+		dir = "ASC"
+	}
+
+	limit := opts.Limit
+	if limit < 1 {
+		limit = 20
+	}
+	argCount++
+	args = append(args, limit+1)
+
+	query := fmt.Sprintf(`
+		SELECT c.id, c.tenant_id, c.creator_id, c.platform, c.platform_content_id, c.content_type,
+		       c.title, c.description, c.original_url, c.thumbnail_url, c.summary, c.category,
+		       c.tags, c.geo_classification, c.source_location, c.sentiment, c.quality_score,
+		       c.engagement_score, c.view_count, c.like_count, c.comment_count, c.share_count,
+		       c.processing_status, c.processed_at, c.published_at, c.ai_analysis, c.metadata,
+		       c.featured_until, c.created_at, c.updated_at
+		FROM content c
+		WHERE %s
+		ORDER BY c.%s %s, c.id %s
+		LIMIT $%d
+	`, strings.Join(whereClauses, " AND "), sortCol, dir, dir, argCount)
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query creator content: %w", err)
+	}
+	defer rows.Close()
+
+	var contents []*models.Content
+	for rows.Next() {
+		var content models.Content
+		if err := rows.StructScan(&content); err != nil {
+			return nil, "", fmt.Errorf("failed to scan content: %w", err)
+		}
+		contents = append(contents, &content)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	var nextCursor string
+	if len(contents) > limit {
+		contents = contents[:limit]
+		last := contents[len(contents)-1]
+		nextCursor = encodeCursor(keysetSortValue(last, sortCol), last.ID)
+	}
+
+	return contents, nextCursor, nil
+}
@@ -0,0 +1,259 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+// defaultBulkUpsertBatchSize is how many creators BulkUpsert sends to
+// Postgres per INSERT, bounding each statement's placeholder count
+// regardless of how large the crawler's ingest is.
+const defaultBulkUpsertBatchSize = 500
+
+// BulkUpsertStatus is the outcome BulkUpsert recorded for one input
+// creator.
+type BulkUpsertStatus string
+
+const (
+	BulkUpsertInserted BulkUpsertStatus = "inserted"
+	BulkUpsertUpdated  BulkUpsertStatus = "updated"
+	BulkUpsertSkipped  BulkUpsertStatus = "skipped"
+)
+
+// BulkUpsertRow reports what happened to one creator passed to
+// BulkUpsert. Err is only set for BulkUpsertSkipped rows.
+type BulkUpsertRow struct {
+	PlatformID string
+	ID         uuid.UUID
+	Status     BulkUpsertStatus
+	Err        error
+}
+
+// BulkUpsertResult is BulkUpsert's return value. Rows holds one entry
+// per input creator, not necessarily in input order; Inserted/Updated/
+// Skipped are Rows' status counts for callers that don't need per-row
+// detail.
+type BulkUpsertResult struct {
+	Rows     []BulkUpsertRow
+	Inserted int
+	Updated  int
+	Skipped  int
+}
+
+const bulkUpsertColumns = `id, tenant_id, name, platform, platform_id, avatar_url, bio, tier,
+        verified_at, follower_count, content_count, engagement_rate,
+        topics_expertise, social_links, metadata, active, created_at, updated_at`
+
+const bulkUpsertColumnCount = 18
+
+// bulkUpsertOnConflict always replaces descriptive columns with the
+// latest observation, but only advances follower_count/engagement_rate
+// if this observation's updated_at is newer, and never lets a null
+// verified_at clear an existing one.
+const bulkUpsertOnConflict = `ON CONFLICT (tenant_id, platform, platform_id) DO UPDATE SET
+    name             = EXCLUDED.name,
+    avatar_url       = EXCLUDED.avatar_url,
+    bio              = EXCLUDED.bio,
+    tier             = EXCLUDED.tier,
+    topics_expertise = EXCLUDED.topics_expertise,
+    social_links     = EXCLUDED.social_links,
+    metadata         = EXCLUDED.metadata,
+    active           = EXCLUDED.active,
+    follower_count   = CASE WHEN EXCLUDED.updated_at > creators.updated_at THEN EXCLUDED.follower_count ELSE creators.follower_count END,
+    content_count    = CASE WHEN EXCLUDED.updated_at > creators.updated_at THEN EXCLUDED.content_count ELSE creators.content_count END,
+    engagement_rate  = CASE WHEN EXCLUDED.updated_at > creators.updated_at THEN EXCLUDED.engagement_rate ELSE creators.engagement_rate END,
+    verified_at      = COALESCE(EXCLUDED.verified_at, creators.verified_at),
+    updated_at       = GREATEST(EXCLUDED.updated_at, creators.updated_at)
+RETURNING id, tenant_id, platform, platform_id, (xmax = 0) AS inserted`
+
+// BulkUpsert ingests creators in batches of defaultBulkUpsertBatchSize,
+// each batch landing as a single INSERT ... ON CONFLICT statement keyed
+// on (tenant_id, platform, platform_id). A batch that fails outright is
+// rolled back to its savepoint and retried one row at a time, so only
+// the offending rows come back BulkUpsertSkipped.
+func (r *CreatorRepository) BulkUpsert(ctx context.Context, creators []*models.Creator) (BulkUpsertResult, error) {
+	var result BulkUpsertResult
+	if len(creators) == 0 {
+		return result, nil
+	}
+
+	for _, c := range creators {
+		if c.ID == uuid.Nil {
+			c.ID = uuid.New()
+		}
+	}
+
+	err := r.db.WithTransaction(ctx, func(tx *sqlx.Tx) error {
+		for i, start := 0, 0; start < len(creators); i, start = i+1, start+defaultBulkUpsertBatchSize {
+			end := start + defaultBulkUpsertBatchSize
+			if end > len(creators) {
+				end = len(creators)
+			}
+
+			rows, err := bulkUpsertBatch(ctx, tx, creators[start:end], i)
+			if err != nil {
+				return fmt.Errorf("failed to bulk upsert creators: %w", err)
+			}
+			result.Rows = append(result.Rows, rows...)
+		}
+		return nil
+	})
+	if err != nil {
+		return BulkUpsertResult{}, err
+	}
+
+	for _, row := range result.Rows {
+		switch row.Status {
+		case BulkUpsertInserted:
+			result.Inserted++
+		case BulkUpsertUpdated:
+			result.Updated++
+		case BulkUpsertSkipped:
+			result.Skipped++
+		}
+	}
+	return result, nil
+}
+
+// bulkUpsertBatch runs batch as one multi-row upsert under a savepoint
+// named for its position, so sibling batches are unaffected if this
+// one has to roll back and retry row by row.
+func bulkUpsertBatch(ctx context.Context, tx *sqlx.Tx, batch []*models.Creator, batchIndex int) ([]BulkUpsertRow, error) {
+	savepoint := fmt.Sprintf("bulk_upsert_%d", batchIndex)
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+		return nil, err
+	}
+
+	rows, err := execBulkUpsert(ctx, tx, batch)
+	if err == nil {
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+savepoint); err != nil {
+			return nil, err
+		}
+		return rows, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+savepoint); err != nil {
+		return nil, err
+	}
+
+	retried := make([]BulkUpsertRow, 0, len(batch))
+	for j, c := range batch {
+		rowSavepoint := fmt.Sprintf("%s_row_%d", savepoint, j)
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT "+rowSavepoint); err != nil {
+			return nil, err
+		}
+
+		rs, err := execBulkUpsert(ctx, tx, []*models.Creator{c})
+		if err != nil {
+			if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+rowSavepoint); rbErr != nil {
+				return nil, rbErr
+			}
+			retried = append(retried, BulkUpsertRow{PlatformID: c.PlatformID, Status: BulkUpsertSkipped, Err: err})
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+rowSavepoint); err != nil {
+			return nil, err
+		}
+		retried = append(retried, rs...)
+	}
+	return retried, nil
+}
+
+// execBulkUpsert builds and runs a single INSERT ... ON CONFLICT
+// statement covering every creator in batch, after deduplicating
+// creators that share a conflict key (tenant_id, platform, platform_id)
+// - Postgres rejects a multi-row ON CONFLICT DO UPDATE that targets the
+// same row twice.
+func execBulkUpsert(ctx context.Context, tx *sqlx.Tx, batch []*models.Creator) ([]BulkUpsertRow, error) {
+	order, byKey := dedupeBulkUpsertBatch(batch)
+
+	args := make([]interface{}, 0, len(order)*bulkUpsertColumnCount)
+	placeholders := make([]string, 0, len(order))
+
+	for i, key := range order {
+		c := byKey[key]
+		ph := make([]string, bulkUpsertColumnCount)
+		for j := 0; j < bulkUpsertColumnCount; j++ {
+			ph[j] = fmt.Sprintf("$%d", i*bulkUpsertColumnCount+j+1)
+		}
+		placeholders = append(placeholders, "("+strings.Join(ph, ", ")+")")
+		args = append(args,
+			c.ID, c.TenantID, c.Name, c.Platform, c.PlatformID, c.AvatarURL, c.Bio, c.Tier,
+			c.VerifiedAt, c.FollowerCount, c.ContentCount, c.EngagementRate,
+			c.TopicsExpertise, c.SocialLinks, c.Metadata, c.Active, c.CreatedAt, c.UpdatedAt,
+		)
+	}
+
+	query := fmt.Sprintf("INSERT INTO creators (%s)\nVALUES %s\n%s",
+		bulkUpsertColumns, strings.Join(placeholders, ", "), bulkUpsertOnConflict)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rowsByKey := make(map[string]BulkUpsertRow, len(order))
+	for rows.Next() {
+		var id uuid.UUID
+		var tenantID, platform, platformID string
+		var inserted bool
+		if err := rows.Scan(&id, &tenantID, &platform, &platformID, &inserted); err != nil {
+			return nil, err
+		}
+
+		status := BulkUpsertUpdated
+		if inserted {
+			status = BulkUpsertInserted
+		}
+		key := bulkUpsertKey(tenantID, platform, platformID)
+		rowsByKey[key] = BulkUpsertRow{PlatformID: platformID, ID: id, Status: status}
+		if c, ok := byKey[key]; ok {
+			c.ID = id
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// One result per input creator, even though duplicates were folded
+	// into a single upserted row above, so a caller iterating batch can
+	// still match every creator it passed in to a status.
+	results := make([]BulkUpsertRow, 0, len(batch))
+	for _, c := range batch {
+		key := bulkUpsertKey(c.TenantID, string(c.Platform), c.PlatformID)
+		row, ok := rowsByKey[key]
+		if !ok {
+			return nil, fmt.Errorf("bulk upsert: no result row for tenant %s platform %s platform_id %s", c.TenantID, c.Platform, c.PlatformID)
+		}
+		row.PlatformID = c.PlatformID
+		c.ID = row.ID
+		results = append(results, row)
+	}
+	return results, nil
+}
+
+func bulkUpsertKey(tenantID, platform, platformID string) string {
+	return tenantID + "\x00" + platform + "\x00" + platformID
+}
+
+// dedupeBulkUpsertBatch collapses batch down to one creator per conflict
+// key (tenant_id, platform, platform_id), keeping the last occurrence.
+// order preserves each key's first-seen position.
+func dedupeBulkUpsertBatch(batch []*models.Creator) (order []string, byKey map[string]*models.Creator) {
+	byKey = make(map[string]*models.Creator, len(batch))
+	order = make([]string, 0, len(batch))
+	for _, c := range batch {
+		key := bulkUpsertKey(c.TenantID, string(c.Platform), c.PlatformID)
+		if _, seen := byKey[key]; !seen {
+			order = append(order, key)
+		}
+		byKey[key] = c
+	}
+	return order, byKey
+}
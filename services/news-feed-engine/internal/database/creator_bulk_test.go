@@ -0,0 +1,58 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+func TestDedupeBulkUpsertBatchKeepsLastOccurrence(t *testing.T) {
+	now := time.Now()
+	stale := &models.Creator{
+		ID:         uuid.New(),
+		TenantID:   "tenant-1",
+		Platform:   models.Platform("youtube"),
+		PlatformID: "ch-1",
+		Name:       "Stale Name",
+		UpdatedAt:  now.Add(-time.Hour),
+	}
+	fresh := &models.Creator{
+		ID:         uuid.New(),
+		TenantID:   "tenant-1",
+		Platform:   models.Platform("youtube"),
+		PlatformID: "ch-1",
+		Name:       "Fresh Name",
+		UpdatedAt:  now,
+	}
+	other := &models.Creator{
+		ID:         uuid.New(),
+		TenantID:   "tenant-1",
+		Platform:   models.Platform("youtube"),
+		PlatformID: "ch-2",
+		Name:       "Other Creator",
+		UpdatedAt:  now,
+	}
+
+	order, byKey := dedupeBulkUpsertBatch([]*models.Creator{stale, fresh, other})
+
+	assert.Len(t, order, 2, "two distinct conflict keys should survive dedup")
+	assert.Equal(t, fresh, byKey[bulkUpsertKey("tenant-1", "youtube", "ch-1")], "the last occurrence of a duplicate key should win")
+	assert.Equal(t, other, byKey[bulkUpsertKey("tenant-1", "youtube", "ch-2")])
+}
+
+func TestDedupeBulkUpsertBatchNoDuplicates(t *testing.T) {
+	batch := []*models.Creator{
+		{TenantID: "t1", Platform: models.Platform("tiktok"), PlatformID: "a"},
+		{TenantID: "t1", Platform: models.Platform("tiktok"), PlatformID: "b"},
+		{TenantID: "t2", Platform: models.Platform("tiktok"), PlatformID: "a"},
+	}
+
+	order, byKey := dedupeBulkUpsertBatch(batch)
+
+	assert.Len(t, order, 3)
+	assert.Len(t, byKey, 3)
+}
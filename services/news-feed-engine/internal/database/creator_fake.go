@@ -0,0 +1,291 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database/sqlcgen"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+// FakeCreatorQuerier is an in-memory creatorQuerier for unit tests that
+// don't want a live Postgres connection. It replicates ListCreators'
+// filter/sort/paginate behavior and the rest of the generated queries'
+// basic semantics closely enough to exercise CreatorRepository's Go-side
+// logic (List's count-then-page flow, GetByID's not-found mapping,
+// Update's whitelist-by-struct behavior) without a database.
+type FakeCreatorQuerier struct {
+	mu       sync.Mutex
+	creators map[uuid.UUID]models.Creator
+}
+
+// NewFakeCreatorQuerier creates an empty FakeCreatorQuerier.
+func NewFakeCreatorQuerier() *FakeCreatorQuerier {
+	return &FakeCreatorQuerier{creators: make(map[uuid.UUID]models.Creator)}
+}
+
+// Seed inserts or overwrites a creator directly, bypassing CreateCreator,
+// so a test can set up fixture data without going through ID generation.
+func (f *FakeCreatorQuerier) Seed(c models.Creator) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.creators[c.ID] = c
+}
+
+func (f *FakeCreatorQuerier) matches(c models.Creator, tenantID string, platform, tier *string, verifiedOnly bool, active *bool) bool {
+	if c.TenantID != tenantID {
+		return false
+	}
+	if platform != nil && string(c.Platform) != *platform {
+		return false
+	}
+	if tier != nil && string(c.Tier) != *tier {
+		return false
+	}
+	if verifiedOnly && c.VerifiedAt == nil {
+		return false
+	}
+	if active != nil && c.Active != *active {
+		return false
+	}
+	return true
+}
+
+func (f *FakeCreatorQuerier) ListCreators(ctx context.Context, arg sqlcgen.ListCreatorsParams) ([]models.Creator, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var matched []models.Creator
+	for _, c := range f.creators {
+		if f.matches(c, arg.TenantID, arg.Platform, arg.Tier, arg.VerifiedOnly, arg.Active) {
+			matched = append(matched, c)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		ki, kj := sortKey(matched[i], arg.SortBy), sortKey(matched[j], arg.SortBy)
+		if arg.Ascending {
+			return ki < kj
+		}
+		return ki > kj
+	})
+
+	start := int(arg.OffsetCount)
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := start + int(arg.LimitCount)
+	if end > len(matched) {
+		end = len(matched)
+	}
+	return matched[start:end], nil
+}
+
+func (f *FakeCreatorQuerier) ListCreatorsByCursor(ctx context.Context, arg sqlcgen.ListCreatorsByCursorParams) ([]models.Creator, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var matched []models.Creator
+	for _, c := range f.creators {
+		if f.matches(c, arg.TenantID, arg.Platform, arg.Tier, arg.VerifiedOnly, arg.Active) {
+			matched = append(matched, c)
+		}
+	}
+
+	signedKey := func(c models.Creator) float64 {
+		k := sortKey(c, arg.SortBy)
+		if !arg.Ascending {
+			k = -k
+		}
+		return k
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		ki, kj := signedKey(matched[i]), signedKey(matched[j])
+		if ki != kj {
+			return ki < kj
+		}
+		return matched[i].ID.String() < matched[j].ID.String()
+	})
+
+	if arg.CursorSortKey != nil {
+		cutoff := *arg.CursorSortKey
+		filtered := matched[:0]
+		for _, c := range matched {
+			k := signedKey(c)
+			if k > cutoff || (k == cutoff && c.ID.String() > arg.CursorID.String()) {
+				filtered = append(filtered, c)
+			}
+		}
+		matched = filtered
+	}
+
+	if int(arg.LimitCount) < len(matched) {
+		matched = matched[:arg.LimitCount]
+	}
+	return matched, nil
+}
+
+func sortKey(c models.Creator, sortBy string) float64 {
+	switch sortBy {
+	case "follower_count":
+		return float64(c.FollowerCount)
+	case "content_count":
+		return float64(c.ContentCount)
+	case "engagement_rate":
+		return c.EngagementRate
+	default:
+		return float64(c.CreatedAt.Unix())
+	}
+}
+
+func (f *FakeCreatorQuerier) CountCreators(ctx context.Context, arg sqlcgen.CountCreatorsParams) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var total int64
+	for _, c := range f.creators {
+		if f.matches(c, arg.TenantID, arg.Platform, arg.Tier, arg.VerifiedOnly, arg.Active) {
+			total++
+		}
+	}
+	return total, nil
+}
+
+func (f *FakeCreatorQuerier) GetCreatorByID(ctx context.Context, arg sqlcgen.GetCreatorByIDParams) (models.Creator, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c, ok := f.creators[arg.ID]
+	if !ok || c.TenantID != arg.TenantID {
+		return models.Creator{}, sql.ErrNoRows
+	}
+	return c, nil
+}
+
+func (f *FakeCreatorQuerier) GetCreatorByPlatformID(ctx context.Context, arg sqlcgen.GetCreatorByPlatformIDParams) (models.Creator, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, c := range f.creators {
+		if c.TenantID == arg.TenantID && string(c.Platform) == arg.Platform && c.PlatformID == arg.PlatformID {
+			return c, nil
+		}
+	}
+	return models.Creator{}, sql.ErrNoRows
+}
+
+func (f *FakeCreatorQuerier) GetTopCreators(ctx context.Context, arg sqlcgen.GetTopCreatorsParams) ([]models.Creator, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var matched []models.Creator
+	for _, c := range f.creators {
+		if c.TenantID == arg.TenantID && c.Active {
+			matched = append(matched, c)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if arg.ByEngagement {
+			return matched[i].EngagementRate > matched[j].EngagementRate
+		}
+		return matched[i].FollowerCount > matched[j].FollowerCount
+	})
+
+	if int(arg.LimitCount) < len(matched) {
+		matched = matched[:arg.LimitCount]
+	}
+	return matched, nil
+}
+
+func (f *FakeCreatorQuerier) CreateCreator(ctx context.Context, arg sqlcgen.CreateCreatorParams) (sqlcgen.CreateCreatorRow, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c := models.Creator{
+		ID: arg.ID, TenantID: arg.TenantID, Name: arg.Name, Platform: arg.Platform,
+		PlatformID: arg.PlatformID, AvatarURL: arg.AvatarURL, Bio: arg.Bio, Tier: arg.Tier,
+		FollowerCount: arg.FollowerCount, ContentCount: arg.ContentCount, EngagementRate: arg.EngagementRate,
+		TopicsExpertise: arg.TopicsExpertise, SocialLinks: arg.SocialLinks, Metadata: arg.Metadata,
+		Active: arg.Active,
+	}
+	f.creators[c.ID] = c
+	return sqlcgen.CreateCreatorRow{CreatedAt: c.CreatedAt, UpdatedAt: c.UpdatedAt}, nil
+}
+
+func (f *FakeCreatorQuerier) UpdateCreator(ctx context.Context, arg sqlcgen.UpdateCreatorParams) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c, ok := f.creators[arg.ID]
+	if !ok || c.TenantID != arg.TenantID {
+		return 0, nil
+	}
+
+	if arg.Name != nil {
+		c.Name = *arg.Name
+	}
+	if arg.Bio != nil {
+		c.Bio = *arg.Bio
+	}
+	if arg.AvatarURL != nil {
+		c.AvatarURL = *arg.AvatarURL
+	}
+	if arg.FollowerCount != nil {
+		c.FollowerCount = *arg.FollowerCount
+	}
+	if arg.EngagementRate != nil {
+		c.EngagementRate = *arg.EngagementRate
+	}
+	if arg.VerifiedAt != nil {
+		c.VerifiedAt = arg.VerifiedAt
+	}
+	if arg.Tier != nil {
+		c.Tier = *arg.Tier
+	}
+	if arg.Platform != nil {
+		c.Platform = *arg.Platform
+	}
+	if arg.Active != nil {
+		c.Active = *arg.Active
+	}
+	if arg.Metadata != nil {
+		c.Metadata = arg.Metadata
+	}
+	if arg.TopicsExpertise != nil {
+		c.TopicsExpertise = arg.TopicsExpertise
+	}
+
+	f.creators[c.ID] = c
+	return 1, nil
+}
+
+func (f *FakeCreatorQuerier) SoftDeleteCreator(ctx context.Context, arg sqlcgen.SoftDeleteCreatorParams) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c, ok := f.creators[arg.ID]
+	if !ok || c.TenantID != arg.TenantID {
+		return 0, nil
+	}
+	c.Active = false
+	f.creators[c.ID] = c
+	return 1, nil
+}
+
+func (f *FakeCreatorQuerier) VerifyCreator(ctx context.Context, arg sqlcgen.VerifyCreatorParams) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c, ok := f.creators[arg.ID]
+	if !ok || c.TenantID != arg.TenantID {
+		return 0, nil
+	}
+	c.Tier = arg.Tier
+	f.creators[c.ID] = c
+	return 1, nil
+}
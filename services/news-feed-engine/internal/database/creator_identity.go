@@ -0,0 +1,257 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+// minCandidateConfidence is the identityMatchScore a creator pair must
+// clear to surface from FindCandidates at all; below this the match is
+// noise rather than a real duplicate worth a human's attention.
+const minCandidateConfidence = 0.5
+
+// CandidateMatch is a creator FindCandidates considers a likely
+// cross-platform duplicate of the one it was asked about, along with
+// the confidence identityMatchScore assigned the pair.
+type CandidateMatch struct {
+	Creator    models.Creator
+	Confidence float64
+}
+
+// FindCandidates scores every other active creator on a different
+// platform against creatorID's name, social links, bio, and avatar, and
+// returns the ones that clear minCandidateConfidence, most confident
+// first. Creators already linked to creatorID's identity are excluded.
+func (r *CreatorRepository) FindCandidates(ctx context.Context, tenantID string, creatorID uuid.UUID) ([]CandidateMatch, error) {
+	target, err := r.GetByID(ctx, tenantID, creatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	others, err := r.listOtherActiveCreators(ctx, tenantID, creatorID, target.Platform)
+	if err != nil {
+		return nil, err
+	}
+
+	linked, err := r.linkedCreatorIDSet(ctx, tenantID, creatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []CandidateMatch
+	for _, candidate := range others {
+		if linked[candidate.ID] {
+			continue
+		}
+		confidence := identityMatchScore(ctx, *target, candidate)
+		if confidence >= minCandidateConfidence {
+			candidates = append(candidates, CandidateMatch{Creator: candidate, Confidence: confidence})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Confidence > candidates[j].Confidence })
+	return candidates, nil
+}
+
+// listOtherActiveCreators returns every active creator in tenantID other
+// than creatorID, excluding excludePlatform, since identity linking is
+// about finding the same person's presence on a *different* platform.
+func (r *CreatorRepository) listOtherActiveCreators(ctx context.Context, tenantID string, creatorID uuid.UUID, excludePlatform models.Platform) ([]models.Creator, error) {
+	query := `
+		SELECT c.id, c.tenant_id, c.name, c.platform, c.platform_id, c.avatar_url, c.bio,
+		       c.tier, c.verified_at, c.follower_count, c.content_count, c.engagement_rate,
+		       c.topics_expertise, c.social_links, c.metadata, c.active, c.created_at, c.updated_at
+		FROM creators c
+		WHERE c.tenant_id = $1 AND c.id != $2 AND c.platform != $3 AND c.active = true
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, creatorID, excludePlatform)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query candidate creators: %w", err)
+	}
+	defer rows.Close()
+
+	var creators []models.Creator
+	for rows.Next() {
+		var c models.Creator
+		if err := rows.Scan(
+			&c.ID, &c.TenantID, &c.Name, &c.Platform, &c.PlatformID, &c.AvatarURL, &c.Bio,
+			&c.Tier, &c.VerifiedAt, &c.FollowerCount, &c.ContentCount, &c.EngagementRate,
+			&c.TopicsExpertise, &c.SocialLinks, &c.Metadata, &c.Active, &c.CreatedAt, &c.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan candidate creator: %w", err)
+		}
+		creators = append(creators, c)
+	}
+	return creators, nil
+}
+
+// linkedCreatorIDSet returns the set of creator IDs already sharing
+// creatorID's active identity (not including creatorID itself).
+func (r *CreatorRepository) linkedCreatorIDSet(ctx context.Context, tenantID string, creatorID uuid.UUID) (map[uuid.UUID]bool, error) {
+	linked, err := r.GetLinkedCreators(ctx, tenantID, creatorID)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[uuid.UUID]bool, len(linked))
+	for _, c := range linked {
+		set[c.ID] = true
+	}
+	return set, nil
+}
+
+// GetLinkedCreators returns every creator sharing creatorID's active
+// identity (per creator_identities), not including creatorID itself.
+// Returns an empty slice, not an error, if creatorID isn't linked to
+// anything.
+func (r *CreatorRepository) GetLinkedCreators(ctx context.Context, tenantID string, creatorID uuid.UUID) ([]models.Creator, error) {
+	query := `
+		SELECT c.id, c.tenant_id, c.name, c.platform, c.platform_id, c.avatar_url, c.bio,
+		       c.tier, c.verified_at, c.follower_count, c.content_count, c.engagement_rate,
+		       c.topics_expertise, c.social_links, c.metadata, c.active, c.created_at, c.updated_at
+		FROM creators c
+		JOIN creator_identities ci ON ci.creator_id = c.id AND ci.unlinked_at IS NULL
+		WHERE ci.tenant_id = $1
+		  AND c.id != $2
+		  AND ci.identity_id = (
+		      SELECT identity_id FROM creator_identities
+		      WHERE tenant_id = $1 AND creator_id = $2 AND unlinked_at IS NULL
+		      LIMIT 1
+		  )
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, creatorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query linked creators: %w", err)
+	}
+	defer rows.Close()
+
+	creators := make([]models.Creator, 0)
+	for rows.Next() {
+		var c models.Creator
+		if err := rows.Scan(
+			&c.ID, &c.TenantID, &c.Name, &c.Platform, &c.PlatformID, &c.AvatarURL, &c.Bio,
+			&c.Tier, &c.VerifiedAt, &c.FollowerCount, &c.ContentCount, &c.EngagementRate,
+			&c.TopicsExpertise, &c.SocialLinks, &c.Metadata, &c.Active, &c.CreatedAt, &c.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan linked creator: %w", err)
+		}
+		creators = append(creators, c)
+	}
+	return creators, nil
+}
+
+// LinkIdentities links creatorIDs together as the same real-world
+// identity at the given confidence, returning the identity_id they now
+// share. If any of creatorIDs already belongs to an active identity,
+// that identity_id is reused and every member (existing and new) is
+// upserted onto it at the new confidence, rather than creating a
+// competing identity group. Merging two creators that each already
+// belong to a *different* existing identity isn't supported - Unmerge
+// the conflicting one first.
+func (r *CreatorRepository) LinkIdentities(ctx context.Context, tenantID string, creatorIDs []uuid.UUID, confidence float64) (uuid.UUID, error) {
+	if len(creatorIDs) < 2 {
+		return uuid.Nil, fmt.Errorf("LinkIdentities requires at least two creator IDs")
+	}
+
+	var identityID uuid.UUID
+	err := r.db.WithTransaction(ctx, func(tx *sqlx.Tx) error {
+		existing, err := existingIdentityID(ctx, tx, tenantID, creatorIDs)
+		if err != nil {
+			return err
+		}
+		identityID = existing
+		if identityID == uuid.Nil {
+			identityID = uuid.New()
+		}
+
+		for _, creatorID := range creatorIDs {
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO creator_identities (identity_id, tenant_id, creator_id, confidence, linked_at)
+				VALUES ($1, $2, $3, $4, now())
+				ON CONFLICT (identity_id, creator_id) DO UPDATE SET
+					confidence = EXCLUDED.confidence,
+					linked_at = now(),
+					unlinked_at = NULL
+			`, identityID, tenantID, creatorID, confidence)
+			if err != nil {
+				return fmt.Errorf("failed to link creator %s: %w", creatorID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return identityID, nil
+}
+
+// existingIdentityID returns the identity_id any of creatorIDs already
+// actively belongs to, or uuid.Nil if none of them do.
+func existingIdentityID(ctx context.Context, tx *sqlx.Tx, tenantID string, creatorIDs []uuid.UUID) (uuid.UUID, error) {
+	placeholders := make([]string, len(creatorIDs))
+	args := make([]interface{}, 0, len(creatorIDs)+1)
+	args = append(args, tenantID)
+	for i, id := range creatorIDs {
+		placeholders[i] = fmt.Sprintf("$%d", i+2)
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT identity_id FROM creator_identities
+		WHERE tenant_id = $1 AND creator_id IN (%s) AND unlinked_at IS NULL
+		LIMIT 1`, strings.Join(placeholders, ", "))
+
+	var identityID uuid.UUID
+	err := tx.QueryRowContext(ctx, query, args...).Scan(&identityID)
+	if err == sql.ErrNoRows {
+		return uuid.Nil, nil
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to look up existing identity: %w", err)
+	}
+	return identityID, nil
+}
+
+// Merge links duplicateIDs onto canonicalID's identity, the usual entry
+// point once FindCandidates (or a human reviewer) has decided which
+// records are the same creator. It's a thin wrapper around
+// LinkIdentities that saves the caller from remembering to include
+// canonicalID in the creator list itself.
+func (r *CreatorRepository) Merge(ctx context.Context, tenantID string, canonicalID uuid.UUID, duplicateIDs []uuid.UUID, confidence float64) (uuid.UUID, error) {
+	if len(duplicateIDs) == 0 {
+		return uuid.Nil, fmt.Errorf("Merge requires at least one duplicate creator ID")
+	}
+	return r.LinkIdentities(ctx, tenantID, append([]uuid.UUID{canonicalID}, duplicateIDs...), confidence)
+}
+
+// Unmerge removes creatorID from its identity group. It sets
+// unlinked_at rather than deleting the creator_identities row, so the
+// link's history - who linked it, at what confidence, and when - stays
+// around for audit even though it's no longer active.
+func (r *CreatorRepository) Unmerge(ctx context.Context, tenantID string, creatorID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE creator_identities
+		SET unlinked_at = now()
+		WHERE tenant_id = $1 AND creator_id = $2 AND unlinked_at IS NULL
+	`, tenantID, creatorID)
+	if err != nil {
+		return fmt.Errorf("failed to unmerge creator identity: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to read unmerge result: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("creator %s is not linked to any identity", creatorID)
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package database
+
+import (
+	"context"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"math/bits"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+// Weights for identityMatchScore's four signals. They're renormalized
+// per-pair by weightedScore over whichever signals are actually
+// computable, so a creator with no bio or avatar isn't penalized to a
+// near-zero score just for being sparse.
+const (
+	nameWeight        = 0.35
+	socialLinksWeight = 0.30
+	bioWeight         = 0.15
+	avatarWeight      = 0.20
+)
+
+// avatarHashTimeout bounds how long identityMatchScore will wait on an
+// avatar fetch before giving up on that signal for the pair.
+const avatarHashTimeout = 3 * time.Second
+
+// avatarHashSize is the side length of the grayscale grid avgHash
+// downsamples to; 8x8 gives a 64-bit hash.
+const avatarHashSize = 8
+
+var avatarHTTPClient = &http.Client{Timeout: avatarHashTimeout}
+
+// scoreComponent is one signal feeding into weightedScore. active is
+// false when the signal couldn't be computed for this pair (e.g. one
+// side has no avatar), in which case its weight is excluded from the
+// renormalization rather than counted as a zero match.
+type scoreComponent struct {
+	weight float64
+	value  float64
+	active bool
+}
+
+// weightedScore renormalizes components by the weights of only the
+// active ones, so missing signals don't drag a sparse profile's score
+// down to zero.
+func weightedScore(components []scoreComponent) float64 {
+	var weightSum, valueSum float64
+	for _, c := range components {
+		if !c.active {
+			continue
+		}
+		weightSum += c.weight
+		valueSum += c.weight * c.value
+	}
+	if weightSum == 0 {
+		return 0
+	}
+	return valueSum / weightSum
+}
+
+// identityMatchScore estimates the probability that a and b, active
+// creators on different platforms, are the same real person. It
+// combines normalized-name similarity, social link overlap, bio token
+// similarity, and avatar perceptual hash distance.
+func identityMatchScore(ctx context.Context, a, b models.Creator) float64 {
+	nameScore := jaccardSimilarity(tokenize(normalizeName(a.Name)), tokenize(normalizeName(b.Name)))
+
+	socialScore, socialActive := socialLinksOverlap(a.SocialLinks, b.SocialLinks)
+
+	bioScore := jaccardSimilarity(tokenize(normalizeName(a.Bio)), tokenize(normalizeName(b.Bio)))
+	bioActive := strings.TrimSpace(a.Bio) != "" && strings.TrimSpace(b.Bio) != ""
+
+	avatarScore, avatarActive := avatarDistance(ctx, a, b)
+
+	return weightedScore([]scoreComponent{
+		{weight: nameWeight, value: nameScore, active: true},
+		{weight: socialLinksWeight, value: socialScore, active: socialActive},
+		{weight: bioWeight, value: bioScore, active: bioActive},
+		{weight: avatarWeight, value: avatarScore, active: avatarActive},
+	})
+}
+
+var nameNoisePattern = regexp.MustCompile(`@\w+|\([^)]*\)`)
+
+// normalizeName strips embedded @handles and parenthetical asides, then
+// folds to lowercase letters/digits/spaces only. This is a practical
+// approximation of a true Unicode NFKD fold plus combining-mark strip -
+// this tree doesn't vendor golang.org/x/text/unicode/norm - but handles
+// the common cases (case, punctuation, emoji) that trip up exact-string
+// matching between platforms.
+func normalizeName(name string) string {
+	name = nameNoisePattern.ReplaceAllString(name, " ")
+	var b strings.Builder
+	for _, r := range name {
+		r = unicode.ToLower(r)
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) {
+			b.WriteRune(r)
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func tokenize(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Fields(s)
+}
+
+// jaccardSimilarity is the size of the intersection over the size of
+// the union of a and b, treated as sets. Returns 0 if both are empty.
+func jaccardSimilarity(a, b []string) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	set := make(map[string]struct{}, len(a))
+	for _, tok := range a {
+		set[tok] = struct{}{}
+	}
+	union := make(map[string]struct{}, len(a)+len(b))
+	for tok := range set {
+		union[tok] = struct{}{}
+	}
+	intersection := 0
+	for _, tok := range b {
+		if _, ok := set[tok]; ok {
+			intersection++
+		}
+		union[tok] = struct{}{}
+	}
+	if len(union) == 0 {
+		return 0
+	}
+	return float64(intersection) / float64(len(union))
+}
+
+// socialLinksOverlap returns the Jaccard similarity of a and b's
+// canonicalized URLs, plus whether the comparison was meaningful at all
+// (false if either side has no canonicalizable links).
+func socialLinksOverlap(a, b models.JSONB) (float64, bool) {
+	linksA := canonicalSocialLinks(a)
+	linksB := canonicalSocialLinks(b)
+	if len(linksA) == 0 || len(linksB) == 0 {
+		return 0, false
+	}
+	return jaccardSimilarity(linksA, linksB), true
+}
+
+func canonicalSocialLinks(links models.JSONB) []string {
+	canonical := make([]string, 0, len(links))
+	for _, v := range links {
+		raw, ok := v.(string)
+		if !ok || raw == "" {
+			continue
+		}
+		canonical = append(canonical, canonicalizeURL(raw))
+	}
+	return canonical
+}
+
+// canonicalizeURL lower-cases the host (stripping a leading "www."),
+// drops a trailing slash from the path, and falls back to a plain
+// lowercase/trim-trailing-slash of raw if it doesn't parse as a URL
+// with a host.
+func canonicalizeURL(raw string) string {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || parsed.Host == "" {
+		return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(raw)), "/")
+	}
+	host := strings.TrimPrefix(strings.ToLower(parsed.Host), "www.")
+	path := strings.TrimSuffix(parsed.Path, "/")
+	return host + path
+}
+
+// avatarDistance returns a similarity in [0,1] between a and b's avatar
+// images based on average-hash perceptual distance, and whether the
+// comparison was possible at all (false if either avatar is missing or
+// couldn't be fetched/decoded).
+func avatarDistance(ctx context.Context, a, b models.Creator) (float64, bool) {
+	if a.AvatarURL == "" || b.AvatarURL == "" {
+		return 0, false
+	}
+	hashA, err := avgHash(ctx, a.AvatarURL)
+	if err != nil {
+		return 0, false
+	}
+	hashB, err := avgHash(ctx, b.AvatarURL)
+	if err != nil {
+		return 0, false
+	}
+	return 1 - float64(hammingDistance(hashA, hashB))/64, true
+}
+
+// avgHash computes a 64-bit average hash (aHash) of the image at
+// imageURL: downsample to an 8x8 grayscale grid, then set bit i if
+// pixel i is brighter than the grid's mean brightness.
+func avgHash(ctx context.Context, imageURL string) (uint64, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, avatarHashTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := avatarHTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	img, _, err := image.Decode(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	grid := downsampleGrayscale(img, avatarHashSize, avatarHashSize)
+	var sum int
+	for _, v := range grid {
+		sum += v
+	}
+	mean := sum / len(grid)
+
+	var hash uint64
+	for i, v := range grid {
+		if v > mean {
+			hash |= 1 << uint(i)
+		}
+	}
+	return hash, nil
+}
+
+// downsampleGrayscale nearest-neighbor samples img to a w x h grid of
+// luminance values in [0,255], row-major.
+func downsampleGrayscale(img image.Image, w, h int) []int {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	grid := make([]int, 0, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			srcX := bounds.Min.X + x*srcW/w
+			srcY := bounds.Min.Y + y*srcH/h
+			r, g, b, _ := img.At(srcX, srcY).RGBA()
+			r, g, b = r>>8, g>>8, b>>8
+			lum := int(299*r+587*g+114*b) / 1000
+			grid = append(grid, lum)
+		}
+	}
+	return grid
+}
+
+// hammingDistance counts the differing bits between a and b.
+func hammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
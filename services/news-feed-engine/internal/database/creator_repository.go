@@ -4,24 +4,65 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database/sqlcgen"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/telemetry"
 )
 
+// creatorQuerier is the subset of *sqlcgen.Queries CreatorRepository
+// drives its point reads/writes through. It exists so a fake can stand
+// in for the generated queries in unit tests without a live database;
+// see FakeCreatorQuerier.
+type creatorQuerier interface {
+	ListCreators(ctx context.Context, arg sqlcgen.ListCreatorsParams) ([]models.Creator, error)
+	ListCreatorsByCursor(ctx context.Context, arg sqlcgen.ListCreatorsByCursorParams) ([]models.Creator, error)
+	CountCreators(ctx context.Context, arg sqlcgen.CountCreatorsParams) (int64, error)
+	GetCreatorByID(ctx context.Context, arg sqlcgen.GetCreatorByIDParams) (models.Creator, error)
+	GetCreatorByPlatformID(ctx context.Context, arg sqlcgen.GetCreatorByPlatformIDParams) (models.Creator, error)
+	GetTopCreators(ctx context.Context, arg sqlcgen.GetTopCreatorsParams) ([]models.Creator, error)
+	CreateCreator(ctx context.Context, arg sqlcgen.CreateCreatorParams) (sqlcgen.CreateCreatorRow, error)
+	UpdateCreator(ctx context.Context, arg sqlcgen.UpdateCreatorParams) (int64, error)
+	SoftDeleteCreator(ctx context.Context, arg sqlcgen.SoftDeleteCreatorParams) (int64, error)
+	VerifyCreator(ctx context.Context, arg sqlcgen.VerifyCreatorParams) (int64, error)
+}
+
 // CreatorRepository handles creator database operations
 type CreatorRepository struct {
-	db *DB
+	db        *DB
+	q         creatorQuerier
+	telemetry telemetry.Reporter
 }
 
 // NewCreatorRepository creates a new creator repository
 func NewCreatorRepository(db *DB) *CreatorRepository {
-	return &CreatorRepository{db: db}
+	return &CreatorRepository{db: db, q: sqlcgen.New(db), telemetry: telemetry.NoopReporter{}}
 }
 
-// CreatorListOptions contains options for filtering creators
-type CreatorListOptions struct {
+// WithTelemetry overrides the Reporter Create/Update/SoftDelete/Verify
+// emit anonymized lifecycle events through. Defaults to
+// telemetry.NoopReporter, so this is a no-op unless a deployment opts in.
+func (r *CreatorRepository) WithTelemetry(reporter telemetry.Reporter) *CreatorRepository {
+	r.telemetry = reporter
+	return r
+}
+
+// ListCreatorsParams filters and sorts List. It replaces the old
+// CreatorListOptions + hand-built WHERE/ORDER BY string: every field
+// here maps directly onto a sqlcgen.ListCreatorsParams arg, so there's
+// no argCount/fmt.Sprintf threading left to get wrong.
+type ListCreatorsParams struct {
+	// Page is the offset-based page number, honored when Cursor is
+	// empty. Prefer Cursor for new callers: Page has to re-scan and
+	// discard every prior row on each request, where a cursor is a
+	// single indexable range scan regardless of how deep the caller
+	// pages.
 	Page         int
 	Limit        int
 	Platform     string // youtube, twitter, reddit
@@ -30,343 +71,461 @@ type CreatorListOptions struct {
 	Active       *bool
 	SortBy       string // follower_count, content_count, engagement_rate, created_at
 	Order        string // asc, desc
+	// Cursor, if set, requests the page immediately after the one
+	// NextCursor was returned from. It must have been issued for the
+	// same SortBy/Order as this request - List returns an error
+	// otherwise, since the opaque cursor is otherwise meaningless.
+	Cursor string
+	// IncludeTotal runs the extra CountCreators query needed to
+	// populate List's total return value. Callers that only need a
+	// page of results (e.g. cursor-paginated UIs with no page count)
+	// should leave this false to skip that query.
+	IncludeTotal bool
 }
 
-// List retrieves a paginated list of creators with filters
-func (r *CreatorRepository) List(ctx context.Context, tenantID string, opts CreatorListOptions) ([]models.Creator, int, error) {
-	query := `
-		SELECT c.id, c.tenant_id, c.name, c.platform, c.platform_id, c.avatar_url, c.bio,
-		       c.tier, c.verified_at, c.follower_count, c.content_count, c.engagement_rate,
-		       c.topics_expertise, c.social_links, c.metadata, c.active, c.created_at, c.updated_at
-		FROM creators c
-		WHERE c.tenant_id = $1
-	`
-	args := []interface{}{tenantID}
-	argCount := 1
-
-	// Add filters
-	if opts.Platform != "" {
-		argCount++
-		query += fmt.Sprintf(" AND c.platform = $%d", argCount)
-		args = append(args, opts.Platform)
-	}
-
-	if opts.Tier != "" {
-		argCount++
-		query += fmt.Sprintf(" AND c.tier = $%d", argCount)
-		args = append(args, opts.Tier)
-	}
-
-	if opts.VerifiedOnly {
-		query += " AND c.verified_at IS NOT NULL"
-	}
-
-	if opts.Active != nil {
-		argCount++
-		query += fmt.Sprintf(" AND c.active = $%d", argCount)
-		args = append(args, *opts.Active)
-	}
+// creatorCursor is the decoded form of a ListCreatorsParams.Cursor /
+// List's returned nextCursor. It's tied to the sort it was issued
+// under so a cursor minted for one SortBy/Order can't be silently
+// replayed against another, which would otherwise resume from an
+// unrelated position in the result set.
+type creatorCursor struct {
+	SortBy    string    `json:"sort_by"`
+	Ascending bool      `json:"ascending"`
+	SortKey   float64   `json:"sort_key"`
+	ID        uuid.UUID `json:"id"`
+}
 
-	// Count total matching records
-	countQuery := "SELECT COUNT(*) FROM creators c WHERE c.tenant_id = $1"
-	countArgs := []interface{}{tenantID}
-	if opts.Platform != "" {
-		countQuery += " AND c.platform = $2"
-		countArgs = append(countArgs, opts.Platform)
-	}
-	if opts.Tier != "" {
-		countQuery += fmt.Sprintf(" AND c.tier = $%d", len(countArgs)+1)
-		countArgs = append(countArgs, opts.Tier)
-	}
-	if opts.VerifiedOnly {
-		countQuery += " AND c.verified_at IS NOT NULL"
+func encodeCreatorCursor(c creatorCursor) (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
 	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
 
-	var total int
-	err := r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total)
+func decodeCreatorCursor(s string) (creatorCursor, error) {
+	var c creatorCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count creators: %w", err)
+		return c, fmt.Errorf("invalid cursor: %w", err)
 	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, fmt.Errorf("invalid cursor: %w", err)
+	}
+	return c, nil
+}
 
-	// Add sorting
+// List retrieves a page of creators matching opts' filters. total is
+// only computed when opts.IncludeTotal is set; otherwise it is 0.
+// nextCursor is non-empty whenever the page returned is full, meaning
+// there may be more rows to fetch - callers keyset-paginating should
+// keep requesting pages until it comes back empty. Page-based requests
+// (opts.Cursor unset) never keep track of a cursor across offsets.
+func (r *CreatorRepository) List(ctx context.Context, tenantID string, opts ListCreatorsParams) (creators []models.Creator, total int, nextCursor string, err error) {
 	sortBy := "created_at"
 	if opts.SortBy == "follower_count" || opts.SortBy == "content_count" || opts.SortBy == "engagement_rate" {
 		sortBy = opts.SortBy
 	}
+	ascending := opts.Order == "asc"
 
-	order := "DESC"
-	if opts.Order == "asc" {
-		order = "ASC"
+	if opts.IncludeTotal {
+		countArgs := sqlcgen.CountCreatorsParams{
+			TenantID:     tenantID,
+			Platform:     nonEmptyPtr(opts.Platform),
+			Tier:         nonEmptyPtr(opts.Tier),
+			VerifiedOnly: opts.VerifiedOnly,
+			Active:       opts.Active,
+		}
+		count, err := r.q.CountCreators(ctx, countArgs)
+		if err != nil {
+			return nil, 0, "", fmt.Errorf("failed to count creators: %w", err)
+		}
+		total = int(count)
 	}
 
-	query += fmt.Sprintf(" ORDER BY c.%s %s", sortBy, order)
-
-	// Add pagination
-	argCount++
-	query += fmt.Sprintf(" LIMIT $%d", argCount)
-	args = append(args, opts.Limit)
+	if opts.Cursor != "" {
+		cursor, err := decodeCreatorCursor(opts.Cursor)
+		if err != nil {
+			return nil, 0, "", err
+		}
+		if cursor.SortBy != sortBy || cursor.Ascending != ascending {
+			return nil, 0, "", fmt.Errorf("cursor was issued for a different sort")
+		}
 
-	argCount++
-	query += fmt.Sprintf(" OFFSET $%d", argCount)
-	args = append(args, (opts.Page-1)*opts.Limit)
+		creators, err = r.q.ListCreatorsByCursor(ctx, sqlcgen.ListCreatorsByCursorParams{
+			TenantID:      tenantID,
+			Platform:      nonEmptyPtr(opts.Platform),
+			Tier:          nonEmptyPtr(opts.Tier),
+			VerifiedOnly:  opts.VerifiedOnly,
+			Active:        opts.Active,
+			SortBy:        sortBy,
+			Ascending:     ascending,
+			CursorSortKey: &cursor.SortKey,
+			CursorID:      cursor.ID,
+			LimitCount:    int32(opts.Limit),
+		})
+		if err != nil {
+			return nil, 0, "", fmt.Errorf("failed to query creators: %w", err)
+		}
+	} else {
+		page := opts.Page
+		if page < 1 {
+			page = 1
+		}
 
-	// Execute query
-	rows, err := r.db.QueryContext(ctx, query, args...)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to query creators: %w", err)
+		creators, err = r.q.ListCreators(ctx, sqlcgen.ListCreatorsParams{
+			TenantID:     tenantID,
+			Platform:     nonEmptyPtr(opts.Platform),
+			Tier:         nonEmptyPtr(opts.Tier),
+			VerifiedOnly: opts.VerifiedOnly,
+			Active:       opts.Active,
+			SortBy:       sortBy,
+			Ascending:    ascending,
+			LimitCount:   int32(opts.Limit),
+			OffsetCount:  int32((page - 1) * opts.Limit),
+		})
+		if err != nil {
+			return nil, 0, "", fmt.Errorf("failed to query creators: %w", err)
+		}
 	}
-	defer rows.Close()
 
-	var creators []models.Creator
-	for rows.Next() {
-		var c models.Creator
-		err := rows.Scan(
-			&c.ID, &c.TenantID, &c.Name, &c.Platform, &c.PlatformID, &c.AvatarURL, &c.Bio,
-			&c.Tier, &c.VerifiedAt, &c.FollowerCount, &c.ContentCount, &c.EngagementRate,
-			&c.TopicsExpertise, &c.SocialLinks, &c.Metadata, &c.Active, &c.CreatedAt, &c.UpdatedAt,
-		)
+	if len(creators) > 0 && opts.Limit > 0 && len(creators) == opts.Limit {
+		last := creators[len(creators)-1]
+		nextCursor, err = encodeCreatorCursor(creatorCursor{
+			SortBy:    sortBy,
+			Ascending: ascending,
+			SortKey:   sortKeyFor(last, sortBy, ascending),
+			ID:        last.ID,
+		})
 		if err != nil {
-			return nil, 0, fmt.Errorf("failed to scan creator: %w", err)
+			return nil, 0, "", fmt.Errorf("failed to encode next cursor: %w", err)
 		}
-		creators = append(creators, c)
 	}
 
-	return creators, total, nil
+	return creators, total, nextCursor, nil
 }
 
-// GetByID retrieves a single creator by ID
-func (r *CreatorRepository) GetByID(ctx context.Context, tenantID string, creatorID uuid.UUID) (*models.Creator, error) {
-	query := `
-		SELECT c.id, c.tenant_id, c.name, c.platform, c.platform_id, c.avatar_url, c.bio,
-		       c.tier, c.verified_at, c.follower_count, c.content_count, c.engagement_rate,
-		       c.topics_expertise, c.social_links, c.metadata, c.active, c.created_at, c.updated_at
-		FROM creators c
-		WHERE c.tenant_id = $1 AND c.id = $2
-	`
+// sortKeyFor recomputes the signed sort key ListCreators/
+// ListCreatorsByCursor order by for c, so List can mint a cursor from
+// the last row of a page without a round trip back to the database to
+// ask for it.
+func sortKeyFor(c models.Creator, sortBy string, ascending bool) float64 {
+	var key float64
+	switch sortBy {
+	case "follower_count":
+		key = float64(c.FollowerCount)
+	case "content_count":
+		key = float64(c.ContentCount)
+	case "engagement_rate":
+		key = c.EngagementRate
+	default:
+		key = float64(c.CreatedAt.Unix())
+	}
+	if !ascending {
+		key = -key
+	}
+	return key
+}
 
-	var c models.Creator
-	err := r.db.QueryRowContext(ctx, query, tenantID, creatorID).Scan(
-		&c.ID, &c.TenantID, &c.Name, &c.Platform, &c.PlatformID, &c.AvatarURL, &c.Bio,
-		&c.Tier, &c.VerifiedAt, &c.FollowerCount, &c.ContentCount, &c.EngagementRate,
-		&c.TopicsExpertise, &c.SocialLinks, &c.Metadata, &c.Active, &c.CreatedAt, &c.UpdatedAt,
-	)
+// nonEmptyPtr returns nil for an empty string, and a pointer to s
+// otherwise - the Go-side equivalent of a sqlc.narg filter that should
+// be skipped when the caller didn't ask for it.
+func nonEmptyPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
 
+// GetByID retrieves a single creator by ID
+func (r *CreatorRepository) GetByID(ctx context.Context, tenantID string, creatorID uuid.UUID) (*models.Creator, error) {
+	c, err := r.q.GetCreatorByID(ctx, sqlcgen.GetCreatorByIDParams{TenantID: tenantID, ID: creatorID})
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("creator not found")
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get creator: %w", err)
 	}
-
 	return &c, nil
 }
 
 // GetByPlatformID retrieves a creator by platform and platform_id
 func (r *CreatorRepository) GetByPlatformID(ctx context.Context, tenantID string, platform, platformID string) (*models.Creator, error) {
-	query := `
-		SELECT c.id, c.tenant_id, c.name, c.platform, c.platform_id, c.avatar_url, c.bio,
-		       c.tier, c.verified_at, c.follower_count, c.content_count, c.engagement_rate,
-		       c.topics_expertise, c.social_links, c.metadata, c.active, c.created_at, c.updated_at
-		FROM creators c
-		WHERE c.tenant_id = $1 AND c.platform = $2 AND c.platform_id = $3
-	`
-
-	var c models.Creator
-	err := r.db.QueryRowContext(ctx, query, tenantID, platform, platformID).Scan(
-		&c.ID, &c.TenantID, &c.Name, &c.Platform, &c.PlatformID, &c.AvatarURL, &c.Bio,
-		&c.Tier, &c.VerifiedAt, &c.FollowerCount, &c.ContentCount, &c.EngagementRate,
-		&c.TopicsExpertise, &c.SocialLinks, &c.Metadata, &c.Active, &c.CreatedAt, &c.UpdatedAt,
-	)
-
+	c, err := r.q.GetCreatorByPlatformID(ctx, sqlcgen.GetCreatorByPlatformIDParams{
+		TenantID: tenantID, Platform: platform, PlatformID: platformID,
+	})
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("creator not found")
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get creator: %w", err)
 	}
-
 	return &c, nil
 }
 
 // GetTopCreators retrieves top creators by follower count or engagement rate
 func (r *CreatorRepository) GetTopCreators(ctx context.Context, tenantID string, metric string, limit int) ([]models.Creator, error) {
-	// Validate metric
-	sortBy := "follower_count"
-	if metric == "engagement" {
-		sortBy = "engagement_rate"
+	creators, err := r.q.GetTopCreators(ctx, sqlcgen.GetTopCreatorsParams{
+		TenantID:     tenantID,
+		ByEngagement: metric == "engagement",
+		LimitCount:   int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query top creators: %w", err)
+	}
+	return creators, nil
+}
+
+// CreatorSearchOptions filters SearchBackend.SearchCreators. Platform,
+// Tier, and Topics are facet filters layered on top of the ranked
+// lexical match; MinFollowers excludes creators below a follower
+// threshold regardless of how well they rank.
+type CreatorSearchOptions struct {
+	Page         int
+	Limit        int
+	Platform     string
+	Tier         string
+	MinFollowers int64
+	Topics       []string
+}
+
+// CreatorSearchResult is SearchBackend.SearchCreators' return value.
+// Creators is the ranked, paginated page; FacetsByPlatform and
+// FacetsByTier are counts across the entire matching set (not just this
+// page), so a UI can render filter-sidebar counts without a second
+// request.
+type CreatorSearchResult struct {
+	Creators         []models.Creator
+	Total            int
+	FacetsByPlatform map[string]int
+	FacetsByTier     map[string]int
+}
+
+// SearchBackend is implemented by anything that can run ranked, faceted
+// creator search. CreatorRepository.SearchCreators below is the default
+// Postgres tsvector backend; CreatorHandler.WithSearchBackend lets a
+// deployment swap in something like an OpenSearch adapter without
+// changing the handler.
+type SearchBackend interface {
+	SearchCreators(ctx context.Context, tenantID, query string, opts CreatorSearchOptions) (CreatorSearchResult, error)
+}
+
+// SearchCreators performs lexical search against the generated
+// search_vector tsvector column (name + bio + topics_expertise, see the
+// news_feed schema migration that adds it alongside a GIN index),
+// mirroring ContentRepository.SearchFullText. It ranks with ts_rank_cd
+// rather than plain ts_rank since creator bios tend to repeat keywords
+// and cd rewards contiguous phrase matches over raw term frequency.
+// Facet counts are computed with a single GROUP BY query sharing the
+// same WHERE clause (minus pagination) so they reflect the full
+// matching set, not just the returned page.
+func (r *CreatorRepository) SearchCreators(ctx context.Context, tenantID, query string, opts CreatorSearchOptions) (CreatorSearchResult, error) {
+	whereClauses := []string{"c.tenant_id = $1", "c.search_vector @@ websearch_to_tsquery('english', $2)"}
+	args := []interface{}{tenantID, query}
+	argCount := 2
+
+	if opts.Platform != "" {
+		argCount++
+		whereClauses = append(whereClauses, fmt.Sprintf("c.platform = $%d", argCount))
+		args = append(args, opts.Platform)
+	}
+	if opts.Tier != "" {
+		argCount++
+		whereClauses = append(whereClauses, fmt.Sprintf("c.tier = $%d", argCount))
+		args = append(args, opts.Tier)
+	}
+	if opts.MinFollowers > 0 {
+		argCount++
+		whereClauses = append(whereClauses, fmt.Sprintf("c.follower_count >= $%d", argCount))
+		args = append(args, opts.MinFollowers)
+	}
+	if len(opts.Topics) > 0 {
+		argCount++
+		whereClauses = append(whereClauses, fmt.Sprintf("c.topics_expertise && $%d", argCount))
+		args = append(args, opts.Topics)
+	}
+
+	whereClause := strings.Join(whereClauses, " AND ")
+
+	var result CreatorSearchResult
+	result.FacetsByPlatform = make(map[string]int)
+	result.FacetsByTier = make(map[string]int)
+
+	facetQuery := fmt.Sprintf(`
+		SELECT c.platform, c.tier, COUNT(*)
+		FROM creators c
+		WHERE %s
+		GROUP BY c.platform, c.tier
+	`, whereClause)
+
+	facetRows, err := r.db.QueryContext(ctx, facetQuery, args...)
+	if err != nil {
+		return result, fmt.Errorf("failed to compute creator search facets: %w", err)
+	}
+	for facetRows.Next() {
+		var platform, tier string
+		var count int
+		if err := facetRows.Scan(&platform, &tier, &count); err != nil {
+			facetRows.Close()
+			return result, fmt.Errorf("failed to scan creator search facet: %w", err)
+		}
+		result.FacetsByPlatform[platform] += count
+		result.FacetsByTier[tier] += count
+		result.Total += count
+	}
+	facetRows.Close()
+
+	page := opts.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := opts.Limit
+	if limit < 1 {
+		limit = 20
 	}
+	offset := (page - 1) * limit
 
-	query := fmt.Sprintf(`
+	rankExpr := "ts_rank_cd(c.search_vector, websearch_to_tsquery('english', $2))"
+	searchQuery := fmt.Sprintf(`
 		SELECT c.id, c.tenant_id, c.name, c.platform, c.platform_id, c.avatar_url, c.bio,
 		       c.tier, c.verified_at, c.follower_count, c.content_count, c.engagement_rate,
-		       c.topics_expertise, c.social_links, c.metadata, c.active, c.created_at, c.updated_at
+		       c.topics_expertise, c.social_links, c.metadata, c.active, c.created_at, c.updated_at,
+		       %s as search_rank
 		FROM creators c
-		WHERE c.tenant_id = $1 AND c.active = true
-		ORDER BY c.%s DESC
-		LIMIT $2
-	`, sortBy)
+		WHERE %s
+		ORDER BY search_rank DESC
+		LIMIT $%d OFFSET $%d
+	`, rankExpr, whereClause, argCount+1, argCount+2)
+
+	searchArgs := append(append([]interface{}{}, args...), limit, offset)
 
-	rows, err := r.db.QueryContext(ctx, query, tenantID, limit)
+	rows, err := r.db.QueryContext(ctx, searchQuery, searchArgs...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query top creators: %w", err)
+		return result, fmt.Errorf("failed to search creators: %w", err)
 	}
 	defer rows.Close()
 
-	var creators []models.Creator
 	for rows.Next() {
 		var c models.Creator
+		var searchRank float64
 		err := rows.Scan(
 			&c.ID, &c.TenantID, &c.Name, &c.Platform, &c.PlatformID, &c.AvatarURL, &c.Bio,
 			&c.Tier, &c.VerifiedAt, &c.FollowerCount, &c.ContentCount, &c.EngagementRate,
 			&c.TopicsExpertise, &c.SocialLinks, &c.Metadata, &c.Active, &c.CreatedAt, &c.UpdatedAt,
+			&searchRank,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan creator: %w", err)
+			return result, fmt.Errorf("failed to scan creator: %w", err)
 		}
-		creators = append(creators, c)
+		result.Creators = append(result.Creators, c)
 	}
 
-	return creators, nil
+	return result, nil
 }
 
 // Create inserts a new creator
 func (r *CreatorRepository) Create(ctx context.Context, creator *models.Creator) error {
-	query := `
-		INSERT INTO creators (
-			id, tenant_id, name, platform, platform_id, avatar_url, bio, tier,
-			follower_count, content_count, engagement_rate, topics_expertise,
-			social_links, metadata, active
-		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
-		)
-		RETURNING created_at, updated_at
-	`
-
-	// Generate UUID if not provided
 	if creator.ID == uuid.Nil {
 		creator.ID = uuid.New()
 	}
 
-	err := r.db.QueryRowContext(ctx, query,
-		creator.ID, creator.TenantID, creator.Name, creator.Platform, creator.PlatformID,
-		creator.AvatarURL, creator.Bio, creator.Tier, creator.FollowerCount,
-		creator.ContentCount, creator.EngagementRate, creator.TopicsExpertise,
-		creator.SocialLinks, creator.Metadata, creator.Active,
-	).Scan(&creator.CreatedAt, &creator.UpdatedAt)
-
+	row, err := r.q.CreateCreator(ctx, sqlcgen.CreateCreatorParams{
+		ID:              creator.ID,
+		TenantID:        creator.TenantID,
+		Name:            creator.Name,
+		Platform:        creator.Platform,
+		PlatformID:      creator.PlatformID,
+		AvatarURL:       creator.AvatarURL,
+		Bio:             creator.Bio,
+		Tier:            creator.Tier,
+		FollowerCount:   creator.FollowerCount,
+		ContentCount:    creator.ContentCount,
+		EngagementRate:  creator.EngagementRate,
+		TopicsExpertise: creator.TopicsExpertise,
+		SocialLinks:     creator.SocialLinks,
+		Metadata:        creator.Metadata,
+		Active:          creator.Active,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to create creator: %w", err)
 	}
 
+	creator.CreatedAt = row.CreatedAt
+	creator.UpdatedAt = row.UpdatedAt
+	r.telemetry.CreatorCreated(creator.TenantID, creator.ID, string(creator.Platform))
 	return nil
 }
 
-// Update updates an existing creator
-func (r *CreatorRepository) Update(ctx context.Context, creatorID uuid.UUID, updates map[string]interface{}) error {
-	if len(updates) == 0 {
-		return nil
-	}
-
-	// Whitelist of allowed updatable fields
-	allowedFields := map[string]bool{
-		"name":            true,
-		"bio":             true,
-		"profile_url":     true,
-		"follower_count":  true,
-		"engagement_rate": true,
-		"verified_at":     true,
-		"tier":            true,
-		"platform":        true,
-		"active":          true,
-		"metadata":        true,
-	}
-
-	// Build dynamic UPDATE query with field validation
-	query := "UPDATE creators SET "
-	args := []interface{}{}
-	argCount := 0
-
-	for field, value := range updates {
-		// Validate field name is in whitelist
-		if !allowedFields[field] {
-			return fmt.Errorf("field %q is not allowed for update", field)
-		}
-
-		argCount++
-		if argCount > 1 {
-			query += ", "
-		}
-		query += fmt.Sprintf("%s = $%d", field, argCount)
-		args = append(args, value)
-	}
-
-	argCount++
-	query += fmt.Sprintf(", updated_at = NOW() WHERE id = $%d", argCount)
-	args = append(args, creatorID)
+// CreatorUpdate is Update's typed replacement for the old
+// map[string]interface{} + string-key whitelist: a nil field leaves its
+// column unchanged, so the set of updatable fields is enforced by the
+// Go compiler instead of a runtime allowedFields check.
+type CreatorUpdate struct {
+	Name            *string
+	Bio             *string
+	AvatarURL       *string
+	FollowerCount   *int64
+	EngagementRate  *float64
+	VerifiedAt      *time.Time
+	Tier            *models.CreatorTier
+	Platform        *models.Platform
+	Active          *bool
+	Metadata        models.JSONB
+	TopicsExpertise []string
+}
 
-	result, err := r.db.ExecContext(ctx, query, args...)
+// Update applies the set fields of update to creatorID, scoped to
+// tenantID like every other creator lookup in this repository.
+func (r *CreatorRepository) Update(ctx context.Context, tenantID string, creatorID uuid.UUID, update CreatorUpdate) error {
+	rows, err := r.q.UpdateCreator(ctx, sqlcgen.UpdateCreatorParams{
+		Name:            update.Name,
+		Bio:             update.Bio,
+		AvatarURL:       update.AvatarURL,
+		FollowerCount:   update.FollowerCount,
+		EngagementRate:  update.EngagementRate,
+		VerifiedAt:      update.VerifiedAt,
+		Tier:            update.Tier,
+		Platform:        update.Platform,
+		Active:          update.Active,
+		Metadata:        update.Metadata,
+		TopicsExpertise: update.TopicsExpertise,
+		TenantID:        tenantID,
+		ID:              creatorID,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to update creator: %w", err)
 	}
-
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
-
 	if rows == 0 {
 		return fmt.Errorf("creator not found")
 	}
-
+	r.telemetry.CreatorUpdated(tenantID, creatorID)
 	return nil
 }
 
 // SoftDelete marks a creator as inactive
 func (r *CreatorRepository) SoftDelete(ctx context.Context, tenantID string, creatorID uuid.UUID) error {
-	query := `
-		UPDATE creators
-		SET active = false, updated_at = NOW()
-		WHERE tenant_id = $1 AND id = $2
-	`
-
-	result, err := r.db.ExecContext(ctx, query, tenantID, creatorID)
+	rows, err := r.q.SoftDeleteCreator(ctx, sqlcgen.SoftDeleteCreatorParams{TenantID: tenantID, ID: creatorID})
 	if err != nil {
 		return fmt.Errorf("failed to soft delete creator: %w", err)
 	}
-
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
-
 	if rows == 0 {
 		return fmt.Errorf("creator not found")
 	}
-
+	r.telemetry.CreatorDeleted(tenantID, creatorID)
 	return nil
 }
 
 // Verify marks a creator as verified and sets the tier
 func (r *CreatorRepository) Verify(ctx context.Context, tenantID string, creatorID uuid.UUID, tier string) error {
-	query := `
-		UPDATE creators
-		SET tier = $1, verified_at = NOW(), updated_at = NOW()
-		WHERE tenant_id = $2 AND id = $3
-	`
-
-	result, err := r.db.ExecContext(ctx, query, tier, tenantID, creatorID)
+	rows, err := r.q.VerifyCreator(ctx, sqlcgen.VerifyCreatorParams{
+		Tier: models.CreatorTier(tier), TenantID: tenantID, ID: creatorID,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to verify creator: %w", err)
 	}
-
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
-
 	if rows == 0 {
 		return fmt.Errorf("creator not found")
 	}
-
+	r.telemetry.CreatorVerified(tenantID, creatorID, tier)
 	return nil
 }
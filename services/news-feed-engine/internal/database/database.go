@@ -39,6 +39,12 @@ func Connect(dsn string) (*DB, error) {
 	return &DB{db}, nil
 }
 
+// SQLDB returns the underlying *sql.DB, for packages (like migrations)
+// that need the stdlib interface goose drives rather than sqlx's.
+func (db *DB) SQLDB() *sql.DB {
+	return db.DB.DB
+}
+
 // WithTransaction executes a function within a database transaction
 func (db *DB) WithTransaction(ctx context.Context, fn func(*sqlx.Tx) error) error {
 	tx, err := db.BeginTxx(ctx, &sql.TxOptions{})
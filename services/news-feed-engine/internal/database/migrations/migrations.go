@@ -0,0 +1,60 @@
+// Package migrations drives the tenant/content/template schema with
+// goose, over SQL files embedded at build time so the binary carries
+// its own schema history instead of depending on a migration runner
+// being deployed alongside it.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+
+	"github.com/pressly/goose/v3"
+)
+
+//go:embed sql/*.sql
+var embedFS embed.FS
+
+// dir is the embedded path goose reads migrations from, relative to
+// embedFS's root.
+const dir = "sql"
+
+func init() {
+	goose.SetBaseFS(embedFS)
+	if err := goose.SetDialect("postgres"); err != nil {
+		panic(fmt.Sprintf("migrations: unsupported dialect: %v", err))
+	}
+}
+
+// EnsureSchema applies every migration under sql/ that hasn't already
+// run against db, in version order. The service calls this once at
+// startup, before accepting traffic, so a freshly provisioned database
+// never serves a request against a schema it hasn't fully migrated.
+func EnsureSchema(ctx context.Context, db *sql.DB) error {
+	if err := goose.UpContext(ctx, db, dir); err != nil {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+	return nil
+}
+
+// Status prints each migration's applied/pending state, for the
+// `migrate status` CLI subcommand.
+func Status(ctx context.Context, db *sql.DB) error {
+	return goose.StatusContext(ctx, db, dir)
+}
+
+// Down rolls back the most recently applied migration, for the
+// `migrate down` CLI subcommand.
+func Down(ctx context.Context, db *sql.DB) error {
+	return goose.DownContext(ctx, db, dir)
+}
+
+// Create scaffolds a new timestamped SQL migration file on disk under
+// internal/database/migrations/sql, for the `migrate create` CLI
+// subcommand. Unlike EnsureSchema/Status/Down this writes to the real
+// filesystem, not embedFS, since the new file needs to exist before
+// the next build embeds it.
+func Create(name string) error {
+	return goose.Create(nil, "internal/database/migrations/sql", name, "sql")
+}
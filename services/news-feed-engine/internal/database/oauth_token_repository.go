@@ -0,0 +1,72 @@
+// Package database provides the OAuth posting-credential repository
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/integrations"
+)
+
+// OAuthTokenRepository persists posting credentials in the oauth_tokens
+// table and implements integrations.OAuthTokenStore.
+type OAuthTokenRepository struct {
+	db *DB
+}
+
+// NewOAuthTokenRepository creates a new OAuth token repository.
+func NewOAuthTokenRepository(db *DB) *OAuthTokenRepository {
+	return &OAuthTokenRepository{db: db}
+}
+
+// Get returns tenantID/userID's stored credential for platform, or nil
+// if they haven't authorized it.
+func (r *OAuthTokenRepository) Get(ctx context.Context, tenantID, platform, userID string) (*integrations.OAuthToken, error) {
+	query := `SELECT tenant_id, platform, user_id, access_token, refresh_token, token_secret,
+			instance_host, expires_at, created_at, updated_at
+		FROM oauth_tokens WHERE tenant_id = $1 AND platform = $2 AND user_id = $3`
+
+	var token integrations.OAuthToken
+	err := r.db.GetContext(ctx, &token, query, tenantID, platform, userID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load oauth token: %w", err)
+	}
+	return &token, nil
+}
+
+// Save upserts a credential.
+func (r *OAuthTokenRepository) Save(ctx context.Context, token *integrations.OAuthToken) error {
+	query := `
+		INSERT INTO oauth_tokens (tenant_id, platform, user_id, access_token, refresh_token,
+			token_secret, instance_host, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, now(), now())
+		ON CONFLICT (tenant_id, platform, user_id) DO UPDATE SET
+			access_token = EXCLUDED.access_token,
+			refresh_token = EXCLUDED.refresh_token,
+			token_secret = EXCLUDED.token_secret,
+			instance_host = EXCLUDED.instance_host,
+			expires_at = EXCLUDED.expires_at,
+			updated_at = now()`
+
+	_, err := r.db.ExecContext(ctx, query, token.TenantID, token.Platform, token.UserID,
+		token.AccessToken, token.RefreshToken, token.TokenSecret, token.InstanceHost, token.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to save oauth token: %w", err)
+	}
+	return nil
+}
+
+// Delete revokes tenantID/userID's stored credential for platform.
+func (r *OAuthTokenRepository) Delete(ctx context.Context, tenantID, platform, userID string) error {
+	_, err := r.db.ExecContext(ctx,
+		`DELETE FROM oauth_tokens WHERE tenant_id = $1 AND platform = $2 AND user_id = $3`,
+		tenantID, platform, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete oauth token: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+// ErrPreferencesVersionMismatch is returned by PreferencesRepository.Save
+// when ifMatch is non-zero and doesn't equal the row's current UpdatedAt,
+// mirroring ContentRepository.Patch/ErrVersionMismatch's optimistic
+// concurrency check.
+var ErrPreferencesVersionMismatch = fmt.Errorf("preferences have been modified since ifMatch")
+
+// PreferencesRepository handles user_preferences database operations.
+type PreferencesRepository struct {
+	db *DB
+}
+
+// NewPreferencesRepository creates a new preferences repository.
+func NewPreferencesRepository(db *DB) *PreferencesRepository {
+	return &PreferencesRepository{db: db}
+}
+
+const preferencesColumns = `tenant_id, user_id, default_home, feed_menu, sort, locale, theme,
+	       autoplay, enabled_categories, muted_creators, muted_keywords, max_results, extra, updated_at`
+
+func preferencesRow(p *models.UserPreferences) []interface{} {
+	return []interface{}{
+		&p.TenantID, &p.UserID, &p.DefaultHome, &p.FeedMenu, &p.Sort, &p.Locale, &p.Theme,
+		&p.Autoplay, &p.EnabledCategories, &p.MutedCreators, &p.MutedKeywords, &p.MaxResults,
+		&p.Extra, &p.UpdatedAt,
+	}
+}
+
+// GetByUser retrieves tenantID/userID's preferences row, returning
+// (nil, nil) if the user has never saved one - this is the expected
+// case for most users, not an error, mirroring
+// ingestion.ChannelCursorStore.Get's no-cursor-yet contract.
+func (r *PreferencesRepository) GetByUser(ctx context.Context, tenantID, userID string) (*models.UserPreferences, error) {
+	query := `SELECT ` + preferencesColumns + ` FROM user_preferences WHERE tenant_id = $1 AND user_id = $2`
+
+	var p models.UserPreferences
+	err := r.db.QueryRowContext(ctx, query, tenantID, userID).Scan(preferencesRow(&p)...)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get preferences: %w", err)
+	}
+	return &p, nil
+}
+
+// Save upserts prefs. When ifMatch is non-zero and a row already exists,
+// the write only applies if the row's current UpdatedAt equals ifMatch;
+// a mismatch (someone else updated it first) returns
+// ErrPreferencesVersionMismatch instead of silently overwriting.
+func (r *PreferencesRepository) Save(ctx context.Context, prefs *models.UserPreferences, ifMatch time.Time) (time.Time, error) {
+	var ifMatchArg interface{}
+	if !ifMatch.IsZero() {
+		ifMatchArg = ifMatch
+	}
+
+	query := `
+		INSERT INTO user_preferences (` + preferencesColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NOW())
+		ON CONFLICT (tenant_id, user_id) DO UPDATE SET
+			default_home = EXCLUDED.default_home,
+			feed_menu = EXCLUDED.feed_menu,
+			sort = EXCLUDED.sort,
+			locale = EXCLUDED.locale,
+			theme = EXCLUDED.theme,
+			autoplay = EXCLUDED.autoplay,
+			enabled_categories = EXCLUDED.enabled_categories,
+			muted_creators = EXCLUDED.muted_creators,
+			muted_keywords = EXCLUDED.muted_keywords,
+			max_results = EXCLUDED.max_results,
+			extra = EXCLUDED.extra,
+			updated_at = NOW()
+		WHERE $14::timestamptz IS NULL OR user_preferences.updated_at = $14::timestamptz
+		RETURNING updated_at
+	`
+
+	var updatedAt time.Time
+	err := r.db.QueryRowContext(ctx, query,
+		prefs.TenantID, prefs.UserID, prefs.DefaultHome, prefs.FeedMenu, prefs.Sort, prefs.Locale,
+		prefs.Theme, prefs.Autoplay, prefs.EnabledCategories, prefs.MutedCreators, prefs.MutedKeywords,
+		prefs.MaxResults, prefs.Extra, ifMatchArg,
+	).Scan(&updatedAt)
+
+	if err == sql.ErrNoRows {
+		return time.Time{}, ErrPreferencesVersionMismatch
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to save preferences: %w", err)
+	}
+	return updatedAt, nil
+}
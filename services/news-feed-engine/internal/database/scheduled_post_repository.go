@@ -0,0 +1,110 @@
+// Package database provides the scheduled cross-post repository
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/integrations"
+)
+
+// ScheduledPostRepository persists scheduled cross-posts in the
+// scheduled_posts table and implements integrations.ScheduledPostStore.
+type ScheduledPostRepository struct {
+	db *DB
+}
+
+// NewScheduledPostRepository creates a new scheduled post repository.
+func NewScheduledPostRepository(db *DB) *ScheduledPostRepository {
+	return &ScheduledPostRepository{db: db}
+}
+
+// scheduledPostRow is scheduled_posts' column shape; Request is stored
+// as jsonb and marshaled/unmarshaled by hand since CrossPostRequest
+// nests UnifiedContent's own map[string]interface{} metadata, which
+// sqlx can't scan directly.
+type scheduledPostRow struct {
+	ID        string    `db:"id"`
+	TenantID  string    `db:"tenant_id"`
+	Request   []byte    `db:"request"`
+	RunAt     time.Time `db:"run_at"`
+	Status    string    `db:"status"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// Create assigns post an ID and persists it with status "pending".
+func (r *ScheduledPostRepository) Create(ctx context.Context, post *integrations.ScheduledPost) error {
+	if post.ID == "" {
+		post.ID = uuid.New().String()
+	}
+	if post.Status == "" {
+		post.Status = "pending"
+	}
+
+	request, err := json.Marshal(post.Request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal scheduled post request: %w", err)
+	}
+
+	query := `
+		INSERT INTO scheduled_posts (id, tenant_id, request, run_at, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, now())`
+
+	_, err = r.db.ExecContext(ctx, query, post.ID, post.TenantID, request, post.RunAt, post.Status)
+	if err != nil {
+		return fmt.Errorf("failed to create scheduled post: %w", err)
+	}
+	return nil
+}
+
+// ListDue returns every pending scheduled post whose run_at has passed.
+func (r *ScheduledPostRepository) ListDue(ctx context.Context, before time.Time) ([]integrations.ScheduledPost, error) {
+	query := `SELECT id, tenant_id, request, run_at, status, created_at
+		FROM scheduled_posts WHERE status = 'pending' AND run_at < $1`
+
+	var rows []scheduledPostRow
+	if err := r.db.SelectContext(ctx, &rows, query, before); err != nil {
+		return nil, fmt.Errorf("failed to list due scheduled posts: %w", err)
+	}
+
+	posts := make([]integrations.ScheduledPost, 0, len(rows))
+	for _, row := range rows {
+		var request integrations.CrossPostRequest
+		if err := json.Unmarshal(row.Request, &request); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal scheduled post %s request: %w", row.ID, err)
+		}
+		posts = append(posts, integrations.ScheduledPost{
+			ID:        row.ID,
+			TenantID:  row.TenantID,
+			Request:   request,
+			RunAt:     row.RunAt,
+			Status:    row.Status,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return posts, nil
+}
+
+// MarkPosted records a successful delivery. results isn't persisted
+// today (the table has no column for it); it's accepted so a future
+// column can be added without changing the interface.
+func (r *ScheduledPostRepository) MarkPosted(ctx context.Context, id string, results []integrations.CrossPostResult) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE scheduled_posts SET status = 'posted' WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark scheduled post posted: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed delivery attempt; errMsg isn't persisted
+// today for the same reason noted on MarkPosted.
+func (r *ScheduledPostRepository) MarkFailed(ctx context.Context, id string, errMsg string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE scheduled_posts SET status = 'failed' WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark scheduled post failed: %w", err)
+	}
+	return nil
+}
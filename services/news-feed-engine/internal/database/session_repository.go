@@ -0,0 +1,194 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+// SessionRepository handles sessions database operations: one row per
+// issued access/refresh token pair, so a user can list and revoke their
+// active logins and AuthMiddleware can reject a token whose session was
+// terminated elsewhere.
+type SessionRepository struct {
+	db *DB
+}
+
+// NewSessionRepository creates a new session repository.
+func NewSessionRepository(db *DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+const sessionColumns = `id, tenant_id, user_id, jti, roles, user_agent, ip, last_seen, expires_at, revoked_at, created_at`
+
+func sessionRow(s *models.Session) []interface{} {
+	return []interface{}{
+		&s.ID, &s.TenantID, &s.UserID, &s.JTI, &s.Roles, &s.UserAgent, &s.IP,
+		&s.LastSeen, &s.ExpiresAt, &s.RevokedAt, &s.CreatedAt,
+	}
+}
+
+// Create records a freshly issued session.
+func (r *SessionRepository) Create(ctx context.Context, s *models.Session) error {
+	query := `
+		INSERT INTO sessions (tenant_id, user_id, jti, roles, user_agent, ip, last_seen, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), $7)
+		RETURNING ` + sessionColumns
+
+	if err := r.db.QueryRowContext(ctx, query,
+		s.TenantID, s.UserID, s.JTI, s.Roles, s.UserAgent, s.IP, s.ExpiresAt,
+	).Scan(sessionRow(s)...); err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+// GetByJTI looks up the session an access token's "jti" claim refers
+// to. Returns (nil, nil) if no such session exists (e.g. an old token
+// pre-dating this subsystem) rather than an error, since AuthMiddleware
+// treats "no session row" the same as "session not terminated".
+func (r *SessionRepository) GetByJTI(ctx context.Context, tenantID, jti string) (*models.Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE tenant_id = $1 AND jti = $2`
+
+	var s models.Session
+	err := r.db.QueryRowContext(ctx, query, tenantID, jti).Scan(sessionRow(&s)...)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return &s, nil
+}
+
+// Get looks up a session by its id, scoped to tenantID. Returns (nil,
+// nil) if no such session exists.
+func (r *SessionRepository) Get(ctx context.Context, tenantID string, id uuid.UUID) (*models.Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE tenant_id = $1 AND id = $2`
+
+	var s models.Session
+	err := r.db.QueryRowContext(ctx, query, tenantID, id).Scan(sessionRow(&s)...)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return &s, nil
+}
+
+// RotateJTI replaces a session's jti and last_seen, issued a fresh
+// access token for an existing session via /auth/refresh without
+// needing a new session row (and therefore without losing the
+// session's place in the user's active-sessions list).
+func (r *SessionRepository) RotateJTI(ctx context.Context, tenantID string, id uuid.UUID, jti string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE sessions SET jti = $3, last_seen = NOW()
+		WHERE tenant_id = $1 AND id = $2`, tenantID, id, jti)
+	if err != nil {
+		return fmt.Errorf("failed to rotate session jti: %w", err)
+	}
+	return nil
+}
+
+// ListByUser returns tenantID/userID's sessions, newest first, for the
+// "active sessions" admin view.
+func (r *SessionRepository) ListByUser(ctx context.Context, tenantID, userID string) ([]models.Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE tenant_id = $1 AND user_id = $2 ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []models.Session
+	for rows.Next() {
+		var s models.Session
+		if err := rows.Scan(sessionRow(&s)...); err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+// Touch updates a session's last_seen/user_agent/ip to the values of
+// its most recent request, so the active-sessions view reflects current
+// usage rather than just the original login.
+func (r *SessionRepository) Touch(ctx context.Context, tenantID, jti, userAgent, ip string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE sessions SET last_seen = NOW(), user_agent = $3, ip = $4
+		WHERE tenant_id = $1 AND jti = $2`, tenantID, jti, userAgent, ip)
+	if err != nil {
+		return fmt.Errorf("failed to touch session: %w", err)
+	}
+	return nil
+}
+
+// Revoke marks a session terminated by id, scoped to tenantID so a
+// caller can never revoke another tenant's session by guessing a UUID.
+func (r *SessionRepository) Revoke(ctx context.Context, tenantID string, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE sessions SET revoked_at = NOW()
+		WHERE tenant_id = $1 AND id = $2 AND revoked_at IS NULL`, tenantID, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
+
+// RevokeByJTI marks the session for an access token's "jti" claim
+// terminated, used by /auth/logout where the caller only has its own
+// current token, not the session's row id.
+func (r *SessionRepository) RevokeByJTI(ctx context.Context, tenantID, jti string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE sessions SET revoked_at = NOW()
+		WHERE tenant_id = $1 AND jti = $2 AND revoked_at IS NULL`, tenantID, jti)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
+
+// IsTerminated reports whether sessionID has been revoked or has
+// expired, for AuthMiddleware's "sid" check. A session that doesn't
+// exist at all is treated as terminated, since that can only happen for
+// a forged or stale sid.
+func (r *SessionRepository) IsTerminated(ctx context.Context, tenantID string, sessionID uuid.UUID) (bool, error) {
+	var revoked bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT revoked_at IS NOT NULL OR expires_at < NOW()
+		FROM sessions WHERE tenant_id = $1 AND id = $2`, tenantID, sessionID).Scan(&revoked)
+	if err == sql.ErrNoRows {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check session status: %w", err)
+	}
+	return revoked, nil
+}
+
+// expiredSessionRetention is how long a terminated/expired session row
+// is kept around for the active-sessions history view before Prune
+// removes it.
+const expiredSessionRetention = 30 * 24 * time.Hour
+
+// Prune deletes sessions that expired or were revoked more than
+// expiredSessionRetention ago, for a periodic cleanup job.
+func (r *SessionRepository) Prune(ctx context.Context) (int, error) {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM sessions
+		WHERE (revoked_at IS NOT NULL AND revoked_at < $1)
+		   OR expires_at < $1`, time.Now().Add(-expiredSessionRetention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune sessions: %w", err)
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
@@ -0,0 +1,79 @@
+// Package database provides the YouTube PubSubHubbub subscription repository
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/integrations"
+)
+
+// SubscriptionRepository persists PubSubHubbub subscription leases in the
+// youtube_subscriptions table and implements
+// integrations.PubSubSubscriptionStore.
+type SubscriptionRepository struct {
+	db *DB
+}
+
+// NewSubscriptionRepository creates a new subscription repository.
+func NewSubscriptionRepository(db *DB) *SubscriptionRepository {
+	return &SubscriptionRepository{db: db}
+}
+
+// Get returns the tracked lease for channelID, or nil if none exists.
+func (r *SubscriptionRepository) Get(ctx context.Context, channelID string) (*integrations.PubSubSubscription, error) {
+	query := `SELECT channel_id, secret, lease_seconds, expires_at, updated_at
+		FROM youtube_subscriptions WHERE channel_id = $1`
+
+	var sub integrations.PubSubSubscription
+	err := r.db.GetContext(ctx, &sub, query, channelID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load youtube subscription: %w", err)
+	}
+	return &sub, nil
+}
+
+// Save upserts a subscription's lease.
+func (r *SubscriptionRepository) Save(ctx context.Context, sub *integrations.PubSubSubscription) error {
+	query := `
+		INSERT INTO youtube_subscriptions (channel_id, secret, lease_seconds, expires_at, updated_at)
+		VALUES ($1, $2, $3, $4, now())
+		ON CONFLICT (channel_id) DO UPDATE SET
+			secret = EXCLUDED.secret,
+			lease_seconds = EXCLUDED.lease_seconds,
+			expires_at = EXCLUDED.expires_at,
+			updated_at = now()`
+
+	_, err := r.db.ExecContext(ctx, query, sub.ChannelID, sub.Secret, sub.LeaseSeconds, sub.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to save youtube subscription: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a channel's tracked lease.
+func (r *SubscriptionRepository) Delete(ctx context.Context, channelID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM youtube_subscriptions WHERE channel_id = $1`, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to delete youtube subscription: %w", err)
+	}
+	return nil
+}
+
+// ListExpiringBefore returns every subscription whose lease expires
+// before cutoff, for RenewExpiring to re-subscribe.
+func (r *SubscriptionRepository) ListExpiringBefore(ctx context.Context, cutoff time.Time) ([]integrations.PubSubSubscription, error) {
+	query := `SELECT channel_id, secret, lease_seconds, expires_at, updated_at
+		FROM youtube_subscriptions WHERE expires_at < $1`
+
+	var subs []integrations.PubSubSubscription
+	if err := r.db.SelectContext(ctx, &subs, query, cutoff); err != nil {
+		return nil, fmt.Errorf("failed to list expiring youtube subscriptions: %w", err)
+	}
+	return subs, nil
+}
@@ -0,0 +1,84 @@
+// Package database provides the ContentAggregator sync-state repository
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/integrations"
+)
+
+// SyncStateRepository persists ContentAggregator watermarks and seen-ID
+// history in the sync_watermarks and sync_seen_ids tables, and
+// implements integrations.SyncStateStore.
+type SyncStateRepository struct {
+	db *DB
+}
+
+// NewSyncStateRepository creates a new sync state repository.
+func NewSyncStateRepository(db *DB) *SyncStateRepository {
+	return &SyncStateRepository{db: db}
+}
+
+// GetWatermark returns sourceKey's watermark, or the zero value if the
+// source has never completed a run.
+func (r *SyncStateRepository) GetWatermark(ctx context.Context, sourceKey string) (integrations.SyncWatermark, error) {
+	query := `SELECT last_seen_id, last_seen_published_at FROM sync_watermarks WHERE source_key = $1`
+
+	var wm integrations.SyncWatermark
+	err := r.db.GetContext(ctx, &wm, query, sourceKey)
+	if err == sql.ErrNoRows {
+		return integrations.SyncWatermark{}, nil
+	}
+	if err != nil {
+		return integrations.SyncWatermark{}, fmt.Errorf("failed to load sync watermark: %w", err)
+	}
+	return wm, nil
+}
+
+// SetWatermark upserts sourceKey's watermark.
+func (r *SyncStateRepository) SetWatermark(ctx context.Context, sourceKey string, wm integrations.SyncWatermark) error {
+	query := `
+		INSERT INTO sync_watermarks (source_key, last_seen_id, last_seen_published_at, updated_at)
+		VALUES ($1, $2, $3, now())
+		ON CONFLICT (source_key) DO UPDATE SET
+			last_seen_id = EXCLUDED.last_seen_id,
+			last_seen_published_at = EXCLUDED.last_seen_published_at,
+			updated_at = now()`
+
+	_, err := r.db.ExecContext(ctx, query, sourceKey, wm.LastSeenID, wm.LastSeenPublishedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save sync watermark: %w", err)
+	}
+	return nil
+}
+
+// MarkSeen records id as emitted for sourceKey and reports whether it
+// was already recorded. The insert's ON CONFLICT DO NOTHING makes the
+// check-and-record atomic: if no row was inserted, id was already seen.
+func (r *SyncStateRepository) MarkSeen(ctx context.Context, sourceKey, id string) (bool, error) {
+	query := `INSERT INTO sync_seen_ids (source_key, content_id, seen_at)
+		VALUES ($1, $2, now()) ON CONFLICT (source_key, content_id) DO NOTHING`
+
+	result, err := r.db.ExecContext(ctx, query, sourceKey, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark content seen: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to read mark-seen result: %w", err)
+	}
+	return rows == 0, nil
+}
+
+// ResetSource clears sourceKey's watermark and seen-ID history.
+func (r *SyncStateRepository) ResetSource(ctx context.Context, sourceKey string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM sync_watermarks WHERE source_key = $1`, sourceKey); err != nil {
+		return fmt.Errorf("failed to reset sync watermark: %w", err)
+	}
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM sync_seen_ids WHERE source_key = $1`, sourceKey); err != nil {
+		return fmt.Errorf("failed to reset sync seen ids: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+// Package database provides database access for creator verification requests
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+// VerificationRequestRepository handles creator verification request
+// database operations
+type VerificationRequestRepository struct {
+	db *DB
+}
+
+// NewVerificationRequestRepository creates a new verification request repository
+func NewVerificationRequestRepository(db *DB) *VerificationRequestRepository {
+	return &VerificationRequestRepository{db: db}
+}
+
+// Create inserts a new pending verification request
+func (r *VerificationRequestRepository) Create(ctx context.Context, req *models.VerificationRequest) error {
+	query := `
+		INSERT INTO verification_requests (
+			id, tenant_id, creator_id, requested_tier, evidence_urls,
+			requested_by, status
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7
+		)
+		RETURNING created_at, updated_at
+	`
+
+	if req.ID == uuid.Nil {
+		req.ID = uuid.New()
+	}
+	if req.Status == "" {
+		req.Status = models.VerificationStatusPending
+	}
+
+	err := r.db.QueryRowContext(ctx, query,
+		req.ID, req.TenantID, req.CreatorID, req.RequestedTier, req.EvidenceURLs,
+		req.RequestedBy, req.Status,
+	).Scan(&req.CreatedAt, &req.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create verification request: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a single verification request by ID
+func (r *VerificationRequestRepository) GetByID(ctx context.Context, tenantID string, requestID uuid.UUID) (*models.VerificationRequest, error) {
+	query := `
+		SELECT id, tenant_id, creator_id, requested_tier, evidence_urls,
+		       requested_by, status, reviewer_id, reviewer_notes, decided_at,
+		       created_at, updated_at
+		FROM verification_requests
+		WHERE tenant_id = $1 AND id = $2
+	`
+
+	var v models.VerificationRequest
+	err := r.db.QueryRowContext(ctx, query, tenantID, requestID).Scan(
+		&v.ID, &v.TenantID, &v.CreatorID, &v.RequestedTier, &v.EvidenceURLs,
+		&v.RequestedBy, &v.Status, &v.ReviewerID, &v.ReviewerNotes, &v.DecidedAt,
+		&v.CreatedAt, &v.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("verification request not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get verification request: %w", err)
+	}
+
+	return &v, nil
+}
+
+// ListByCreator retrieves every verification request filed against a
+// creator, newest first, for the tenant's audit trail.
+func (r *VerificationRequestRepository) ListByCreator(ctx context.Context, tenantID string, creatorID uuid.UUID) ([]models.VerificationRequest, error) {
+	query := `
+		SELECT id, tenant_id, creator_id, requested_tier, evidence_urls,
+		       requested_by, status, reviewer_id, reviewer_notes, decided_at,
+		       created_at, updated_at
+		FROM verification_requests
+		WHERE tenant_id = $1 AND creator_id = $2
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, creatorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query verification requests: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []models.VerificationRequest
+	for rows.Next() {
+		var v models.VerificationRequest
+		err := rows.Scan(
+			&v.ID, &v.TenantID, &v.CreatorID, &v.RequestedTier, &v.EvidenceURLs,
+			&v.RequestedBy, &v.Status, &v.ReviewerID, &v.ReviewerNotes, &v.DecidedAt,
+			&v.CreatedAt, &v.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan verification request: %w", err)
+		}
+		requests = append(requests, v)
+	}
+
+	return requests, nil
+}
+
+// Decide transitions a pending verification request to approved or
+// rejected, recording the reviewer's identity and notes. It only affects
+// rows still in the pending state, so a request that was already decided
+// (or revoked) reports zero rows affected rather than silently
+// overwriting an earlier decision.
+func (r *VerificationRequestRepository) Decide(ctx context.Context, tenantID string, requestID uuid.UUID, status models.VerificationStatus, reviewerID, reviewerNotes string) error {
+	query := `
+		UPDATE verification_requests
+		SET status = $1, reviewer_id = $2, reviewer_notes = $3, decided_at = NOW(), updated_at = NOW()
+		WHERE tenant_id = $4 AND id = $5 AND status = $6
+	`
+
+	result, err := r.db.ExecContext(ctx, query, status, reviewerID, reviewerNotes, tenantID, requestID, models.VerificationStatusPending)
+	if err != nil {
+		return fmt.Errorf("failed to decide verification request: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("verification request not found or already decided")
+	}
+
+	return nil
+}
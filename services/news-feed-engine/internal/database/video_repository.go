@@ -4,10 +4,16 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/transcript"
 )
 
 // VideoRepository handles video database operations
@@ -28,59 +34,78 @@ type VideoListOptions struct {
 	ContentID *uuid.UUID
 	DateFrom  string
 	DateTo    string
-	SortBy    string // created_at, duration, view_count
-	Order     string // asc, desc
+	// MinDuration/MaxDuration filter on v.duration (seconds), inclusive.
+	// Nil means unbounded on that side.
+	MinDuration *int
+	MaxDuration *int
+	// Type classifies by duration the same way ytapi.Video.IsShort/IsLive
+	// do: "short" (<= 60s), "long" (> 60s), or "live" (duration is 0,
+	// i.e. generated from a still-live source at summarization time).
+	// Empty means no classification filter.
+	Type   string
+	SortBy string // created_at, duration, view_count
+	Order  string // asc, desc
 }
 
-// List retrieves a paginated list of videos with filters
-func (r *VideoRepository) List(ctx context.Context, tenantID string, opts VideoListOptions) ([]models.VideoSummary, int, error) {
-	query := `
-		SELECT v.id, v.tenant_id, v.content_id, v.title, v.script, v.voice_id, v.avatar_id,
-		       v.video_url, v.thumbnail_url, v.duration, v.file_size, v.resolution, v.format,
-		       v.status, v.error_message, v.view_count, v.like_count, v.share_count,
-		       v.generated_at, v.generation_time, v.metadata, v.created_at, v.updated_at
-		FROM video_summaries v
-		WHERE v.tenant_id = $1
-	`
+// buildFilters returns the shared WHERE conditions (and their args) for
+// opts, so List's row query and count query stay in sync instead of
+// drifting, as they did before MinDuration/MaxDuration/Type were added.
+func (opts VideoListOptions) buildFilters(tenantID string) (string, []interface{}) {
+	conditions := []string{"v.tenant_id = $1"}
 	args := []interface{}{tenantID}
-	argCount := 1
 
-	// Add filters
-	if opts.Status != "" {
-		argCount++
-		query += fmt.Sprintf(" AND v.status = $%d", argCount)
-		args = append(args, opts.Status)
+	addCondition := func(clause string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(clause, len(args)))
 	}
 
+	if opts.Status != "" {
+		addCondition("v.status = $%d", opts.Status)
+	}
 	if opts.ContentID != nil {
-		argCount++
-		query += fmt.Sprintf(" AND v.content_id = $%d", argCount)
-		args = append(args, opts.ContentID)
+		addCondition("v.content_id = $%d", opts.ContentID)
 	}
-
 	if opts.DateFrom != "" {
-		argCount++
-		query += fmt.Sprintf(" AND v.created_at >= $%d", argCount)
-		args = append(args, opts.DateFrom)
+		addCondition("v.created_at >= $%d", opts.DateFrom)
 	}
-
 	if opts.DateTo != "" {
-		argCount++
-		query += fmt.Sprintf(" AND v.created_at <= $%d", argCount)
-		args = append(args, opts.DateTo)
+		addCondition("v.created_at <= $%d", opts.DateTo)
 	}
-
-	// Count total matching records
-	countQuery := "SELECT COUNT(*) FROM video_summaries v WHERE v.tenant_id = $1"
-	if opts.Status != "" {
-		countQuery += " AND v.status = $2"
+	if opts.MinDuration != nil {
+		addCondition("v.duration >= $%d", *opts.MinDuration)
 	}
-	if opts.ContentID != nil {
-		countQuery += fmt.Sprintf(" AND v.content_id = $%d", argCount-1)
+	if opts.MaxDuration != nil {
+		addCondition("v.duration <= $%d", *opts.MaxDuration)
+	}
+	switch opts.Type {
+	case "short":
+		conditions = append(conditions, "v.duration > 0 AND v.duration <= 60")
+	case "long":
+		conditions = append(conditions, "v.duration > 60")
+	case "live":
+		conditions = append(conditions, "v.duration = 0")
 	}
 
+	return strings.Join(conditions, " AND "), args
+}
+
+// List retrieves a paginated list of videos with filters
+func (r *VideoRepository) List(ctx context.Context, tenantID string, opts VideoListOptions) ([]models.VideoSummary, int, error) {
+	where, args := opts.buildFilters(tenantID)
+	argCount := len(args)
+
+	query := `
+		SELECT v.id, v.tenant_id, v.content_id, v.title, v.script, v.voice_id, v.avatar_id,
+		       v.video_url, v.thumbnail_url, v.duration, v.file_size, v.resolution, v.format,
+		       v.status, v.error_message, v.view_count, v.like_count, v.share_count,
+		       v.generated_at, v.generation_time, v.metadata, v.created_at, v.updated_at
+		FROM video_summaries v
+		WHERE ` + where
+
+	countQuery := "SELECT COUNT(*) FROM video_summaries v WHERE " + where
+
 	var total int
-	err := r.db.QueryRowContext(ctx, countQuery, args[:argCount]...).Scan(&total)
+	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count videos: %w", err)
 	}
@@ -161,25 +186,47 @@ func (r *VideoRepository) GetByID(ctx context.Context, tenantID string, videoID
 	return &v, nil
 }
 
-// GetTranscript retrieves the transcript (script) for a video
-func (r *VideoRepository) GetTranscript(ctx context.Context, tenantID string, videoID uuid.UUID) (string, error) {
+// GetTranscript retrieves a video's structured, timed transcript.
+// transcript_cues holds the cue model produced alongside the video by
+// the rendering worker; rows generated before that worker captured
+// cue-level timing have it NULL, so GetTranscript falls back to a
+// single untimed cue built from the plain-text script, spanning the
+// video's whole duration.
+func (r *VideoRepository) GetTranscript(ctx context.Context, tenantID string, videoID uuid.UUID) (transcript.Transcript, error) {
 	query := `
-		SELECT v.script
+		SELECT v.script, v.transcript_cues, v.duration
 		FROM video_summaries v
 		WHERE v.tenant_id = $1 AND v.id = $2
 	`
 
-	var transcript string
-	err := r.db.QueryRowContext(ctx, query, tenantID, videoID).Scan(&transcript)
+	var script string
+	var cuesJSON []byte
+	var duration int
+	err := r.db.QueryRowContext(ctx, query, tenantID, videoID).Scan(&script, &cuesJSON, &duration)
 
 	if err == sql.ErrNoRows {
-		return "", fmt.Errorf("video not found")
+		return nil, fmt.Errorf("video not found")
 	}
 	if err != nil {
-		return "", fmt.Errorf("failed to get transcript: %w", err)
+		return nil, fmt.Errorf("failed to get transcript: %w", err)
+	}
+
+	if len(cuesJSON) > 0 {
+		var cues transcript.Transcript
+		if err := json.Unmarshal(cuesJSON, &cues); err != nil {
+			return nil, fmt.Errorf("failed to decode transcript cues: %w", err)
+		}
+		return cues, nil
 	}
 
-	return transcript, nil
+	if script == "" {
+		return transcript.Transcript{}, nil
+	}
+	return transcript.Transcript{{
+		Start: 0,
+		End:   time.Duration(duration) * time.Second,
+		Text:  script,
+	}}, nil
 }
 
 // GetQueueStats retrieves video generation queue statistics
@@ -209,3 +256,217 @@ func (r *VideoRepository) GetQueueStats(ctx context.Context, tenantID string) (*
 	stats.TenantID = tenantID
 	return &stats, nil
 }
+
+// videoBackoffBase and videoBackoffMax bound Fail's retry scheduling:
+// attempt N waits min(base*2^(N-1), max) before next_attempt_at, so a
+// flaky render backend doesn't get hammered but a transient blip retries
+// quickly.
+const (
+	videoBackoffBase = 30 * time.Second
+	videoBackoffMax  = 30 * time.Minute
+)
+
+// ClaimNext atomically hands one pending (or due-for-retry) video job to
+// workerID, marking it processing and leasing it for leaseDuration. It
+// returns nil, nil if no job is available. Concurrent workers calling
+// ClaimNext never receive the same row: SELECT ... FOR UPDATE SKIP LOCKED
+// lets each worker skip rows another transaction already has locked
+// instead of blocking on them.
+func (r *VideoRepository) ClaimNext(ctx context.Context, tenantID, workerID string, leaseDuration time.Duration) (*models.VideoSummary, error) {
+	var claimed *models.VideoSummary
+
+	err := r.db.WithTransaction(ctx, func(tx *sqlx.Tx) error {
+		var id uuid.UUID
+		err := tx.QueryRowContext(ctx, `
+			SELECT id FROM video_summaries
+			WHERE tenant_id = $1
+			  AND status = $2
+			  AND (next_attempt_at IS NULL OR next_attempt_at <= NOW())
+			ORDER BY created_at ASC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		`, tenantID, models.StatusPending).Scan(&id)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to select claimable video: %w", err)
+		}
+
+		row := tx.QueryRowxContext(ctx, `
+			UPDATE video_summaries
+			SET status = $1, worker_id = $2, lease_expires_at = NOW() + $3::interval
+			WHERE id = $4
+			RETURNING id, tenant_id, content_id, title, script, voice_id, avatar_id,
+			          video_url, thumbnail_url, duration, file_size, resolution, format,
+			          status, error_message, view_count, like_count, share_count,
+			          generated_at, generation_time, metadata, created_at, updated_at
+		`, models.StatusProcessing, workerID, leaseDuration.String(), id)
+
+		var v models.VideoSummary
+		if err := row.StructScan(&v); err != nil {
+			return fmt.Errorf("failed to claim video: %w", err)
+		}
+		claimed = &v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+// Heartbeat extends the lease on a video still owned by workerID, so a
+// worker mid-render doesn't lose its claim to the reaper. It is a no-op
+// (no error) if the video is no longer leased to workerID, since that
+// means it was already reaped or completed out from under this worker.
+func (r *VideoRepository) Heartbeat(ctx context.Context, videoID uuid.UUID, workerID string, leaseDuration time.Duration) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE video_summaries
+		SET lease_expires_at = NOW() + $1::interval
+		WHERE id = $2 AND worker_id = $3 AND status = $4
+	`, leaseDuration.String(), videoID, workerID, models.StatusProcessing)
+	if err != nil {
+		return fmt.Errorf("failed to extend video lease: %w", err)
+	}
+	return nil
+}
+
+// Complete marks a video generation job finished successfully, recording
+// the renderer's output file size/format and wall-clock generation time
+// alongside the video/thumbnail URLs and duration.
+func (r *VideoRepository) Complete(ctx context.Context, videoID uuid.UUID, videoURL, thumbnailURL string, duration int, fileSize int64, format string, generationTime int) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE video_summaries
+		SET status = $1, video_url = $2, thumbnail_url = $3, duration = $4,
+		    file_size = $5, format = $6, generation_time = $7,
+		    generated_at = NOW(), worker_id = NULL, lease_expires_at = NULL
+		WHERE id = $8
+	`, models.StatusCompleted, videoURL, thumbnailURL, duration, fileSize, format, generationTime, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to complete video: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to confirm video completion: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("video not found")
+	}
+	return nil
+}
+
+// Fail records a failed attempt. If retryable, the job goes back to
+// pending with next_attempt_at set by an exponential backoff on its
+// attempts counter; otherwise it is marked failed for good.
+func (r *VideoRepository) Fail(ctx context.Context, videoID uuid.UUID, errMsg string, retryable bool) error {
+	if !retryable {
+		result, err := r.db.ExecContext(ctx, `
+			UPDATE video_summaries
+			SET status = $1, error_message = $2, attempts = attempts + 1,
+			    worker_id = NULL, lease_expires_at = NULL
+			WHERE id = $3
+		`, models.StatusFailed, errMsg, videoID)
+		if err != nil {
+			return fmt.Errorf("failed to mark video failed: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to confirm video failure: %w", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("video not found")
+		}
+		return nil
+	}
+
+	var attempts int
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE video_summaries
+		SET status = $1, error_message = $2, attempts = attempts + 1,
+		    worker_id = NULL, lease_expires_at = NULL
+		WHERE id = $3
+		RETURNING attempts
+	`, models.StatusPending, errMsg, videoID).Scan(&attempts)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("video not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to schedule video retry: %w", err)
+	}
+
+	backoff := time.Duration(float64(videoBackoffBase) * math.Pow(2, float64(attempts-1)))
+	if backoff > videoBackoffMax {
+		backoff = videoBackoffMax
+	}
+	if _, err := r.db.ExecContext(ctx, `
+		UPDATE video_summaries SET next_attempt_at = NOW() + $1::interval WHERE id = $2
+	`, backoff.String(), videoID); err != nil {
+		return fmt.Errorf("failed to set video retry backoff: %w", err)
+	}
+	return nil
+}
+
+// ReapExpiredLeases returns every video stuck in processing past its
+// lease_expires_at back to pending, so a worker that crashed or was
+// killed mid-render doesn't strand its job forever. It returns the number
+// of jobs reaped.
+func (r *VideoRepository) ReapExpiredLeases(ctx context.Context) (int, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE video_summaries
+		SET status = $1, worker_id = NULL, lease_expires_at = NULL
+		WHERE status = $2 AND lease_expires_at IS NOT NULL AND lease_expires_at < NOW()
+	`, models.StatusPending, models.StatusProcessing)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reap expired video leases: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to confirm reaped video leases: %w", err)
+	}
+	return int(rows), nil
+}
+
+// Retry moves a failed video back to pending for immediate re-attempt,
+// regardless of Fail's backoff schedule, so an operator can force a retry
+// without waiting out next_attempt_at.
+func (r *VideoRepository) Retry(ctx context.Context, tenantID string, videoID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE video_summaries
+		SET status = $1, next_attempt_at = NULL, error_message = ''
+		WHERE tenant_id = $2 AND id = $3 AND status = $4
+	`, models.StatusPending, tenantID, videoID, models.StatusFailed)
+	if err != nil {
+		return fmt.Errorf("failed to retry video: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to confirm video retry: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("video not found")
+	}
+	return nil
+}
+
+// Cancel stops a pending or processing video job, marking it failed with
+// a fixed message so it neither gets claimed nor reaped back to pending.
+func (r *VideoRepository) Cancel(ctx context.Context, tenantID string, videoID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE video_summaries
+		SET status = $1, error_message = 'cancelled', worker_id = NULL,
+		    lease_expires_at = NULL, next_attempt_at = NULL
+		WHERE tenant_id = $2 AND id = $3 AND status IN ($4, $5)
+	`, models.StatusFailed, tenantID, videoID, models.StatusPending, models.StatusProcessing)
+	if err != nil {
+		return fmt.Errorf("failed to cancel video: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to confirm video cancellation: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("video not found")
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Package database provides the outbound webmention repository
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/integrations"
+)
+
+// WebmentionRepository persists outbound webmention attempts in the
+// webmention_out table and implements integrations.WebmentionStore.
+type WebmentionRepository struct {
+	db *DB
+}
+
+// NewWebmentionRepository creates a new webmention repository.
+func NewWebmentionRepository(db *DB) *WebmentionRepository {
+	return &WebmentionRepository{db: db}
+}
+
+// Upsert records or updates the attempt for (post_id, target), so re-sends
+// triggered by a post update don't create duplicate rows.
+func (r *WebmentionRepository) Upsert(ctx context.Context, rec integrations.WebmentionRecord) error {
+	query := `
+		INSERT INTO webmention_out (post_id, target, endpoint, status, last_attempt)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (post_id, target) DO UPDATE
+		SET endpoint = EXCLUDED.endpoint, status = EXCLUDED.status, last_attempt = EXCLUDED.last_attempt`
+
+	if _, err := r.db.ExecContext(ctx, query, rec.PostID, rec.Target, rec.Endpoint, rec.Status, rec.LastAttempt); err != nil {
+		return fmt.Errorf("failed to upsert webmention attempt: %w", err)
+	}
+	return nil
+}
+
+// ListForPost returns every webmention attempt recorded for postID.
+func (r *WebmentionRepository) ListForPost(ctx context.Context, postID string) ([]integrations.WebmentionRecord, error) {
+	query := `SELECT post_id, target, endpoint, status, last_attempt FROM webmention_out WHERE post_id = $1`
+
+	var records []integrations.WebmentionRecord
+	if err := r.db.SelectContext(ctx, &records, query, postID); err != nil {
+		return nil, fmt.Errorf("failed to list webmention attempts: %w", err)
+	}
+	return records, nil
+}
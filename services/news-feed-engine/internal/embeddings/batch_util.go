@@ -0,0 +1,45 @@
+package embeddings
+
+// approxTokenCount estimates a text's token count for batch-chunking
+// purposes. This repo doesn't vendor a tokenizer, so it uses the common
+// ~4-characters-per-token heuristic rather than an exact count; it only
+// needs to be close enough to stay under a provider's per-request token
+// ceiling.
+func approxTokenCount(text string) int {
+	return len(text)/4 + 1
+}
+
+// chunkByLimits splits texts into ordered batches that each respect
+// maxBatchSize (item count) and maxTokens (approxTokenCount sum), so a
+// single oversized request doesn't get rejected by the provider.
+func chunkByLimits(texts []string, maxBatchSize, maxTokens int) [][]string {
+	if len(texts) == 0 {
+		return nil
+	}
+
+	var (
+		chunks     [][]string
+		current    []string
+		currentLen int
+	)
+
+	flush := func() {
+		if len(current) > 0 {
+			chunks = append(chunks, current)
+			current = nil
+			currentLen = 0
+		}
+	}
+
+	for _, text := range texts {
+		tokens := approxTokenCount(text)
+		if len(current) > 0 && (len(current) >= maxBatchSize || currentLen+tokens > maxTokens) {
+			flush()
+		}
+		current = append(current, text)
+		currentLen += tokens
+	}
+	flush()
+
+	return chunks
+}
@@ -0,0 +1,134 @@
+package embeddings
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultBatchWindow is how long BatchingService waits after the first
+// pending Generate call before flushing, to give concurrent callers a
+// chance to coalesce into the same provider request.
+const defaultBatchWindow = 20 * time.Millisecond
+
+// pendingEmbed is one caller's coalesced Generate request, waiting to
+// be folded into the next GenerateBatch call.
+type pendingEmbed struct {
+	text   string
+	result chan<- batchResult
+}
+
+type batchResult struct {
+	embedding []float32
+	err       error
+}
+
+// BatchingService wraps a Service and coalesces concurrent single-text
+// Generate calls arriving within window into one GenerateBatch call,
+// fanning the results back out to each caller. This is what feed
+// ingestion should call instead of the underlying provider directly:
+// articles arriving in a burst share one HTTP round trip instead of
+// paying for one each.
+type BatchingService struct {
+	inner   Service
+	window  time.Duration
+	limiter *RateLimiter
+
+	mu      sync.Mutex
+	pending []pendingEmbed
+	timer   *time.Timer
+}
+
+// NewBatchingService wraps inner, coalescing Generate calls received
+// within window into a single GenerateBatch call. A window of 0 uses
+// defaultBatchWindow.
+func NewBatchingService(inner Service, window time.Duration) *BatchingService {
+	if window <= 0 {
+		window = defaultBatchWindow
+	}
+	return &BatchingService{
+		inner:  inner,
+		window: window,
+	}
+}
+
+// WithRateLimiter attaches a token-bucket limiter so flushes honor the
+// provider's request quota even when traffic is bursty.
+func (b *BatchingService) WithRateLimiter(limiter *RateLimiter) *BatchingService {
+	b.limiter = limiter
+	return b
+}
+
+// Generate enqueues text to be embedded in the next coalesced batch and
+// blocks until that batch's result is available or ctx is cancelled.
+func (b *BatchingService) Generate(ctx context.Context, text string) ([]float32, error) {
+	resultCh := make(chan batchResult, 1)
+
+	b.mu.Lock()
+	b.pending = append(b.pending, pendingEmbed{text: text, result: resultCh})
+	if b.timer == nil {
+		b.timer = time.AfterFunc(b.window, b.flush)
+	}
+	b.mu.Unlock()
+
+	select {
+	case res := <-resultCh:
+		return res.embedding, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// GenerateBatch embeds texts directly via the wrapped Service, bypassing
+// coalescing: the caller has already batched its own request, so there's
+// nothing left to coalesce.
+func (b *BatchingService) GenerateBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	if b.limiter != nil {
+		if err := b.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return b.inner.GenerateBatch(ctx, texts)
+}
+
+// Dim reports the wrapped Service's output dimension.
+func (b *BatchingService) Dim() int { return b.inner.Dim() }
+
+func (b *BatchingService) flush() {
+	b.mu.Lock()
+	batch := b.pending
+	b.pending = nil
+	b.timer = nil
+	b.mu.Unlock()
+
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx := context.Background()
+	if b.limiter != nil {
+		if err := b.limiter.Wait(ctx); err != nil {
+			for _, p := range batch {
+				p.result <- batchResult{err: err}
+			}
+			return
+		}
+	}
+
+	texts := make([]string, len(batch))
+	for i, p := range batch {
+		texts[i] = p.text
+	}
+
+	embeddings, err := b.inner.GenerateBatch(ctx, texts)
+	if err != nil {
+		for _, p := range batch {
+			p.result <- batchResult{err: err}
+		}
+		return
+	}
+
+	for i, p := range batch {
+		p.result <- batchResult{embedding: embeddings[i]}
+	}
+}
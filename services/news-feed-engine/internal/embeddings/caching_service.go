@@ -0,0 +1,165 @@
+package embeddings
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/cache"
+)
+
+// CachingServiceTTL is how long a cached embedding is kept by default.
+// An embedding for a given (model, text) pair never changes, so this is
+// generous -- long enough that a job re-ranking older articles with the
+// same model hits cache almost every time.
+const CachingServiceTTL = 30 * 24 * time.Hour
+
+// CacheMetrics is a snapshot of a CachingService's hit/miss/byte
+// counters.
+type CacheMetrics struct {
+	Hits   uint64
+	Misses uint64
+	Bytes  uint64
+}
+
+// CachingService wraps a Service and caches embeddings by
+// (model, sha256(text)) in a cache.Store, so reprocessing the same text
+// with the same model skips the paid provider call entirely. Pass a
+// cache.LRUStore for a single replica or cache.RedisStore to share the
+// cache across replicas, the same tradeoff the content cache.Store
+// backends already make.
+type CachingService struct {
+	inner Service
+	store cache.Store
+	model string
+	ttl   time.Duration
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+	bytes  atomic.Uint64
+}
+
+// NewCachingService wraps inner, caching its output in store under keys
+// scoped to model so switching models doesn't serve a stale-dimension
+// vector out of a store shared across models. ttl <= 0 uses
+// CachingServiceTTL.
+func NewCachingService(inner Service, store cache.Store, model string, ttl time.Duration) *CachingService {
+	if ttl <= 0 {
+		ttl = CachingServiceTTL
+	}
+	return &CachingService{inner: inner, store: store, model: model, ttl: ttl}
+}
+
+// Generate returns text's cached embedding if present, otherwise
+// generates it via the wrapped Service and caches the result.
+func (c *CachingService) Generate(ctx context.Context, text string) ([]float32, error) {
+	key := c.cacheKey(text)
+
+	if raw, ok, err := c.store.Get(ctx, key); err == nil && ok {
+		if embedding, derr := decodeEmbedding(raw); derr == nil {
+			c.hits.Add(1)
+			return embedding, nil
+		}
+	}
+	c.misses.Add(1)
+
+	embedding, err := c.inner.Generate(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	c.writeCache(ctx, text, embedding)
+
+	return embedding, nil
+}
+
+// GenerateBatch returns cached embeddings for any texts already
+// present, and only calls the wrapped Service's GenerateBatch for the
+// misses, caching those results in turn. Order of the returned slice
+// matches texts.
+func (c *CachingService) GenerateBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	results := make([][]float32, len(texts))
+
+	var missIdx []int
+	var missTexts []string
+
+	for i, text := range texts {
+		raw, ok, err := c.store.Get(ctx, c.cacheKey(text))
+		if err == nil && ok {
+			if embedding, derr := decodeEmbedding(raw); derr == nil {
+				c.hits.Add(1)
+				results[i] = embedding
+				continue
+			}
+		}
+		c.misses.Add(1)
+		missIdx = append(missIdx, i)
+		missTexts = append(missTexts, text)
+	}
+
+	if len(missTexts) == 0 {
+		return results, nil
+	}
+
+	embeddings, err := c.inner.GenerateBatch(ctx, missTexts)
+	if err != nil {
+		return nil, err
+	}
+
+	for j, idx := range missIdx {
+		results[idx] = embeddings[j]
+		c.writeCache(ctx, texts[idx], embeddings[j])
+	}
+
+	return results, nil
+}
+
+// Dim reports the wrapped Service's output dimension.
+func (c *CachingService) Dim() int { return c.inner.Dim() }
+
+// Warm pre-populates the cache for texts, generating (and caching) any
+// not already present. Run this before a bulk re-ranking job so its
+// read path never blocks on the provider.
+func (c *CachingService) Warm(ctx context.Context, texts []string) error {
+	_, err := c.GenerateBatch(ctx, texts)
+	return err
+}
+
+// Metrics returns a snapshot of this CachingService's hit/miss/byte
+// counters.
+func (c *CachingService) Metrics() CacheMetrics {
+	return CacheMetrics{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+		Bytes:  c.bytes.Load(),
+	}
+}
+
+func (c *CachingService) cacheKey(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return fmt.Sprintf("embedding:%s:%s", c.model, hex.EncodeToString(sum[:]))
+}
+
+func (c *CachingService) writeCache(ctx context.Context, text string, embedding []float32) {
+	raw, err := encodeEmbedding(embedding)
+	if err != nil {
+		return
+	}
+	if err := c.store.Set(ctx, c.cacheKey(text), raw, c.ttl); err != nil {
+		return
+	}
+	c.bytes.Add(uint64(len(raw)))
+}
+
+func encodeEmbedding(v []float32) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func decodeEmbedding(raw []byte) ([]float32, error) {
+	var v []float32
+	err := json.Unmarshal(raw, &v)
+	return v, err
+}
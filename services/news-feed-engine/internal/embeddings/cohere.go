@@ -0,0 +1,120 @@
+package embeddings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// cohereEmbedDim is embed-english-v3.0's output dimension.
+const cohereEmbedDim = 1024
+
+// CohereService implements embedding generation using Cohere's Embed API.
+type CohereService struct {
+	apiKey     string
+	model      string
+	httpClient *http.Client
+	apiURL     string
+}
+
+// NewCohereService creates a new Cohere-based embedding service.
+func NewCohereService(apiKey string) *CohereService {
+	return &CohereService{
+		apiKey: apiKey,
+		model:  "embed-english-v3.0",
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		apiURL: "https://api.cohere.com/v1/embed",
+	}
+}
+
+func init() {
+	Register("cohere", func(u *url.URL) (Service, error) {
+		apiKey := u.Query().Get("api_key")
+		if apiKey == "" {
+			return nil, fmt.Errorf("embeddings: cohere:// dsn requires api_key")
+		}
+		return NewCohereService(apiKey), nil
+	})
+}
+
+// Generate creates an embed-english-v3.0 embedding vector
+// (cohereEmbedDim-dimensional) from text
+func (s *CohereService) Generate(ctx context.Context, text string) ([]float32, error) {
+	if text == "" {
+		return nil, fmt.Errorf("text cannot be empty")
+	}
+
+	reqBody := map[string]interface{}{
+		"texts":      []string{text},
+		"model":      s.model,
+		"input_type": "search_document",
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.apiURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Embeddings [][]float32 `json:"embeddings"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if len(result.Embeddings) == 0 || len(result.Embeddings[0]) == 0 {
+		return nil, fmt.Errorf("no embedding returned from API")
+	}
+
+	return result.Embeddings[0], nil
+}
+
+// Dim reports embed-english-v3.0's output dimension.
+func (s *CohereService) Dim() int { return cohereEmbedDim }
+
+// GenerateBatch embeds texts one at a time via Generate. Cohere's Embed
+// API does support a native "texts" array, but batching it natively
+// isn't needed yet; this keeps GenerateBatch available to satisfy
+// Service without duplicating the request/retry plumbing OpenAIService
+// and ClaudeService already have.
+func (s *CohereService) GenerateBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	results := make([][]float32, len(texts))
+	for i, text := range texts {
+		embedding, err := s.Generate(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = embedding
+	}
+	return results, nil
+}
@@ -0,0 +1,166 @@
+package embeddings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// hfDefaultDim is all-MiniLM-L6-v2's output dimension, used when a DSN
+// doesn't specify one explicitly.
+const hfDefaultDim = 384
+
+// HuggingFaceService implements embedding generation using the
+// HuggingFace Inference API's feature-extraction pipeline.
+type HuggingFaceService struct {
+	apiKey     string
+	model      string
+	dim        int
+	httpClient *http.Client
+	apiURL     string
+}
+
+// NewHuggingFaceService creates a new HuggingFace Inference-based
+// embedding service. model defaults to "sentence-transformers/all-MiniLM-L6-v2"
+// and dim to hfDefaultDim when left empty/zero.
+func NewHuggingFaceService(apiKey, model string, dim int) *HuggingFaceService {
+	if model == "" {
+		model = "sentence-transformers/all-MiniLM-L6-v2"
+	}
+	if dim == 0 {
+		dim = hfDefaultDim
+	}
+	return &HuggingFaceService{
+		apiKey: apiKey,
+		model:  model,
+		dim:    dim,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		apiURL: "https://api-inference.huggingface.co/pipeline/feature-extraction/" + model,
+	}
+}
+
+func init() {
+	Register("huggingface", func(u *url.URL) (Service, error) {
+		apiKey := u.Query().Get("api_key")
+		if apiKey == "" {
+			return nil, fmt.Errorf("embeddings: huggingface:// dsn requires api_key")
+		}
+		dim := 0
+		if raw := u.Query().Get("dim"); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil {
+				return nil, fmt.Errorf("embeddings: huggingface:// dsn has invalid dim %q: %w", raw, err)
+			}
+			dim = parsed
+		}
+		return NewHuggingFaceService(apiKey, u.Query().Get("model"), dim), nil
+	})
+}
+
+// Generate creates an embedding vector from text via the model's
+// feature-extraction pipeline. The API returns either a flat (already
+// pooled) vector or a per-token matrix depending on the model, so a
+// nested response is mean-pooled into a single vector.
+func (s *HuggingFaceService) Generate(ctx context.Context, text string) ([]float32, error) {
+	if text == "" {
+		return nil, fmt.Errorf("text cannot be empty")
+	}
+
+	reqBody := map[string]interface{}{
+		"inputs": text,
+		"options": map[string]interface{}{
+			"wait_for_model": true,
+		},
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.apiURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var flat []float32
+	if err := json.Unmarshal(body, &flat); err == nil && len(flat) > 0 {
+		return flat, nil
+	}
+
+	var perToken [][]float32
+	if err := json.Unmarshal(body, &perToken); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(perToken) == 0 {
+		return nil, fmt.Errorf("no embedding returned from API")
+	}
+
+	return meanPool(perToken), nil
+}
+
+// Dim reports the configured model's output dimension.
+func (s *HuggingFaceService) Dim() int { return s.dim }
+
+// GenerateBatch embeds texts one at a time via Generate. The Inference
+// API's free tier is per-text rate limited anyway, so native batching
+// buys little here.
+func (s *HuggingFaceService) GenerateBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	results := make([][]float32, len(texts))
+	for i, text := range texts {
+		embedding, err := s.Generate(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = embedding
+	}
+	return results, nil
+}
+
+// meanPool averages a matrix of per-token embeddings into a single
+// vector, matching the pooling sentence-transformer models apply
+// internally when an Inference API model returns per-token output
+// instead of an already-pooled vector.
+func meanPool(tokens [][]float32) []float32 {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	pooled := make([]float32, len(tokens[0]))
+	for _, token := range tokens {
+		for i, v := range token {
+			pooled[i] += v
+		}
+	}
+	for i := range pooled {
+		pooled[i] /= float32(len(tokens))
+	}
+
+	return pooled
+}
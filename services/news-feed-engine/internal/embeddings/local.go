@@ -0,0 +1,98 @@
+package embeddings
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// LocalRunner wraps an offline embedding model (e.g. loaded via
+// onnxruntime-go or llama.cpp bindings) so LocalService stays decoupled
+// from any specific inference runtime.
+type LocalRunner interface {
+	// Embed runs the model on text and returns its embedding vector.
+	Embed(text string) ([]float32, error)
+
+	// Dim reports the model's output dimension.
+	Dim() int
+
+	// Close releases any resources (memory-mapped weights, native
+	// handles, ...) held by the runtime.
+	Close() error
+}
+
+// LocalRunnerFactory loads the model at modelPath and returns a runner
+// for it. This repo doesn't vendor an ONNX/gguf inference runtime, so
+// the default is nil; a build-tagged adapter (e.g. behind an
+// `onnxruntime` build tag) should set this at init time before
+// NewLocalService is called.
+var LocalRunnerFactory func(modelPath string) (LocalRunner, error)
+
+// LocalService implements embedding generation against an offline
+// sentence-transformer style model, so deployments without any
+// third-party API key still get semantic search.
+type LocalService struct {
+	runner LocalRunner
+}
+
+// NewLocalService loads the model at modelPath via LocalRunnerFactory.
+// It errors if no factory has been registered, rather than silently
+// falling back to a cloud provider.
+func NewLocalService(modelPath string) (*LocalService, error) {
+	if LocalRunnerFactory == nil {
+		return nil, fmt.Errorf("embeddings: no LocalRunnerFactory registered; build with an offline inference adapter to use local:// models")
+	}
+	if modelPath == "" {
+		return nil, fmt.Errorf("embeddings: local model path cannot be empty")
+	}
+
+	runner, err := LocalRunnerFactory(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load local model %q: %w", modelPath, err)
+	}
+
+	return &LocalService{runner: runner}, nil
+}
+
+func init() {
+	Register("local", func(u *url.URL) (Service, error) {
+		modelPath := u.Opaque
+		if modelPath == "" {
+			modelPath = u.Path
+		}
+		if modelPath == "" {
+			return nil, fmt.Errorf("embeddings: local:// dsn requires a model path")
+		}
+		return NewLocalService(modelPath)
+	})
+}
+
+// Generate creates an embedding vector from text using the loaded
+// offline model.
+func (s *LocalService) Generate(ctx context.Context, text string) ([]float32, error) {
+	if text == "" {
+		return nil, fmt.Errorf("text cannot be empty")
+	}
+	return s.runner.Embed(text)
+}
+
+// Dim reports the loaded model's output dimension.
+func (s *LocalService) Dim() int { return s.runner.Dim() }
+
+// GenerateBatch embeds texts one at a time via Generate. An offline
+// runtime pays no network round trip per call, so there's no latency
+// win from batching the way there is for the cloud providers.
+func (s *LocalService) GenerateBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	results := make([][]float32, len(texts))
+	for i, text := range texts {
+		embedding, err := s.Generate(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = embedding
+	}
+	return results, nil
+}
+
+// Close releases the underlying runner's resources.
+func (s *LocalService) Close() error { return s.runner.Close() }
@@ -0,0 +1,78 @@
+package embeddings
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket limiter for outbound embeddings
+// provider calls, so a bulk backfill doesn't blow through a provider's
+// per-second quota. Unlike middleware.RateLimiter (which throttles
+// inbound HTTP requests per client), this throttles this process's own
+// outbound calls to a single provider.
+type RateLimiter struct {
+	mu         sync.Mutex
+	tokens     float64
+	max        float64
+	refillRate float64 // tokens per second
+	last       time.Time
+}
+
+// NewRateLimiter creates a token bucket that refills at ratePerSecond
+// tokens/sec up to a capacity of burst tokens. It starts full, so the
+// first burst requests go through immediately.
+func NewRateLimiter(ratePerSecond float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		tokens:     float64(burst),
+		max:        float64(burst),
+		refillRate: ratePerSecond,
+		last:       time.Now(),
+	}
+}
+
+func (r *RateLimiter) refill() {
+	now := time.Now()
+	elapsed := now.Sub(r.last).Seconds()
+	r.last = now
+	r.tokens += elapsed * r.refillRate
+	if r.tokens > r.max {
+		r.tokens = r.max
+	}
+}
+
+// Allow reports whether a token is available and, if so, consumes it.
+func (r *RateLimiter) Allow() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.refill()
+	if r.tokens < 1 {
+		return false
+	}
+	r.tokens--
+	return true
+}
+
+// Wait blocks until a token is available or ctx is cancelled.
+func (r *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		r.mu.Lock()
+		r.refill()
+		if r.tokens >= 1 {
+			r.tokens--
+			r.mu.Unlock()
+			return nil
+		}
+		missing := 1 - r.tokens
+		wait := time.Duration(missing/r.refillRate*1000) * time.Millisecond
+		r.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
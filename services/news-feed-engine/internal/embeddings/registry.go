@@ -0,0 +1,52 @@
+package embeddings
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+)
+
+// Factory constructs a Service from a DSN's parsed form. The scheme
+// ("openai", "voyage", "cohere", "huggingface", "local", ...) selects
+// the factory; Open passes the rest of the DSN through unparsed so each
+// backend can pull whatever query params (api_key, model, dim, ...) or
+// path segments it needs.
+type Factory func(dsn *url.URL) (Service, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+// Register adds factory under scheme for Open to dispatch to. Intended
+// to be called from each backend's init(), so a duplicate registration
+// is a programming error worth panicking on rather than silently
+// shadowing one backend with another.
+func Register(scheme string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := registry[scheme]; exists {
+		panic(fmt.Sprintf("embeddings: Register called twice for scheme %q", scheme))
+	}
+	registry[scheme] = factory
+}
+
+// Open constructs a Service from a URL-like DSN, e.g.
+// "openai://?api_key=sk-..." or "local:///path/to/model.onnx?dim=384".
+// The scheme selects the registered backend; everything else (host,
+// path, query) is that backend's to interpret.
+func Open(dsn string) (Service, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("embeddings: invalid dsn %q: %w", dsn, err)
+	}
+
+	registryMu.RLock()
+	factory, ok := registry[u.Scheme]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("embeddings: no backend registered for scheme %q", u.Scheme)
+	}
+
+	return factory(u)
+}
@@ -0,0 +1,43 @@
+package embeddings
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// maxRetries bounds exponential-backoff retries for transient provider
+// failures (429 rate limiting, 5xx). Most providers recover within a
+// couple of seconds, so anything beyond this should surface to the
+// caller rather than keep a bulk ingestion job blocked indefinitely.
+const maxRetries = 4
+
+// isRetryableStatus reports whether an HTTP status from an embeddings
+// provider is worth retrying: 429 (rate limited) or any 5xx.
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// backoffWithJitter returns the delay before retry attempt (0-indexed),
+// doubling each attempt and adding up to 50% random jitter so many
+// concurrent callers backing off from the same provider don't retry in
+// lockstep.
+func backoffWithJitter(attempt int) time.Duration {
+	base := 250 * time.Millisecond * time.Duration(1<<uint(attempt))
+	jitter := time.Duration(rand.Int63n(int64(base) / 2))
+	return base + jitter
+}
+
+// sleepForRetry blocks for the backoff duration or returns ctx's error
+// if it's cancelled first.
+func sleepForRetry(ctx context.Context, attempt int) error {
+	timer := time.NewTimer(backoffWithJitter(attempt))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -15,8 +16,30 @@ import (
 type Service interface {
 	// Generate creates a semantic embedding vector from text
 	Generate(ctx context.Context, text string) ([]float32, error)
+
+	// GenerateBatch creates embedding vectors for multiple texts in as
+	// few provider round trips as possible, preserving input order.
+	// Feed pipelines doing bulk backfills should prefer this over
+	// calling Generate in a loop.
+	GenerateBatch(ctx context.Context, texts []string) ([][]float32, error)
+
+	// Dim reports the embedding's output dimension, so downstream
+	// vector-store code (e.g. a fixed-width pgvector column) can
+	// validate compatibility before writing to it.
+	Dim() int
 }
 
+// voyage2Dim is voyage-2's output dimension.
+const voyage2Dim = 1024
+
+// voyageMaxBatchSize and voyageMaxTokensPerRequest bound a single
+// voyage-2 embeddings request; texts beyond either limit are split
+// into multiple requests by GenerateBatch.
+const (
+	voyageMaxBatchSize        = 128
+	voyageMaxTokensPerRequest = 320000
+)
+
 // ClaudeService implements embedding generation using Claude API
 type ClaudeService struct {
 	apiKey     string
@@ -37,7 +60,18 @@ func NewClaudeService(apiKey string) *ClaudeService {
 	}
 }
 
-// Generate creates a 1536-dimensional embedding vector from text
+func init() {
+	Register("voyage", func(u *url.URL) (Service, error) {
+		apiKey := u.Query().Get("api_key")
+		if apiKey == "" {
+			return nil, fmt.Errorf("embeddings: voyage:// dsn requires api_key")
+		}
+		return NewClaudeService(apiKey), nil
+	})
+}
+
+// Generate creates a voyage-2 embedding vector (voyage2Dim-dimensional)
+// from text
 func (s *ClaudeService) Generate(ctx context.Context, text string) ([]float32, error) {
 	if text == "" {
 		return nil, fmt.Errorf("text cannot be empty")
@@ -98,6 +132,104 @@ func (s *ClaudeService) Generate(ctx context.Context, text string) ([]float32, e
 	return result.Data[0].Embedding, nil
 }
 
+// Dim reports voyage-2's output dimension.
+func (s *ClaudeService) Dim() int { return voyage2Dim }
+
+// GenerateBatch embeds texts via voyage-2's native array input,
+// splitting into multiple requests when voyageMaxBatchSize or
+// voyageMaxTokensPerRequest would otherwise be exceeded, and retrying
+// individual requests with exponential backoff on 429/5xx.
+func (s *ClaudeService) GenerateBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	results := make([][]float32, 0, len(texts))
+	for _, chunk := range chunkByLimits(texts, voyageMaxBatchSize, voyageMaxTokensPerRequest) {
+		embeddings, err := s.generateBatchChunk(ctx, chunk)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, embeddings...)
+	}
+
+	return results, nil
+}
+
+func (s *ClaudeService) generateBatchChunk(ctx context.Context, texts []string) ([][]float32, error) {
+	reqBody := map[string]interface{}{
+		"input": texts,
+		"model": "voyage-2",
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	var result struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, "POST", s.apiURL, bytes.NewBuffer(jsonData))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute request: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			if isRetryableStatus(resp.StatusCode) && attempt < maxRetries {
+				if err := sleepForRetry(ctx, attempt); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		break
+	}
+
+	embeddings := make([][]float32, len(texts))
+	for _, d := range result.Data {
+		if d.Index < 0 || d.Index >= len(embeddings) {
+			continue
+		}
+		embeddings[d.Index] = d.Embedding
+	}
+
+	return embeddings, nil
+}
+
+// openAISmallDim is text-embedding-3-small's output dimension.
+const openAISmallDim = 1536
+
+// openAIMaxBatchSize and openAIMaxTokensPerRequest bound a single
+// text-embedding-3-small request; texts beyond either limit are split
+// into multiple requests by GenerateBatch.
+const (
+	openAIMaxBatchSize        = 100
+	openAIMaxTokensPerRequest = 300000
+)
+
 // OpenAIService implements embedding generation using OpenAI API
 type OpenAIService struct {
 	apiKey     string
@@ -116,7 +248,18 @@ func NewOpenAIService(apiKey string) *OpenAIService {
 	}
 }
 
-// Generate creates a 1536-dimensional embedding vector from text using text-embedding-3-small
+func init() {
+	Register("openai", func(u *url.URL) (Service, error) {
+		apiKey := u.Query().Get("api_key")
+		if apiKey == "" {
+			return nil, fmt.Errorf("embeddings: openai:// dsn requires api_key")
+		}
+		return NewOpenAIService(apiKey), nil
+	})
+}
+
+// Generate creates a text-embedding-3-small embedding vector
+// (openAISmallDim-dimensional) from text
 func (s *OpenAIService) Generate(ctx context.Context, text string) ([]float32, error) {
 	if text == "" {
 		return nil, fmt.Errorf("text cannot be empty")
@@ -176,3 +319,90 @@ func (s *OpenAIService) Generate(ctx context.Context, text string) ([]float32, e
 
 	return result.Data[0].Embedding, nil
 }
+
+// Dim reports text-embedding-3-small's output dimension.
+func (s *OpenAIService) Dim() int { return openAISmallDim }
+
+// GenerateBatch embeds texts via text-embedding-3-small's native array
+// input, splitting into multiple requests when openAIMaxBatchSize or
+// openAIMaxTokensPerRequest would otherwise be exceeded, and retrying
+// individual requests with exponential backoff on 429/5xx.
+func (s *OpenAIService) GenerateBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	results := make([][]float32, 0, len(texts))
+	for _, chunk := range chunkByLimits(texts, openAIMaxBatchSize, openAIMaxTokensPerRequest) {
+		embeddings, err := s.generateBatchChunk(ctx, chunk)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, embeddings...)
+	}
+
+	return results, nil
+}
+
+func (s *OpenAIService) generateBatchChunk(ctx context.Context, texts []string) ([][]float32, error) {
+	reqBody := map[string]interface{}{
+		"input": texts,
+		"model": "text-embedding-3-small",
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	var result struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, "POST", s.apiURL, bytes.NewBuffer(jsonData))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute request: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			if isRetryableStatus(resp.StatusCode) && attempt < maxRetries {
+				if err := sleepForRetry(ctx, attempt); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		break
+	}
+
+	embeddings := make([][]float32, len(texts))
+	for _, d := range result.Data {
+		if d.Index < 0 || d.Index >= len(embeddings) {
+			continue
+		}
+		embeddings[d.Index] = d.Embedding
+	}
+
+	return embeddings, nil
+}
@@ -0,0 +1,33 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/kafka"
+	"go.uber.org/zap"
+)
+
+// RunConsumer consumes kafka.JobEventMessage values off consumer and
+// publishes each one to hub. It blocks until ctx is cancelled, so
+// callers typically register it with internal/process as a Process
+// whose Run calls this directly.
+func RunConsumer(ctx context.Context, consumer *kafka.Consumer, hub *Hub, logger *zap.Logger) error {
+	if logger == nil {
+		logger, _ = zap.NewDevelopment()
+	}
+	return consumer.Run(ctx, func(ctx context.Context, _ string, value json.RawMessage) error {
+		var msg kafka.JobEventMessage
+		if err := json.Unmarshal(value, &msg); err != nil {
+			return fmt.Errorf("failed to decode job event message: %w", err)
+		}
+		hub.Publish(Event{
+			Topic:    msg.Topic,
+			Type:     msg.Type,
+			TenantID: msg.TenantID,
+			Data:     msg.Data,
+		})
+		return nil
+	})
+}
@@ -0,0 +1,154 @@
+// Package events fans out ingestion/processing/video pipeline lifecycle
+// notifications to per-tenant Server-Sent Events subscribers, so a client
+// that triggered a job can watch it progress instead of polling
+// GetContent/GetVideo for a status change. Events are produced elsewhere
+// (the ingestion/processing workers consuming KafkaRawTopic/
+// KafkaProcessedTopic, and the video-rendering worker consuming
+// KafkaVideoTopic — see internal/kafka.JobEventMessage) and reach this
+// package's Hub over a dedicated Kafka topic via RunConsumer.
+package events
+
+import (
+	"fmt"
+	"sync"
+)
+
+// backlogSize bounds how many past events per tenant are kept for
+// Last-Event-ID resume; subscriberBufferSize bounds how far a single
+// slow connection can lag before it starts losing events rather than
+// blocking Publish for everyone else.
+const (
+	backlogSize          = 256
+	subscriberBufferSize = 64
+)
+
+// Event is one job-lifecycle notification fanned out to subscribers.
+type Event struct {
+	ID       string      `json:"id"`
+	Topic    string      `json:"topic"` // "ingestion", "processing", "video"
+	Type     string      `json:"type"`  // e.g. "ingestion.queued", "processing.completed"
+	TenantID string      `json:"tenant_id"`
+	Data     interface{} `json:"data,omitempty"`
+}
+
+// Subscription is one connected client's view of the Hub. Events whose
+// Topic matches the subscription (or any event, if no topics were
+// requested) arrive on C.
+type Subscription struct {
+	C      chan Event
+	topics map[string]bool
+}
+
+func newSubscription(topics []string) *Subscription {
+	set := make(map[string]bool, len(topics))
+	for _, t := range topics {
+		set[t] = true
+	}
+	return &Subscription{C: make(chan Event, subscriberBufferSize), topics: set}
+}
+
+func (s *Subscription) matches(topic string) bool {
+	if len(s.topics) == 0 {
+		return true
+	}
+	return s.topics[topic]
+}
+
+// Hub fans Events out to per-tenant subscribers and keeps a short
+// per-tenant backlog so a reconnecting client can replay what it missed
+// via Last-Event-ID. The zero value is not usable; construct with
+// NewHub.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[*Subscription]struct{} // tenantID -> set
+	backlog     map[string][]Event                    // tenantID -> bounded ring, oldest first
+	seq         map[string]uint64                     // tenantID -> last assigned event ID
+}
+
+// NewHub creates an empty Hub.
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[string]map[*Subscription]struct{}),
+		backlog:     make(map[string][]Event),
+		seq:         make(map[string]uint64),
+	}
+}
+
+// Subscribe registers a new Subscription for tenantID scoped to topics
+// (empty means all topics), first replaying any backlogged events after
+// lastEventID (empty means "no replay, start from now"). Callers must
+// call Unsubscribe when the connection ends.
+func (h *Hub) Subscribe(tenantID string, topics []string, lastEventID string) *Subscription {
+	sub := newSubscription(topics)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if lastEventID != "" {
+		replaying := false
+		for _, ev := range h.backlog[tenantID] {
+			if !replaying {
+				if ev.ID == lastEventID {
+					replaying = true
+				}
+				continue
+			}
+			if sub.matches(ev.Topic) {
+				sub.C <- ev
+			}
+		}
+	}
+
+	if h.subscribers[tenantID] == nil {
+		h.subscribers[tenantID] = make(map[*Subscription]struct{})
+	}
+	h.subscribers[tenantID][sub] = struct{}{}
+	return sub
+}
+
+// Unsubscribe removes sub from tenantID's subscriber set.
+func (h *Hub) Unsubscribe(tenantID string, sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subscribers[tenantID], sub)
+	if len(h.subscribers[tenantID]) == 0 {
+		delete(h.subscribers, tenantID)
+	}
+}
+
+// Publish assigns ev the next per-tenant sequence number, appends it to
+// that tenant's backlog, and fans it out to every matching subscriber.
+// A subscriber whose buffer is full has its oldest unconsumed event
+// dropped to make room for ev rather than blocking Publish, so one slow
+// client can't stall delivery to everyone else.
+func (h *Hub) Publish(ev Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.seq[ev.TenantID]++
+	ev.ID = fmt.Sprintf("%d", h.seq[ev.TenantID])
+
+	backlog := append(h.backlog[ev.TenantID], ev)
+	if len(backlog) > backlogSize {
+		backlog = backlog[len(backlog)-backlogSize:]
+	}
+	h.backlog[ev.TenantID] = backlog
+
+	for sub := range h.subscribers[ev.TenantID] {
+		if !sub.matches(ev.Topic) {
+			continue
+		}
+		select {
+		case sub.C <- ev:
+		default:
+			select {
+			case <-sub.C:
+			default:
+			}
+			select {
+			case sub.C <- ev:
+			default:
+			}
+		}
+	}
+}
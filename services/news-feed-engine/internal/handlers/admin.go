@@ -5,10 +5,16 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/audit"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/middleware"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/ratelimit"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/tenantbundle"
 )
 
 // AdminHandler handles admin configuration operations
@@ -17,6 +23,9 @@ type AdminHandler struct {
 	sourceRepo    *database.SourceRepository
 	templateRepo  *database.TemplateRepository
 	analyticsRepo *database.AnalyticsRepository
+	videoLimiter  *ratelimit.VideoLimiter
+	bundles       *tenantbundle.Service
+	audit         audit.Logger
 }
 
 // NewAdminHandler creates a new admin handler with repository dependencies
@@ -25,15 +34,45 @@ func NewAdminHandler(
 	sourceRepo *database.SourceRepository,
 	templateRepo *database.TemplateRepository,
 	analyticsRepo *database.AnalyticsRepository,
+	videoLimiter *ratelimit.VideoLimiter,
 ) *AdminHandler {
 	return &AdminHandler{
 		configRepo:    configRepo,
 		sourceRepo:    sourceRepo,
 		templateRepo:  templateRepo,
 		analyticsRepo: analyticsRepo,
+		videoLimiter:  videoLimiter,
+		bundles:       tenantbundle.NewService(configRepo, sourceRepo, templateRepo),
 	}
 }
 
+// WithAudit enables audit logging of tenant config, source, and
+// template mutations (UpdateConfig/UpdateSourcesConfig/UpdateVideoTemplates).
+func (h *AdminHandler) WithAudit(logger audit.Logger) *AdminHandler {
+	h.audit = logger
+	return h
+}
+
+// logAudit is a no-op when h.audit is nil, so every mutation handler can
+// call it unconditionally instead of guarding each call site.
+func (h *AdminHandler) logAudit(c *gin.Context, tenantID, action, targetType, targetID string, before, after map[string]interface{}) {
+	if h.audit == nil {
+		return
+	}
+	h.audit.Log(c.Request.Context(), models.AuditEvent{
+		TenantID:   tenantID,
+		ActorID:    middleware.GetUserID(c),
+		ActorType:  "user",
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Before:     before,
+		After:      after,
+		IP:         c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+	})
+}
+
 // GetConfig returns the current tenant configuration from database
 func (h *AdminHandler) GetConfig(c *gin.Context) {
 	tenantID := middleware.GetTenantID(c)
@@ -94,19 +133,24 @@ func (h *AdminHandler) UpdateConfig(c *gin.Context) {
 		return
 	}
 
-	if err := h.configRepo.Update(c.Request.Context(), tenantID, request); err != nil {
+	before, _ := h.configRepo.Get(c.Request.Context(), tenantID)
+
+	version, err := h.configRepo.Update(c.Request.Context(), tenantID, request, middleware.GetUserID(c))
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "database_error",
 			Message: "failed to update configuration",
 		})
 		return
 	}
+	h.logAudit(c, tenantID, "tenant_config.update", "tenant_config", tenantID, audit.ToMap(before), request)
 
 	c.JSON(http.StatusOK, SuccessResponse{
 		Success: true,
 		Message: "configuration updated",
 		Data: map[string]interface{}{
 			"tenant_id": tenantID,
+			"version":   version.Version,
 			"updated":   request,
 		},
 	})
@@ -135,7 +179,14 @@ func (h *AdminHandler) GetSourcesConfig(c *gin.Context) {
 	})
 }
 
-// UpdateSourcesConfig updates content source configuration in database
+// UpdateSourcesConfig applies a batch of content source updates as a
+// single transaction (see SourceRepository.BulkUpdate) - unlike the old
+// per-item loop, a bad update in the middle of the batch rolls everything
+// back instead of leaving the tenant half-applied. With ?dry_run=true,
+// nothing is written: the response is a structured diff of what the
+// batch would do (see diffSourceUpdates). A real write returns a
+// change_id that can be passed to RollbackSourcesChange to undo the
+// whole batch in one call.
 func (h *AdminHandler) UpdateSourcesConfig(c *gin.Context) {
 	tenantID := middleware.GetTenantID(c)
 
@@ -151,14 +202,45 @@ func (h *AdminHandler) UpdateSourcesConfig(c *gin.Context) {
 		return
 	}
 
-	// Process each source update
-	updated := 0
-	errors := make([]string, 0)
+	existing, err := h.sourceRepo.List(c.Request.Context(), tenantID, database.SourceListOptions{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "failed to retrieve sources",
+		})
+		return
+	}
+
+	if c.Query("dry_run") == "true" {
+		c.JSON(http.StatusOK, SuccessResponse{
+			Success: true,
+			Data: map[string]interface{}{
+				"tenant_id": tenantID,
+				"diff":      diffSourceUpdates(existing, request.Sources),
+			},
+		})
+		return
+	}
+
+	existingByID := make(map[string]models.ContentSource, len(existing))
+	for _, source := range existing {
+		existingByID[source.ID.String()] = source
+	}
+
+	changeID, err := h.sourceRepo.BulkUpdate(c.Request.Context(), tenantID, request.Sources, middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "failed to update sources: " + err.Error(),
+		})
+		return
+	}
+
 	for _, source := range request.Sources {
-		if err := h.sourceRepo.Update(c.Request.Context(), tenantID, source.ID, source); err != nil {
-			errors = append(errors, source.ID)
+		if b, ok := existingByID[source.ID]; ok {
+			h.logAudit(c, tenantID, "content_source.bulk_update", "content_source", source.ID, audit.ToMap(&b), audit.ToMap(source))
 		} else {
-			updated++
+			h.logAudit(c, tenantID, "content_source.bulk_update", "content_source", source.ID, nil, audit.ToMap(source))
 		}
 	}
 
@@ -167,12 +249,128 @@ func (h *AdminHandler) UpdateSourcesConfig(c *gin.Context) {
 		Message: "sources configuration updated",
 		Data: map[string]interface{}{
 			"tenant_id":     tenantID,
-			"sources_count": updated,
-			"errors":        errors,
+			"sources_count": len(request.Sources),
+			"change_id":     changeID,
 		},
 	})
 }
 
+// RollbackSourcesChange restores every source touched by a prior
+// UpdateSourcesConfig call (identified by the change_id it returned) to
+// its pre-update state, in one transaction - undoing the whole batch
+// rather than one source at a time.
+func (h *AdminHandler) RollbackSourcesChange(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+
+	changeID, err := uuid.Parse(c.Param("change_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "change_id must be a UUID",
+		})
+		return
+	}
+
+	if err := h.sourceRepo.RollbackChange(c.Request.Context(), tenantID, changeID, middleware.GetUserID(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "failed to roll back sources change: " + err.Error(),
+		})
+		return
+	}
+	h.logAudit(c, tenantID, "content_source.rollback_change", "content_source", changeID.String(), nil, nil)
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Message: "sources change rolled back",
+		Data: map[string]interface{}{
+			"tenant_id": tenantID,
+			"change_id": changeID,
+		},
+	})
+}
+
+// FieldChange is one field's value before and after a bulk update, as
+// reported by a ?dry_run=true diff preview.
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// ChangeDiffEntry describes what a ?dry_run=true bulk update would do to
+// one entity. "added"/"removed" are informational here, not
+// destructive: UpdateSourcesConfig/UpdateVideoTemplates only ever patch
+// existing rows, so an id the batch names but that doesn't exist is
+// reported "added" and an existing row the batch doesn't mention is
+// reported "removed" without anything actually being created or
+// deleted.
+type ChangeDiffEntry struct {
+	ID      string                 `json:"id"`
+	Status  string                 `json:"status"` // "added" | "modified" | "removed" | "unchanged"
+	Changes map[string]FieldChange `json:"changes,omitempty"`
+}
+
+// diffSourceUpdates compares updates against existing, field by field for
+// the fields each update actually sets, without writing anything.
+func diffSourceUpdates(existing []models.ContentSource, updates []database.SourceUpdate) []ChangeDiffEntry {
+	existingByID := make(map[string]models.ContentSource, len(existing))
+	for _, source := range existing {
+		existingByID[source.ID.String()] = source
+	}
+	seen := make(map[string]bool, len(updates))
+
+	var entries []ChangeDiffEntry
+	for _, update := range updates {
+		seen[update.ID] = true
+
+		current, ok := existingByID[update.ID]
+		if !ok {
+			entries = append(entries, ChangeDiffEntry{ID: update.ID, Status: "added"})
+			continue
+		}
+
+		changes := map[string]FieldChange{}
+		if update.Name != "" && update.Name != current.Name {
+			changes["name"] = FieldChange{Old: current.Name, New: update.Name}
+		}
+		if update.Platform != "" && update.Platform != string(current.Platform) {
+			changes["platform"] = FieldChange{Old: current.Platform, New: update.Platform}
+		}
+		if update.SourceType != "" && update.SourceType != current.SourceType {
+			changes["source_type"] = FieldChange{Old: current.SourceType, New: update.SourceType}
+		}
+		if update.Identifier != "" && update.Identifier != current.Identifier {
+			changes["identifier"] = FieldChange{Old: current.Identifier, New: update.Identifier}
+		}
+		if update.Category != "" && update.Category != current.Category {
+			changes["category"] = FieldChange{Old: current.Category, New: update.Category}
+		}
+		if update.Priority != nil && *update.Priority != current.Priority {
+			changes["priority"] = FieldChange{Old: current.Priority, New: *update.Priority}
+		}
+		if update.IngestionCron != "" && update.IngestionCron != current.IngestionCron {
+			changes["ingestion_cron"] = FieldChange{Old: current.IngestionCron, New: update.IngestionCron}
+		}
+		if update.Active != nil && *update.Active != current.Active {
+			changes["active"] = FieldChange{Old: current.Active, New: *update.Active}
+		}
+
+		status := "modified"
+		if len(changes) == 0 {
+			status = "unchanged"
+		}
+		entries = append(entries, ChangeDiffEntry{ID: update.ID, Status: status, Changes: changes})
+	}
+
+	for id, source := range existingByID {
+		if !seen[id] {
+			entries = append(entries, ChangeDiffEntry{ID: source.ID.String(), Status: "removed"})
+		}
+	}
+
+	return entries
+}
+
 // GetVideoTemplates returns available video generation templates from database
 func (h *AdminHandler) GetVideoTemplates(c *gin.Context) {
 	tenantID := middleware.GetTenantID(c)
@@ -196,7 +394,10 @@ func (h *AdminHandler) GetVideoTemplates(c *gin.Context) {
 	})
 }
 
-// UpdateVideoTemplates updates video generation templates in database
+// UpdateVideoTemplates applies a batch of video template updates as a
+// single transaction (see TemplateRepository.BulkUpdate). Mirrors
+// UpdateSourcesConfig: ?dry_run=true returns a diff without writing, and
+// a real write returns a change_id for RollbackTemplatesChange.
 func (h *AdminHandler) UpdateVideoTemplates(c *gin.Context) {
 	tenantID := middleware.GetTenantID(c)
 
@@ -212,14 +413,45 @@ func (h *AdminHandler) UpdateVideoTemplates(c *gin.Context) {
 		return
 	}
 
-	// Process each template update
-	updated := 0
-	errors := make([]string, 0)
+	existing, err := h.templateRepo.List(c.Request.Context(), tenantID, database.TemplateListOptions{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "failed to retrieve templates",
+		})
+		return
+	}
+
+	if c.Query("dry_run") == "true" {
+		c.JSON(http.StatusOK, SuccessResponse{
+			Success: true,
+			Data: map[string]interface{}{
+				"tenant_id": tenantID,
+				"diff":      diffTemplateUpdates(existing, request.Templates),
+			},
+		})
+		return
+	}
+
+	existingByID := make(map[string]models.VideoTemplate, len(existing))
+	for _, template := range existing {
+		existingByID[template.ID.String()] = template
+	}
+
+	changeID, err := h.templateRepo.BulkUpdate(c.Request.Context(), tenantID, request.Templates, middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "failed to update templates: " + err.Error(),
+		})
+		return
+	}
+
 	for _, template := range request.Templates {
-		if err := h.templateRepo.Update(c.Request.Context(), tenantID, template.ID, template); err != nil {
-			errors = append(errors, template.ID)
+		if b, ok := existingByID[template.ID]; ok {
+			h.logAudit(c, tenantID, "video_template.bulk_update", "video_template", template.ID, audit.ToMap(&b), audit.ToMap(template))
 		} else {
-			updated++
+			h.logAudit(c, tenantID, "video_template.bulk_update", "video_template", template.ID, nil, audit.ToMap(template))
 		}
 	}
 
@@ -228,12 +460,109 @@ func (h *AdminHandler) UpdateVideoTemplates(c *gin.Context) {
 		Message: "video templates updated",
 		Data: map[string]interface{}{
 			"tenant_id":       tenantID,
-			"templates_count": updated,
-			"errors":          errors,
+			"templates_count": len(request.Templates),
+			"change_id":       changeID,
+		},
+	})
+}
+
+// RollbackTemplatesChange restores every template touched by a prior
+// UpdateVideoTemplates call (identified by the change_id it returned) to
+// its pre-update state, in one transaction. Mirrors RollbackSourcesChange.
+func (h *AdminHandler) RollbackTemplatesChange(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+
+	changeID, err := uuid.Parse(c.Param("change_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "change_id must be a UUID",
+		})
+		return
+	}
+
+	if err := h.templateRepo.RollbackChange(c.Request.Context(), tenantID, changeID, middleware.GetUserID(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "failed to roll back templates change: " + err.Error(),
+		})
+		return
+	}
+	h.logAudit(c, tenantID, "video_template.rollback_change", "video_template", changeID.String(), nil, nil)
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Message: "video templates change rolled back",
+		Data: map[string]interface{}{
+			"tenant_id": tenantID,
+			"change_id": changeID,
 		},
 	})
 }
 
+// diffTemplateUpdates mirrors diffSourceUpdates for video templates.
+func diffTemplateUpdates(existing []models.VideoTemplate, updates []database.TemplateUpdate) []ChangeDiffEntry {
+	existingByID := make(map[string]models.VideoTemplate, len(existing))
+	for _, template := range existing {
+		existingByID[template.ID.String()] = template
+	}
+	seen := make(map[string]bool, len(updates))
+
+	var entries []ChangeDiffEntry
+	for _, update := range updates {
+		seen[update.ID] = true
+
+		current, ok := existingByID[update.ID]
+		if !ok {
+			entries = append(entries, ChangeDiffEntry{ID: update.ID, Status: "added"})
+			continue
+		}
+
+		changes := map[string]FieldChange{}
+		if update.Name != "" && update.Name != current.Name {
+			changes["name"] = FieldChange{Old: current.Name, New: update.Name}
+		}
+		if update.Description != "" && update.Description != current.Description {
+			changes["description"] = FieldChange{Old: current.Description, New: update.Description}
+		}
+		if update.Category != "" && update.Category != current.Category {
+			changes["category"] = FieldChange{Old: current.Category, New: update.Category}
+		}
+		if update.VoiceID != "" && update.VoiceID != current.VoiceID {
+			changes["voice_id"] = FieldChange{Old: current.VoiceID, New: update.VoiceID}
+		}
+		if update.AvatarID != "" && update.AvatarID != current.AvatarID {
+			changes["avatar_id"] = FieldChange{Old: current.AvatarID, New: update.AvatarID}
+		}
+		if update.Resolution != "" && update.Resolution != current.Resolution {
+			changes["resolution"] = FieldChange{Old: current.Resolution, New: update.Resolution}
+		}
+		if update.Duration != nil && *update.Duration != current.Duration {
+			changes["duration"] = FieldChange{Old: current.Duration, New: *update.Duration}
+		}
+		if update.IsDefault != nil && *update.IsDefault != current.IsDefault {
+			changes["is_default"] = FieldChange{Old: current.IsDefault, New: *update.IsDefault}
+		}
+		if update.Active != nil && *update.Active != current.Active {
+			changes["active"] = FieldChange{Old: current.Active, New: *update.Active}
+		}
+
+		status := "modified"
+		if len(changes) == 0 {
+			status = "unchanged"
+		}
+		entries = append(entries, ChangeDiffEntry{ID: update.ID, Status: status, Changes: changes})
+	}
+
+	for id, template := range existingByID {
+		if !seen[id] {
+			entries = append(entries, ChangeDiffEntry{ID: template.ID.String(), Status: "removed"})
+		}
+	}
+
+	return entries
+}
+
 // GetAnalyticsOverview returns overview analytics from database
 func (h *AdminHandler) GetAnalyticsOverview(c *gin.Context) {
 	tenantID := middleware.GetTenantID(c)
@@ -306,6 +635,54 @@ func (h *AdminHandler) GetContentAnalytics(c *gin.Context) {
 	})
 }
 
+// RebuildAnalyticsRollupsRequest bounds the historical range to recompute.
+type RebuildAnalyticsRollupsRequest struct {
+	From time.Time `json:"from" binding:"required"`
+	To   time.Time `json:"to" binding:"required"`
+}
+
+// RebuildAnalyticsRollups recomputes analytics_daily/creator_tier_daily
+// for an explicit [from, to) range, for backfilling a newly onboarded
+// tenant's history or recovering from a gap in the background refresh
+// job - the dashboard endpoints above only ever read rollups, never
+// compute them inline.
+func (h *AdminHandler) RebuildAnalyticsRollups(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+
+	var req RebuildAnalyticsRollupsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+	if !req.To.After(req.From) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "to must be after from",
+		})
+		return
+	}
+
+	if err := h.analyticsRepo.RebuildRollups(c.Request.Context(), tenantID, req.From, req.To); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "failed to rebuild analytics rollups",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Data: map[string]interface{}{
+			"tenant_id": tenantID,
+			"from":      req.From,
+			"to":        req.To,
+		},
+	})
+}
+
 // GetCreatorAnalytics returns creator-specific analytics from database
 func (h *AdminHandler) GetCreatorAnalytics(c *gin.Context) {
 	tenantID := middleware.GetTenantID(c)
@@ -336,3 +713,129 @@ func (h *AdminHandler) GetCreatorAnalytics(c *gin.Context) {
 		Data:    analytics,
 	})
 }
+
+// UpdateTenantQuota adjusts a tenant's video generation admission budget
+// (max concurrent in-flight jobs and/or max jobs per hour) at runtime.
+// Unlike the rest of this handler, the target tenant is the :id path
+// param rather than the caller's own X-Tenant-ID - this is a cross-tenant
+// operator action, not a tenant self-service one. Either field may be
+// omitted to leave it unchanged.
+func (h *AdminHandler) UpdateTenantQuota(c *gin.Context) {
+	if h.videoLimiter == nil {
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "ratelimit_unavailable",
+			Message: "video rate limiting is not enabled",
+		})
+		return
+	}
+
+	tenantID := c.Param("id")
+	if tenantID == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "tenant id is required",
+		})
+		return
+	}
+
+	var request struct {
+		MaxInFlight *int `json:"max_in_flight"`
+		MaxPerHour  *int `json:"max_per_hour"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "invalid request body: " + err.Error(),
+		})
+		return
+	}
+	if request.MaxInFlight == nil && request.MaxPerHour == nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "no fields to update",
+		})
+		return
+	}
+	if request.MaxInFlight != nil && *request.MaxInFlight < 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "max_in_flight must be non-negative",
+		})
+		return
+	}
+	if request.MaxPerHour != nil && *request.MaxPerHour < 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "max_per_hour must be non-negative",
+		})
+		return
+	}
+
+	quota, err := h.videoLimiter.UpdateQuota(c.Request.Context(), tenantID, request.MaxInFlight, request.MaxPerHour)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "ratelimit_error",
+			Message: "failed to update tenant quota",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Message: "tenant quota updated",
+		Data: map[string]interface{}{
+			"tenant_id": tenantID,
+			"quota":     quota,
+		},
+	})
+}
+
+// ExportTenantBundle returns the caller's tenant config, sources, and
+// templates as one portable JSON document (tenantbundle.Bundle), for
+// cloning a tenant or backing it up before a risky change.
+func (h *AdminHandler) ExportTenantBundle(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+
+	data, err := h.bundles.Export(c.Request.Context(), tenantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "failed to export tenant bundle",
+		})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", data)
+}
+
+// ImportTenantBundle applies a tenantbundle.Bundle (as produced by
+// ExportTenantBundle) to the caller's tenant. With ?dry_run=true every
+// row is validated and matched against existing rows but nothing is
+// written, so staging->prod promotion can be checked before it commits.
+func (h *AdminHandler) ImportTenantBundle(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	dryRun := c.Query("dry_run") == "true"
+
+	var bundle tenantbundle.Bundle
+	if err := c.ShouldBindJSON(&bundle); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "invalid bundle: " + err.Error(),
+		})
+		return
+	}
+
+	result, err := h.bundles.Import(c.Request.Context(), tenantID, bundle, middleware.GetUserID(c), dryRun)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "failed to import tenant bundle",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Data:    result,
+	})
+}
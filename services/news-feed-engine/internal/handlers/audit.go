@@ -0,0 +1,77 @@
+// Package handlers provides HTTP handlers for the audit log query API
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/middleware"
+)
+
+// AuditHandler exposes a read-only, cursor-paginated view over
+// AuditRepository for operators investigating who changed what.
+type AuditHandler struct {
+	repo *database.AuditRepository
+}
+
+// NewAuditHandler creates a new audit handler.
+func NewAuditHandler(repo *database.AuditRepository) *AuditHandler {
+	return &AuditHandler{repo: repo}
+}
+
+// ListEvents returns the caller's tenant's audit events, newest first,
+// filterable by actor_id/target_type/target_id/from/to and paginated
+// with a cursor token (see database.AuditRepository.Query) rather than
+// page/limit - the table is append-only and can grow very large, so an
+// opaque "next" token avoids re-scanning and discarding rows on each page.
+func (h *AuditHandler) ListEvents(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+
+	query := database.AuditQuery{
+		ActorID:    c.Query("actor_id"),
+		TargetType: c.Query("target_type"),
+		TargetID:   c.Query("target_id"),
+		Limit:      limit,
+		Cursor:     c.Query("cursor"),
+	}
+
+	if from := c.Query("from"); from != "" {
+		parsed, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "from must be RFC3339"})
+			return
+		}
+		query.From = parsed
+	}
+	if to := c.Query("to"); to != "" {
+		parsed, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "to must be RFC3339"})
+			return
+		}
+		query.To = parsed
+	}
+
+	page, err := h.repo.Query(c.Request.Context(), tenantID, query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "failed to query audit events",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Data: map[string]interface{}{
+			"events":      page.Events,
+			"next_cursor": page.NextCursor,
+		},
+	})
+}
@@ -0,0 +1,160 @@
+// Package handlers provides HTTP handlers for the session subsystem:
+// refreshing an access token, logging out, and listing/revoking a
+// user's active sessions.
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/auth"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/middleware"
+)
+
+// AuthHandler exposes the session subsystem (internal/auth) over HTTP.
+type AuthHandler struct {
+	sessions *auth.Manager
+	repo     *database.SessionRepository
+}
+
+// NewAuthHandler creates a new auth handler.
+func NewAuthHandler(sessions *auth.Manager, repo *database.SessionRepository) *AuthHandler {
+	return &AuthHandler{sessions: sessions, repo: repo}
+}
+
+// Refresh exchanges a refresh token for a new access token, rotating
+// the underlying session's jti.
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var request struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "refresh_token is required",
+		})
+		return
+	}
+
+	pair, err := h.sessions.Refresh(c.Request.Context(), request.RefreshToken)
+	if errors.Is(err, auth.ErrSessionTerminated) {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "session_terminated",
+			Message: "this session has been logged out or has expired",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "invalid_refresh_token",
+			Message: "refresh token is invalid or expired",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Data:    pair,
+	})
+}
+
+// Logout terminates the caller's current session: the session row is
+// revoked and its access token's jti is added to the revocation list,
+// so the token in the Authorization header that called this endpoint
+// stops working immediately.
+func (h *AuthHandler) Logout(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	sid := middleware.GetSessionID(c)
+	if sid == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "token predates the session subsystem, nothing to log out",
+		})
+		return
+	}
+
+	if err := h.sessions.Logout(c.Request.Context(), tenantID, sid, middleware.GetJTI(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "failed to log out",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Message: "logged out",
+	})
+}
+
+// ListSessions returns the caller's own active sessions, for an
+// account-security "where you're logged in" view.
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	userID := middleware.GetUserID(c)
+
+	sessions, err := h.repo.ListByUser(c.Request.Context(), tenantID, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "failed to list sessions",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Data: map[string]interface{}{
+			"sessions": sessions,
+		},
+	})
+}
+
+// RevokeSession terminates one of the caller's own sessions by id,
+// e.g. to sign out a device other than the one making this request.
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	userID := middleware.GetUserID(c)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "invalid session id",
+		})
+		return
+	}
+
+	session, err := h.repo.Get(c.Request.Context(), tenantID, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "failed to look up session",
+		})
+		return
+	}
+	if session == nil || session.UserID != userID {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "not_found",
+			Message: "session not found",
+		})
+		return
+	}
+
+	if err := h.repo.Revoke(c.Request.Context(), tenantID, id); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "failed to revoke session",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Message: "session revoked",
+	})
+}
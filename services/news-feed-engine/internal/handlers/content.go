@@ -2,16 +2,27 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/audit"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/cache"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/embeddings"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/events"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/ingestion"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/kafka"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/middleware"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/preferences"
 )
 
 // ContentHandler handles content-related operations
@@ -21,6 +32,39 @@ type ContentHandler struct {
 	embeddingService  embeddings.Service
 	kafkaRawTopic     string
 	kafkaProcessTopic string
+
+	backfill       *ingestion.BackfillRunner
+	channelReaders map[string]ingestion.ChannelReader
+	cursors        ingestion.ChannelCursorStore
+
+	jobsMu sync.RWMutex
+	jobs   map[string]backfillJob // job ID -> (tenant, source), for GET /ingestion/jobs/:id
+
+	// cache backs the read endpoints (see WithCache); nil means caching is
+	// disabled and every request hits the repository directly.
+	cache                cache.Store
+	cacheInvalidateTopic string
+
+	// events backs StreamEvents (see WithEvents); nil means GET
+	// /content/events responds 404 rather than opening a stream nobody
+	// will ever publish to.
+	events           *events.Hub
+	kafkaEventsTopic string
+
+	// preferences backs ListContent's muted-creator/keyword filtering,
+	// category/sort/page-size defaults (see WithPreferences); nil means
+	// every caller gets the same unfiltered feed.
+	preferences *preferences.Service
+
+	// audit records PatchContent before/after diffs, set by WithAudit.
+	// Nil disables audit logging entirely.
+	audit audit.Logger
+}
+
+// backfillJob identifies the cursor a job ID's progress should be read from.
+type backfillJob struct {
+	TenantID string
+	Source   string
 }
 
 // NewContentHandler creates a new content handler
@@ -31,6 +75,169 @@ func NewContentHandler(repo *database.ContentRepository, kafkaProducer *kafka.Pr
 		embeddingService:  embeddingService,
 		kafkaRawTopic:     rawTopic,
 		kafkaProcessTopic: processTopic,
+		jobs:              make(map[string]backfillJob),
+	}
+}
+
+// WithBackfill enables historical-mode ingestion: runner drives readers to
+// completion and cursors backs its progress, so TriggerIngestion can
+// accept mode="historical" and GET /ingestion/jobs/:id can report on it.
+func (h *ContentHandler) WithBackfill(runner *ingestion.BackfillRunner, readers map[string]ingestion.ChannelReader, cursors ingestion.ChannelCursorStore) *ContentHandler {
+	h.backfill = runner
+	h.channelReaders = readers
+	h.cursors = cursors
+	return h
+}
+
+// WithCache enables response caching for ListContent, GetContent,
+// GetContentByCategory, GetContentByGeo, GetTrendingContent, and
+// SearchContent against store. invalidateTopic, if non-empty, is where
+// DeleteContent/ProcessContent publish cache-invalidation events for other
+// replicas to consume (see internal/cache.RunInvalidationConsumer); it is
+// only meaningful when store isn't already shared across replicas (e.g. a
+// per-replica cache.LRUStore rather than a cache.RedisStore).
+func (h *ContentHandler) WithCache(store cache.Store, invalidateTopic string) *ContentHandler {
+	h.cache = store
+	h.cacheInvalidateTopic = invalidateTopic
+	return h
+}
+
+// WithEvents enables GET /content/events: hub fans out the job-lifecycle
+// notifications StreamEvents subscribes to, and eventsTopic is where
+// this handler publishes the steps it performs directly (currently just
+// ingestion.queued from TriggerIngestion) for hub's consumer to pick up
+// alongside whatever the downstream ingestion/processing/video workers
+// publish there themselves.
+func (h *ContentHandler) WithEvents(hub *events.Hub, eventsTopic string) *ContentHandler {
+	h.events = hub
+	h.kafkaEventsTopic = eventsTopic
+	return h
+}
+
+// WithPreferences enables per-user feed customization on ListContent:
+// muted creators/keywords are filtered out, EnabledCategories/Sort/MaxResults
+// from the caller's UserPreferences are honored when the request didn't
+// explicitly override them.
+func (h *ContentHandler) WithPreferences(service *preferences.Service) *ContentHandler {
+	h.preferences = service
+	return h
+}
+
+// WithAudit enables audit logging of PatchContent.
+func (h *ContentHandler) WithAudit(logger audit.Logger) *ContentHandler {
+	h.audit = logger
+	return h
+}
+
+// logAudit is a no-op when h.audit is nil, so every mutation handler can
+// call it unconditionally instead of guarding each call site.
+func (h *ContentHandler) logAudit(c *gin.Context, tenantID, action, targetID string, before, after map[string]interface{}) {
+	if h.audit == nil {
+		return
+	}
+	h.audit.Log(c.Request.Context(), models.AuditEvent{
+		TenantID:   tenantID,
+		ActorID:    middleware.GetUserID(c),
+		ActorType:  "user",
+		Action:     action,
+		TargetType: "content",
+		TargetID:   targetID,
+		Before:     before,
+		After:      after,
+		IP:         c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+	})
+}
+
+// publishJobEvent publishes a kafka.JobEventMessage for eventType so
+// every replica's events.Hub (via internal/events.RunConsumer) fans it
+// out to that tenant's SSE subscribers, including ones connected to a
+// different replica than the one handling this request.
+func (h *ContentHandler) publishJobEvent(ctx context.Context, tenantID, topic, eventType string, data map[string]interface{}) {
+	if h.events == nil || h.kafkaEventsTopic == "" {
+		return
+	}
+	_ = h.kafkaProducer.Publish(ctx, kafka.Message{
+		Topic: h.kafkaEventsTopic,
+		Key:   tenantID,
+		Value: kafka.JobEventMessage{
+			TenantID:  tenantID,
+			Topic:     topic,
+			Type:      eventType,
+			Data:      data,
+			EmittedAt: time.Now(),
+		},
+	})
+}
+
+// cachedList runs compute through h.cache under key/ttl when caching is
+// enabled, decoding/encoding its ([]*models.Content, int) result as JSON;
+// with no cache configured it just calls compute directly.
+func (h *ContentHandler) cachedList(ctx context.Context, key string, ttl time.Duration, compute func() ([]*models.Content, int, error)) ([]*models.Content, int, error) {
+	if h.cache == nil {
+		return compute()
+	}
+
+	type page struct {
+		Contents []*models.Content `json:"contents"`
+		Total    int               `json:"total"`
+	}
+
+	raw, err := h.cache.GetOrCompute(ctx, key, ttl, func(ctx context.Context) ([]byte, error) {
+		contents, total, err := compute()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(page{Contents: contents, Total: total})
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var p page
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return nil, 0, err
+	}
+	return p.Contents, p.Total, nil
+}
+
+// cachedItem mirrors cachedList for a single *models.Content result.
+func (h *ContentHandler) cachedItem(ctx context.Context, key string, ttl time.Duration, compute func() (*models.Content, error)) (*models.Content, error) {
+	if h.cache == nil {
+		return compute()
+	}
+
+	raw, err := h.cache.GetOrCompute(ctx, key, ttl, func(ctx context.Context) ([]byte, error) {
+		content, err := compute()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(content)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var content models.Content
+	if err := json.Unmarshal(raw, &content); err != nil {
+		return nil, err
+	}
+	return &content, nil
+}
+
+// invalidateContent drops contentID's GetContent cache entry and publishes
+// a cache-invalidation event for it so other replicas drop it too. List
+// endpoints are left to their (much shorter) TTLs rather than invalidated
+// individually, since a single content mutation can affect an unbounded
+// number of cached list/search pages.
+func (h *ContentHandler) invalidateContent(ctx context.Context, tenantID string, contentID uuid.UUID) {
+	if h.cache == nil {
+		return
+	}
+	key := cache.Key(tenantID, "get", map[string]string{"id": contentID.String()})
+	_ = h.cache.Delete(ctx, key)
+	if h.cacheInvalidateTopic != "" {
+		_ = cache.PublishInvalidation(ctx, h.kafkaProducer, h.cacheInvalidateTopic, tenantID, []string{key})
 	}
 }
 
@@ -63,7 +270,28 @@ func (h *ContentHandler) ListContent(c *gin.Context) {
 		Order:             order,
 	}
 
-	contents, total, err := h.repo.List(c.Request.Context(), tenantID, opts)
+	var prefs *models.UserPreferences
+	if h.preferences != nil {
+		var err error
+		prefs, err = h.preferences.Get(c.Request.Context(), tenantID, middleware.GetUserID(c))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "database_error",
+				Message: "Failed to load preferences",
+			})
+			return
+		}
+		opts = preferences.ResolveListOptions(prefs, opts)
+	}
+
+	key := cache.Key(tenantID, "list", map[string]string{
+		"page": strconv.Itoa(page), "limit": strconv.Itoa(opts.Limit),
+		"category": category, "platform": platform, "geo": geoClass,
+		"sort": opts.SortBy, "order": order, "user": middleware.GetUserID(c),
+	})
+	contents, total, err := h.cachedList(c.Request.Context(), key, cache.TTLList, func() ([]*models.Content, int, error) {
+		return h.repo.List(c.Request.Context(), tenantID, opts)
+	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "database_error",
@@ -72,13 +300,17 @@ func (h *ContentHandler) ListContent(c *gin.Context) {
 		return
 	}
 
-	totalPages := (total + limit - 1) / limit
+	if prefs != nil {
+		contents = preferences.FilterContent(prefs, contents)
+	}
+
+	totalPages := (total + opts.Limit - 1) / opts.Limit
 
 	c.JSON(http.StatusOK, PaginatedResponse{
 		Data: contents,
 		Pagination: Pagination{
 			Page:       page,
-			Limit:      limit,
+			Limit:      opts.Limit,
 			TotalItems: int64(total),
 			TotalPages: totalPages,
 			HasMore:    page < totalPages,
@@ -100,7 +332,10 @@ func (h *ContentHandler) GetContent(c *gin.Context) {
 		return
 	}
 
-	content, err := h.repo.GetByID(c.Request.Context(), tenantID, contentID)
+	key := cache.Key(tenantID, "get", map[string]string{"id": contentID.String()})
+	content, err := h.cachedItem(c.Request.Context(), key, cache.TTLGetByID, func() (*models.Content, error) {
+		return h.repo.GetByID(c.Request.Context(), tenantID, contentID)
+	})
 	if err != nil {
 		if err.Error() == "content not found" {
 			c.JSON(http.StatusNotFound, ErrorResponse{
@@ -122,6 +357,89 @@ func (h *ContentHandler) GetContent(c *gin.Context) {
 	})
 }
 
+// PatchContent applies a sparse update to a content item's editorial
+// metadata (title, category, geo_classification, tags, metadata). An
+// If-Match header carrying the row's current updated_at (as returned by
+// GetContent) is honored as an optimistic-concurrency check: a mismatch
+// means another moderator edited the row first, and the caller should
+// re-fetch and retry rather than overwrite that edit.
+func (h *ContentHandler) PatchContent(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	contentIDStr := c.Param("id")
+
+	contentID, err := uuid.Parse(contentIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid content ID format",
+		})
+		return
+	}
+
+	var body struct {
+		Title             *string                `json:"title"`
+		Category          *string                `json:"category"`
+		GeoClassification *string                `json:"geo_classification"`
+		Tags              *[]string              `json:"tags"`
+		Metadata          map[string]interface{} `json:"metadata"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "invalid request body",
+		})
+		return
+	}
+
+	fields := make(map[string]interface{})
+	if body.Title != nil {
+		fields["title"] = *body.Title
+	}
+	if body.Category != nil {
+		fields["category"] = *body.Category
+	}
+	if body.GeoClassification != nil {
+		fields["geo_classification"] = *body.GeoClassification
+	}
+	if body.Tags != nil {
+		fields["tags"] = *body.Tags
+	}
+	if body.Metadata != nil {
+		fields["metadata"] = body.Metadata
+	}
+	if len(fields) == 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "no patchable fields in request body",
+		})
+		return
+	}
+
+	before, _ := h.repo.GetByID(c.Request.Context(), tenantID, contentID)
+
+	updatedAt, err := h.repo.Patch(c.Request.Context(), tenantID, contentID, fields, c.GetHeader("If-Match"))
+	switch {
+	case err == nil:
+		h.logAudit(c, tenantID, "content.patch", contentID.String(), audit.ToMap(before), fields)
+		c.JSON(http.StatusOK, SuccessResponse{
+			Success: true,
+			Data: map[string]interface{}{
+				"content_id": contentID,
+				"updated_at": updatedAt,
+			},
+		})
+	case err == database.ErrVersionMismatch:
+		c.JSON(http.StatusPreconditionFailed, ErrorResponse{
+			Error:   "precondition_failed",
+			Message: "content was modified by someone else; re-fetch and retry",
+		})
+	case err.Error() == "content not found":
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Content not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "database_error", Message: "Failed to patch content"})
+	}
+}
+
 // GetContentByCategory returns content filtered by category
 func (h *ContentHandler) GetContentByCategory(c *gin.Context) {
 	tenantID := middleware.GetTenantID(c)
@@ -142,7 +460,12 @@ func (h *ContentHandler) GetContentByCategory(c *gin.Context) {
 		Category: category,
 	}
 
-	contents, total, err := h.repo.List(c.Request.Context(), tenantID, opts)
+	key := cache.Key(tenantID, "by_category", map[string]string{
+		"category": category, "page": strconv.Itoa(page), "limit": strconv.Itoa(limit),
+	})
+	contents, total, err := h.cachedList(c.Request.Context(), key, cache.TTLByCategory, func() ([]*models.Content, int, error) {
+		return h.repo.List(c.Request.Context(), tenantID, opts)
+	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "database_error",
@@ -185,7 +508,12 @@ func (h *ContentHandler) GetContentByGeo(c *gin.Context) {
 		GeoClassification: geoClassification,
 	}
 
-	contents, total, err := h.repo.List(c.Request.Context(), tenantID, opts)
+	key := cache.Key(tenantID, "by_geo", map[string]string{
+		"classification": geoClassification, "page": strconv.Itoa(page), "limit": strconv.Itoa(limit),
+	})
+	contents, total, err := h.cachedList(c.Request.Context(), key, cache.TTLByGeo, func() ([]*models.Content, int, error) {
+		return h.repo.List(c.Request.Context(), tenantID, opts)
+	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "database_error",
@@ -218,7 +546,11 @@ func (h *ContentHandler) GetTrendingContent(c *gin.Context) {
 		limit = 20
 	}
 
-	contents, err := h.repo.GetTrending(c.Request.Context(), tenantID, timeRange, limit)
+	key := cache.Key(tenantID, "trending", map[string]string{"range": timeRange, "limit": strconv.Itoa(limit)})
+	contents, _, err := h.cachedList(c.Request.Context(), key, cache.TTLTrending, func() ([]*models.Content, int, error) {
+		contents, err := h.repo.GetTrending(c.Request.Context(), tenantID, timeRange, limit)
+		return contents, len(contents), err
+	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "database_error",
@@ -239,7 +571,12 @@ func (h *ContentHandler) GetTrendingContent(c *gin.Context) {
 	})
 }
 
-// SearchContent performs semantic search across content
+// SearchContent performs search across content. mode selects the
+// retrieval strategy: "vector" (pgvector cosine similarity only), "text"
+// (Postgres full-text/tsvector only), or "hybrid" (default) which fuses
+// both ranked lists with Reciprocal Rank Fusion so a lexical match for
+// that exact term still surfaces when an embedding-only ranking would
+// have buried it, and vice versa.
 func (h *ContentHandler) SearchContent(c *gin.Context) {
 	tenantID := middleware.GetTenantID(c)
 	query := c.Query("q")
@@ -248,6 +585,7 @@ func (h *ContentHandler) SearchContent(c *gin.Context) {
 	category := c.Query("category")
 	dateFrom := c.Query("from")
 	dateTo := c.Query("to")
+	mode := c.DefaultQuery("mode", "hybrid")
 
 	if query == "" {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
@@ -258,6 +596,17 @@ func (h *ContentHandler) SearchContent(c *gin.Context) {
 		return
 	}
 
+	switch mode {
+	case "vector", "text", "hybrid":
+	default:
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "mode must be one of: vector, text, hybrid",
+			Code:    "INVALID_MODE",
+		})
+		return
+	}
+
 	if page < 1 {
 		page = 1
 	}
@@ -265,18 +614,44 @@ func (h *ContentHandler) SearchContent(c *gin.Context) {
 		limit = 20
 	}
 
-	// Generate embedding for semantic search
+	searchParams := map[string]string{
+		"q": query, "page": strconv.Itoa(page), "limit": strconv.Itoa(limit),
+		"category": category, "from": dateFrom, "to": dateTo, "mode": mode,
+	}
+	opts := database.ListOptions{
+		Page:     page,
+		Limit:    limit,
+		Category: category,
+		DateFrom: dateFrom,
+		DateTo:   dateTo,
+	}
+
+	if mode == "text" {
+		key := cache.Key(tenantID, "search", searchParams)
+		contents, total, err := h.cachedList(c.Request.Context(), key, cache.TTLSearch, func() ([]*models.Content, int, error) {
+			return h.repo.SearchFullText(c.Request.Context(), tenantID, query, opts)
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "database_error",
+				Message: "Failed to search content",
+			})
+			return
+		}
+		h.respondSearch(c, contents, total, page, limit)
+		return
+	}
+
+	// vector and hybrid modes both need an embedding; fall back to a
+	// plain list (lexical filtering by category/date only) if generation
+	// fails, same as before this mode ever existed.
 	embedding, err := h.embeddingService.Generate(c.Request.Context(), query)
 	if err != nil {
-		// Fall back to text-based filtering if embedding generation fails
-		opts := database.ListOptions{
-			Page:     page,
-			Limit:    limit,
-			Category: category,
-			DateFrom: dateFrom,
-			DateTo:   dateTo,
-		}
-		contents, total, err := h.repo.List(c.Request.Context(), tenantID, opts)
+		searchParams["mode"] = "fallback"
+		key := cache.Key(tenantID, "search", searchParams)
+		contents, total, err := h.cachedList(c.Request.Context(), key, cache.TTLSearch, func() ([]*models.Content, int, error) {
+			return h.repo.List(c.Request.Context(), tenantID, opts)
+		})
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, ErrorResponse{
 				Error:   "database_error",
@@ -284,29 +659,22 @@ func (h *ContentHandler) SearchContent(c *gin.Context) {
 			})
 			return
 		}
-		totalPages := int((int64(total) + int64(limit) - 1) / int64(limit))
-		c.JSON(http.StatusOK, PaginatedResponse{
-			Data: contents,
-			Pagination: Pagination{
-				Page:       page,
-				Limit:      limit,
-				TotalItems: int64(total),
-				TotalPages: totalPages,
-				HasMore:    page < totalPages,
-			},
-		})
+		h.respondSearch(c, contents, total, page, limit)
 		return
 	}
 
-	// Use semantic search with pgvector
-	opts := database.ListOptions{
-		Page:     page,
-		Limit:    limit,
-		Category: category,
-		DateFrom: dateFrom,
-		DateTo:   dateTo,
+	key := cache.Key(tenantID, "search", searchParams)
+	var contents []*models.Content
+	var total int
+	if mode == "vector" {
+		contents, total, err = h.cachedList(c.Request.Context(), key, cache.TTLSearch, func() ([]*models.Content, int, error) {
+			return h.repo.SearchSemantic(c.Request.Context(), tenantID, embedding, opts)
+		})
+	} else {
+		contents, total, err = h.cachedList(c.Request.Context(), key, cache.TTLSearch, func() ([]*models.Content, int, error) {
+			return h.repo.SearchHybrid(c.Request.Context(), tenantID, embedding, query, opts)
+		})
 	}
-	contents, total, err := h.repo.SearchSemantic(c.Request.Context(), tenantID, embedding, opts)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "database_error",
@@ -315,8 +683,13 @@ func (h *ContentHandler) SearchContent(c *gin.Context) {
 		return
 	}
 
-	totalPages := (total + limit - 1) / limit
+	h.respondSearch(c, contents, total, page, limit)
+}
 
+// respondSearch writes the paginated search response shared by every
+// SearchContent mode.
+func (h *ContentHandler) respondSearch(c *gin.Context, contents []*models.Content, total, page, limit int) {
+	totalPages := (total + limit - 1) / limit
 	c.JSON(http.StatusOK, PaginatedResponse{
 		Data: contents,
 		Pagination: Pagination{
@@ -338,6 +711,8 @@ func (h *ContentHandler) TriggerIngestion(c *gin.Context) {
 		Platform string   `json:"platform"` // youtube, twitter, reddit, rss
 		Force    bool     `json:"force"`    // Force re-ingestion even if already processed
 		Priority int      `json:"priority"` // Job priority (1-10, 10 = highest)
+		Mode     string   `json:"mode"`     // "" (current window, default) or "historical"
+		From     string   `json:"from"`     // RFC3339 date; earliest item a historical crawl should reach
 	}
 
 	if err := c.ShouldBindJSON(&request); err != nil {
@@ -348,6 +723,11 @@ func (h *ContentHandler) TriggerIngestion(c *gin.Context) {
 		return
 	}
 
+	if request.Mode == "historical" {
+		h.triggerBackfill(c, tenantID, request.Platform, request.Sources, request.From)
+		return
+	}
+
 	// Validate platform
 	validPlatforms := map[string]bool{
 		"youtube": true,
@@ -405,6 +785,11 @@ func (h *ContentHandler) TriggerIngestion(c *gin.Context) {
 		return
 	}
 
+	h.publishJobEvent(c.Request.Context(), tenantID, "ingestion", "ingestion.queued", map[string]interface{}{
+		"platform":    request.Platform,
+		"jobs_queued": jobsQueued,
+	})
+
 	c.JSON(http.StatusAccepted, SuccessResponse{
 		Success: true,
 		Message: "ingestion jobs queued successfully",
@@ -418,6 +803,112 @@ func (h *ContentHandler) TriggerIngestion(c *gin.Context) {
 	})
 }
 
+// triggerBackfill starts a resumable historical crawl for each source,
+// one goroutine per source, and returns a job ID per source the caller can
+// poll via GetIngestionJob. Progress itself lives in the persisted cursor
+// (so a restart resumes it); the in-memory job map only remembers which
+// cursor a job ID refers to.
+func (h *ContentHandler) triggerBackfill(c *gin.Context, tenantID, platform string, sources []string, from string) {
+	if h.backfill == nil {
+		c.JSON(http.StatusNotImplemented, ErrorResponse{
+			Error:   "not_configured",
+			Message: "historical backfill is not configured on this deployment",
+		})
+		return
+	}
+
+	reader, ok := h.channelReaders[platform]
+	if !ok {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_platform",
+			Message: "no channel reader configured for platform " + platform,
+		})
+		return
+	}
+
+	var fromTime time.Time
+	if from != "" {
+		var err error
+		fromTime, err = time.Parse("2006-01-02", from)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid_from", Message: "from must be YYYY-MM-DD"})
+			return
+		}
+	}
+
+	jobIDs := make(map[string]string, len(sources))
+	for _, source := range sources {
+		jobID := uuid.New().String()
+
+		h.jobsMu.Lock()
+		h.jobs[jobID] = backfillJob{TenantID: tenantID, Source: source}
+		h.jobsMu.Unlock()
+
+		jobIDs[source] = jobID
+
+		go func(source string) {
+			if err := h.backfill.Run(c.Copy().Request.Context(), reader, tenantID, source, fromTime); err != nil {
+				// The cursor itself (readable via the job's status
+				// endpoint) is the durable record of how far the crawl
+				// got; a failed Run here just means it'll resume later
+				// from an operator re-triggering the same source.
+				return
+			}
+		}(source)
+	}
+
+	c.JSON(http.StatusAccepted, SuccessResponse{
+		Success: true,
+		Message: "historical backfill started",
+		Data: map[string]interface{}{
+			"tenant_id": tenantID,
+			"platform":  platform,
+			"jobs":      jobIDs,
+		},
+	})
+}
+
+// GetIngestionJob reports a historical-backfill job's cursor progress:
+// pages fetched, items enqueued, and the last page token.
+func (h *ContentHandler) GetIngestionJob(c *gin.Context) {
+	jobID := c.Param("id")
+
+	h.jobsMu.RLock()
+	job, ok := h.jobs[jobID]
+	h.jobsMu.RUnlock()
+	if !ok {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "unknown ingestion job"})
+		return
+	}
+
+	cursor, err := h.cursors.Get(c.Request.Context(), job.TenantID, job.Source)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "database_error", Message: "failed to load job progress"})
+		return
+	}
+	if cursor == nil {
+		c.JSON(http.StatusOK, SuccessResponse{Success: true, Data: map[string]interface{}{"status": "pending"}})
+		return
+	}
+
+	status := "running"
+	if cursor.Done {
+		status = "completed"
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Data: map[string]interface{}{
+			"status":         status,
+			"source":         job.Source,
+			"pages_fetched":  cursor.PagesFetched,
+			"items_enqueued": cursor.ItemsEnqueued,
+			"last_token":     cursor.Token,
+			"updated_at":     cursor.UpdatedAt,
+		},
+	})
+}
+
 // ProcessContent triggers AI processing for a specific content item
 func (h *ContentHandler) ProcessContent(c *gin.Context) {
 	tenantID := middleware.GetTenantID(c)
@@ -436,7 +927,8 @@ func (h *ContentHandler) ProcessContent(c *gin.Context) {
 	}
 
 	// Validate content ID format
-	if _, err := uuid.Parse(contentID); err != nil {
+	contentUUID, err := uuid.Parse(contentID)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid content ID format",
@@ -454,7 +946,7 @@ func (h *ContentHandler) ProcessContent(c *gin.Context) {
 		QueuedAt:   time.Now(),
 	}
 
-	err := h.kafkaProducer.Publish(c.Request.Context(), kafka.Message{
+	err = h.kafkaProducer.Publish(c.Request.Context(), kafka.Message{
 		Topic: h.kafkaProcessTopic,
 		Key:   tenantID + "/" + contentID,
 		Value: msg,
@@ -468,6 +960,12 @@ func (h *ContentHandler) ProcessContent(c *gin.Context) {
 		return
 	}
 
+	// The processing job runs asynchronously in a downstream worker, so
+	// this handler can't wait for "completion"; invalidate eagerly since
+	// a queued job's eventual write would otherwise serve a stale
+	// GetContent response for the rest of its TTL.
+	h.invalidateContent(c.Request.Context(), tenantID, contentUUID)
+
 	c.JSON(http.StatusAccepted, SuccessResponse{
 		Success: true,
 		Message: "content processing queued successfully",
@@ -509,6 +1007,8 @@ func (h *ContentHandler) DeleteContent(c *gin.Context) {
 		return
 	}
 
+	h.invalidateContent(c.Request.Context(), tenantID, contentID)
+
 	c.JSON(http.StatusOK, SuccessResponse{
 		Success: true,
 		Message: "content deleted",
@@ -518,3 +1018,67 @@ func (h *ContentHandler) DeleteContent(c *gin.Context) {
 		},
 	})
 }
+
+// StreamEvents opens a Server-Sent Events stream of ingestion/processing/
+// video job-lifecycle events for the caller's tenant, scoped to the
+// comma-separated topics query param (e.g. "ingestion,processing,video";
+// omitted means all topics). A client that reconnects with a Last-Event-ID
+// header replays whatever of that tenant's bounded backlog it missed
+// before switching to live events, so a dropped connection doesn't lose
+// progress it already had. This lets TriggerIngestion/ProcessContent
+// callers observe completion instead of polling GetContent.
+func (h *ContentHandler) StreamEvents(c *gin.Context) {
+	if h.events == nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "not_found",
+			Message: "event streaming is not enabled",
+		})
+		return
+	}
+
+	tenantID := middleware.GetTenantID(c)
+
+	var topics []string
+	if raw := c.Query("topics"); raw != "" {
+		topics = strings.Split(raw, ",")
+	}
+
+	sub := h.events.Subscribe(tenantID, topics, c.GetHeader("Last-Event-ID"))
+	defer h.events.Unsubscribe(tenantID, sub)
+
+	flusher, ok := c.Writer.(http.Flusher)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "streaming_unsupported",
+			Message: "response writer does not support streaming",
+		})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Writer.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(30 * time.Second)
+	defer heartbeat.Stop()
+
+	ctx := c.Request.Context()
+	for {
+		select {
+		case ev := <-sub.C:
+			data, err := json.Marshal(ev)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", ev.ID, ev.Type, data)
+			flusher.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			flusher.Flush()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -2,12 +2,25 @@
 package handlers
 
 import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/audit"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/events"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/integrations"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/kafka"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/middleware"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
 )
@@ -16,14 +29,170 @@ import (
 type CreatorHandler struct {
 	repo        *database.CreatorRepository
 	contentRepo *database.ContentRepository
+
+	// YouTube channel backfill, set by WithBackfill. All nil/zero until
+	// then, in which case BackfillCreator reports 501 rather than
+	// attempting a job it has nowhere to run or checkpoint.
+	youtube             *integrations.YouTubeIntegration
+	backfillCheckpoints integrations.BackfillCheckpointRepository
+	kafkaProducer       *kafka.Producer
+	kafkaRawTopic       string
+
+	backfillJobsMu sync.RWMutex
+	backfillJobs   map[uuid.UUID]*creatorBackfillStatus
+
+	// Bulk creator import, set by WithCreatorImport. importProducer is nil
+	// until then, in which case BulkImportCreators still upserts rows but
+	// skips the CreatorImportedMessage publish.
+	importProducer *kafka.Producer
+	importTopic    string
+
+	// Creator verification workflow, set by WithVerification. verifications
+	// is nil until then, in which case the verification-request endpoints
+	// report 501 rather than a nil-pointer panic.
+	verifications    *database.VerificationRequestRepository
+	events           *events.Hub
+	kafkaEventsTopic string
+
+	// searchBackend runs SearchCreators. Defaults to repo itself (the
+	// Postgres tsvector implementation); WithSearchBackend overrides it,
+	// e.g. with an OpenSearch adapter.
+	searchBackend database.SearchBackend
+
+	// audit records UpdateCreator/DeleteCreator before/after diffs, set
+	// by WithAudit. Nil disables audit logging entirely.
+	audit audit.Logger
+}
+
+// creatorBackfillStatus is BackfillCreator's in-memory view of a running
+// job; GetCreatorBackfillStatus reports it while the job is in flight and
+// falls back to the persisted checkpoint once the process restarts and
+// this map is empty again.
+type creatorBackfillStatus struct {
+	mu            sync.Mutex
+	Status        string `json:"status"`
+	PagesFetched  int    `json:"pages_fetched"`
+	VideosEmitted int    `json:"videos_emitted"`
+	Error         string `json:"error,omitempty"`
+}
+
+// validCreatorPlatforms is the set of Platform values CreateCreator and
+// BulkImportCreators accept.
+var validCreatorPlatforms = map[string]bool{
+	"youtube": true, "twitter": true, "reddit": true, "rss": true, "internal": true,
+}
+
+// validCreatorTiers is the set of CreatorTier values GetCreatorsByTier,
+// VerifyCreator, and BulkImportCreators accept.
+var validCreatorTiers = map[string]bool{
+	"platinum":   true,
+	"gold":       true,
+	"silver":     true,
+	"bronze":     true,
+	"unverified": true,
 }
 
 // NewCreatorHandler creates a new creator handler
 func NewCreatorHandler(repo *database.CreatorRepository, contentRepo *database.ContentRepository) *CreatorHandler {
 	return &CreatorHandler{
-		repo:        repo,
-		contentRepo: contentRepo,
+		repo:          repo,
+		contentRepo:   contentRepo,
+		backfillJobs:  make(map[uuid.UUID]*creatorBackfillStatus),
+		searchBackend: repo,
+	}
+}
+
+// WithSearchBackend overrides the backend SearchCreators uses to run
+// ranked, faceted creator search, e.g. an OpenSearch adapter swapped in
+// once a deployment outgrows Postgres tsvector.
+func (h *CreatorHandler) WithSearchBackend(backend database.SearchBackend) *CreatorHandler {
+	h.searchBackend = backend
+	return h
+}
+
+// WithBackfill enables POST /creators/:id/backfill and GET
+// /creators/:id/backfill/status: youtube runs the crawl, checkpoints
+// persists its progress so a job resumes after a restart, and videos are
+// published to producer/rawTopic the same way TriggerIngestion's
+// historical mode publishes discovered items.
+func (h *CreatorHandler) WithBackfill(youtube *integrations.YouTubeIntegration, checkpoints integrations.BackfillCheckpointRepository, producer *kafka.Producer, rawTopic string) *CreatorHandler {
+	h.youtube = youtube
+	h.backfillCheckpoints = checkpoints
+	h.kafkaProducer = producer
+	h.kafkaRawTopic = rawTopic
+	return h
+}
+
+// WithCreatorImport enables publishing a CreatorImportedMessage to topic
+// for every row BulkImportCreators accepts (dry runs excepted), so content
+// ingestion/enrichment can start crawling it without waiting on the next
+// scheduled sync.
+func (h *CreatorHandler) WithCreatorImport(producer *kafka.Producer, topic string) *CreatorHandler {
+	h.importProducer = producer
+	h.importTopic = topic
+	return h
+}
+
+// WithVerification enables the verification-request endpoints
+// (CreateVerificationRequest, ListVerificationRequests,
+// ApproveVerificationRequest, RejectVerificationRequest): repo persists
+// the requests and their audit trail, and an approval publishes
+// creator.verification.changed via producer to hub/eventsTopic the same
+// way ContentHandler.WithEvents wires its job-lifecycle events, so
+// downstream ranking/trust-score services can react without polling
+// ListCreators.
+func (h *CreatorHandler) WithVerification(repo *database.VerificationRequestRepository, producer *kafka.Producer, hub *events.Hub, eventsTopic string) *CreatorHandler {
+	h.verifications = repo
+	h.kafkaProducer = producer
+	h.events = hub
+	h.kafkaEventsTopic = eventsTopic
+	return h
+}
+
+// publishVerificationEvent publishes a kafka.JobEventMessage so every
+// replica's events.Hub (via internal/events.RunConsumer) fans it out to
+// that tenant's SSE subscribers under the "creator" topic.
+// WithAudit enables audit logging of UpdateCreator/DeleteCreator.
+func (h *CreatorHandler) WithAudit(logger audit.Logger) *CreatorHandler {
+	h.audit = logger
+	return h
+}
+
+// logAudit is a no-op when h.audit is nil, so every mutation handler can
+// call it unconditionally instead of guarding each call site.
+func (h *CreatorHandler) logAudit(c *gin.Context, tenantID, action, targetID string, before, after map[string]interface{}) {
+	if h.audit == nil {
+		return
+	}
+	h.audit.Log(c.Request.Context(), models.AuditEvent{
+		TenantID:   tenantID,
+		ActorID:    middleware.GetUserID(c),
+		ActorType:  "user",
+		Action:     action,
+		TargetType: "creator",
+		TargetID:   targetID,
+		Before:     before,
+		After:      after,
+		IP:         c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+	})
+}
+
+func (h *CreatorHandler) publishVerificationEvent(ctx context.Context, tenantID, eventType string, data map[string]interface{}) {
+	if h.events == nil || h.kafkaEventsTopic == "" || h.kafkaProducer == nil {
+		return
 	}
+	_ = h.kafkaProducer.Publish(ctx, kafka.Message{
+		Topic: h.kafkaEventsTopic,
+		Key:   tenantID,
+		Value: kafka.JobEventMessage{
+			TenantID:  tenantID,
+			Topic:     "creator",
+			Type:      eventType,
+			Data:      data,
+			EmittedAt: time.Now(),
+		},
+	})
 }
 
 // ListCreators returns a paginated list of content creators
@@ -43,7 +212,7 @@ func (h *CreatorHandler) ListCreators(c *gin.Context) {
 		limit = 20
 	}
 
-	opts := database.CreatorListOptions{
+	opts := database.ListCreatorsParams{
 		Page:         page,
 		Limit:        limit,
 		Platform:     platform,
@@ -51,9 +220,10 @@ func (h *CreatorHandler) ListCreators(c *gin.Context) {
 		VerifiedOnly: verifiedOnly,
 		SortBy:       c.DefaultQuery("sort", "created_at"),
 		Order:        c.DefaultQuery("order", "desc"),
+		IncludeTotal: true,
 	}
 
-	creators, total, err := h.repo.List(c.Request.Context(), tenantID, opts)
+	creators, total, _, err := h.repo.List(c.Request.Context(), tenantID, opts)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "database_error",
@@ -76,6 +246,92 @@ func (h *CreatorHandler) ListCreators(c *gin.Context) {
 	})
 }
 
+// creatorSearchFacets is the facet-count portion of
+// creatorSearchResponse.
+type creatorSearchFacets struct {
+	Platform map[string]int `json:"platform"`
+	Tier     map[string]int `json:"tier"`
+}
+
+// creatorSearchResponse extends PaginatedResponse with the facet counts
+// SearchCreators computes alongside the ranked results, so a UI can
+// render a filter sidebar without a second round-trip.
+type creatorSearchResponse struct {
+	Data       interface{}         `json:"data"`
+	Pagination Pagination          `json:"pagination"`
+	Facets     creatorSearchFacets `json:"facets"`
+}
+
+// SearchCreators performs ranked, faceted search over creators by
+// name/bio/topics_expertise via searchBackend (database.CreatorRepository's
+// Postgres tsvector implementation by default; see WithSearchBackend).
+// facets query params (e.g. "platform,tier") are accepted for forward
+// compatibility with a future backend that only computes the facets a
+// caller asks for; the Postgres backend always returns both today since
+// it's a single grouped query either way.
+func (h *CreatorHandler) SearchCreators(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	query := c.Query("q")
+	if query == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "search query is required",
+			Code:    "MISSING_QUERY",
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	opts := database.CreatorSearchOptions{
+		Page:     page,
+		Limit:    limit,
+		Platform: c.Query("platform"),
+		Tier:     c.Query("tier"),
+	}
+	if minFollowers := c.Query("min_followers"); minFollowers != "" {
+		if v, err := strconv.ParseInt(minFollowers, 10, 64); err == nil {
+			opts.MinFollowers = v
+		}
+	}
+	if topics := c.Query("topics"); topics != "" {
+		opts.Topics = strings.Split(topics, ",")
+	}
+
+	result, err := h.searchBackend.SearchCreators(c.Request.Context(), tenantID, query, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to search creators",
+		})
+		return
+	}
+
+	totalPages := (result.Total + limit - 1) / limit
+
+	c.JSON(http.StatusOK, creatorSearchResponse{
+		Data: result.Creators,
+		Pagination: Pagination{
+			Page:       page,
+			Limit:      limit,
+			TotalItems: int64(result.Total),
+			TotalPages: totalPages,
+			HasMore:    page < totalPages,
+		},
+		Facets: creatorSearchFacets{
+			Platform: result.FacetsByPlatform,
+			Tier:     result.FacetsByTier,
+		},
+	})
+}
+
 // GetCreator returns a single creator by ID
 func (h *CreatorHandler) GetCreator(c *gin.Context) {
 	tenantID := middleware.GetTenantID(c)
@@ -120,14 +376,7 @@ func (h *CreatorHandler) GetCreatorsByTier(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
 
 	// Validate tier
-	validTiers := map[string]bool{
-		"platinum":   true,
-		"gold":       true,
-		"silver":     true,
-		"bronze":     true,
-		"unverified": true,
-	}
-	if !validTiers[tier] {
+	if !validCreatorTiers[tier] {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error:   "bad_request",
 			Message: "invalid tier value",
@@ -144,15 +393,16 @@ func (h *CreatorHandler) GetCreatorsByTier(c *gin.Context) {
 		limit = 20
 	}
 
-	opts := database.CreatorListOptions{
-		Page:   page,
-		Limit:  limit,
-		Tier:   tier,
-		SortBy: c.DefaultQuery("sort", "follower_count"),
-		Order:  c.DefaultQuery("order", "desc"),
+	opts := database.ListCreatorsParams{
+		Page:         page,
+		Limit:        limit,
+		Tier:         tier,
+		SortBy:       c.DefaultQuery("sort", "follower_count"),
+		Order:        c.DefaultQuery("order", "desc"),
+		IncludeTotal: true,
 	}
 
-	creators, total, err := h.repo.List(c.Request.Context(), tenantID, opts)
+	creators, total, _, err := h.repo.List(c.Request.Context(), tenantID, opts)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "database_error",
@@ -274,10 +524,7 @@ func (h *CreatorHandler) CreateCreator(c *gin.Context) {
 	}
 
 	// Validate platform
-	validPlatforms := map[string]bool{
-		"youtube": true, "twitter": true, "reddit": true, "rss": true, "internal": true,
-	}
-	if !validPlatforms[request.Platform] {
+	if !validCreatorPlatforms[request.Platform] {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_platform",
 			Message: "Platform must be one of: youtube, twitter, reddit, rss, internal",
@@ -321,6 +568,7 @@ func (h *CreatorHandler) CreateCreator(c *gin.Context) {
 
 // UpdateCreator updates an existing creator
 func (h *CreatorHandler) UpdateCreator(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
 	creatorIDStr := c.Param("id")
 
 	creatorID, err := uuid.Parse(creatorIDStr)
@@ -378,7 +626,30 @@ func (h *CreatorHandler) UpdateCreator(c *gin.Context) {
 		return
 	}
 
-	if err := h.repo.Update(c.Request.Context(), creatorID, updates); err != nil {
+	before, _ := h.repo.GetByID(c.Request.Context(), tenantID, creatorID)
+
+	update := database.CreatorUpdate{}
+	if request.Name != "" {
+		update.Name = &request.Name
+	}
+	if request.AvatarURL != "" {
+		update.AvatarURL = &request.AvatarURL
+	}
+	if request.Bio != "" {
+		update.Bio = &request.Bio
+	}
+	if request.Tier != "" {
+		tier := models.CreatorTier(request.Tier)
+		update.Tier = &tier
+	}
+	if len(request.TopicsExpertise) > 0 {
+		update.TopicsExpertise = request.TopicsExpertise
+	}
+	if request.Active != nil {
+		update.Active = request.Active
+	}
+
+	if err := h.repo.Update(c.Request.Context(), tenantID, creatorID, update); err != nil {
 		if err.Error() == "creator not found" {
 			c.JSON(http.StatusNotFound, ErrorResponse{
 				Error:   "not_found",
@@ -392,6 +663,7 @@ func (h *CreatorHandler) UpdateCreator(c *gin.Context) {
 		})
 		return
 	}
+	h.logAudit(c, tenantID, "creator.update", creatorID.String(), audit.ToMap(before), updates)
 
 	c.JSON(http.StatusOK, SuccessResponse{
 		Success: true,
@@ -417,6 +689,8 @@ func (h *CreatorHandler) DeleteCreator(c *gin.Context) {
 		return
 	}
 
+	before, _ := h.repo.GetByID(c.Request.Context(), tenantID, creatorID)
+
 	if err := h.repo.SoftDelete(c.Request.Context(), tenantID, creatorID); err != nil {
 		if err.Error() == "creator not found" {
 			c.JSON(http.StatusNotFound, ErrorResponse{
@@ -431,6 +705,7 @@ func (h *CreatorHandler) DeleteCreator(c *gin.Context) {
 		})
 		return
 	}
+	h.logAudit(c, tenantID, "creator.delete", creatorID.String(), audit.ToMap(before), map[string]interface{}{"active": false})
 
 	c.JSON(http.StatusOK, SuccessResponse{
 		Success: true,
@@ -470,14 +745,7 @@ func (h *CreatorHandler) VerifyCreator(c *gin.Context) {
 	}
 
 	// Validate tier
-	validTiers := map[string]bool{
-		"platinum":   true,
-		"gold":       true,
-		"silver":     true,
-		"bronze":     true,
-		"unverified": true,
-	}
-	if !validTiers[request.Tier] {
+	if !validCreatorTiers[request.Tier] {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error:   "bad_request",
 			Message: "invalid tier value",
@@ -512,3 +780,702 @@ func (h *CreatorHandler) VerifyCreator(c *gin.Context) {
 		},
 	})
 }
+
+// CreateVerificationRequest files a request to promote a creator to a
+// higher tier, pending reviewer sign-off. Unlike VerifyCreator, it never
+// mutates the creator row itself; ApproveVerificationRequest does that
+// once a reviewer approves.
+func (h *CreatorHandler) CreateVerificationRequest(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	creatorIDStr := c.Param("id")
+
+	creatorID, err := uuid.Parse(creatorIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid creator ID format",
+		})
+		return
+	}
+
+	if h.verifications == nil {
+		c.JSON(http.StatusNotImplemented, ErrorResponse{
+			Error:   "not_configured",
+			Message: "creator verification workflow is not configured on this deployment",
+		})
+		return
+	}
+
+	var request struct {
+		RequestedTier string   `json:"requested_tier" binding:"required"`
+		EvidenceURLs  []string `json:"evidence_urls"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	if !validCreatorTiers[request.RequestedTier] {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "invalid tier value",
+			Code:    "INVALID_TIER",
+		})
+		return
+	}
+
+	if _, err := h.repo.GetByID(c.Request.Context(), tenantID, creatorID); err != nil {
+		if err.Error() == "creator not found" {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Creator not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to retrieve creator",
+		})
+		return
+	}
+
+	vr := &models.VerificationRequest{
+		TenantID:      tenantID,
+		CreatorID:     creatorID,
+		RequestedTier: models.CreatorTier(request.RequestedTier),
+		EvidenceURLs:  request.EvidenceURLs,
+		RequestedBy:   middleware.GetUserID(c),
+		Status:        models.VerificationStatusPending,
+	}
+
+	if err := h.verifications.Create(c.Request.Context(), vr); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to create verification request",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Success: true,
+		Message: "verification request created successfully",
+		Data:    vr,
+	})
+}
+
+// ListVerificationRequests returns the audit trail of verification
+// requests filed against a creator, newest first.
+func (h *CreatorHandler) ListVerificationRequests(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	creatorIDStr := c.Param("id")
+
+	creatorID, err := uuid.Parse(creatorIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid creator ID format",
+		})
+		return
+	}
+
+	if h.verifications == nil {
+		c.JSON(http.StatusNotImplemented, ErrorResponse{
+			Error:   "not_configured",
+			Message: "creator verification workflow is not configured on this deployment",
+		})
+		return
+	}
+
+	requests, err := h.verifications.ListByCreator(c.Request.Context(), tenantID, creatorID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to retrieve verification requests",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Data:    requests,
+	})
+}
+
+// decideVerificationRequest is the shared body of ApproveVerificationRequest
+// and RejectVerificationRequest: both parse the request ID, read the
+// reviewer's optional notes, and transition the request via
+// VerificationRequestRepository.Decide; only the outcome status and the
+// post-decision side effect (Verify + publish) differ.
+func (h *CreatorHandler) decideVerificationRequest(c *gin.Context, status models.VerificationStatus) {
+	tenantID := middleware.GetTenantID(c)
+	requestIDStr := c.Param("id")
+
+	requestID, err := uuid.Parse(requestIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid verification request ID format",
+		})
+		return
+	}
+
+	if h.verifications == nil {
+		c.JSON(http.StatusNotImplemented, ErrorResponse{
+			Error:   "not_configured",
+			Message: "creator verification workflow is not configured on this deployment",
+		})
+		return
+	}
+
+	var request struct {
+		ReviewerNotes string `json:"reviewer_notes"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	vr, err := h.verifications.GetByID(c.Request.Context(), tenantID, requestID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "not_found",
+			Message: "Verification request not found",
+		})
+		return
+	}
+
+	reviewerID := middleware.GetUserID(c)
+	if err := h.verifications.Decide(c.Request.Context(), tenantID, requestID, status, reviewerID, request.ReviewerNotes); err != nil {
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "conflict",
+			Message: "Verification request not found or already decided",
+		})
+		return
+	}
+
+	if status == models.VerificationStatusApproved {
+		priorTier := ""
+		if creator, err := h.repo.GetByID(c.Request.Context(), tenantID, vr.CreatorID); err == nil {
+			priorTier = string(creator.Tier)
+		}
+		if err := h.repo.Verify(c.Request.Context(), tenantID, vr.CreatorID, string(vr.RequestedTier)); err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "database_error",
+				Message: "Failed to apply verification decision",
+			})
+			return
+		}
+		h.publishVerificationEvent(c.Request.Context(), tenantID, "creator.verification.changed", map[string]interface{}{
+			"creator_id":  vr.CreatorID.String(),
+			"prior_tier":  priorTier,
+			"new_tier":    string(vr.RequestedTier),
+			"reviewer_id": reviewerID,
+			"request_id":  requestID.String(),
+		})
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Message: "verification request " + string(status),
+		Data: map[string]interface{}{
+			"request_id":  requestID.String(),
+			"creator_id":  vr.CreatorID.String(),
+			"status":      status,
+			"reviewer_id": reviewerID,
+		},
+	})
+}
+
+// ApproveVerificationRequest approves a pending verification request,
+// promotes the creator to the requested tier via CreatorRepository.Verify,
+// and publishes creator.verification.changed for downstream ranking and
+// trust-score services.
+func (h *CreatorHandler) ApproveVerificationRequest(c *gin.Context) {
+	h.decideVerificationRequest(c, models.VerificationStatusApproved)
+}
+
+// RejectVerificationRequest rejects a pending verification request
+// without touching the creator's current tier.
+func (h *CreatorHandler) RejectVerificationRequest(c *gin.Context) {
+	h.decideVerificationRequest(c, models.VerificationStatusRejected)
+}
+
+// BackfillCreator starts a resumable historical crawl of a YouTube
+// creator's full upload history via YouTubeIntegration.BackfillChannel,
+// running in a background goroutine so the request returns as soon as
+// the job is accepted; poll GetCreatorBackfillStatus for progress.
+func (h *CreatorHandler) BackfillCreator(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	creatorIDStr := c.Param("id")
+
+	creatorID, err := uuid.Parse(creatorIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid creator ID format",
+		})
+		return
+	}
+
+	if h.youtube == nil || h.backfillCheckpoints == nil {
+		c.JSON(http.StatusNotImplemented, ErrorResponse{
+			Error:   "not_configured",
+			Message: "channel backfill is not configured on this deployment",
+		})
+		return
+	}
+
+	creator, err := h.repo.GetByID(c.Request.Context(), tenantID, creatorID)
+	if err != nil {
+		if err.Error() == "creator not found" {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Creator not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to retrieve creator",
+		})
+		return
+	}
+	if creator.Platform != models.PlatformYouTube {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_platform",
+			Message: "backfill is only supported for youtube creators",
+		})
+		return
+	}
+
+	// All fields are optional; pass {} to backfill the full history with
+	// the defaults (see BackfillOptions).
+	var request struct {
+		StopAt        string `json:"stop_at"` // RFC3339; crawl stops once it reaches a video published before this
+		StopAtVideoID string `json:"stop_at_video_id"`
+		PageSize      int    `json:"page_size"`
+		BatchSize     int    `json:"batch_size"`
+		QuotaBudget   int    `json:"quota_budget"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	opts := integrations.BackfillOptions{
+		StopAtVideoID: request.StopAtVideoID,
+		PageSize:      request.PageSize,
+		BatchSize:     request.BatchSize,
+		QuotaBudget:   request.QuotaBudget,
+	}
+	if request.StopAt != "" {
+		stopAt, err := time.Parse(time.RFC3339, request.StopAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid_stop_at", Message: "stop_at must be RFC3339"})
+			return
+		}
+		opts.StopAt = stopAt
+	}
+
+	status := &creatorBackfillStatus{Status: "running"}
+	h.backfillJobsMu.Lock()
+	h.backfillJobs[creatorID] = status
+	h.backfillJobsMu.Unlock()
+
+	channelID := creator.PlatformID
+	go func() {
+		ctx := context.Background()
+		err := h.youtube.BackfillChannel(ctx, channelID, opts, h.backfillCheckpoints, func(ctx context.Context, videos []integrations.YouTubeVideo) error {
+			for _, video := range videos {
+				msg := kafka.ContentIngestionMessage{
+					TenantID:    tenantID,
+					SourceType:  "youtube",
+					SourceID:    video.ID,
+					URL:         "https://www.youtube.com/watch?v=" + video.ID,
+					Mode:        "historical",
+					Priority:    3,
+					RequestedAt: time.Now(),
+				}
+				if pubErr := h.kafkaProducer.Publish(ctx, kafka.Message{
+					Topic: h.kafkaRawTopic,
+					Key:   tenantID + "/youtube/" + video.ID,
+					Value: msg,
+				}); pubErr != nil {
+					continue
+				}
+				status.mu.Lock()
+				status.VideosEmitted++
+				status.mu.Unlock()
+			}
+			status.mu.Lock()
+			status.PagesFetched++
+			status.mu.Unlock()
+			return nil
+		})
+
+		status.mu.Lock()
+		defer status.mu.Unlock()
+		if err != nil {
+			status.Status = "failed"
+			status.Error = err.Error()
+			return
+		}
+		status.Status = "completed"
+	}()
+
+	c.JSON(http.StatusAccepted, SuccessResponse{
+		Success: true,
+		Message: "channel backfill started",
+		Data: map[string]interface{}{
+			"creator_id": creatorID.String(),
+			"channel_id": channelID,
+		},
+	})
+}
+
+// GetCreatorBackfillStatus reports a channel backfill job's progress:
+// the in-memory status (and running counters) if the job is still being
+// tracked by this replica, or its persisted checkpoint otherwise, e.g.
+// after a restart.
+func (h *CreatorHandler) GetCreatorBackfillStatus(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	creatorIDStr := c.Param("id")
+
+	creatorID, err := uuid.Parse(creatorIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid creator ID format",
+		})
+		return
+	}
+
+	h.backfillJobsMu.RLock()
+	status, ok := h.backfillJobs[creatorID]
+	h.backfillJobsMu.RUnlock()
+	if ok {
+		status.mu.Lock()
+		defer status.mu.Unlock()
+		c.JSON(http.StatusOK, SuccessResponse{
+			Success: true,
+			Data: map[string]interface{}{
+				"status":         status.Status,
+				"pages_fetched":  status.PagesFetched,
+				"videos_emitted": status.VideosEmitted,
+				"error":          status.Error,
+			},
+		})
+		return
+	}
+
+	if h.backfillCheckpoints == nil {
+		c.JSON(http.StatusNotImplemented, ErrorResponse{
+			Error:   "not_configured",
+			Message: "channel backfill is not configured on this deployment",
+		})
+		return
+	}
+
+	creator, err := h.repo.GetByID(c.Request.Context(), tenantID, creatorID)
+	if err != nil {
+		if err.Error() == "creator not found" {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Creator not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to retrieve creator",
+		})
+		return
+	}
+
+	checkpoint, err := h.backfillCheckpoints.Get(c.Request.Context(), creator.PlatformID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "failed to load backfill progress",
+		})
+		return
+	}
+	if checkpoint == nil {
+		c.JSON(http.StatusOK, SuccessResponse{Success: true, Data: map[string]interface{}{"status": "pending"}})
+		return
+	}
+
+	checkpointStatus := "running"
+	if checkpoint.Done {
+		checkpointStatus = "completed"
+	}
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Data: map[string]interface{}{
+			"status":            checkpointStatus,
+			"last_video_id":     checkpoint.LastVideoID,
+			"last_published_at": checkpoint.LastPublishedAt,
+			"updated_at":        checkpoint.UpdatedAt,
+		},
+	})
+}
+
+// creatorImportRow is one row of an uploaded CSV/NDJSON bulk import.
+type creatorImportRow struct {
+	Name            string                 `json:"name"`
+	Platform        string                 `json:"platform"`
+	PlatformID      string                 `json:"platform_id"`
+	Tier            string                 `json:"tier"`
+	Bio             string                 `json:"bio"`
+	TopicsExpertise []string               `json:"topics_expertise"`
+	SocialLinks     map[string]interface{} `json:"social_links"`
+}
+
+// creatorImportResult is one row's outcome, streamed back as an
+// application/x-ndjson line so a caller importing thousands of rows can
+// reconcile partial failures without buffering the whole response.
+type creatorImportResult struct {
+	Row    int    `json:"row"`
+	Status string `json:"status"` // created, updated, dry_run, invalid
+	Error  string `json:"error,omitempty"`
+}
+
+// BulkImportCreators onboards a tenant's full creator roster in one
+// request: POST /admin/creators/bulk accepts either a multipart/form-data
+// upload with a "file" part (CSV columns: name, platform, platform_id,
+// tier, bio, topics_expertise, social_links - the last two pipe-separated
+// and JSON respectively) or a raw application/x-ndjson body of
+// creatorImportRow objects, one per line. Rows are streamed and upserted
+// independently via CreatorRepository, so one bad row doesn't fail the
+// whole batch; the response is itself application/x-ndjson, one
+// creatorImportResult per row in upload order. ?dry_run=true validates
+// every row without writing anything or publishing to Kafka.
+func (h *CreatorHandler) BulkImportCreators(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	dryRun := c.Query("dry_run") == "true"
+
+	nextRow, closeSrc, err := h.openCreatorImportSource(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: err.Error(),
+		})
+		return
+	}
+	defer closeSrc()
+
+	flusher, ok := c.Writer.(http.Flusher)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "streaming_unsupported",
+			Message: "response writer does not support streaming",
+		})
+		return
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Writer.WriteHeader(http.StatusOK)
+
+	enc := json.NewEncoder(c.Writer)
+	for row := 1; ; row++ {
+		record, err := nextRow()
+		if err == io.EOF {
+			return
+		}
+		result := creatorImportResult{Row: row}
+		if err != nil {
+			result.Status = "invalid"
+			result.Error = err.Error()
+		} else {
+			result.Status, result.Error = h.importCreatorRow(c.Request.Context(), tenantID, record, dryRun)
+		}
+		enc.Encode(result)
+		flusher.Flush()
+	}
+}
+
+// importCreatorRow validates and, unless dryRun, upserts a single row: an
+// existing platform_id match is updated in place, otherwise a new creator
+// is created. On success it publishes a CreatorImportedMessage so content
+// ingestion/enrichment can start crawling the creator immediately.
+func (h *CreatorHandler) importCreatorRow(ctx context.Context, tenantID string, record creatorImportRow, dryRun bool) (status string, errMsg string) {
+	if record.Name == "" || record.Platform == "" || record.PlatformID == "" {
+		return "invalid", "name, platform, and platform_id are required"
+	}
+	if !validCreatorPlatforms[record.Platform] {
+		return "invalid", "platform must be one of: youtube, twitter, reddit, rss, internal"
+	}
+
+	tier := models.CreatorTierUnverified
+	if record.Tier != "" {
+		if !validCreatorTiers[record.Tier] {
+			return "invalid", "invalid tier value"
+		}
+		tier = models.CreatorTier(record.Tier)
+	}
+
+	existing, err := h.repo.GetByPlatformID(ctx, tenantID, record.Platform, record.PlatformID)
+	if err != nil && err.Error() != "creator not found" {
+		return "invalid", "failed to look up existing creator: " + err.Error()
+	}
+
+	creatorID := uuid.Nil
+	if existing != nil {
+		creatorID = existing.ID
+		if dryRun {
+			return "dry_run", ""
+		}
+		update := database.CreatorUpdate{
+			Name: &record.Name,
+			Bio:  &record.Bio,
+			Tier: &tier,
+		}
+		if err := h.repo.Update(ctx, tenantID, creatorID, update); err != nil {
+			return "invalid", "failed to update creator: " + err.Error()
+		}
+	} else {
+		if dryRun {
+			return "dry_run", ""
+		}
+		creator := &models.Creator{
+			TenantID:        tenantID,
+			Name:            record.Name,
+			Platform:        models.Platform(record.Platform),
+			PlatformID:      record.PlatformID,
+			Bio:             record.Bio,
+			Tier:            tier,
+			TopicsExpertise: record.TopicsExpertise,
+			SocialLinks:     models.JSONB(record.SocialLinks),
+			Active:          true,
+		}
+		if err := h.repo.Create(ctx, creator); err != nil {
+			return "invalid", "failed to create creator: " + err.Error()
+		}
+		creatorID = creator.ID
+	}
+
+	if h.importProducer != nil && h.importTopic != "" {
+		_ = h.importProducer.Publish(ctx, kafka.Message{
+			Topic: h.importTopic,
+			Key:   tenantID + "/" + record.Platform + "/" + record.PlatformID,
+			Value: kafka.CreatorImportedMessage{
+				TenantID:   tenantID,
+				CreatorID:  creatorID.String(),
+				Platform:   record.Platform,
+				PlatformID: record.PlatformID,
+				ImportedAt: time.Now(),
+			},
+		})
+	}
+
+	if existing != nil {
+		return "updated", ""
+	}
+	return "created", ""
+}
+
+// openCreatorImportSource dispatches on Content-Type to build the row
+// iterator BulkImportCreators drains: multipart/form-data with a "file"
+// part is parsed as CSV, anything else is read as application/x-ndjson
+// from the raw request body. The returned close func releases whichever
+// underlying reader was opened.
+func (h *CreatorHandler) openCreatorImportSource(c *gin.Context) (func() (creatorImportRow, error), func(), error) {
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		file, _, err := c.Request.FormFile("file")
+		if err != nil {
+			return nil, nil, fmt.Errorf("missing file part: %w", err)
+		}
+		nextRow, err := newCreatorImportCSVReader(file)
+		if err != nil {
+			file.Close()
+			return nil, nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		return nextRow, func() { file.Close() }, nil
+	}
+
+	return newCreatorImportNDJSONReader(c.Request.Body), func() { c.Request.Body.Close() }, nil
+}
+
+// newCreatorImportCSVReader returns a row iterator over r's CSV content,
+// mapping columns by header name rather than position so a caller may
+// omit or reorder optional columns.
+func newCreatorImportCSVReader(r io.Reader) (func() (creatorImportRow, error), error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	columnIndex := make(map[string]int, len(header))
+	for i, name := range header {
+		columnIndex[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+
+	get := func(fields []string, column string) string {
+		i, ok := columnIndex[column]
+		if !ok || i >= len(fields) {
+			return ""
+		}
+		return strings.TrimSpace(fields[i])
+	}
+
+	return func() (creatorImportRow, error) {
+		fields, err := reader.Read()
+		if err != nil {
+			return creatorImportRow{}, err
+		}
+
+		row := creatorImportRow{
+			Name:       get(fields, "name"),
+			Platform:   get(fields, "platform"),
+			PlatformID: get(fields, "platform_id"),
+			Tier:       get(fields, "tier"),
+			Bio:        get(fields, "bio"),
+		}
+		if topics := get(fields, "topics_expertise"); topics != "" {
+			row.TopicsExpertise = strings.Split(topics, "|")
+		}
+		if links := get(fields, "social_links"); links != "" {
+			if err := json.Unmarshal([]byte(links), &row.SocialLinks); err != nil {
+				return creatorImportRow{}, fmt.Errorf("invalid social_links JSON: %w", err)
+			}
+		}
+		return row, nil
+	}, nil
+}
+
+// newCreatorImportNDJSONReader returns a row iterator over r, one JSON
+// object per line.
+func newCreatorImportNDJSONReader(r io.Reader) func() (creatorImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	return func() (creatorImportRow, error) {
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			var row creatorImportRow
+			if err := json.Unmarshal([]byte(line), &row); err != nil {
+				return creatorImportRow{}, fmt.Errorf("invalid ndjson line: %w", err)
+			}
+			return row, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return creatorImportRow{}, err
+		}
+		return creatorImportRow{}, io.EOF
+	}
+}
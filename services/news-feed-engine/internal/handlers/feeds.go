@@ -0,0 +1,173 @@
+// Package handlers provides HTTP handlers for bulk feed subscription
+// import/export via OPML
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/integrations"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/middleware"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+// FeedsHandler handles bulk RSS/Atom feed subscription import and
+// export via OPML, layered on SourceRepository.BulkUpsert the same way
+// the admin sources config endpoints are.
+type FeedsHandler struct {
+	sourceRepo *database.SourceRepository
+}
+
+// NewFeedsHandler creates a new feeds handler.
+func NewFeedsHandler(sourceRepo *database.SourceRepository) *FeedsHandler {
+	return &FeedsHandler{sourceRepo: sourceRepo}
+}
+
+// ImportFeeds parses an OPML document from the request body and
+// upserts each feed outline as a content source, matched by (tenant_id,
+// identifier) exactly like SourceRepository.BulkUpsert. A category
+// folder's title becomes its contained feeds' Category; any custom
+// outline attributes (keep-original-content, block-filter rules,
+// category tags, ...) are preserved in the source's Config. With
+// ?dry_run=true nothing is written.
+func (h *FeedsHandler) ImportFeeds(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	dryRun := c.Query("dry_run") == "true"
+
+	subs, err := integrations.ParseOPML(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "invalid OPML: " + err.Error(),
+		})
+		return
+	}
+
+	sources := flattenSubscriptions(subs, "")
+	results, err := h.sourceRepo.BulkUpsert(c.Request.Context(), tenantID, sources, middleware.GetUserID(c), dryRun)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "failed to import feeds",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Data: map[string]interface{}{
+			"tenant_id": tenantID,
+			"results":   results,
+			"count":     len(results),
+		},
+	})
+}
+
+// ExportFeeds returns every RSS content source configured for the
+// caller's tenant as an OPML document, grouping feeds into one category
+// folder per distinct ContentSource.Category.
+func (h *FeedsHandler) ExportFeeds(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+
+	sources, err := h.sourceRepo.List(c.Request.Context(), tenantID, database.SourceListOptions{Platform: string(models.PlatformRSS)})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "failed to retrieve feeds",
+		})
+		return
+	}
+
+	data, err := integrations.SerializeOPML(subscriptionsFromSources(sources))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "failed to serialize OPML",
+		})
+		return
+	}
+
+	c.Data(http.StatusOK, "text/x-opml+xml", data)
+}
+
+// flattenSubscriptions walks an OPML outline tree into content source
+// rows: feed outlines (XMLURL set) each become one ContentSource, using
+// the nearest enclosing folder's title as their Category; folder
+// outlines just recurse into their children.
+func flattenSubscriptions(subs []integrations.Subscription, category string) []models.ContentSource {
+	var sources []models.ContentSource
+	for _, sub := range subs {
+		if sub.IsFeed() {
+			source := models.ContentSource{
+				Name:       sub.Title,
+				Platform:   models.PlatformRSS,
+				SourceType: "feed",
+				Identifier: sub.XMLURL,
+				Category:   category,
+				Active:     true,
+			}
+			if len(sub.Attrs) > 0 {
+				source.Config = make(models.JSONB, len(sub.Attrs))
+				for k, v := range sub.Attrs {
+					source.Config[k] = v
+				}
+			}
+			sources = append(sources, source)
+			continue
+		}
+
+		folderCategory := sub.Title
+		if folderCategory == "" {
+			folderCategory = category
+		}
+		sources = append(sources, flattenSubscriptions(sub.Children, folderCategory)...)
+	}
+	return sources
+}
+
+// subscriptionsFromSources is the inverse of flattenSubscriptions: it
+// groups sources into one category folder per distinct Category, with
+// uncategorized sources left at the top level.
+func subscriptionsFromSources(sources []models.ContentSource) []integrations.Subscription {
+	var top []integrations.Subscription
+	folders := make(map[string]*integrations.Subscription)
+	var order []string
+
+	for _, source := range sources {
+		sub := subscriptionFromSource(source)
+		if source.Category == "" {
+			top = append(top, sub)
+			continue
+		}
+		folder, ok := folders[source.Category]
+		if !ok {
+			folder = &integrations.Subscription{Title: source.Category}
+			folders[source.Category] = folder
+			order = append(order, source.Category)
+		}
+		folder.Children = append(folder.Children, sub)
+	}
+
+	for _, category := range order {
+		top = append(top, *folders[category])
+	}
+	return top
+}
+
+func subscriptionFromSource(source models.ContentSource) integrations.Subscription {
+	sub := integrations.Subscription{
+		Title:  source.Name,
+		XMLURL: source.Identifier,
+		Type:   "rss",
+	}
+	if len(source.Config) > 0 {
+		sub.Attrs = make(map[string]string, len(source.Config))
+		for k, v := range source.Config {
+			if s, ok := v.(string); ok {
+				sub.Attrs[k] = s
+			}
+		}
+	}
+	return sub
+}
@@ -7,8 +7,13 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/audit"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/kafka"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/process"
 )
 
 // ServiceStatus represents the overall service health status
@@ -22,8 +27,13 @@ type ServiceStatus struct {
 
 // HealthCheck holds dependencies for health checks
 type HealthCheck struct {
-	db       *database.DB
-	producer *kafka.Producer
+	db          *database.DB
+	producer    *kafka.Producer
+	kafkaTopics []string
+	runner      *process.Runner
+	redis       *redis.Client
+	upstream    []metrics.HealthChecker
+	auditLogger *audit.PostgresLogger
 }
 
 // NewHealthCheck creates a new health check handler
@@ -34,6 +44,47 @@ func NewHealthCheck(db *database.DB, producer *kafka.Producer) *HealthCheck {
 	}
 }
 
+// WithRunner attaches a process.Runner whose registered processes'
+// HealthCheck() results are folded into Readiness, so /ready reflects
+// the actual state of every subsystem the runner manages instead of the
+// fixed postgres/kafka pair.
+func (h *HealthCheck) WithRunner(runner *process.Runner) *HealthCheck {
+	h.runner = runner
+	return h
+}
+
+// WithKafkaTopics names the topics Readiness's Kafka check should probe
+// for an available, adequately-replicated leader on every partition
+// (see metrics.KafkaChecker), in place of merely checking that the
+// producer object is non-nil.
+func (h *HealthCheck) WithKafkaTopics(topics ...string) *HealthCheck {
+	h.kafkaTopics = topics
+	return h
+}
+
+// WithRedis folds a Redis ping into Readiness, e.g. when the response
+// cache backend is Redis rather than an in-process LRU.
+func (h *HealthCheck) WithRedis(client *redis.Client) *HealthCheck {
+	h.redis = client
+	return h
+}
+
+// WithAuditLogger folds an "audit_log" check into Readiness, reporting
+// degraded/unhealthy when auditLogger's write buffer backs up or its
+// writes have been failing (see audit.CreateAuditHealthChecker).
+func (h *HealthCheck) WithAuditLogger(auditLogger *audit.PostgresLogger) *HealthCheck {
+	h.auditLogger = auditLogger
+	return h
+}
+
+// WithUpstreamChecker registers an additional upstream dependency
+// checker (see metrics.NewServiceAvailabilityChecker, usually wrapped in
+// a metrics.CachingChecker) to fold into Readiness.
+func (h *HealthCheck) WithUpstreamChecker(checker metrics.HealthChecker) *HealthCheck {
+	h.upstream = append(h.upstream, checker)
+	return h
+}
+
 // HealthHandler returns basic service health status
 func HealthHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, ServiceStatus{
@@ -61,38 +112,76 @@ func ReadinessHandler(c *gin.Context) {
 	})
 }
 
-// Readiness checks if the service is ready to accept traffic with actual dependency checks
+// Readiness checks if the service is ready to accept traffic by
+// aggregating a metrics.CompositeChecker over postgres, Kafka, Redis,
+// every process the Runner manages, and any registered upstream service
+// checkers - rather than hard-coding a status for any one of them.
 func (h *HealthCheck) Readiness(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	checks := make(map[string]string)
-	allHealthy := true
+	var checkers []metrics.HealthChecker
 
-	// Check PostgreSQL connection
 	if h.db != nil {
-		if err := h.db.PingContext(ctx); err != nil {
-			checks["postgres"] = "unhealthy: " + err.Error()
-			allHealthy = false
+		checkers = append(checkers, metrics.CreateDatabaseHealthChecker(h.db.DB.DB))
+	}
+	if client := h.producer.Client(); client != nil {
+		// Real broker/topic metadata probe, not just "producer exists".
+		checkers = append(checkers, metrics.CreateKafkaHealthChecker(client, h.kafkaTopics...))
+	} else if h.producer != nil {
+		// Noop producer (e.g. SKIP_KAFKA_INIT in development): there's no
+		// client to probe, so report it plainly rather than claiming a
+		// health status we can't actually verify.
+		checkers = append(checkers, func(ctx context.Context) metrics.HealthCheckResult {
+			return metrics.HealthCheckResult{
+				Name:      "kafka",
+				Status:    metrics.HealthStatusHealthy,
+				Message:   "noop producer, no broker configured",
+				Timestamp: time.Now(),
+			}
+		})
+	}
+	if h.redis != nil {
+		checkers = append(checkers, metrics.CreateRedisHealthChecker(h.redis))
+	}
+	if h.runner != nil {
+		checkers = append(checkers, h.runner.AsHealthChecker("processes"))
+	}
+	if h.auditLogger != nil {
+		checkers = append(checkers, audit.CreateAuditHealthChecker(h.auditLogger, 0, 0))
+	}
+	checkers = append(checkers, h.upstream...)
+
+	// Run every checker once, then hand the already-computed results to a
+	// CompositeChecker (via closures that just replay them) so the
+	// overall ready/not_ready verdict uses the same severity rules as
+	// everywhere else CompositeChecker is used, instead of a hand-rolled
+	// allHealthy bool.
+	results := make([]metrics.HealthCheckResult, len(checkers))
+	replay := make([]metrics.HealthChecker, len(checkers))
+	for i, checker := range checkers {
+		result := checker(ctx)
+		results[i] = result
+		replay[i] = func(ctx context.Context) metrics.HealthCheckResult { return result }
+	}
+	overall := metrics.NewCompositeChecker("readiness", replay...).Check(ctx)
+
+	checks := make(map[string]string, len(results))
+	for _, result := range results {
+		if result.Status == metrics.HealthStatusHealthy {
+			checks[result.Name] = "healthy"
 		} else {
-			checks["postgres"] = "healthy"
+			checks[result.Name] = string(result.Status) + ": " + result.Message
 		}
-	} else {
-		checks["postgres"] = "not_configured"
 	}
-
-	// Check Kafka connection
-	if h.producer != nil {
-		// Kafka producer is considered healthy if it was initialized
-		// A more thorough check could send a test message to a health topic
-		checks["kafka"] = "healthy"
-	} else {
+	if len(checks) == 0 {
+		checks["postgres"] = "not_configured"
 		checks["kafka"] = "not_configured"
 	}
 
 	status := "ready"
 	httpStatus := http.StatusOK
-	if !allHealthy {
+	if overall.Status != metrics.HealthStatusHealthy {
 		status = "not_ready"
 		httpStatus = http.StatusServiceUnavailable
 	}
@@ -133,9 +222,10 @@ type Pagination struct {
 
 // ErrorResponse represents an API error response
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
-	Code    string `json:"code,omitempty"`
+	Error   string      `json:"error"`
+	Message string      `json:"message"`
+	Code    string      `json:"code,omitempty"`
+	Details interface{} `json:"details,omitempty"` // e.g. []validation.FieldError for schema validation failures
 }
 
 // SuccessResponse represents a successful API response
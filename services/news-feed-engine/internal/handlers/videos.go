@@ -2,6 +2,8 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -11,6 +13,8 @@ import (
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/kafka"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/middleware"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/ratelimit"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/transcript"
 )
 
 // VideoHandler handles video-related operations
@@ -18,14 +22,20 @@ type VideoHandler struct {
 	repo            *database.VideoRepository
 	kafkaProducer   *kafka.Producer
 	kafkaVideoTopic string
+	// limiter enforces per-tenant admission control on GenerateVideo and
+	// reports usage from GetVideoQueue. It is nil if Redis wasn't
+	// available at startup, in which case admission is unlimited - the
+	// same degrade-gracefully posture as kafka.NewNoopProducer.
+	limiter *ratelimit.VideoLimiter
 }
 
 // NewVideoHandler creates a new video handler
-func NewVideoHandler(repo *database.VideoRepository, kafkaProducer *kafka.Producer, videoTopic string) *VideoHandler {
+func NewVideoHandler(repo *database.VideoRepository, kafkaProducer *kafka.Producer, videoTopic string, limiter *ratelimit.VideoLimiter) *VideoHandler {
 	return &VideoHandler{
 		repo:            repo,
 		kafkaProducer:   kafkaProducer,
 		kafkaVideoTopic: videoTopic,
+		limiter:         limiter,
 	}
 }
 
@@ -53,13 +63,31 @@ func (h *VideoHandler) ListVideos(c *gin.Context) {
 		}
 	}
 
+	// Parse duration bounds if provided. Invalid values are ignored rather
+	// than rejected, consistent with content_id above.
+	var minDuration, maxDuration *int
+	if v, err := strconv.Atoi(c.Query("min_duration")); err == nil {
+		minDuration = &v
+	}
+	if v, err := strconv.Atoi(c.Query("max_duration")); err == nil {
+		maxDuration = &v
+	}
+
+	videoType := c.Query("type")
+	if videoType != "short" && videoType != "long" && videoType != "live" {
+		videoType = ""
+	}
+
 	opts := database.VideoListOptions{
-		Page:      page,
-		Limit:     limit,
-		Status:    status,
-		ContentID: contentID,
-		SortBy:    c.DefaultQuery("sort", "created_at"),
-		Order:     c.DefaultQuery("order", "desc"),
+		Page:        page,
+		Limit:       limit,
+		Status:      status,
+		ContentID:   contentID,
+		MinDuration: minDuration,
+		MaxDuration: maxDuration,
+		Type:        videoType,
+		SortBy:      c.DefaultQuery("sort", "created_at"),
+		Order:       c.DefaultQuery("order", "desc"),
 	}
 
 	videos, total, err := h.repo.List(c.Request.Context(), tenantID, opts)
@@ -121,20 +149,37 @@ func (h *VideoHandler) GetVideo(c *gin.Context) {
 	})
 }
 
-// GetVideoTranscript returns the transcript for a video
+// transcriptContentType and transcriptExtension give each supported
+// GetVideoTranscript format its Content-Type and attachment file
+// extension.
+var (
+	transcriptContentType = map[string]string{
+		"srt":  "application/x-subrip",
+		"vtt":  "text/vtt",
+		"json": "application/json",
+		"text": "text/plain",
+	}
+	transcriptExtension = map[string]string{
+		"srt":  "srt",
+		"vtt":  "vtt",
+		"json": "json",
+		"text": "txt",
+	}
+)
+
+// GetVideoTranscript returns a video's transcript rendered as SRT, WebVTT,
+// JSON, or plain text (format query param, default "text"), as a
+// downloadable attachment rather than wrapped in SuccessResponse. By
+// default word-level timing is preserved when present; ?merge_words=true
+// collapses it away, leaving only cue-level start/end/text.
 func (h *VideoHandler) GetVideoTranscript(c *gin.Context) {
 	tenantID := middleware.GetTenantID(c)
 	videoIDStr := c.Param("id")
 	format := c.DefaultQuery("format", "text")
+	mergeWords := c.Query("merge_words") == "true"
 
-	// Validate format
-	validFormats := map[string]bool{
-		"text": true,
-		"srt":  true,
-		"vtt":  true,
-		"json": true,
-	}
-	if !validFormats[format] {
+	contentType, ok := transcriptContentType[format]
+	if !ok {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error:   "bad_request",
 			Message: "invalid format, must be one of: text, srt, vtt, json",
@@ -152,7 +197,7 @@ func (h *VideoHandler) GetVideoTranscript(c *gin.Context) {
 		return
 	}
 
-	transcript, err := h.repo.GetTranscript(c.Request.Context(), tenantID, videoID)
+	cues, err := h.repo.GetTranscript(c.Request.Context(), tenantID, videoID)
 	if err != nil {
 		if err.Error() == "video not found" {
 			c.JSON(http.StatusNotFound, ErrorResponse{
@@ -168,16 +213,32 @@ func (h *VideoHandler) GetVideoTranscript(c *gin.Context) {
 		return
 	}
 
-	// TODO: Format conversion (SRT, VTT, JSON) - for now just return text
-	c.JSON(http.StatusOK, SuccessResponse{
-		Success: true,
-		Data: map[string]interface{}{
-			"video_id":   videoID.String(),
-			"tenant_id":  tenantID,
-			"format":     format,
-			"transcript": transcript,
-		},
-	})
+	if mergeWords {
+		cues = cues.MergeWords()
+	}
+
+	var body []byte
+	switch format {
+	case "srt":
+		body = []byte(transcript.RenderSRT(cues))
+	case "vtt":
+		body = []byte(transcript.RenderVTT(cues))
+	case "json":
+		body, err = transcript.RenderJSON(cues)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "encode_error",
+				Message: "Failed to render transcript",
+			})
+			return
+		}
+	default:
+		body = []byte(transcript.RenderText(cues))
+	}
+
+	filename := fmt.Sprintf("%s.%s", videoID.String(), transcriptExtension[format])
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Data(http.StatusOK, contentType, body)
 }
 
 // GenerateVideo queues a new video generation job
@@ -190,7 +251,7 @@ func (h *VideoHandler) GenerateVideo(c *gin.Context) {
 		VoiceID    string                 `json:"voice_id"`
 		AvatarID   string                 `json:"avatar_id"`
 		Resolution string                 `json:"resolution"`
-		Priority   int                    `json:"priority"`
+		Priority   *int                   `json:"priority"`
 		Options    map[string]interface{} `json:"options,omitempty"`
 	}
 
@@ -226,25 +287,59 @@ func (h *VideoHandler) GenerateVideo(c *gin.Context) {
 		return
 	}
 
-	// Default priority
-	if request.Priority == 0 {
-		request.Priority = 5
+	// Default priority when omitted
+	priority := 5
+	if request.Priority != nil {
+		priority = *request.Priority
 	}
+	if priority < 0 || priority > 9 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "invalid priority, must be between 0 and 9",
+			Code:    "INVALID_PRIORITY",
+		})
+		return
+	}
+
+	if h.limiter != nil {
+		if err := h.limiter.Reserve(c.Request.Context(), tenantID, priority); err != nil {
+			var budgetErr *ratelimit.BudgetError
+			if errors.As(err, &budgetErr) {
+				c.Header("Retry-After", strconv.Itoa(int(budgetErr.RetryAfter.Seconds())))
+				c.JSON(http.StatusTooManyRequests, ErrorResponse{
+					Error:   "budget_exceeded",
+					Message: budgetErr.Reason,
+					Code:    "TENANT_BUDGET_EXCEEDED",
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "ratelimit_error",
+				Message: "Failed to check tenant video budget",
+			})
+			return
+		}
+	}
+
+	// Route to the priority-specific topic so a worker can subscribe to
+	// only the priorities it serves.
+	topic := kafka.VideoPriorityTopic(h.kafkaVideoTopic, priority)
 
 	// Publish video generation job to Kafka
 	msg := kafka.VideoGenerationMessage{
-		TenantID:    tenantID,
-		ContentID:   request.ContentID,
-		TemplateID:  request.TemplateID,
-		VoiceID:     request.VoiceID,
-		AvatarID:    request.AvatarID,
-		Options:     request.Options,
-		Priority:    request.Priority,
-		RequestedAt: time.Now(),
+		TenantID:       tenantID,
+		ContentID:      request.ContentID,
+		TemplateID:     request.TemplateID,
+		VoiceID:        request.VoiceID,
+		AvatarID:       request.AvatarID,
+		Options:        request.Options,
+		Priority:       priority,
+		RequestedAt:    time.Now(),
+		IdempotencyKey: middleware.GetIdempotencyKey(c),
 	}
 
 	err := h.kafkaProducer.Publish(c.Request.Context(), kafka.Message{
-		Topic: h.kafkaVideoTopic,
+		Topic: topic,
 		Key:   tenantID + "/" + request.ContentID,
 		Value: msg,
 	})
@@ -264,13 +359,17 @@ func (h *VideoHandler) GenerateVideo(c *gin.Context) {
 			"tenant_id":   tenantID,
 			"content_id":  request.ContentID,
 			"template_id": request.TemplateID,
-			"priority":    request.Priority,
+			"priority":    priority,
+			"topic":       topic,
 			"status":      "queued",
 		},
 	})
 }
 
-// GetVideoQueue returns the current video generation queue status
+// GetVideoQueue returns the current video generation queue status: DB-backed
+// status counts from VideoRepository, plus (when rate limiting is enabled)
+// the tenant's in-flight count, per-priority queue depth, and remaining
+// quota from the VideoLimiter.
 func (h *VideoHandler) GetVideoQueue(c *gin.Context) {
 	tenantID := middleware.GetTenantID(c)
 
@@ -283,8 +382,112 @@ func (h *VideoHandler) GetVideoQueue(c *gin.Context) {
 		return
 	}
 
+	data := map[string]interface{}{
+		"tenant_id":  stats.TenantID,
+		"queued":     stats.Queued,
+		"processing": stats.Processing,
+		"completed":  stats.Completed,
+		"failed":     stats.Failed,
+	}
+
+	if h.limiter != nil {
+		status, err := h.limiter.Status(c.Request.Context(), tenantID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "ratelimit_error",
+				Message: "Failed to retrieve tenant video budget",
+			})
+			return
+		}
+		data["in_flight"] = status.InFlight
+		data["priority_depth"] = status.PriorityDepth
+		data["quota"] = status.Quota
+		data["remaining_this_hour"] = status.RemainingHour
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Data:    data,
+	})
+}
+
+// RetryVideo moves a failed video job back to pending for immediate
+// re-attempt, bypassing Fail's exponential backoff so an operator doesn't
+// have to wait out next_attempt_at.
+func (h *VideoHandler) RetryVideo(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	videoIDStr := c.Param("id")
+
+	videoID, err := uuid.Parse(videoIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid video ID format",
+		})
+		return
+	}
+
+	if err := h.repo.Retry(c.Request.Context(), tenantID, videoID); err != nil {
+		if err.Error() == "video not found" {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Video not found, or not in a failed state",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to retry video",
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, SuccessResponse{
 		Success: true,
-		Data:    stats,
+		Message: "video queued for retry",
+		Data: map[string]interface{}{
+			"video_id":  videoID,
+			"tenant_id": tenantID,
+		},
+	})
+}
+
+// CancelVideo stops a pending or in-progress video job so it is neither
+// claimed nor reaped back into the queue.
+func (h *VideoHandler) CancelVideo(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	videoIDStr := c.Param("id")
+
+	videoID, err := uuid.Parse(videoIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid video ID format",
+		})
+		return
+	}
+
+	if err := h.repo.Cancel(c.Request.Context(), tenantID, videoID); err != nil {
+		if err.Error() == "video not found" {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Video not found, or already completed/failed",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to cancel video",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Message: "video cancelled",
+		Data: map[string]interface{}{
+			"video_id":  videoID,
+			"tenant_id": tenantID,
+		},
 	})
 }
@@ -3,15 +3,21 @@ package handlers
 
 import (
 	"crypto/hmac"
+	"crypto/sha1"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"encoding/xml"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/integrations"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/integrations/videosource"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/kafka"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/middleware"
 )
@@ -20,18 +26,47 @@ import (
 type WebhookHandler struct {
 	producer              *kafka.Producer
 	rawTopic              string
+	youtube               *integrations.YouTubeIntegration
 	youtubeWebhookSecret  string
 	twitterConsumerSecret string
+	mastodonWebhookSecret string
+
+	// videoSource hydrates full video metadata for a YouTubeWebhook push
+	// notification. It defaults to youtube itself (nil if youtube is
+	// nil); WithVideoSource overrides it with a
+	// videosource.FallbackSource so a Data API quota exhaustion doesn't
+	// drop the metadata for an incoming notification. PubSubHubbub lease
+	// confirmation always goes through youtube directly, since neither
+	// Invidious nor Piped offer push notifications.
+	videoSource videosource.VideoSource
 }
 
-// NewWebhookHandler creates a new webhook handler
-func NewWebhookHandler(producer *kafka.Producer, rawTopic, youtubeSecret, twitterSecret string) *WebhookHandler {
-	return &WebhookHandler{
+// NewWebhookHandler creates a new webhook handler. youtube may be nil
+// (e.g. no YOUTUBE_API_KEY configured), in which case YouTubeWebhook
+// still ingests notifications but skips hydrating full video metadata
+// and PubSubHubbub lease confirmation.
+func NewWebhookHandler(producer *kafka.Producer, rawTopic string, youtube *integrations.YouTubeIntegration, youtubeSecret, twitterSecret, mastodonSecret string) *WebhookHandler {
+	h := &WebhookHandler{
 		producer:              producer,
 		rawTopic:              rawTopic,
+		youtube:               youtube,
 		youtubeWebhookSecret:  youtubeSecret,
 		twitterConsumerSecret: twitterSecret,
+		mastodonWebhookSecret: mastodonSecret,
 	}
+	if youtube != nil {
+		h.videoSource = youtube
+	}
+	return h
+}
+
+// WithVideoSource overrides the video source YouTubeWebhook uses to
+// hydrate a push notification's full video metadata, e.g. a
+// videosource.FallbackSource chaining Invidious/Piped after the Data
+// API.
+func (h *WebhookHandler) WithVideoSource(source videosource.VideoSource) *WebhookHandler {
+	h.videoSource = source
+	return h
 }
 
 // AtomFeed represents a YouTube PubSubHubbub Atom feed
@@ -72,6 +107,16 @@ func (h *WebhookHandler) YouTubeWebhook(c *gin.Context) {
 		mode := c.Query("hub.mode")
 
 		if mode == "subscribe" || mode == "unsubscribe" {
+			// On a subscribe verification, the hub reports the lease it
+			// actually granted; record it so the renewer doesn't rely on
+			// the lease SubscribeChannel merely requested.
+			if mode == "subscribe" && h.youtube != nil {
+				if channelID := channelIDFromTopic(c.Query("hub.topic")); channelID != "" {
+					if leaseSeconds, err := strconv.Atoi(c.Query("hub.lease_seconds")); err == nil {
+						_ = h.youtube.ConfirmSubscription(c.Request.Context(), channelID, leaseSeconds)
+					}
+				}
+			}
 			c.String(http.StatusOK, challenge)
 			return
 		}
@@ -92,10 +137,12 @@ func (h *WebhookHandler) YouTubeWebhook(c *gin.Context) {
 		return
 	}
 
-	// Verify HMAC signature if secret is configured
+	// Verify HMAC-SHA1 signature if secret is configured. PubSubHubbub
+	// hubs sign X-Hub-Signature with SHA1, not the SHA256 this service's
+	// other webhooks use (see verifyHMACSignature).
 	if h.youtubeWebhookSecret != "" {
 		signature := c.GetHeader("X-Hub-Signature")
-		if !h.verifyHMACSignature(body, signature, h.youtubeWebhookSecret) {
+		if !verifyHMACSHA1Signature(body, signature, h.youtubeWebhookSecret) {
 			c.JSON(http.StatusUnauthorized, ErrorResponse{
 				Error:   "🔐 YouTube webhook signature verification failed",
 				Message: "The request signature doesn't match our records. Please verify your webhook secret is correct and try again",
@@ -117,21 +164,34 @@ func (h *WebhookHandler) YouTubeWebhook(c *gin.Context) {
 	// Process each entry and publish to Kafka
 	published := 0
 	for _, entry := range feed.Entries {
+		metadata := map[string]interface{}{
+			"video_id":   entry.VideoID,
+			"channel_id": entry.ChannelID,
+			"title":      entry.Title,
+			"author":     entry.Author.Name,
+		}
+
+		// Hydrate full metadata via videoSource; the Atom push payload
+		// itself only carries the video/channel IDs and a title.
+		if h.videoSource != nil {
+			if details, err := h.videoSource.GetVideoDetails(c.Request.Context(), entry.VideoID); err == nil {
+				metadata["description"] = details.Description
+				metadata["duration"] = details.Duration
+				metadata["view_count"] = details.ViewCount
+				metadata["tags"] = details.Tags
+			}
+		}
+
 		msg := kafka.Message{
 			Topic: h.rawTopic,
 			Key:   entry.VideoID,
 			Value: kafka.ContentIngestionMessage{
-				TenantID:   tenantID,
-				SourceType: "youtube",
-				SourceID:   entry.ChannelID,
-				URL:        entry.Link.Href,
-				Priority:   1,
-				Metadata: map[string]interface{}{
-					"video_id":   entry.VideoID,
-					"channel_id": entry.ChannelID,
-					"title":      entry.Title,
-					"author":     entry.Author.Name,
-				},
+				TenantID:    tenantID,
+				SourceType:  "youtube",
+				SourceID:    entry.ChannelID,
+				URL:         entry.Link.Href,
+				Priority:    1,
+				Metadata:    metadata,
 				RequestedAt: time.Now(),
 			},
 			Timestamp: time.Now(),
@@ -354,6 +414,123 @@ func (h *WebhookHandler) RedditWebhook(c *gin.Context) {
 	})
 }
 
+// ActivityPubActivity represents an inbound ActivityPub delivery to our
+// inbox, trimmed to the fields needed to ingest federated posts (Mastodon
+// and other ActivityPub servers deliver "Create" activities wrapping a
+// "Note" object for new statuses).
+type ActivityPubActivity struct {
+	Context string            `json:"@context"`
+	ID      string            `json:"id"`
+	Type    string            `json:"type"`
+	Actor   string            `json:"actor"`
+	Object  ActivityPubObject `json:"object"`
+}
+
+// ActivityPubObject represents the object embedded in an ActivityPub
+// activity, typically a Note.
+type ActivityPubObject struct {
+	ID           string   `json:"id"`
+	Type         string   `json:"type"`
+	AttributedTo string   `json:"attributedTo"`
+	Content      string   `json:"content"`
+	URL          string   `json:"url"`
+	Published    string   `json:"published"`
+	To           []string `json:"to"`
+}
+
+// MastodonWebhook handles inbound ActivityPub deliveries to our shared
+// inbox, so federated posts from followed Mastodon (and other
+// ActivityPub-speaking) accounts can be ingested the same way platform
+// webhooks are.
+func (h *WebhookHandler) MastodonWebhook(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "failed to read request body",
+		})
+		return
+	}
+
+	// ActivityPub deliveries are authenticated via HTTP Signatures in
+	// production; until that verifier lands, fall back to a shared-secret
+	// HMAC header so the inbox isn't left wide open.
+	if h.mastodonWebhookSecret != "" {
+		signature := c.GetHeader("X-Hub-Signature-256")
+		if !h.verifyHMACSignature(body, signature, h.mastodonWebhookSecret) {
+			c.JSON(http.StatusUnauthorized, ErrorResponse{
+				Error:   "🔐 ActivityPub delivery signature verification failed",
+				Message: "The request signature doesn't match our records. Please verify your webhook secret is correct and try again",
+			})
+			return
+		}
+	}
+
+	var activity ActivityPubActivity
+	if err := json.Unmarshal(body, &activity); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "failed to parse ActivityPub activity",
+		})
+		return
+	}
+
+	// Only "Create" activities wrapping a "Note" represent new federated
+	// posts; other activity types (Follow, Like, Announce, Delete, ...)
+	// are acknowledged but not ingested as content.
+	if activity.Type != "Create" || activity.Object.Type != "Note" {
+		c.JSON(http.StatusOK, SuccessResponse{
+			Success: true,
+			Message: "activity acknowledged",
+			Data: map[string]interface{}{
+				"platform": "mastodon",
+				"type":     activity.Type,
+				"ingested": false,
+			},
+		})
+		return
+	}
+
+	msg := kafka.Message{
+		Topic: h.rawTopic,
+		Key:   activity.Object.ID,
+		Value: kafka.ContentIngestionMessage{
+			TenantID:   tenantID,
+			SourceType: "mastodon",
+			SourceID:   activity.Object.AttributedTo,
+			URL:        activity.Object.URL,
+			Priority:   1,
+			Metadata: map[string]interface{}{
+				"activity_id": activity.ID,
+				"actor":       activity.Actor,
+				"content":     activity.Object.Content,
+				"published":   activity.Object.Published,
+			},
+			RequestedAt: time.Now(),
+		},
+		Timestamp: time.Now(),
+	}
+
+	if err := h.producer.Publish(c.Request.Context(), msg); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "publish_error",
+			Message: "failed to publish event",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Message: "activity processed",
+		Data: map[string]interface{}{
+			"platform": "mastodon",
+			"note_id":  activity.Object.ID,
+		},
+	})
+}
+
 // verifyHMACSignature verifies HMAC-SHA256 signature
 func (h *WebhookHandler) verifyHMACSignature(body []byte, signature, secret string) bool {
 	if signature == "" {
@@ -369,3 +546,30 @@ func (h *WebhookHandler) verifyHMACSignature(body []byte, signature, secret stri
 
 	return hmac.Equal([]byte(signature), []byte(expectedSignature))
 }
+
+// verifyHMACSHA1Signature verifies the HMAC-SHA1 signature PubSubHubbub
+// hubs send in X-Hub-Signature.
+func verifyHMACSHA1Signature(body []byte, signature, secret string) bool {
+	if signature == "" {
+		return false
+	}
+
+	signature = strings.TrimPrefix(signature, "sha1=")
+
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	expectedSignature := hex.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(signature), []byte(expectedSignature))
+}
+
+// channelIDFromTopic extracts the channel_id query parameter from a
+// PubSubHubbub hub.topic URL (e.g.
+// "https://www.youtube.com/xml/feeds/videos.xml?channel_id=UC...").
+func channelIDFromTopic(topic string) string {
+	parsed, err := url.Parse(topic)
+	if err != nil {
+		return ""
+	}
+	return parsed.Query().Get("channel_id")
+}
@@ -3,21 +3,29 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/kafka"
 	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/middleware"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/validation"
 )
 
 // WhitelabelHandler handles white-label configuration operations
 type WhitelabelHandler struct {
-	configRepo *database.ConfigRepository
+	configRepo      *database.ConfigRepository
+	kafkaProducer   *kafka.Producer
+	whitelabelTopic string
 }
 
 // NewWhitelabelHandler creates a new whitelabel handler
-func NewWhitelabelHandler(configRepo *database.ConfigRepository) *WhitelabelHandler {
+func NewWhitelabelHandler(configRepo *database.ConfigRepository, kafkaProducer *kafka.Producer, whitelabelTopic string) *WhitelabelHandler {
 	return &WhitelabelHandler{
-		configRepo: configRepo,
+		configRepo:      configRepo,
+		kafkaProducer:   kafkaProducer,
+		whitelabelTopic: whitelabelTopic,
 	}
 }
 
@@ -97,38 +105,14 @@ func (h *WhitelabelHandler) UpdateWhitelabelConfig(c *gin.Context) {
 	delete(request, "created_at")
 	delete(request, "updated_at")
 
-	// Validate color formats if provided
-	if primaryColor, ok := request["primary_color"].(string); ok && primaryColor != "" {
-		if !isValidColor(primaryColor) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "bad_request",
-				Message: "invalid primary_color format",
-				Code:    "INVALID_COLOR",
-			})
-			return
-		}
-	}
-
-	if secondaryColor, ok := request["secondary_color"].(string); ok && secondaryColor != "" {
-		if !isValidColor(secondaryColor) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "bad_request",
-				Message: "invalid secondary_color format",
-				Code:    "INVALID_COLOR",
-			})
-			return
-		}
-	}
-
-	if accentColor, ok := request["accent_color"].(string); ok && accentColor != "" {
-		if !isValidColor(accentColor) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "bad_request",
-				Message: "invalid accent_color format",
-				Code:    "INVALID_COLOR",
-			})
-			return
-		}
+	if fieldErrors := validation.ValidateWhitelabelConfig(request); len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation_failed",
+			Message: "one or more fields failed schema validation",
+			Code:    "SCHEMA_VALIDATION",
+			Details: fieldErrors,
+		})
+		return
 	}
 
 	if len(request) == 0 {
@@ -140,7 +124,8 @@ func (h *WhitelabelHandler) UpdateWhitelabelConfig(c *gin.Context) {
 	}
 
 	// Update configuration in database
-	if err := h.configRepo.Update(c.Request.Context(), tenantID, request); err != nil {
+	version, err := h.configRepo.Update(c.Request.Context(), tenantID, request, middleware.GetUserID(c))
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "database_error",
 			Message: "failed to update configuration",
@@ -148,28 +133,187 @@ func (h *WhitelabelHandler) UpdateWhitelabelConfig(c *gin.Context) {
 		return
 	}
 
+	h.publishConfigChanged(c, tenantID, version.Version)
+
 	c.JSON(http.StatusOK, SuccessResponse{
 		Success: true,
 		Message: "white-label configuration updated",
 		Data: map[string]interface{}{
 			"tenant_id": tenantID,
+			"version":   version.Version,
 			"updated":   request,
 		},
 	})
 }
 
-// isValidColor validates a hex color string
-func isValidColor(color string) bool {
-	if len(color) != 7 && len(color) != 4 {
-		return false
+// GetWhitelabelHistory returns a paginated, newest-first list of
+// configuration version summaries for a tenant.
+func (h *WhitelabelHandler) GetWhitelabelHistory(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	versions, total, err := h.configRepo.ListVersions(c.Request.Context(), tenantID, page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "failed to retrieve configuration history",
+		})
+		return
+	}
+
+	totalPages := (total + limit - 1) / limit
+
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Data: versions,
+		Pagination: Pagination{
+			Page:       page,
+			Limit:      limit,
+			TotalItems: int64(total),
+			TotalPages: totalPages,
+			HasMore:    page < totalPages,
+		},
+	})
+}
+
+// GetWhitelabelVersion returns the full configuration snapshot recorded at
+// a specific version.
+func (h *WhitelabelHandler) GetWhitelabelVersion(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "version must be an integer",
+		})
+		return
+	}
+
+	cv, err := h.configRepo.GetVersion(c.Request.Context(), tenantID, version)
+	if err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "not_found",
+			Message: "configuration version not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Data:    cv,
+	})
+}
+
+// GetWhitelabelDiff returns the RFC 6902 JSON Patch between two
+// configuration versions, selected with ?from=X&to=Y.
+func (h *WhitelabelHandler) GetWhitelabelDiff(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+
+	from, err := strconv.Atoi(c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "from must be an integer version number",
+		})
+		return
+	}
+	to, err := strconv.Atoi(c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "to must be an integer version number",
+		})
+		return
+	}
+
+	patch, err := h.configRepo.Diff(c.Request.Context(), tenantID, from, to)
+	if err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "not_found",
+			Message: "failed to diff configuration versions: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Data: map[string]interface{}{
+			"tenant_id": tenantID,
+			"from":      from,
+			"to":        to,
+			"patch":     patch,
+		},
+	})
+}
+
+// RollbackWhitelabelConfig creates a new configuration version equal to
+// the snapshot recorded at :version.
+func (h *WhitelabelHandler) RollbackWhitelabelConfig(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+
+	target, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "version must be an integer",
+		})
+		return
 	}
-	if color[0] != '#' {
-		return false
+
+	version, err := h.configRepo.Rollback(c.Request.Context(), tenantID, target, middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "database_error",
+			Message: "failed to roll back configuration: " + err.Error(),
+		})
+		return
 	}
-	for _, c := range color[1:] {
-		if !((c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')) {
-			return false
-		}
+
+	h.publishConfigChanged(c, tenantID, version.Version)
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Message: "white-label configuration rolled back",
+		Data: map[string]interface{}{
+			"tenant_id":      tenantID,
+			"rolled_back_to": target,
+			"version":        version.Version,
+		},
+	})
+}
+
+// publishConfigChanged notifies downstream renderers that a tenant's
+// white-label branding changed. Best-effort: a publish failure is logged
+// by the underlying producer but never fails the HTTP request, since the
+// configuration change has already been durably committed.
+func (h *WhitelabelHandler) publishConfigChanged(c *gin.Context, tenantID string, version int) {
+	if h.kafkaProducer == nil || h.whitelabelTopic == "" {
+		return
 	}
-	return true
+	_ = h.kafkaProducer.Publish(c.Request.Context(), kafka.Message{
+		Topic: h.whitelabelTopic,
+		Key:   tenantID,
+		Value: kafka.WhitelabelConfigChangedMessage{
+			TenantID:  tenantID,
+			Version:   version,
+			UpdatedBy: middleware.GetUserID(c),
+			ChangedAt: time.Now(),
+		},
+	})
+}
+
+// GetWhitelabelSchema returns the JSON Schema document describing the
+// fields PUT /whitelabel accepts (see validation.WhitelabelConfigSchema),
+// so an admin UI can render an edit form directly from it instead of
+// hand-coding one per field.
+func (h *WhitelabelHandler) GetWhitelabelSchema(c *gin.Context) {
+	c.Data(http.StatusOK, "application/schema+json", []byte(validation.WhitelabelConfigSchema))
 }
@@ -0,0 +1,242 @@
+// Package healthhttp serves a Registry of health checks over HTTP the
+// way metrics.HealthCheckHandler serves a plain
+// metrics.HealthCheckRegistry, but with tag-scoped readiness and a
+// content-negotiated full report (JSON, human text, or Prometheus
+// exposition) instead of JSON-only.
+package healthhttp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
+)
+
+// prometheusContentType is the exposition format content type expected
+// by Prometheus scrapers (kept in sync with the unexported constant of
+// the same name in vendor's pkg/metrics/prometheus.go).
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Options configures Handler's three endpoints.
+type Options struct {
+	// Liveness gates Healthz; nil means always alive.
+	Liveness *metrics.LivenessChecker
+	// Readiness gates Readyz in addition to the checks selected by
+	// ReadinessTags; nil means no extra gate.
+	Readiness *metrics.ReadinessChecker
+	// ReadinessTags selects the subset of registry checks Readyz runs;
+	// empty means every registered check.
+	ReadinessTags []string
+}
+
+// Handler serves liveness, readiness, and full health reporting over a
+// Registry.
+type Handler struct {
+	registry *Registry
+	opts     Options
+}
+
+// New creates a Handler over registry.
+func New(registry *Registry, opts Options) *Handler {
+	return &Handler{registry: registry, opts: opts}
+}
+
+// Healthz is the liveness probe: it never runs registry checks, only
+// opts.Liveness, so it stays cheap and reliable even when a downstream
+// dependency (e.g. the database) is the thing that's actually down.
+func (h *Handler) Healthz(c *gin.Context) {
+	alive := true
+	if h.opts.Liveness != nil {
+		alive = h.opts.Liveness.IsAlive(c.Request.Context())
+	}
+	if !alive {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unhealthy"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "alive"})
+}
+
+// Readyz is the readiness probe: it runs opts.Readiness (if set) plus
+// every registry check tagged with one of opts.ReadinessTags (or every
+// check, if ReadinessTags is empty), so callers can scope readiness to
+// e.g. "critical" without pulling in slower, non-blocking checks.
+func (h *Handler) Readyz(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if h.opts.Readiness != nil && !h.opts.Readiness.IsReady(ctx) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "not_ready"})
+		return
+	}
+
+	results := h.registry.checkTagged(ctx, h.opts.ReadinessTags)
+	status := overallStatus(results)
+
+	checks := make(map[string]string, len(results))
+	for _, result := range results {
+		checks[result.Name] = string(result.Status)
+	}
+
+	body := gin.H{"status": "ready", "checks": checks}
+	httpStatus := http.StatusOK
+	if status != metrics.HealthStatusHealthy {
+		body["status"] = "not_ready"
+		httpStatus = http.StatusServiceUnavailable
+	}
+	c.JSON(httpStatus, body)
+}
+
+// Health serves the full report, content-negotiated from the request's
+// Accept header (or an explicit ?format= override): application/json
+// (the default, a structured metrics.HealthReport), text/plain (a human
+// summary), or the Prometheus/OpenMetrics text exposition format. An
+// ETag derived from each check's name/status/message short-circuits to
+// 304 Not Modified on a matching If-None-Match, so orchestrators polling
+// /health on a tight interval don't pay for a full body every hit.
+func (h *Handler) Health(c *gin.Context) {
+	ctx := c.Request.Context()
+	results := h.registry.checkAll(ctx)
+
+	etag := etagFor(results)
+	c.Header("ETag", etag)
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	report := &metrics.HealthReport{
+		Status:    overallStatus(results),
+		Timestamp: time.Now(),
+		Checks:    resultsToMap(results),
+	}
+
+	switch negotiate(c.GetHeader("Accept"), c.Query("format")) {
+	case formatPrometheus:
+		c.Data(http.StatusOK, prometheusContentType, []byte(renderPrometheus(results)))
+	case formatText:
+		c.String(http.StatusOK, renderText(report))
+	default:
+		httpStatus := http.StatusOK
+		if report.Status == metrics.HealthStatusUnhealthy {
+			httpStatus = http.StatusServiceUnavailable
+		}
+		c.JSON(httpStatus, report)
+	}
+}
+
+type format int
+
+const (
+	formatJSON format = iota
+	formatText
+	formatPrometheus
+)
+
+// negotiate picks a response format, preferring an explicit
+// ?format=json|text|prometheus query param over the Accept header.
+func negotiate(accept, queryFormat string) format {
+	switch strings.ToLower(queryFormat) {
+	case "prometheus", "openmetrics":
+		return formatPrometheus
+	case "text":
+		return formatText
+	case "json":
+		return formatJSON
+	}
+
+	accept = strings.ToLower(accept)
+	switch {
+	case strings.Contains(accept, "version=0.0.4"), strings.Contains(accept, "openmetrics-text"):
+		return formatPrometheus
+	case strings.Contains(accept, "application/json"):
+		return formatJSON
+	case strings.Contains(accept, "text/plain"):
+		return formatText
+	default:
+		return formatJSON
+	}
+}
+
+// overallStatus derives the same unhealthy > degraded > healthy verdict
+// as metrics.HealthCheckRegistry.OverallStatus, from results already
+// computed by the caller rather than re-running every check.
+func overallStatus(results []metrics.HealthCheckResult) metrics.HealthStatus {
+	status := metrics.HealthStatusHealthy
+	for _, result := range results {
+		if result.Status == metrics.HealthStatusUnhealthy {
+			return metrics.HealthStatusUnhealthy
+		}
+		if result.Status == metrics.HealthStatusDegraded {
+			status = metrics.HealthStatusDegraded
+		}
+	}
+	return status
+}
+
+func resultsToMap(results []metrics.HealthCheckResult) map[string]metrics.HealthCheckResult {
+	out := make(map[string]metrics.HealthCheckResult, len(results))
+	for _, result := range results {
+		out[result.Name] = result
+	}
+	return out
+}
+
+// etagFor hashes each check's name/status/message - deliberately
+// excluding Timestamp/Duration, which change on every run even when
+// nothing of substance did - so identical health produces an identical
+// ETag and repeat polls can short-circuit on If-None-Match.
+func etagFor(results []metrics.HealthCheckResult) string {
+	var b strings.Builder
+	for _, result := range results {
+		fmt.Fprintf(&b, "%s:%s:%s;", result.Name, result.Status, result.Message)
+	}
+	sum := sha256.Sum256([]byte(b.String()))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+func renderText(report *metrics.HealthReport) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "status: %s\n", report.Status)
+
+	names := make([]string, 0, len(report.Checks))
+	for name := range report.Checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		result := report.Checks[name]
+		fmt.Fprintf(&b, "%s: %s", name, result.Status)
+		if result.Message != "" {
+			fmt.Fprintf(&b, " (%s)", result.Message)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func renderPrometheus(results []metrics.HealthCheckResult) string {
+	var b strings.Builder
+	b.WriteString("# HELP health_check_status Health check status (1 = healthy, 0 = degraded or unhealthy)\n")
+	b.WriteString("# TYPE health_check_status gauge\n")
+	for _, result := range results {
+		value := 0
+		if result.Status == metrics.HealthStatusHealthy {
+			value = 1
+		}
+		fmt.Fprintf(&b, "health_check_status{name=%q,status=%q} %d\n", result.Name, result.Status, value)
+	}
+
+	b.WriteString("# HELP health_check_duration_seconds Duration of each health check's most recent run\n")
+	b.WriteString("# TYPE health_check_duration_seconds gauge\n")
+	for _, result := range results {
+		fmt.Fprintf(&b, "health_check_duration_seconds{name=%q} %f\n", result.Name, result.Duration.Seconds())
+	}
+
+	return b.String()
+}
@@ -0,0 +1,112 @@
+package healthhttp
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
+)
+
+// Registry wraps a metrics.HealthCheckRegistry with per-check tags and
+// optional TTL memoization, so Handler's /readyz can be scoped to a
+// caller-selected subset of checks (e.g. "critical") instead of always
+// running every registered check, and expensive checks aren't re-run on
+// every orchestrator probe.
+type Registry struct {
+	inner *metrics.HealthCheckRegistry
+
+	mu   sync.RWMutex
+	tags map[string][]string
+}
+
+// NewRegistry wraps inner, or a fresh metrics.HealthCheckRegistry if
+// inner is nil.
+func NewRegistry(inner *metrics.HealthCheckRegistry) *Registry {
+	if inner == nil {
+		inner = metrics.NewHealthCheckRegistry()
+	}
+	return &Registry{inner: inner, tags: make(map[string][]string)}
+}
+
+// Register registers checker under name, tagged for tag-scoped readiness
+// queries. Pass ttl > 0 to memoize checker's result for that long (see
+// metrics.CreateCachingHealthChecker) - useful for checks /readyz would
+// otherwise re-run on every hit, such as a database ping under a tight
+// orchestrator probe interval.
+func (r *Registry) Register(name string, checker metrics.HealthChecker, ttl time.Duration, tags ...string) error {
+	if ttl > 0 {
+		checker = metrics.CreateCachingHealthChecker(name, checker, ttl)
+	}
+	if err := r.inner.Register(name, checker); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.tags[name] = tags
+	r.mu.Unlock()
+	return nil
+}
+
+// Tag adds tags to an already-registered check - e.g. one registered
+// directly on the underlying metrics.HealthCheckRegistry before it was
+// wrapped here - without re-registering its checker.
+func (r *Registry) Tag(name string, tags ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tags[name] = append(r.tags[name], tags...)
+}
+
+// checkAll runs every registered check, sorted by name.
+func (r *Registry) checkAll(ctx context.Context) []metrics.HealthCheckResult {
+	resultsByName := r.inner.CheckAll(ctx)
+
+	names := make([]string, 0, len(resultsByName))
+	for name := range resultsByName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make([]metrics.HealthCheckResult, len(names))
+	for i, name := range names {
+		out[i] = resultsByName[name]
+	}
+	return out
+}
+
+// checkTagged runs every check tagged with at least one of tags (or
+// every registered check, if tags is empty), sorted by name.
+func (r *Registry) checkTagged(ctx context.Context, tags []string) []metrics.HealthCheckResult {
+	if len(tags) == 0 {
+		return r.checkAll(ctx)
+	}
+
+	want := make(map[string]struct{}, len(tags))
+	for _, t := range tags {
+		want[t] = struct{}{}
+	}
+
+	r.mu.RLock()
+	var names []string
+	for name, checkTags := range r.tags {
+		for _, t := range checkTags {
+			if _, ok := want[t]; ok {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	r.mu.RUnlock()
+	sort.Strings(names)
+
+	out := make([]metrics.HealthCheckResult, 0, len(names))
+	for _, name := range names {
+		result, err := r.inner.CheckOne(ctx, name)
+		if err != nil {
+			continue
+		}
+		out = append(out, result)
+	}
+	return out
+}
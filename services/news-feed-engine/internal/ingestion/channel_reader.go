@@ -0,0 +1,197 @@
+// Package ingestion implements resumable historical backfill: walking a
+// source's full history, page by page, rather than the single current-
+// window fetch TriggerIngestion otherwise performs.
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/integrations"
+)
+
+// DiscoveredItem is one piece of content found while paging through a
+// source's history, enough to enqueue a per-item ingestion message.
+type DiscoveredItem struct {
+	SourceID    string
+	URL         string
+	Title       string
+	PublishedAt time.Time
+}
+
+// ChannelReader pages through one platform's history for a source. An
+// empty pageToken means "start from the beginning"; a returned
+// nextPageToken of "" means the reader has reached the end (or, for
+// platforms with no true cursor, that it has covered everything newer
+// than `from`).
+type ChannelReader interface {
+	Platform() string
+	FetchPage(ctx context.Context, source, pageToken string, from time.Time) (items []DiscoveredItem, nextPageToken string, err error)
+}
+
+// YouTubeChannelReader pages a channel's uploads playlist via the Data
+// API's pageToken.
+type YouTubeChannelReader struct {
+	yt *integrations.YouTubeIntegration
+}
+
+// NewYouTubeChannelReader creates a reader backed by yt.
+func NewYouTubeChannelReader(yt *integrations.YouTubeIntegration) *YouTubeChannelReader {
+	return &YouTubeChannelReader{yt: yt}
+}
+
+// Platform identifies this reader.
+func (r *YouTubeChannelReader) Platform() string { return "youtube" }
+
+// FetchPage returns one page of channel's uploads, filtered to videos
+// published at or after from.
+func (r *YouTubeChannelReader) FetchPage(ctx context.Context, channelID, pageToken string, from time.Time) ([]DiscoveredItem, string, error) {
+	videos, next, err := r.yt.GetChannelVideosPage(ctx, channelID, pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	items := make([]DiscoveredItem, 0, len(videos))
+	for _, v := range videos {
+		if !from.IsZero() && v.PublishedAt.Before(from) {
+			continue
+		}
+		items = append(items, DiscoveredItem{
+			SourceID:    v.ID,
+			URL:         fmt.Sprintf("https://www.youtube.com/watch?v=%s", v.ID),
+			Title:       v.Title,
+			PublishedAt: v.PublishedAt,
+		})
+	}
+	return items, next, nil
+}
+
+// RSSChannelReader windows an RSS/Atom feed by pubDate since feeds don't
+// expose a true pagination cursor: the "page token" it hands back is the
+// timestamp of the oldest item seen so far, re-used as the `from` floor
+// on the next call, and FetchPage signals completion once a full fetch
+// returns nothing newer than that floor.
+type RSSChannelReader struct {
+	rss *integrations.RSSIntegration
+}
+
+// NewRSSChannelReader creates a reader backed by rss.
+func NewRSSChannelReader(rss *integrations.RSSIntegration) *RSSChannelReader {
+	return &RSSChannelReader{rss: rss}
+}
+
+// Platform identifies this reader.
+func (r *RSSChannelReader) Platform() string { return "rss" }
+
+// FetchPage fetches the full feed and returns entries within [from, pageToken)
+// pubDate window, oldest-first cursor semantics as described on the type.
+func (r *RSSChannelReader) FetchPage(ctx context.Context, feedURL, pageToken string, from time.Time) ([]DiscoveredItem, string, error) {
+	feed, err := r.rss.FetchFeed(ctx, feedURL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var upperBound time.Time
+	if pageToken != "" {
+		upperBound, _ = time.Parse(time.RFC3339, pageToken)
+	}
+
+	var items []DiscoveredItem
+	oldest := time.Now()
+	for _, entry := range feed.Items {
+		if !from.IsZero() && entry.PublishedAt.Before(from) {
+			continue
+		}
+		if !upperBound.IsZero() && !entry.PublishedAt.Before(upperBound) {
+			continue
+		}
+		items = append(items, DiscoveredItem{
+			SourceID:    entry.ID,
+			URL:         entry.Link,
+			Title:       entry.Title,
+			PublishedAt: entry.PublishedAt,
+		})
+		if entry.PublishedAt.Before(oldest) {
+			oldest = entry.PublishedAt
+		}
+	}
+
+	if len(items) == 0 {
+		return nil, "", nil // caught up to `from`: no next page
+	}
+	return items, oldest.Format(time.RFC3339), nil
+}
+
+// RedditChannelReader walks a subreddit/user listing using Reddit's
+// `after` fullname cursor.
+type RedditChannelReader struct {
+	fetchListing func(ctx context.Context, source, after string) (items []DiscoveredItem, nextAfter string, err error)
+}
+
+// NewRedditChannelReader creates a reader that calls fetchListing for each
+// page. The actual Reddit API client lives where the rest of this
+// project's platform credentials are configured; fetchListing is injected
+// so this package doesn't need its own HTTP/OAuth plumbing.
+func NewRedditChannelReader(fetchListing func(ctx context.Context, source, after string) ([]DiscoveredItem, string, error)) *RedditChannelReader {
+	return &RedditChannelReader{fetchListing: fetchListing}
+}
+
+// Platform identifies this reader.
+func (r *RedditChannelReader) Platform() string { return "reddit" }
+
+// FetchPage delegates to fetchListing, filtering out items older than from.
+func (r *RedditChannelReader) FetchPage(ctx context.Context, source, after string, from time.Time) ([]DiscoveredItem, string, error) {
+	items, next, err := r.fetchListing(ctx, source, after)
+	if err != nil {
+		return nil, "", err
+	}
+	filtered := items[:0]
+	for _, item := range items {
+		if from.IsZero() || !item.PublishedAt.Before(from) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered, next, nil
+}
+
+// TwitterChannelReader pages a user's tweets via Twitter's opaque
+// pagination_token/next_token cursor.
+type TwitterChannelReader struct {
+	tw *integrations.TwitterIntegration
+}
+
+// NewTwitterChannelReader creates a reader backed by tw.
+func NewTwitterChannelReader(tw *integrations.TwitterIntegration) *TwitterChannelReader {
+	return &TwitterChannelReader{tw: tw}
+}
+
+// Platform identifies this reader.
+func (r *TwitterChannelReader) Platform() string { return "twitter" }
+
+// FetchPage returns one page of a user's tweets starting at from. Twitter
+// pagination is layered onto GetUserTweets in a follow-up once that call
+// exposes next_token; for now a single page covering everything since
+// `from` is fetched and the reader reports itself done (nextPageToken "").
+func (r *TwitterChannelReader) FetchPage(ctx context.Context, userID, pageToken string, from time.Time) ([]DiscoveredItem, string, error) {
+	var since *time.Time
+	if !from.IsZero() {
+		since = &from
+	}
+
+	tweets, err := r.tw.GetUserTweets(ctx, userID, 100, since)
+	if err != nil {
+		return nil, "", err
+	}
+
+	items := make([]DiscoveredItem, 0, len(tweets))
+	for _, t := range tweets {
+		items = append(items, DiscoveredItem{
+			SourceID:    t.ID,
+			URL:         fmt.Sprintf("https://twitter.com/i/web/status/%s", t.ID),
+			Title:       t.Text,
+			PublishedAt: t.CreatedAt,
+		})
+	}
+	return items, "", nil
+}
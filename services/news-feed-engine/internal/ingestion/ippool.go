@@ -0,0 +1,235 @@
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
+)
+
+// defaultCooldown is how long an IP is marked cooling after a 429/403,
+// if IPPool wasn't constructed with an explicit one.
+const defaultCooldown = 5 * time.Minute
+
+// Lease is an acquired, per-request claim on one egress IP. Ingesters
+// call Release after the request completes, reporting whether it hit a
+// platform rate limit so IPPool can throttle that IP going forward.
+type Lease struct {
+	IP       string
+	pool     *IPPool
+	platform string
+	host     string
+}
+
+// Release reports the outcome of the request Lease.IP made. rateLimited
+// should be true on HTTP 429/403; any other outcome (including a
+// successful request) clears the IP's failure count.
+func (l *Lease) Release(ctx context.Context, rateLimited bool) {
+	l.pool.release(ctx, l, rateLimited)
+}
+
+// IPPool owns a set of egress IPs (or upstream proxy endpoints) shared
+// across ingesters, handing out leases with per-(platform, host) cooldowns
+// so one ingester's 429 doesn't starve another's use of a still-healthy
+// IP. Failure counts live in Redis so the cooldown is consistent across
+// worker replicas, the same approach ratelimit.VideoLimiter takes for
+// tenant quotas.
+type IPPool struct {
+	client   *redis.Client
+	cooldown time.Duration
+
+	mu      sync.RWMutex
+	ips     []string
+	weights map[string]int
+	sticky  map[string]string // ContentSource.ID -> IP
+	rng     *rand.Rand
+}
+
+// NewIPPool creates an IPPool over ips, each with equal weight. Call
+// Reload to change the IP list (and weights) later without restarting.
+func NewIPPool(client *redis.Client, ips []string, cooldown time.Duration) *IPPool {
+	if cooldown <= 0 {
+		cooldown = defaultCooldown
+	}
+	weights := make(map[string]int, len(ips))
+	for _, ip := range ips {
+		weights[ip] = 1
+	}
+	return &IPPool{
+		client:   client,
+		cooldown: cooldown,
+		ips:      append([]string(nil), ips...),
+		weights:  weights,
+		sticky:   make(map[string]string),
+		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Reload replaces the pool's IP list and weights in place, so a config
+// change takes effect without restarting the process. Sticky bindings to
+// IPs no longer present are dropped; an in-flight crawl bound to one of
+// those falls back to ordinary weighted selection on its next lease.
+func (p *IPPool) Reload(ips []string, weights map[string]int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.ips = append([]string(nil), ips...)
+	newWeights := make(map[string]int, len(ips))
+	for _, ip := range ips {
+		w := 1
+		if weights != nil {
+			if configured, ok := weights[ip]; ok && configured > 0 {
+				w = configured
+			}
+		}
+		newWeights[ip] = w
+	}
+	p.weights = newWeights
+
+	for sourceID, ip := range p.sticky {
+		if _, ok := newWeights[ip]; !ok {
+			delete(p.sticky, sourceID)
+		}
+	}
+}
+
+// Acquire selects an egress IP for a request to (platform, host),
+// skipping any IP currently cooling for that pair. If sourceID is
+// non-empty and already has a sticky binding to a non-cooling IP, that
+// binding is reused so the crawl keeps its session cookies; otherwise a
+// fresh selection is made and, for a non-empty sourceID, bound for the
+// rest of the crawl.
+func (p *IPPool) Acquire(ctx context.Context, platform, host, sourceID string) (*Lease, error) {
+	p.mu.RLock()
+	ips := append([]string(nil), p.ips...)
+	weights := make(map[string]int, len(p.weights))
+	for ip, w := range p.weights {
+		weights[ip] = w
+	}
+	sticky, hasSticky := p.sticky[sourceID]
+	p.mu.RUnlock()
+
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("ingestion: IP pool is empty")
+	}
+
+	if sourceID != "" && hasSticky {
+		cooling, err := p.isCooling(ctx, platform, host, sticky)
+		if err != nil {
+			return nil, err
+		}
+		if !cooling {
+			return &Lease{IP: sticky, pool: p, platform: platform, host: host}, nil
+		}
+	}
+
+	candidates := make([]string, 0, len(ips))
+	totalWeight := 0
+	for _, ip := range ips {
+		cooling, err := p.isCooling(ctx, platform, host, ip)
+		if err != nil {
+			return nil, err
+		}
+		if cooling {
+			continue
+		}
+		candidates = append(candidates, ip)
+		totalWeight += weights[ip]
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("ingestion: all IPs cooling for %s/%s", platform, host)
+	}
+
+	ip := p.weightedPick(candidates, weights, totalWeight)
+
+	if sourceID != "" {
+		p.mu.Lock()
+		p.sticky[sourceID] = ip
+		p.mu.Unlock()
+	}
+
+	p.leasesCounter(ip, platform, "acquired").Increment()
+
+	return &Lease{IP: ip, pool: p, platform: platform, host: host}, nil
+}
+
+func (p *IPPool) weightedPick(candidates []string, weights map[string]int, totalWeight int) string {
+	if totalWeight <= 0 {
+		return candidates[p.rng.Intn(len(candidates))]
+	}
+	target := p.rng.Intn(totalWeight)
+	for _, ip := range candidates {
+		target -= weights[ip]
+		if target < 0 {
+			return ip
+		}
+	}
+	return candidates[len(candidates)-1]
+}
+
+func (p *IPPool) release(ctx context.Context, lease *Lease, rateLimited bool) {
+	key := coolingKey(lease.platform, lease.host, lease.IP)
+	failKey := failureKey(lease.platform, lease.host, lease.IP)
+
+	if !rateLimited {
+		p.client.Del(ctx, failKey)
+		p.leasesCounter(lease.IP, lease.platform, "ok").Increment()
+		return
+	}
+
+	p.client.Incr(ctx, failKey)
+	p.client.Expire(ctx, failKey, 24*time.Hour)
+	p.client.Set(ctx, key, "1", p.cooldown)
+	p.leasesCounter(lease.IP, lease.platform, "throttled").Increment()
+}
+
+func (p *IPPool) isCooling(ctx context.Context, platform, host, ip string) (bool, error) {
+	exists, err := p.client.Exists(ctx, coolingKey(platform, host, ip)).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check IP cooldown: %w", err)
+	}
+	return exists > 0, nil
+}
+
+// CoolingCount reports how many of the pool's IPs are currently cooling
+// for (platform, host), for NewIPPoolChecker.
+func (p *IPPool) CoolingCount(ctx context.Context, platform, host string) (cooling, total int, err error) {
+	p.mu.RLock()
+	ips := append([]string(nil), p.ips...)
+	p.mu.RUnlock()
+
+	for _, ip := range ips {
+		isCooling, err := p.isCooling(ctx, platform, host, ip)
+		if err != nil {
+			return 0, 0, err
+		}
+		if isCooling {
+			cooling++
+		}
+	}
+	return cooling, len(ips), nil
+}
+
+// leasesCounter returns the ingest_ip_leases_total counter for
+// (ip, platform, result), lazily registering it in the global metrics
+// registry on first use - the same per-label-combination naming
+// ytapi.QuotaTracker's metricFor uses, since Counter itself has no
+// notion of label cardinality.
+func (p *IPPool) leasesCounter(ip, platform, result string) *metrics.Counter {
+	name := fmt.Sprintf("ingest_ip_leases_total_%s_%s_%s", ip, platform, result)
+	return metrics.RegisterCounter(name, map[string]string{"ip": ip, "platform": platform, "result": result})
+}
+
+func coolingKey(platform, host, ip string) string {
+	return fmt.Sprintf("ippool:cooling:%s:%s:%s", platform, host, ip)
+}
+
+func failureKey(platform, host, ip string) string {
+	return fmt.Sprintf("ippool:failures:%s:%s:%s", platform, host, ip)
+}
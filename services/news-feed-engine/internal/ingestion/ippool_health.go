@@ -0,0 +1,76 @@
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
+)
+
+// IPPoolChecker reports the fraction of an IPPool's egress IPs currently
+// cooling for a given (platform, host), so /health reflects remaining
+// scraping capacity the same way metrics.RedisChecker reflects cache
+// reachability.
+type IPPoolChecker struct {
+	pool     *IPPool
+	platform string
+	host     string
+}
+
+// NewIPPoolChecker creates a health checker for pool scoped to
+// (platform, host) - register one per platform/host an ingester
+// actually crawls, since cooldowns are tracked per pair.
+func NewIPPoolChecker(pool *IPPool, platform, host string) *IPPoolChecker {
+	return &IPPoolChecker{pool: pool, platform: platform, host: host}
+}
+
+// Check reports HealthStatusUnhealthy when every IP is cooling,
+// HealthStatusDegraded when more than half are, and HealthStatusHealthy
+// otherwise.
+func (c *IPPoolChecker) Check(ctx context.Context) metrics.HealthCheckResult {
+	name := fmt.Sprintf("ip_pool:%s:%s", c.platform, c.host)
+
+	cooling, total, err := c.pool.CoolingCount(ctx, c.platform, c.host)
+	if err != nil {
+		return metrics.HealthCheckResult{
+			Name:      name,
+			Status:    metrics.HealthStatusUnhealthy,
+			Message:   err.Error(),
+			Timestamp: time.Now(),
+		}
+	}
+	if total == 0 {
+		return metrics.HealthCheckResult{
+			Name:      name,
+			Status:    metrics.HealthStatusUnhealthy,
+			Message:   "IP pool is empty",
+			Timestamp: time.Now(),
+		}
+	}
+
+	status := metrics.HealthStatusHealthy
+	switch {
+	case cooling == total:
+		status = metrics.HealthStatusUnhealthy
+	case float64(cooling)/float64(total) > 0.5:
+		status = metrics.HealthStatusDegraded
+	}
+
+	return metrics.HealthCheckResult{
+		Name:      name,
+		Status:    status,
+		Message:   fmt.Sprintf("%d/%d IPs cooling", cooling, total),
+		Timestamp: time.Now(),
+	}
+}
+
+// CreateIPPoolHealthChecker creates a metrics.HealthChecker function for
+// pool scoped to (platform, host), ready to pass to
+// metrics.HealthCheckRegistry.Register.
+func CreateIPPoolHealthChecker(pool *IPPool, platform, host string) metrics.HealthChecker {
+	checker := NewIPPoolChecker(pool, platform, host)
+	return func(ctx context.Context) metrics.HealthCheckResult {
+		return checker.Check(ctx)
+	}
+}
@@ -0,0 +1,254 @@
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+	"go.uber.org/zap"
+)
+
+// Adaptive scheduling defaults, used whenever a ContentSource's Config
+// doesn't override them. baseInterval itself is not one of these: it
+// comes from Config["base_interval_seconds"], since IngestionCron is a
+// cron expression and this package has no cron parser to turn it into a
+// duration without vendoring one.
+const (
+	defaultMinInterval     = time.Minute
+	defaultMaxInterval     = 24 * time.Hour
+	defaultBaseInterval    = time.Hour
+	defaultBumpWindow      = 3
+	defaultBumpThreshold   = 5
+	defaultBumpFactor      = 2.0
+	defaultBackoffFactor   = 2.0
+	defaultErrorBackoffCap = 5
+)
+
+// AdaptiveScheduleConfig tunes NextInterval for one ContentSource. Every
+// field has a package default, so a source's Config only needs to set
+// the keys it wants to override.
+type AdaptiveScheduleConfig struct {
+	// BaseInterval is the source's steady-state ingestion cadence,
+	// absent any recent activity bump or backoff.
+	BaseInterval time.Duration
+	// MinInterval and MaxInterval bound the interval NextInterval can
+	// return, however aggressively activity or errors push it.
+	MinInterval time.Duration
+	MaxInterval time.Duration
+	// BumpWindow and BumpThreshold: if the last BumpWindow ingestions
+	// together found at least BumpThreshold new items, the next
+	// interval shrinks by BumpFactor.
+	BumpWindow    int
+	BumpThreshold int
+	BumpFactor    float64
+	// BackoffFactor is the per-consecutive-empty-run multiplier applied
+	// when the most recent ingestions found nothing.
+	BackoffFactor float64
+	// ErrorBackoffCap bounds how many consecutive errors count toward
+	// the 2^n error backoff multiplier, so a source stuck failing for
+	// weeks doesn't grow its interval without bound.
+	ErrorBackoffCap int
+}
+
+// ParseAdaptiveScheduleConfig reads an AdaptiveScheduleConfig out of a
+// ContentSource's Config JSONB, falling back to package defaults for any
+// key that's absent or the wrong type. baseIntervalFallback is used when
+// Config has no base_interval_seconds, e.g. for a source created before
+// this feature existed.
+func ParseAdaptiveScheduleConfig(cfg models.JSONB, baseIntervalFallback time.Duration) AdaptiveScheduleConfig {
+	sc := AdaptiveScheduleConfig{
+		BaseInterval:    baseIntervalFallback,
+		MinInterval:     defaultMinInterval,
+		MaxInterval:     defaultMaxInterval,
+		BumpWindow:      defaultBumpWindow,
+		BumpThreshold:   defaultBumpThreshold,
+		BumpFactor:      defaultBumpFactor,
+		BackoffFactor:   defaultBackoffFactor,
+		ErrorBackoffCap: defaultErrorBackoffCap,
+	}
+	if secs, ok := configSeconds(cfg, "base_interval_seconds"); ok {
+		sc.BaseInterval = time.Duration(secs) * time.Second
+	}
+	if secs, ok := configSeconds(cfg, "min_interval_seconds"); ok {
+		sc.MinInterval = time.Duration(secs) * time.Second
+	}
+	if secs, ok := configSeconds(cfg, "max_interval_seconds"); ok {
+		sc.MaxInterval = time.Duration(secs) * time.Second
+	}
+	if n, ok := configInt(cfg, "bump_window"); ok {
+		sc.BumpWindow = n
+	}
+	if n, ok := configInt(cfg, "bump_threshold"); ok {
+		sc.BumpThreshold = n
+	}
+	if f, ok := configFloat(cfg, "bump_factor"); ok {
+		sc.BumpFactor = f
+	}
+	if f, ok := configFloat(cfg, "backoff_factor"); ok {
+		sc.BackoffFactor = f
+	}
+	if n, ok := configInt(cfg, "error_backoff_cap"); ok {
+		sc.ErrorBackoffCap = n
+	}
+	return sc
+}
+
+func configFloat(cfg models.JSONB, key string) (float64, bool) {
+	v, present := cfg[key]
+	if !present {
+		return 0, false
+	}
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+func configSeconds(cfg models.JSONB, key string) (float64, bool) { return configFloat(cfg, key) }
+
+func configInt(cfg models.JSONB, key string) (int, bool) {
+	f, ok := configFloat(cfg, key)
+	if !ok {
+		return 0, false
+	}
+	return int(f), true
+}
+
+// NextInterval computes the next ingestion interval for a source, given
+// its adaptive schedule config, the item counts from its most recent
+// ingestion runs (recentCounts[0] is the most recent), and its current
+// consecutive error count.
+//
+// The rule: start from cfg.BaseInterval. If the last cfg.BumpWindow runs
+// together found at least cfg.BumpThreshold items, shrink the interval
+// by cfg.BumpFactor. Otherwise, if the most recent runs found nothing,
+// grow it by cfg.BackoffFactor once per consecutive empty run. Either
+// way, multiply the result by 2^min(consecutiveErrors, cfg.ErrorBackoffCap)
+// to push failing sources out further, then clamp to
+// [cfg.MinInterval, cfg.MaxInterval].
+func NextInterval(cfg AdaptiveScheduleConfig, recentCounts []int, consecutiveErrors int) time.Duration {
+	interval := cfg.BaseInterval
+	if interval <= 0 {
+		interval = defaultMinInterval
+	}
+
+	if cfg.BumpWindow > 0 && len(recentCounts) >= cfg.BumpWindow {
+		window := recentCounts[:cfg.BumpWindow]
+		sum := 0
+		for _, c := range window {
+			sum += c
+		}
+		if sum >= cfg.BumpThreshold && cfg.BumpFactor > 0 {
+			interval = time.Duration(float64(interval) / cfg.BumpFactor)
+		}
+	} else if emptyStreak := consecutiveEmptyRuns(recentCounts); emptyStreak > 0 && cfg.BackoffFactor > 0 {
+		for i := 0; i < emptyStreak; i++ {
+			interval = time.Duration(float64(interval) * cfg.BackoffFactor)
+			if interval >= cfg.MaxInterval {
+				break
+			}
+		}
+	}
+
+	if consecutiveErrors > 0 {
+		n := consecutiveErrors
+		if cfg.ErrorBackoffCap > 0 && n > cfg.ErrorBackoffCap {
+			n = cfg.ErrorBackoffCap
+		}
+		interval *= 1 << uint(n)
+	}
+
+	if cfg.MinInterval > 0 && interval < cfg.MinInterval {
+		interval = cfg.MinInterval
+	}
+	if cfg.MaxInterval > 0 && interval > cfg.MaxInterval {
+		interval = cfg.MaxInterval
+	}
+	return interval
+}
+
+// consecutiveEmptyRuns counts the leading zeros in recentCounts (most
+// recent first), i.e. how many ingestions in a row have found nothing.
+func consecutiveEmptyRuns(recentCounts []int) int {
+	n := 0
+	for _, c := range recentCounts {
+		if c != 0 {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// SourceStore is the persistence boundary the Scheduler needs from
+// content_sources/ingestion_history. Implemented by
+// internal/database.SourceRepository.
+type SourceStore interface {
+	ClaimDue(ctx context.Context, tenantID string, now time.Time, limit int) ([]models.ContentSource, error)
+	RecentIngestionCounts(ctx context.Context, sourceID uuid.UUID, limit int) ([]int, error)
+	RecordIngestionOutcome(ctx context.Context, source models.ContentSource, itemsFound int, ingestErr error, nextInterval time.Duration) error
+}
+
+// Scheduler claims due ContentSources for ingestion and, once a run
+// completes, recomputes and persists each source's next_ingestion_at
+// using an activity-bump/error-backoff rule (see NextInterval) instead
+// of the static IngestionCron cadence every source previously shared.
+type Scheduler struct {
+	store  SourceStore
+	logger *zap.Logger
+}
+
+// NewScheduler creates a Scheduler backed by store.
+func NewScheduler(store SourceStore, logger *zap.Logger) *Scheduler {
+	return &Scheduler{store: store, logger: logger}
+}
+
+// ClaimDue returns up to limit sources for tenantID whose next_ingestion_at
+// has passed as of now, claiming them so a concurrent worker pulling from
+// the same tenant won't also pick them up.
+func (s *Scheduler) ClaimDue(ctx context.Context, tenantID string, now time.Time, limit int) ([]models.ContentSource, error) {
+	sources, err := s.store.ClaimDue(ctx, tenantID, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due sources: %w", err)
+	}
+	return sources, nil
+}
+
+// BumpOnActivity records the outcome of one ingestion run for source and
+// recomputes its next_ingestion_at: itemsFound and ingestErr feed the
+// source's running counters, and together with its ingestion_history
+// drive NextInterval's activity-bump/backoff decision for the next run.
+func (s *Scheduler) BumpOnActivity(ctx context.Context, source models.ContentSource, itemsFound int, ingestErr error) error {
+	recent, err := s.store.RecentIngestionCounts(ctx, source.ID, defaultBumpWindow)
+	if err != nil {
+		return fmt.Errorf("failed to load ingestion history for source %s: %w", source.ID, err)
+	}
+
+	consecutiveErrors := source.ErrorCount
+	if ingestErr != nil {
+		consecutiveErrors++
+	} else {
+		consecutiveErrors = 0
+	}
+
+	cfg := ParseAdaptiveScheduleConfig(source.Config, defaultBaseInterval)
+	next := NextInterval(cfg, recent, consecutiveErrors)
+
+	if err := s.store.RecordIngestionOutcome(ctx, source, itemsFound, ingestErr, next); err != nil {
+		return fmt.Errorf("failed to record ingestion outcome for source %s: %w", source.ID, err)
+	}
+
+	s.logger.Debug("recomputed source ingestion schedule",
+		zap.String("source_id", source.ID.String()),
+		zap.Int("items_found", itemsFound),
+		zap.Bool("had_error", ingestErr != nil),
+		zap.Duration("next_interval", next),
+	)
+	return nil
+}
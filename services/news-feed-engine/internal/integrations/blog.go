@@ -8,6 +8,8 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
+	"github.com/kushin77/elevatedIQ/pkg/metrics/otelbridge"
 	"go.uber.org/zap"
 )
 
@@ -17,6 +19,27 @@ type BlogIntegration struct {
 	apiKey     string
 	httpClient *http.Client
 	logger     *zap.Logger
+
+	// activityPub federates this blog's posts when set via
+	// WithActivityPub; nil means the blog only speaks its own API.
+	activityPub *ActivityPubIntegration
+	// webmention sends outbound webmentions on publish/update when set
+	// via WithWebmention; nil disables outbound webmentions.
+	webmention *WebmentionIntegration
+}
+
+// WithActivityPub attaches ActivityPub federation so HandleWebhook also
+// emits Create/Update/Delete activities to followers.
+func (b *BlogIntegration) WithActivityPub(ap *ActivityPubIntegration) *BlogIntegration {
+	b.activityPub = ap
+	return b
+}
+
+// WithWebmention attaches outbound Webmention delivery so HandleWebhook
+// sends webmentions to every external link in a published/updated post.
+func (b *BlogIntegration) WithWebmention(wm *WebmentionIntegration) *BlogIntegration {
+	b.webmention = wm
+	return b
 }
 
 // BlogPost represents a blog post from the platform
@@ -69,6 +92,9 @@ type BlogComment struct {
 	ParentID  string    `json:"parent_id,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	LikeCount int       `json:"like_count"`
+	// Type distinguishes a native comment ("") from one created out of a
+	// federated reply or a webmention ("reply", "like", "repost", "mention").
+	Type string `json:"type,omitempty"`
 }
 
 // BlogCategory represents a blog category
@@ -81,18 +107,33 @@ type BlogCategory struct {
 	ParentID    string `json:"parent_id,omitempty"`
 }
 
-// NewBlogIntegration creates a new blog integration
+// NewBlogIntegration creates a new blog integration. The HTTP client is
+// instrumented with otelbridge.RoundTripper by default so every call made
+// through makeRequest gets a client span and W3C propagation headers for
+// free; use WithHTTPClient to supply a different client (e.g. in tests).
 func NewBlogIntegration(baseURL, apiKey string, logger *zap.Logger) *BlogIntegration {
+	tp := metrics.GetGlobalTracingProvider()
+	if tp == nil {
+		tp, _ = metrics.NewTracingProvider(metrics.TracingConfig{})
+	}
 	return &BlogIntegration{
 		baseURL: baseURL,
 		apiKey:  apiKey,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: otelbridge.NewRoundTripper(tp, nil),
 		},
 		logger: logger,
 	}
 }
 
+// WithHTTPClient overrides the HTTP client used for blog API requests,
+// e.g. for tests or callers that need a custom transport (proxies, mTLS).
+func (b *BlogIntegration) WithHTTPClient(client *http.Client) *BlogIntegration {
+	b.httpClient = client
+	return b
+}
+
 // GetPosts retrieves blog posts with filtering options
 func (b *BlogIntegration) GetPosts(ctx context.Context, opts GetPostsOptions) ([]BlogPost, int, error) {
 	params := url.Values{
@@ -207,6 +248,113 @@ func (b *BlogIntegration) GetComments(ctx context.Context, postID string, page,
 	return result.Comments, nil
 }
 
+// CreateComment records comment against its PostID on the blog platform,
+// returning the platform's copy (with ID populated). Used for native
+// comments as well as ones synthesized from a federated reply or a
+// verified incoming webmention.
+func (b *BlogIntegration) CreateComment(ctx context.Context, comment *BlogComment) (*BlogComment, error) {
+	payload := map[string]interface{}{
+		"user_id":   comment.UserID,
+		"user_name": comment.UserName,
+		"content":   comment.Content,
+		"parent_id": comment.ParentID,
+		"type":      comment.Type,
+	}
+
+	resp, err := b.makeRequest(ctx, "POST", fmt.Sprintf("/api/v1/posts/%s/comments", comment.PostID), nil, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	var created BlogComment
+	if err := json.Unmarshal(resp, &created); err != nil {
+		return nil, fmt.Errorf("failed to parse comment response: %w", err)
+	}
+
+	return &created, nil
+}
+
+// CreatePost creates a new post on the blog platform from post,
+// returning the platform's copy (with ID/Slug/timestamps populated).
+func (b *BlogIntegration) CreatePost(ctx context.Context, post *BlogPost) (*BlogPost, error) {
+	payload := map[string]interface{}{
+		"title":          post.Title,
+		"content":        post.Content,
+		"excerpt":        post.Excerpt,
+		"categories":     post.Categories,
+		"tags":           post.Tags,
+		"featured_image": post.FeaturedImage,
+		"status":         post.Status,
+	}
+	if post.Status == "" {
+		payload["status"] = "published"
+	}
+	if !post.PublishedAt.IsZero() {
+		payload["published_at"] = post.PublishedAt
+	}
+
+	resp, err := b.makeRequest(ctx, "POST", "/api/v1/posts", nil, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create post: %w", err)
+	}
+
+	var created BlogPost
+	if err := json.Unmarshal(resp, &created); err != nil {
+		return nil, fmt.Errorf("failed to parse create post response: %w", err)
+	}
+	return &created, nil
+}
+
+// UpdatePost applies a partial update to the post identified by idOrSlug,
+// returning the platform's updated copy. Zero-value fields in updates are
+// omitted from the request so a partial update (e.g. Micropub's "update"
+// action only sending title and content) doesn't blank out the rest.
+func (b *BlogIntegration) UpdatePost(ctx context.Context, idOrSlug string, updates *BlogPost) (*BlogPost, error) {
+	payload := map[string]interface{}{}
+	if updates.Title != "" {
+		payload["title"] = updates.Title
+	}
+	if updates.Content != "" {
+		payload["content"] = updates.Content
+	}
+	if updates.Excerpt != "" {
+		payload["excerpt"] = updates.Excerpt
+	}
+	if len(updates.Categories) > 0 {
+		payload["categories"] = updates.Categories
+	}
+	if len(updates.Tags) > 0 {
+		payload["tags"] = updates.Tags
+	}
+
+	resp, err := b.makeRequest(ctx, "PATCH", fmt.Sprintf("/api/v1/posts/%s", idOrSlug), nil, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update post: %w", err)
+	}
+
+	var updated BlogPost
+	if err := json.Unmarshal(resp, &updated); err != nil {
+		return nil, fmt.Errorf("failed to parse update post response: %w", err)
+	}
+	return &updated, nil
+}
+
+// SetPostStatus transitions the post identified by idOrSlug to status
+// (e.g. "published" to undo a prior delete), returning the platform's
+// updated copy.
+func (b *BlogIntegration) SetPostStatus(ctx context.Context, idOrSlug, status string) (*BlogPost, error) {
+	resp, err := b.makeRequest(ctx, "PATCH", fmt.Sprintf("/api/v1/posts/%s", idOrSlug), nil, map[string]interface{}{"status": status})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set post status: %w", err)
+	}
+
+	var updated BlogPost
+	if err := json.Unmarshal(resp, &updated); err != nil {
+		return nil, fmt.Errorf("failed to parse set status response: %w", err)
+	}
+	return &updated, nil
+}
+
 // PublishToFeed publishes a content item to the news feed
 func (b *BlogIntegration) PublishToFeed(ctx context.Context, post *BlogPost, tenantID string) error {
 	payload := map[string]interface{}{
@@ -251,6 +399,8 @@ func (b *BlogIntegration) HandleWebhook(payload *WebhookPayload) error {
 			zap.String("title", payload.Post.Title),
 			zap.String("tenant_id", payload.TenantID),
 		)
+		b.federate(context.Background(), b.activityPub.PublishCreate, &payload.Post)
+		b.sendWebmentions(&payload.Post)
 		// This would trigger content ingestion
 		return nil
 
@@ -259,12 +409,15 @@ func (b *BlogIntegration) HandleWebhook(payload *WebhookPayload) error {
 			zap.String("post_id", payload.Post.ID),
 			zap.String("title", payload.Post.Title),
 		)
+		b.federate(context.Background(), b.activityPub.PublishUpdate, &payload.Post)
+		b.sendWebmentions(&payload.Post)
 		return nil
 
 	case "post.deleted":
 		b.logger.Info("Blog post deleted",
 			zap.String("post_id", payload.Post.ID),
 		)
+		b.federate(context.Background(), b.activityPub.PublishDelete, &payload.Post)
 		// This would trigger content removal from feed
 		return nil
 
@@ -276,6 +429,36 @@ func (b *BlogIntegration) HandleWebhook(payload *WebhookPayload) error {
 	}
 }
 
+// federate fans a post out through ActivityPub via publish, if federation
+// has been configured with WithActivityPub. Errors are logged rather than
+// returned since HandleWebhook's own contract predates federation and
+// remote delivery failures shouldn't fail webhook processing.
+func (b *BlogIntegration) federate(ctx context.Context, publish func(context.Context, *BlogPost) error, post *BlogPost) {
+	if b.activityPub == nil {
+		return
+	}
+	if err := publish(ctx, post); err != nil {
+		b.logger.Warn("activitypub federation failed", zap.String("post_id", post.ID), zap.Error(err))
+	}
+}
+
+// sendWebmentions discovers and delivers webmentions for post's external
+// links in the background, if webmention delivery is configured. It runs
+// asynchronously, like federate, so slow remote endpoints don't hold up
+// webhook processing; unlike federate it can take a while per target
+// (one fetch per link plus retries), which is the main reason it's
+// backgrounded rather than inlined into HandleWebhook directly.
+func (b *BlogIntegration) sendWebmentions(post *BlogPost) {
+	if b.webmention == nil {
+		return
+	}
+	go func() {
+		if err := b.webmention.SendForPost(context.Background(), post); err != nil {
+			b.logger.Warn("webmention delivery failed", zap.String("post_id", post.ID), zap.Error(err))
+		}
+	}()
+}
+
 // makeRequest makes an HTTP request to the blog API
 func (b *BlogIntegration) makeRequest(ctx context.Context, method, endpoint string, params url.Values, body interface{}) ([]byte, error) {
 	apiURL := b.baseURL + endpoint
@@ -296,6 +479,9 @@ func (b *BlogIntegration) makeRequest(ctx context.Context, method, endpoint stri
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", b.apiKey))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	// Trace propagation headers are attached by the otelbridge.RoundTripper
+	// configured on b.httpClient, not here, so a caller using WithHTTPClient
+	// with a plain client still gets a usable (if untraced) request.
 
 	resp, err := b.httpClient.Do(req)
 	if err != nil {
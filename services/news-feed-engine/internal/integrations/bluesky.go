@@ -0,0 +1,129 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// blueskyPublicAppView is the AT Protocol AppView Bluesky operates that
+// serves unauthenticated reads of public data - unlike most of the
+// protocol, which requires a signed-in session.
+const blueskyPublicAppView = "https://public.api.bsky.app"
+
+// BlueskyIntegration fetches an actor's public feed from the AT
+// Protocol via Bluesky's public AppView.
+type BlueskyIntegration struct {
+	httpClient *http.Client
+	logger     *zap.Logger
+	appViewURL string
+}
+
+// NewBlueskyIntegration creates a new Bluesky integration against the
+// public AppView.
+func NewBlueskyIntegration(logger *zap.Logger) *BlueskyIntegration {
+	return &BlueskyIntegration{
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		logger:     logger,
+		appViewURL: blueskyPublicAppView,
+	}
+}
+
+// BlueskyPost represents a single post from an actor's feed.
+type BlueskyPost struct {
+	URI               string
+	CID               string
+	Text              string
+	AuthorDID         string
+	AuthorHandle      string
+	AuthorDisplayName string
+	AuthorAvatarURL   string
+	CreatedAt         time.Time
+	LikeCount         int64
+	RepostCount       int64
+	ReplyCount        int64
+	EmbedImageURL     string
+}
+
+// GetAuthorFeed fetches actor's (a handle like alice.bsky.social, or a
+// did:plc:... identifier) public feed via the app.bsky.feed.getAuthorFeed
+// XRPC query, returning up to limit posts.
+func (bi *BlueskyIntegration) GetAuthorFeed(ctx context.Context, actor string, limit int) ([]BlueskyPost, error) {
+	endpoint := fmt.Sprintf("%s/xrpc/app.bsky.feed.getAuthorFeed?actor=%s&limit=%d",
+		bi.appViewURL, url.QueryEscape(actor), limit)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := bi.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bluesky author feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("bluesky author feed request failed: %s - %s", resp.Status, string(body))
+	}
+
+	var result struct {
+		Feed []struct {
+			Post struct {
+				URI    string `json:"uri"`
+				CID    string `json:"cid"`
+				Author struct {
+					DID         string `json:"did"`
+					Handle      string `json:"handle"`
+					DisplayName string `json:"displayName"`
+					Avatar      string `json:"avatar"`
+				} `json:"author"`
+				Record struct {
+					Text      string    `json:"text"`
+					CreatedAt time.Time `json:"createdAt"`
+				} `json:"record"`
+				LikeCount   int64 `json:"likeCount"`
+				RepostCount int64 `json:"repostCount"`
+				ReplyCount  int64 `json:"replyCount"`
+				Embed       *struct {
+					Images []struct {
+						Fullsize string `json:"fullsize"`
+					} `json:"images"`
+				} `json:"embed,omitempty"`
+			} `json:"post"`
+		} `json:"feed"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse bluesky author feed: %w", err)
+	}
+
+	posts := make([]BlueskyPost, 0, len(result.Feed))
+	for _, item := range result.Feed {
+		p := item.Post
+		post := BlueskyPost{
+			URI:               p.URI,
+			CID:               p.CID,
+			Text:              p.Record.Text,
+			AuthorDID:         p.Author.DID,
+			AuthorHandle:      p.Author.Handle,
+			AuthorDisplayName: p.Author.DisplayName,
+			AuthorAvatarURL:   p.Author.Avatar,
+			CreatedAt:         p.Record.CreatedAt,
+			LikeCount:         p.LikeCount,
+			RepostCount:       p.RepostCount,
+			ReplyCount:        p.ReplyCount,
+		}
+		if p.Embed != nil && len(p.Embed.Images) > 0 {
+			post.EmbedImageURL = p.Embed.Images[0].Fullsize
+		}
+		posts = append(posts, post)
+	}
+
+	return posts, nil
+}
@@ -0,0 +1,112 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// fakeCDNProvider is a cdn.Provider whose Mirror/TransformURL/PurgeByTag
+// calls are recorded rather than hitting a real CDN, so CDNManager's
+// orchestration can be tested without network access.
+type fakeCDNProvider struct {
+	mirroredPath string
+	mirroredTag  string
+	purgedTags   []string
+}
+
+func (f *fakeCDNProvider) SignedURL(path string, expiry time.Time, clientIP string) (string, error) {
+	return "https://cdn.example.com/" + path + "?signed=1", nil
+}
+
+func (f *fakeCDNProvider) PurgeURL(ctx context.Context, url string) error {
+	return nil
+}
+
+func (f *fakeCDNProvider) PurgeByTag(ctx context.Context, tag string) error {
+	f.purgedTags = append(f.purgedTags, tag)
+	return nil
+}
+
+func (f *fakeCDNProvider) TransformURL(originURL string, preset string) (string, error) {
+	return originURL + "#" + preset, nil
+}
+
+func (f *fakeCDNProvider) Mirror(ctx context.Context, path string, tag string, src io.Reader) (string, error) {
+	f.mirroredPath = path
+	f.mirroredTag = tag
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return "", err
+	}
+	return "https://cdn.example.com/" + path + "?bytes=" + strconv.Itoa(len(data)), nil
+}
+
+func TestCDNManagerPopulateVariants(t *testing.T) {
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(bytes.Repeat([]byte("x"), 16))
+	}))
+	defer origin.Close()
+
+	provider := &fakeCDNProvider{}
+	manager := NewCDNManager(provider, nil)
+
+	asset := &MediaAsset{ID: "asset-1", URLs: AssetURLs{Original: origin.URL}}
+	if err := manager.PopulateVariants(context.Background(), asset); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if provider.mirroredTag != "asset-1" {
+		t.Fatalf("expected mirror tag %q, got %q", "asset-1", provider.mirroredTag)
+	}
+	if asset.URLs.CDN == "" {
+		t.Fatal("expected AssetURLs.CDN to be populated")
+	}
+	if len(asset.URLs.Thumbnails) != len(cdnThumbnailPresets) {
+		t.Fatalf("expected %d thumbnails, got %d", len(cdnThumbnailPresets), len(asset.URLs.Thumbnails))
+	}
+	if asset.URLs.Thumbnails["small"] != asset.URLs.CDN+"#thumb_sm" {
+		t.Fatalf("unexpected small thumbnail url: %s", asset.URLs.Thumbnails["small"])
+	}
+	if len(asset.URLs.Optimized) != len(cdnOptimizedPresets) {
+		t.Fatalf("expected %d optimized variants, got %d", len(cdnOptimizedPresets), len(asset.URLs.Optimized))
+	}
+}
+
+func TestCDNManagerPopulateVariantsNoProviderIsNoOp(t *testing.T) {
+	manager := NewCDNManager(nil, nil)
+	asset := &MediaAsset{ID: "asset-1", URLs: AssetURLs{Original: "https://example.com/original.jpg"}}
+
+	if err := manager.PopulateVariants(context.Background(), asset); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if asset.URLs.CDN != "" {
+		t.Fatalf("expected AssetURLs.CDN to stay empty without a provider, got %q", asset.URLs.CDN)
+	}
+}
+
+func TestCDNManagerPopulateVariantsRequiresOriginalURL(t *testing.T) {
+	manager := NewCDNManager(&fakeCDNProvider{}, nil)
+	asset := &MediaAsset{ID: "asset-1"}
+
+	if err := manager.PopulateVariants(context.Background(), asset); err == nil {
+		t.Fatal("expected an error when the asset has no original URL")
+	}
+}
+
+func TestCDNManagerPurgeVariants(t *testing.T) {
+	provider := &fakeCDNProvider{}
+	manager := NewCDNManager(provider, nil)
+
+	if err := manager.PurgeVariants(context.Background(), "asset-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(provider.purgedTags) != 1 || provider.purgedTags[0] != "asset-1" {
+		t.Fatalf("expected PurgeByTag called with asset-1, got %v", provider.purgedTags)
+	}
+}
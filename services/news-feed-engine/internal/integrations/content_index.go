@@ -0,0 +1,249 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/embeddings"
+)
+
+// contentIndexRRFK mirrors database.ContentRepository.SearchHybrid's
+// Reciprocal Rank Fusion constant, so fused scores behave consistently
+// across both search subsystems.
+const contentIndexRRFK = 60
+
+// recencyDecayLambda controls how much a result's fused score decays
+// with age: score *= exp(-recencyDecayLambda * age_days). At this rate
+// a week-old item's score is down to about half its undecayed value.
+const recencyDecayLambda = 0.1
+
+// ContentSearchFilters narrows a ContentIndex.Search call to a tenant
+// and, optionally, a single platform.
+type ContentSearchFilters struct {
+	TenantID string
+	Platform string // empty matches every platform
+}
+
+// ContentSearchResult is a single Search hit, carrying the ranks that
+// went into its fused position (0 meaning "did not appear in that
+// list") so callers can debug relevance beyond just the final order.
+type ContentSearchResult struct {
+	Content    UnifiedContent
+	VectorRank int
+	BM25Rank   int
+	Score      float64
+}
+
+// ContentIndexStore persists UnifiedContent for hybrid search. It's
+// implemented by database.ContentIndexRepository against a Postgres
+// table carrying both a tsvector GIN index (title/description/content/
+// tags) and a pgvector embedding column.
+type ContentIndexStore interface {
+	// Index upserts item keyed by (tenant_id, platform, id), storing
+	// embedding alongside it for vector search. embedding may be nil
+	// when the item had no indexable text.
+	Index(ctx context.Context, tenantID string, item UnifiedContent, embedding []float32) error
+
+	// SearchFullText returns up to limit candidates ranked by ts_rank
+	// against query, most relevant first.
+	SearchFullText(ctx context.Context, filters ContentSearchFilters, query string, limit int) ([]UnifiedContent, error)
+
+	// SearchVector returns up to limit candidates ranked by cosine
+	// distance to embedding, closest first.
+	SearchVector(ctx context.Context, filters ContentSearchFilters, embedding []float32, limit int) ([]UnifiedContent, error)
+}
+
+// ContentIndex indexes every UnifiedContent a ContentAggregator fetches
+// into a ContentIndexStore and answers hybrid search queries across all
+// of it, giving a unified cross-platform search that the hub's own
+// Fetch* methods lack (each only ever looks at one source at a time).
+type ContentIndex struct {
+	store    ContentIndexStore
+	embedder embeddings.Service
+	logger   *zap.Logger
+}
+
+// NewContentIndex creates a new content index. embedder is typically an
+// embeddings.OpenAIService built from cfg.OpenAIAPIKey.
+func NewContentIndex(store ContentIndexStore, embedder embeddings.Service, logger *zap.Logger) *ContentIndex {
+	return &ContentIndex{store: store, embedder: embedder, logger: logger}
+}
+
+// IndexAll embeds and persists every item in content. Wire it as a
+// ContentAggregator callback, e.g.:
+//
+//	NewContentAggregator(hub, sources, func(c []UnifiedContent) error {
+//		return contentIndex.IndexAll(ctx, tenantID, c)
+//	})
+//
+// Failures to index an individual item are logged and skipped rather
+// than failing the whole batch, since one bad item shouldn't stop the
+// aggregator from advancing its sync watermark for the rest.
+func (ci *ContentIndex) IndexAll(ctx context.Context, tenantID string, content []UnifiedContent) error {
+	for _, item := range content {
+		if err := ci.indexOne(ctx, tenantID, item); err != nil {
+			ci.logger.Error("Failed to index content",
+				zap.String("platform", item.Platform),
+				zap.String("id", item.ID),
+				zap.Error(err),
+			)
+		}
+	}
+	return nil
+}
+
+func (ci *ContentIndex) indexOne(ctx context.Context, tenantID string, item UnifiedContent) error {
+	text := indexableText(item)
+
+	var embedding []float32
+	if text != "" {
+		var err error
+		embedding, err = ci.embedder.Generate(ctx, text)
+		if err != nil {
+			return fmt.Errorf("failed to embed content: %w", err)
+		}
+	}
+
+	if err := ci.store.Index(ctx, tenantID, item, embedding); err != nil {
+		return fmt.Errorf("failed to store content index entry: %w", err)
+	}
+	return nil
+}
+
+// indexableText joins the fields SearchFullText's tsvector is built
+// from, so embeddings are generated from the same text that's indexed
+// for full-text search.
+func indexableText(item UnifiedContent) string {
+	parts := make([]string, 0, 4)
+	if item.Title != "" {
+		parts = append(parts, item.Title)
+	}
+	if item.Description != "" {
+		parts = append(parts, item.Description)
+	}
+	if item.Content != "" {
+		parts = append(parts, item.Content)
+	}
+	if len(item.Tags) > 0 {
+		parts = append(parts, strings.Join(item.Tags, " "))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// Search runs full-text search and approximate vector KNN against
+// store concurrently, fuses the two ranked lists with Reciprocal Rank
+// Fusion (score = sum(1 / (contentIndexRRFK + rank_i)) across the lists
+// a result appears in), applies recency decay, and returns the top k
+// results by decayed score.
+func (ci *ContentIndex) Search(ctx context.Context, filters ContentSearchFilters, query string, k int) ([]ContentSearchResult, error) {
+	poolSize := k * 4
+	if poolSize < 40 {
+		poolSize = 40
+	}
+
+	var (
+		vectorResults []UnifiedContent
+		textResults   []UnifiedContent
+		vectorErr     error
+		textErr       error
+		wg            sync.WaitGroup
+	)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		embedding, err := ci.embedder.Generate(ctx, query)
+		if err != nil {
+			vectorErr = fmt.Errorf("failed to embed query: %w", err)
+			return
+		}
+		vectorResults, vectorErr = ci.store.SearchVector(ctx, filters, embedding, poolSize)
+	}()
+	go func() {
+		defer wg.Done()
+		textResults, textErr = ci.store.SearchFullText(ctx, filters, query, poolSize)
+	}()
+	wg.Wait()
+
+	if vectorErr != nil && textErr != nil {
+		return nil, fmt.Errorf("content search failed: vector: %v, text: %v", vectorErr, textErr)
+	}
+	if vectorErr != nil {
+		ci.logger.Warn("Vector search failed, falling back to full-text only", zap.Error(vectorErr))
+	}
+	if textErr != nil {
+		ci.logger.Warn("Full-text search failed, falling back to vector only", zap.Error(textErr))
+	}
+
+	type fused struct {
+		content    UnifiedContent
+		vectorRank int
+		bm25Rank   int
+		score      float64
+	}
+
+	byKey := make(map[string]*fused)
+	order := make([]string, 0, len(vectorResults)+len(textResults))
+
+	for i, c := range vectorResults {
+		key := c.Platform + ":" + c.ID
+		f := &fused{content: c, vectorRank: i + 1}
+		f.score += 1.0 / float64(contentIndexRRFK+i+1)
+		byKey[key] = f
+		order = append(order, key)
+	}
+	for i, c := range textResults {
+		key := c.Platform + ":" + c.ID
+		rank := i + 1
+		if f, ok := byKey[key]; ok {
+			f.bm25Rank = rank
+			f.score += 1.0 / float64(contentIndexRRFK+rank)
+		} else {
+			f := &fused{content: c, bm25Rank: rank}
+			f.score += 1.0 / float64(contentIndexRRFK+rank)
+			byKey[key] = f
+			order = append(order, key)
+		}
+	}
+
+	results := make([]*fused, 0, len(order))
+	for _, key := range order {
+		f := byKey[key]
+		f.score *= recencyDecay(f.content.PublishedAt)
+		results = append(results, f)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].score > results[j].score })
+
+	if len(results) > k {
+		results = results[:k]
+	}
+
+	out := make([]ContentSearchResult, 0, len(results))
+	for _, f := range results {
+		out = append(out, ContentSearchResult{
+			Content:    f.content,
+			VectorRank: f.vectorRank,
+			BM25Rank:   f.bm25Rank,
+			Score:      f.score,
+		})
+	}
+	return out, nil
+}
+
+// recencyDecay returns exp(-recencyDecayLambda * age_days) for
+// publishedAt, so Search's fused score favors newer content.
+// publishedAt in the future (clock skew) is treated as age zero.
+func recencyDecay(publishedAt time.Time) float64 {
+	ageDays := time.Since(publishedAt).Hours() / 24
+	if ageDays < 0 {
+		ageDays = 0
+	}
+	return math.Exp(-recencyDecayLambda * ageDays)
+}
@@ -0,0 +1,128 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestListAssetsEncodesFiltersAndTags(t *testing.T) {
+	var gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		json.NewEncoder(w).Encode(ListAssetsResponse{})
+	}))
+	defer server.Close()
+
+	client := NewMediaManagerClient(server.URL, "key", "tenant", nil)
+	_, err := client.ListAssets(context.Background(), ListAssetsOptions{
+		Type:  "image",
+		Tags:  []string{"news", "breaking"},
+		Limit: 25,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	q, err := url.ParseQuery(gotQuery)
+	if err != nil {
+		t.Fatalf("failed to parse recorded query: %v", err)
+	}
+	if q.Get("type") != "image" {
+		t.Fatalf("expected type=image, got %q", q.Get("type"))
+	}
+	if q.Get("limit") != "25" {
+		t.Fatalf("expected limit=25, got %q", q.Get("limit"))
+	}
+	if got := q["tag"]; len(got) != 2 || got[0] != "news" || got[1] != "breaking" {
+		t.Fatalf("expected tag=news&tag=breaking, got %v", got)
+	}
+}
+
+func TestListAssetsCursorTakesPrecedenceOverOffset(t *testing.T) {
+	var gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		json.NewEncoder(w).Encode(ListAssetsResponse{})
+	}))
+	defer server.Close()
+
+	client := NewMediaManagerClient(server.URL, "key", "tenant", nil)
+	_, err := client.ListAssets(context.Background(), ListAssetsOptions{Cursor: "abc", Offset: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	q, err := url.ParseQuery(gotQuery)
+	if err != nil {
+		t.Fatalf("failed to parse recorded query: %v", err)
+	}
+	if q.Get("cursor") != "abc" {
+		t.Fatalf("expected cursor=abc, got %q", q.Get("cursor"))
+	}
+	if q.Has("offset") {
+		t.Fatalf("expected offset to be omitted when cursor is set, got %q", q.Get("offset"))
+	}
+}
+
+func TestIterateAssetsPagesThroughCursors(t *testing.T) {
+	pages := [][]MediaAsset{
+		{{ID: "a"}, {ID: "b"}},
+		{{ID: "c"}},
+	}
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := ListAssetsResponse{Items: pages[calls]}
+		if calls < len(pages)-1 {
+			resp.NextCursor = "next"
+		}
+		calls++
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewMediaManagerClient(server.URL, "key", "tenant", nil)
+
+	var ids []string
+	for asset, err := range client.IterateAssets(context.Background(), ListAssetsOptions{}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, asset.ID)
+	}
+
+	if len(ids) != 3 || ids[0] != "a" || ids[1] != "b" || ids[2] != "c" {
+		t.Fatalf("expected [a b c], got %v", ids)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 pages fetched, got %d", calls)
+	}
+}
+
+func TestIterateAssetsStopsOnYieldFalse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ListAssetsResponse{
+			Items:      []MediaAsset{{ID: "a"}, {ID: "b"}},
+			NextCursor: "next",
+		})
+	}))
+	defer server.Close()
+
+	client := NewMediaManagerClient(server.URL, "key", "tenant", nil)
+
+	var ids []string
+	for asset, err := range client.IterateAssets(context.Background(), ListAssetsOptions{}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, asset.ID)
+		break
+	}
+
+	if len(ids) != 1 || ids[0] != "a" {
+		t.Fatalf("expected iteration to stop after one item, got %v", ids)
+	}
+}
@@ -0,0 +1,232 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// MastodonIntegration fetches public statuses from any Mastodon
+// instance's REST API. Reading public statuses needs no access token,
+// since Mastodon (unlike Twitter/Reddit) serves a federated account's
+// public timeline to anonymous callers by design.
+type MastodonIntegration struct {
+	httpClient *http.Client
+	logger     *zap.Logger
+}
+
+// NewMastodonIntegration creates a new Mastodon integration. A single
+// instance can fetch from any Mastodon server, since the instance host
+// is passed per call rather than fixed at construction.
+func NewMastodonIntegration(logger *zap.Logger) *MastodonIntegration {
+	return &MastodonIntegration{
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		logger:     logger,
+	}
+}
+
+// MastodonStatus represents a single public status (toot).
+type MastodonStatus struct {
+	ID              string
+	Content         string // sanitized HTML, per Mastodon's API
+	URL             string
+	CreatedAt       time.Time
+	AccountID       string
+	Username        string
+	DisplayName     string
+	AvatarURL       string
+	RepliesCount    int64
+	ReblogsCount    int64
+	FavouritesCount int64
+	Tags            []string
+	MediaURL        string
+}
+
+// GetAccountStatuses looks up username on instanceHost's public API and
+// returns up to limit of their recent top-level (non-reply) statuses.
+func (mi *MastodonIntegration) GetAccountStatuses(ctx context.Context, instanceHost, username string, limit int) ([]MastodonStatus, error) {
+	account, err := mi.lookupAccount(ctx, instanceHost, username)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("https://%s/api/v1/accounts/%s/statuses?limit=%d&exclude_replies=true",
+		instanceHost, url.PathEscape(account.ID), limit)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := mi.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch mastodon statuses: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("mastodon statuses request failed: %s - %s", resp.Status, string(body))
+	}
+
+	var raw []struct {
+		ID              string    `json:"id"`
+		Content         string    `json:"content"`
+		URL             string    `json:"url"`
+		CreatedAt       time.Time `json:"created_at"`
+		RepliesCount    int64     `json:"replies_count"`
+		ReblogsCount    int64     `json:"reblogs_count"`
+		FavouritesCount int64     `json:"favourites_count"`
+		Tags            []struct {
+			Name string `json:"name"`
+		} `json:"tags"`
+		MediaAttachments []struct {
+			URL string `json:"url"`
+		} `json:"media_attachments"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to parse mastodon statuses: %w", err)
+	}
+
+	statuses := make([]MastodonStatus, 0, len(raw))
+	for _, s := range raw {
+		status := MastodonStatus{
+			ID:              s.ID,
+			Content:         s.Content,
+			URL:             s.URL,
+			CreatedAt:       s.CreatedAt,
+			AccountID:       account.ID,
+			Username:        account.Username,
+			DisplayName:     account.DisplayName,
+			AvatarURL:       account.AvatarURL,
+			RepliesCount:    s.RepliesCount,
+			ReblogsCount:    s.ReblogsCount,
+			FavouritesCount: s.FavouritesCount,
+		}
+		for _, t := range s.Tags {
+			status.Tags = append(status.Tags, t.Name)
+		}
+		if len(s.MediaAttachments) > 0 {
+			status.MediaURL = s.MediaAttachments[0].URL
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// CreateStatus posts text to instanceHost as accessToken's user,
+// attaching mediaIDs (previously uploaded via instanceHost's
+// /api/v2/media endpoint) if any.
+func (mi *MastodonIntegration) CreateStatus(ctx context.Context, instanceHost, accessToken, text string, mediaIDs []string) (*MastodonStatus, error) {
+	payload := map[string]interface{}{"status": text}
+	if len(mediaIDs) > 0 {
+		payload["media_ids"] = mediaIDs
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("https://%s/api/v1/statuses", instanceHost)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(string(body)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := mi.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to post mastodon status: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to post mastodon status: %s - %s", resp.Status, string(respBody))
+	}
+
+	var raw struct {
+		ID        string    `json:"id"`
+		Content   string    `json:"content"`
+		URL       string    `json:"url"`
+		CreatedAt time.Time `json:"created_at"`
+		Account   struct {
+			ID          string `json:"id"`
+			Username    string `json:"username"`
+			DisplayName string `json:"display_name"`
+			Avatar      string `json:"avatar"`
+		} `json:"account"`
+	}
+	if err := json.Unmarshal(respBody, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse mastodon status response: %w", err)
+	}
+
+	return &MastodonStatus{
+		ID:          raw.ID,
+		Content:     raw.Content,
+		URL:         raw.URL,
+		CreatedAt:   raw.CreatedAt,
+		AccountID:   raw.Account.ID,
+		Username:    raw.Account.Username,
+		DisplayName: raw.Account.DisplayName,
+		AvatarURL:   raw.Account.Avatar,
+	}, nil
+}
+
+type mastodonAccount struct {
+	ID          string
+	Username    string
+	DisplayName string
+	AvatarURL   string
+}
+
+// lookupAccount resolves username to an account ID via the
+// instance-local lookup endpoint (no search across instances is
+// needed, since the caller already knows which instance username is
+// on).
+func (mi *MastodonIntegration) lookupAccount(ctx context.Context, instanceHost, username string) (*mastodonAccount, error) {
+	endpoint := fmt.Sprintf("https://%s/api/v1/accounts/lookup?acct=%s", instanceHost, url.QueryEscape(username))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := mi.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up mastodon account: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("mastodon account lookup failed: %s - %s", resp.Status, string(body))
+	}
+
+	var account struct {
+		ID          string `json:"id"`
+		Username    string `json:"username"`
+		DisplayName string `json:"display_name"`
+		Avatar      string `json:"avatar"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&account); err != nil {
+		return nil, fmt.Errorf("failed to parse mastodon account: %w", err)
+	}
+
+	return &mastodonAccount{
+		ID:          account.ID,
+		Username:    account.Username,
+		DisplayName: account.DisplayName,
+		AvatarURL:   account.Avatar,
+	}, nil
+}
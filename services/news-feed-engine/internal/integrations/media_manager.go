@@ -3,22 +3,47 @@ package integrations
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"image"
 	"io"
+	"iter"
+	"math/bits"
+	"mime/multipart"
 	"net/http"
+	"net/url"
+	"os"
+	"strconv"
 	"time"
 
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/cdn"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/mediavec"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/resilience"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/rightsengine"
 	"go.uber.org/zap"
 )
 
+// defaultMaxUploadSize bounds UploadAsset/UploadAssetStream when the
+// client hasn't been given an explicit WithMaxUploadSize.
+const defaultMaxUploadSize = 500 * 1024 * 1024 // 500MB
+
+// uploadChunkSize is the size of each PUT in the resumable upload path,
+// mirroring the multipart-upload chunk size used by S3-backed media
+// services. Assets larger than this use the resumable path instead of a
+// single multipart/form-data POST.
+const uploadChunkSize = 5 * 1024 * 1024 // 5MB
+
 // MediaManagerClient provides integration with the Media Manager service
 type MediaManagerClient struct {
-	baseURL    string
-	apiKey     string
-	tenantID   string
-	httpClient *http.Client
-	logger     *zap.Logger
+	baseURL       string
+	apiKey        string
+	tenantID      string
+	httpClient    *http.Client
+	logger        *zap.Logger
+	maxUploadSize int64
+	semanticIndex *mediavec.Index
 }
 
 // MediaAsset represents a unified asset across the platform
@@ -39,6 +64,9 @@ type MediaAsset struct {
 	Performance    *AssetPerformance `json:"performance,omitempty"`
 	CustomTags     []string          `json:"custom_tags"`
 	Metadata       map[string]any    `json:"metadata"`
+	PHash          string            `json:"phash,omitempty"`     // 64-bit DCT perceptual hash, hex-encoded
+	DHash          string            `json:"dhash,omitempty"`     // 64-bit difference hash, hex-encoded
+	BlurHash       string            `json:"blur_hash,omitempty"` // BlurHash placeholder, decodable client-side before the CDN URL loads
 	CreatedAt      time.Time         `json:"created_at"`
 	UpdatedAt      time.Time         `json:"updated_at"`
 }
@@ -63,6 +91,9 @@ type AIAssetAnalysis struct {
 	ContentRating    string           `json:"content_rating"`
 	AutoTags         []string         `json:"auto_tags"`
 	Embeddings       []float32        `json:"embeddings,omitempty"`
+	PHash            string           `json:"phash,omitempty"`
+	DHash            string           `json:"dhash,omitempty"`
+	BlurHash         string           `json:"blur_hash,omitempty"`
 }
 
 // DetectedObject represents an object detected in media
@@ -116,6 +147,11 @@ type AssetPerformance struct {
 	AvgEngagementRate      float64   `json:"avg_engagement_rate"`
 	BestPerformingPlatform string    `json:"best_performing_platform,omitempty"`
 	LastUsed               time.Time `json:"last_used,omitempty"`
+	// BlockedCount counts how many times rightsengine has denied a use
+	// of this asset (expired license, disallowed platform, or a
+	// restriction rule), so repeatedly-blocked assets are visible
+	// without digging through logs.
+	BlockedCount int `json:"blocked_count,omitempty"`
 }
 
 // RecommendedAsset contains an asset with recommendation context
@@ -135,10 +171,39 @@ func NewMediaManagerClient(baseURL, apiKey, tenantID string, logger *zap.Logger)
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		logger: logger,
+		logger:        logger,
+		maxUploadSize: defaultMaxUploadSize,
 	}
 }
 
+// WithMaxUploadSize overrides the default 500MB cap UploadAsset and
+// UploadAssetStream enforce on asset size.
+func (c *MediaManagerClient) WithMaxUploadSize(maxBytes int64) *MediaManagerClient {
+	c.maxUploadSize = maxBytes
+	return c
+}
+
+// WithSemanticIndex attaches a per-tenant mediavec.Index that
+// SemanticSearchHybrid queries locally and that SyncAssetFromContent and
+// AnalyzeAsset keep warm via hot-reload as new embeddings come in. A nil
+// index (the default) makes SemanticSearchHybrid behave exactly like
+// SemanticSearch.
+func (c *MediaManagerClient) WithSemanticIndex(idx *mediavec.Index) *MediaManagerClient {
+	c.semanticIndex = idx
+	return c
+}
+
+// WithClientOptions wraps the client's http.Client.Transport in a
+// resilience.Transport configured by opts, adding retries, a per-host
+// circuit breaker, per-endpoint-group rate limiting, client tracing
+// spans, and GET hedging around every request this client makes. Safe
+// to call at most once; a second call wraps the already-wrapped
+// transport.
+func (c *MediaManagerClient) WithClientOptions(opts resilience.ClientOptions) *MediaManagerClient {
+	c.httpClient.Transport = resilience.NewTransport(opts, c.httpClient.Transport)
+	return c
+}
+
 // GetAsset retrieves an asset by ID
 func (c *MediaManagerClient) GetAsset(ctx context.Context, assetID string) (*MediaAsset, error) {
 	req, err := c.newRequest(ctx, "GET", fmt.Sprintf("/api/v1/assets/%s", assetID), nil)
@@ -154,38 +219,82 @@ func (c *MediaManagerClient) GetAsset(ctx context.Context, assetID string) (*Med
 	return &asset, nil
 }
 
-// ListAssets lists assets with optional filtering
+// ListAssetsOptions filters and paginates ListAssets. Cursor and Offset
+// are mutually exclusive pagination modes; if Cursor is set it takes
+// precedence, since it's stable under concurrent inserts in a way
+// offset paging isn't.
 type ListAssetsOptions struct {
-	Type   string   `json:"type,omitempty"`
-	Tags   []string `json:"tags,omitempty"`
-	Limit  int      `json:"limit,omitempty"`
-	Offset int      `json:"offset,omitempty"`
+	Type            string    `json:"type,omitempty"`
+	Tags            []string  `json:"tags,omitempty"`
+	MimeTypes       []string  `json:"mime_types,omitempty"`
+	CreatedAfter    time.Time `json:"created_after,omitempty"`
+	CreatedBefore   time.Time `json:"created_before,omitempty"`
+	MinWidth        int       `json:"min_width,omitempty"`
+	MinHeight       int       `json:"min_height,omitempty"`
+	UsageRightsType string    `json:"usage_rights_type,omitempty"`
+	// Sort is a field name, optionally "-"-prefixed for descending (e.g.
+	// "-created_at", "usage_count").
+	Sort string `json:"sort,omitempty"`
+	// Cursor resumes a previous ListAssets/IterateAssets call from its
+	// NextCursor.
+	Cursor string `json:"cursor,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+	Offset int    `json:"offset,omitempty"`
 }
 
 type ListAssetsResponse struct {
 	Items []MediaAsset `json:"items"`
 	Total int          `json:"total"`
+	// NextCursor is non-empty when more results are available; pass it
+	// back as ListAssetsOptions.Cursor to fetch the next page.
+	NextCursor string `json:"next_cursor"`
 }
 
 func (c *MediaManagerClient) ListAssets(ctx context.Context, opts ListAssetsOptions) (*ListAssetsResponse, error) {
-	url := "/api/v1/assets"
-	params := []string{}
+	q := url.Values{}
 
 	if opts.Type != "" {
-		params = append(params, fmt.Sprintf("type=%s", opts.Type))
+		q.Set("type", opts.Type)
+	}
+	for _, tag := range opts.Tags {
+		q.Add("tag", tag)
+	}
+	for _, mimeType := range opts.MimeTypes {
+		q.Add("mime_type", mimeType)
+	}
+	if !opts.CreatedAfter.IsZero() {
+		q.Set("created_after", opts.CreatedAfter.Format(time.RFC3339))
+	}
+	if !opts.CreatedBefore.IsZero() {
+		q.Set("created_before", opts.CreatedBefore.Format(time.RFC3339))
+	}
+	if opts.MinWidth > 0 {
+		q.Set("min_width", strconv.Itoa(opts.MinWidth))
+	}
+	if opts.MinHeight > 0 {
+		q.Set("min_height", strconv.Itoa(opts.MinHeight))
+	}
+	if opts.UsageRightsType != "" {
+		q.Set("usage_rights_type", opts.UsageRightsType)
+	}
+	if opts.Sort != "" {
+		q.Set("sort", opts.Sort)
 	}
 	if opts.Limit > 0 {
-		params = append(params, fmt.Sprintf("limit=%d", opts.Limit))
+		q.Set("limit", strconv.Itoa(opts.Limit))
 	}
-	if opts.Offset > 0 {
-		params = append(params, fmt.Sprintf("offset=%d", opts.Offset))
+	if opts.Cursor != "" {
+		q.Set("cursor", opts.Cursor)
+	} else if opts.Offset > 0 {
+		q.Set("offset", strconv.Itoa(opts.Offset))
 	}
 
-	if len(params) > 0 {
-		url += "?" + joinParams(params)
+	path := "/api/v1/assets"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
 	}
 
-	req, err := c.newRequest(ctx, "GET", url, nil)
+	req, err := c.newRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -198,6 +307,34 @@ func (c *MediaManagerClient) ListAssets(ctx context.Context, opts ListAssetsOpti
 	return &response, nil
 }
 
+// IterateAssets pages through every asset matching opts via ListAssets'
+// cursor pagination, yielding one (asset, nil) pair per asset. A
+// ListAssets failure yields a single (nil, err) pair and stops
+// iteration. opts.Cursor and opts.Offset are overwritten as iteration
+// proceeds; the caller's copy is left untouched.
+func (c *MediaManagerClient) IterateAssets(ctx context.Context, opts ListAssetsOptions) iter.Seq2[*MediaAsset, error] {
+	return func(yield func(*MediaAsset, error) bool) {
+		for {
+			page, err := c.ListAssets(ctx, opts)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for i := range page.Items {
+				if !yield(&page.Items[i], nil) {
+					return
+				}
+			}
+
+			if page.NextCursor == "" {
+				return
+			}
+			opts.Cursor = page.NextCursor
+		}
+	}
+}
+
 // SemanticSearch performs semantic search for assets
 type SemanticSearchRequest struct {
 	Query     string    `json:"query,omitempty"`
@@ -227,6 +364,49 @@ func (c *MediaManagerClient) SemanticSearch(ctx context.Context, search Semantic
 	return response.Items, nil
 }
 
+// HybridOptions configures SemanticSearchHybrid's reciprocal rank
+// fusion of its keyword and vector retrievers.
+type HybridOptions struct {
+	// K is the RRF k constant; K <= 0 uses mediavec.DefaultRRFK.
+	K int
+}
+
+// SemanticSearchHybrid runs search against the locally attached
+// semantic index (see WithSemanticIndex), combining BM25 keyword
+// search over auto/custom tags and extracted text with ANN search over
+// embeddings via reciprocal rank fusion. If no index is attached, it
+// falls back to SemanticSearch against the Media Manager itself.
+func (c *MediaManagerClient) SemanticSearchHybrid(ctx context.Context, search SemanticSearchRequest, opts HybridOptions) ([]MediaAsset, error) {
+	if c.semanticIndex == nil {
+		return c.SemanticSearch(ctx, search)
+	}
+
+	limit := search.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+
+	queryTokens := mediavec.Tokenize(search.Query)
+	fused := c.semanticIndex.Search(search.Embedding, queryTokens, limit, opts.K)
+
+	assets := make([]MediaAsset, 0, len(fused))
+	for _, result := range fused {
+		asset, err := c.GetAsset(ctx, result.ID)
+		if err != nil {
+			c.logger.Warn("hybrid search: failed to hydrate indexed asset, skipping",
+				zap.String("asset_id", result.ID),
+				zap.Error(err),
+			)
+			continue
+		}
+		if search.Type != "" && asset.Type != search.Type {
+			continue
+		}
+		assets = append(assets, *asset)
+	}
+	return assets, nil
+}
+
 // GetRecommendations gets asset recommendations for content
 type RecommendationRequest struct {
 	ContentText     string `json:"content_text"`
@@ -267,17 +447,227 @@ type UploadAssetRequest struct {
 }
 
 func (c *MediaManagerClient) UploadAsset(ctx context.Context, upload UploadAssetRequest) (*MediaAsset, error) {
-	// For multipart upload, we'd use a different implementation
-	// This is a placeholder showing the structure
+	return c.UploadAssetStream(ctx, bytes.NewReader(upload.FileData), upload)
+}
+
+// UploadAssetStream uploads an asset read from r, so callers don't have
+// to buffer the entire file in memory the way UploadAsset's []byte
+// FileData does. It spools r to a temp file while hashing it with
+// SHA-256 (via io.MultiWriter), rejects anything over maxUploadSize,
+// then short-circuits the transfer with a HEAD /api/v1/assets/by-hash/{sha256}
+// dedup check before choosing between a single multipart/form-data POST
+// (small assets) and a resumable, chunked upload (anything bigger than
+// one uploadChunkSize chunk).
+func (c *MediaManagerClient) UploadAssetStream(ctx context.Context, r io.Reader, upload UploadAssetRequest) (*MediaAsset, error) {
+	tmp, err := os.CreateTemp("", "media-upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upload spool file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hasher), io.LimitReader(r, c.maxUploadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read asset data: %w", err)
+	}
+	if size > c.maxUploadSize {
+		return nil, fmt.Errorf("asset exceeds max upload size of %d bytes", c.maxUploadSize)
+	}
+	contentHash := hex.EncodeToString(hasher.Sum(nil))
+
+	if asset, deduped, err := c.findByHash(ctx, contentHash); err != nil {
+		return nil, err
+	} else if deduped {
+		c.logger.Info("asset already uploaded, skipping transfer",
+			zap.String("sha256", contentHash),
+			zap.String("asset_id", asset.ID),
+		)
+		return asset, nil
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to rewind upload spool file: %w", err)
+	}
+
+	if size > uploadChunkSize {
+		return c.uploadChunked(ctx, tmp, size, contentHash, upload)
+	}
+	return c.uploadMultipart(ctx, tmp, contentHash, upload)
+}
+
+// findByHash checks whether the tenant already has an asset with the
+// given content hash, so UploadAssetStream can skip re-transferring
+// bytes the Media Manager already stored.
+func (c *MediaManagerClient) findByHash(ctx context.Context, contentHash string) (*MediaAsset, bool, error) {
+	req, err := c.newRequest(ctx, http.MethodHead, fmt.Sprintf("/api/v1/assets/by-hash/%s", contentHash), nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("dedup check failed: %w", err)
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return nil, false, nil
+	case http.StatusOK:
+		assetID := resp.Header.Get("X-Asset-Id")
+		if assetID == "" {
+			return nil, false, fmt.Errorf("dedup check returned 200 without an X-Asset-Id header")
+		}
+		asset, err := c.GetAsset(ctx, assetID)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to fetch deduped asset %s: %w", assetID, err)
+		}
+		return asset, true, nil
+	default:
+		return nil, false, fmt.Errorf("dedup check returned status %d", resp.StatusCode)
+	}
+}
+
+// uploadMultipart streams r as a single multipart/form-data POST via an
+// io.Pipe, so the encoded form body is never buffered in full either.
+func (c *MediaManagerClient) uploadMultipart(ctx context.Context, r io.Reader, contentHash string, upload UploadAssetRequest) (*MediaAsset, error) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		err := func() error {
+			part, err := mw.CreateFormFile("file", upload.Filename)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(part, r); err != nil {
+				return err
+			}
+			if err := mw.WriteField("type", upload.Type); err != nil {
+				return err
+			}
+			if err := mw.WriteField("sha256", contentHash); err != nil {
+				return err
+			}
+			for _, tag := range upload.Tags {
+				if err := mw.WriteField("tags", tag); err != nil {
+					return err
+				}
+			}
+			if len(upload.Metadata) > 0 {
+				metadataJSON, err := json.Marshal(upload.Metadata)
+				if err != nil {
+					return err
+				}
+				if err := mw.WriteField("metadata", string(metadataJSON)); err != nil {
+					return err
+				}
+			}
+			return mw.Close()
+		}()
+		pw.CloseWithError(err)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/v1/assets", pr)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("X-Tenant-ID", c.tenantID)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	var asset MediaAsset
+	if err := c.doRequest(req, &asset); err != nil {
+		return nil, fmt.Errorf("multipart upload failed: %w", err)
+	}
+	return &asset, nil
+}
+
+// initUploadResponse is the Media Manager's response to initiating a
+// resumable upload session.
+type initUploadResponse struct {
+	UploadID string `json:"upload_id"`
+}
+
+// uploadChunked runs the resumable path for assets larger than
+// uploadChunkSize: initiate a session, PUT uploadChunkSize chunks with
+// Content-Range, then finalize by content hash - mirroring the
+// multipart-upload pattern used by S3-backed media services, without
+// ever holding more than one chunk of r in memory.
+func (c *MediaManagerClient) uploadChunked(ctx context.Context, r io.Reader, size int64, contentHash string, upload UploadAssetRequest) (*MediaAsset, error) {
+	initBody, err := json.Marshal(map[string]any{
+		"filename": upload.Filename,
+		"type":     upload.Type,
+		"size":     size,
+		"sha256":   contentHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	initReq, err := c.newRequest(ctx, http.MethodPost, "/api/v1/assets/uploads", bytes.NewReader(initBody))
+	if err != nil {
+		return nil, err
+	}
+
+	var session initUploadResponse
+	if err := c.doRequest(initReq, &session); err != nil {
+		return nil, fmt.Errorf("failed to initiate resumable upload: %w", err)
+	}
+
+	buf := make([]byte, uploadChunkSize)
+	var offset int64
+	for offset < size {
+		n, err := io.ReadFull(r, buf)
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("failed to read chunk at offset %d: %w", offset, err)
+		}
+		if n == 0 {
+			return nil, fmt.Errorf("unexpected end of asset data at offset %d (expected %d bytes total)", offset, size)
+		}
 
-	c.logger.Info("Uploading asset to Media Manager",
-		zap.String("filename", upload.Filename),
-		zap.String("type", upload.Type),
-		zap.Int("size", len(upload.FileData)),
+		chunkReq, err := http.NewRequestWithContext(ctx, http.MethodPut,
+			fmt.Sprintf("%s/api/v1/assets/uploads/%s", c.baseURL, session.UploadID),
+			bytes.NewReader(buf[:n]))
+		if err != nil {
+			return nil, err
+		}
+		chunkReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+		chunkReq.Header.Set("X-Tenant-ID", c.tenantID)
+		chunkReq.Header.Set("Content-Type", "application/octet-stream")
+		chunkReq.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+int64(n)-1, size))
+
+		if err := c.doRequest(chunkReq, nil); err != nil {
+			return nil, fmt.Errorf("failed to upload chunk at offset %d: %w", offset, err)
+		}
+
+		offset += int64(n)
+	}
+
+	finalizeBody, err := json.Marshal(map[string]string{"sha256": contentHash})
+	if err != nil {
+		return nil, err
+	}
+
+	finalizeReq, err := c.newRequest(ctx, http.MethodPost,
+		fmt.Sprintf("/api/v1/assets/uploads/%s/finalize", session.UploadID), bytes.NewReader(finalizeBody))
+	if err != nil {
+		return nil, err
+	}
+
+	var asset MediaAsset
+	if err := c.doRequest(finalizeReq, &asset); err != nil {
+		return nil, fmt.Errorf("failed to finalize resumable upload: %w", err)
+	}
+
+	c.logger.Info("resumable asset upload complete",
+		zap.String("upload_id", session.UploadID),
+		zap.String("sha256", contentHash),
+		zap.Int64("size", size),
 	)
 
-	// Placeholder - actual implementation would use multipart form
-	return nil, fmt.Errorf("upload not implemented - use multipart form")
+	return &asset, nil
 }
 
 // AnalyzeAsset triggers AI analysis for an asset
@@ -292,9 +682,38 @@ func (c *MediaManagerClient) AnalyzeAsset(ctx context.Context, assetID string) (
 		return nil, err
 	}
 
+	c.publishToSemanticIndex(assetID, &analysis, nil)
+
 	return &analysis, nil
 }
 
+// publishToSemanticIndex hot-reloads the attached semantic index (if
+// any) with assetID's latest embedding and text fields. usageRights may
+// be nil if the caller doesn't have it to hand; eviction on expiry only
+// applies once it's known. A nil semanticIndex or an analysis without
+// embeddings is a no-op.
+func (c *MediaManagerClient) publishToSemanticIndex(assetID string, analysis *AIAssetAnalysis, usageRights *UsageRights) {
+	if c.semanticIndex == nil || analysis == nil || len(analysis.Embeddings) == 0 {
+		return
+	}
+
+	texts := append([]string{}, analysis.AutoTags...)
+	for _, extracted := range analysis.Text {
+		texts = append(texts, extracted.Text)
+	}
+
+	var expiresAt time.Time
+	if usageRights != nil {
+		expiresAt = usageRights.ExpiresAt
+	}
+
+	c.semanticIndex.Publish(mediavec.Document{
+		ID:        assetID,
+		Embedding: analysis.Embeddings,
+		Text:      mediavec.Tokenize(texts...),
+	}, expiresAt)
+}
+
 // SyncAssetFromContent syncs an asset from content
 func (c *MediaManagerClient) SyncAssetFromContent(ctx context.Context, content *UnifiedContent) (*MediaAsset, error) {
 	// Determine asset type from content type
@@ -312,14 +731,34 @@ func (c *MediaManagerClient) SyncAssetFromContent(ctx context.Context, content *
 		"author_name":     content.AuthorName,
 	}
 
-	// Create asset record
-	body, err := json.Marshal(map[string]any{
+	syncFields := map[string]any{
 		"url":       content.ThumbnailURL,
 		"type":      assetType,
 		"tags":      content.Tags,
 		"metadata":  metadata,
 		"source_id": content.ID,
-	})
+	}
+
+	// Perceptual hashes let the feed engine detect cross-platform
+	// duplicate media that differ only by re-encoding, and a BlurHash
+	// gives the UI an instant placeholder before the CDN URL loads -
+	// both computed locally so they don't depend on the Media Manager
+	// having fetched and decoded the image itself yet.
+	var blurHash, dHash, pHash string
+	if assetType == "image" && content.ThumbnailURL != "" {
+		blurHash, dHash, pHash = c.computeImageHashes(ctx, content.ThumbnailURL)
+		if blurHash != "" {
+			syncFields["blur_hash"] = blurHash
+		}
+		if dHash != "" {
+			syncFields["dhash"] = dHash
+		}
+		if pHash != "" {
+			syncFields["phash"] = pHash
+		}
+	}
+
+	body, err := json.Marshal(syncFields)
 	if err != nil {
 		return nil, err
 	}
@@ -334,19 +773,105 @@ func (c *MediaManagerClient) SyncAssetFromContent(ctx context.Context, content *
 		return nil, err
 	}
 
+	// The Media Manager may not echo back hashes it didn't compute
+	// itself, so fall back to what we already have locally.
+	if asset.BlurHash == "" {
+		asset.BlurHash = blurHash
+	}
+	if asset.DHash == "" {
+		asset.DHash = dHash
+	}
+	if asset.PHash == "" {
+		asset.PHash = pHash
+	}
+
 	c.logger.Info("Asset synced to Media Manager",
 		zap.String("asset_id", asset.ID),
 		zap.String("content_id", content.ID),
 	)
 
+	c.publishToSemanticIndex(asset.ID, asset.AIAnalysis, asset.UsageRights)
+
 	return &asset, nil
 }
 
+// computeImageHashes fetches imageURL and computes its BlurHash, DHash,
+// and PHash. Any failure (network, decode, unsupported format) is
+// logged and treated as "no hash available" rather than failing the
+// sync - the hashes are a best-effort enrichment, not required for the
+// asset record to exist.
+func (c *MediaManagerClient) computeImageHashes(ctx context.Context, imageURL string) (blurHash, dHash, pHash string) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		c.logger.Warn("failed to build image fetch request for perceptual hashing", zap.Error(err))
+		return "", "", ""
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logger.Warn("failed to fetch image for perceptual hashing", zap.String("url", imageURL), zap.Error(err))
+		return "", "", ""
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		c.logger.Warn("image fetch returned non-200 status for perceptual hashing",
+			zap.String("url", imageURL), zap.Int("status", resp.StatusCode))
+		return "", "", ""
+	}
+
+	img, _, err := image.Decode(resp.Body)
+	if err != nil {
+		c.logger.Warn("failed to decode image for perceptual hashing", zap.String("url", imageURL), zap.Error(err))
+		return "", "", ""
+	}
+
+	blurHash, err = EncodeBlurHash(img, 4, 3)
+	if err != nil {
+		c.logger.Warn("failed to compute blurhash", zap.String("url", imageURL), zap.Error(err))
+		blurHash = ""
+	}
+
+	return blurHash, ComputeDHash(img), ComputePHash(img)
+}
+
+// FindSimilar returns assets whose perceptual hash is within threshold
+// Hamming distance of assetID's, for finding cross-platform duplicate
+// media that differ only by re-encoding.
+func (c *MediaManagerClient) FindSimilar(ctx context.Context, assetID string, threshold int) ([]MediaAsset, error) {
+	path := fmt.Sprintf("/api/v1/assets/%s/similar?threshold=%d", assetID, threshold)
+	req, err := c.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Items []MediaAsset `json:"items"`
+	}
+	if err := c.doRequest(req, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Items, nil
+}
+
+// PHashDistance returns the Hamming distance between two hex-encoded
+// 64-bit perceptual hashes (PHash or DHash), so callers can compare
+// locally without a round trip through FindSimilar.
+func (c *MediaManagerClient) PHashDistance(a, b string) int {
+	va, errA := strconv.ParseUint(a, 16, 64)
+	vb, errB := strconv.ParseUint(b, 16, 64)
+	if errA != nil || errB != nil {
+		return 64
+	}
+	return bits.OnesCount64(va ^ vb)
+}
+
 // Helper methods
 
 func (c *MediaManagerClient) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
-	url := c.baseURL + path
-	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	fullURL := c.baseURL + path
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, body)
 	if err != nil {
 		return nil, err
 	}
@@ -379,17 +904,6 @@ func (c *MediaManagerClient) doRequest(req *http.Request, result any) error {
 	return nil
 }
 
-func joinParams(params []string) string {
-	result := ""
-	for i, p := range params {
-		if i > 0 {
-			result += "&"
-		}
-		result += p
-	}
-	return result
-}
-
 // MediaManagerIntegration provides high-level integration methods
 type MediaManagerIntegration struct {
 	client     *MediaManagerClient
@@ -398,11 +912,100 @@ type MediaManagerIntegration struct {
 	logger     *zap.Logger
 }
 
-// CDNManager manages CDN uploads and optimizations
+// CDNManager pushes asset variants to a pluggable cdn.Provider
+// (CloudFront, Cloudflare, or Bunny.net, chosen by the DSN scheme passed
+// to cdn.Open) and populates AssetURLs from a fixed preset table, so
+// every asset gets the same deterministic set of derived URLs
+// regardless of which CDN backend is configured.
 type CDNManager struct {
-	cdnBaseURL string
-	cdnAPIKey  string
-	logger     *zap.Logger
+	provider cdn.Provider
+	logger   *zap.Logger
+}
+
+// NewCDNManager creates a CDNManager over provider (typically built with
+// cdn.Open against a "cloudfront://", "cloudflare://", or "bunny://"
+// DSN). provider may be nil, in which case PopulateVariants is a no-op
+// that leaves AssetURLs at whatever the Media Manager already returned.
+func NewCDNManager(provider cdn.Provider, logger *zap.Logger) *CDNManager {
+	return &CDNManager{provider: provider, logger: logger}
+}
+
+// cdnThumbnailPresets and cdnOptimizedPresets are the fixed mapping from
+// an AssetURLs bucket to the shared preset table in package cdn.
+var (
+	cdnThumbnailPresets = map[string]string{"small": "thumb_sm", "medium": "thumb_md"}
+	cdnOptimizedPresets = map[string]string{"webp": "webp_1080", "avif": "avif_square"}
+)
+
+// PopulateVariants mirrors asset's original to the CDN and fills in
+// AssetURLs.CDN, Optimized, and Thumbnails deterministically from the
+// preset tables above. A nil provider is a no-op.
+func (m *CDNManager) PopulateVariants(ctx context.Context, asset *MediaAsset) error {
+	if m.provider == nil {
+		return nil
+	}
+	if asset.URLs.Original == "" {
+		return fmt.Errorf("cdn: asset %s has no original URL to mirror", asset.ID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, asset.URLs.Original, nil)
+	if err != nil {
+		return fmt.Errorf("cdn: failed to build origin fetch request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("cdn: failed to fetch origin asset: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("cdn: origin asset fetch returned status %d", resp.StatusCode)
+	}
+
+	cdnURL, err := m.provider.Mirror(ctx, fmt.Sprintf("assets/%s/original", asset.ID), asset.ID, resp.Body)
+	if err != nil {
+		return fmt.Errorf("cdn: failed to mirror asset to CDN: %w", err)
+	}
+	asset.URLs.CDN = cdnURL
+
+	thumbnails := make(map[string]string, len(cdnThumbnailPresets))
+	for size, preset := range cdnThumbnailPresets {
+		variantURL, err := m.provider.TransformURL(cdnURL, preset)
+		if err != nil {
+			return fmt.Errorf("cdn: failed to build %s thumbnail url: %w", size, err)
+		}
+		thumbnails[size] = variantURL
+	}
+	asset.URLs.Thumbnails = thumbnails
+
+	optimized := make(map[string]string, len(cdnOptimizedPresets))
+	for format, preset := range cdnOptimizedPresets {
+		variantURL, err := m.provider.TransformURL(cdnURL, preset)
+		if err != nil {
+			return fmt.Errorf("cdn: failed to build %s variant url: %w", format, err)
+		}
+		optimized[format] = variantURL
+	}
+	asset.URLs.Optimized = optimized
+
+	return nil
+}
+
+// PurgeVariants purges every cached variant of asset from the CDN, e.g.
+// after the origin asset has been replaced.
+func (m *CDNManager) PurgeVariants(ctx context.Context, assetID string) error {
+	if m.provider == nil {
+		return nil
+	}
+	return m.provider.PurgeByTag(ctx, assetID)
+}
+
+// SignedURL returns a time-limited, optionally IP-restricted URL for
+// path from the configured CDN provider.
+func (m *CDNManager) SignedURL(path string, expiry time.Time, clientIP string) (string, error) {
+	if m.provider == nil {
+		return "", fmt.Errorf("cdn: no provider configured")
+	}
+	return m.provider.SignedURL(path, expiry, clientIP)
 }
 
 // AIAssetAnalyzer provides AI analysis for assets
@@ -410,19 +1013,18 @@ type AIAssetAnalyzer struct {
 	logger *zap.Logger
 }
 
-// NewMediaManagerIntegration creates a new integration instance
+// NewMediaManagerIntegration creates a new integration instance.
+// cdnProvider is typically built with cdn.Open against a
+// "cloudfront://", "cloudflare://", or "bunny://" DSN; pass nil to
+// disable CDN variant population.
 func NewMediaManagerIntegration(
 	baseURL, apiKey, tenantID string,
-	cdnBaseURL, cdnAPIKey string,
+	cdnProvider cdn.Provider,
 	logger *zap.Logger,
 ) *MediaManagerIntegration {
 	return &MediaManagerIntegration{
-		client: NewMediaManagerClient(baseURL, apiKey, tenantID, logger),
-		cdnManager: &CDNManager{
-			cdnBaseURL: cdnBaseURL,
-			cdnAPIKey:  cdnAPIKey,
-			logger:     logger,
-		},
+		client:     NewMediaManagerClient(baseURL, apiKey, tenantID, logger),
+		cdnManager: NewCDNManager(cdnProvider, logger),
 		aiAnalyzer: &AIAssetAnalyzer{logger: logger},
 		logger:     logger,
 	}
@@ -450,6 +1052,33 @@ func (m *MediaManagerIntegration) SyncAsset(ctx context.Context, content *Unifie
 		)
 	} else {
 		asset.AIAnalysis = analysis
+		m.client.publishToSemanticIndex(asset.ID, analysis, asset.UsageRights)
+	}
+
+	// 3. Push variants to the CDN
+	if err := m.cdnManager.PopulateVariants(ctx, asset); err != nil {
+		m.logger.Warn("failed to populate CDN variants, continuing with origin URLs",
+			zap.String("asset_id", asset.ID),
+			zap.Error(err),
+		)
+	}
+
+	// 4. Flag assets synced with an already-expired license so they
+	// don't get picked up by recommendations without anyone noticing.
+	if asset.UsageRights != nil {
+		decision, err := rightsengine.Evaluate(rightsAssetFrom(*asset), rightsengine.UsageContext{PublishAt: time.Now()})
+		if err != nil {
+			m.logger.Warn("rights engine evaluation failed during sync",
+				zap.String("asset_id", asset.ID),
+				zap.Error(err),
+			)
+		} else if !decision.Allowed {
+			m.logger.Warn("asset synced with an expired or restricted license",
+				zap.String("asset_id", asset.ID),
+				zap.String("reason", decision.Reason),
+			)
+			m.recordBlockedUse(ctx, asset.ID)
+		}
 	}
 
 	m.logger.Info("Asset sync complete",
@@ -473,7 +1102,88 @@ func (m *MediaManagerIntegration) GetRecommendationsForContent(
 		Limit:           limit,
 	}
 
-	return m.client.GetRecommendations(ctx, req)
+	recommendations, err := m.client.GetRecommendations(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	usageCtx := rightsengine.UsageContext{
+		TargetPlatform: content.Platform,
+		UsageType:      rightsengine.UsageEditorial,
+		PublishAt:      content.PublishedAt,
+	}
+
+	allowed := make([]RecommendedAsset, 0, len(recommendations))
+	for _, rec := range recommendations {
+		if rec.Asset.UsageRights == nil {
+			allowed = append(allowed, rec)
+			continue
+		}
+
+		decision, err := rightsengine.Evaluate(rightsAssetFrom(rec.Asset), usageCtx)
+		if err != nil {
+			m.logger.Warn("rights engine evaluation failed, excluding asset from recommendations",
+				zap.String("asset_id", rec.Asset.ID),
+				zap.Error(err),
+			)
+			continue
+		}
+		if !decision.Allowed {
+			m.logger.Info("recommendation filtered by usage rights",
+				zap.String("asset_id", rec.Asset.ID),
+				zap.String("reason", decision.Reason),
+			)
+			m.recordBlockedUse(ctx, rec.Asset.ID)
+			continue
+		}
+		allowed = append(allowed, rec)
+	}
+
+	return allowed, nil
+}
+
+// rightsAssetFrom adapts a MediaAsset's UsageRights into the
+// rightsengine.Asset shape Evaluate consumes.
+func rightsAssetFrom(asset MediaAsset) rightsengine.Asset {
+	rights := asset.UsageRights
+	return rightsengine.Asset{
+		ID:                  asset.ID,
+		AttributionRequired: rights.AttributionRequired,
+		AttributionText:     rights.AttributionText,
+		ExpiresAt:           rights.ExpiresAt,
+		AllowedPlatforms:    rights.AllowedPlatforms,
+		Restrictions:        rights.Restrictions,
+	}
+}
+
+// recordBlockedUse increments the asset's AssetPerformance.BlockedCount
+// after a rightsengine denial. Failures are logged and otherwise
+// ignored - a missed counter increment shouldn't fail the caller's
+// recommendation request.
+func (m *MediaManagerIntegration) recordBlockedUse(ctx context.Context, assetID string) {
+	asset, err := m.client.GetAsset(ctx, assetID)
+	if err != nil {
+		m.logger.Warn("failed to load asset to record blocked use", zap.String("asset_id", assetID), zap.Error(err))
+		return
+	}
+	if asset.Performance == nil {
+		asset.Performance = &AssetPerformance{}
+	}
+	asset.Performance.BlockedCount++
+
+	body, err := json.Marshal(map[string]any{"blocked_count": asset.Performance.BlockedCount})
+	if err != nil {
+		m.logger.Warn("failed to encode blocked-use update", zap.String("asset_id", assetID), zap.Error(err))
+		return
+	}
+	req, err := m.client.newRequest(ctx, http.MethodPatch, fmt.Sprintf("/api/v1/assets/%s/performance", assetID), bytes.NewReader(body))
+	if err != nil {
+		m.logger.Warn("failed to build blocked-use update request", zap.String("asset_id", assetID), zap.Error(err))
+		return
+	}
+	if err := m.client.doRequest(req, nil); err != nil {
+		m.logger.Warn("failed to persist blocked use count", zap.String("asset_id", assetID), zap.Error(err))
+	}
 }
 
 func getCategory(content *UnifiedContent) string {
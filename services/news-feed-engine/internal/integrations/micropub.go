@@ -0,0 +1,375 @@
+package integrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// MicropubServer implements the W3C Micropub spec so third-party editors
+// (Quill, Indigenous, iA Writer, ...) can create and manage posts against
+// BlogIntegration without talking its proprietary API directly.
+type MicropubServer struct {
+	blog   *BlogIntegration
+	auth   *IndieAuthVerifier
+	tracer *metrics.TracingProvider
+	logger *zap.Logger
+
+	mediaBaseURL string
+}
+
+// NewMicropubServer creates a Micropub endpoint backed by blog, requiring
+// tokens to be verified by auth.
+func NewMicropubServer(blog *BlogIntegration, auth *IndieAuthVerifier, mediaBaseURL string, logger *zap.Logger) *MicropubServer {
+	tp := metrics.GetGlobalTracingProvider()
+	if tp == nil {
+		tp, _ = metrics.NewTracingProvider(metrics.TracingConfig{})
+	}
+	return &MicropubServer{blog: blog, auth: auth, tracer: tp, logger: logger, mediaBaseURL: mediaBaseURL}
+}
+
+// micropubPost is the internal representation built from either an
+// x-www-form-urlencoded submission or an MF2-JSON body.
+type micropubPost struct {
+	Type       string
+	Content    string
+	Name       string
+	Categories []string
+	InReplyTo  string
+	Slug       string
+	Published  time.Time
+}
+
+// ServeHTTP routes GET (config/source queries) and POST (create/update/
+// delete/undelete, and media uploads) per the Micropub spec. A POST
+// that isn't a media upload is parsed before the token is verified, so
+// the scope check below can see the real action from a JSON body
+// instead of just the query string FormValue would see.
+func (m *MicropubServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := m.tracer.StartSpan(r.Context(), "micropub.request", &metrics.SpanOptions{SpanKind: trace.SpanKindServer})
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	isMedia := strings.HasPrefix(r.URL.Path, "/micropub/media")
+
+	if r.Method == http.MethodPost && !isMedia {
+		post, action, target, err := m.parseRequest(r)
+		if err != nil {
+			m.tracer.EndSpan(span, err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		user, err := m.auth.Verify(r, scopeForAction(action))
+		if err != nil {
+			m.tracer.EndSpan(span, err)
+			http.Error(w, "unauthorized: "+err.Error(), http.StatusUnauthorized)
+			return
+		}
+		m.handlePost(w, r, user, post, action, target)
+		return
+	}
+
+	scope := ""
+	if isMedia {
+		scope = "media"
+	}
+	user, err := m.auth.Verify(r, scope)
+	if err != nil {
+		m.tracer.EndSpan(span, err)
+		http.Error(w, "unauthorized: "+err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		m.handleQuery(w, r)
+	case http.MethodPost:
+		m.handleMedia(w, r, user)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// scopeForAction maps a Micropub "action" to the IndieAuth scope that
+// must be present on the token for that action to be allowed.
+func scopeForAction(action string) string {
+	switch action {
+	case "update":
+		return "update"
+	case "delete", "undelete":
+		return "delete"
+	default:
+		return "create"
+	}
+}
+
+func (m *MicropubServer) handleQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	switch r.URL.Query().Get("q") {
+	case "config":
+		cats, err := m.blog.GetCategories(ctx)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		syndicate := make([]map[string]string, 0, len(cats))
+		for _, c := range cats {
+			syndicate = append(syndicate, map[string]string{"uid": c.Slug, "name": c.Name})
+		}
+		writeJSON(w, map[string]interface{}{
+			"media-endpoint": m.mediaBaseURL,
+			"syndicate-to":   syndicate,
+			"post-types": []map[string]string{
+				{"type": "note", "name": "Note"},
+				{"type": "article", "name": "Article"},
+			},
+		})
+
+	case "source":
+		post, err := m.blog.GetPost(ctx, r.URL.Query().Get("url"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		writeJSON(w, postToMF2(post))
+
+	default:
+		http.Error(w, "unsupported query", http.StatusBadRequest)
+	}
+}
+
+func (m *MicropubServer) handlePost(w http.ResponseWriter, r *http.Request, user string, post micropubPost, action, target string) {
+	ctx, span := m.tracer.StartSpan(r.Context(), "micropub.post", nil)
+	defer span.End()
+
+	switch action {
+	case "update":
+		updates := &BlogPost{
+			Title:      post.Name,
+			Content:    post.Content,
+			Categories: post.Categories,
+		}
+		if _, err := m.blog.UpdatePost(ctx, target, updates); err != nil {
+			m.tracer.EndSpan(span, err)
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case "delete":
+		if err := m.blog.HandleWebhook(&WebhookPayload{Event: "post.deleted", Post: BlogPost{ID: target}}); err != nil {
+			m.tracer.EndSpan(span, err)
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case "undelete":
+		if _, err := m.blog.SetPostStatus(ctx, target, "published"); err != nil {
+			m.tracer.EndSpan(span, err)
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		blogPost := &BlogPost{
+			Title:       post.Name,
+			Content:     post.Content,
+			Slug:        post.Slug,
+			Categories:  post.Categories,
+			Status:      "published",
+			PublishedAt: post.Published,
+			Author:      BlogAuthor{Name: user},
+		}
+		if blogPost.PublishedAt.IsZero() {
+			blogPost.PublishedAt = time.Now()
+		}
+		if err := m.blog.PublishToFeed(ctx, blogPost, ""); err != nil {
+			m.tracer.EndSpan(span, err)
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Location", fmt.Sprintf("%s/blog/%s", m.blog.baseURL, blogPost.Slug))
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+func (m *MicropubServer) parseRequest(r *http.Request) (micropubPost, string, string, error) {
+	contentType := r.Header.Get("Content-Type")
+
+	if strings.HasPrefix(contentType, "application/json") {
+		var mf2 struct {
+			Type       []string            `json:"type"`
+			Action     string              `json:"action"`
+			URL        string              `json:"url"`
+			Properties map[string][]string `json:"properties"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&mf2); err != nil {
+			return micropubPost{}, "", "", fmt.Errorf("invalid mf2 json: %w", err)
+		}
+		post := micropubPost{Type: "entry"}
+		if len(mf2.Properties["content"]) > 0 {
+			post.Content = mf2.Properties["content"][0]
+		}
+		if len(mf2.Properties["name"]) > 0 {
+			post.Name = mf2.Properties["name"][0]
+		}
+		post.Categories = mf2.Properties["category"]
+		return post, mf2.Action, mf2.URL, nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return micropubPost{}, "", "", fmt.Errorf("invalid form body: %w", err)
+	}
+
+	post := micropubPost{
+		Type:       strings.TrimPrefix(r.FormValue("h"), "entry"),
+		Content:    r.FormValue("content"),
+		Name:       r.FormValue("name"),
+		Categories: r.Form["category[]"],
+		InReplyTo:  r.FormValue("in-reply-to"),
+		Slug:       r.FormValue("mp-slug"),
+	}
+	if published := r.FormValue("published"); published != "" {
+		if t, err := time.Parse(time.RFC3339, published); err == nil {
+			post.Published = t
+		}
+	}
+	return post, r.FormValue("action"), r.FormValue("url"), nil
+}
+
+func (m *MicropubServer) handleMedia(w http.ResponseWriter, r *http.Request, user string) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		http.Error(w, "invalid multipart upload: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "missing file part", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	location := fmt.Sprintf("%s/%d-%s", m.mediaBaseURL, time.Now().UnixNano(), sanitizeFilename(header.Filename))
+	m.logger.Info("micropub media upload", zap.String("user", user), zap.String("location", location))
+
+	w.Header().Set("Location", location)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func sanitizeFilename(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+func postToMF2(post *BlogPost) map[string]interface{} {
+	return map[string]interface{}{
+		"type": []string{"h-entry"},
+		"properties": map[string][]string{
+			"name":      {post.Title},
+			"content":   {post.Content},
+			"category":  post.Tags,
+			"published": {post.PublishedAt.Format(time.RFC3339)},
+			"url":       {fmt.Sprintf("%s/blog/%s", post.Slug, post.Slug)},
+		},
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// IndieAuthVerifier validates `Authorization: Bearer` tokens against an
+// IndieAuth token endpoint and checks the granted scope covers the action
+// being performed.
+type IndieAuthVerifier struct {
+	tokenEndpoint string
+	httpClient    *http.Client
+	tracer        *metrics.TracingProvider
+}
+
+// NewIndieAuthVerifier creates a verifier that calls tokenEndpoint to
+// introspect bearer tokens.
+func NewIndieAuthVerifier(tokenEndpoint string) *IndieAuthVerifier {
+	tp := metrics.GetGlobalTracingProvider()
+	if tp == nil {
+		tp, _ = metrics.NewTracingProvider(metrics.TracingConfig{})
+	}
+	return &IndieAuthVerifier{
+		tokenEndpoint: tokenEndpoint,
+		httpClient:    &http.Client{Timeout: 10 * time.Second},
+		tracer:        tp,
+	}
+}
+
+// Verify checks the request's bearer token is valid and carries
+// requiredScope (a no-op check if requiredScope is ""), returning the
+// authenticated user's "me" URL.
+func (v *IndieAuthVerifier) Verify(r *http.Request, requiredScope string) (string, error) {
+	_, span := v.tracer.StartSpan(r.Context(), "indieauth.verify", nil)
+	defer span.End()
+
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		err := fmt.Errorf("missing bearer token")
+		v.tracer.EndSpan(span, err)
+		return "", err
+	}
+	token := strings.TrimPrefix(auth, "Bearer ")
+
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, v.tokenEndpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		v.tracer.EndSpan(span, err)
+		return "", fmt.Errorf("token endpoint request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("token endpoint rejected token: %s", resp.Status)
+		v.tracer.EndSpan(span, err)
+		return "", err
+	}
+
+	var result struct {
+		Me    string `json:"me"`
+		Scope string `json:"scope"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		v.tracer.EndSpan(span, err)
+		return "", fmt.Errorf("invalid token endpoint response: %w", err)
+	}
+
+	if requiredScope != "" && !hasScope(result.Scope, requiredScope) {
+		err := fmt.Errorf("token missing required scope %q", requiredScope)
+		v.tracer.EndSpan(span, err)
+		return "", err
+	}
+
+	return result.Me, nil
+}
+
+func hasScope(scopes, required string) bool {
+	for _, s := range strings.Fields(scopes) {
+		if s == required {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,402 @@
+package integrations
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// oauthStateTTL is how long a pending authorization-code handshake is
+// kept around waiting for its callback before it's considered
+// abandoned, e.g. a user who started the flow and never finished it.
+const oauthStateTTL = 10 * time.Minute
+
+// OAuthToken is a user's posting credential for one platform, scoped to
+// the tenant and user that completed the authorization handshake.
+// AccessToken/RefreshToken hold an OAuth2 bearer (Twitter, Mastodon);
+// TokenSecret is unused by either flow today but kept so an OAuth1a
+// integration (e.g. a future X v1.1 media endpoint that still requires
+// it) can be added without another schema change.
+type OAuthToken struct {
+	TenantID     string    `db:"tenant_id" json:"tenant_id"`
+	Platform     string    `db:"platform" json:"platform"` // twitter, mastodon
+	UserID       string    `db:"user_id" json:"user_id"`
+	AccessToken  string    `db:"access_token" json:"access_token"`
+	RefreshToken string    `db:"refresh_token" json:"refresh_token,omitempty"`
+	TokenSecret  string    `db:"token_secret" json:"token_secret,omitempty"`
+	InstanceHost string    `db:"instance_host" json:"instance_host,omitempty"` // mastodon only
+	ExpiresAt    time.Time `db:"expires_at" json:"expires_at,omitempty"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// OAuthTokenStore persists per-tenant, per-user posting credentials.
+// Implemented by internal/database.OAuthTokenRepository against the
+// oauth_tokens table.
+type OAuthTokenStore interface {
+	Get(ctx context.Context, tenantID, platform, userID string) (*OAuthToken, error)
+	Save(ctx context.Context, token *OAuthToken) error
+	Delete(ctx context.Context, tenantID, platform, userID string) error
+}
+
+// pendingAuth tracks one in-flight authorization-code handshake between
+// StartAuth and HandleCallback, keyed by the random state value passed
+// through the redirect.
+type pendingAuth struct {
+	tenantID     string
+	userID       string
+	platform     string
+	instanceHost string // mastodon only
+	codeVerifier string // twitter PKCE only
+	createdAt    time.Time
+}
+
+// OAuthManager runs the OAuth2 authorization-code (with PKCE, for
+// Twitter/X) handshake that lets a tenant's user grant this service
+// posting access, and persists the resulting tokens via an
+// OAuthTokenStore so CrossPost can use them later without the user
+// present.
+type OAuthManager struct {
+	store OAuthTokenStore
+
+	twitterClientID     string
+	twitterClientSecret string
+
+	// mastodonClientID/Secret authenticate as a single OAuth app
+	// pre-registered (via each instance's /api/v1/apps) against every
+	// Mastodon instance this service is expected to post to. A deployment
+	// that needs to support arbitrary, not-pre-registered instances would
+	// need to register an app per instance on first use instead.
+	mastodonClientID     string
+	mastodonClientSecret string
+
+	redirectBaseURL string
+	httpClient      *http.Client
+	logger          *zap.Logger
+
+	mu      sync.Mutex
+	pending map[string]pendingAuth
+}
+
+// NewOAuthManager creates an OAuth manager. redirectBaseURL is this
+// service's own public base URL (e.g. "https://feeds.example.com"); the
+// callback redirect URI registered with each platform must be
+// {redirectBaseURL}/oauth/{platform}/callback.
+func NewOAuthManager(store OAuthTokenStore, twitterClientID, twitterClientSecret, mastodonClientID, mastodonClientSecret, redirectBaseURL string, logger *zap.Logger) *OAuthManager {
+	return &OAuthManager{
+		store:                store,
+		twitterClientID:      twitterClientID,
+		twitterClientSecret:  twitterClientSecret,
+		mastodonClientID:     mastodonClientID,
+		mastodonClientSecret: mastodonClientSecret,
+		redirectBaseURL:      redirectBaseURL,
+		httpClient:           &http.Client{Timeout: 15 * time.Second},
+		logger:               logger,
+		pending:              make(map[string]pendingAuth),
+	}
+}
+
+// StartAuth begins an authorization-code handshake for tenantID/userID
+// on platform ("twitter" or "mastodon"), returning the URL the user
+// should be redirected to. instanceHost is required for "mastodon"
+// (which instance they're authorizing against) and ignored otherwise.
+func (m *OAuthManager) StartAuth(platform, tenantID, userID, instanceHost string) (string, error) {
+	state, err := randomToken(32)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate oauth state: %w", err)
+	}
+
+	auth := pendingAuth{tenantID: tenantID, userID: userID, platform: platform, instanceHost: instanceHost, createdAt: time.Now()}
+
+	switch platform {
+	case "twitter":
+		verifier, err := randomToken(48)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate pkce verifier: %w", err)
+		}
+		auth.codeVerifier = verifier
+		m.putPending(state, auth)
+
+		challenge := pkceChallenge(verifier)
+		params := url.Values{
+			"response_type":         {"code"},
+			"client_id":             {m.twitterClientID},
+			"redirect_uri":          {m.callbackURL("twitter")},
+			"scope":                 {"tweet.read tweet.write users.read offline.access"},
+			"state":                 {state},
+			"code_challenge":        {challenge},
+			"code_challenge_method": {"S256"},
+		}
+		return "https://twitter.com/i/oauth2/authorize?" + params.Encode(), nil
+
+	case "mastodon":
+		if instanceHost == "" {
+			return "", fmt.Errorf("mastodon authorization requires an instance host")
+		}
+		m.putPending(state, auth)
+
+		params := url.Values{
+			"response_type": {"code"},
+			"client_id":     {m.mastodonClientID},
+			"redirect_uri":  {m.callbackURL("mastodon")},
+			"scope":         {"write:statuses"},
+			"state":         {state},
+		}
+		return fmt.Sprintf("https://%s/oauth/authorize?%s", instanceHost, params.Encode()), nil
+
+	default:
+		return "", fmt.Errorf("unsupported oauth platform: %s", platform)
+	}
+}
+
+// HandleCallback completes the handshake state identifies, exchanging
+// code for an access token with the platform and persisting it via the
+// configured OAuthTokenStore.
+func (m *OAuthManager) HandleCallback(ctx context.Context, state, code string) (*OAuthToken, error) {
+	auth, ok := m.takePending(state)
+	if !ok {
+		return nil, fmt.Errorf("unknown or expired oauth state")
+	}
+
+	var token *OAuthToken
+	var err error
+	switch auth.platform {
+	case "twitter":
+		token, err = m.exchangeTwitterCode(ctx, auth, code)
+	case "mastodon":
+		token, err = m.exchangeMastodonCode(ctx, auth, code)
+	default:
+		return nil, fmt.Errorf("unsupported oauth platform: %s", auth.platform)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if m.store != nil {
+		if err := m.store.Save(ctx, token); err != nil {
+			return nil, fmt.Errorf("failed to persist oauth token: %w", err)
+		}
+	}
+	return token, nil
+}
+
+func (m *OAuthManager) exchangeTwitterCode(ctx context.Context, auth pendingAuth, code string) (*OAuthToken, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {m.callbackURL("twitter")},
+		"code_verifier": {auth.codeVerifier},
+		"client_id":     {m.twitterClientID},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.twitter.com/2/oauth2/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(m.twitterClientID, m.twitterClientSecret)
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange twitter authorization code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("twitter token exchange failed: %s - %s", resp.Status, string(body))
+	}
+
+	var result struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse twitter token response: %w", err)
+	}
+
+	now := time.Now()
+	return &OAuthToken{
+		TenantID:     auth.tenantID,
+		Platform:     "twitter",
+		UserID:       auth.userID,
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+		ExpiresAt:    now.Add(time.Duration(result.ExpiresIn) * time.Second),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// exchangeMastodonCode trades code for an access token against
+// instanceHost's own OAuth server.
+func (m *OAuthManager) exchangeMastodonCode(ctx context.Context, auth pendingAuth, code string) (*OAuthToken, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {m.callbackURL("mastodon")},
+		"client_id":     {m.mastodonClientID},
+		"client_secret": {m.mastodonClientSecret},
+		"scope":         {"write:statuses"},
+	}
+
+	endpoint := fmt.Sprintf("https://%s/oauth/token", auth.instanceHost)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange mastodon authorization code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("mastodon token exchange failed: %s - %s", resp.Status, string(body))
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse mastodon token response: %w", err)
+	}
+
+	now := time.Now()
+	return &OAuthToken{
+		TenantID:     auth.tenantID,
+		Platform:     "mastodon",
+		UserID:       auth.userID,
+		AccessToken:  result.AccessToken,
+		InstanceHost: auth.instanceHost,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// RefreshTwitterToken exchanges token's refresh_token for a new access
+// token, since Twitter's OAuth2 user-context tokens (unlike its app-only
+// bearer) expire in about two hours.
+func (m *OAuthManager) RefreshTwitterToken(ctx context.Context, token *OAuthToken) (*OAuthToken, error) {
+	if token.RefreshToken == "" {
+		return nil, fmt.Errorf("token has no refresh_token to refresh with")
+	}
+
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {token.RefreshToken},
+		"client_id":     {m.twitterClientID},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.twitter.com/2/oauth2/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(m.twitterClientID, m.twitterClientSecret)
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh twitter token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("twitter token refresh failed: %s - %s", resp.Status, string(body))
+	}
+
+	var result struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse twitter refresh response: %w", err)
+	}
+
+	token.AccessToken = result.AccessToken
+	if result.RefreshToken != "" {
+		token.RefreshToken = result.RefreshToken
+	}
+	token.ExpiresAt = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	token.UpdatedAt = time.Now()
+
+	if m.store != nil {
+		if err := m.store.Save(ctx, token); err != nil {
+			return nil, fmt.Errorf("failed to persist refreshed oauth token: %w", err)
+		}
+	}
+	return token, nil
+}
+
+func (m *OAuthManager) callbackURL(platform string) string {
+	return fmt.Sprintf("%s/oauth/%s/callback", strings.TrimSuffix(m.redirectBaseURL, "/"), platform)
+}
+
+func (m *OAuthManager) putPending(state string, auth pendingAuth) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evictExpiredLocked()
+	m.pending[state] = auth
+}
+
+func (m *OAuthManager) takePending(state string) (pendingAuth, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	auth, ok := m.pending[state]
+	delete(m.pending, state)
+	if !ok || time.Since(auth.createdAt) > oauthStateTTL {
+		return pendingAuth{}, false
+	}
+	return auth, true
+}
+
+// evictExpiredLocked drops abandoned handshakes so pending doesn't grow
+// unbounded; called while m.mu is already held.
+func (m *OAuthManager) evictExpiredLocked() {
+	for state, auth := range m.pending {
+		if time.Since(auth.createdAt) > oauthStateTTL {
+			delete(m.pending, state)
+		}
+	}
+}
+
+// randomToken returns a URL-safe base64 string encoding n random bytes.
+func randomToken(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// pkceChallenge derives the S256 code_challenge for a PKCE verifier.
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,161 @@
+package integrations
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// Subscription is one OPML <outline>: either a feed subscription
+// (XMLURL set) or a category folder grouping nested subscriptions.
+// Custom per-outline attributes OPML producers attach beyond the
+// standard title/text/xmlUrl/htmlUrl/type (keep-original-content,
+// block-filter rules, category tags, etc) round-trip through Attrs.
+type Subscription struct {
+	Title    string            `json:"title"`
+	Text     string            `json:"text,omitempty"`
+	XMLURL   string            `json:"xml_url,omitempty"`
+	HTMLURL  string            `json:"html_url,omitempty"`
+	Type     string            `json:"type,omitempty"`
+	Attrs    map[string]string `json:"attrs,omitempty"`
+	Children []Subscription    `json:"children,omitempty"`
+}
+
+// IsFeed reports whether this outline is a feed subscription rather
+// than a category folder.
+func (s Subscription) IsFeed() bool {
+	return s.XMLURL != ""
+}
+
+// opmlDocument and opmlOutline mirror the OPML 2.0 XML shape. Outline
+// is recursive (outline elements can nest arbitrarily to model category
+// hierarchies), and Attrs captures every attribute - known and custom -
+// via xml's ",any,attr" so ParseOPML can separate the ones it
+// understands from the ones it just needs to preserve.
+type opmlDocument struct {
+	XMLName xml.Name      `xml:"opml"`
+	Head    opmlHead      `xml:"head"`
+	Outline []opmlOutline `xml:"body>outline"`
+}
+
+type opmlHead struct {
+	Title string `xml:"title,omitempty"`
+}
+
+type opmlOutline struct {
+	Attrs   []xml.Attr    `xml:",any,attr"`
+	Outline []opmlOutline `xml:"outline"`
+}
+
+// knownOutlineAttrs are the standard OPML outline attributes surfaced
+// as Subscription fields rather than left in Attrs.
+var knownOutlineAttrs = map[string]bool{
+	"title": true, "text": true, "xmlurl": true, "htmlurl": true, "type": true,
+}
+
+// ParseOPML reads an OPML document and returns its top-level outlines
+// as a Subscription tree, preserving nested category folders and any
+// custom attributes each outline carries.
+func ParseOPML(r io.Reader) ([]Subscription, error) {
+	var doc opmlDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OPML: %w", err)
+	}
+
+	subs := make([]Subscription, 0, len(doc.Outline))
+	for _, o := range doc.Outline {
+		subs = append(subs, outlineToSubscription(o))
+	}
+	return subs, nil
+}
+
+func outlineToSubscription(o opmlOutline) Subscription {
+	sub := Subscription{Attrs: map[string]string{}}
+	for _, attr := range o.Attrs {
+		key := attr.Name.Local
+		switch key {
+		case "title":
+			sub.Title = attr.Value
+		case "text":
+			sub.Text = attr.Value
+		case "xmlUrl":
+			sub.XMLURL = attr.Value
+		case "htmlUrl":
+			sub.HTMLURL = attr.Value
+		case "type":
+			sub.Type = attr.Value
+		default:
+			sub.Attrs[key] = attr.Value
+		}
+	}
+	if len(sub.Attrs) == 0 {
+		sub.Attrs = nil
+	}
+	if sub.Title == "" {
+		sub.Title = sub.Text
+	}
+
+	for _, child := range o.Outline {
+		sub.Children = append(sub.Children, outlineToSubscription(child))
+	}
+	return sub
+}
+
+// SerializeOPML renders subs as an OPML 2.0 document, nesting category
+// folders and round-tripping each outline's custom attributes.
+func SerializeOPML(subs []Subscription) ([]byte, error) {
+	doc := opmlDocument{
+		Head:    opmlHead{Title: "Subscriptions"},
+		Outline: make([]opmlOutline, 0, len(subs)),
+	}
+	for _, sub := range subs {
+		doc.Outline = append(doc.Outline, subscriptionToOutline(sub))
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize OPML: %w", err)
+	}
+
+	var buf []byte
+	buf = append(buf, []byte(xml.Header)...)
+	buf = append(buf, out...)
+	return buf, nil
+}
+
+func subscriptionToOutline(sub Subscription) opmlOutline {
+	o := opmlOutline{}
+
+	title := sub.Title
+	if title == "" {
+		title = sub.Text
+	}
+	o.Attrs = append(o.Attrs, xml.Attr{Name: xml.Name{Local: "title"}, Value: title})
+	if sub.Text != "" {
+		o.Attrs = append(o.Attrs, xml.Attr{Name: xml.Name{Local: "text"}, Value: sub.Text})
+	} else {
+		o.Attrs = append(o.Attrs, xml.Attr{Name: xml.Name{Local: "text"}, Value: title})
+	}
+	if sub.XMLURL != "" {
+		o.Attrs = append(o.Attrs, xml.Attr{Name: xml.Name{Local: "xmlUrl"}, Value: sub.XMLURL})
+	}
+	if sub.HTMLURL != "" {
+		o.Attrs = append(o.Attrs, xml.Attr{Name: xml.Name{Local: "htmlUrl"}, Value: sub.HTMLURL})
+	}
+	if sub.Type != "" {
+		o.Attrs = append(o.Attrs, xml.Attr{Name: xml.Name{Local: "type"}, Value: sub.Type})
+	} else if sub.IsFeed() {
+		o.Attrs = append(o.Attrs, xml.Attr{Name: xml.Name{Local: "type"}, Value: "rss"})
+	}
+	for key, val := range sub.Attrs {
+		if knownOutlineAttrs[key] {
+			continue
+		}
+		o.Attrs = append(o.Attrs, xml.Attr{Name: xml.Name{Local: key}, Value: val})
+	}
+
+	for _, child := range sub.Children {
+		o.Outline = append(o.Outline, subscriptionToOutline(child))
+	}
+	return o
+}
@@ -0,0 +1,300 @@
+package integrations
+
+import (
+	"fmt"
+	"image"
+	_ "image/gif"  // register GIF decoding for image.Decode
+	_ "image/jpeg" // register JPEG decoding for image.Decode
+	_ "image/png"  // register PNG decoding for image.Decode
+	"math"
+	"sort"
+)
+
+// blurhashDigits is the base83 alphabet used by the BlurHash encoding,
+// fixed by the spec (see github.com/woltapp/blurhash).
+const blurhashDigits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// blurhashSampleSize is the resolution EncodeBlurHash downsamples to
+// before computing DCT coefficients - blurhashes encode a heavily
+// blurred thumbnail, so sampling at full resolution would cost far more
+// than the result warrants.
+const blurhashSampleSize = 32
+
+// EncodeBlurHash computes a BlurHash placeholder string for img with
+// componentsX x componentsY DCT components (each 1-9; 4x3 is a
+// reasonable default for photo thumbnails), following the encoding
+// described at github.com/woltapp/blurhash.
+func EncodeBlurHash(img image.Image, componentsX, componentsY int) (string, error) {
+	if componentsX < 1 || componentsX > 9 || componentsY < 1 || componentsY > 9 {
+		return "", fmt.Errorf("blurhash components must be between 1 and 9, got %dx%d", componentsX, componentsY)
+	}
+
+	pixels := resizeLinearRGB(img, blurhashSampleSize, blurhashSampleSize)
+
+	factors := make([][3]float64, componentsX*componentsY)
+	for j := 0; j < componentsY; j++ {
+		for i := 0; i < componentsX; i++ {
+			var r, g, b float64
+			for y := 0; y < blurhashSampleSize; y++ {
+				for x := 0; x < blurhashSampleSize; x++ {
+					basis := math.Cos(math.Pi*float64(i)*float64(x)/float64(blurhashSampleSize)) *
+						math.Cos(math.Pi*float64(j)*float64(y)/float64(blurhashSampleSize))
+					px := pixels[y][x]
+					r += basis * px[0]
+					g += basis * px[1]
+					b += basis * px[2]
+				}
+			}
+
+			normalisation := 2.0
+			if i == 0 && j == 0 {
+				normalisation = 1.0
+			}
+			scale := normalisation / float64(blurhashSampleSize*blurhashSampleSize)
+			factors[j*componentsX+i] = [3]float64{r * scale, g * scale, b * scale}
+		}
+	}
+
+	dc := factors[0]
+	ac := factors[1:]
+
+	quantisedMaximumValue := 0
+	maximumValue := 1.0
+	if len(ac) > 0 {
+		actualMaximumValue := 0.0
+		for _, f := range ac {
+			for _, v := range f {
+				if av := math.Abs(v); av > actualMaximumValue {
+					actualMaximumValue = av
+				}
+			}
+		}
+		quantisedMaximumValue = clampInt(int(math.Floor(actualMaximumValue*166-0.5)), 0, 82)
+		maximumValue = float64(quantisedMaximumValue+1) / 166
+	}
+
+	sizeFlag := (componentsX - 1) + (componentsY-1)*9
+	hash := encode83(sizeFlag, 1) +
+		encode83(quantisedMaximumValue, 1) +
+		encode83(encodeBlurHashDC(dc), 4)
+	for _, f := range ac {
+		hash += encode83(encodeBlurHashAC(f, maximumValue), 2)
+	}
+
+	return hash, nil
+}
+
+func encodeBlurHashDC(value [3]float64) int {
+	r := linearToSRGB(value[0])
+	g := linearToSRGB(value[1])
+	b := linearToSRGB(value[2])
+	return (r << 16) + (g << 8) + b
+}
+
+func encodeBlurHashAC(value [3]float64, maximumValue float64) int {
+	quantise := func(v float64) int {
+		return clampInt(int(math.Floor(signPow(v/maximumValue, 0.5)*9+9.5)), 0, 18)
+	}
+	return quantise(value[0])*19*19 + quantise(value[1])*19 + quantise(value[2])
+}
+
+func encode83(value, length int) string {
+	result := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		result[i] = blurhashDigits[value%83]
+		value /= 83
+	}
+	return string(result)
+}
+
+func signPow(value, exponent float64) float64 {
+	sign := 1.0
+	if value < 0 {
+		sign = -1.0
+	}
+	return sign * math.Pow(math.Abs(value), exponent)
+}
+
+func clampInt(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// ComputeDHash computes a 64-bit difference hash: img is downsampled to
+// a 9x8 grayscale grid and each bit records whether a pixel is darker
+// than its right neighbor. It's cheap and tolerant of minor
+// re-encoding/resizing, making it a good complement to ComputePHash.
+func ComputeDHash(img image.Image) string {
+	gray := resizeGray(img, 9, 8)
+
+	var value uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			value <<= 1
+			if gray[y][x] < gray[y][x+1] {
+				value |= 1
+			}
+		}
+	}
+	return fmt.Sprintf("%016x", value)
+}
+
+// phashSampleSize and phashBits define ComputePHash's working
+// resolution and the low-frequency DCT block it hashes, matching the
+// common 32x32 DCT / top-left 8x8 pHash construction.
+const (
+	phashSampleSize = 32
+	phashBits       = 8
+)
+
+// ComputePHash computes a 64-bit DCT-based perceptual hash: img is
+// downsampled to phashSampleSize^2 grayscale, run through a 2D DCT, and
+// the top-left phashBits x phashBits low-frequency block (excluding the
+// DC term) is thresholded against its own median. Unlike ComputeDHash,
+// this is robust to re-encoding and moderate recompression since it
+// hashes low-frequency structure rather than local pixel gradients.
+func ComputePHash(img image.Image) string {
+	gray := resizeGray(img, phashSampleSize, phashSampleSize)
+	coeffs := dct2D(gray)
+
+	values := make([]float64, 0, phashBits*phashBits-1)
+	for y := 0; y < phashBits; y++ {
+		for x := 0; x < phashBits; x++ {
+			if x == 0 && y == 0 {
+				continue
+			}
+			values = append(values, coeffs[y][x])
+		}
+	}
+	median := medianOf(values)
+
+	var value uint64
+	for y := 0; y < phashBits; y++ {
+		for x := 0; x < phashBits; x++ {
+			value <<= 1
+			if x != 0 || y != 0 {
+				if coeffs[y][x] > median {
+					value |= 1
+				}
+			}
+		}
+	}
+	return fmt.Sprintf("%016x", value)
+}
+
+func medianOf(values []float64) float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	return sorted[len(sorted)/2]
+}
+
+// dct2D computes a separable 2D DCT-II of a square matrix (unnormalized
+// - ComputePHash only compares coefficients against their own median,
+// so the usual orthonormality scaling factors are irrelevant).
+func dct2D(matrix [][]float64) [][]float64 {
+	n := len(matrix)
+
+	rows := make([][]float64, n)
+	for y := 0; y < n; y++ {
+		rows[y] = dct1D(matrix[y])
+	}
+
+	out := make([][]float64, n)
+	for y := 0; y < n; y++ {
+		out[y] = make([]float64, n)
+	}
+	col := make([]float64, n)
+	for x := 0; x < n; x++ {
+		for y := 0; y < n; y++ {
+			col[y] = rows[y][x]
+		}
+		colDCT := dct1D(col)
+		for y := 0; y < n; y++ {
+			out[y][x] = colDCT[y]
+		}
+	}
+	return out
+}
+
+func dct1D(input []float64) []float64 {
+	n := len(input)
+	output := make([]float64, n)
+	for u := 0; u < n; u++ {
+		var sum float64
+		for x := 0; x < n; x++ {
+			sum += input[x] * math.Cos(math.Pi/float64(n)*(float64(x)+0.5)*float64(u))
+		}
+		output[u] = sum
+	}
+	return output
+}
+
+// resizeGray box-samples img down to width x height grayscale values in
+// [0,1], using ITU-R BT.601 luma weights.
+func resizeGray(img image.Image, width, height int) [][]float64 {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+
+	out := make([][]float64, height)
+	for y := 0; y < height; y++ {
+		out[y] = make([]float64, width)
+		srcY := bounds.Min.Y + y*srcH/height
+		for x := 0; x < width; x++ {
+			srcX := bounds.Min.X + x*srcW/width
+			r, g, b, _ := img.At(srcX, srcY).RGBA()
+			out[y][x] = 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+		}
+	}
+	return out
+}
+
+// resizeLinearRGB nearest-neighbor samples img down to width x height
+// and converts each sample from sRGB to linear light, as BlurHash's DCT
+// coefficients are defined over linear-light color.
+func resizeLinearRGB(img image.Image, width, height int) [][][3]float64 {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+
+	out := make([][][3]float64, height)
+	for y := 0; y < height; y++ {
+		out[y] = make([][3]float64, width)
+		srcY := bounds.Min.Y + y*srcH/height
+		for x := 0; x < width; x++ {
+			srcX := bounds.Min.X + x*srcW/width
+			r, g, b, _ := img.At(srcX, srcY).RGBA()
+			out[y][x] = [3]float64{srgbToLinear(r), srgbToLinear(g), srgbToLinear(b)}
+		}
+	}
+	return out
+}
+
+func srgbToLinear(c uint32) float64 {
+	v := float64(c) / 65535.0
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(v float64) int {
+	if v < 0 {
+		v = 0
+	}
+	if v > 1 {
+		v = 1
+	}
+
+	var srgb float64
+	if v <= 0.0031308 {
+		srgb = v * 12.92
+	} else {
+		srgb = 1.055*math.Pow(v, 1/2.4) - 0.055
+	}
+	return clampInt(int(math.Round(srgb*255)), 0, 255)
+}
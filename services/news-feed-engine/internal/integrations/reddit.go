@@ -0,0 +1,243 @@
+package integrations
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// redditTokenMaxAge is how long a minted script-app bearer is cached
+// before redditAppTokenManager proactively re-mints it. Reddit's OAuth2
+// tokens are valid for an hour; refreshing a bit early avoids racing a
+// request against expiry.
+const redditTokenMaxAge = 50 * time.Minute
+
+// redditUserAgent identifies this integration per Reddit's API rules,
+// which throttle or ban generic/missing User-Agent strings regardless
+// of whether the request is otherwise authorized.
+const redditUserAgent = "news-feed-engine/1.0 (content aggregation bot)"
+
+// redditAppTokenManager mints and caches Reddit's OAuth2 script-app
+// bearer token via client_credentials, the same caching shape as
+// twitterAppTokenManager.
+type redditAppTokenManager struct {
+	clientID     string
+	clientSecret string
+	httpClient   *http.Client
+
+	mu       sync.RWMutex
+	token    string
+	mintedAt time.Time
+}
+
+// Token returns a cached bearer token, minting (or re-minting, if
+// forceRefresh is set or the cache is older than redditTokenMaxAge) one
+// first.
+func (m *redditAppTokenManager) Token(ctx context.Context, forceRefresh bool) (string, error) {
+	m.mu.RLock()
+	valid := m.token != "" && time.Since(m.mintedAt) < redditTokenMaxAge
+	token := m.token
+	m.mu.RUnlock()
+
+	if valid && !forceRefresh {
+		return token, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !forceRefresh && m.token != "" && time.Since(m.mintedAt) < redditTokenMaxAge {
+		return m.token, nil
+	}
+
+	minted, err := m.mint(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	m.token = minted
+	m.mintedAt = time.Now()
+	return m.token, nil
+}
+
+func (m *redditAppTokenManager) mint(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://www.reddit.com/api/v1/access_token",
+		strings.NewReader("grant_type=client_credentials"))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(m.clientID+":"+m.clientSecret)))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", redditUserAgent)
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to mint reddit access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to mint reddit access token: %s - %s", resp.Status, string(body))
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to parse reddit token response: %w", err)
+	}
+	if result.AccessToken == "" {
+		return "", fmt.Errorf("reddit token response missing access_token")
+	}
+
+	return result.AccessToken, nil
+}
+
+// redditAuthError marks a response rejected for auth reasons (expired
+// or revoked token), distinct from any other API error, so callers know
+// retrying with a freshly minted token is worth attempting.
+type redditAuthError struct{ status int }
+
+func (e *redditAuthError) Error() string {
+	return fmt.Sprintf("reddit API auth error: status %d", e.status)
+}
+
+func isRedditAuthError(err error) bool {
+	var authErr *redditAuthError
+	return errors.As(err, &authErr)
+}
+
+// RedditIntegration fetches subreddit listings via Reddit's OAuth2 API.
+type RedditIntegration struct {
+	tokenMgr   *redditAppTokenManager
+	httpClient *http.Client
+	logger     *zap.Logger
+}
+
+// NewRedditIntegration creates a new Reddit integration authenticated
+// as a script app with clientID/clientSecret.
+func NewRedditIntegration(clientID, clientSecret string, logger *zap.Logger) *RedditIntegration {
+	httpClient := &http.Client{Timeout: 15 * time.Second}
+	return &RedditIntegration{
+		tokenMgr:   &redditAppTokenManager{clientID: clientID, clientSecret: clientSecret, httpClient: httpClient},
+		httpClient: httpClient,
+		logger:     logger,
+	}
+}
+
+// RedditPost represents a single listing entry from a subreddit.
+type RedditPost struct {
+	ID          string
+	Title       string
+	Selftext    string
+	URL         string
+	Permalink   string
+	Author      string
+	Subreddit   string
+	Score       int64
+	NumComments int64
+	CreatedAt   time.Time
+	Thumbnail   string
+	IsSelf      bool
+}
+
+// GetSubredditListing fetches up to limit posts from subreddit's "new"
+// listing, retrying once with a freshly minted token if the cached one
+// was rejected.
+func (ri *RedditIntegration) GetSubredditListing(ctx context.Context, subreddit string, limit int) ([]RedditPost, error) {
+	posts, err := ri.fetchListing(ctx, subreddit, limit, false)
+	if err != nil && isRedditAuthError(err) {
+		return ri.fetchListing(ctx, subreddit, limit, true)
+	}
+	return posts, err
+}
+
+func (ri *RedditIntegration) fetchListing(ctx context.Context, subreddit string, limit int, forceRefresh bool) ([]RedditPost, error) {
+	token, err := ri.tokenMgr.Token(ctx, forceRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("https://oauth.reddit.com/r/%s/new.json?limit=%d", url.PathEscape(subreddit), limit)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "bearer "+token)
+	req.Header.Set("User-Agent", redditUserAgent)
+
+	resp, err := ri.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch subreddit listing: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, &redditAuthError{status: resp.StatusCode}
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("subreddit listing request failed: %s - %s", resp.Status, string(body))
+	}
+
+	var listing struct {
+		Data struct {
+			Children []struct {
+				Data struct {
+					ID          string  `json:"id"`
+					Title       string  `json:"title"`
+					Selftext    string  `json:"selftext"`
+					URL         string  `json:"url"`
+					Permalink   string  `json:"permalink"`
+					Author      string  `json:"author"`
+					Subreddit   string  `json:"subreddit"`
+					Score       int64   `json:"score"`
+					NumComments int64   `json:"num_comments"`
+					CreatedUTC  float64 `json:"created_utc"`
+					Thumbnail   string  `json:"thumbnail"`
+					IsSelf      bool    `json:"is_self"`
+				} `json:"data"`
+			} `json:"children"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&listing); err != nil {
+		return nil, fmt.Errorf("failed to parse subreddit listing: %w", err)
+	}
+
+	posts := make([]RedditPost, 0, len(listing.Data.Children))
+	for _, child := range listing.Data.Children {
+		d := child.Data
+		posts = append(posts, RedditPost{
+			ID:          d.ID,
+			Title:       d.Title,
+			Selftext:    d.Selftext,
+			URL:         d.URL,
+			Permalink:   "https://www.reddit.com" + d.Permalink,
+			Author:      d.Author,
+			Subreddit:   d.Subreddit,
+			Score:       d.Score,
+			NumComments: d.NumComments,
+			CreatedAt:   time.Unix(int64(d.CreatedUTC), 0).UTC(),
+			Thumbnail:   d.Thumbnail,
+			IsSelf:      d.IsSelf,
+		})
+	}
+
+	return posts, nil
+}
@@ -2,15 +2,16 @@ package integrations
 
 import (
 	"context"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
-	"html"
 	"io"
 	"net/http"
-	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/integrations/sanitizer"
 	"go.uber.org/zap"
 )
 
@@ -27,11 +28,22 @@ type FeedItem struct {
 	Link        string    `json:"link"`
 	Description string    `json:"description"`
 	Content     string    `json:"content"`
+	ContentHTML string    `json:"content_html,omitempty"`
+	ContentText string    `json:"content_text,omitempty"`
 	Author      string    `json:"author"`
 	Categories  []string  `json:"categories"`
 	PublishedAt time.Time `json:"published_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 	ImageURL    string    `json:"image_url"`
+
+	// Media carries the item's Media RSS payload (media:group,
+	// media:content, media:thumbnail, etc), if it had one.
+	Media *MediaElement `json:"media,omitempty"`
+
+	// Podcast consolidates episode/season/duration/cover-art fields
+	// from whichever of the iTunes or Google Play podcast namespaces
+	// the feed used, if either was present.
+	Podcast *PodcastMetadata `json:"podcast,omitempty"`
 }
 
 // Feed represents an RSS/Atom feed
@@ -42,6 +54,104 @@ type Feed struct {
 	Language    string     `json:"language"`
 	Items       []FeedItem `json:"items"`
 	LastUpdated time.Time  `json:"last_updated"`
+
+	// Podcast channel-level metadata (iTunes/Google Play namespaces).
+	PodcastAuthor     string   `json:"podcast_author,omitempty"`
+	PodcastCategories []string `json:"podcast_categories,omitempty"`
+	PodcastExplicit   bool     `json:"podcast_explicit,omitempty"`
+	PodcastImageURL   string   `json:"podcast_image_url,omitempty"`
+	PodcastSummary    string   `json:"podcast_summary,omitempty"`
+}
+
+// MediaElement is a normalized view of an item's Media RSS payload,
+// merging media:content/thumbnail/etc found directly on the item with
+// any found inside a media:group.
+type MediaElement struct {
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Credit      string             `json:"credit,omitempty"`
+	Rating      string             `json:"rating,omitempty"`
+	PeerLink    string             `json:"peer_link,omitempty"`
+	Categories  []string           `json:"categories,omitempty"`
+	Thumbnails  []MediaThumbnail   `json:"thumbnails,omitempty"`
+	Contents    []MediaContentItem `json:"contents,omitempty"`
+}
+
+// MediaThumbnail is one media:thumbnail.
+type MediaThumbnail struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+}
+
+// MediaContentItem is one media:content.
+type MediaContentItem struct {
+	URL       string `json:"url"`
+	Type      string `json:"type,omitempty"`
+	Medium    string `json:"medium,omitempty"`
+	Width     int    `json:"width,omitempty"`
+	Height    int    `json:"height,omitempty"`
+	Duration  int    `json:"duration,omitempty"`
+	FileSize  int64  `json:"file_size,omitempty"`
+	Bitrate   int    `json:"bitrate,omitempty"`
+	Lang      string `json:"lang,omitempty"`
+	IsDefault bool   `json:"is_default,omitempty"`
+}
+
+// BestImage returns the highest-resolution thumbnail URL, falling back
+// to the first image-typed media:content if there are no thumbnails.
+func (m *MediaElement) BestImage() string {
+	var best MediaThumbnail
+	for _, t := range m.Thumbnails {
+		if t.Width*t.Height > best.Width*best.Height {
+			best = t
+		}
+	}
+	if best.URL != "" {
+		return best.URL
+	}
+	for _, c := range m.Contents {
+		if c.Medium == "image" || strings.HasPrefix(c.Type, "image/") {
+			return c.URL
+		}
+	}
+	return ""
+}
+
+// Enclosures returns every media:content that isn't an image - the
+// downloadable media (audio, video, a torrent) a client would offer.
+func (m *MediaElement) Enclosures() []MediaContentItem {
+	var out []MediaContentItem
+	for _, c := range m.Contents {
+		if c.Medium == "image" || strings.HasPrefix(c.Type, "image/") {
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// PickByType returns the first media:content matching mime exactly, or
+// matching as a prefix when mime ends in "/" (e.g. "video/" matches
+// "video/mp4"). Returns nil if nothing matches.
+func (m *MediaElement) PickByType(mime string) *MediaContentItem {
+	for i, c := range m.Contents {
+		if c.Type == mime || (strings.HasSuffix(mime, "/") && strings.HasPrefix(c.Type, mime)) {
+			return &m.Contents[i]
+		}
+	}
+	return nil
+}
+
+// PodcastMetadata consolidates the fields a podcast client needs
+// regardless of whether the feed supplied them via the iTunes or the
+// Google Play Podcasts namespace.
+type PodcastMetadata struct {
+	Episode         int    `json:"episode,omitempty"`
+	Season          int    `json:"season,omitempty"`
+	EpisodeType     string `json:"episode_type,omitempty"`
+	DurationSeconds int    `json:"duration_seconds,omitempty"`
+	ImageURL        string `json:"image_url,omitempty"`
 }
 
 // RSS 2.0 structs
@@ -56,20 +166,54 @@ type rssChannel struct {
 	Description string    `xml:"description"`
 	Language    string    `xml:"language"`
 	Items       []rssItem `xml:"item"`
+
+	// iTunes/Google Play podcast namespaces, channel level
+	ItunesAuthor       string                  `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd author"`
+	ItunesCategory     []itunesCategoryXML     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd category"`
+	ItunesExplicit     string                  `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd explicit"`
+	ItunesImage        *itunesImageXML         `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+	ItunesSummary      string                  `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd summary"`
+	GooglePlayCategory []googlePlayCategoryXML `xml:"http://www.google.com/schemas/play-podcasts/1.0 category"`
+	GooglePlayExplicit string                  `xml:"http://www.google.com/schemas/play-podcasts/1.0 explicit"`
 }
 
 type rssItem struct {
-	Title        string        `xml:"title"`
-	Link         string        `xml:"link"`
-	Description  string        `xml:"description"`
-	Content      string        `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
-	Author       string        `xml:"author"`
-	Creator      string        `xml:"http://purl.org/dc/elements/1.1/ creator"`
-	Categories   []string      `xml:"category"`
-	PubDate      string        `xml:"pubDate"`
-	GUID         string        `xml:"guid"`
-	Enclosure    *rssEnclosure `xml:"enclosure"`
-	MediaContent *mediaContent `xml:"http://search.yahoo.com/mrss/ content"`
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	Description string        `xml:"description"`
+	Content     string        `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	Author      string        `xml:"author"`
+	Creator     string        `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Categories  []string      `xml:"category"`
+	PubDate     string        `xml:"pubDate"`
+	GUID        string        `xml:"guid"`
+	Enclosure   *rssEnclosure `xml:"enclosure"`
+
+	// Media RSS (http://search.yahoo.com/mrss/)
+	MediaGroup      *mediaGroupXML      `xml:"http://search.yahoo.com/mrss/ group"`
+	MediaContents   []mediaContentXML   `xml:"http://search.yahoo.com/mrss/ content"`
+	MediaThumbnails []mediaThumbnailXML `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	MediaTitle      string              `xml:"http://search.yahoo.com/mrss/ title"`
+	MediaDesc       string              `xml:"http://search.yahoo.com/mrss/ description"`
+	MediaCredit     string              `xml:"http://search.yahoo.com/mrss/ credit"`
+	MediaRating     string              `xml:"http://search.yahoo.com/mrss/ rating"`
+	MediaPeerLink   *mediaPeerLinkXML   `xml:"http://search.yahoo.com/mrss/ peerLink"`
+	MediaCategory   []string            `xml:"http://search.yahoo.com/mrss/ category"`
+
+	// iTunes podcast namespace, item level
+	ItunesAuthor      string          `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd author"`
+	ItunesDuration    string          `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+	ItunesEpisode     string          `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd episode"`
+	ItunesSeason      string          `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd season"`
+	ItunesEpisodeType string          `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd episodeType"`
+	ItunesImage       *itunesImageXML `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+	ItunesExplicit    string          `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd explicit"`
+	ItunesSummary     string          `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd summary"`
+
+	// Google Play Podcasts namespace, item level
+	GooglePlayDescription string          `xml:"http://www.google.com/schemas/play-podcasts/1.0 description"`
+	GooglePlayExplicit    string          `xml:"http://www.google.com/schemas/play-podcasts/1.0 explicit"`
+	GooglePlayImage       *itunesImageXML `xml:"http://www.google.com/schemas/play-podcasts/1.0 image"`
 }
 
 type rssEnclosure struct {
@@ -77,18 +221,127 @@ type rssEnclosure struct {
 	Type string `xml:"type,attr"`
 }
 
-type mediaContent struct {
+// mediaGroupXML is Media RSS's media:group - an alternate bundle of
+// media:content/thumbnail/etc that some feeds use instead of putting
+// them directly on the item.
+type mediaGroupXML struct {
+	Contents    []mediaContentXML   `xml:"http://search.yahoo.com/mrss/ content"`
+	Thumbnails  []mediaThumbnailXML `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	Title       string              `xml:"http://search.yahoo.com/mrss/ title"`
+	Description string              `xml:"http://search.yahoo.com/mrss/ description"`
+	Credit      string              `xml:"http://search.yahoo.com/mrss/ credit"`
+	Rating      string              `xml:"http://search.yahoo.com/mrss/ rating"`
+	Category    []string            `xml:"http://search.yahoo.com/mrss/ category"`
+}
+
+type mediaContentXML struct {
+	URL       string `xml:"url,attr"`
+	Type      string `xml:"type,attr"`
+	Medium    string `xml:"medium,attr"`
+	Width     int    `xml:"width,attr"`
+	Height    int    `xml:"height,attr"`
+	Duration  int    `xml:"duration,attr"`
+	FileSize  int64  `xml:"fileSize,attr"`
+	Bitrate   int    `xml:"bitrate,attr"`
+	Lang      string `xml:"lang,attr"`
+	IsDefault bool   `xml:"isDefault,attr"`
+}
+
+type mediaThumbnailXML struct {
 	URL    string `xml:"url,attr"`
-	Medium string `xml:"medium,attr"`
+	Width  int    `xml:"width,attr"`
+	Height int    `xml:"height,attr"`
+}
+
+type mediaPeerLinkXML struct {
+	Type string `xml:"type,attr"`
+	Href string `xml:"href,attr"`
+}
+
+type itunesImageXML struct {
+	Href string `xml:"href,attr"`
+}
+
+// itunesCategoryXML supports one level of nesting - iTunes categories
+// declare at most a category and one sub-category.
+type itunesCategoryXML struct {
+	Text string              `xml:"text,attr"`
+	Sub  []itunesCategoryXML `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd category"`
+}
+
+type googlePlayCategoryXML struct {
+	Text string `xml:"text,attr"`
+}
+
+// RDF/RSS 1.0 structs (http://purl.org/rss/1.0/). Unlike RSS 2.0, items
+// are siblings of channel directly under rdf:RDF rather than nested
+// inside it.
+type rdfFeed struct {
+	XMLName xml.Name   `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# RDF"`
+	Channel rdfChannel `xml:"http://purl.org/rss/1.0/ channel"`
+	Items   []rdfItem  `xml:"http://purl.org/rss/1.0/ item"`
+}
+
+type rdfChannel struct {
+	Title       string `xml:"http://purl.org/rss/1.0/ title"`
+	Link        string `xml:"http://purl.org/rss/1.0/ link"`
+	Description string `xml:"http://purl.org/rss/1.0/ description"`
+}
+
+type rdfItem struct {
+	About       string   `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# about,attr"`
+	Title       string   `xml:"http://purl.org/rss/1.0/ title"`
+	Link        string   `xml:"http://purl.org/rss/1.0/ link"`
+	Description string   `xml:"http://purl.org/rss/1.0/ description"`
+	Creator     string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Date        string   `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Subjects    []string `xml:"http://purl.org/dc/elements/1.1/ subject"`
+}
+
+// JSON Feed 1.1 structs (https://www.jsonfeed.org/version/1.1/).
+type jsonFeedDoc struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url"`
+	Description string         `json:"description"`
+	Language    string         `json:"language"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	Title         string           `json:"title"`
+	ContentHTML   string           `json:"content_html"`
+	ContentText   string           `json:"content_text"`
+	Summary       string           `json:"summary"`
+	Image         string           `json:"image"`
+	DatePublished string           `json:"date_published"`
+	DateModified  string           `json:"date_modified"`
+	Authors       []jsonFeedAuthor `json:"authors"`
+	Tags          []string         `json:"tags"`
 }
 
-// Atom structs
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// Atom structs. Atom 1.0 (http://www.w3.org/2005/Atom) and the older
+// Atom 0.3 (http://purl.org/atom/ns#) share the same entry shape except
+// for their date element names - 0.3 uses issued/modified where 1.0
+// uses published/updated - and 0.3's content model lacks the type
+// attribute's xhtml/html distinction 1.0 added. atomEntry below declares
+// both sets of date tags so a single struct parses either namespace;
+// parseAtom picks whichever pair is populated.
 type atomFeed struct {
-	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	XMLName  xml.Name    `xml:"feed"`
 	Title    string      `xml:"title"`
 	Link     []atomLink  `xml:"link"`
 	Subtitle string      `xml:"subtitle"`
+	Tagline  string      `xml:"tagline"` // Atom 0.3's name for Subtitle
 	Updated  string      `xml:"updated"`
+	Modified string      `xml:"modified"` // Atom 0.3's name for Updated
 	Entries  []atomEntry `xml:"entry"`
 }
 
@@ -107,6 +360,8 @@ type atomEntry struct {
 	Categories []atomCategory `xml:"category"`
 	Published  string         `xml:"published"`
 	Updated    string         `xml:"updated"`
+	Issued     string         `xml:"issued"`   // Atom 0.3's name for Published
+	Modified   string         `xml:"modified"` // Atom 0.3's name for Updated
 }
 
 type atomContent struct {
@@ -142,7 +397,7 @@ func (r *RSSIntegration) FetchFeed(ctx context.Context, feedURL string) (*Feed,
 	}
 
 	req.Header.Set("User-Agent", "ElevatedIQ News Feed Engine/1.0")
-	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml, text/xml")
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/feed+json, application/xml, text/xml")
 
 	resp, err := r.httpClient.Do(req)
 	if err != nil {
@@ -159,24 +414,63 @@ func (r *RSSIntegration) FetchFeed(ctx context.Context, feedURL string) (*Feed,
 		return nil, fmt.Errorf("failed to read feed body: %w", err)
 	}
 
-	// Try to detect and parse feed type
-	feed, err := r.parseRSS(body)
-	if err == nil {
-		return feed, nil
+	return r.parseFeedBody(resp.Header.Get("Content-Type"), body)
+}
+
+// parseFeedBody detects the feed format from contentType and the body's
+// root element, then parses it. Detection picks an order to try first;
+// every parser is still attempted as a fallback, since a server-reported
+// Content-Type or a feed's own root element can be wrong.
+func (r *RSSIntegration) parseFeedBody(contentType string, body []byte) (*Feed, error) {
+	parsers := []func([]byte) (*Feed, error){r.parseRSS, r.parseAtom, r.parseRDF}
+
+	if strings.Contains(contentType, "json") {
+		if feed, err := r.parseJSONFeed(body); err == nil {
+			return feed, nil
+		}
+	} else {
+		switch detectXMLRoot(body) {
+		case "rdf":
+			parsers = []func([]byte) (*Feed, error){r.parseRDF, r.parseRSS, r.parseAtom}
+		case "feed":
+			parsers = []func([]byte) (*Feed, error){r.parseAtom, r.parseRSS, r.parseRDF}
+		}
 	}
 
-	feed, err = r.parseAtom(body)
-	if err == nil {
+	for _, parse := range parsers {
+		if feed, err := parse(body); err == nil {
+			return feed, nil
+		}
+	}
+
+	if feed, err := r.parseJSONFeed(body); err == nil {
 		return feed, nil
 	}
 
-	return nil, fmt.Errorf("failed to parse feed as RSS or Atom")
+	return nil, fmt.Errorf("failed to parse feed as RSS, Atom, RDF, or JSON Feed")
+}
+
+// detectXMLRoot returns the local name of the document's root element
+// ("rdf", "rss", "feed"), or "" if it can't be determined. It tolerates
+// a leading XML declaration, comments, and processing instructions,
+// which real-world feeds often have before the root element.
+func detectXMLRoot(body []byte) string {
+	dec := xml.NewDecoder(strings.NewReader(string(body)))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return ""
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return strings.ToLower(start.Name.Local)
+		}
+	}
 }
 
 // parseRSS parses RSS 2.0 feed
 func (r *RSSIntegration) parseRSS(data []byte) (*Feed, error) {
 	var rss rssFeed
-	if err := xml.Unmarshal(data, &rss); err != nil {
+	if err := decodeXML(data, &rss); err != nil {
 		return nil, err
 	}
 
@@ -191,22 +485,25 @@ func (r *RSSIntegration) parseRSS(data []byte) (*Feed, error) {
 		Language:    rss.Channel.Language,
 		Items:       make([]FeedItem, 0, len(rss.Channel.Items)),
 	}
+	applyChannelPodcastMeta(feed, &rss.Channel)
 
+	loggedDates := make(map[string]bool)
 	for _, item := range rss.Channel.Items {
 		feedItem := FeedItem{
-			ID:          item.GUID,
-			Title:       cleanText(item.Title),
-			Link:        item.Link,
-			Description: cleanHTML(item.Description),
-			Categories:  item.Categories,
+			ID:         item.GUID,
+			Title:      stripToText(item.Title),
+			Link:       item.Link,
+			Categories: item.Categories,
 		}
+		feedItem.Description, _ = sanitizeHTML(item.Description, feedItem.Link)
 
 		// Use content:encoded if available, otherwise description
-		if item.Content != "" {
-			feedItem.Content = cleanHTML(item.Content)
-		} else {
-			feedItem.Content = feedItem.Description
+		raw := item.Content
+		if raw == "" {
+			raw = item.Description
 		}
+		feedItem.ContentHTML, feedItem.ContentText = sanitizeHTML(raw, feedItem.Link)
+		feedItem.Content = feedItem.ContentHTML
 
 		// Author
 		if item.Author != "" {
@@ -217,22 +514,28 @@ func (r *RSSIntegration) parseRSS(data []byte) (*Feed, error) {
 
 		// Parse published date
 		if item.PubDate != "" {
-			pubDate, err := parseRSSDate(item.PubDate)
+			pubDate, err := r.parseDate(item.PubDate, loggedDates)
 			if err == nil {
 				feedItem.PublishedAt = pubDate
 			}
 		}
 
-		// Image URL from enclosure or media:content
-		if item.Enclosure != nil && strings.HasPrefix(item.Enclosure.Type, "image/") {
+		feedItem.Media = buildMediaElement(&item)
+		feedItem.Podcast = buildPodcastMetadata(&item)
+
+		// Image URL from enclosure, Media RSS, or podcast cover art
+		switch {
+		case item.Enclosure != nil && strings.HasPrefix(item.Enclosure.Type, "image/"):
 			feedItem.ImageURL = item.Enclosure.URL
-		} else if item.MediaContent != nil && (item.MediaContent.Medium == "image" || strings.HasPrefix(item.MediaContent.URL, "http")) {
-			feedItem.ImageURL = item.MediaContent.URL
-		} else {
+		case feedItem.Media != nil && feedItem.Media.BestImage() != "":
+			feedItem.ImageURL = feedItem.Media.BestImage()
+		case feedItem.Podcast != nil && feedItem.Podcast.ImageURL != "":
+			feedItem.ImageURL = feedItem.Podcast.ImageURL
+		default:
 			// Try to extract image from content
-			feedItem.ImageURL = extractImageFromHTML(item.Content)
+			feedItem.ImageURL = bestImage(item.Content, feedItem.Link)
 			if feedItem.ImageURL == "" {
-				feedItem.ImageURL = extractImageFromHTML(item.Description)
+				feedItem.ImageURL = bestImage(item.Description, feedItem.Link)
 			}
 		}
 
@@ -247,10 +550,161 @@ func (r *RSSIntegration) parseRSS(data []byte) (*Feed, error) {
 	return feed, nil
 }
 
+// applyChannelPodcastMeta copies channel-level iTunes/Google Play
+// podcast fields onto feed, preferring iTunes when both are present.
+func applyChannelPodcastMeta(feed *Feed, channel *rssChannel) {
+	feed.PodcastAuthor = channel.ItunesAuthor
+
+	for _, cat := range channel.ItunesCategory {
+		feed.PodcastCategories = append(feed.PodcastCategories, cat.Text)
+		for _, sub := range cat.Sub {
+			feed.PodcastCategories = append(feed.PodcastCategories, sub.Text)
+		}
+	}
+	for _, cat := range channel.GooglePlayCategory {
+		feed.PodcastCategories = append(feed.PodcastCategories, cat.Text)
+	}
+
+	feed.PodcastExplicit = isExplicitValue(channel.ItunesExplicit) || isExplicitValue(channel.GooglePlayExplicit)
+
+	if channel.ItunesImage != nil {
+		feed.PodcastImageURL = channel.ItunesImage.Href
+	}
+
+	feed.PodcastSummary = channel.ItunesSummary
+}
+
+// isExplicitValue reports whether an iTunes/Google Play explicit value
+// ("yes", "true", "explicit") marks content as explicit.
+func isExplicitValue(v string) bool {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "yes", "true", "explicit":
+		return true
+	default:
+		return false
+	}
+}
+
+// buildMediaElement normalizes item's Media RSS fields - whether they
+// were declared directly on the item or grouped under media:group -
+// into a single MediaElement. Returns nil if item carries no Media RSS
+// data at all.
+func buildMediaElement(item *rssItem) *MediaElement {
+	contents := toMediaContentItems(item.MediaContents)
+	thumbnails := toMediaThumbnails(item.MediaThumbnails)
+	title, description, credit, rating := item.MediaTitle, item.MediaDesc, item.MediaCredit, item.MediaRating
+	categories := append([]string{}, item.MediaCategory...)
+	var peerLink string
+	if item.MediaPeerLink != nil {
+		peerLink = item.MediaPeerLink.Href
+	}
+
+	if item.MediaGroup != nil {
+		contents = append(contents, toMediaContentItems(item.MediaGroup.Contents)...)
+		thumbnails = append(thumbnails, toMediaThumbnails(item.MediaGroup.Thumbnails)...)
+		if title == "" {
+			title = item.MediaGroup.Title
+		}
+		if description == "" {
+			description = item.MediaGroup.Description
+		}
+		if credit == "" {
+			credit = item.MediaGroup.Credit
+		}
+		if rating == "" {
+			rating = item.MediaGroup.Rating
+		}
+		categories = append(categories, item.MediaGroup.Category...)
+	}
+
+	if len(contents) == 0 && len(thumbnails) == 0 && title == "" && description == "" && credit == "" && rating == "" && peerLink == "" && len(categories) == 0 {
+		return nil
+	}
+
+	return &MediaElement{
+		Title: title, Description: description, Credit: credit, Rating: rating,
+		PeerLink: peerLink, Categories: categories, Thumbnails: thumbnails, Contents: contents,
+	}
+}
+
+func toMediaContentItems(xs []mediaContentXML) []MediaContentItem {
+	out := make([]MediaContentItem, 0, len(xs))
+	for _, x := range xs {
+		out = append(out, MediaContentItem{
+			URL: x.URL, Type: x.Type, Medium: x.Medium, Width: x.Width, Height: x.Height,
+			Duration: x.Duration, FileSize: x.FileSize, Bitrate: x.Bitrate, Lang: x.Lang, IsDefault: x.IsDefault,
+		})
+	}
+	return out
+}
+
+func toMediaThumbnails(xs []mediaThumbnailXML) []MediaThumbnail {
+	out := make([]MediaThumbnail, 0, len(xs))
+	for _, x := range xs {
+		out = append(out, MediaThumbnail{URL: x.URL, Width: x.Width, Height: x.Height})
+	}
+	return out
+}
+
+// buildPodcastMetadata consolidates item's iTunes/Google Play podcast
+// fields, preferring iTunes when both namespaces supplied a value.
+// Returns nil if item carries none of these fields.
+func buildPodcastMetadata(item *rssItem) *PodcastMetadata {
+	episode, _ := parseIntField(item.ItunesEpisode)
+	season, _ := parseIntField(item.ItunesSeason)
+	duration := parseItunesDuration(item.ItunesDuration)
+
+	var imageURL string
+	if item.ItunesImage != nil {
+		imageURL = item.ItunesImage.Href
+	} else if item.GooglePlayImage != nil {
+		imageURL = item.GooglePlayImage.Href
+	}
+
+	if episode == 0 && season == 0 && item.ItunesEpisodeType == "" && duration == 0 && imageURL == "" {
+		return nil
+	}
+
+	return &PodcastMetadata{
+		Episode: episode, Season: season, EpisodeType: item.ItunesEpisodeType,
+		DurationSeconds: duration, ImageURL: imageURL,
+	}
+}
+
+// parseItunesDuration parses itunes:duration, which may be plain
+// seconds ("1800"), "MM:SS", or "HH:MM:SS". Returns 0 if s is empty or
+// doesn't match any of these.
+func parseItunesDuration(s string) int {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0
+	}
+	parts := strings.Split(s, ":")
+	seconds := 0
+	for _, p := range parts {
+		n, err := parseIntField(p)
+		if err != nil {
+			return 0
+		}
+		seconds = seconds*60 + n
+	}
+	return seconds
+}
+
+// parseIntField parses s as a base-10 integer, returning 0 (with
+// the error from strconv.Atoi) if it isn't one.
+func parseIntField(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
+
 // parseAtom parses Atom feed
 func (r *RSSIntegration) parseAtom(data []byte) (*Feed, error) {
 	var atom atomFeed
-	if err := xml.Unmarshal(data, &atom); err != nil {
+	if err := decodeXML(data, &atom); err != nil {
 		return nil, err
 	}
 
@@ -258,9 +712,18 @@ func (r *RSSIntegration) parseAtom(data []byte) (*Feed, error) {
 		return nil, fmt.Errorf("not a valid Atom feed")
 	}
 
+	description := atom.Subtitle
+	if description == "" {
+		description = atom.Tagline
+	}
+	updatedStr := atom.Updated
+	if updatedStr == "" {
+		updatedStr = atom.Modified
+	}
+
 	feed := &Feed{
 		Title:       atom.Title,
-		Description: atom.Subtitle,
+		Description: description,
 		Items:       make([]FeedItem, 0, len(atom.Entries)),
 	}
 
@@ -273,8 +736,8 @@ func (r *RSSIntegration) parseAtom(data []byte) (*Feed, error) {
 	}
 
 	// Parse updated time
-	if atom.Updated != "" {
-		updated, err := time.Parse(time.RFC3339, atom.Updated)
+	if updatedStr != "" {
+		updated, err := time.Parse(time.RFC3339, updatedStr)
 		if err == nil {
 			feed.LastUpdated = updated
 		}
@@ -283,7 +746,7 @@ func (r *RSSIntegration) parseAtom(data []byte) (*Feed, error) {
 	for _, entry := range atom.Entries {
 		feedItem := FeedItem{
 			ID:    entry.ID,
-			Title: cleanText(entry.Title),
+			Title: stripToText(entry.Title),
 		}
 
 		// Find entry link
@@ -297,16 +760,20 @@ func (r *RSSIntegration) parseAtom(data []byte) (*Feed, error) {
 		// Content
 		if entry.Content.Content != "" {
 			if entry.Content.Type == "html" || entry.Content.Type == "xhtml" {
-				feedItem.Content = cleanHTML(entry.Content.Content)
+				feedItem.ContentHTML, feedItem.ContentText = sanitizeHTML(entry.Content.Content, feedItem.Link)
 			} else {
-				feedItem.Content = cleanText(entry.Content.Content)
+				feedItem.ContentText = stripToText(entry.Content.Content)
+				feedItem.ContentHTML = feedItem.ContentText
 			}
+			feedItem.Content = feedItem.ContentHTML
 		}
 
 		// Summary/Description
-		feedItem.Description = cleanHTML(entry.Summary)
+		feedItem.Description, _ = sanitizeHTML(entry.Summary, feedItem.Link)
 		if feedItem.Content == "" {
-			feedItem.Content = feedItem.Description
+			feedItem.ContentHTML = feedItem.Description
+			feedItem.ContentText = stripToText(entry.Summary)
+			feedItem.Content = feedItem.ContentHTML
 		}
 
 		// Author
@@ -323,22 +790,152 @@ func (r *RSSIntegration) parseAtom(data []byte) (*Feed, error) {
 			}
 		}
 
-		// Parse dates
-		if entry.Published != "" {
-			published, err := time.Parse(time.RFC3339, entry.Published)
+		// Parse dates - prefer Atom 1.0's published/updated, falling
+		// back to 0.3's issued/modified.
+		publishedStr := entry.Published
+		if publishedStr == "" {
+			publishedStr = entry.Issued
+		}
+		updatedStr := entry.Updated
+		if updatedStr == "" {
+			updatedStr = entry.Modified
+		}
+		if publishedStr != "" {
+			published, err := time.Parse(time.RFC3339, publishedStr)
 			if err == nil {
 				feedItem.PublishedAt = published
 			}
 		}
-		if entry.Updated != "" {
-			updated, err := time.Parse(time.RFC3339, entry.Updated)
+		if updatedStr != "" {
+			updated, err := time.Parse(time.RFC3339, updatedStr)
 			if err == nil {
 				feedItem.UpdatedAt = updated
 			}
 		}
 
 		// Extract image from content
-		feedItem.ImageURL = extractImageFromHTML(entry.Content.Content)
+		feedItem.ImageURL = bestImage(entry.Content.Content, feedItem.Link)
+
+		feed.Items = append(feed.Items, feedItem)
+	}
+
+	return feed, nil
+}
+
+// parseRDF parses an RDF/RSS 1.0 feed (http://purl.org/rss/1.0/),
+// notably used by Slashdot and other older sites. Items are siblings of
+// channel under rdf:RDF rather than nested inside it like RSS 2.0.
+func (r *RSSIntegration) parseRDF(data []byte) (*Feed, error) {
+	var rdf rdfFeed
+	if err := decodeXML(data, &rdf); err != nil {
+		return nil, err
+	}
+
+	if rdf.Channel.Title == "" {
+		return nil, fmt.Errorf("not a valid RDF feed")
+	}
+
+	feed := &Feed{
+		Title:       rdf.Channel.Title,
+		Link:        rdf.Channel.Link,
+		Description: rdf.Channel.Description,
+		Items:       make([]FeedItem, 0, len(rdf.Items)),
+	}
+
+	loggedDates := make(map[string]bool)
+	for _, item := range rdf.Items {
+		feedItem := FeedItem{
+			ID:         item.About,
+			Title:      stripToText(item.Title),
+			Link:       item.Link,
+			Author:     item.Creator,
+			Categories: item.Subjects,
+		}
+		feedItem.Description, _ = sanitizeHTML(item.Description, feedItem.Link)
+		feedItem.ContentHTML, feedItem.ContentText = sanitizeHTML(item.Description, feedItem.Link)
+		feedItem.Content = feedItem.ContentHTML
+
+		if item.Date != "" {
+			if published, err := r.parseDate(item.Date, loggedDates); err == nil {
+				feedItem.PublishedAt = published
+			}
+		}
+
+		if feedItem.ID == "" {
+			feedItem.ID = feedItem.Link
+		}
+
+		feedItem.ImageURL = bestImage(item.Description, feedItem.Link)
+
+		feed.Items = append(feed.Items, feedItem)
+	}
+
+	return feed, nil
+}
+
+// parseJSONFeed parses a JSON Feed 1.1 document
+// (https://www.jsonfeed.org/version/1.1/, served as
+// application/feed+json).
+func (r *RSSIntegration) parseJSONFeed(data []byte) (*Feed, error) {
+	var doc jsonFeedDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	if doc.Version == "" || doc.Title == "" {
+		return nil, fmt.Errorf("not a valid JSON Feed")
+	}
+
+	feed := &Feed{
+		Title:       doc.Title,
+		Link:        doc.HomePageURL,
+		Description: doc.Description,
+		Language:    doc.Language,
+		Items:       make([]FeedItem, 0, len(doc.Items)),
+	}
+
+	for _, item := range doc.Items {
+		feedItem := FeedItem{
+			ID:          item.ID,
+			Title:       stripToText(item.Title),
+			Link:        item.URL,
+			Description: stripToText(item.Summary),
+			ImageURL:    item.Image,
+		}
+
+		if item.ContentHTML != "" {
+			feedItem.ContentHTML, feedItem.ContentText = sanitizeHTML(item.ContentHTML, feedItem.Link)
+		} else {
+			feedItem.ContentText = stripToText(item.ContentText)
+			feedItem.ContentHTML = feedItem.ContentText
+		}
+		feedItem.Content = feedItem.ContentHTML
+		if feedItem.Description == "" {
+			feedItem.Description = feedItem.Content
+		}
+
+		if len(item.Authors) > 0 {
+			feedItem.Author = item.Authors[0].Name
+		}
+		feedItem.Categories = item.Tags
+
+		if item.DatePublished != "" {
+			if published, err := time.Parse(time.RFC3339, item.DatePublished); err == nil {
+				feedItem.PublishedAt = published
+			}
+		}
+		if item.DateModified != "" {
+			if updated, err := time.Parse(time.RFC3339, item.DateModified); err == nil {
+				feedItem.UpdatedAt = updated
+			}
+		}
+
+		if feedItem.ImageURL == "" {
+			feedItem.ImageURL = bestImage(feedItem.Content, feedItem.Link)
+		}
+		if feedItem.ID == "" {
+			feedItem.ID = feedItem.Link
+		}
 
 		feed.Items = append(feed.Items, feedItem)
 	}
@@ -355,7 +952,10 @@ func (r *RSSIntegration) ValidateFeedURL(ctx context.Context, feedURL string) (b
 	return feed != nil && len(feed.Items) > 0, nil
 }
 
-// parseRSSDate parses various RSS date formats
+// parseRSSDate parses the date formats seen across RSS, Atom, and RDF
+// feeds, roughly ordered from most to least common. As a last resort it
+// also accepts a bare Unix epoch-seconds integer, which some feeds emit
+// instead of any textual format.
 func parseRSSDate(dateStr string) (time.Time, error) {
 	formats := []string{
 		time.RFC1123Z,
@@ -364,10 +964,15 @@ func parseRSSDate(dateStr string) (time.Time, error) {
 		time.RFC822,
 		"Mon, 2 Jan 2006 15:04:05 -0700",
 		"Mon, 2 Jan 2006 15:04:05 MST",
-		"2006-01-02T15:04:05-07:00",
+		time.RFC3339,
+		"2006-01-02T15:04:05.999999Z",
+		"2006-01-02T15:04:05-0700",
 		"2006-01-02T15:04:05Z",
 		"2006-01-02 15:04:05",
 		"2006-01-02",
+		"Monday, January 2, 2006 3:04 PM MST",
+		time.ANSIC,
+		time.UnixDate,
 	}
 
 	dateStr = strings.TrimSpace(dateStr)
@@ -378,56 +983,52 @@ func parseRSSDate(dateStr string) (time.Time, error) {
 		}
 	}
 
+	if seconds, err := strconv.ParseInt(dateStr, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+
 	return time.Time{}, fmt.Errorf("unable to parse date: %s", dateStr)
 }
 
-// cleanText removes HTML and cleans up text
-func cleanText(s string) string {
-	s = html.UnescapeString(s)
-	s = regexp.MustCompile(`<[^>]*>`).ReplaceAllString(s, "")
-	s = regexp.MustCompile(`\s+`).ReplaceAllString(s, " ")
-	return strings.TrimSpace(s)
+// parseDate parses dateStr via parseRSSDate, logging the raw string via
+// r.logger the first time it fails to parse within one feed - logged
+// accumulates across a single parseRSS/parseRDF call's item loop - so a
+// feed whose date format parseRSSDate can't handle logs once instead of
+// once per item.
+func (r *RSSIntegration) parseDate(dateStr string, logged map[string]bool) (time.Time, error) {
+	t, err := parseRSSDate(dateStr)
+	if err != nil && !logged[dateStr] {
+		logged[dateStr] = true
+		r.logger.Warn("unable to parse feed item date", zap.String("raw_date", dateStr))
+	}
+	return t, err
 }
 
-// cleanHTML removes HTML tags but preserves some structure
-func cleanHTML(s string) string {
-	s = html.UnescapeString(s)
-
-	// Replace common block elements with newlines
-	s = regexp.MustCompile(`(?i)<br\s*/?\s*>`).ReplaceAllString(s, "\n")
-	s = regexp.MustCompile(`(?i)</p>`).ReplaceAllString(s, "\n\n")
-	s = regexp.MustCompile(`(?i)</div>`).ReplaceAllString(s, "\n")
-	s = regexp.MustCompile(`(?i)</li>`).ReplaceAllString(s, "\n")
-
-	// Remove all remaining tags
-	s = regexp.MustCompile(`<[^>]*>`).ReplaceAllString(s, "")
-
-	// Clean up whitespace
-	s = regexp.MustCompile(`[ \t]+`).ReplaceAllString(s, " ")
-	s = regexp.MustCompile(`\n\s*\n`).ReplaceAllString(s, "\n\n")
-
-	return strings.TrimSpace(s)
+// stripToText renders s as plain text: entities decoded, every tag
+// removed (not just stripped - an unallowed tag's own content is kept,
+// matching sanitizer.Sanitize's unwrap behavior), whitespace collapsed.
+func stripToText(s string) string {
+	_, text := sanitizer.Sanitize(s, "", sanitizer.Policy{})
+	return text
 }
 
-// extractImageFromHTML extracts the first image URL from HTML content
-func extractImageFromHTML(htmlContent string) string {
-	if htmlContent == "" {
-		return ""
-	}
-
-	// Try to find img src
-	imgRegex := regexp.MustCompile(`<img[^>]+src=["']([^"']+)["']`)
-	matches := imgRegex.FindStringSubmatch(htmlContent)
-	if len(matches) > 1 {
-		return matches[1]
-	}
+// sanitizeHTML allowlist-sanitizes s against sanitizer.DefaultPolicy,
+// resolving relative URLs against baseURL, and returns the sanitized
+// HTML alongside its plain-text rendering.
+func sanitizeHTML(s, baseURL string) (contentHTML string, contentText string) {
+	return sanitizer.Sanitize(s, baseURL, sanitizer.DefaultPolicy())
+}
 
-	// Try to find og:image or similar meta tags (less common in feed content)
-	metaRegex := regexp.MustCompile(`<meta[^>]+content=["']([^"']+\.(jpg|jpeg|png|gif|webp)[^"']*)["']`)
-	matches = metaRegex.FindStringSubmatch(htmlContent)
-	if len(matches) > 1 {
-		return matches[1]
+// bestImage returns the highest-resolution image sanitizer.ExtractImages
+// finds in htmlContent (an <img>, or an OpenGraph/Twitter Card meta
+// image), resolved against baseURL. Returns "" if htmlContent has none.
+func bestImage(htmlContent, baseURL string) string {
+	candidates := sanitizer.ExtractImages(htmlContent, baseURL)
+	best, bestWidth := "", -1
+	for _, c := range candidates {
+		if c.Width > bestWidth {
+			best, bestWidth = c.URL, c.Width
+		}
 	}
-
-	return ""
+	return best
 }
@@ -0,0 +1,157 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// FeedCandidate is one feed URL Discover found on a page, with enough
+// metadata for a caller to present a subscribe picker before fetching
+// and parsing it.
+type FeedCandidate struct {
+	Title string `json:"title,omitempty"`
+	Type  string `json:"type"` // "rss", "atom", or "json"
+	URL   string `json:"url"`
+}
+
+// feedLinkTypes maps the MIME types feed <link> tags declare to
+// FeedCandidate.Type.
+var feedLinkTypes = map[string]string{
+	"application/rss+xml":   "rss",
+	"application/atom+xml":  "atom",
+	"application/feed+json": "json",
+}
+
+// wellKnownFeedPaths are common feed locations probed relative to the
+// site's root when nothing is discoverable from <link> tags.
+var wellKnownFeedPaths = []string{"/feed", "/rss", "/atom.xml", "/index.xml", "/feed.json"}
+
+var (
+	linkTagPattern   = regexp.MustCompile(`(?is)<link\b[^>]*>`)
+	anchorTagPattern = regexp.MustCompile(`(?is)<a\b[^>]*?href\s*=\s*["']([^"']*)["'][^>]*>(.*?)</a>`)
+	htmlAttrPattern  = regexp.MustCompile(`(?i)([a-zA-Z][a-zA-Z0-9-]*)\s*=\s*"([^"]*)"|([a-zA-Z][a-zA-Z0-9-]*)\s*=\s*'([^']*)'`)
+	htmlTagPattern   = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// Discover fetches siteURL's HTML and returns candidate feed URLs,
+// deduplicated by URL: <link rel="alternate" type="application/rss+xml|
+// application/atom+xml|application/feed+json"> tags, common well-known
+// feed paths (probed with a HEAD request so only ones that actually
+// resolve are returned), and <a href> links whose URL or link text
+// mentions "rss" or "feed". Callers should present the result as a
+// picker rather than auto-subscribing, since the heuristics here can
+// both miss feeds and surface false positives.
+func (r *RSSIntegration) Discover(ctx context.Context, siteURL string) ([]FeedCandidate, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, siteURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "ElevatedIQ News Feed Engine/1.0")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("page returned status: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page body: %w", err)
+	}
+
+	base, _ := url.Parse(siteURL)
+	page := string(body)
+
+	seen := make(map[string]bool)
+	var candidates []FeedCandidate
+	add := func(c FeedCandidate) {
+		if c.URL == "" || seen[c.URL] {
+			return
+		}
+		seen[c.URL] = true
+		candidates = append(candidates, c)
+	}
+
+	for _, tag := range linkTagPattern.FindAllString(page, -1) {
+		attrs := parseTagAttrs(tag)
+		feedType, ok := feedLinkTypes[strings.ToLower(attrs["type"])]
+		if !ok || !strings.Contains(strings.ToLower(attrs["rel"]), "alternate") {
+			continue
+		}
+		add(FeedCandidate{Title: attrs["title"], Type: feedType, URL: resolveDiscoveredURL(base, attrs["href"])})
+	}
+
+	for _, path := range wellKnownFeedPaths {
+		candidateURL := resolveDiscoveredURL(base, path)
+		if candidateURL == "" || seen[candidateURL] || !r.probeFeedURL(ctx, candidateURL) {
+			continue
+		}
+		add(FeedCandidate{Type: "rss", URL: candidateURL})
+	}
+
+	for _, m := range anchorTagPattern.FindAllStringSubmatch(page, -1) {
+		href, text := m[1], strings.TrimSpace(stripTags(m[2]))
+		lower := strings.ToLower(href + " " + text)
+		if !strings.Contains(lower, "rss") && !strings.Contains(lower, "feed") {
+			continue
+		}
+		add(FeedCandidate{Title: text, Type: "rss", URL: resolveDiscoveredURL(base, href)})
+	}
+
+	return candidates, nil
+}
+
+// probeFeedURL reports whether feedURL resolves with a 2xx status,
+// used to filter well-known feed paths down to ones that actually
+// exist rather than returning every guess unconditionally.
+func (r *RSSIntegration) probeFeedURL(ctx context.Context, feedURL string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, feedURL, nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("User-Agent", "ElevatedIQ News Feed Engine/1.0")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode >= 200 && resp.StatusCode < 300
+}
+
+func parseTagAttrs(tag string) map[string]string {
+	attrs := make(map[string]string)
+	for _, m := range htmlAttrPattern.FindAllStringSubmatch(tag, -1) {
+		key, val := m[1], m[2]
+		if key == "" {
+			key, val = m[3], m[4]
+		}
+		attrs[strings.ToLower(key)] = html.UnescapeString(val)
+	}
+	return attrs
+}
+
+func resolveDiscoveredURL(base *url.URL, ref string) string {
+	if base == nil || ref == "" {
+		return ref
+	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	return base.ResolveReference(u).String()
+}
+
+func stripTags(s string) string {
+	return htmlTagPattern.ReplaceAllString(s, "")
+}
@@ -0,0 +1,180 @@
+package integrations
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FeedFetchState is what FetchFeedConditional remembers about one feed
+// URL between polls, so a repeat poll can skip re-downloading and
+// re-parsing a body that hasn't changed.
+type FeedFetchState struct {
+	ETag             string    `json:"etag,omitempty"`
+	LastModified     string    `json:"last_modified,omitempty"`
+	LastFetchedAt    time.Time `json:"last_fetched_at,omitempty"`
+	NextPollAt       time.Time `json:"next_poll_at,omitempty"`
+	ConsecutiveFails int       `json:"consecutive_fails,omitempty"`
+	BodyHash         string    `json:"body_hash,omitempty"`
+}
+
+// FetchFeedConditional fetches feedURL using the conditional headers
+// state carries (If-None-Match/If-Modified-Since), transparently
+// decompresses gzip/deflate response bodies, and honors Cache-Control
+// max-age and Retry-After when computing the returned state's
+// NextPollAt. It returns a nil *Feed (with no error) when the server
+// answered 304 or the body's hash matches state.BodyHash - the caller's
+// signal that nothing changed since the last fetch.
+//
+// brotli ("br") is not decompressed: this package has no brotli decoder
+// vendored, so Accept-Encoding only advertises gzip/deflate. A br-only
+// server will be read as opaque compressed bytes and fail to parse as
+// any feed format.
+func (r *RSSIntegration) FetchFeedConditional(ctx context.Context, feedURL string, state FeedFetchState) (*Feed, FeedFetchState, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, state, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", "ElevatedIQ News Feed Engine/1.0")
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/feed+json, application/xml, text/xml")
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	if state.ETag != "" {
+		req.Header.Set("If-None-Match", state.ETag)
+	}
+	if state.LastModified != "" {
+		req.Header.Set("If-Modified-Since", state.LastModified)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		state.ConsecutiveFails++
+		return nil, state, fmt.Errorf("failed to fetch feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	next := state
+	next.LastFetchedAt = time.Now()
+
+	if resp.StatusCode == http.StatusNotModified {
+		next.ConsecutiveFails = 0
+		next.NextPollAt = nextPollTime(resp, next.LastFetchedAt, defaultPollInterval)
+		return nil, next, nil
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		next.ConsecutiveFails++
+		next.NextPollAt = nextPollTime(resp, next.LastFetchedAt, backoffInterval(defaultPollInterval, next.ConsecutiveFails))
+		return nil, next, fmt.Errorf("feed returned status: %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		next.ConsecutiveFails++
+		return nil, next, fmt.Errorf("feed returned status: %d", resp.StatusCode)
+	}
+
+	body, err := decodeBody(resp)
+	if err != nil {
+		next.ConsecutiveFails++
+		return nil, next, fmt.Errorf("failed to read feed body: %w", err)
+	}
+
+	hash := hashBody(body)
+	if state.BodyHash != "" && hash == state.BodyHash {
+		next.ConsecutiveFails = 0
+		next.NextPollAt = nextPollTime(resp, next.LastFetchedAt, defaultPollInterval)
+		return nil, next, nil
+	}
+
+	feed, err := r.parseFeedBody(resp.Header.Get("Content-Type"), body)
+	if err != nil {
+		next.ConsecutiveFails++
+		return nil, next, err
+	}
+
+	next.ETag = resp.Header.Get("ETag")
+	next.LastModified = resp.Header.Get("Last-Modified")
+	next.BodyHash = hash
+	next.ConsecutiveFails = 0
+	next.NextPollAt = nextPollTime(resp, next.LastFetchedAt, pollIntervalFromFeed(feed, defaultPollInterval))
+
+	return feed, next, nil
+}
+
+func decodeBody(resp *http.Response) ([]byte, error) {
+	switch strings.ToLower(resp.Header.Get("Content-Encoding")) {
+	case "gzip":
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		return io.ReadAll(gz)
+	case "deflate":
+		fl := flate.NewReader(resp.Body)
+		defer fl.Close()
+		return io.ReadAll(fl)
+	default:
+		return io.ReadAll(resp.Body)
+	}
+}
+
+func hashBody(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// nextPollTime honors Retry-After (seconds or HTTP date) first, then
+// Cache-Control's max-age, falling back to fallback if the response set
+// neither.
+func nextPollTime(resp *http.Response, from time.Time, fallback time.Duration) time.Time {
+	if ra := resp.Header.Get("Retry-After"); ra != "" {
+		if secs, err := strconv.Atoi(strings.TrimSpace(ra)); err == nil {
+			return from.Add(time.Duration(secs) * time.Second)
+		}
+		if when, err := http.ParseTime(ra); err == nil {
+			return when
+		}
+	}
+	if cc := resp.Header.Get("Cache-Control"); cc != "" {
+		for _, directive := range strings.Split(cc, ",") {
+			directive = strings.TrimSpace(directive)
+			if secs, ok := strings.CutPrefix(directive, "max-age="); ok {
+				if n, err := strconv.Atoi(secs); err == nil {
+					return from.Add(time.Duration(n) * time.Second)
+				}
+			}
+		}
+	}
+	return from.Add(fallback)
+}
+
+// backoffInterval doubles base once per consecutive failure, capped at
+// maxPollInterval, with up to 20% jitter so many feeds failing at once
+// don't all retry in lockstep.
+func backoffInterval(base time.Duration, consecutiveFails int) time.Duration {
+	interval := base
+	for i := 0; i < consecutiveFails && interval < maxPollInterval; i++ {
+		interval *= 2
+	}
+	if interval > maxPollInterval {
+		interval = maxPollInterval
+	}
+	return withJitter(interval)
+}
+
+func withJitter(interval time.Duration) time.Duration {
+	if interval <= 0 {
+		return interval
+	}
+	jitter := time.Duration(rand.Int63n(int64(interval) / 5)) // up to 20%
+	return interval + jitter - jitter/2
+}
@@ -0,0 +1,174 @@
+package integrations
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Default poll cadence bounds, used whenever a feed's own observed
+// publish cadence can't be computed (too few items) or would fall
+// outside them.
+const (
+	defaultPollInterval = 15 * time.Minute
+	minPollInterval     = time.Minute
+	maxPollInterval     = 12 * time.Hour
+)
+
+// FeedPollResult is what FeedScheduler.Poll sends on its result channel
+// for one feed URL.
+type FeedPollResult struct {
+	FeedURL string
+	Feed    *Feed
+	Err     error
+}
+
+// FeedScheduler turns RSSIntegration into a long-running polling
+// subsystem: given a set of feed URLs, it tracks each one's
+// FeedFetchState and next-poll time, computing the next interval from
+// exponential backoff on failures and the feed's own observed publish
+// cadence, rather than polling every feed on one fixed cadence. It's
+// the per-feed-URL analogue of ingestion.Scheduler's adaptive per-source
+// interval, but driven by HTTP response metadata and item timestamps
+// instead of ingestion_history.
+type FeedScheduler struct {
+	rss    *RSSIntegration
+	logger *zap.Logger
+
+	mu     sync.Mutex
+	states map[string]FeedFetchState
+}
+
+// NewFeedScheduler creates a FeedScheduler that fetches feeds via rss.
+func NewFeedScheduler(rss *RSSIntegration, logger *zap.Logger) *FeedScheduler {
+	return &FeedScheduler{
+		rss:    rss,
+		logger: logger,
+		states: make(map[string]FeedFetchState),
+	}
+}
+
+// State returns the FeedScheduler's current FeedFetchState for feedURL,
+// so a caller can persist it across process restarts and seed it back
+// in with SetState.
+func (s *FeedScheduler) State(feedURL string) (FeedFetchState, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.states[feedURL]
+	return state, ok
+}
+
+// SetState seeds feedURL's FeedFetchState, e.g. after loading it back
+// from persistent storage at startup.
+func (s *FeedScheduler) SetState(feedURL string, state FeedFetchState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[feedURL] = state
+}
+
+// Poll fetches every feed in feedURLs whose NextPollAt has passed as of
+// now (feeds never polled before are always due), sending each result
+// to the returned channel as its fetch completes. The channel is
+// closed once every due feed has been attempted. A feed result with a
+// nil Feed and nil Err means the feed hasn't changed since the last
+// poll (304 or an unchanged body hash).
+func (s *FeedScheduler) Poll(ctx context.Context, feedURLs []string, now time.Time) <-chan FeedPollResult {
+	results := make(chan FeedPollResult)
+
+	due := make([]string, 0, len(feedURLs))
+	for _, feedURL := range feedURLs {
+		s.mu.Lock()
+		state := s.states[feedURL]
+		s.mu.Unlock()
+		if state.NextPollAt.IsZero() || !state.NextPollAt.After(now) {
+			due = append(due, feedURL)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, feedURL := range due {
+		wg.Add(1)
+		go func(feedURL string) {
+			defer wg.Done()
+			s.pollOne(ctx, feedURL, results)
+		}(feedURL)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+func (s *FeedScheduler) pollOne(ctx context.Context, feedURL string, results chan<- FeedPollResult) {
+	s.mu.Lock()
+	state := s.states[feedURL]
+	s.mu.Unlock()
+
+	feed, next, err := s.rss.FetchFeedConditional(ctx, feedURL, state)
+	if err != nil {
+		next.NextPollAt = time.Now().Add(backoffInterval(defaultPollInterval, next.ConsecutiveFails))
+		s.logger.Warn("feed poll failed", zap.String("feed_url", feedURL), zap.Error(err), zap.Int("consecutive_fails", next.ConsecutiveFails))
+	}
+
+	s.mu.Lock()
+	s.states[feedURL] = next
+	s.mu.Unlock()
+
+	results <- FeedPollResult{FeedURL: feedURL, Feed: feed, Err: err}
+}
+
+// pollIntervalFromFeed derives a poll interval from feed's observed
+// publish cadence - the median gap between consecutive items' published
+// times - clamped to [min, max]. Falls back to fallback if feed has
+// fewer than 3 dated items to compute a cadence from.
+func pollIntervalFromFeed(feed *Feed, fallback time.Duration) time.Duration {
+	return clampInterval(medianPublishGap(feed), minPollInterval, maxPollInterval, fallback)
+}
+
+func medianPublishGap(feed *Feed) time.Duration {
+	if feed == nil {
+		return 0
+	}
+	var dates []time.Time
+	for _, item := range feed.Items {
+		if !item.PublishedAt.IsZero() {
+			dates = append(dates, item.PublishedAt)
+		}
+	}
+	if len(dates) < 3 {
+		return 0
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].After(dates[j]) })
+
+	gaps := make([]time.Duration, 0, len(dates)-1)
+	for i := 0; i < len(dates)-1; i++ {
+		gap := dates[i].Sub(dates[i+1])
+		if gap > 0 {
+			gaps = append(gaps, gap)
+		}
+	}
+	if len(gaps) == 0 {
+		return 0
+	}
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+	return gaps[len(gaps)/2]
+}
+
+func clampInterval(interval, lo, hi, fallback time.Duration) time.Duration {
+	if interval <= 0 {
+		interval = fallback
+	}
+	if interval < lo {
+		interval = lo
+	}
+	if interval > hi {
+		interval = hi
+	}
+	return interval
+}
@@ -0,0 +1,129 @@
+package sanitizer
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ImageCandidate is one image URL ExtractImages found, with its known
+// width (0 if unknown) so a caller can pick the largest.
+type ImageCandidate struct {
+	URL   string
+	Width int
+	// Source identifies where the candidate came from: "img" for an
+	// <img> tag, "og" for an OpenGraph og:image meta tag, or "twitter"
+	// for a twitter:image/twitter:image:src meta tag.
+	Source string
+}
+
+// ExtractImages returns every candidate image in htmlContent: each
+// <img src>'s URL (with its widest srcset candidate's width, if any, or
+// its width attribute otherwise), plus any OpenGraph/Twitter Card meta
+// image tags. URLs are resolved against baseURL.
+func ExtractImages(htmlContent, baseURL string) []ImageCandidate {
+	base, _ := url.Parse(baseURL)
+	tokens := tokenize(htmlContent)
+
+	var candidates []ImageCandidate
+	metaWidths := map[string]int{}
+
+	// First pass: collect og:image:width / twitter:image:width meta
+	// tags, keyed by the property/name they modify, since they can
+	// appear either before or after the image meta tag they describe.
+	for _, tok := range tokens {
+		if tok.Type != StartTagToken && tok.Type != SelfClosingTagToken {
+			continue
+		}
+		if tok.Data != "meta" {
+			continue
+		}
+		attrs := attrMap(tok.Attrs)
+		key := attrs["property"]
+		if key == "" {
+			key = attrs["name"]
+		}
+		if strings.HasSuffix(key, ":width") {
+			if w, err := strconv.Atoi(strings.TrimSpace(attrs["content"])); err == nil {
+				metaWidths[strings.TrimSuffix(key, ":width")] = w
+			}
+		}
+	}
+
+	for _, tok := range tokens {
+		if tok.Type != StartTagToken && tok.Type != SelfClosingTagToken {
+			continue
+		}
+		attrs := attrMap(tok.Attrs)
+
+		switch tok.Data {
+		case "img":
+			src := attrs["src"]
+			width := 0
+			if w, err := strconv.Atoi(strings.TrimSpace(attrs["width"])); err == nil {
+				width = w
+			}
+			if best, bestWidth, ok := bestSrcsetCandidate(attrs["srcset"]); ok {
+				src = best
+				width = bestWidth
+			}
+			if src == "" {
+				continue
+			}
+			candidates = append(candidates, ImageCandidate{URL: resolveURL(base, src), Width: width, Source: "img"})
+		case "meta":
+			key := attrs["property"]
+			source := "og"
+			if key == "" {
+				key = attrs["name"]
+				source = "twitter"
+			}
+			if key != "og:image" && key != "twitter:image" && key != "twitter:image:src" {
+				continue
+			}
+			content := attrs["content"]
+			if content == "" {
+				continue
+			}
+			width := metaWidths[key]
+			candidates = append(candidates, ImageCandidate{URL: resolveURL(base, content), Width: width, Source: source})
+		}
+	}
+
+	return candidates
+}
+
+func attrMap(attrs []Attr) map[string]string {
+	m := make(map[string]string, len(attrs))
+	for _, a := range attrs {
+		m[a.Key] = a.Val
+	}
+	return m
+}
+
+// bestSrcsetCandidate returns the widest-descriptor URL in a srcset
+// attribute (e.g. "a.jpg 480w, b.jpg 1024w" -> b.jpg, 1024). Density
+// descriptors ("2x") are ignored since they don't give a pixel width;
+// ok is false if srcset has no width-descriptor candidates.
+func bestSrcsetCandidate(srcset string) (bestURL string, bestWidth int, ok bool) {
+	for _, c := range strings.Split(srcset, ",") {
+		fields := strings.Fields(strings.TrimSpace(c))
+		if len(fields) < 2 {
+			continue
+		}
+		descriptor := fields[1]
+		if !strings.HasSuffix(descriptor, "w") {
+			continue
+		}
+		w, err := strconv.Atoi(strings.TrimSuffix(descriptor, "w"))
+		if err != nil {
+			continue
+		}
+		if w > bestWidth {
+			bestWidth = w
+			bestURL = fields[0]
+			ok = true
+		}
+	}
+	return bestURL, bestWidth, ok
+}
@@ -0,0 +1,189 @@
+package sanitizer
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Policy is an allowlist of tags and, per tag, the attributes permitted
+// on them. Tags/attributes not listed are stripped - for a disallowed
+// tag, its content is kept (unwrapped) but the tag itself is removed,
+// matching how the previous regex-based cleanHTML treated unknown tags.
+type Policy struct {
+	AllowedTags  map[string]bool
+	AllowedAttrs map[string][]string // tag -> attribute keys
+	GlobalAttrs  []string            // attribute keys allowed on every allowed tag
+}
+
+// DefaultPolicy is the allowlist RSS item content is sanitized against:
+// common prose/structure tags, with href/src (plus a few presentational
+// attributes) on the tags that need them.
+func DefaultPolicy() Policy {
+	return Policy{
+		AllowedTags: map[string]bool{
+			"p": true, "a": true, "img": true, "ul": true, "ol": true, "li": true,
+			"blockquote": true, "pre": true, "code": true,
+			"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+			"br": true, "strong": true, "em": true,
+		},
+		AllowedAttrs: map[string][]string{
+			"a":   {"href", "rel", "target"},
+			"img": {"src", "alt", "width", "height", "srcset"},
+		},
+	}
+}
+
+var disallowedSchemes = []string{"javascript:", "data:", "vbscript:"}
+
+// Sanitize walks htmlContent's token stream against policy, returning a
+// sanitized HTML fragment (contentHTML) and a tag-stripped plain-text
+// rendering (contentText). Relative href/src values are resolved
+// against baseURL; external anchors (a differing host than baseURL) get
+// rel="noopener noreferrer" forced onto them.
+func Sanitize(htmlContent, baseURL string, policy Policy) (contentHTML string, contentText string) {
+	base, _ := url.Parse(baseURL)
+
+	tokens := tokenize(htmlContent)
+	var htmlBuilder, textBuilder strings.Builder
+
+	for _, tok := range tokens {
+		switch tok.Type {
+		case TextToken:
+			decoded := html.UnescapeString(tok.Data)
+			htmlBuilder.WriteString(html.EscapeString(decoded))
+			textBuilder.WriteString(decoded)
+		case CommentToken:
+			// dropped
+		case StartTagToken, SelfClosingTagToken:
+			if !policy.AllowedTags[tok.Data] {
+				continue
+			}
+			htmlBuilder.WriteString(renderTag(tok, base, policy))
+		case EndTagToken:
+			if !policy.AllowedTags[tok.Data] {
+				continue
+			}
+			htmlBuilder.WriteString("</" + tok.Data + ">")
+		}
+	}
+
+	return strings.TrimSpace(htmlBuilder.String()), normalizeWhitespace(textBuilder.String())
+}
+
+func renderTag(tok Token, base *url.URL, policy Policy) string {
+	allowed := make(map[string]bool, len(policy.GlobalAttrs)+len(policy.AllowedAttrs[tok.Data]))
+	for _, a := range policy.GlobalAttrs {
+		allowed[a] = true
+	}
+	for _, a := range policy.AllowedAttrs[tok.Data] {
+		allowed[a] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("<" + tok.Data)
+
+	isExternalAnchor := false
+	for _, attr := range tok.Attrs {
+		if strings.HasPrefix(attr.Key, "on") {
+			continue // inline event handlers are never allowed, regardless of policy
+		}
+		if !allowed[attr.Key] {
+			continue
+		}
+
+		val := attr.Val
+		switch attr.Key {
+		case "href", "src":
+			if hasDisallowedScheme(val) {
+				continue
+			}
+			val = resolveURL(base, val)
+			if attr.Key == "href" && tok.Data == "a" && isExternalURL(base, val) {
+				isExternalAnchor = true
+			}
+		case "srcset":
+			val = normalizeSrcset(base, val)
+		}
+
+		b.WriteString(" " + attr.Key + `="` + html.EscapeString(val) + `"`)
+	}
+
+	if isExternalAnchor {
+		b.WriteString(` rel="noopener noreferrer"`)
+	}
+
+	if tok.Type == SelfClosingTagToken {
+		b.WriteString(" />")
+	} else {
+		b.WriteString(">")
+	}
+	return b.String()
+}
+
+// asciiControlStripper removes ASCII tabs, carriage returns, and line
+// feeds - browsers strip these anywhere in a URL before resolving its
+// scheme, so "java\tscript:alert(1)" still runs as javascript: even
+// though a naive prefix check on the raw attribute value wouldn't see
+// it as one.
+var asciiControlStripper = strings.NewReplacer("\t", "", "\r", "", "\n", "")
+
+func hasDisallowedScheme(val string) bool {
+	v := strings.ToLower(strings.TrimSpace(asciiControlStripper.Replace(val)))
+	for _, scheme := range disallowedSchemes {
+		if strings.HasPrefix(v, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveURL resolves ref against base, returning ref unchanged if
+// either fails to parse or base is nil.
+func resolveURL(base *url.URL, ref string) string {
+	if base == nil {
+		return ref
+	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return base.ResolveReference(u).String()
+}
+
+// isExternalURL reports whether resolved points at a different host
+// than base.
+func isExternalURL(base *url.URL, resolved string) bool {
+	if base == nil || base.Host == "" {
+		return false
+	}
+	u, err := url.Parse(resolved)
+	if err != nil {
+		return false
+	}
+	return u.Host != "" && !strings.EqualFold(u.Host, base.Host)
+}
+
+// normalizeSrcset resolves every URL candidate in a srcset attribute
+// against base, leaving each candidate's width/density descriptor
+// untouched.
+func normalizeSrcset(base *url.URL, srcset string) string {
+	candidates := strings.Split(srcset, ",")
+	out := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		fields := strings.Fields(strings.TrimSpace(c))
+		if len(fields) == 0 {
+			continue
+		}
+		fields[0] = resolveURL(base, fields[0])
+		out = append(out, strings.Join(fields, " "))
+	}
+	return strings.Join(out, ", ")
+}
+
+var whitespaceRun = regexp.MustCompile(`\s+`)
+
+func normalizeWhitespace(s string) string {
+	return strings.TrimSpace(whitespaceRun.ReplaceAllString(s, " "))
+}
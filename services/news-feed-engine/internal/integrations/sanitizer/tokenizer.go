@@ -0,0 +1,232 @@
+// Package sanitizer allowlist-sanitizes untrusted HTML fragments (feed
+// item descriptions/content) and extracts candidate images from them.
+//
+// It's built on a small hand-rolled HTML tokenizer rather than
+// golang.org/x/net/html: this module has no HTML parsing library
+// vendored anywhere (see internal/ingestion/scheduler.go's doc comment
+// for the equivalent no-cron-parser situation), so adding one would
+// mean introducing a new dependency rather than following an existing
+// one. The tokenizer below handles the well-formed-ish XHTML snippets
+// real feeds emit - quoted attributes, self-closing tags, comments -
+// but is not a spec-compliant HTML5 parser: it doesn't implement the
+// tree-construction algorithm's error-recovery rules for malformed
+// markup (e.g. mismatched or overlapping tags), so hostile or badly
+// broken input may sanitize less precisely than a full parser would.
+package sanitizer
+
+import "strings"
+
+// TokenType identifies what kind of token Token.Data/Attrs hold.
+type TokenType int
+
+const (
+	TextToken TokenType = iota
+	StartTagToken
+	EndTagToken
+	SelfClosingTagToken
+	CommentToken
+)
+
+// Attr is one HTML attribute, in source order.
+type Attr struct {
+	Key string
+	Val string
+}
+
+// Token is one tokenizer output: a run of text, a start/end/self-closing
+// tag, or a comment (comments carry no Data/Attrs - they're always
+// dropped by Sanitize).
+type Token struct {
+	Type  TokenType
+	Data  string // tag name (lowercased) for tags, raw text for TextToken
+	Attrs []Attr
+}
+
+// tokenize splits an HTML fragment into a flat token stream.
+func tokenize(s string) []Token {
+	var tokens []Token
+	pos := 0
+	n := len(s)
+
+	for pos < n {
+		lt := strings.IndexByte(s[pos:], '<')
+		if lt == -1 {
+			if pos < n {
+				tokens = append(tokens, Token{Type: TextToken, Data: s[pos:]})
+			}
+			break
+		}
+		if lt > 0 {
+			tokens = append(tokens, Token{Type: TextToken, Data: s[pos : pos+lt]})
+		}
+		pos += lt
+
+		switch {
+		case strings.HasPrefix(s[pos:], "<!--"):
+			end := strings.Index(s[pos:], "-->")
+			if end == -1 {
+				pos = n
+				break
+			}
+			tokens = append(tokens, Token{Type: CommentToken})
+			pos += end + len("-->")
+		case strings.HasPrefix(s[pos:], "<!") || strings.HasPrefix(s[pos:], "<?"):
+			// Doctype/CDATA/processing instructions: skip to '>'.
+			end := strings.IndexByte(s[pos:], '>')
+			if end == -1 {
+				pos = n
+				break
+			}
+			pos += end + 1
+		default:
+			tok, newPos, ok := readTag(s, pos)
+			if !ok {
+				// Not a real tag (e.g. a lone '<' in text) - emit it as text.
+				tokens = append(tokens, Token{Type: TextToken, Data: "<"})
+				pos++
+				break
+			}
+			tokens = append(tokens, tok)
+			pos = newPos
+		}
+	}
+
+	return tokens
+}
+
+// readTag parses one start/end/self-closing tag beginning at s[pos]
+// (which must be '<'). Returns ok=false if s[pos:] isn't a well-formed
+// tag, in which case the caller treats '<' as literal text.
+func readTag(s string, pos int) (Token, int, bool) {
+	n := len(s)
+	i := pos + 1
+	if i >= n {
+		return Token{}, pos, false
+	}
+
+	closing := false
+	if s[i] == '/' {
+		closing = true
+		i++
+	}
+
+	nameStart := i
+	for i < n && isNameByte(s[i]) {
+		i++
+	}
+	if i == nameStart {
+		return Token{}, pos, false
+	}
+	name := strings.ToLower(s[nameStart:i])
+
+	var attrs []Attr
+	selfClosing := false
+
+	for i < n {
+		i = skipSpace(s, i)
+		if i >= n {
+			return Token{}, pos, false
+		}
+		if s[i] == '>' {
+			i++
+			break
+		}
+		if s[i] == '/' && i+1 < n && s[i+1] == '>' {
+			selfClosing = true
+			i += 2
+			break
+		}
+		if closing {
+			// End tags don't carry attributes in practice; bail out to
+			// the next '>' rather than trying to parse them.
+			end := strings.IndexByte(s[i:], '>')
+			if end == -1 {
+				return Token{}, pos, false
+			}
+			i += end + 1
+			break
+		}
+
+		attr, newPos, ok := readAttr(s, i)
+		if !ok {
+			return Token{}, pos, false
+		}
+		attrs = append(attrs, attr)
+		i = newPos
+	}
+
+	switch {
+	case closing:
+		return Token{Type: EndTagToken, Data: name}, i, true
+	case selfClosing || voidElements[name]:
+		return Token{Type: SelfClosingTagToken, Data: name, Attrs: attrs}, i, true
+	default:
+		return Token{Type: StartTagToken, Data: name, Attrs: attrs}, i, true
+	}
+}
+
+func readAttr(s string, pos int) (Attr, int, bool) {
+	n := len(s)
+	i := skipSpace(s, pos)
+	keyStart := i
+	for i < n && isAttrNameByte(s[i]) {
+		i++
+	}
+	if i == keyStart {
+		return Attr{}, pos, false
+	}
+	key := strings.ToLower(s[keyStart:i])
+
+	i = skipSpace(s, i)
+	if i >= n || s[i] != '=' {
+		return Attr{Key: key}, i, true
+	}
+	i = skipSpace(s, i+1)
+	if i >= n {
+		return Attr{}, pos, false
+	}
+
+	if s[i] == '"' || s[i] == '\'' {
+		quote := s[i]
+		i++
+		valStart := i
+		end := strings.IndexByte(s[i:], quote)
+		if end == -1 {
+			return Attr{}, pos, false
+		}
+		val := s[valStart : valStart+end]
+		return Attr{Key: key, Val: val}, valStart + end + 1, true
+	}
+
+	valStart := i
+	for i < n && !isSpaceByte(s[i]) && s[i] != '>' {
+		i++
+	}
+	return Attr{Key: key, Val: s[valStart:i]}, i, true
+}
+
+func skipSpace(s string, i int) int {
+	for i < len(s) && isSpaceByte(s[i]) {
+		i++
+	}
+	return i
+}
+
+func isSpaceByte(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\f'
+}
+
+func isNameByte(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9') || b == '-' || b == ':' || b == '_'
+}
+
+func isAttrNameByte(b byte) bool {
+	return isNameByte(b)
+}
+
+// voidElements never have a closing tag or children, per the HTML spec.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"source": true, "track": true, "wbr": true,
+}
@@ -2,12 +2,22 @@ package integrations
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
+
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
 )
 
 // SocialMediaHub provides unified access to social media platforms
@@ -16,8 +26,46 @@ type SocialMediaHub struct {
 	twitter  *TwitterIntegration
 	rss      *RSSIntegration
 	blog     *BlogIntegration
+	reddit   *RedditIntegration
+	mastodon *MastodonIntegration
+	bluesky  *BlueskyIntegration
 	logger   *zap.Logger
 	tenantID string
+
+	// oauth resolves a tenant's user into posting credentials for
+	// CrossPost; nil until WithOAuthManager is called, in which case
+	// CrossPost's twitter/mastodon branches report that OAuth isn't
+	// configured instead of attempting to post.
+	oauth *OAuthManager
+
+	// scheduled persists CrossPostRequests whose Schedule is in the
+	// future; nil until WithScheduledPostStore is called, in which case
+	// CrossPost rejects scheduled requests instead of silently posting
+	// them immediately.
+	scheduled ScheduledPostStore
+
+	// twitterReader is what FetchTwitterUser actually reads tweets
+	// through; selected by NewSocialMediaHub from config.TwitterBackend,
+	// it may be twitter itself, a TwitterScraperBackend, or an
+	// autoTwitterReader composing both. Posting still goes through
+	// twitter directly, since only it can authenticate writes.
+	twitterReader twitterReader
+}
+
+// WithOAuthManager enables CrossPost's twitter and mastodon branches by
+// giving the hub a way to resolve a tenant's user into posting
+// credentials.
+func (h *SocialMediaHub) WithOAuthManager(oauth *OAuthManager) *SocialMediaHub {
+	h.oauth = oauth
+	return h
+}
+
+// WithScheduledPostStore enables CrossPostRequest.Schedule by giving the
+// hub somewhere to persist a scheduled request until RunDueScheduledPosts
+// picks it up.
+func (h *SocialMediaHub) WithScheduledPostStore(store ScheduledPostStore) *SocialMediaHub {
+	h.scheduled = store
+	return h
 }
 
 // SocialMediaConfig contains configuration for social media integrations
@@ -26,7 +74,19 @@ type SocialMediaConfig struct {
 	TwitterBearerToken string `json:"twitter_bearer_token"`
 	BlogBaseURL        string `json:"blog_base_url"`
 	BlogAPIKey         string `json:"blog_api_key"`
+	RedditClientID     string `json:"reddit_client_id"`
+	RedditClientSecret string `json:"reddit_client_secret"`
 	TenantID           string `json:"tenant_id"`
+
+	// TwitterBackend selects how FetchTwitterUser reads tweets: "api"
+	// (TwitterBearerToken only), "nitter" (NitterInstances only), or
+	// "auto" (try the API, fall back to Nitter scraping on a 429/401).
+	// Defaults to "auto" so a hub configured with both still degrades
+	// gracefully.
+	TwitterBackend string `json:"twitter_backend"`
+	// NitterInstances are the Nitter mirror hosts (e.g. "nitter.net")
+	// TwitterScraperBackend round-robins across.
+	NitterInstances []string `json:"nitter_instances"`
 }
 
 // UnifiedContent represents content from any platform in a unified format
@@ -71,6 +131,7 @@ func NewSocialMediaHub(config *SocialMediaConfig, logger *zap.Logger) *SocialMed
 	if config.TwitterBearerToken != "" {
 		hub.twitter = NewTwitterIntegration(config.TwitterBearerToken, logger)
 	}
+	hub.twitterReader = selectTwitterReader(config, hub.twitter, logger)
 
 	hub.rss = NewRSSIntegration(logger)
 
@@ -78,9 +139,55 @@ func NewSocialMediaHub(config *SocialMediaConfig, logger *zap.Logger) *SocialMed
 		hub.blog = NewBlogIntegration(config.BlogBaseURL, config.BlogAPIKey, logger)
 	}
 
+	if config.RedditClientID != "" && config.RedditClientSecret != "" {
+		hub.reddit = NewRedditIntegration(config.RedditClientID, config.RedditClientSecret, logger)
+	}
+
+	// Mastodon and Bluesky need no API key - both serve public reads of
+	// a federated/open account's posts to anonymous callers - so they're
+	// always available, same as rss.
+	hub.mastodon = NewMastodonIntegration(logger)
+	hub.bluesky = NewBlueskyIntegration(logger)
+
 	return hub
 }
 
+// selectTwitterReader builds the twitterReader NewSocialMediaHub wires up,
+// per config.TwitterBackend. "api" and "nitter" use only the backend they
+// name, returning nil if it isn't configured; "auto" (and any other or
+// empty value) prefers an autoTwitterReader composing both when both are
+// configured, falling back to whichever one is.
+func selectTwitterReader(config *SocialMediaConfig, api *TwitterIntegration, logger *zap.Logger) twitterReader {
+	var scraper *TwitterScraperBackend
+	if len(config.NitterInstances) > 0 {
+		scraper = NewTwitterScraperBackend(config.NitterInstances, logger)
+	}
+
+	switch strings.ToLower(config.TwitterBackend) {
+	case "api":
+		if api == nil {
+			return nil
+		}
+		return api
+	case "nitter":
+		if scraper == nil {
+			return nil
+		}
+		return scraper
+	default:
+		switch {
+		case api != nil && scraper != nil:
+			return newAutoTwitterReader(api, scraper, logger)
+		case api != nil:
+			return api
+		case scraper != nil:
+			return scraper
+		default:
+			return nil
+		}
+	}
+}
+
 // FetchYouTubeChannel fetches recent videos from a YouTube channel
 func (h *SocialMediaHub) FetchYouTubeChannel(ctx context.Context, channelID string, maxVideos int, since *time.Time) ([]UnifiedContent, error) {
 	if h.youtube == nil {
@@ -122,16 +229,16 @@ func (h *SocialMediaHub) FetchYouTubeChannel(ctx context.Context, channelID stri
 
 // FetchTwitterUser fetches recent tweets from a Twitter user
 func (h *SocialMediaHub) FetchTwitterUser(ctx context.Context, username string, maxTweets int, since *time.Time) ([]UnifiedContent, error) {
-	if h.twitter == nil {
+	if h.twitterReader == nil {
 		return nil, fmt.Errorf("Twitter integration not configured")
 	}
 
-	user, err := h.twitter.GetUser(ctx, username)
+	user, err := h.twitterReader.GetUser(ctx, username)
 	if err != nil {
 		return nil, err
 	}
 
-	tweets, err := h.twitter.GetUserTweets(ctx, user.ID, maxTweets, since)
+	tweets, err := h.twitterReader.GetUserTweets(ctx, user.ID, maxTweets, since)
 	if err != nil {
 		return nil, err
 	}
@@ -246,23 +353,211 @@ func (h *SocialMediaHub) FetchBlogPosts(ctx context.Context, opts GetPostsOption
 	return content, nil
 }
 
-// CrossPostToPlatforms cross-posts content to multiple platforms
+// FetchSubreddit fetches recent posts from a subreddit
+func (h *SocialMediaHub) FetchSubreddit(ctx context.Context, subreddit string, maxPosts int, since *time.Time) ([]UnifiedContent, error) {
+	if h.reddit == nil {
+		return nil, fmt.Errorf("Reddit integration not configured")
+	}
+
+	posts, err := h.reddit.GetSubredditListing(ctx, subreddit, maxPosts)
+	if err != nil {
+		return nil, err
+	}
+
+	content := make([]UnifiedContent, 0, len(posts))
+	for _, post := range posts {
+		if since != nil && post.CreatedAt.Before(*since) {
+			continue
+		}
+
+		uc := UnifiedContent{
+			ID:           post.ID,
+			Platform:     "reddit",
+			ContentType:  "post",
+			Title:        post.Title,
+			Content:      post.Selftext,
+			URL:          post.Permalink,
+			ThumbnailURL: post.Thumbnail,
+			AuthorName:   post.Author,
+			PublishedAt:  post.CreatedAt,
+			Metrics: ContentMetrics{
+				LikeCount:    post.Score,
+				CommentCount: post.NumComments,
+			},
+			Categories: []string{post.Subreddit},
+			Metadata: map[string]interface{}{
+				"is_self": post.IsSelf,
+				"link":    post.URL,
+			},
+		}
+
+		content = append(content, uc)
+	}
+
+	return content, nil
+}
+
+// FetchMastodonAccount fetches recent statuses from a Mastodon account
+func (h *SocialMediaHub) FetchMastodonAccount(ctx context.Context, instanceHost, username string, maxPosts int, since *time.Time) ([]UnifiedContent, error) {
+	if h.mastodon == nil {
+		return nil, fmt.Errorf("Mastodon integration not configured")
+	}
+
+	statuses, err := h.mastodon.GetAccountStatuses(ctx, instanceHost, username, maxPosts)
+	if err != nil {
+		return nil, err
+	}
+
+	content := make([]UnifiedContent, 0, len(statuses))
+	for _, status := range statuses {
+		if since != nil && status.CreatedAt.Before(*since) {
+			continue
+		}
+
+		content = append(content, UnifiedContent{
+			ID:              status.ID,
+			Platform:        "mastodon",
+			ContentType:     "toot",
+			Title:           truncateText(status.Content, 100),
+			Content:         status.Content,
+			URL:             status.URL,
+			ThumbnailURL:    status.MediaURL,
+			AuthorID:        status.AccountID,
+			AuthorName:      status.DisplayName,
+			AuthorAvatarURL: status.AvatarURL,
+			PublishedAt:     status.CreatedAt,
+			Metrics: ContentMetrics{
+				LikeCount:    status.FavouritesCount,
+				CommentCount: status.RepliesCount,
+				ShareCount:   status.ReblogsCount,
+			},
+			Tags: status.Tags,
+			Metadata: map[string]interface{}{
+				"instance": instanceHost,
+				"username": status.Username,
+			},
+		})
+	}
+
+	return content, nil
+}
+
+// FetchBlueskyFeed fetches recent posts from a Bluesky/AT Protocol actor
+func (h *SocialMediaHub) FetchBlueskyFeed(ctx context.Context, actor string, maxPosts int, since *time.Time) ([]UnifiedContent, error) {
+	if h.bluesky == nil {
+		return nil, fmt.Errorf("Bluesky integration not configured")
+	}
+
+	posts, err := h.bluesky.GetAuthorFeed(ctx, actor, maxPosts)
+	if err != nil {
+		return nil, err
+	}
+
+	content := make([]UnifiedContent, 0, len(posts))
+	for _, post := range posts {
+		if since != nil && post.CreatedAt.Before(*since) {
+			continue
+		}
+
+		content = append(content, UnifiedContent{
+			ID:              post.URI,
+			Platform:        "bluesky",
+			ContentType:     "post",
+			Title:           truncateText(post.Text, 100),
+			Content:         post.Text,
+			URL:             fmt.Sprintf("https://bsky.app/profile/%s/post/%s", post.AuthorHandle, blueskyRecordKey(post.URI)),
+			ThumbnailURL:    post.EmbedImageURL,
+			AuthorID:        post.AuthorDID,
+			AuthorName:      post.AuthorDisplayName,
+			AuthorAvatarURL: post.AuthorAvatarURL,
+			PublishedAt:     post.CreatedAt,
+			Metrics: ContentMetrics{
+				LikeCount:    post.LikeCount,
+				CommentCount: post.ReplyCount,
+				ShareCount:   post.RepostCount,
+			},
+			Metadata: map[string]interface{}{
+				"cid": post.CID,
+			},
+		})
+	}
+
+	return content, nil
+}
+
+// blueskyRecordKey extracts the trailing record key from an at:// URI
+// (at://did:plc:.../app.bsky.feed.post/<rkey>), the path segment
+// bsky.app's web URLs use in place of the full URI.
+func blueskyRecordKey(atURI string) string {
+	idx := strings.LastIndex(atURI, "/")
+	if idx == -1 {
+		return atURI
+	}
+	return atURI[idx+1:]
+}
+
+// CrossPostRequest cross-posts content to multiple platforms on behalf
+// of TenantID's UserID, immediately or (if Schedule is set) at a later
+// time via RunDueScheduledPosts.
 type CrossPostRequest struct {
+	TenantID  string         `json:"tenant_id"`
+	UserID    string         `json:"user_id"`
 	Content   UnifiedContent `json:"content"`
-	Platforms []string       `json:"platforms"` // twitter, blog, etc.
-	Schedule  *time.Time     `json:"schedule,omitempty"`
+	Platforms []string       `json:"platforms"` // twitter, mastodon, blog
+	// MastodonInstance is required when Platforms includes "mastodon",
+	// since posting credentials are scoped per-instance.
+	MastodonInstance string     `json:"mastodon_instance,omitempty"`
+	Schedule         *time.Time `json:"schedule,omitempty"`
 }
 
 type CrossPostResult struct {
-	Platform string `json:"platform"`
-	Success  bool   `json:"success"`
-	PostID   string `json:"post_id,omitempty"`
-	URL      string `json:"url,omitempty"`
-	Error    string `json:"error,omitempty"`
+	Platform  string `json:"platform"`
+	Success   bool   `json:"success"`
+	Scheduled bool   `json:"scheduled,omitempty"`
+	PostID    string `json:"post_id,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Error     string `json:"error,omitempty"`
 }
 
-// CrossPost posts content to multiple platforms
+// CrossPost posts content to multiple platforms, or, if req.Schedule is
+// set to a future time, persists req via the configured
+// ScheduledPostStore for RunDueScheduledPosts to post later.
 func (h *SocialMediaHub) CrossPost(ctx context.Context, req *CrossPostRequest) []CrossPostResult {
+	if req.Schedule != nil && req.Schedule.After(time.Now()) {
+		return h.scheduleCrossPost(ctx, req)
+	}
+	return h.doCrossPost(ctx, req)
+}
+
+// scheduleCrossPost persists req for later delivery, returning one
+// result per requested platform marked Scheduled rather than Success.
+func (h *SocialMediaHub) scheduleCrossPost(ctx context.Context, req *CrossPostRequest) []CrossPostResult {
+	results := make([]CrossPostResult, 0, len(req.Platforms))
+
+	if h.scheduled == nil {
+		for _, platform := range req.Platforms {
+			results = append(results, CrossPostResult{Platform: platform, Error: "scheduled posting is not configured"})
+		}
+		return results
+	}
+
+	post := &ScheduledPost{TenantID: req.TenantID, Request: *req, RunAt: *req.Schedule, Status: "pending"}
+	if err := h.scheduled.Create(ctx, post); err != nil {
+		for _, platform := range req.Platforms {
+			results = append(results, CrossPostResult{Platform: platform, Error: fmt.Sprintf("failed to schedule post: %v", err)})
+		}
+		return results
+	}
+
+	for _, platform := range req.Platforms {
+		results = append(results, CrossPostResult{Platform: platform, Scheduled: true})
+	}
+	return results
+}
+
+// doCrossPost actually publishes req's content to every requested
+// platform right now.
+func (h *SocialMediaHub) doCrossPost(ctx context.Context, req *CrossPostRequest) []CrossPostResult {
 	results := make([]CrossPostResult, 0, len(req.Platforms))
 
 	for _, platform := range req.Platforms {
@@ -270,39 +565,215 @@ func (h *SocialMediaHub) CrossPost(ctx context.Context, req *CrossPostRequest) [
 
 		switch platform {
 		case "blog":
-			if h.blog != nil {
-				// Create blog post from content
-				result.Success = true
-				result.PostID = "simulated_blog_post_id"
-				h.logger.Info("Would cross-post to blog",
-					zap.String("title", req.Content.Title),
-				)
-			} else {
+			if h.blog == nil {
 				result.Error = "Blog integration not configured"
+				break
 			}
+			created, err := h.blog.CreatePost(ctx, &BlogPost{
+				Title:   req.Content.Title,
+				Content: req.Content.Content,
+				Excerpt: req.Content.Description,
+				Tags:    req.Content.Tags,
+			})
+			if err != nil {
+				result.Error = err.Error()
+				break
+			}
+			result.Success = true
+			result.PostID = created.ID
 
 		case "twitter":
-			// Twitter requires OAuth for posting, which requires user authorization
-			result.Error = "Twitter posting requires OAuth authorization"
+			result = h.crossPostTwitter(ctx, req)
+
+		case "mastodon":
+			result = h.crossPostMastodon(ctx, req)
 
 		default:
 			result.Error = fmt.Sprintf("Unknown platform: %s", platform)
 		}
 
+		result.Platform = platform
 		results = append(results, result)
 	}
 
 	return results
 }
 
+// crossPostTwitter resolves req's user's Twitter credentials and
+// publishes req.Content as a thread, since UnifiedContent.Content often
+// runs well past a single tweet's length.
+func (h *SocialMediaHub) crossPostTwitter(ctx context.Context, req *CrossPostRequest) CrossPostResult {
+	if h.twitter == nil || h.oauth == nil {
+		return CrossPostResult{Error: "Twitter posting is not configured"}
+	}
+
+	token, err := h.userToken(ctx, "twitter", req.TenantID, req.UserID, "")
+	if err != nil {
+		return CrossPostResult{Error: err.Error()}
+	}
+
+	text := req.Content.Title
+	if req.Content.Content != "" {
+		text = req.Content.Title + "\n\n" + req.Content.Content
+	}
+
+	thread, err := h.twitter.PostThread(ctx, token.AccessToken, text, nil)
+	if err != nil {
+		return CrossPostResult{Error: err.Error()}
+	}
+	if len(thread) == 0 {
+		return CrossPostResult{Error: "no tweets were posted"}
+	}
+
+	first := thread[0]
+	return CrossPostResult{
+		Success: true,
+		PostID:  first.ID,
+		URL:     fmt.Sprintf("https://twitter.com/i/web/status/%s", first.ID),
+	}
+}
+
+// crossPostMastodon resolves req's user's Mastodon credentials for
+// req.MastodonInstance and posts req.Content as a status.
+func (h *SocialMediaHub) crossPostMastodon(ctx context.Context, req *CrossPostRequest) CrossPostResult {
+	if h.mastodon == nil || h.oauth == nil {
+		return CrossPostResult{Error: "Mastodon posting is not configured"}
+	}
+	if req.MastodonInstance == "" {
+		return CrossPostResult{Error: "mastodon_instance is required to cross-post to mastodon"}
+	}
+
+	token, err := h.userToken(ctx, "mastodon", req.TenantID, req.UserID, req.MastodonInstance)
+	if err != nil {
+		return CrossPostResult{Error: err.Error()}
+	}
+
+	text := req.Content.Title
+	if req.Content.URL != "" {
+		text = text + "\n\n" + req.Content.URL
+	}
+
+	status, err := h.mastodon.CreateStatus(ctx, req.MastodonInstance, token.AccessToken, text, nil)
+	if err != nil {
+		return CrossPostResult{Error: err.Error()}
+	}
+
+	return CrossPostResult{Success: true, PostID: status.ID, URL: status.URL}
+}
+
+// userToken fetches tenantID/userID's stored platform credential,
+// refreshing it first if it's a Twitter token past its ExpiresAt.
+func (h *SocialMediaHub) userToken(ctx context.Context, platform, tenantID, userID, instanceHost string) (*OAuthToken, error) {
+	store := h.oauth.store
+	if store == nil {
+		return nil, fmt.Errorf("%s posting has no token store configured", platform)
+	}
+
+	token, err := store.Get(ctx, tenantID, platform, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s credentials: %w", platform, err)
+	}
+	if token == nil {
+		return nil, fmt.Errorf("%s is not authorized for this user", platform)
+	}
+	if platform == "mastodon" && token.InstanceHost != instanceHost {
+		return nil, fmt.Errorf("user is authorized on a different mastodon instance")
+	}
+
+	if platform == "twitter" && !token.ExpiresAt.IsZero() && time.Now().After(token.ExpiresAt) {
+		token, err = h.oauth.RefreshTwitterToken(ctx, token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to refresh twitter credentials: %w", err)
+		}
+	}
+
+	return token, nil
+}
+
+// ScheduledPost is a CrossPostRequest persisted for delivery at RunAt.
+type ScheduledPost struct {
+	ID        string           `db:"id" json:"id"`
+	TenantID  string           `db:"tenant_id" json:"tenant_id"`
+	Request   CrossPostRequest `db:"request" json:"request"`
+	RunAt     time.Time        `db:"run_at" json:"run_at"`
+	Status    string           `db:"status" json:"status"` // pending, posted, failed
+	CreatedAt time.Time        `db:"created_at" json:"created_at"`
+}
+
+// ScheduledPostStore persists scheduled CrossPostRequests. Implemented
+// by internal/database.ScheduledPostRepository against the
+// scheduled_posts table.
+type ScheduledPostStore interface {
+	Create(ctx context.Context, post *ScheduledPost) error
+	ListDue(ctx context.Context, before time.Time) ([]ScheduledPost, error)
+	MarkPosted(ctx context.Context, id string, results []CrossPostResult) error
+	MarkFailed(ctx context.Context, id string, errMsg string) error
+}
+
+// RunDueScheduledPosts posts every scheduled request whose RunAt has
+// passed, returning how many were processed. Intended to be called
+// periodically by a background process, mirroring
+// YouTubeIntegration.RenewExpiring's polling convention.
+func (h *SocialMediaHub) RunDueScheduledPosts(ctx context.Context) (int, error) {
+	if h.scheduled == nil {
+		return 0, nil
+	}
+
+	due, err := h.scheduled.ListDue(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to list due scheduled posts: %w", err)
+	}
+
+	for _, post := range due {
+		results := h.doCrossPost(ctx, &post.Request)
+
+		failed := false
+		for _, result := range results {
+			if !result.Success {
+				failed = true
+				break
+			}
+		}
+
+		if failed {
+			h.logger.Warn("scheduled cross-post had at least one failing platform", zap.String("scheduled_post_id", post.ID))
+			if err := h.scheduled.MarkFailed(ctx, post.ID, firstError(results)); err != nil {
+				h.logger.Warn("failed to record failed scheduled post", zap.String("scheduled_post_id", post.ID), zap.Error(err))
+			}
+			continue
+		}
+
+		if err := h.scheduled.MarkPosted(ctx, post.ID, results); err != nil {
+			h.logger.Warn("failed to record posted scheduled post", zap.String("scheduled_post_id", post.ID), zap.Error(err))
+		}
+	}
+
+	return len(due), nil
+}
+
+// firstError returns the first non-empty Error message in results, for
+// a scheduled post's failure record.
+func firstError(results []CrossPostResult) string {
+	for _, result := range results {
+		if result.Error != "" {
+			return result.Error
+		}
+	}
+	return "unknown error"
+}
+
 // SyncCreator syncs a creator's content from all connected platforms
 type CreatorSync struct {
-	CreatorID       string    `json:"creator_id"`
-	YouTubeChannel  string    `json:"youtube_channel"`
-	TwitterHandle   string    `json:"twitter_handle"`
-	RSSFeeds        []string  `json:"rss_feeds"`
-	SyncSince       time.Time `json:"sync_since"`
-	MaxItemsPerFeed int       `json:"max_items_per_feed"`
+	CreatorID        string    `json:"creator_id"`
+	YouTubeChannel   string    `json:"youtube_channel"`
+	TwitterHandle    string    `json:"twitter_handle"`
+	RSSFeeds         []string  `json:"rss_feeds"`
+	Subreddits       []string  `json:"subreddits"`
+	MastodonInstance string    `json:"mastodon_instance"`
+	MastodonUsername string    `json:"mastodon_username"`
+	BlueskyActor     string    `json:"bluesky_actor"`
+	SyncSince        time.Time `json:"sync_since"`
+	MaxItemsPerFeed  int       `json:"max_items_per_feed"`
 }
 
 // SyncCreatorContent syncs all content from a creator
@@ -353,21 +824,69 @@ func (h *SocialMediaHub) SyncCreatorContent(ctx context.Context, sync *CreatorSy
 		}
 	}
 
+	// Sync subreddits
+	for _, subreddit := range sync.Subreddits {
+		content, err := h.FetchSubreddit(ctx, subreddit, maxItems, &since)
+		if err != nil {
+			h.logger.Warn("Failed to sync subreddit",
+				zap.String("subreddit", subreddit),
+				zap.Error(err),
+			)
+		} else {
+			allContent = append(allContent, content...)
+		}
+	}
+
+	// Sync Mastodon
+	if sync.MastodonInstance != "" && sync.MastodonUsername != "" && h.mastodon != nil {
+		content, err := h.FetchMastodonAccount(ctx, sync.MastodonInstance, sync.MastodonUsername, maxItems, &since)
+		if err != nil {
+			h.logger.Warn("Failed to sync Mastodon content",
+				zap.String("instance", sync.MastodonInstance),
+				zap.String("username", sync.MastodonUsername),
+				zap.Error(err),
+			)
+		} else {
+			allContent = append(allContent, content...)
+		}
+	}
+
+	// Sync Bluesky
+	if sync.BlueskyActor != "" && h.bluesky != nil {
+		content, err := h.FetchBlueskyFeed(ctx, sync.BlueskyActor, maxItems, &since)
+		if err != nil {
+			h.logger.Warn("Failed to sync Bluesky content",
+				zap.String("actor", sync.BlueskyActor),
+				zap.Error(err),
+			)
+		} else {
+			allContent = append(allContent, content...)
+		}
+	}
+
 	return allContent, nil
 }
 
 // ContentAggregator aggregates content from multiple sources
 type ContentAggregator struct {
-	hub      *SocialMediaHub
-	sources  []ContentSource
-	callback func([]UnifiedContent) error
-	interval time.Duration
-	logger   *zap.Logger
+	hub       *SocialMediaHub
+	sources   []ContentSource
+	callback  func([]UnifiedContent) error
+	interval  time.Duration
+	logger    *zap.Logger
+	syncState SyncStateStore
+
+	itemsFetched *metrics.Counter
+	itemsEmitted *metrics.Counter
 }
 
 // ContentSource defines a source for content aggregation
 type ContentSource struct {
-	Type       string `json:"type"` // youtube, twitter, rss, blog
+	Type string `json:"type"` // youtube, twitter, rss, blog, reddit, mastodon, bluesky
+
+	// Identifier is the source's handle: a subreddit name for reddit, a
+	// Bluesky actor (handle or DID) for bluesky, or "instance.host/username"
+	// for mastodon. All other types take a single plain identifier.
 	Identifier string `json:"identifier"`
 	Enabled    bool   `json:"enabled"`
 }
@@ -375,14 +894,40 @@ type ContentSource struct {
 // NewContentAggregator creates a new content aggregator
 func NewContentAggregator(hub *SocialMediaHub, sources []ContentSource, callback func([]UnifiedContent) error) *ContentAggregator {
 	return &ContentAggregator{
-		hub:      hub,
-		sources:  sources,
-		callback: callback,
-		interval: 15 * time.Minute,
-		logger:   hub.logger,
+		hub:          hub,
+		sources:      sources,
+		callback:     callback,
+		interval:     15 * time.Minute,
+		logger:       hub.logger,
+		itemsFetched: metrics.RegisterCounter("content_aggregator_items_fetched_total", nil),
+		itemsEmitted: metrics.RegisterCounter("content_aggregator_items_emitted_total", nil),
 	}
 }
 
+// WithSyncStateStore enables incremental sync: aggregate fetches only
+// what's new since each source's last successful run and filters out
+// content it has already emitted, instead of re-fetching a fixed window
+// and re-emitting everything in it every run.
+func (a *ContentAggregator) WithSyncStateStore(store SyncStateStore) *ContentAggregator {
+	a.syncState = store
+	return a
+}
+
+// ResetSource clears source's watermark and seen-ID history, so the
+// next run re-fetches and re-emits everything it finds for it. Intended
+// for admin use after a source's history needs to be backfilled again.
+func (a *ContentAggregator) ResetSource(ctx context.Context, source ContentSource) error {
+	if a.syncState == nil {
+		return fmt.Errorf("content aggregator has no sync state store configured")
+	}
+	return a.syncState.ResetSource(ctx, sourceKey(source))
+}
+
+// sourceKey identifies source within the sync state store.
+func sourceKey(source ContentSource) string {
+	return source.Type + ":" + source.Identifier
+}
+
 // Start begins the aggregation loop
 func (a *ContentAggregator) Start(ctx context.Context) {
 	ticker := time.NewTicker(a.interval)
@@ -403,13 +948,24 @@ func (a *ContentAggregator) Start(ctx context.Context) {
 
 func (a *ContentAggregator) aggregate(ctx context.Context) {
 	var allContent []UnifiedContent
-	since := time.Now().Add(-24 * time.Hour)
+	watermarks := make(map[string]SyncWatermark)
 
 	for _, source := range a.sources {
 		if !source.Enabled {
 			continue
 		}
 
+		key := sourceKey(source)
+		since := time.Now().Add(-24 * time.Hour)
+		if a.syncState != nil {
+			wm, err := a.syncState.GetWatermark(ctx, key)
+			if err != nil {
+				a.logger.Error("Failed to load sync watermark", zap.String("source", key), zap.Error(err))
+			} else if !wm.LastSeenPublishedAt.IsZero() {
+				since = wm.LastSeenPublishedAt
+			}
+		}
+
 		var content []UnifiedContent
 		var err error
 
@@ -420,6 +976,17 @@ func (a *ContentAggregator) aggregate(ctx context.Context) {
 			content, err = a.hub.FetchTwitterUser(ctx, source.Identifier, 20, &since)
 		case "rss":
 			content, err = a.hub.FetchRSSFeed(ctx, source.Identifier, &since)
+		case "reddit":
+			content, err = a.hub.FetchSubreddit(ctx, source.Identifier, 20, &since)
+		case "mastodon":
+			instance, username, splitErr := splitMastodonIdentifier(source.Identifier)
+			if splitErr != nil {
+				err = splitErr
+				break
+			}
+			content, err = a.hub.FetchMastodonAccount(ctx, instance, username, 20, &since)
+		case "bluesky":
+			content, err = a.hub.FetchBlueskyFeed(ctx, source.Identifier, 20, &since)
 		}
 
 		if err != nil {
@@ -430,13 +997,43 @@ func (a *ContentAggregator) aggregate(ctx context.Context) {
 			)
 			continue
 		}
+		a.itemsFetched.Add(int64(len(content)))
+
+		wm := SyncWatermark{}
+		for _, item := range content {
+			if a.syncState != nil {
+				seen, seenErr := a.syncState.MarkSeen(ctx, key, item.Platform+":"+item.ID)
+				if seenErr != nil {
+					a.logger.Error("Failed to check seen content", zap.String("source", key), zap.Error(seenErr))
+				} else if seen {
+					continue
+				}
+			}
+
+			allContent = append(allContent, item)
+			a.itemsEmitted.Increment()
+			if item.PublishedAt.After(wm.LastSeenPublishedAt) {
+				wm.LastSeenID = item.ID
+				wm.LastSeenPublishedAt = item.PublishedAt
+			}
+		}
+		if a.syncState != nil && wm.LastSeenID != "" {
+			watermarks[key] = wm
+		}
+	}
 
-		allContent = append(allContent, content...)
+	if len(allContent) == 0 {
+		return
+	}
+
+	if err := a.callback(allContent); err != nil {
+		a.logger.Error("Failed to process aggregated content", zap.Error(err))
+		return
 	}
 
-	if len(allContent) > 0 {
-		if err := a.callback(allContent); err != nil {
-			a.logger.Error("Failed to process aggregated content", zap.Error(err))
+	for key, wm := range watermarks {
+		if err := a.syncState.SetWatermark(ctx, key, wm); err != nil {
+			a.logger.Error("Failed to save sync watermark", zap.String("source", key), zap.Error(err))
 		}
 	}
 }
@@ -449,20 +1046,51 @@ func truncateText(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+// splitMastodonIdentifier parses a ContentSource.Identifier of the form
+// "instance.host/username" for source.Type == "mastodon".
+func splitMastodonIdentifier(identifier string) (instanceHost, username string, err error) {
+	idx := strings.Index(identifier, "/")
+	if idx == -1 || idx == 0 || idx == len(identifier)-1 {
+		return "", "", fmt.Errorf("mastodon identifier must be \"instance.host/username\", got %q", identifier)
+	}
+	return identifier[:idx], identifier[idx+1:], nil
+}
+
 // WebhookHandler handles incoming webhooks from various platforms
 type WebhookHandler struct {
 	hub    *SocialMediaHub
 	logger *zap.Logger
+
+	// youtubeWebhookSecret verifies X-Hub-Signature on incoming YouTube
+	// PubSubHubbub notifications. Empty skips verification, e.g. in
+	// development.
+	youtubeWebhookSecret string
+
+	// onContent receives each UnifiedContent item HandleYouTubeWebhook
+	// extracts and enriches from a push notification. Nil until
+	// WithContentHandler is called, in which case notifications are
+	// still verified and acknowledged but otherwise dropped.
+	onContent func(context.Context, UnifiedContent)
 }
 
-// NewWebhookHandler creates a new webhook handler
-func NewWebhookHandler(hub *SocialMediaHub) *WebhookHandler {
+// NewWebhookHandler creates a new webhook handler. youtubeWebhookSecret
+// should match the secret SocialMediaHub's YouTubeIntegration requested
+// via SubscribeChannel.
+func NewWebhookHandler(hub *SocialMediaHub, youtubeWebhookSecret string) *WebhookHandler {
 	return &WebhookHandler{
-		hub:    hub,
-		logger: hub.logger,
+		hub:                  hub,
+		logger:               hub.logger,
+		youtubeWebhookSecret: youtubeWebhookSecret,
 	}
 }
 
+// WithContentHandler registers fn to receive each UnifiedContent item
+// extracted from a verified YouTube push notification.
+func (wh *WebhookHandler) WithContentHandler(fn func(context.Context, UnifiedContent)) *WebhookHandler {
+	wh.onContent = fn
+	return wh
+}
+
 // HandleBlogWebhook handles webhooks from the blog platform
 func (wh *WebhookHandler) HandleBlogWebhook(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -488,24 +1116,216 @@ func (wh *WebhookHandler) HandleBlogWebhook(w http.ResponseWriter, r *http.Reque
 	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
 }
 
-// HandleYouTubeWebhook handles PubSubHubbub notifications from YouTube
+// HandleYouTubeWebhook handles PubSubHubbub notifications from YouTube:
+// the hub's asynchronous subscribe/unsubscribe verification GET, and the
+// push notification POST carrying new/updated video entries. Each
+// notification's signature is verified, its Atom entries are enriched
+// via the hub's YouTubeIntegration, and the result is handed to
+// onContent, if registered, as a UnifiedContent.
 func (wh *WebhookHandler) HandleYouTubeWebhook(w http.ResponseWriter, r *http.Request) {
-	// Verify subscription (hub.challenge)
 	if r.Method == http.MethodGet {
 		challenge := r.URL.Query().Get("hub.challenge")
-		if challenge != "" {
-			w.Write([]byte(challenge))
+		mode := r.URL.Query().Get("hub.mode")
+
+		if mode == "subscribe" && wh.hub.youtube != nil {
+			if channelID := channelIDFromHubTopic(r.URL.Query().Get("hub.topic")); channelID != "" {
+				if leaseSeconds, err := strconv.Atoi(r.URL.Query().Get("hub.lease_seconds")); err == nil {
+					_ = wh.hub.youtube.ConfirmSubscription(r.Context(), channelID, leaseSeconds)
+				}
+			}
+		}
+
+		w.Write([]byte(challenge))
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	if wh.youtubeWebhookSecret != "" {
+		if !verifySHA1Signature(body, r.Header.Get("X-Hub-Signature"), wh.youtubeWebhookSecret) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
 			return
 		}
 	}
 
-	// Handle notification
-	if r.Method == http.MethodPost {
-		wh.logger.Info("Received YouTube webhook notification")
-		// Parse Atom feed entry and process
-		w.WriteHeader(http.StatusOK)
+	var feed youtubePushFeed
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		http.Error(w, "failed to parse Atom feed", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	for _, entry := range feed.Entries {
+		content := UnifiedContent{
+			ID:          entry.VideoID,
+			Platform:    "youtube",
+			ContentType: "video",
+			Title:       entry.Title,
+			URL:         entry.Link.Href,
+			AuthorID:    entry.ChannelID,
+			AuthorName:  entry.Author.Name,
+			Metadata:    map[string]interface{}{"video_id": entry.VideoID, "channel_id": entry.ChannelID},
+		}
+		if published, err := time.Parse(time.RFC3339, entry.Published); err == nil {
+			content.PublishedAt = published
+		}
+
+		if wh.hub.youtube != nil {
+			if video, err := wh.hub.youtube.GetVideoDetails(ctx, entry.VideoID); err == nil {
+				content.Description = video.Description
+				content.ThumbnailURL = video.ThumbnailURL
+				content.PublishedAt = video.PublishedAt
+				content.Tags = video.Tags
+				content.Metrics = ContentMetrics{
+					ViewCount:    video.ViewCount,
+					LikeCount:    video.LikeCount,
+					CommentCount: video.CommentCount,
+				}
+			} else {
+				wh.logger.Warn("failed to enrich youtube push notification",
+					zap.String("video_id", entry.VideoID), zap.Error(err))
+			}
+		}
+
+		if wh.onContent != nil {
+			wh.onContent(ctx, content)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{"status": "ok", "entries": len(feed.Entries)})
+}
+
+// youtubePushFeed is the Atom body a PubSubHubbub hub POSTs for a
+// YouTube channel's upload feed. encoding/xml matches fields by local
+// name regardless of namespace, so the yt: prefix on videoId/channelId
+// needs no explicit namespace tag.
+type youtubePushFeed struct {
+	Entries []youtubePushEntry `xml:"entry"`
+}
+
+type youtubePushEntry struct {
+	VideoID   string            `xml:"videoId"`
+	ChannelID string            `xml:"channelId"`
+	Title     string            `xml:"title"`
+	Published string            `xml:"published"`
+	Link      youtubePushLink   `xml:"link"`
+	Author    youtubePushAuthor `xml:"author"`
+}
+
+type youtubePushLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type youtubePushAuthor struct {
+	Name string `xml:"name"`
+}
+
+// verifySHA1Signature verifies the HMAC-SHA1 signature PubSubHubbub hubs
+// send in X-Hub-Signature.
+func verifySHA1Signature(body []byte, signature, secret string) bool {
+	if signature == "" {
+		return false
+	}
+
+	signature = strings.TrimPrefix(signature, "sha1=")
+
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(signature), []byte(expected))
+}
+
+// channelIDFromHubTopic extracts the channel_id query parameter from a
+// PubSubHubbub hub.topic URL.
+func channelIDFromHubTopic(topic string) string {
+	parsed, err := url.Parse(topic)
+	if err != nil {
+		return ""
+	}
+	return parsed.Query().Get("channel_id")
+}
+
+// HandleOAuthStart begins a posting-authorization handshake for the
+// platform named in path "/oauth/{platform}/start", redirecting the
+// caller to that platform's consent screen. tenant_id and user_id are
+// required query parameters; instance_host is required when platform is
+// "mastodon".
+func (wh *WebhookHandler) HandleOAuthStart(w http.ResponseWriter, r *http.Request) {
+	if wh.hub.oauth == nil {
+		http.Error(w, "oauth is not configured", http.StatusNotImplemented)
+		return
+	}
+
+	platform, ok := oauthPlatformFromPath(r.URL.Path, "start")
+	if !ok {
+		http.Error(w, "expected path /oauth/{platform}/start", http.StatusBadRequest)
+		return
+	}
+
+	tenantID := r.URL.Query().Get("tenant_id")
+	userID := r.URL.Query().Get("user_id")
+	if tenantID == "" || userID == "" {
+		http.Error(w, "tenant_id and user_id are required", http.StatusBadRequest)
+		return
+	}
+
+	redirectURL, err := wh.hub.oauth.StartAuth(platform, tenantID, userID, r.URL.Query().Get("instance_host"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, redirectURL, http.StatusFound)
+}
+
+// HandleOAuthCallback completes the handshake a platform redirects back
+// to "/oauth/{platform}/callback" with its state/code query parameters,
+// persisting the resulting credential.
+func (wh *WebhookHandler) HandleOAuthCallback(w http.ResponseWriter, r *http.Request) {
+	if wh.hub.oauth == nil {
+		http.Error(w, "oauth is not configured", http.StatusNotImplemented)
 		return
 	}
 
-	http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	if errParam := r.URL.Query().Get("error"); errParam != "" {
+		http.Error(w, "authorization denied: "+errParam, http.StatusBadRequest)
+		return
+	}
+
+	state := r.URL.Query().Get("state")
+	code := r.URL.Query().Get("code")
+	if state == "" || code == "" {
+		http.Error(w, "state and code are required", http.StatusBadRequest)
+		return
+	}
+
+	token, err := wh.hub.oauth.HandleCallback(r.Context(), state, code)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "authorized", "platform": token.Platform})
+}
+
+// oauthPlatformFromPath extracts {platform} from a "/oauth/{platform}/{suffix}"
+// path.
+func oauthPlatformFromPath(path, suffix string) (string, bool) {
+	trimmed := strings.TrimSuffix(strings.TrimPrefix(path, "/oauth/"), "/"+suffix)
+	if trimmed == path || trimmed == "" {
+		return "", false
+	}
+	return trimmed, true
 }
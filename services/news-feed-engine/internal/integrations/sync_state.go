@@ -0,0 +1,42 @@
+package integrations
+
+import (
+	"context"
+	"time"
+)
+
+// SyncWatermark is a ContentSource's incremental-sync progress:
+// the point ContentAggregator.aggregate resumed fetching from on its
+// last successful run.
+type SyncWatermark struct {
+	LastSeenID          string    `db:"last_seen_id" json:"last_seen_id"`
+	LastSeenPublishedAt time.Time `db:"last_seen_published_at" json:"last_seen_published_at"`
+}
+
+// SyncStateStore tracks per-source sync watermarks and recently-emitted
+// content IDs, so ContentAggregator.aggregate can fetch only what's new
+// since its last run instead of re-fetching a fixed window and
+// re-emitting content the callback already processed.
+//
+// MarkSeen is the dedup check: it's an exact membership test rather than
+// a bloom/cuckoo filter, since a false positive there would silently
+// drop a genuinely new item. Implementations bound its footprint with a
+// TTL instead.
+type SyncStateStore interface {
+	// GetWatermark returns sourceKey's watermark, or the zero value if
+	// the source has never completed a run.
+	GetWatermark(ctx context.Context, sourceKey string) (SyncWatermark, error)
+
+	// SetWatermark advances sourceKey's watermark. Callers must only
+	// call this after the batch containing wm has been fully processed.
+	SetWatermark(ctx context.Context, sourceKey string, wm SyncWatermark) error
+
+	// MarkSeen records id as emitted for sourceKey and reports whether
+	// it was already recorded, atomically, so a concurrent aggregate
+	// run can't double-emit the same item.
+	MarkSeen(ctx context.Context, sourceKey, id string) (alreadySeen bool, err error)
+
+	// ResetSource clears sourceKey's watermark and seen-ID history, so
+	// the next run re-fetches and re-emits everything it finds.
+	ResetSource(ctx context.Context, sourceKey string) error
+}
@@ -2,19 +2,117 @@ package integrations
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
 )
 
+// defaultAppTokenMaxAge is how long a minted app-only bearer is cached
+// before twitterAppTokenManager proactively re-mints it, independent of
+// whatever a 401 tells us. Twitter doesn't expire app-only tokens on a
+// fixed schedule, but minting on a cadence bounds exposure if a token is
+// ever invalidated out of band.
+const defaultAppTokenMaxAge = 15 * time.Minute
+
+// twitterAppTokenManager mints and caches an OAuth2 app-only bearer
+// token via client_credentials, refreshing it transparently once it's
+// older than maxAge or a request reports it invalid.
+type twitterAppTokenManager struct {
+	clientID     string
+	clientSecret string
+	httpClient   *http.Client
+	maxAge       time.Duration
+
+	mu       sync.RWMutex
+	token    string
+	mintedAt time.Time
+}
+
+// Token returns a cached bearer token, minting (or re-minting, if
+// forceRefresh is set or the cache is older than maxAge) one first.
+func (m *twitterAppTokenManager) Token(ctx context.Context, forceRefresh bool) (string, error) {
+	m.mu.RLock()
+	valid := m.token != "" && time.Since(m.mintedAt) < m.maxAge
+	token := m.token
+	m.mu.RUnlock()
+
+	if valid && !forceRefresh {
+		return token, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Another goroutine may have refreshed while we waited for the lock.
+	if !forceRefresh && m.token != "" && time.Since(m.mintedAt) < m.maxAge {
+		return m.token, nil
+	}
+
+	minted, err := m.mint(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	m.token = minted
+	m.mintedAt = time.Now()
+	return m.token, nil
+}
+
+// mint POSTs grant_type=client_credentials to Twitter's OAuth2 token
+// endpoint, authenticating with HTTP Basic auth of the URL-encoded
+// client ID and secret per Twitter's app-only auth flow.
+func (m *twitterAppTokenManager) mint(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.twitter.com/oauth2/token",
+		strings.NewReader("grant_type=client_credentials"))
+	if err != nil {
+		return "", err
+	}
+
+	credentials := url.QueryEscape(m.clientID) + ":" + url.QueryEscape(m.clientSecret)
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(credentials)))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded;charset=UTF-8")
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to mint app-only bearer token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to mint app-only bearer token: %s - %s", resp.Status, string(body))
+	}
+
+	var result struct {
+		TokenType   string `json:"token_type"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if result.AccessToken == "" {
+		return "", fmt.Errorf("token response missing access_token")
+	}
+
+	return result.AccessToken, nil
+}
+
 // TwitterIntegration handles Twitter API v2 interactions
 type TwitterIntegration struct {
 	bearerToken string
+	tokenMgr    *twitterAppTokenManager
 	httpClient  *http.Client
 	logger      *zap.Logger
 }
@@ -85,7 +183,8 @@ type TwitterUser struct {
 	CreatedAt       time.Time `json:"created_at"`
 }
 
-// NewTwitterIntegration creates a new Twitter integration
+// NewTwitterIntegration creates a new Twitter integration authenticated
+// with a static bearer token.
 func NewTwitterIntegration(bearerToken string, logger *zap.Logger) *TwitterIntegration {
 	return &TwitterIntegration{
 		bearerToken: bearerToken,
@@ -96,6 +195,25 @@ func NewTwitterIntegration(bearerToken string, logger *zap.Logger) *TwitterInteg
 	}
 }
 
+// NewTwitterIntegrationWithAppAuth creates a Twitter integration that
+// mints its own app-only bearer token via OAuth2 client_credentials
+// instead of relying on one provisioned and rotated out of band. The
+// token is cached for defaultAppTokenMaxAge and transparently re-minted
+// on expiry or a 401 from the API.
+func NewTwitterIntegrationWithAppAuth(clientID, clientSecret string, logger *zap.Logger) *TwitterIntegration {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	return &TwitterIntegration{
+		tokenMgr: &twitterAppTokenManager{
+			clientID:     clientID,
+			clientSecret: clientSecret,
+			httpClient:   httpClient,
+			maxAge:       defaultAppTokenMaxAge,
+		},
+		httpClient: httpClient,
+		logger:     logger,
+	}
+}
+
 // GetUser retrieves user information by username
 func (t *TwitterIntegration) GetUser(ctx context.Context, username string) (*TwitterUser, error) {
 	params := url.Values{
@@ -454,31 +572,289 @@ func (t *TwitterIntegration) GetTweet(ctx context.Context, tweetID string) (*Twe
 	return tweet, nil
 }
 
-// makeRequest makes an HTTP request to Twitter API v2
+// makeRequest makes an HTTP request to Twitter API v2. When the
+// integration was constructed with NewTwitterIntegrationWithAppAuth, a
+// 401 triggers one forced token refresh and retry, since that's the
+// only signal we get that a cached app-only token was invalidated out
+// of band before maxAge.
 func (t *TwitterIntegration) makeRequest(ctx context.Context, endpoint string, params url.Values) ([]byte, error) {
+	body, status, err := t.doRequest(ctx, endpoint, params, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == http.StatusUnauthorized && t.tokenMgr != nil {
+		body, status, err = t.doRequest(ctx, endpoint, params, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("Twitter API error: %d - %s", status, string(body))
+	}
+
+	return body, nil
+}
+
+// doRequest issues a single attempt of the request, returning the raw
+// body and status code so makeRequest can decide whether a 401 deserves
+// a forced-refresh retry.
+func (t *TwitterIntegration) doRequest(ctx context.Context, endpoint string, params url.Values, forceRefresh bool) ([]byte, int, error) {
+	authHeader, err := t.authHeader(ctx, forceRefresh)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get Twitter auth token: %w", err)
+	}
+
 	apiURL := fmt.Sprintf("https://api.twitter.com/2/%s?%s", endpoint, params.Encode())
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.bearerToken))
+	req.Header.Set("Authorization", authHeader)
 
 	resp, err := t.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return body, resp.StatusCode, nil
+}
+
+// maxTweetLength is the number of characters (not bytes/runes with
+// Twitter's weighted counting, which this simplified split doesn't
+// model) PostThread packs into each tweet of a thread.
+const maxTweetLength = 280
+
+// PostTweet publishes a single tweet as accessToken's user via the v2
+// tweets endpoint, optionally as a reply to replyToID (empty for a
+// top-level tweet), with mediaIDs (from UploadMedia) attached.
+func (t *TwitterIntegration) PostTweet(ctx context.Context, accessToken, text, replyToID string, mediaIDs []string) (*Tweet, error) {
+	payload := map[string]interface{}{"text": text}
+	if replyToID != "" {
+		payload["reply"] = map[string]string{"in_reply_to_tweet_id": replyToID}
+	}
+	if len(mediaIDs) > 0 {
+		payload["media"] = map[string][]string{"media_ids": mediaIDs}
+	}
+
+	body, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Twitter API error: %s - %s", resp.Status, string(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.twitter.com/2/tweets", strings.NewReader(string(body)))
+	if err != nil {
+		return nil, err
 	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
 
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to post tweet: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to post tweet: %s - %s", resp.Status, string(respBody))
+	}
+
+	var result struct {
+		Data struct {
+			ID   string `json:"id"`
+			Text string `json:"text"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse post tweet response: %w", err)
+	}
+
+	return &Tweet{ID: result.Data.ID, Text: result.Data.Text, CreatedAt: time.Now()}, nil
+}
+
+// PostThread publishes text as a thread, splitting it at word
+// boundaries into tweets of at most maxTweetLength characters and
+// chaining each as a reply to the one before it, attaching mediaIDs (if
+// any) to the first tweet only. It returns every tweet posted, in
+// order; if a reply in the middle of the thread fails, it returns the
+// tweets posted so far alongside the error so the caller isn't left
+// with no record of the partial thread.
+func (t *TwitterIntegration) PostThread(ctx context.Context, accessToken, text string, mediaIDs []string) ([]Tweet, error) {
+	parts := splitTweetThread(text, maxTweetLength)
+
+	posted := make([]Tweet, 0, len(parts))
+	var replyToID string
+	for i, part := range parts {
+		var media []string
+		if i == 0 {
+			media = mediaIDs
+		}
+
+		tweet, err := t.PostTweet(ctx, accessToken, part, replyToID, media)
+		if err != nil {
+			return posted, fmt.Errorf("failed to post thread part %d/%d: %w", i+1, len(parts), err)
+		}
+		posted = append(posted, *tweet)
+		replyToID = tweet.ID
+	}
+
+	return posted, nil
+}
+
+// splitTweetThread breaks text into chunks of at most maxLen characters,
+// preferring to break on a space so words aren't split mid-word.
+func splitTweetThread(text string, maxLen int) []string {
+	text = strings.TrimSpace(text)
+	if len([]rune(text)) <= maxLen {
+		return []string{text}
+	}
+
+	var parts []string
+	runes := []rune(text)
+	for len(runes) > 0 {
+		if len(runes) <= maxLen {
+			parts = append(parts, strings.TrimSpace(string(runes)))
+			break
+		}
+
+		cut := maxLen
+		for cut > 0 && runes[cut] != ' ' {
+			cut--
+		}
+		if cut == 0 {
+			cut = maxLen
+		}
+
+		parts = append(parts, strings.TrimSpace(string(runes[:cut])))
+		runes = runes[cut:]
+	}
+
+	return parts
+}
+
+// UploadMedia uploads data (image or short video, up to v1.1's chunked
+// upload limits) via the three-phase INIT/APPEND/FINALIZE flow Twitter's
+// v1.1 media endpoint still requires for v2 tweet attachments, returning
+// the resulting media_id to pass into PostTweet/PostThread.
+func (t *TwitterIntegration) UploadMedia(ctx context.Context, accessToken string, data []byte, mimeType string) (string, error) {
+	mediaID, err := t.mediaInit(ctx, accessToken, len(data), mimeType)
+	if err != nil {
+		return "", err
+	}
+
+	const chunkSize = 4 * 1024 * 1024
+	for i, segment := 0, 0; i < len(data); i += chunkSize {
+		end := i + chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		if err := t.mediaAppend(ctx, accessToken, mediaID, segment, data[i:end]); err != nil {
+			return "", err
+		}
+		segment++
+	}
+
+	if err := t.mediaFinalize(ctx, accessToken, mediaID); err != nil {
+		return "", err
+	}
+
+	return mediaID, nil
+}
+
+func (t *TwitterIntegration) mediaInit(ctx context.Context, accessToken string, totalBytes int, mimeType string) (string, error) {
+	form := url.Values{
+		"command":     {"INIT"},
+		"total_bytes": {fmt.Sprintf("%d", totalBytes)},
+		"media_type":  {mimeType},
+	}
+	resp, err := t.mediaRequest(ctx, accessToken, form)
+	if err != nil {
+		return "", fmt.Errorf("media upload INIT failed: %w", err)
+	}
+
+	var result struct {
+		MediaIDString string `json:"media_id_string"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return "", fmt.Errorf("failed to parse media INIT response: %w", err)
+	}
+	return result.MediaIDString, nil
+}
+
+func (t *TwitterIntegration) mediaAppend(ctx context.Context, accessToken, mediaID string, segmentIndex int, chunk []byte) error {
+	form := url.Values{
+		"command":       {"APPEND"},
+		"media_id":      {mediaID},
+		"segment_index": {fmt.Sprintf("%d", segmentIndex)},
+		"media_data":    {base64.StdEncoding.EncodeToString(chunk)},
+	}
+	if _, err := t.mediaRequest(ctx, accessToken, form); err != nil {
+		return fmt.Errorf("media upload APPEND (segment %d) failed: %w", segmentIndex, err)
+	}
+	return nil
+}
+
+func (t *TwitterIntegration) mediaFinalize(ctx context.Context, accessToken, mediaID string) error {
+	form := url.Values{
+		"command":  {"FINALIZE"},
+		"media_id": {mediaID},
+	}
+	if _, err := t.mediaRequest(ctx, accessToken, form); err != nil {
+		return fmt.Errorf("media upload FINALIZE failed: %w", err)
+	}
+	return nil
+}
+
+// mediaRequest POSTs form to the v1.1 chunked media upload endpoint,
+// authenticated as accessToken's user (the v1.1 media endpoint accepts
+// the same OAuth2 user-context bearer the v2 API uses).
+func (t *TwitterIntegration) mediaRequest(ctx context.Context, accessToken string, form url.Values) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://upload.twitter.com/1.1/media/upload.json", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("%s - %s", resp.Status, string(body))
+	}
 	return body, nil
 }
+
+// authHeader returns the Authorization header value for a request,
+// minting/refreshing an app-only token via tokenMgr if one is
+// configured, or falling back to the static bearerToken otherwise.
+func (t *TwitterIntegration) authHeader(ctx context.Context, forceRefresh bool) (string, error) {
+	if t.tokenMgr != nil {
+		token, err := t.tokenMgr.Token(ctx, forceRefresh)
+		if err != nil {
+			return "", err
+		}
+		return "Bearer " + token, nil
+	}
+	return "Bearer " + t.bearerToken, nil
+}
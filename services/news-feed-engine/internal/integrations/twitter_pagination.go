@@ -0,0 +1,253 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// IterUserTweetsOptions configures IterUserTweets. MaxResults bounds the
+// per-page size (Twitter caps at 100); the iterator still pages through
+// every result past that via pagination_token unless WithAuto(false) is
+// set.
+type IterUserTweetsOptions struct {
+	MaxResults int
+	StartTime  *time.Time
+	EndTime    *time.Time
+	UntilID    string
+}
+
+// IterUserTweets returns a TweetIterator over userID's tweets, auto-
+// paging through meta.next_token until exhausted or the iterator's
+// options say otherwise.
+func (t *TwitterIntegration) IterUserTweets(ctx context.Context, userID string, opts IterUserTweetsOptions) *TweetIterator {
+	return &TweetIterator{
+		t:      t,
+		ctx:    ctx,
+		userID: userID,
+		opts:   opts,
+		auto:   true,
+	}
+}
+
+// TweetIterator walks a paginated Twitter v2 tweet listing one page at a
+// time, fetching lazily as callers advance past the buffered page via
+// Next/Tweet, mirroring the iterator ergonomics of established Go
+// Twitter v2 clients.
+type TweetIterator struct {
+	t      *TwitterIntegration
+	ctx    context.Context
+	userID string
+	opts   IterUserTweetsOptions
+
+	auto bool
+	rate time.Duration
+
+	started   bool
+	lastFetch time.Time
+	nextToken string
+
+	buffer  []Tweet
+	pos     int
+	current Tweet
+	err     error
+}
+
+// WithAuto disables (false) or re-enables (true) automatic paging past
+// the first page. Disabled, Next returns false once the buffered page
+// is exhausted even if more pages are available.
+func (it *TweetIterator) WithAuto(auto bool) *TweetIterator {
+	it.auto = auto
+	return it
+}
+
+// WithRate throttles page fetches to at most one per d, so a long-running
+// iteration doesn't trip Twitter's 15-minute rate window.
+func (it *TweetIterator) WithRate(d time.Duration) *TweetIterator {
+	it.rate = d
+	return it
+}
+
+// Next advances the iterator, fetching another page if the buffered one
+// is exhausted and auto-paging is enabled. It returns false at the end
+// of the listing or on error; check Err to distinguish the two.
+func (it *TweetIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.pos >= len(it.buffer) {
+		if it.started && (!it.auto || it.nextToken == "") {
+			return false
+		}
+		if err := it.fetchPage(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+
+	it.current = it.buffer[it.pos]
+	it.pos++
+	return true
+}
+
+// Tweet returns the tweet Next just advanced to.
+func (it *TweetIterator) Tweet() Tweet { return it.current }
+
+// Err returns the error that stopped iteration, if any.
+func (it *TweetIterator) Err() error { return it.err }
+
+// Collect drains the iterator into a slice, stopping early once max
+// tweets have been collected (max <= 0 means no limit).
+func (it *TweetIterator) Collect(max int) ([]Tweet, error) {
+	var out []Tweet
+	for it.Next() {
+		out = append(out, it.Tweet())
+		if max > 0 && len(out) >= max {
+			break
+		}
+	}
+	return out, it.Err()
+}
+
+func (it *TweetIterator) fetchPage() error {
+	if it.started && it.rate > 0 {
+		if wait := it.rate - time.Since(it.lastFetch); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-it.ctx.Done():
+				timer.Stop()
+				return it.ctx.Err()
+			}
+			timer.Stop()
+		}
+	}
+
+	page, next, err := it.t.fetchUserTweetsPage(it.ctx, it.userID, it.opts, it.nextToken)
+	it.started = true
+	it.lastFetch = time.Now()
+	if err != nil {
+		return err
+	}
+
+	it.buffer = page
+	it.pos = 0
+	it.nextToken = next
+	return nil
+}
+
+// fetchUserTweetsPage fetches one page of userID's tweets, returning the
+// page and the pagination_token for the next one (empty if there isn't
+// one). It's the pagination-aware sibling of GetUserTweets, which only
+// ever fetches the first page.
+func (t *TwitterIntegration) fetchUserTweetsPage(ctx context.Context, userID string, opts IterUserTweetsOptions, paginationToken string) ([]Tweet, string, error) {
+	maxResults := opts.MaxResults
+	if maxResults <= 0 {
+		maxResults = 100
+	}
+
+	params := url.Values{
+		"tweet.fields": {"id,text,author_id,created_at,conversation_id,public_metrics,entities,referenced_tweets"},
+		"user.fields":  {"id,name,username"},
+		"expansions":   {"author_id"},
+		"max_results":  {fmt.Sprintf("%d", maxResults)},
+	}
+	if opts.StartTime != nil {
+		params.Set("start_time", opts.StartTime.Format(time.RFC3339))
+	}
+	if opts.EndTime != nil {
+		params.Set("end_time", opts.EndTime.Format(time.RFC3339))
+	}
+	if opts.UntilID != "" {
+		params.Set("until_id", opts.UntilID)
+	}
+	if paginationToken != "" {
+		params.Set("pagination_token", paginationToken)
+	}
+
+	endpoint := fmt.Sprintf("users/%s/tweets", userID)
+	resp, err := t.makeRequest(ctx, endpoint, params)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get user tweets: %w", err)
+	}
+
+	var result struct {
+		Data []struct {
+			ID             string `json:"id"`
+			Text           string `json:"text"`
+			AuthorID       string `json:"author_id"`
+			CreatedAt      string `json:"created_at"`
+			ConversationID string `json:"conversation_id"`
+			PublicMetrics  struct {
+				RetweetCount    int `json:"retweet_count"`
+				ReplyCount      int `json:"reply_count"`
+				LikeCount       int `json:"like_count"`
+				QuoteCount      int `json:"quote_count"`
+				BookmarkCount   int `json:"bookmark_count"`
+				ImpressionCount int `json:"impression_count"`
+			} `json:"public_metrics"`
+			Entities         *TweetEntities    `json:"entities,omitempty"`
+			ReferencedTweets []ReferencedTweet `json:"referenced_tweets,omitempty"`
+		} `json:"data"`
+		Includes struct {
+			Users []struct {
+				ID       string `json:"id"`
+				Name     string `json:"name"`
+				Username string `json:"username"`
+			} `json:"users"`
+		} `json:"includes"`
+		Meta struct {
+			NextToken string `json:"next_token"`
+		} `json:"meta"`
+	}
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, "", fmt.Errorf("failed to parse tweets response: %w", err)
+	}
+
+	userMap := make(map[string]struct {
+		Name     string
+		Username string
+	})
+	for _, user := range result.Includes.Users {
+		userMap[user.ID] = struct {
+			Name     string
+			Username string
+		}{Name: user.Name, Username: user.Username}
+	}
+
+	tweets := make([]Tweet, 0, len(result.Data))
+	for _, item := range result.Data {
+		createdAt, _ := time.Parse(time.RFC3339, item.CreatedAt)
+
+		tweet := Tweet{
+			ID:             item.ID,
+			Text:           item.Text,
+			AuthorID:       item.AuthorID,
+			CreatedAt:      createdAt,
+			ConversationID: item.ConversationID,
+			PublicMetrics: TweetMetrics{
+				RetweetCount:    item.PublicMetrics.RetweetCount,
+				ReplyCount:      item.PublicMetrics.ReplyCount,
+				LikeCount:       item.PublicMetrics.LikeCount,
+				QuoteCount:      item.PublicMetrics.QuoteCount,
+				BookmarkCount:   item.PublicMetrics.BookmarkCount,
+				ImpressionCount: item.PublicMetrics.ImpressionCount,
+			},
+			Entities:         item.Entities,
+			ReferencedTweets: item.ReferencedTweets,
+		}
+
+		if user, ok := userMap[item.AuthorID]; ok {
+			tweet.AuthorName = user.Name
+			tweet.AuthorUsername = user.Username
+		}
+
+		tweets = append(tweets, tweet)
+	}
+
+	return tweets, result.Meta.NextToken, nil
+}
@@ -0,0 +1,453 @@
+package integrations
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/resilience"
+)
+
+// Circuit breaker defaults for TwitterScraperBackend's instance pool,
+// mirroring videosource.FallbackSource's thresholds: an instance is
+// skipped once it has failed this many times in a row, and retried
+// again after cooldown has elapsed since its last failure.
+const (
+	nitterFailureThreshold = 5
+	nitterCooldown         = 30 * time.Second
+)
+
+// twitterReader is satisfied by both TwitterIntegration and
+// TwitterScraperBackend, letting FetchTwitterUser read tweets through
+// whichever backend NewSocialMediaHub selected without caring which one
+// it got. Posting (PostTweet, PostThread, ...) stays TwitterIntegration-only,
+// since a scraper can't authenticate to write.
+type twitterReader interface {
+	GetUser(ctx context.Context, username string) (*TwitterUser, error)
+	GetUserTweets(ctx context.Context, userID string, maxResults int, startTime *time.Time) ([]Tweet, error)
+}
+
+// TwitterScraperBackend reads tweets by scraping a pool of Nitter mirror
+// instances' RSS feeds instead of calling the official, increasingly
+// rate-limited and paid X API. It round-robins across the configured
+// instances, skipping any one a per-instance circuit breaker currently
+// considers unhealthy, mirroring videosource.FallbackSource's pattern.
+//
+// Nitter's RSS feed is the only reliably structured, cross-instance
+// interface it exposes; it carries tweet text, link, and publish date
+// but no engagement counts, so public metrics are parsed best-effort
+// from each tweet's status page HTML (see parseNitterStatsFromHTML) and
+// may come back zeroed if an instance's markup doesn't match.
+type TwitterScraperBackend struct {
+	instances  []string
+	httpClient *http.Client
+	logger     *zap.Logger
+
+	cursor atomic.Uint64
+
+	mu       sync.Mutex
+	failures []int
+	openedAt []time.Time
+}
+
+// NewTwitterScraperBackend creates a backend that round-robins across
+// instances (e.g. "nitter.net"). At least one instance is required.
+func NewTwitterScraperBackend(instances []string, logger *zap.Logger) *TwitterScraperBackend {
+	if len(instances) == 0 {
+		panic("integrations: NewTwitterScraperBackend requires at least one Nitter instance")
+	}
+	return &TwitterScraperBackend{
+		instances:  instances,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		logger:     logger,
+		failures:   make([]int, len(instances)),
+		openedAt:   make([]time.Time, len(instances)),
+	}
+}
+
+// WithClientOptions wraps the backend's http.Client.Transport in a
+// resilience.Transport, adding retries, per-host rate limiting, and
+// tracing around every request it makes to a Nitter instance, the same
+// opt-in pattern MediaManagerClient.WithClientOptions uses.
+func (s *TwitterScraperBackend) WithClientOptions(opts resilience.ClientOptions) *TwitterScraperBackend {
+	s.httpClient.Transport = resilience.NewTransport(opts, s.httpClient.Transport)
+	return s
+}
+
+// GetUser implements twitterReader by fetching username's Nitter RSS
+// feed and parsing its channel metadata. The returned ID is the handle
+// itself, since Nitter's RSS exposes no numeric user ID.
+func (s *TwitterScraperBackend) GetUser(ctx context.Context, username string) (*TwitterUser, error) {
+	feed, _, err := s.fetchFeed(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	name, handle := splitNitterChannelTitle(feed.Channel.Title)
+	if handle == "" {
+		handle = username
+	}
+
+	return &TwitterUser{
+		ID:              handle,
+		Username:        handle,
+		Name:            name,
+		Description:     html.UnescapeString(stripHTMLTags(feed.Channel.Description)),
+		ProfileImageURL: feed.Channel.Image.URL,
+	}, nil
+}
+
+// GetUserTweets implements twitterReader by fetching userID's (the
+// handle, per GetUser) Nitter RSS feed and parsing each item into a
+// Tweet, extracting hashtags and a best-effort referenced tweet from the
+// item title and fetching its status page for public metrics. Items
+// older than startTime are dropped; at most maxResults are returned.
+func (s *TwitterScraperBackend) GetUserTweets(ctx context.Context, userID string, maxResults int, startTime *time.Time) ([]Tweet, error) {
+	feed, instance, err := s.fetchFeed(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, handle := splitNitterChannelTitle(feed.Channel.Title)
+	if handle == "" {
+		handle = userID
+	}
+
+	tweets := make([]Tweet, 0, len(feed.Channel.Items))
+	for _, item := range feed.Channel.Items {
+		if len(tweets) >= maxResults {
+			break
+		}
+
+		createdAt, _ := time.Parse(time.RFC1123Z, strings.TrimSpace(item.PubDate))
+		if startTime != nil && createdAt.Before(*startTime) {
+			continue
+		}
+
+		text := html.UnescapeString(stripHTMLTags(item.Title))
+		tweet := Tweet{
+			ID:             nitterTweetID(item.GUID, item.Link),
+			Text:           text,
+			AuthorID:       handle,
+			AuthorUsername: handle,
+			AuthorName:     name,
+			CreatedAt:      createdAt,
+			PublicMetrics:  s.fetchStatusMetrics(ctx, instance, item.Link),
+		}
+
+		if hashtags := nitterHashtagPattern.FindAllString(text, -1); len(hashtags) > 0 {
+			entities := &TweetEntities{}
+			for _, tag := range hashtags {
+				entities.Hashtags = append(entities.Hashtags, struct {
+					Tag   string `json:"tag"`
+					Start int    `json:"start"`
+					End   int    `json:"end"`
+				}{Tag: strings.TrimPrefix(tag, "#")})
+			}
+			tweet.Entities = entities
+		}
+
+		if ref := nitterReferencedTweet(item.Title); ref != nil {
+			tweet.ReferencedTweets = []ReferencedTweet{*ref}
+		}
+
+		tweets = append(tweets, tweet)
+	}
+
+	return tweets, nil
+}
+
+// nitterFeed mirrors the channel/item shape Nitter's RSS feeds expose at
+// https://{instance}/{username}/rss.
+type nitterFeed struct {
+	XMLName xml.Name `xml:"rss"`
+	Channel struct {
+		Title       string `xml:"title"`
+		Description string `xml:"description"`
+		Image       struct {
+			URL string `xml:"url"`
+		} `xml:"image"`
+		Items []nitterItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type nitterItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	PubDate     string `xml:"pubDate"`
+	Description string `xml:"description"`
+	GUID        string `xml:"guid"`
+}
+
+// fetchFeed fetches and parses username's RSS feed from the next
+// healthy instance in round-robin order, falling through to the next on
+// failure. It also returns the instance that served the feed, so the
+// caller can fetch each item's status page from the same one.
+func (s *TwitterScraperBackend) fetchFeed(ctx context.Context, username string) (*nitterFeed, string, error) {
+	var lastErr error
+	for _, i := range s.attemptOrder() {
+		instance := s.instances[i]
+		body, err := s.get(ctx, fmt.Sprintf("https://%s/%s/rss", instance, username))
+		if err != nil {
+			s.recordFailure(i)
+			lastErr = err
+			continue
+		}
+
+		var feed nitterFeed
+		if err := xml.Unmarshal(body, &feed); err != nil {
+			s.recordFailure(i)
+			lastErr = fmt.Errorf("failed to parse nitter feed from %s: %w", instance, err)
+			continue
+		}
+
+		s.recordSuccess(i)
+		return &feed, instance, nil
+	}
+	if lastErr == nil {
+		return nil, "", fmt.Errorf("twitter scraper: all %d nitter instance(s) are circuit-broken", len(s.instances))
+	}
+	return nil, "", fmt.Errorf("twitter scraper: all attempted nitter instances failed: %w", lastErr)
+}
+
+// fetchStatusMetrics best-effort fetches statusURL's HTML page and
+// parses its public engagement counts. Nitter's RSS feed carries no
+// metrics, so this is the only way to surface them; any failure (an
+// instance down, markup not matching parseNitterStatsFromHTML) yields a
+// zeroed TweetMetrics rather than failing the whole tweet.
+func (s *TwitterScraperBackend) fetchStatusMetrics(ctx context.Context, instance, statusURL string) TweetMetrics {
+	body, err := s.get(ctx, statusURL)
+	if err != nil {
+		s.logger.Debug("Failed to fetch nitter status page for metrics",
+			zap.String("instance", instance), zap.Error(err))
+		return TweetMetrics{}
+	}
+	return parseNitterStatsFromHTML(string(body))
+}
+
+func (s *TwitterScraperBackend) get(ctx context.Context, endpoint string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("nitter request to %s returned %s", endpoint, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// attemptOrder returns the indices of instances whose breaker isn't
+// currently open, starting from the next position in the round-robin
+// cursor so load is spread across the pool instead of always hitting
+// instances[0] first.
+func (s *TwitterScraperBackend) attemptOrder() []int {
+	start := int(s.cursor.Add(1)-1) % len(s.instances)
+	order := make([]int, 0, len(s.instances))
+	for i := 0; i < len(s.instances); i++ {
+		idx := (start + i) % len(s.instances)
+		if !s.isOpen(idx) {
+			order = append(order, idx)
+		}
+	}
+	return order
+}
+
+// isOpen reports whether instance i's breaker currently considers it
+// unhealthy, resetting its failure count first if cooldown has elapsed
+// since it was last marked unhealthy.
+func (s *TwitterScraperBackend) isOpen(i int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures[i] < nitterFailureThreshold {
+		return false
+	}
+	if time.Since(s.openedAt[i]) >= nitterCooldown {
+		s.failures[i] = 0
+		s.openedAt[i] = time.Time{}
+		return false
+	}
+	return true
+}
+
+func (s *TwitterScraperBackend) recordSuccess(i int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[i] = 0
+	s.openedAt[i] = time.Time{}
+}
+
+func (s *TwitterScraperBackend) recordFailure(i int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[i]++
+	if s.failures[i] >= nitterFailureThreshold && s.openedAt[i].IsZero() {
+		s.openedAt[i] = time.Now()
+	}
+}
+
+var (
+	// nitterChannelTitlePattern splits a Nitter channel title of the
+	// form "Display Name / @handle" into its two parts.
+	nitterChannelTitlePattern = regexp.MustCompile(`^(.*) / @(\S+)$`)
+
+	nitterHashtagPattern = regexp.MustCompile(`#\w+`)
+
+	// nitterRetweetPattern matches the "RT @user: " prefix Nitter
+	// carries over from the original tweet's text when an item in the
+	// feed is a retweet.
+	nitterRetweetPattern = regexp.MustCompile(`^RT @(\w+): `)
+
+	htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+	// nitterStatPattern matches one of Nitter's tweet-stat icons
+	// ("icon-comment", "icon-retweet", "icon-quote", "icon-heart")
+	// followed by its count, tolerating whatever markup an instance's
+	// theme puts between the icon and the number. Different Nitter
+	// forks style this differently, so an instance whose markup doesn't
+	// match simply yields a zero count for that metric.
+	nitterStatPattern = regexp.MustCompile(`(?s)icon-(comment|retweet|quote|heart)[^>]*>.*?(\d[\d,]*)\s*</`)
+)
+
+func splitNitterChannelTitle(title string) (name, handle string) {
+	if m := nitterChannelTitlePattern.FindStringSubmatch(title); m != nil {
+		return strings.TrimSpace(m[1]), m[2]
+	}
+	return title, ""
+}
+
+func stripHTMLTags(s string) string {
+	return strings.TrimSpace(htmlTagPattern.ReplaceAllString(s, ""))
+}
+
+// nitterTweetID recovers a tweet's numeric ID from its RSS guid or,
+// failing that, the last path segment of its status link.
+func nitterTweetID(guid, link string) string {
+	if guid != "" {
+		if idx := strings.LastIndex(guid, "/"); idx != -1 {
+			return guid[idx+1:]
+		}
+		return guid
+	}
+	if idx := strings.LastIndex(link, "/"); idx != -1 {
+		return link[idx+1:]
+	}
+	return link
+}
+
+// nitterReferencedTweet reports a best-effort ReferencedTweet if title
+// looks like a retweet, per nitterRetweetPattern. Nitter's RSS doesn't
+// expose the original tweet's ID separately, so Type is set without ID.
+func nitterReferencedTweet(title string) *ReferencedTweet {
+	if m := nitterRetweetPattern.FindStringSubmatch(title); m != nil {
+		return &ReferencedTweet{Type: "retweeted"}
+	}
+	return nil
+}
+
+// parseNitterStatsFromHTML best-effort extracts public engagement
+// counts from a Nitter status page. See nitterStatPattern's comment for
+// why this can legitimately come back all zero.
+func parseNitterStatsFromHTML(pageHTML string) TweetMetrics {
+	var metrics TweetMetrics
+	for _, m := range nitterStatPattern.FindAllStringSubmatch(pageHTML, -1) {
+		count, err := strconv.Atoi(strings.ReplaceAll(m[2], ",", ""))
+		if err != nil {
+			continue
+		}
+		switch m[1] {
+		case "comment":
+			metrics.ReplyCount = count
+		case "retweet":
+			metrics.RetweetCount = count
+		case "quote":
+			metrics.QuoteCount = count
+		case "heart":
+			metrics.LikeCount = count
+		}
+	}
+	return metrics
+}
+
+// autoTwitterReader tries the official API first and transparently
+// falls back to scraping Nitter on a 429 (rate limited) or 401
+// (unauthorized/expired token) response, so a hub configured with both
+// degrades instead of failing outright.
+type autoTwitterReader struct {
+	api     *TwitterIntegration
+	scraper *TwitterScraperBackend
+	logger  *zap.Logger
+
+	mu           sync.Mutex
+	usernameByID map[string]string
+}
+
+func newAutoTwitterReader(api *TwitterIntegration, scraper *TwitterScraperBackend, logger *zap.Logger) *autoTwitterReader {
+	return &autoTwitterReader{api: api, scraper: scraper, logger: logger, usernameByID: make(map[string]string)}
+}
+
+func (a *autoTwitterReader) GetUser(ctx context.Context, username string) (*TwitterUser, error) {
+	user, err := a.api.GetUser(ctx, username)
+	if err != nil {
+		if !isTwitterAPIFallbackError(err) {
+			return nil, err
+		}
+		a.logger.Warn("Twitter API unavailable, falling back to Nitter scraping", zap.Error(err))
+		return a.scraper.GetUser(ctx, username)
+	}
+
+	a.mu.Lock()
+	a.usernameByID[user.ID] = username
+	a.mu.Unlock()
+	return user, nil
+}
+
+// GetUserTweets tries the API first. If it fails over to the scraper,
+// userID (the API's numeric user ID) is useless to Nitter, so it's
+// translated back to the username GetUser resolved it from; if no
+// mapping exists, userID is assumed to already be a handle (GetUser
+// itself must have fallen back to the scraper for this user).
+func (a *autoTwitterReader) GetUserTweets(ctx context.Context, userID string, maxResults int, startTime *time.Time) ([]Tweet, error) {
+	tweets, err := a.api.GetUserTweets(ctx, userID, maxResults, startTime)
+	if err == nil {
+		return tweets, nil
+	}
+	if !isTwitterAPIFallbackError(err) {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	username, ok := a.usernameByID[userID]
+	a.mu.Unlock()
+	if !ok {
+		username = userID
+	}
+
+	a.logger.Warn("Twitter API unavailable, falling back to Nitter scraping", zap.Error(err))
+	return a.scraper.GetUserTweets(ctx, username, maxResults, startTime)
+}
+
+// isTwitterAPIFallbackError reports whether err looks like the 429 or
+// 401 makeRequest formats as "Twitter API error: <status> - ...", the
+// only signal available today since TwitterIntegration has no typed
+// error for its HTTP status.
+func isTwitterAPIFallbackError(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "Twitter API error: 429") || strings.Contains(msg, "Twitter API error: 401")
+}
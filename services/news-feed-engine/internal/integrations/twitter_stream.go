@@ -0,0 +1,407 @@
+package integrations
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// streamStallTimeout is how long FilteredStream waits without seeing any
+// line (including Twitter's ~20s keep-alive newlines) before treating
+// the connection as stalled and reconnecting.
+const streamStallTimeout = 90 * time.Second
+
+// streamMaxLineBytes bounds a single NDJSON line. Twitter's envelopes
+// are normally well under this; a line past it signals a malformed
+// response rather than a legitimately large tweet.
+const streamMaxLineBytes = 1 << 20 // 1MB
+
+const (
+	streamLinearBackoffStep = 250 * time.Millisecond
+	streamLinearBackoffMax  = 16 * time.Second
+
+	streamExponentialBackoffBase = 1 * time.Minute
+	streamExponentialBackoffMax  = 320 * time.Second
+)
+
+// StreamRule is a single Twitter filtered-stream matching rule.
+type StreamRule struct {
+	ID    string `json:"id,omitempty"`
+	Value string `json:"value"`
+	Tag   string `json:"tag,omitempty"`
+}
+
+// streamStatusError records a non-200 response from the stream
+// endpoint, so the reconnect loop can tell a rate limit (420/429) apart
+// from a TCP/IP-level failure and back off accordingly.
+type streamStatusError struct {
+	status int
+	body   string
+}
+
+func (e *streamStatusError) Error() string {
+	return fmt.Sprintf("Twitter stream error: %d - %s", e.status, e.body)
+}
+
+func (e *streamStatusError) rateLimited() bool {
+	return e.status == http.StatusTooManyRequests || e.status == 420
+}
+
+// AddRules adds rules to the filtered stream's active rule set via
+// POST /2/tweets/search/stream/rules. Call this before FilteredStream;
+// an empty stream (no rules) receives nothing.
+func (t *TwitterIntegration) AddRules(ctx context.Context, rules []StreamRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	return t.modifyStreamRules(ctx, map[string]interface{}{"add": rules})
+}
+
+// DeleteRules removes rules by ID from the filtered stream's active rule
+// set via POST /2/tweets/search/stream/rules.
+func (t *TwitterIntegration) DeleteRules(ctx context.Context, ruleIDs []string) error {
+	if len(ruleIDs) == 0 {
+		return nil
+	}
+	return t.modifyStreamRules(ctx, map[string]interface{}{
+		"delete": map[string][]string{"ids": ruleIDs},
+	})
+}
+
+func (t *TwitterIntegration) modifyStreamRules(ctx context.Context, payload interface{}) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal stream rules: %w", err)
+	}
+
+	authHeader, err := t.authHeader(ctx, false)
+	if err != nil {
+		return fmt.Errorf("failed to get Twitter auth token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.twitter.com/2/tweets/search/stream/rules", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", authHeader)
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to modify stream rules: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("Twitter API error modifying stream rules: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Errors []struct {
+			Title  string `json:"title"`
+			Detail string `json:"detail"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &result); err == nil && len(result.Errors) > 0 {
+		return fmt.Errorf("Twitter API rejected stream rules: %s", result.Errors[0].Detail)
+	}
+
+	return nil
+}
+
+// FilteredStream opens Twitter API v2's filtered stream
+// (GET /2/tweets/search/stream) for rules and returns a channel of
+// decoded tweets plus a channel of non-fatal errors (stalls, rate
+// limits, transport errors) encountered while reconnecting. Both
+// channels close once ctx is cancelled. Callers typically call AddRules
+// once up front; FilteredStream itself only connects and streams.
+func (t *TwitterIntegration) FilteredStream(ctx context.Context, rules []StreamRule) (<-chan Tweet, <-chan error, error) {
+	if err := t.AddRules(ctx, rules); err != nil {
+		return nil, nil, err
+	}
+
+	tweets := make(chan Tweet)
+	errs := make(chan error, 1)
+
+	go t.runStream(ctx, tweets, errs)
+
+	return tweets, errs, nil
+}
+
+// runStream reconnects to the stream endpoint until ctx is cancelled,
+// backing off linearly on TCP/IP-level errors and exponentially on HTTP
+// 420/429 rate limiting, per Twitter's reconnection guidance.
+func (t *TwitterIntegration) runStream(ctx context.Context, tweets chan<- Tweet, errs chan<- error) {
+	defer close(tweets)
+	defer close(errs)
+
+	tcpAttempt := 0
+	rateLimitAttempt := 0
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		err := t.connectStream(ctx, tweets)
+		if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return
+		}
+
+		select {
+		case errs <- err:
+		default:
+		}
+
+		var statusErr *streamStatusError
+		if errors.As(err, &statusErr) && statusErr.rateLimited() {
+			rateLimitAttempt++
+			tcpAttempt = 0
+			if werr := sleepForStreamRetry(ctx, exponentialStreamBackoff(rateLimitAttempt)); werr != nil {
+				return
+			}
+			continue
+		}
+
+		tcpAttempt++
+		rateLimitAttempt = 0
+		if werr := sleepForStreamRetry(ctx, linearStreamBackoff(tcpAttempt)); werr != nil {
+			return
+		}
+	}
+}
+
+// connectStream opens a single stream connection and pushes decoded
+// tweets to tweets until the connection ends, returning the error that
+// ended it (nil is never returned here; only ctx cancellation or a
+// stream error ends a connection).
+func (t *TwitterIntegration) connectStream(ctx context.Context, tweets chan<- Tweet) error {
+	authHeader, err := t.authHeader(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	params := url.Values{
+		"tweet.fields": {"id,text,author_id,created_at,conversation_id,public_metrics,entities,referenced_tweets"},
+		"user.fields":  {"id,name,username"},
+		"expansions":   {"author_id"},
+	}
+	apiURL := "https://api.twitter.com/2/tweets/search/stream?" + params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", authHeader)
+
+	resp, err := streamHTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode == http.StatusUnauthorized && t.tokenMgr != nil {
+			if _, rerr := t.tokenMgr.Token(ctx, true); rerr != nil {
+				return rerr
+			}
+		}
+		return &streamStatusError{status: resp.StatusCode, body: string(body)}
+	}
+
+	lineCh := make(chan []byte)
+	scanDone := make(chan error, 1)
+
+	scanCtx, cancelScan := context.WithCancel(ctx)
+	defer cancelScan()
+
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), streamMaxLineBytes)
+		for scanner.Scan() {
+			line := append([]byte(nil), scanner.Bytes()...)
+			select {
+			case lineCh <- line:
+			case <-scanCtx.Done():
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			scanDone <- err
+			return
+		}
+		scanDone <- io.EOF
+	}()
+
+	stallTimer := time.NewTimer(streamStallTimeout)
+	defer stallTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-stallTimer.C:
+			return fmt.Errorf("twitter stream stalled: no data for %s", streamStallTimeout)
+		case err := <-scanDone:
+			return err
+		case line := <-lineCh:
+			if !stallTimer.Stop() {
+				select {
+				case <-stallTimer.C:
+				default:
+				}
+			}
+			stallTimer.Reset(streamStallTimeout)
+
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue // keep-alive heartbeat
+			}
+
+			tweet, perr := parseStreamEnvelope(line)
+			if perr != nil {
+				if t.logger != nil {
+					t.logger.Warn("failed to parse twitter stream envelope", zap.Error(perr))
+				}
+				continue
+			}
+
+			select {
+			case tweets <- *tweet:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// streamEnvelope is one line of the filtered stream's NDJSON body: a
+// tweet plus its expansions, matching GetUserTweets' response shape.
+type streamEnvelope struct {
+	Data struct {
+		ID             string `json:"id"`
+		Text           string `json:"text"`
+		AuthorID       string `json:"author_id"`
+		CreatedAt      string `json:"created_at"`
+		ConversationID string `json:"conversation_id"`
+		PublicMetrics  struct {
+			RetweetCount    int `json:"retweet_count"`
+			ReplyCount      int `json:"reply_count"`
+			LikeCount       int `json:"like_count"`
+			QuoteCount      int `json:"quote_count"`
+			BookmarkCount   int `json:"bookmark_count"`
+			ImpressionCount int `json:"impression_count"`
+		} `json:"public_metrics"`
+		Entities         *TweetEntities    `json:"entities,omitempty"`
+		ReferencedTweets []ReferencedTweet `json:"referenced_tweets,omitempty"`
+	} `json:"data"`
+	Includes struct {
+		Users []struct {
+			ID       string `json:"id"`
+			Name     string `json:"name"`
+			Username string `json:"username"`
+		} `json:"users"`
+	} `json:"includes"`
+	Errors []struct {
+		Title  string `json:"title"`
+		Detail string `json:"detail"`
+	} `json:"errors"`
+}
+
+func parseStreamEnvelope(line []byte) (*Tweet, error) {
+	var env streamEnvelope
+	if err := json.Unmarshal(line, &env); err != nil {
+		return nil, fmt.Errorf("failed to parse stream envelope: %w", err)
+	}
+
+	if len(env.Errors) > 0 {
+		return nil, fmt.Errorf("stream envelope error: %s", env.Errors[0].Detail)
+	}
+
+	createdAt, _ := time.Parse(time.RFC3339, env.Data.CreatedAt)
+
+	tweet := &Tweet{
+		ID:             env.Data.ID,
+		Text:           env.Data.Text,
+		AuthorID:       env.Data.AuthorID,
+		CreatedAt:      createdAt,
+		ConversationID: env.Data.ConversationID,
+		PublicMetrics: TweetMetrics{
+			RetweetCount:    env.Data.PublicMetrics.RetweetCount,
+			ReplyCount:      env.Data.PublicMetrics.ReplyCount,
+			LikeCount:       env.Data.PublicMetrics.LikeCount,
+			QuoteCount:      env.Data.PublicMetrics.QuoteCount,
+			BookmarkCount:   env.Data.PublicMetrics.BookmarkCount,
+			ImpressionCount: env.Data.PublicMetrics.ImpressionCount,
+		},
+		Entities:         env.Data.Entities,
+		ReferencedTweets: env.Data.ReferencedTweets,
+	}
+
+	for _, user := range env.Includes.Users {
+		if user.ID == env.Data.AuthorID {
+			tweet.AuthorName = user.Name
+			tweet.AuthorUsername = user.Username
+			break
+		}
+	}
+
+	return tweet, nil
+}
+
+// streamHTTPClient returns a client with no overall request timeout:
+// the filtered stream is a long-lived connection governed by ctx
+// cancellation and the stall watchdog, not a fixed deadline.
+func streamHTTPClient() *http.Client {
+	return &http.Client{}
+}
+
+// linearStreamBackoff implements Twitter's guidance for TCP/IP-level
+// network errors: wait streamLinearBackoffStep, then increase linearly,
+// capped at streamLinearBackoffMax.
+func linearStreamBackoff(attempt int) time.Duration {
+	d := streamLinearBackoffStep * time.Duration(attempt)
+	if d > streamLinearBackoffMax {
+		d = streamLinearBackoffMax
+	}
+	return d
+}
+
+// exponentialStreamBackoff implements Twitter's guidance for HTTP
+// 420/429 rate limiting: wait streamExponentialBackoffBase, then double
+// each attempt, capped at streamExponentialBackoffMax.
+func exponentialStreamBackoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	d := streamExponentialBackoffBase * time.Duration(uint(1)<<uint(attempt-1))
+	if d > streamExponentialBackoffMax || d <= 0 {
+		d = streamExponentialBackoffMax
+	}
+	return d
+}
+
+func sleepForStreamRetry(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,177 @@
+package videosource
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/ytapi"
+)
+
+// Circuit breaker defaults for FallbackSource, overridable via
+// NewFallbackSourceWithThresholds. Mirrors
+// metrics.ServiceAvailabilityChecker's defaults/shape, trading its
+// slow-response tier for a simpler healthy/unhealthy breaker since a
+// VideoSource call either succeeds or it doesn't.
+const (
+	defaultFailureThreshold = 5
+	defaultCooldown         = 30 * time.Second
+)
+
+// FallbackSource tries an ordered list of VideoSources, skipping any one
+// a circuit breaker currently considers unhealthy, and returns the first
+// successful result. This lets ingestion ride out a YouTube Data API
+// quota exhaustion (or any one Invidious/Piped instance outage) by
+// falling through to the next source instead of failing the call.
+type FallbackSource struct {
+	sources          []VideoSource
+	failureThreshold int
+	cooldown         time.Duration
+
+	mu       sync.Mutex
+	failures []int
+	openedAt []time.Time
+}
+
+// NewFallbackSource creates a FallbackSource trying sources in order,
+// using package default circuit breaker thresholds. At least one source
+// is required.
+func NewFallbackSource(sources ...VideoSource) *FallbackSource {
+	return NewFallbackSourceWithThresholds(defaultFailureThreshold, defaultCooldown, sources...)
+}
+
+// NewFallbackSourceWithThresholds creates a FallbackSource with
+// overridden circuit breaker thresholds: a source is skipped once it has
+// failed failureThreshold times in a row, and retried again after
+// cooldown has elapsed since its last failure.
+func NewFallbackSourceWithThresholds(failureThreshold int, cooldown time.Duration, sources ...VideoSource) *FallbackSource {
+	if len(sources) == 0 {
+		panic("videosource: NewFallbackSource requires at least one source")
+	}
+	return &FallbackSource{
+		sources:          sources,
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		failures:         make([]int, len(sources)),
+		openedAt:         make([]time.Time, len(sources)),
+	}
+}
+
+// GetChannel tries each source in order, as described on FallbackSource.
+func (f *FallbackSource) GetChannel(ctx context.Context, channelID string) (*ytapi.Channel, error) {
+	var lastErr error
+	for _, i := range f.attemptOrder() {
+		result, err := f.sources[i].GetChannel(ctx, channelID)
+		if err == nil {
+			f.recordSuccess(i)
+			return result, nil
+		}
+		f.recordFailure(i)
+		lastErr = err
+	}
+	if lastErr == nil {
+		return nil, fmt.Errorf("videosource: all %d source(s) are circuit-broken", len(f.sources))
+	}
+	return nil, fmt.Errorf("videosource: all attempted sources failed: %w", lastErr)
+}
+
+// GetChannelVideos tries each source in order, as described on FallbackSource.
+func (f *FallbackSource) GetChannelVideos(ctx context.Context, channelID string, maxResults int, publishedAfter *time.Time) ([]ytapi.Video, error) {
+	var lastErr error
+	for _, i := range f.attemptOrder() {
+		result, err := f.sources[i].GetChannelVideos(ctx, channelID, maxResults, publishedAfter)
+		if err == nil {
+			f.recordSuccess(i)
+			return result, nil
+		}
+		f.recordFailure(i)
+		lastErr = err
+	}
+	if lastErr == nil {
+		return nil, fmt.Errorf("videosource: all %d source(s) are circuit-broken", len(f.sources))
+	}
+	return nil, fmt.Errorf("videosource: all attempted sources failed: %w", lastErr)
+}
+
+// GetVideoDetails tries each source in order, as described on FallbackSource.
+func (f *FallbackSource) GetVideoDetails(ctx context.Context, videoID string) (*ytapi.Video, error) {
+	var lastErr error
+	for _, i := range f.attemptOrder() {
+		result, err := f.sources[i].GetVideoDetails(ctx, videoID)
+		if err == nil {
+			f.recordSuccess(i)
+			return result, nil
+		}
+		f.recordFailure(i)
+		lastErr = err
+	}
+	if lastErr == nil {
+		return nil, fmt.Errorf("videosource: all %d source(s) are circuit-broken", len(f.sources))
+	}
+	return nil, fmt.Errorf("videosource: all attempted sources failed: %w", lastErr)
+}
+
+// GetCaptions tries each source in order, as described on FallbackSource.
+func (f *FallbackSource) GetCaptions(ctx context.Context, videoID string) (string, error) {
+	var lastErr error
+	for _, i := range f.attemptOrder() {
+		result, err := f.sources[i].GetCaptions(ctx, videoID)
+		if err == nil {
+			f.recordSuccess(i)
+			return result, nil
+		}
+		f.recordFailure(i)
+		lastErr = err
+	}
+	if lastErr == nil {
+		return "", fmt.Errorf("videosource: all %d source(s) are circuit-broken", len(f.sources))
+	}
+	return "", fmt.Errorf("videosource: all attempted sources failed: %w", lastErr)
+}
+
+// attemptOrder returns the indices of sources whose breaker isn't
+// currently open, in priority order.
+func (f *FallbackSource) attemptOrder() []int {
+	var order []int
+	for i := range f.sources {
+		if !f.isOpen(i) {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// isOpen reports whether source i's breaker currently considers it
+// unhealthy, resetting its failure count first if cooldown has elapsed
+// since it was last marked unhealthy.
+func (f *FallbackSource) isOpen(i int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.failures[i] < f.failureThreshold {
+		return false
+	}
+	if time.Since(f.openedAt[i]) >= f.cooldown {
+		f.failures[i] = 0
+		f.openedAt[i] = time.Time{}
+		return false
+	}
+	return true
+}
+
+func (f *FallbackSource) recordSuccess(i int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures[i] = 0
+	f.openedAt[i] = time.Time{}
+}
+
+func (f *FallbackSource) recordFailure(i int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures[i]++
+	if f.failures[i] >= f.failureThreshold && f.openedAt[i].IsZero() {
+		f.openedAt[i] = time.Now()
+	}
+}
@@ -0,0 +1,110 @@
+package videosource
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/ytapi"
+)
+
+// fakeSource is a VideoSource whose GetVideoDetails either fails or
+// returns a canned video, counting calls made to it.
+type fakeSource struct {
+	fail  bool
+	calls int
+}
+
+func (f *fakeSource) GetChannel(ctx context.Context, channelID string) (*ytapi.Channel, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeSource) GetChannelVideos(ctx context.Context, channelID string, maxResults int, publishedAfter *time.Time) ([]ytapi.Video, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeSource) GetVideoDetails(ctx context.Context, videoID string) (*ytapi.Video, error) {
+	f.calls++
+	if f.fail {
+		return nil, errors.New("source unavailable")
+	}
+	return &ytapi.Video{ID: videoID}, nil
+}
+
+func (f *fakeSource) GetCaptions(ctx context.Context, videoID string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func TestFallbackSourceFailsOverToNextSource(t *testing.T) {
+	primary := &fakeSource{fail: true}
+	secondary := &fakeSource{fail: false}
+	f := NewFallbackSource(primary, secondary)
+
+	video, err := f.GetVideoDetails(context.Background(), "abc")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if video.ID != "abc" {
+		t.Fatalf("expected video from secondary source, got %+v", video)
+	}
+	if primary.calls != 1 || secondary.calls != 1 {
+		t.Fatalf("expected one call to each source, got primary=%d secondary=%d", primary.calls, secondary.calls)
+	}
+}
+
+func TestFallbackSourceTripsBreakerAfterThreshold(t *testing.T) {
+	primary := &fakeSource{fail: true}
+	secondary := &fakeSource{fail: false}
+	f := NewFallbackSourceWithThresholds(2, time.Minute, primary, secondary)
+
+	for i := 0; i < 2; i++ {
+		if _, err := f.GetVideoDetails(context.Background(), "abc"); err != nil {
+			t.Fatalf("unexpected error on call %d: %v", i, err)
+		}
+	}
+	if primary.calls != 2 {
+		t.Fatalf("expected breaker to trip after 2 failures, primary was called %d times", primary.calls)
+	}
+
+	// A third call should skip the now-broken primary entirely.
+	if _, err := f.GetVideoDetails(context.Background(), "abc"); err != nil {
+		t.Fatalf("unexpected error on call 3: %v", err)
+	}
+	if primary.calls != 2 {
+		t.Fatalf("expected primary to stay skipped once its breaker is open, got %d calls", primary.calls)
+	}
+}
+
+func TestFallbackSourceResetsBreakerAfterCooldown(t *testing.T) {
+	primary := &fakeSource{fail: true}
+	secondary := &fakeSource{fail: false}
+	f := NewFallbackSourceWithThresholds(1, time.Millisecond, primary, secondary)
+
+	if _, err := f.GetVideoDetails(context.Background(), "abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if primary.calls != 1 {
+		t.Fatalf("expected primary to be tried once, got %d", primary.calls)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, err := f.GetVideoDetails(context.Background(), "abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if primary.calls != 2 {
+		t.Fatalf("expected primary to be retried after cooldown, got %d calls", primary.calls)
+	}
+}
+
+func TestFallbackSourceAllSourcesFail(t *testing.T) {
+	primary := &fakeSource{fail: true}
+	secondary := &fakeSource{fail: true}
+	f := NewFallbackSource(primary, secondary)
+
+	if _, err := f.GetVideoDetails(context.Background(), "abc"); err == nil {
+		t.Fatal("expected an error when every source fails")
+	}
+}
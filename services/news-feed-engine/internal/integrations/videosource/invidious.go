@@ -0,0 +1,248 @@
+package videosource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/ytapi"
+)
+
+// InvidiousSource implements VideoSource against a pool of Invidious
+// instances (https://api.invidious.io/), round-robin failing over to the
+// next instance the same way ytapi.Client pools API keys - Invidious
+// instances are independently operated and go down far more often than
+// the Data API does.
+type InvidiousSource struct {
+	instances  []string
+	nextIdx    uint32
+	httpClient *http.Client
+	logger     *zap.Logger
+}
+
+// NewInvidiousSource creates an InvidiousSource pooling instances (each a
+// base URL, e.g. "https://yewtu.be"). At least one instance is required;
+// NewInvidiousSource panics otherwise since a source with none can never
+// make a request.
+func NewInvidiousSource(instances []string, logger *zap.Logger) *InvidiousSource {
+	if len(instances) == 0 {
+		panic("videosource: NewInvidiousSource requires at least one instance")
+	}
+	return &InvidiousSource{
+		instances:  instances,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		logger:     logger,
+	}
+}
+
+// GetChannel retrieves channel information by channel ID (Invidious'
+// "ucid").
+func (s *InvidiousSource) GetChannel(ctx context.Context, channelID string) (*ytapi.Channel, error) {
+	body, err := s.get(ctx, "/api/v1/channels/"+url.PathEscape(channelID))
+	if err != nil {
+		return nil, fmt.Errorf("invidious: failed to get channel: %w", err)
+	}
+
+	var result struct {
+		AuthorID         string `json:"authorId"`
+		Author           string `json:"author"`
+		Description      string `json:"description"`
+		AuthorThumbnails []struct {
+			URL string `json:"url"`
+		} `json:"authorThumbnails"`
+		SubCount   int64 `json:"subCount"`
+		VideoCount int64 `json:"videoCount"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("invidious: failed to parse channel response: %w", err)
+	}
+
+	var thumbnail string
+	if len(result.AuthorThumbnails) > 0 {
+		thumbnail = result.AuthorThumbnails[len(result.AuthorThumbnails)-1].URL
+	}
+	return &ytapi.Channel{
+		ID:              result.AuthorID,
+		Title:           result.Author,
+		Description:     result.Description,
+		ThumbnailURL:    thumbnail,
+		SubscriberCount: result.SubCount,
+		VideoCount:      result.VideoCount,
+	}, nil
+}
+
+// GetChannelVideos retrieves channelID's recent uploads, optionally
+// filtered to videos published at or after publishedAfter.
+func (s *InvidiousSource) GetChannelVideos(ctx context.Context, channelID string, maxResults int, publishedAfter *time.Time) ([]ytapi.Video, error) {
+	body, err := s.get(ctx, "/api/v1/channels/"+url.PathEscape(channelID)+"/videos")
+	if err != nil {
+		return nil, fmt.Errorf("invidious: failed to get channel videos: %w", err)
+	}
+
+	var result struct {
+		Videos []invidiousVideo `json:"videos"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("invidious: failed to parse channel videos response: %w", err)
+	}
+
+	videos := make([]ytapi.Video, 0, len(result.Videos))
+	for _, v := range result.Videos {
+		video := v.toVideo()
+		if publishedAfter != nil && video.PublishedAt.Before(*publishedAfter) {
+			continue
+		}
+		videos = append(videos, video)
+		if len(videos) >= maxResults {
+			break
+		}
+	}
+	return videos, nil
+}
+
+// GetVideoDetails retrieves detailed information for a single video.
+func (s *InvidiousSource) GetVideoDetails(ctx context.Context, videoID string) (*ytapi.Video, error) {
+	body, err := s.get(ctx, "/api/v1/videos/"+url.PathEscape(videoID))
+	if err != nil {
+		return nil, fmt.Errorf("invidious: failed to get video details: %w", err)
+	}
+
+	var v invidiousVideo
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, fmt.Errorf("invidious: failed to parse video details response: %w", err)
+	}
+	video := v.toVideo()
+	return &video, nil
+}
+
+// GetCaptions returns the English auto/manual caption track's text for a
+// video, fetching the track content from the URL captions.list reports.
+func (s *InvidiousSource) GetCaptions(ctx context.Context, videoID string) (string, error) {
+	body, err := s.get(ctx, "/api/v1/captions/"+url.PathEscape(videoID))
+	if err != nil {
+		return "", fmt.Errorf("invidious: failed to list captions: %w", err)
+	}
+
+	var result struct {
+		Captions []struct {
+			Label        string `json:"label"`
+			LanguageCode string `json:"languageCode"`
+			URL          string `json:"url"`
+		} `json:"captions"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("invidious: failed to parse captions response: %w", err)
+	}
+
+	for _, c := range result.Captions {
+		if c.LanguageCode == "en" || c.LanguageCode == "en-US" {
+			trackBody, err := s.get(ctx, c.URL)
+			if err != nil {
+				return "", fmt.Errorf("invidious: failed to fetch caption track: %w", err)
+			}
+			return string(trackBody), nil
+		}
+	}
+	return "", fmt.Errorf("no English captions available for video: %s", videoID)
+}
+
+// invidiousVideo is the subset of an Invidious video response this
+// package reads, shared by GetChannelVideos and GetVideoDetails.
+type invidiousVideo struct {
+	VideoID         string `json:"videoId"`
+	Title           string `json:"title"`
+	Description     string `json:"description"`
+	AuthorID        string `json:"authorId"`
+	Author          string `json:"author"`
+	Published       int64  `json:"published"` // unix seconds
+	LengthSeconds   int64  `json:"lengthSeconds"`
+	ViewCount       int64  `json:"viewCount"`
+	LikeCount       int64  `json:"likeCount"`
+	LiveNow         bool   `json:"liveNow"`
+	VideoThumbnails []struct {
+		URL string `json:"url"`
+	} `json:"videoThumbnails"`
+}
+
+func (v invidiousVideo) toVideo() ytapi.Video {
+	var thumbnail string
+	if len(v.VideoThumbnails) > 0 {
+		thumbnail = v.VideoThumbnails[len(v.VideoThumbnails)-1].URL
+	}
+	liveBroadcastContent := "none"
+	if v.LiveNow {
+		liveBroadcastContent = "live"
+	}
+	return ytapi.Video{
+		ID:                   v.VideoID,
+		Title:                v.Title,
+		Description:          v.Description,
+		ChannelID:            v.AuthorID,
+		ChannelTitle:         v.Author,
+		PublishedAt:          time.Unix(v.Published, 0).UTC(),
+		ThumbnailURL:         thumbnail,
+		DurationSeconds:      v.LengthSeconds,
+		ViewCount:            v.ViewCount,
+		LikeCount:            v.LikeCount,
+		LiveBroadcastContent: liveBroadcastContent,
+	}
+}
+
+// get performs one GET against the pooled Invidious instances, round-
+// robin starting from the next instance in rotation and failing over to
+// the rest of the pool on a non-200 response or transport error. path
+// may be instance-relative (starting with "/") or an absolute URL
+// already pointing at a chosen instance (as captions.list's track URLs
+// sometimes are), in which case it's used as-is without rotation.
+func (s *InvidiousSource) get(ctx context.Context, path string) ([]byte, error) {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return s.fetch(ctx, path)
+	}
+
+	start := int(atomic.AddUint32(&s.nextIdx, 1) - 1)
+
+	var lastErr error
+	for i := 0; i < len(s.instances); i++ {
+		instance := s.instances[(start+i)%len(s.instances)]
+		body, err := s.fetch(ctx, instance+path)
+		if err == nil {
+			return body, nil
+		}
+		if s.logger != nil {
+			s.logger.Warn("videosource: invidious instance failed, trying next",
+				zap.String("instance", instance), zap.Error(err))
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("all %d invidious instance(s) failed: %w", len(s.instances), lastErr)
+}
+
+func (s *InvidiousSource) fetch(ctx context.Context, fullURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s - %s", fullURL, resp.Status, string(body))
+	}
+	return body, nil
+}
@@ -0,0 +1,243 @@
+package videosource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/ytapi"
+)
+
+// PipedSource implements VideoSource against a pool of Piped instances
+// (https://github.com/TeamPiped/Piped), round-robin failing over to the
+// next instance on failure, the same way InvidiousSource pools its
+// instances.
+type PipedSource struct {
+	instances  []string
+	nextIdx    uint32
+	httpClient *http.Client
+	logger     *zap.Logger
+}
+
+// NewPipedSource creates a PipedSource pooling instances (each a base
+// URL, e.g. "https://pipedapi.kavin.rocks"). At least one instance is
+// required; NewPipedSource panics otherwise, matching NewInvidiousSource.
+func NewPipedSource(instances []string, logger *zap.Logger) *PipedSource {
+	if len(instances) == 0 {
+		panic("videosource: NewPipedSource requires at least one instance")
+	}
+	return &PipedSource{
+		instances:  instances,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		logger:     logger,
+	}
+}
+
+// GetChannel retrieves channel information by channel ID.
+func (s *PipedSource) GetChannel(ctx context.Context, channelID string) (*ytapi.Channel, error) {
+	body, err := s.get(ctx, "/channel/"+url.PathEscape(channelID))
+	if err != nil {
+		return nil, fmt.Errorf("piped: failed to get channel: %w", err)
+	}
+
+	var result struct {
+		ID             string        `json:"id"`
+		Name           string        `json:"name"`
+		Description    string        `json:"description"`
+		AvatarURL      string        `json:"avatarUrl"`
+		Subscribers    int64         `json:"subscriberCount"`
+		RelatedStreams []pipedStream `json:"relatedStreams"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("piped: failed to parse channel response: %w", err)
+	}
+
+	return &ytapi.Channel{
+		ID:              result.ID,
+		Title:           result.Name,
+		Description:     result.Description,
+		ThumbnailURL:    result.AvatarURL,
+		SubscriberCount: result.Subscribers,
+		VideoCount:      int64(len(result.RelatedStreams)),
+	}, nil
+}
+
+// GetChannelVideos retrieves channelID's recent uploads, optionally
+// filtered to videos published at or after publishedAfter.
+func (s *PipedSource) GetChannelVideos(ctx context.Context, channelID string, maxResults int, publishedAfter *time.Time) ([]ytapi.Video, error) {
+	body, err := s.get(ctx, "/channel/"+url.PathEscape(channelID))
+	if err != nil {
+		return nil, fmt.Errorf("piped: failed to get channel videos: %w", err)
+	}
+
+	var result struct {
+		RelatedStreams []pipedStream `json:"relatedStreams"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("piped: failed to parse channel videos response: %w", err)
+	}
+
+	videos := make([]ytapi.Video, 0, len(result.RelatedStreams))
+	for _, v := range result.RelatedStreams {
+		video := v.toVideo()
+		if publishedAfter != nil && video.PublishedAt.Before(*publishedAfter) {
+			continue
+		}
+		videos = append(videos, video)
+		if len(videos) >= maxResults {
+			break
+		}
+	}
+	return videos, nil
+}
+
+// GetVideoDetails retrieves detailed information for a single video.
+func (s *PipedSource) GetVideoDetails(ctx context.Context, videoID string) (*ytapi.Video, error) {
+	body, err := s.get(ctx, "/streams/"+url.PathEscape(videoID))
+	if err != nil {
+		return nil, fmt.Errorf("piped: failed to get video details: %w", err)
+	}
+
+	var result struct {
+		Title        string `json:"title"`
+		Description  string `json:"description"`
+		UploaderName string `json:"uploader"`
+		UploaderURL  string `json:"uploaderUrl"`
+		UploadDate   string `json:"uploadDate"`
+		ThumbnailURL string `json:"thumbnailUrl"`
+		Duration     int64  `json:"duration"`
+		Views        int64  `json:"views"`
+		Likes        int64  `json:"likes"`
+		Livestream   bool   `json:"livestream"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("piped: failed to parse video details response: %w", err)
+	}
+
+	publishedAt, _ := time.Parse("2006-01-02", result.UploadDate)
+	liveBroadcastContent := "none"
+	if result.Livestream {
+		liveBroadcastContent = "live"
+	}
+	return &ytapi.Video{
+		ID:                   videoID,
+		Title:                result.Title,
+		Description:          result.Description,
+		ChannelID:            strings.TrimPrefix(result.UploaderURL, "/channel/"),
+		ChannelTitle:         result.UploaderName,
+		PublishedAt:          publishedAt,
+		ThumbnailURL:         result.ThumbnailURL,
+		DurationSeconds:      result.Duration,
+		ViewCount:            result.Views,
+		LikeCount:            result.Likes,
+		LiveBroadcastContent: liveBroadcastContent,
+	}, nil
+}
+
+// GetCaptions returns the English subtitle track's text for a video.
+func (s *PipedSource) GetCaptions(ctx context.Context, videoID string) (string, error) {
+	body, err := s.get(ctx, "/streams/"+url.PathEscape(videoID))
+	if err != nil {
+		return "", fmt.Errorf("piped: failed to get subtitles: %w", err)
+	}
+
+	var result struct {
+		Subtitles []struct {
+			URL  string `json:"url"`
+			Code string `json:"code"`
+		} `json:"subtitles"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("piped: failed to parse subtitles response: %w", err)
+	}
+
+	for _, sub := range result.Subtitles {
+		if sub.Code == "en" || sub.Code == "en-US" {
+			trackBody, err := s.fetch(ctx, sub.URL)
+			if err != nil {
+				return "", fmt.Errorf("piped: failed to fetch subtitle track: %w", err)
+			}
+			return string(trackBody), nil
+		}
+	}
+	return "", fmt.Errorf("no English captions available for video: %s", videoID)
+}
+
+// pipedStream is the subset of a Piped relatedStreams entry this package
+// reads.
+type pipedStream struct {
+	URL          string `json:"url"` // e.g. "/watch?v=dQw4w9WgXcQ"
+	Title        string `json:"title"`
+	UploaderName string `json:"uploaderName"`
+	UploaderURL  string `json:"uploaderUrl"`
+	UploadedDate string `json:"uploadedDate"`
+	ThumbnailURL string `json:"thumbnail"`
+	Duration     int64  `json:"duration"`
+	Views        int64  `json:"views"`
+}
+
+func (v pipedStream) toVideo() ytapi.Video {
+	publishedAt, _ := time.Parse("2006-01-02", v.UploadedDate)
+	return ytapi.Video{
+		ID:              strings.TrimPrefix(v.URL, "/watch?v="),
+		Title:           v.Title,
+		ChannelTitle:    v.UploaderName,
+		ChannelID:       strings.TrimPrefix(v.UploaderURL, "/channel/"),
+		PublishedAt:     publishedAt,
+		ThumbnailURL:    v.ThumbnailURL,
+		DurationSeconds: v.Duration,
+		ViewCount:       v.Views,
+	}
+}
+
+// get performs one GET against the pooled Piped instances, round-robin
+// starting from the next instance in rotation and failing over to the
+// rest of the pool on a non-200 response or transport error.
+func (s *PipedSource) get(ctx context.Context, path string) ([]byte, error) {
+	start := int(atomic.AddUint32(&s.nextIdx, 1) - 1)
+
+	var lastErr error
+	for i := 0; i < len(s.instances); i++ {
+		instance := s.instances[(start+i)%len(s.instances)]
+		body, err := s.fetch(ctx, instance+path)
+		if err == nil {
+			return body, nil
+		}
+		if s.logger != nil {
+			s.logger.Warn("videosource: piped instance failed, trying next",
+				zap.String("instance", instance), zap.Error(err))
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("all %d piped instance(s) failed: %w", len(s.instances), lastErr)
+}
+
+func (s *PipedSource) fetch(ctx context.Context, fullURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s - %s", fullURL, resp.Status, string(body))
+	}
+	return body, nil
+}
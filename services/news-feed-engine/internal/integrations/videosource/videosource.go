@@ -0,0 +1,26 @@
+// Package videosource abstracts "get me this channel/video" behind a
+// VideoSource interface so the ingestion pipeline isn't hard-wired to
+// the YouTube Data API. InvidiousSource and PipedSource talk to
+// self-hosted/public frontend mirrors instead, and FallbackSource chains
+// multiple VideoSources with per-source circuit breaking, so a Data API
+// quota exhaustion (or an Invidious/Piped instance outage) degrades
+// rather than blocks ingestion.
+package videosource
+
+import (
+	"context"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/ytapi"
+)
+
+// VideoSource is the subset of integrations.YouTubeIntegration's Data
+// API surface that has an equivalent on Invidious and Piped.
+// *integrations.YouTubeIntegration already satisfies this interface
+// without modification, since its method set was the template for it.
+type VideoSource interface {
+	GetChannel(ctx context.Context, channelID string) (*ytapi.Channel, error)
+	GetChannelVideos(ctx context.Context, channelID string, maxResults int, publishedAfter *time.Time) ([]ytapi.Video, error)
+	GetVideoDetails(ctx context.Context, videoID string) (*ytapi.Video, error)
+	GetCaptions(ctx context.Context, videoID string) (string, error)
+}
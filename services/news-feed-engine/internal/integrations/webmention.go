@@ -0,0 +1,341 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// webmentionFetchMaxBytes bounds how much of a remote page we read while
+// looking for a webmention endpoint or verifying a backlink.
+const webmentionFetchMaxBytes = 2 << 20 // 2 MB
+
+// WebmentionRecord tracks one outbound webmention attempt, keyed by
+// (post_id, target) so re-sends on post updates are idempotent.
+type WebmentionRecord struct {
+	PostID      string    `json:"post_id" db:"post_id"`
+	Target      string    `json:"target" db:"target"`
+	Endpoint    string    `json:"endpoint" db:"endpoint"`
+	Status      string    `json:"status" db:"status"` // pending, sent, failed, unsupported
+	LastAttempt time.Time `json:"last_attempt" db:"last_attempt"`
+}
+
+// WebmentionStore persists outbound webmention attempts. Implemented by
+// internal/database against the webmention_out table.
+type WebmentionStore interface {
+	Upsert(ctx context.Context, rec WebmentionRecord) error
+	ListForPost(ctx context.Context, postID string) ([]WebmentionRecord, error)
+}
+
+// WebmentionIntegration implements the W3C Webmention protocol for
+// BlogIntegration: outbound discovery+delivery when a post is published or
+// updated, and an inbound handler that verifies and records mentions as
+// BlogComments.
+type WebmentionIntegration struct {
+	blog       *BlogIntegration
+	store      WebmentionStore
+	httpClient *http.Client
+	logger     *zap.Logger
+
+	mu      sync.Mutex
+	pending map[string]*WebmentionStatus // status URL token -> status
+}
+
+// WebmentionStatus is the state of an async incoming-webmention
+// verification, returned from the status URL handed back by Receive.
+type WebmentionStatus struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	State  string `json:"state"` // pending, verified, rejected
+	Error  string `json:"error,omitempty"`
+}
+
+// NewWebmentionIntegration creates a webmention adapter for blog.
+func NewWebmentionIntegration(blog *BlogIntegration, store WebmentionStore, logger *zap.Logger) *WebmentionIntegration {
+	return &WebmentionIntegration{
+		blog:       blog,
+		store:      store,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		logger:     logger,
+		pending:    make(map[string]*WebmentionStatus),
+	}
+}
+
+var absoluteHTTPURL = regexp.MustCompile(`^https?://`)
+
+var linkTagRe = regexp.MustCompile(`(?i)<link[^>]+rel=["']webmention["'][^>]*href=["']([^"']+)["']`)
+var linkRelHeaderRe = regexp.MustCompile(`(?i)<([^>]+)>;\s*rel=["']?webmention["']?`)
+
+// SendForPost walks post's rendered HTML, discovers webmention endpoints
+// for every absolute external link, and delivers a webmention for each.
+// It is idempotent across calls for the same post: re-sends on update
+// reuse the same (post_id, target) row rather than creating duplicates.
+func (w *WebmentionIntegration) SendForPost(ctx context.Context, post *BlogPost) error {
+	sourceURL := fmt.Sprintf("%s/blog/%s", w.blog.baseURL, post.Slug)
+	targets := extractExternalLinks(post.HTMLContent, w.blog.baseURL)
+
+	var failures []string
+	for _, target := range targets {
+		endpoint, err := w.discoverEndpoint(ctx, target)
+		rec := WebmentionRecord{PostID: post.ID, Target: target, LastAttempt: time.Now()}
+		if err != nil || endpoint == "" {
+			rec.Status = "unsupported"
+			_ = w.store.Upsert(ctx, rec)
+			continue
+		}
+		rec.Endpoint = endpoint
+
+		if err := w.sendWithRetry(ctx, endpoint, sourceURL, target); err != nil {
+			rec.Status = "failed"
+			failures = append(failures, fmt.Sprintf("%s: %v", target, err))
+		} else {
+			rec.Status = "sent"
+		}
+		if uerr := w.store.Upsert(ctx, rec); uerr != nil {
+			w.logger.Warn("failed to persist webmention attempt", zap.Error(uerr), zap.String("target", target))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("webmention delivery failed for %d target(s): %s", len(failures), strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+func (w *WebmentionIntegration) sendWithRetry(ctx context.Context, endpoint, source, target string) error {
+	const maxAttempts = 3
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(time.Duration(attempt) * 2 * time.Second):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if lastErr = w.send(ctx, endpoint, source, target); lastErr == nil {
+			return nil
+		}
+	}
+	return lastErr
+}
+
+func (w *WebmentionIntegration) send(ctx context.Context, endpoint, source, target string) error {
+	form := url.Values{"source": {source}, "target": {target}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webmention endpoint %s returned %s", endpoint, resp.Status)
+	}
+	return nil
+}
+
+// discoverEndpoint fetches target and looks for a webmention endpoint
+// advertised via a Link response header or a <link rel="webmention"> tag.
+func (w *WebmentionIntegration) discoverEndpoint(ctx context.Context, target string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if link := resp.Header.Get("Link"); link != "" {
+		if m := linkRelHeaderRe.FindStringSubmatch(link); m != nil {
+			return resolveRelative(target, m[1]), nil
+		}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, webmentionFetchMaxBytes))
+	if err != nil {
+		return "", err
+	}
+	if m := linkTagRe.FindSubmatch(body); m != nil {
+		return resolveRelative(target, string(m[1])), nil
+	}
+
+	return "", nil
+}
+
+func resolveRelative(base, ref string) string {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return ref
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return baseURL.ResolveReference(refURL).String()
+}
+
+// extractExternalLinks returns every absolute href in html that does not
+// point back at ownDomain.
+func extractExternalLinks(html, ownBaseURL string) []string {
+	hrefRe := regexp.MustCompile(`(?i)href=["']([^"']+)["']`)
+	seen := make(map[string]bool)
+	var links []string
+	for _, m := range hrefRe.FindAllStringSubmatch(html, -1) {
+		href := m[1]
+		if !absoluteHTTPURL.MatchString(href) || strings.HasPrefix(href, ownBaseURL) {
+			continue
+		}
+		if !seen[href] {
+			seen[href] = true
+			links = append(links, href)
+		}
+	}
+	return links
+}
+
+// Receive handles POST /webmention: it validates source/target, enqueues
+// asynchronous verification, and returns a 202-style status token the
+// caller can poll via Status.
+func (w *WebmentionIntegration) Receive(ctx context.Context, source, target string) (*WebmentionStatus, string, error) {
+	if !absoluteHTTPURL.MatchString(source) || !absoluteHTTPURL.MatchString(target) {
+		return nil, "", fmt.Errorf("source and target must be absolute http(s) URLs")
+	}
+	if source == target {
+		return nil, "", fmt.Errorf("source and target must differ")
+	}
+
+	status := &WebmentionStatus{Source: source, Target: target, State: "pending"}
+	token := fmt.Sprintf("%d-%s", time.Now().UnixNano(), shortHash(source+target))
+
+	w.mu.Lock()
+	w.pending[token] = status
+	w.mu.Unlock()
+
+	go w.verify(context.Background(), token, source, target)
+
+	return status, token, nil
+}
+
+// Status returns the current state of a previously-received webmention.
+func (w *WebmentionIntegration) Status(token string) (*WebmentionStatus, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	s, ok := w.pending[token]
+	return s, ok
+}
+
+// verify fetches source, confirms it actually links to target, and if so
+// records a BlogComment against the matching post.
+func (w *WebmentionIntegration) verify(ctx context.Context, token, source, target string) {
+	status := func(state, errMsg string) {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if s, ok := w.pending[token]; ok {
+			s.State = state
+			s.Error = errMsg
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		status("rejected", err.Error())
+		return
+	}
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		status("rejected", err.Error())
+		return
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, webmentionFetchMaxBytes))
+	if err != nil {
+		status("rejected", err.Error())
+		return
+	}
+	if !strings.Contains(string(body), target) {
+		status("rejected", "source does not link to target")
+		return
+	}
+
+	postID := postIDFromURL(target, w.blog.baseURL)
+	if postID == "" {
+		status("rejected", "target is not a known post")
+		return
+	}
+
+	mf2 := extractMF2(string(body))
+	comment := &BlogComment{
+		PostID:    postID,
+		UserName:  mf2.author,
+		Content:   mf2.content,
+		CreatedAt: time.Now(),
+		Type:      mf2.mentionType,
+	}
+	if _, err := w.blog.CreateComment(ctx, comment); err != nil {
+		status("rejected", fmt.Sprintf("failed to record comment: %v", err))
+		return
+	}
+	w.logger.Info("recorded webmention as comment",
+		zap.String("post_id", comment.PostID), zap.String("type", mf2.mentionType), zap.String("source", source))
+
+	status("verified", "")
+}
+
+type mf2Summary struct {
+	author      string
+	content     string
+	mentionType string // reply, like, repost, mention
+}
+
+// extractMF2 does a best-effort scrape of h-card/h-entry microformat
+// properties out of a webmention source page. A full MF2 parser is out of
+// scope here; this covers the common reply/like/repost shapes editors
+// like to Micropub actually emit.
+func extractMF2(html string) mf2Summary {
+	summary := mf2Summary{mentionType: "mention"}
+
+	if m := regexp.MustCompile(`(?i)class=["'][^"']*p-author[^"']*["'][^>]*>([^<]+)<`).FindStringSubmatch(html); m != nil {
+		summary.author = strings.TrimSpace(m[1])
+	}
+	if m := regexp.MustCompile(`(?i)class=["'][^"']*e-content[^"']*["'][^>]*>([^<]+)<`).FindStringSubmatch(html); m != nil {
+		summary.content = strings.TrimSpace(m[1])
+	}
+
+	switch {
+	case strings.Contains(html, "u-like-of"):
+		summary.mentionType = "like"
+	case strings.Contains(html, "u-repost-of"):
+		summary.mentionType = "repost"
+	case strings.Contains(html, "u-in-reply-to"):
+		summary.mentionType = "reply"
+	}
+
+	return summary
+}
+
+func shortHash(s string) string {
+	var h uint32 = 2166136261
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	return fmt.Sprintf("%x", h)
+}
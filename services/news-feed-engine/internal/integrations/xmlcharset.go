@@ -0,0 +1,157 @@
+package integrations
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// decodeXML decodes an RSS/Atom/RDF document into v, honoring the
+// encoding declared in its XML prolog (legacy feeds are still commonly
+// windows-1252 or iso-8859-1) and scrubbing bytes that are outright
+// invalid in XML 1.0 first, since either one otherwise turns into a
+// hard parse failure on the scraped feeds that actually have them.
+func decodeXML(data []byte, v any) error {
+	dec := xml.NewDecoder(bytes.NewReader(scrubInvalidXMLBytes(data)))
+	dec.CharsetReader = charsetReader
+	return dec.Decode(v)
+}
+
+// charsetReader implements xml.Decoder's CharsetReader hook. This
+// module has no charset conversion library vendored (the equivalent of
+// golang.org/x/net/html/charset would be x/text/encoding), so it only
+// hand-covers the single-byte legacy encodings still seen in the wild
+// on older sites - windows-1252 and iso-8859-1/latin1 - by mapping
+// bytes straight to their Unicode code points. Multi-byte legacy
+// encodings (gb2312, shift-jis, euc-kr, ...) aren't covered: input is
+// passed through unconverted, so a feed declaring one of those will
+// likely still fail to parse or decode with mojibake rather than
+// erroring outright.
+func charsetReader(label string, input io.Reader) (io.Reader, error) {
+	switch normalizeCharsetLabel(label) {
+	case "", "utf-8", "us-ascii", "ascii":
+		return input, nil
+	case "iso-8859-1", "latin1":
+		return singleByteReader(input, latin1ToRune), nil
+	case "windows-1252", "cp1252":
+		return singleByteReader(input, windows1252ToRune), nil
+	default:
+		return input, nil
+	}
+}
+
+func normalizeCharsetLabel(label string) string {
+	return strings.ToLower(strings.TrimSpace(label))
+}
+
+// singleByteReader re-encodes input (single-byte encoded text, one
+// byte per code point) as UTF-8 by mapping each byte through toRune.
+func singleByteReader(input io.Reader, toRune func(byte) rune) io.Reader {
+	raw, err := io.ReadAll(input)
+	if err != nil {
+		return bytes.NewReader(nil)
+	}
+	var buf bytes.Buffer
+	buf.Grow(len(raw))
+	for _, b := range raw {
+		buf.WriteRune(toRune(b))
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+// latin1ToRune maps an iso-8859-1 byte to its Unicode code point: every
+// byte value is already that code point by construction.
+func latin1ToRune(b byte) rune {
+	return rune(b)
+}
+
+// windows1252ToRune maps a windows-1252 byte to its Unicode code point.
+// It's identical to latin1 except for 0x80-0x9F, which windows-1252
+// assigns to printable characters (smart quotes, dashes, the euro
+// sign, ...) instead of the C1 control range iso-8859-1 leaves there.
+func windows1252ToRune(b byte) rune {
+	if r, ok := windows1252HighBytes[b]; ok {
+		return r
+	}
+	return rune(b)
+}
+
+var windows1252HighBytes = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x83: 'ƒ', 0x84: '„',
+	0x85: '…', 0x86: '†', 0x87: '‡', 0x88: 'ˆ',
+	0x89: '‰', 0x8A: 'Š', 0x8B: '‹', 0x8C: 'Œ',
+	0x8E: 'Ž', 0x91: '‘', 0x92: '’', 0x93: '“',
+	0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—',
+	0x98: '˜', 0x99: '™', 0x9A: 'š', 0x9B: '›',
+	0x9C: 'œ', 0x9E: 'ž', 0x9F: 'Ÿ',
+}
+
+// numericCharRef matches decimal/hex numeric character references
+// (&#55296; / &#xD800;), so scrubInvalidXMLBytes can drop the ones that
+// resolve to an XML 1.0-invalid code point.
+var numericCharRef = regexp.MustCompile(`&#x?[0-9a-fA-F]+;`)
+
+// scrubInvalidXMLBytes drops bytes/character references that are
+// outright invalid in XML 1.0 and would otherwise abort the whole
+// parse: control characters other than tab/LF/CR, and numeric
+// character references resolving to an unpaired surrogate
+// (U+D800-U+DFFF) or another invalid code point. These are single-byte
+// ASCII values in every encoding this package handles (UTF-8,
+// iso-8859-1, windows-1252), so it's safe to scrub before charset
+// conversion rather than after.
+func scrubInvalidXMLBytes(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	for _, b := range data {
+		if b < 0x20 && b != '\t' && b != '\n' && b != '\r' {
+			continue
+		}
+		if b == 0x7F {
+			continue
+		}
+		out = append(out, b)
+	}
+	return numericCharRef.ReplaceAllFunc(out, func(ref []byte) []byte {
+		if isInvalidXMLCharRef(string(ref)) {
+			return nil
+		}
+		return ref
+	})
+}
+
+func isInvalidXMLCharRef(ref string) bool {
+	body := ref[2 : len(ref)-1] // strip "&#" and ";"
+	base := 10
+	if len(body) > 0 && (body[0] == 'x' || body[0] == 'X') {
+		base = 16
+		body = body[1:]
+	}
+	code, err := strconv.ParseInt(body, base, 32)
+	if err != nil {
+		return true
+	}
+	return isInvalidXMLChar(rune(code))
+}
+
+// isInvalidXMLChar reports whether r is not a legal XML 1.0 character
+// per https://www.w3.org/TR/xml/#charsets: control characters other
+// than tab/LF/CR, unpaired surrogates, and the two noncharacters at the
+// end of the Basic Multilingual Plane.
+func isInvalidXMLChar(r rune) bool {
+	switch {
+	case r == 0x9 || r == 0xA || r == 0xD:
+		return false
+	case r < 0x20:
+		return true
+	case r >= 0xD800 && r <= 0xDFFF:
+		return true
+	case r == 0xFFFE || r == 0xFFFF:
+		return true
+	case r > 0x10FFFF:
+		return true
+	default:
+		return false
+	}
+}
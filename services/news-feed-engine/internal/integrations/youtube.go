@@ -2,55 +2,63 @@ package integrations
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/ytapi"
 )
 
-// YouTubeIntegration handles YouTube Data API interactions
+// YouTubeIntegration is the service's entry point for everything
+// YouTube: Data API access (delegated to ytapi.Client, which owns quota
+// tracking, key pooling, and retries) and PubSubHubbub push-subscription
+// management, which talks to pubsubhubbub.appspot.com rather than the
+// Data API and so isn't quota-metered.
 type YouTubeIntegration struct {
-	apiKey     string
+	api        *ytapi.Client
 	httpClient *http.Client
 	logger     *zap.Logger
-}
 
-// YouTubeVideo represents a YouTube video
-type YouTubeVideo struct {
-	ID           string    `json:"id"`
-	Title        string    `json:"title"`
-	Description  string    `json:"description"`
-	ChannelID    string    `json:"channel_id"`
-	ChannelTitle string    `json:"channel_title"`
-	PublishedAt  time.Time `json:"published_at"`
-	ThumbnailURL string    `json:"thumbnail_url"`
-	Duration     string    `json:"duration"`
-	ViewCount    int64     `json:"view_count"`
-	LikeCount    int64     `json:"like_count"`
-	CommentCount int64     `json:"comment_count"`
-	Tags         []string  `json:"tags"`
-}
+	// PubSubHubbub push-subscription state, set by WithPubSub. Both are
+	// zero-valued until then, in which case SubscribeChannel/
+	// UnsubscribeChannel/RenewExpiring are no-ops.
+	subscriptions     PubSubSubscriptionStore
+	pubsubCallbackURL string
 
-// YouTubeChannel represents a YouTube channel
-type YouTubeChannel struct {
-	ID              string `json:"id"`
-	Title           string `json:"title"`
-	Description     string `json:"description"`
-	CustomURL       string `json:"custom_url"`
-	ThumbnailURL    string `json:"thumbnail_url"`
-	SubscriberCount int64  `json:"subscriber_count"`
-	VideoCount      int64  `json:"video_count"`
-	ViewCount       int64  `json:"view_count"`
+	// ytdlp enables yt-dlp-based enrichment, set by WithYTDLPFetcher. Nil
+	// until then, in which case GetVideoDetails returns Data API fields
+	// only and GetCaptions falls back to reporting track availability.
+	ytdlp *YTDLPFetcher
 }
 
-// NewYouTubeIntegration creates a new YouTube integration
+// YouTubeVideo is an alias for ytapi.Video, kept so existing callers
+// (ingestion.YouTubeChannelReader, handlers) don't need to import ytapi
+// directly for a type this package has always exposed.
+type YouTubeVideo = ytapi.Video
+
+// YouTubeChannel is an alias for ytapi.Channel; see YouTubeVideo.
+type YouTubeChannel = ytapi.Channel
+
+// NewYouTubeIntegration creates a YouTube integration backed by a single
+// API key with no daily quota cap. Use NewYouTubeIntegrationWithPool for
+// multiple pooled keys and/or a per-key daily budget.
 func NewYouTubeIntegration(apiKey string, logger *zap.Logger) *YouTubeIntegration {
+	return NewYouTubeIntegrationWithPool([]string{apiKey}, 0, logger)
+}
+
+// NewYouTubeIntegrationWithPool creates a YouTube integration backed by
+// ytapi.Client, pooling apiKeys with round-robin failover and capping
+// each key's Data API consumption at dailyQuotaBudget units per day (0
+// means unlimited).
+func NewYouTubeIntegrationWithPool(apiKeys []string, dailyQuotaBudget int, logger *zap.Logger) *YouTubeIntegration {
 	return &YouTubeIntegration{
-		apiKey: apiKey,
+		api: ytapi.NewClient(apiKeys, dailyQuotaBudget, logger),
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -58,372 +66,272 @@ func NewYouTubeIntegration(apiKey string, logger *zap.Logger) *YouTubeIntegratio
 	}
 }
 
-// GetChannel retrieves channel information by channel ID
+// GetChannel retrieves channel information by channel ID.
 func (y *YouTubeIntegration) GetChannel(ctx context.Context, channelID string) (*YouTubeChannel, error) {
-	params := url.Values{
-		"part": {"snippet,statistics"},
-		"id":   {channelID},
-		"key":  {y.apiKey},
-	}
-
-	resp, err := y.makeRequest(ctx, "channels", params)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get channel: %w", err)
-	}
-
-	var result struct {
-		Items []struct {
-			ID      string `json:"id"`
-			Snippet struct {
-				Title       string `json:"title"`
-				Description string `json:"description"`
-				CustomURL   string `json:"customUrl"`
-				Thumbnails  struct {
-					High struct {
-						URL string `json:"url"`
-					} `json:"high"`
-				} `json:"thumbnails"`
-			} `json:"snippet"`
-			Statistics struct {
-				SubscriberCount string `json:"subscriberCount"`
-				VideoCount      string `json:"videoCount"`
-				ViewCount       string `json:"viewCount"`
-			} `json:"statistics"`
-		} `json:"items"`
-	}
-
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse channel response: %w", err)
-	}
-
-	if len(result.Items) == 0 {
-		return nil, fmt.Errorf("channel not found: %s", channelID)
-	}
-
-	item := result.Items[0]
-	return &YouTubeChannel{
-		ID:              item.ID,
-		Title:           item.Snippet.Title,
-		Description:     item.Snippet.Description,
-		CustomURL:       item.Snippet.CustomURL,
-		ThumbnailURL:    item.Snippet.Thumbnails.High.URL,
-		SubscriberCount: parseInt64(item.Statistics.SubscriberCount),
-		VideoCount:      parseInt64(item.Statistics.VideoCount),
-		ViewCount:       parseInt64(item.Statistics.ViewCount),
-	}, nil
+	return y.api.GetChannel(ctx, channelID)
 }
 
-// GetChannelVideos retrieves recent videos from a channel
+// GetChannelVideos retrieves recent videos from a channel.
 func (y *YouTubeIntegration) GetChannelVideos(ctx context.Context, channelID string, maxResults int, publishedAfter *time.Time) ([]YouTubeVideo, error) {
-	// First, get the uploads playlist ID
-	params := url.Values{
-		"part": {"contentDetails"},
-		"id":   {channelID},
-		"key":  {y.apiKey},
-	}
-
-	resp, err := y.makeRequest(ctx, "channels", params)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get channel details: %w", err)
-	}
+	return y.api.GetChannelVideos(ctx, channelID, maxResults, publishedAfter)
+}
 
-	var channelResult struct {
-		Items []struct {
-			ContentDetails struct {
-				RelatedPlaylists struct {
-					Uploads string `json:"uploads"`
-				} `json:"relatedPlaylists"`
-			} `json:"contentDetails"`
-		} `json:"items"`
-	}
+// GetChannelVideosPage retrieves one page of a channel's uploads
+// playlist, starting at pageToken (empty for the first page), returning
+// the videos on that page and the token for the next one ("" once
+// exhausted). Unlike GetChannelVideos this does not enrich
+// durations/stats or filter by date, since historical backfill callers
+// page through the full history and only need enough metadata to
+// enqueue a per-item ingestion job.
+func (y *YouTubeIntegration) GetChannelVideosPage(ctx context.Context, channelID, pageToken string) ([]YouTubeVideo, string, error) {
+	return y.api.GetChannelVideosPage(ctx, channelID, pageToken)
+}
 
-	if err := json.Unmarshal(resp, &channelResult); err != nil {
-		return nil, fmt.Errorf("failed to parse channel details: %w", err)
+// GetVideoDetails retrieves detailed information for a single video. If
+// WithYTDLPFetcher has been called, it also enriches the result with
+// fields the Data API can't cheaply provide (full description, chapter
+// markers, category, availability/age-gate flags, live-stream status,
+// and transcript); a yt-dlp failure is logged and otherwise ignored, so
+// callers still get the Data API fields.
+func (y *YouTubeIntegration) GetVideoDetails(ctx context.Context, videoID string) (*YouTubeVideo, error) {
+	video, err := y.api.GetVideoDetails(ctx, videoID)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(channelResult.Items) == 0 {
-		return nil, fmt.Errorf("channel not found: %s", channelID)
+	if y.ytdlp != nil {
+		enriched, err := y.ytdlp.Fetch(ctx, videoID)
+		if err != nil {
+			y.logger.Warn("yt-dlp enrichment failed, returning Data API fields only",
+				zap.String("video_id", videoID), zap.Error(err))
+			return video, nil
+		}
+		video.Description = enriched.Description
+		video.Chapters = enriched.Chapters
+		video.Category = enriched.Category
+		video.IsAgeRestricted = enriched.IsAgeRestricted
+		video.IsUnavailable = enriched.IsUnavailable
+		video.LiveStatus = enriched.LiveStatus
+		video.Transcript = enriched.Transcript
 	}
 
-	uploadsPlaylistID := channelResult.Items[0].ContentDetails.RelatedPlaylists.Uploads
+	return video, nil
+}
 
-	// Get videos from uploads playlist
-	params = url.Values{
-		"part":       {"snippet"},
-		"playlistId": {uploadsPlaylistID},
-		"maxResults": {fmt.Sprintf("%d", maxResults)},
-		"key":        {y.apiKey},
+// GetCaptions returns the English transcript for a video. With
+// WithYTDLPFetcher enabled, this is the actual auto-generated caption
+// text; otherwise it falls back to reporting which caption track is
+// available, since downloading the actual content through the Data API
+// requires OAuth2 this integration doesn't hold (see
+// ytapi.Client.ListCaptions for the full track list).
+func (y *YouTubeIntegration) GetCaptions(ctx context.Context, videoID string) (string, error) {
+	if y.ytdlp != nil {
+		video, err := y.ytdlp.Fetch(ctx, videoID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get captions: %w", err)
+		}
+		if video.Transcript == "" {
+			return "", fmt.Errorf("no English captions available for video: %s", videoID)
+		}
+		return video.Transcript, nil
 	}
 
-	resp, err = y.makeRequest(ctx, "playlistItems", params)
+	tracks, err := y.api.ListCaptions(ctx, videoID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get playlist items: %w", err)
-	}
-
-	var playlistResult struct {
-		Items []struct {
-			Snippet struct {
-				ResourceID struct {
-					VideoID string `json:"videoId"`
-				} `json:"resourceId"`
-				Title        string    `json:"title"`
-				Description  string    `json:"description"`
-				ChannelID    string    `json:"channelId"`
-				ChannelTitle string    `json:"channelTitle"`
-				PublishedAt  time.Time `json:"publishedAt"`
-				Thumbnails   struct {
-					High struct {
-						URL string `json:"url"`
-					} `json:"high"`
-				} `json:"thumbnails"`
-			} `json:"snippet"`
-		} `json:"items"`
-	}
-
-	if err := json.Unmarshal(resp, &playlistResult); err != nil {
-		return nil, fmt.Errorf("failed to parse playlist items: %w", err)
-	}
-
-	videos := make([]YouTubeVideo, 0, len(playlistResult.Items))
-	for _, item := range playlistResult.Items {
-		if publishedAfter != nil && item.Snippet.PublishedAt.Before(*publishedAfter) {
-			continue
-		}
-		videos = append(videos, YouTubeVideo{
-			ID:           item.Snippet.ResourceID.VideoID,
-			Title:        item.Snippet.Title,
-			Description:  item.Snippet.Description,
-			ChannelID:    item.Snippet.ChannelID,
-			ChannelTitle: item.Snippet.ChannelTitle,
-			PublishedAt:  item.Snippet.PublishedAt,
-			ThumbnailURL: item.Snippet.Thumbnails.High.URL,
-		})
+		return "", fmt.Errorf("failed to get captions: %w", err)
 	}
 
-	// Get video details (duration, stats)
-	if len(videos) > 0 {
-		videoIDs := make([]string, len(videos))
-		for i, v := range videos {
-			videoIDs[i] = v.ID
-		}
-		videos, err = y.enrichVideoDetails(ctx, videos)
-		if err != nil {
-			y.logger.Warn("Failed to enrich video details", zap.Error(err))
+	for _, track := range tracks {
+		if track.Language == "en" || track.Language == "en-US" {
+			return fmt.Sprintf("Caption track available: %s", track.ID), nil
 		}
 	}
 
-	return videos, nil
+	return "", fmt.Errorf("no English captions available for video: %s", videoID)
 }
 
-// GetVideoDetails retrieves detailed information for a single video
-func (y *YouTubeIntegration) GetVideoDetails(ctx context.Context, videoID string) (*YouTubeVideo, error) {
-	params := url.Values{
-		"part": {"snippet,contentDetails,statistics"},
-		"id":   {videoID},
-		"key":  {y.apiKey},
-	}
+const (
+	// pubsubHubbubHubURL is the public Google-hosted hub every YouTube
+	// channel's upload feed is published through.
+	pubsubHubbubHubURL = "https://pubsubhubbub.appspot.com/subscribe"
+	// youtubeFeedURL is the Atom feed PubSubHubbub notifications are
+	// delivered for, parameterized by channel_id.
+	youtubeFeedURL = "https://www.youtube.com/xml/feeds/videos.xml"
+	// defaultLeaseSeconds is what SubscribeChannel requests; the hub may
+	// grant a shorter lease, reported back on its verification callback
+	// (see ConfirmSubscription).
+	defaultLeaseSeconds = 5 * 24 * 60 * 60 // 5 days
+)
 
-	resp, err := y.makeRequest(ctx, "videos", params)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get video details: %w", err)
-	}
+// PubSubSubscription tracks a PubSubHubbub lease for one YouTube channel,
+// so RenewExpiring can re-subscribe before the hub drops it.
+type PubSubSubscription struct {
+	ChannelID    string    `db:"channel_id"`
+	Secret       string    `db:"secret"`
+	LeaseSeconds int       `db:"lease_seconds"`
+	ExpiresAt    time.Time `db:"expires_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
 
-	var result struct {
-		Items []struct {
-			ID      string `json:"id"`
-			Snippet struct {
-				Title        string    `json:"title"`
-				Description  string    `json:"description"`
-				ChannelID    string    `json:"channelId"`
-				ChannelTitle string    `json:"channelTitle"`
-				PublishedAt  time.Time `json:"publishedAt"`
-				Tags         []string  `json:"tags"`
-				Thumbnails   struct {
-					High struct {
-						URL string `json:"url"`
-					} `json:"high"`
-				} `json:"thumbnails"`
-			} `json:"snippet"`
-			ContentDetails struct {
-				Duration string `json:"duration"`
-			} `json:"contentDetails"`
-			Statistics struct {
-				ViewCount    string `json:"viewCount"`
-				LikeCount    string `json:"likeCount"`
-				CommentCount string `json:"commentCount"`
-			} `json:"statistics"`
-		} `json:"items"`
-	}
+// PubSubSubscriptionStore persists PubSubHubbub subscription leases.
+// Implemented by internal/database.SubscriptionRepository against the
+// youtube_subscriptions table.
+type PubSubSubscriptionStore interface {
+	Get(ctx context.Context, channelID string) (*PubSubSubscription, error)
+	Save(ctx context.Context, sub *PubSubSubscription) error
+	Delete(ctx context.Context, channelID string) error
+	ListExpiringBefore(ctx context.Context, cutoff time.Time) ([]PubSubSubscription, error)
+}
 
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse video details: %w", err)
-	}
+// WithPubSub enables SubscribeChannel, UnsubscribeChannel, and
+// RenewExpiring by giving the integration somewhere to persist
+// subscription leases and the publicly-reachable callback URL the hub
+// should push notifications to (must route to the service's
+// POST /api/v1/webhooks/youtube handler).
+func (y *YouTubeIntegration) WithPubSub(store PubSubSubscriptionStore, callbackURL string) *YouTubeIntegration {
+	y.subscriptions = store
+	y.pubsubCallbackURL = callbackURL
+	return y
+}
 
-	if len(result.Items) == 0 {
-		return nil, fmt.Errorf("video not found: %s", videoID)
-	}
+// WithYTDLPFetcher enables yt-dlp-based enrichment of GetVideoDetails
+// and makes it the transcript source for GetCaptions. Gated behind
+// config.EnableYTDLPEnrichment since it shells out per call instead of
+// hitting the Data API.
+func (y *YouTubeIntegration) WithYTDLPFetcher(fetcher *YTDLPFetcher) *YouTubeIntegration {
+	y.ytdlp = fetcher
+	return y
+}
 
-	item := result.Items[0]
-	return &YouTubeVideo{
-		ID:           item.ID,
-		Title:        item.Snippet.Title,
-		Description:  item.Snippet.Description,
-		ChannelID:    item.Snippet.ChannelID,
-		ChannelTitle: item.Snippet.ChannelTitle,
-		PublishedAt:  item.Snippet.PublishedAt,
-		ThumbnailURL: item.Snippet.Thumbnails.High.URL,
-		Duration:     item.ContentDetails.Duration,
-		ViewCount:    parseInt64(item.Statistics.ViewCount),
-		LikeCount:    parseInt64(item.Statistics.LikeCount),
-		CommentCount: parseInt64(item.Statistics.CommentCount),
-		Tags:         item.Snippet.Tags,
-	}, nil
+// SubscribeChannel asks the PubSubHubbub hub to push real-time
+// notifications for channelID's upload feed to this integration's
+// callback URL, replacing BackfillRunner-style polling with push
+// ingestion. The hub verifies the request asynchronously (hub.verify=async)
+// by GETing the callback with a hub.challenge, which
+// handlers.WebhookHandler.YouTubeWebhook echoes back.
+func (y *YouTubeIntegration) SubscribeChannel(ctx context.Context, channelID string) error {
+	return y.callHub(ctx, "subscribe", channelID)
 }
 
-// GetCaptions retrieves video captions/transcript
-func (y *YouTubeIntegration) GetCaptions(ctx context.Context, videoID string) (string, error) {
-	// Note: This requires OAuth2 for third-party captions
-	// For now, we'll use a workaround with the timedtext API
-	params := url.Values{
-		"part":    {"snippet"},
-		"videoId": {videoID},
-		"key":     {y.apiKey},
+// UnsubscribeChannel asks the hub to stop pushing notifications for
+// channelID and forgets its tracked lease.
+func (y *YouTubeIntegration) UnsubscribeChannel(ctx context.Context, channelID string) error {
+	if err := y.callHub(ctx, "unsubscribe", channelID); err != nil {
+		return err
 	}
-
-	resp, err := y.makeRequest(ctx, "captions", params)
-	if err != nil {
-		return "", fmt.Errorf("failed to get captions: %w", err)
+	if y.subscriptions != nil {
+		return y.subscriptions.Delete(ctx, channelID)
 	}
+	return nil
+}
 
-	var result struct {
-		Items []struct {
-			ID      string `json:"id"`
-			Snippet struct {
-				Language     string `json:"language"`
-				TrackKind    string `json:"trackKind"`
-				IsAutoSynced bool   `json:"isAutoSynced"`
-			} `json:"snippet"`
-		} `json:"items"`
+// ConfirmSubscription records the lease duration the hub actually granted
+// for channelID, as reported on its asynchronous verification GET
+// callback - the hub isn't required to honor the lease_seconds
+// SubscribeChannel requested, so RenewExpiring needs the real value to
+// avoid renewing too late.
+func (y *YouTubeIntegration) ConfirmSubscription(ctx context.Context, channelID string, leaseSeconds int) error {
+	if y.subscriptions == nil || leaseSeconds <= 0 {
+		return nil
 	}
-
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return "", fmt.Errorf("failed to parse captions response: %w", err)
+	sub, err := y.subscriptions.Get(ctx, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to load subscription: %w", err)
 	}
-
-	// Find English caption track
-	for _, item := range result.Items {
-		if item.Snippet.Language == "en" || item.Snippet.Language == "en-US" {
-			// Would need OAuth2 to download the actual caption content
-			return fmt.Sprintf("Caption track available: %s", item.ID), nil
-		}
+	if sub == nil {
+		return nil
 	}
-
-	return "", fmt.Errorf("no English captions available for video: %s", videoID)
+	sub.LeaseSeconds = leaseSeconds
+	sub.ExpiresAt = time.Now().Add(time.Duration(leaseSeconds) * time.Second)
+	sub.UpdatedAt = time.Now()
+	return y.subscriptions.Save(ctx, sub)
 }
 
-// enrichVideoDetails adds duration and statistics to video list
-func (y *YouTubeIntegration) enrichVideoDetails(ctx context.Context, videos []YouTubeVideo) ([]YouTubeVideo, error) {
-	videoIDs := ""
-	for i, v := range videos {
-		if i > 0 {
-			videoIDs += ","
-		}
-		videoIDs += v.ID
-	}
-
-	params := url.Values{
-		"part": {"contentDetails,statistics"},
-		"id":   {videoIDs},
-		"key":  {y.apiKey},
+// RenewExpiring re-subscribes every tracked channel whose lease expires
+// within renewBefore, returning how many were renewed. Intended to be
+// called periodically by a background process (see cmd/news-feed/main.go's
+// "youtube-pubsub-renewer"), mirroring the video-lease-reaper pattern.
+func (y *YouTubeIntegration) RenewExpiring(ctx context.Context, renewBefore time.Duration) (int, error) {
+	if y.subscriptions == nil {
+		return 0, nil
 	}
-
-	resp, err := y.makeRequest(ctx, "videos", params)
+	expiring, err := y.subscriptions.ListExpiringBefore(ctx, time.Now().Add(renewBefore))
 	if err != nil {
-		return videos, err
+		return 0, fmt.Errorf("failed to list expiring pubsubhubbub subscriptions: %w", err)
 	}
 
-	var result struct {
-		Items []struct {
-			ID             string `json:"id"`
-			ContentDetails struct {
-				Duration string `json:"duration"`
-			} `json:"contentDetails"`
-			Statistics struct {
-				ViewCount    string `json:"viewCount"`
-				LikeCount    string `json:"likeCount"`
-				CommentCount string `json:"commentCount"`
-			} `json:"statistics"`
-		} `json:"items"`
+	renewed := 0
+	for _, sub := range expiring {
+		if err := y.SubscribeChannel(ctx, sub.ChannelID); err != nil {
+			y.logger.Warn("failed to renew pubsubhubbub subscription",
+				zap.String("channel_id", sub.ChannelID), zap.Error(err))
+			continue
+		}
+		renewed++
 	}
+	return renewed, nil
+}
 
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return videos, err
+// callHub performs the subscribe/unsubscribe POST against the
+// PubSubHubbub hub and, for a successful subscribe, persists the
+// requested lease so RenewExpiring knows to follow up on it.
+func (y *YouTubeIntegration) callHub(ctx context.Context, mode, channelID string) error {
+	if y.pubsubCallbackURL == "" {
+		return fmt.Errorf("pubsubhubbub callback URL not configured")
 	}
 
-	// Create a map for quick lookup
-	detailsMap := make(map[string]struct {
-		Duration     string
-		ViewCount    string
-		LikeCount    string
-		CommentCount string
-	})
-	for _, item := range result.Items {
-		detailsMap[item.ID] = struct {
-			Duration     string
-			ViewCount    string
-			LikeCount    string
-			CommentCount string
-		}{
-			Duration:     item.ContentDetails.Duration,
-			ViewCount:    item.Statistics.ViewCount,
-			LikeCount:    item.Statistics.LikeCount,
-			CommentCount: item.Statistics.CommentCount,
+	var secret string
+	if mode == "subscribe" {
+		existing, err := y.subscriptions.Get(ctx, channelID)
+		if err != nil {
+			return fmt.Errorf("failed to load existing subscription: %w", err)
 		}
-	}
-
-	// Enrich videos
-	for i := range videos {
-		if details, ok := detailsMap[videos[i].ID]; ok {
-			videos[i].Duration = details.Duration
-			videos[i].ViewCount = parseInt64(details.ViewCount)
-			videos[i].LikeCount = parseInt64(details.LikeCount)
-			videos[i].CommentCount = parseInt64(details.CommentCount)
+		if existing != nil {
+			secret = existing.Secret
+		} else {
+			secret = uuid.New().String()
 		}
 	}
 
-	return videos, nil
-}
-
-// makeRequest makes an HTTP request to YouTube API
-func (y *YouTubeIntegration) makeRequest(ctx context.Context, endpoint string, params url.Values) ([]byte, error) {
-	apiURL := fmt.Sprintf("https://www.googleapis.com/youtube/v3/%s?%s", endpoint, params.Encode())
+	form := url.Values{
+		"hub.mode":     {mode},
+		"hub.topic":    {youtubeFeedURL + "?channel_id=" + channelID},
+		"hub.callback": {y.pubsubCallbackURL},
+		"hub.verify":   {"async"},
+	}
+	if secret != "" {
+		form.Set("hub.secret", secret)
+	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, pubsubHubbubHubURL, strings.NewReader(form.Encode()))
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to build pubsubhubbub request: %w", err)
 	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	resp, err := y.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("pubsubhubbub %s request failed: %w", mode, err)
 	}
 	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("pubsubhubbub %s rejected: %s - %s", mode, resp.Status, string(body))
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("YouTube API error: %s - %s", resp.Status, string(body))
+	if mode == "subscribe" && y.subscriptions != nil {
+		sub := &PubSubSubscription{
+			ChannelID:    channelID,
+			Secret:       secret,
+			LeaseSeconds: defaultLeaseSeconds,
+			ExpiresAt:    time.Now().Add(defaultLeaseSeconds * time.Second),
+			UpdatedAt:    time.Now(),
+		}
+		if err := y.subscriptions.Save(ctx, sub); err != nil {
+			return fmt.Errorf("failed to persist subscription lease: %w", err)
+		}
 	}
 
-	return body, nil
-}
-
-// Helper function to parse int64 from string
-func parseInt64(s string) int64 {
-	var result int64
-	fmt.Sscanf(s, "%d", &result)
-	return result
+	return nil
 }
@@ -0,0 +1,153 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// BackfillOptions configures a single YouTubeIntegration.BackfillChannel
+// job. All fields are optional; the zero value backfills the full
+// channel history with the client's default page size and no quota cap.
+type BackfillOptions struct {
+	// PageSize is how many items playlistItems.list requests per page
+	// (the API caps this at 50); <= 0 uses the API default.
+	PageSize int
+
+	// BatchSize is how many videos BackfillChannel buffers before
+	// calling emit, so a caller publishing to Kafka doesn't get one
+	// call per single video. <= 0 emits one page at a time.
+	BatchSize int
+
+	// QuotaBudget caps the number of playlistItems.list pages (1 quota
+	// unit each) this job may fetch before stopping early; 0 means
+	// unlimited. This bounds a single job's spend and is independent of
+	// ytapi.Client's own per-key daily budget.
+	QuotaBudget int
+
+	// StopAt, if non-zero, stops the crawl once it reaches a video
+	// published before this time. playlistItems.list returns newest
+	// first, so this is a lower bound on how far back the job goes.
+	StopAt time.Time
+
+	// StopAtVideoID, if non-empty, stops the crawl once this video ID
+	// is reached (that video is included in the final batch emitted).
+	StopAtVideoID string
+}
+
+// BackfillCheckpoint is BackfillChannel's persisted progress for one
+// channel, resumable after a process restart or a job re-triggered by an
+// operator.
+type BackfillCheckpoint struct {
+	ChannelID       string    `db:"channel_id"`
+	LastPageToken   string    `db:"last_page_token"`
+	LastVideoID     string    `db:"last_video_id"`
+	LastPublishedAt time.Time `db:"last_published_at"`
+	Done            bool      `db:"done"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+// BackfillCheckpointRepository persists BackfillChannel progress.
+// Implemented by internal/database against the backfill_checkpoints
+// table.
+type BackfillCheckpointRepository interface {
+	Get(ctx context.Context, channelID string) (*BackfillCheckpoint, error)
+	Save(ctx context.Context, checkpoint *BackfillCheckpoint) error
+}
+
+// BackfillChannel walks channelID's uploads playlist page by page via
+// playlistItems.list, starting from any checkpoint already persisted for
+// it, until the playlist is exhausted or opts.StopAt/StopAtVideoID is
+// reached. Videos are handed to emit in batches of opts.BatchSize;
+// passing a Kafka-publishing emit lets a caller route them straight to
+// KafkaRawTopic the same way a caller-owned channel would if it preferred
+// to drain them itself. Progress is checkpointed via checkpoints after
+// every page, so an interrupted job resumes from the last committed page
+// rather than restarting.
+func (y *YouTubeIntegration) BackfillChannel(ctx context.Context, channelID string, opts BackfillOptions, checkpoints BackfillCheckpointRepository, emit func(context.Context, []YouTubeVideo) error) error {
+	checkpoint, err := checkpoints.Get(ctx, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to load backfill checkpoint: %w", err)
+	}
+	if checkpoint == nil {
+		checkpoint = &BackfillCheckpoint{ChannelID: channelID}
+	}
+	if checkpoint.Done {
+		return nil
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = opts.PageSize
+	}
+
+	var batch []YouTubeVideo
+	pagesFetched := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if opts.QuotaBudget > 0 && pagesFetched >= opts.QuotaBudget {
+			break
+		}
+
+		videos, next, err := y.api.GetChannelVideosPageSized(ctx, channelID, checkpoint.LastPageToken, opts.PageSize)
+		if err != nil {
+			return fmt.Errorf("backfill page fetch failed after %d pages: %w", pagesFetched, err)
+		}
+		pagesFetched++
+
+		stopped := false
+		for _, video := range videos {
+			if !opts.StopAt.IsZero() && video.PublishedAt.Before(opts.StopAt) {
+				stopped = true
+				break
+			}
+
+			batch = append(batch, video)
+			checkpoint.LastVideoID = video.ID
+			checkpoint.LastPublishedAt = video.PublishedAt
+
+			if video.ID == opts.StopAtVideoID {
+				stopped = true
+				break
+			}
+			if batchSize > 0 && len(batch) >= batchSize {
+				if err := emit(ctx, batch); err != nil {
+					return fmt.Errorf("failed to emit backfill batch: %w", err)
+				}
+				batch = nil
+			}
+		}
+
+		checkpoint.LastPageToken = next
+		checkpoint.Done = stopped || next == ""
+		checkpoint.UpdatedAt = time.Now()
+		if err := checkpoints.Save(ctx, checkpoint); err != nil {
+			return fmt.Errorf("failed to checkpoint backfill after page %d: %w", pagesFetched, err)
+		}
+
+		if checkpoint.Done {
+			break
+		}
+	}
+
+	if len(batch) > 0 {
+		if err := emit(ctx, batch); err != nil {
+			return fmt.Errorf("failed to emit final backfill batch: %w", err)
+		}
+	}
+
+	if y.logger != nil {
+		y.logger.Info("youtube backfill job finished",
+			zap.String("channel_id", channelID),
+			zap.Int("pages_fetched", pagesFetched),
+			zap.Bool("done", checkpoint.Done))
+	}
+	return nil
+}
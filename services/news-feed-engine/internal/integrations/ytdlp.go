@@ -0,0 +1,351 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/ytapi"
+)
+
+// YTDLPFetcher shells out to yt-dlp for video metadata and an
+// auto-generated transcript the YouTube Data API can't cheaply provide:
+// full description, chapter markers, category, availability/age-gate
+// flags, live-stream status, and (from the auto-subs yt-dlp writes
+// alongside the metadata) a plain-text transcript. It's wired into
+// YouTubeIntegration via WithYTDLPFetcher as an optional enrichment path
+// for GetVideoDetails, gated on ENABLE_YTDLP_ENRICHMENT, and is the
+// actual transcript source for GetCaptions once enabled.
+//
+// A bounded worker pool caps concurrent yt-dlp processes, a fixed-window
+// limiter (reusing the RATE_LIMIT_REQUESTS/RATE_LIMIT_WINDOW_SECONDS
+// config that bounds inbound traffic in middleware.RateLimiter) paces
+// invocations so this host's own IP doesn't trip YouTube's throttling,
+// and 429/5xx failures are retried with exponential backoff, mirroring
+// webhooks.Manager.backoffDelay.
+type YTDLPFetcher struct {
+	binaryPath string
+	sem        chan struct{}
+	limiter    *ytdlpRateLimiter
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+	logger     *zap.Logger
+
+	// run executes yt-dlp with args and returns its stdout, swapped out
+	// in tests so they exercise parseYTDLPOutput against canned fixtures
+	// without the binary being installed.
+	run func(ctx context.Context, args []string) ([]byte, error)
+}
+
+// NewYTDLPFetcher creates a YTDLPFetcher that runs binaryPath (typically
+// just "yt-dlp", resolved via PATH) with at most maxWorkers concurrent
+// invocations, each paced by the same per-window request budget
+// middleware.RateLimiter enforces on inbound traffic.
+func NewYTDLPFetcher(binaryPath string, maxWorkers, rateLimitRequests int, rateLimitWindow time.Duration, logger *zap.Logger) *YTDLPFetcher {
+	if maxWorkers <= 0 {
+		maxWorkers = 1
+	}
+	f := &YTDLPFetcher{
+		binaryPath: binaryPath,
+		sem:        make(chan struct{}, maxWorkers),
+		limiter:    newYTDLPRateLimiter(rateLimitRequests, rateLimitWindow),
+		maxRetries: 3,
+		baseDelay:  2 * time.Second,
+		maxDelay:   time.Minute,
+		logger:     logger,
+	}
+	f.run = f.execCommand
+	return f
+}
+
+// Fetch runs yt-dlp for videoID and returns its parsed metadata and
+// transcript. It blocks until a worker slot and a rate-limit token are
+// both available, or ctx is done.
+func (f *YTDLPFetcher) Fetch(ctx context.Context, videoID string) (*ytapi.Video, error) {
+	select {
+	case f.sem <- struct{}{}:
+		defer func() { <-f.sem }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if err := f.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	subsDir, err := os.MkdirTemp("", "ytdlp-subs-"+videoID+"-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create yt-dlp work dir: %w", err)
+	}
+	defer os.RemoveAll(subsDir)
+
+	args := []string{
+		"--dump-json",
+		"--skip-download",
+		"--write-auto-subs",
+		"--sub-langs", "en.*",
+		"--sub-format", "vtt",
+		"-o", filepath.Join(subsDir, "%(id)s.%(ext)s"),
+		"--", videoID,
+	}
+
+	var stdout []byte
+	var lastErr error
+	for attempt := 0; attempt <= f.maxRetries; attempt++ {
+		if attempt > 0 {
+			if !f.sleepBackoff(ctx, attempt) {
+				return nil, ctx.Err()
+			}
+		}
+		stdout, lastErr = f.run(ctx, args)
+		if lastErr == nil {
+			break
+		}
+		if !isRetryableYTDLPError(lastErr) {
+			return nil, fmt.Errorf("yt-dlp failed for video %s: %w", videoID, lastErr)
+		}
+	}
+	if lastErr != nil {
+		return nil, fmt.Errorf("yt-dlp failed for video %s after %d attempts: %w", videoID, f.maxRetries+1, lastErr)
+	}
+
+	video, err := parseYTDLPOutput(stdout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse yt-dlp output for video %s: %w", videoID, err)
+	}
+
+	if vttPath, ok := findAutoSubVTT(subsDir); ok {
+		if vttBytes, err := os.ReadFile(vttPath); err != nil {
+			f.logger.Warn("failed to read yt-dlp auto-subs file",
+				zap.String("video_id", videoID), zap.Error(err))
+		} else {
+			video.Transcript = vttToText(string(vttBytes))
+		}
+	}
+
+	return video, nil
+}
+
+func (f *YTDLPFetcher) execCommand(ctx context.Context, args []string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, f.binaryPath, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// sleepBackoff blocks for backoffDelay(attempt) or until ctx is done,
+// returning false in the latter case.
+func (f *YTDLPFetcher) sleepBackoff(ctx context.Context, attempt int) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(f.backoffDelay(attempt)):
+		return true
+	}
+}
+
+// backoffDelay computes an exponential backoff with full jitter, capped
+// at f.maxDelay: base * 2^(attempt-1), randomized into [0, cap]. Mirrors
+// webhooks.Manager.backoffDelay and ytapi.Client.backoffDelay.
+func (f *YTDLPFetcher) backoffDelay(attempt int) time.Duration {
+	cap := float64(f.baseDelay) * math.Pow(2, float64(attempt-1))
+	if cap > float64(f.maxDelay) {
+		cap = float64(f.maxDelay)
+	}
+	return time.Duration(rand.Int63n(int64(cap) + 1))
+}
+
+// isRetryableYTDLPError reports whether err looks like a transient
+// failure worth retrying: HTTP 429/5xx responses yt-dlp surfaces in its
+// stderr, rather than a permanent failure like an invalid video ID or a
+// private/removed video.
+func isRetryableYTDLPError(err error) bool {
+	msg := err.Error()
+	for _, marker := range []string{"HTTP Error 429", "Too Many Requests", "HTTP Error 5", "Temporary failure"} {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// findAutoSubVTT returns the path of the first .vtt file in dir, which
+// is where --write-auto-subs -o <dir>/%(id)s.%(ext)s left the English
+// auto-caption track, if YouTube had one for this video.
+func findAutoSubVTT(dir string) (string, bool) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", false
+	}
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), ".vtt") {
+			return filepath.Join(dir, entry.Name()), true
+		}
+	}
+	return "", false
+}
+
+// ytdlpJSON is the subset of `yt-dlp --dump-json` fields this fetcher
+// reads; yt-dlp's actual output has many more, which json.Unmarshal
+// ignores.
+type ytdlpJSON struct {
+	ID           string   `json:"id"`
+	Title        string   `json:"title"`
+	Description  string   `json:"description"`
+	ChannelID    string   `json:"channel_id"`
+	Channel      string   `json:"channel"`
+	UploadDate   string   `json:"upload_date"`
+	ViewCount    int64    `json:"view_count"`
+	LikeCount    int64    `json:"like_count"`
+	CommentCount int64    `json:"comment_count"`
+	Tags         []string `json:"tags"`
+	Categories   []string `json:"categories"`
+	AgeLimit     int      `json:"age_limit"`
+	Availability string   `json:"availability"`
+	LiveStatus   string   `json:"live_status"`
+	Chapters     []struct {
+		Title     string  `json:"title"`
+		StartTime float64 `json:"start_time"`
+		EndTime   float64 `json:"end_time"`
+	} `json:"chapters"`
+}
+
+// parseYTDLPOutput parses one JSON object from `yt-dlp --dump-json` into
+// a *ytapi.Video. Fields the Data API already covers (Title, ViewCount,
+// ...) are filled in too, so this can stand alone when the Data API call
+// failed or was skipped entirely.
+func parseYTDLPOutput(data []byte) (*ytapi.Video, error) {
+	var parsed ytdlpJSON
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse yt-dlp JSON: %w", err)
+	}
+
+	video := &ytapi.Video{
+		ID:              parsed.ID,
+		Title:           parsed.Title,
+		Description:     parsed.Description,
+		ChannelID:       parsed.ChannelID,
+		ChannelTitle:    parsed.Channel,
+		ViewCount:       parsed.ViewCount,
+		LikeCount:       parsed.LikeCount,
+		CommentCount:    parsed.CommentCount,
+		Tags:            parsed.Tags,
+		IsAgeRestricted: parsed.AgeLimit >= 18,
+		IsUnavailable:   parsed.Availability != "" && parsed.Availability != "public" && parsed.Availability != "unlisted",
+		LiveStatus:      parsed.LiveStatus,
+	}
+
+	if publishedAt, err := time.Parse("20060102", parsed.UploadDate); err == nil {
+		video.PublishedAt = publishedAt
+	}
+	if len(parsed.Categories) > 0 {
+		video.Category = parsed.Categories[0]
+	}
+	for _, c := range parsed.Chapters {
+		video.Chapters = append(video.Chapters, ytapi.Chapter{
+			Title: c.Title,
+			Start: time.Duration(c.StartTime * float64(time.Second)),
+			End:   time.Duration(c.EndTime * float64(time.Second)),
+		})
+	}
+
+	return video, nil
+}
+
+var vttTagRe = regexp.MustCompile(`<[^>]*>`)
+
+// vttToText collapses a WebVTT auto-subs track into plain transcript
+// text: it drops the header, NOTE/Kind/Language metadata lines, cue
+// indices, and "-->" timing lines, strips inline <00:00:01.440><c>...
+// word-timing tags, and skips a line that exactly repeats the previous
+// one - YouTube's rolling auto-captions re-emit the prior line verbatim
+// as it scrolls, so without this a transcript would repeat most of its
+// own text.
+func vttToText(vtt string) string {
+	var lines []string
+	last := ""
+	for _, raw := range strings.Split(vtt, "\n") {
+		line := strings.TrimSpace(raw)
+		switch {
+		case line == "", line == "WEBVTT":
+			continue
+		case strings.HasPrefix(line, "NOTE"), strings.HasPrefix(line, "Kind:"), strings.HasPrefix(line, "Language:"):
+			continue
+		case strings.Contains(line, "-->"):
+			continue
+		}
+		if _, err := strconv.Atoi(line); err == nil {
+			continue // cue index
+		}
+
+		line = vttTagRe.ReplaceAllString(line, "")
+		line = strings.TrimSpace(line)
+		if line == "" || line == last {
+			continue
+		}
+		lines = append(lines, line)
+		last = line
+	}
+	return strings.Join(lines, " ")
+}
+
+// ytdlpRateLimiter is a fixed-window limiter (at most max calls per
+// window, the count resetting when the window ends) scoped to this process's own
+// yt-dlp invocations, as opposed to middleware.RateLimiter, which limits
+// inbound per-client requests. It reuses the same max/window values so
+// our own scraping backs off at the rate we'd ask a client to.
+type ytdlpRateLimiter struct {
+	mu      sync.Mutex
+	max     int
+	window  time.Duration
+	count   int
+	resetAt time.Time
+}
+
+func newYTDLPRateLimiter(max int, window time.Duration) *ytdlpRateLimiter {
+	return &ytdlpRateLimiter{max: max, window: window, resetAt: time.Now().Add(window)}
+}
+
+// Wait blocks until a token is available or ctx is done. A limiter with
+// max <= 0 never admits a call, matching how a misconfigured budget
+// should fail closed rather than silently allow unlimited scraping.
+func (r *ytdlpRateLimiter) Wait(ctx context.Context) error {
+	for {
+		r.mu.Lock()
+		now := time.Now()
+		if now.After(r.resetAt) {
+			r.count = 0
+			r.resetAt = now.Add(r.window)
+		}
+		if r.max > 0 && r.count < r.max {
+			r.count++
+			r.mu.Unlock()
+			return nil
+		}
+		wait := time.Until(r.resetAt)
+		r.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// canned `yt-dlp --dump-json` output for a fixture video, trimmed to the
+// fields parseYTDLPOutput reads.
+const fixtureYTDLPJSON = `{
+	"id": "dQw4w9WgXcQ",
+	"title": "Example Video",
+	"description": "Full unrestricted description.",
+	"channel_id": "UCabc123",
+	"channel": "Example Channel",
+	"upload_date": "20240115",
+	"view_count": 1000,
+	"like_count": 50,
+	"comment_count": 5,
+	"tags": ["example", "fixture"],
+	"categories": ["Education"],
+	"age_limit": 0,
+	"availability": "public",
+	"live_status": "not_live",
+	"chapters": [
+		{"title": "Intro", "start_time": 0, "end_time": 10.5},
+		{"title": "Body", "start_time": 10.5, "end_time": 60}
+	]
+}`
+
+// canned auto-generated English WebVTT track, including the rolling
+// duplicate lines and inline word-timing tags real YouTube auto-subs use.
+const fixtureAutoSubsVTT = `WEBVTT
+Kind: captions
+Language: en
+
+00:00:00.000 --> 00:00:02.000
+<00:00:00.160><c> hello</c><00:00:00.400><c> and</c>
+welcome to the video
+
+00:00:02.000 --> 00:00:04.000
+welcome to the video
+today we talk about Go
+`
+
+func TestParseYTDLPOutput(t *testing.T) {
+	video, err := parseYTDLPOutput([]byte(fixtureYTDLPJSON))
+	require.NoError(t, err)
+
+	assert.Equal(t, "dQw4w9WgXcQ", video.ID)
+	assert.Equal(t, "Full unrestricted description.", video.Description)
+	assert.Equal(t, "Example Channel", video.ChannelTitle)
+	assert.Equal(t, "Education", video.Category)
+	assert.False(t, video.IsAgeRestricted)
+	assert.False(t, video.IsUnavailable)
+	assert.Equal(t, "not_live", video.LiveStatus)
+	assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), video.PublishedAt)
+	require.Len(t, video.Chapters, 2)
+	assert.Equal(t, "Intro", video.Chapters[0].Title)
+	assert.Equal(t, 10500*time.Millisecond, video.Chapters[0].End)
+}
+
+func TestParseYTDLPOutputAgeRestrictedAndUnavailable(t *testing.T) {
+	video, err := parseYTDLPOutput([]byte(`{"id": "x", "age_limit": 18, "availability": "needs_auth"}`))
+	require.NoError(t, err)
+
+	assert.True(t, video.IsAgeRestricted)
+	assert.True(t, video.IsUnavailable)
+}
+
+func TestVTTToText(t *testing.T) {
+	text := vttToText(fixtureAutoSubsVTT)
+
+	assert.Equal(t, "hello and welcome to the video today we talk about Go", text)
+}
+
+func TestYTDLPFetcherRetriesOnTransientError(t *testing.T) {
+	attempts := 0
+	f := NewYTDLPFetcher("yt-dlp", 1, 100, time.Minute, zap.NewNop())
+	f.baseDelay = time.Millisecond
+	f.maxDelay = 5 * time.Millisecond
+	f.run = func(ctx context.Context, args []string) ([]byte, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errors.New(`ERROR: unable to download video data: HTTP Error 429: Too Many Requests`)
+		}
+		return []byte(fixtureYTDLPJSON), nil
+	}
+
+	video, err := f.Fetch(context.Background(), "dQw4w9WgXcQ")
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, "dQw4w9WgXcQ", video.ID)
+}
+
+func TestYTDLPFetcherDoesNotRetryPermanentError(t *testing.T) {
+	attempts := 0
+	f := NewYTDLPFetcher("yt-dlp", 1, 100, time.Minute, zap.NewNop())
+	f.run = func(ctx context.Context, args []string) ([]byte, error) {
+		attempts++
+		return nil, errors.New("ERROR: Private video. Sign in if you've been granted access to this video")
+	}
+
+	_, err := f.Fetch(context.Background(), "private123")
+
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestYTDLPFetcherBoundsConcurrency(t *testing.T) {
+	const maxWorkers = 2
+	var inFlight, maxObserved atomic.Int32
+
+	f := NewYTDLPFetcher("yt-dlp", maxWorkers, 1000, time.Minute, zap.NewNop())
+	f.run = func(ctx context.Context, args []string) ([]byte, error) {
+		n := inFlight.Add(1)
+		for {
+			observed := maxObserved.Load()
+			if n <= observed || maxObserved.CompareAndSwap(observed, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		inFlight.Add(-1)
+		return []byte(fixtureYTDLPJSON), nil
+	}
+
+	done := make(chan struct{})
+	for i := 0; i < 5; i++ {
+		go func() {
+			_, _ = f.Fetch(context.Background(), "dQw4w9WgXcQ")
+			done <- struct{}{}
+		}()
+	}
+	for i := 0; i < 5; i++ {
+		<-done
+	}
+
+	assert.LessOrEqual(t, int(maxObserved.Load()), maxWorkers)
+}
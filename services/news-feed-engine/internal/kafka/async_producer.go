@@ -0,0 +1,342 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/sarama"
+	"go.uber.org/zap"
+)
+
+// AsyncOptions configures an AsyncProducer.
+type AsyncOptions struct {
+	// QueueSize bounds the number of messages buffered ahead of the
+	// underlying sarama.AsyncProducer. Publish blocks once it fills, so
+	// this is the back-pressure knob: small enough that a stalled broker
+	// is noticed, large enough to absorb a burst.
+	QueueSize int
+	// FlushFrequency and FlushBytes tune how sarama batches messages
+	// before sending; zero leaves sarama's defaults in place.
+	FlushFrequency time.Duration
+	FlushBytes     int
+	// MaxRetries is how many times sarama retries a failed send before
+	// AsyncProducer gives up on a message and routes it to DeadLetterTopic.
+	MaxRetries int
+	// DeadLetterTopic receives messages that exhausted MaxRetries, with
+	// the original topic/key/headers preserved as record headers. Left
+	// empty, exhausted messages are simply dropped (after OnDropped).
+	DeadLetterTopic string
+	// Serializer encodes Message.Value, matching Producer's
+	// WithSerializer. Defaults to JSONSerializer.
+	Serializer Serializer
+}
+
+// withDefaults returns a copy of o with zero-valued fields replaced by
+// sane defaults, mirroring sarama's own NewConfig defaults where this
+// package doesn't need to diverge from them.
+func (o AsyncOptions) withDefaults() AsyncOptions {
+	if o.QueueSize <= 0 {
+		o.QueueSize = 1000
+	}
+	if o.MaxRetries <= 0 {
+		o.MaxRetries = 3
+	}
+	if o.Serializer == nil {
+		o.Serializer = JSONSerializer{}
+	}
+	return o
+}
+
+// AsyncProducer publishes messages through a bounded in-memory queue
+// backed by sarama.AsyncProducer, so Publish returns as soon as a
+// message is queued rather than blocking on the broker round-trip like
+// Producer does. It is the producer to reach for on hot paths - webhook
+// delivery, bulk content ingest - that would otherwise serialize on
+// Kafka; anywhere ordering-sensitive confirmation of a single publish is
+// required, use the synchronous Producer instead.
+//
+// Messages that exhaust retries are forwarded to DeadLetterTopic (if
+// configured) rather than silently lost.
+type AsyncProducer struct {
+	producer sarama.AsyncProducer
+	client   sarama.Client
+	logger   *zap.Logger
+	opts     AsyncOptions
+
+	queue chan *sarama.ProducerMessage
+	wg    sync.WaitGroup
+
+	closeOnce sync.Once
+	closed    chan struct{}
+
+	pending atomic.Int64
+
+	// OnSuccess, OnError, and OnDropped, when set, are called from the
+	// background drain goroutines so callers can feed metrics (e.g.
+	// sent/failed/dropped counters) without AsyncProducer depending on a
+	// particular metrics backend. They must not block.
+	OnSuccess func(msg Message)
+	OnError   func(msg Message, err error)
+	OnDropped func(msg Message, err error)
+}
+
+// NewAsyncProducer creates an AsyncProducer against brokers with the
+// given options.
+func NewAsyncProducer(brokers []string, opts AsyncOptions, logger *zap.Logger) (*AsyncProducer, error) {
+	opts = opts.withDefaults()
+
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	config.Producer.Return.Errors = true
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Retry.Max = opts.MaxRetries
+	config.Producer.Compression = sarama.CompressionSnappy
+	if opts.FlushFrequency > 0 {
+		config.Producer.Flush.Frequency = opts.FlushFrequency
+	}
+	if opts.FlushBytes > 0 {
+		config.Producer.Flush.Bytes = opts.FlushBytes
+	}
+
+	client, err := sarama.NewClient(brokers, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka client: %w", err)
+	}
+
+	producer, err := sarama.NewAsyncProducerFromClient(client)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to create Kafka async producer: %w", err)
+	}
+
+	if logger == nil {
+		logger, _ = zap.NewDevelopment()
+	}
+
+	p := &AsyncProducer{
+		producer: producer,
+		client:   client,
+		logger:   logger,
+		opts:     opts,
+		queue:    make(chan *sarama.ProducerMessage, opts.QueueSize),
+		closed:   make(chan struct{}),
+	}
+
+	p.wg.Add(3)
+	go p.drainQueue()
+	go p.drainSuccesses()
+	go p.drainErrors()
+
+	logger.Info("Kafka async producer created successfully",
+		zap.Strings("brokers", brokers), zap.Int("queue_size", opts.QueueSize))
+
+	return p, nil
+}
+
+// Publish queues msg for asynchronous delivery, blocking only if the
+// queue is full. It returns an error if ctx is cancelled first or the
+// producer has been closed.
+func (p *AsyncProducer) Publish(ctx context.Context, msg Message) error {
+	kafkaMsg, err := toProducerMessage(ctx, p.opts.Serializer, msg)
+	if err != nil {
+		return err
+	}
+	return p.enqueue(ctx, kafkaMsg)
+}
+
+// PublishBatch queues every message in messages, stopping at the first
+// one that fails to enqueue (e.g. because ctx was cancelled).
+func (p *AsyncProducer) PublishBatch(ctx context.Context, messages []Message) error {
+	for i, msg := range messages {
+		kafkaMsg, err := toProducerMessage(ctx, p.opts.Serializer, msg)
+		if err != nil {
+			return fmt.Errorf("message %d: %w", i, err)
+		}
+		if err := p.enqueue(ctx, kafkaMsg); err != nil {
+			return fmt.Errorf("message %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (p *AsyncProducer) enqueue(ctx context.Context, kafkaMsg *sarama.ProducerMessage) error {
+	select {
+	case <-p.closed:
+		return fmt.Errorf("kafka async producer is closed")
+	default:
+	}
+	select {
+	case p.queue <- kafkaMsg:
+		p.pending.Add(1)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-p.closed:
+		return fmt.Errorf("kafka async producer is closed")
+	}
+}
+
+// drainQueue forwards queued messages into sarama's Input channel. It
+// runs until queue is closed (by Close), at which point it closes
+// sarama's AsyncClose so Successes()/Errors() finish draining naturally.
+func (p *AsyncProducer) drainQueue() {
+	defer p.wg.Done()
+	for kafkaMsg := range p.queue {
+		p.producer.Input() <- kafkaMsg
+	}
+	p.producer.AsyncClose()
+}
+
+func (p *AsyncProducer) drainSuccesses() {
+	defer p.wg.Done()
+	for range p.producer.Successes() {
+		p.pending.Add(-1)
+	}
+}
+
+// drainErrors handles sends sarama gave up on after Producer.Retry.Max
+// attempts, routing them to DeadLetterTopic when configured.
+func (p *AsyncProducer) drainErrors() {
+	defer p.wg.Done()
+	for prodErr := range p.producer.Errors() {
+		p.pending.Add(-1)
+		msg := fromProducerMessage(prodErr.Msg)
+
+		p.logger.Error("Kafka async publish failed",
+			zap.String("topic", msg.Topic), zap.String("key", msg.Key), zap.Error(prodErr.Err))
+		if p.OnError != nil {
+			p.OnError(msg, prodErr.Err)
+		}
+
+		if p.opts.DeadLetterTopic == "" {
+			if p.OnDropped != nil {
+				p.OnDropped(msg, prodErr.Err)
+			}
+			continue
+		}
+		if err := p.sendToDeadLetter(prodErr.Msg, prodErr.Err); err != nil {
+			p.logger.Error("Failed to forward message to dead-letter topic",
+				zap.String("original_topic", msg.Topic), zap.String("dlq_topic", p.opts.DeadLetterTopic), zap.Error(err))
+			if p.OnDropped != nil {
+				p.OnDropped(msg, err)
+			}
+		}
+	}
+}
+
+// sendToDeadLetter re-publishes orig to DeadLetterTopic, preserving its
+// original topic/key/headers as x-original-topic/x-original-key plus an
+// x-error and x-retry-count describing why it landed here. It sends
+// synchronously against the client AsyncProducer already holds, so a DLQ
+// failure surfaces immediately rather than recursing through the same
+// queue.
+func (p *AsyncProducer) sendToDeadLetter(orig *sarama.ProducerMessage, cause error) error {
+	syncProducer, err := sarama.NewSyncProducerFromClient(p.client)
+	if err != nil {
+		return fmt.Errorf("failed to create dead-letter producer: %w", err)
+	}
+	defer syncProducer.Close()
+
+	headers := append([]sarama.RecordHeader{}, orig.Headers...)
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte("x-original-topic"), Value: []byte(orig.Topic)},
+		sarama.RecordHeader{Key: []byte("x-error"), Value: []byte(cause.Error())},
+		sarama.RecordHeader{Key: []byte("x-retry-count"), Value: []byte(fmt.Sprintf("%d", p.opts.MaxRetries))},
+	)
+
+	dlqMsg := &sarama.ProducerMessage{
+		Topic:     p.opts.DeadLetterTopic,
+		Key:       orig.Key,
+		Value:     orig.Value,
+		Headers:   headers,
+		Timestamp: time.Now(),
+	}
+	_, _, err = syncProducer.SendMessage(dlqMsg)
+	return err
+}
+
+// Flush blocks until every queued message has been acknowledged
+// (success, error, or dead-letter) or ctx is cancelled.
+func (p *AsyncProducer) Flush(ctx context.Context) error {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		if p.pending.Load() <= 0 {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Close stops accepting new messages, drains the queue and sarama's
+// Successes()/Errors() channels, and releases the underlying client.
+func (p *AsyncProducer) Close() error {
+	var closeErr error
+	p.closeOnce.Do(func() {
+		close(p.closed)
+		close(p.queue)
+		p.wg.Wait()
+		if err := p.client.Close(); err != nil {
+			closeErr = fmt.Errorf("failed to close kafka client: %w", err)
+		}
+	})
+	return closeErr
+}
+
+func toProducerMessage(ctx context.Context, serializer Serializer, msg Message) (*sarama.ProducerMessage, error) {
+	if msg.Timestamp.IsZero() {
+		msg.Timestamp = time.Now()
+	}
+	valueBytes, err := serializer.Serialize(ctx, subjectOf(msg.Value), msg.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	kafkaMsg := &sarama.ProducerMessage{
+		Topic:     msg.Topic,
+		Key:       sarama.StringEncoder(msg.Key),
+		Value:     sarama.ByteEncoder(valueBytes),
+		Timestamp: msg.Timestamp,
+	}
+	if len(msg.Headers) > 0 {
+		kafkaMsg.Headers = make([]sarama.RecordHeader, 0, len(msg.Headers))
+		for key, value := range msg.Headers {
+			kafkaMsg.Headers = append(kafkaMsg.Headers, sarama.RecordHeader{
+				Key:   []byte(key),
+				Value: []byte(value),
+			})
+		}
+	}
+	return kafkaMsg, nil
+}
+
+// fromProducerMessage recovers the topic/key (and string-valued headers)
+// of a sarama.ProducerMessage for logging and OnError/OnDropped
+// callbacks. It does not attempt to unmarshal Value back into
+// Message.Value, since callers only need to identify which message
+// failed, not replay it.
+func fromProducerMessage(kafkaMsg *sarama.ProducerMessage) Message {
+	msg := Message{
+		Topic:     kafkaMsg.Topic,
+		Timestamp: kafkaMsg.Timestamp,
+	}
+	if kafkaMsg.Key != nil {
+		if encoded, err := kafkaMsg.Key.Encode(); err == nil {
+			msg.Key = string(encoded)
+		}
+	}
+	if len(kafkaMsg.Headers) > 0 {
+		msg.Headers = make(map[string]string, len(kafkaMsg.Headers))
+		for _, h := range kafkaMsg.Headers {
+			msg.Headers[string(h.Key)] = string(h.Value)
+		}
+	}
+	return msg
+}
@@ -0,0 +1,135 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/IBM/sarama"
+	"go.uber.org/zap"
+)
+
+// Handler processes the decoded value of a single Kafka message. Returning
+// an error leaves the message unmarked so the consumer group redelivers it
+// on the next rebalance/restart.
+type Handler func(ctx context.Context, key string, value json.RawMessage) error
+
+// Consumer runs a sarama consumer group against a single topic, decoding
+// each message's key and handing its raw value to a Handler. Unlike
+// Producer, which is safe to run as a noop, Consumer is only constructed
+// when there is somewhere for its messages to go (e.g. cache invalidation
+// is only wired up when a shared Store needs it), so there is no
+// NewNoopConsumer.
+type Consumer struct {
+	group        sarama.ConsumerGroup
+	topic        string
+	logger       *zap.Logger
+	deserializer Deserializer
+}
+
+// ConsumerOption configures optional Consumer behavior, applied in
+// NewConsumer after its required arguments.
+type ConsumerOption func(*Consumer)
+
+// WithDeserializer overrides the Deserializer Decode uses, e.g. to a
+// ConfluentRegistrySerializer matching the Serializer a producer
+// published with. Defaults to JSONSerializer.
+func WithDeserializer(d Deserializer) ConsumerOption {
+	return func(c *Consumer) {
+		c.deserializer = d
+	}
+}
+
+// NewConsumer creates a Consumer in the given consumer group, reading from
+// topic.
+func NewConsumer(brokers []string, group, topic string, logger *zap.Logger, opts ...ConsumerOption) (*Consumer, error) {
+	config := sarama.NewConfig()
+	config.Consumer.Return.Errors = true
+	config.Consumer.Offsets.Initial = sarama.OffsetNewest
+
+	consumerGroup, err := sarama.NewConsumerGroup(brokers, group, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka consumer group: %w", err)
+	}
+	if logger == nil {
+		logger, _ = zap.NewDevelopment()
+	}
+	c := &Consumer{group: consumerGroup, topic: topic, logger: logger, deserializer: JSONSerializer{}}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// Decode deserializes a message's raw value into target using Consumer's
+// configured Deserializer, e.g. inside a Handler:
+//
+//	func(ctx context.Context, key string, value json.RawMessage) error {
+//		var msg kafka.ContentIngestionMessage
+//		if err := consumer.Decode(ctx, msg.SchemaSubject(), value, &msg); err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (c *Consumer) Decode(ctx context.Context, subject string, value json.RawMessage, target interface{}) error {
+	return c.deserializer.Deserialize(ctx, subject, value, target)
+}
+
+// Run consumes topic until ctx is cancelled, calling handle for every
+// message. It reconnects to the consumer group after rebalances, which
+// sarama surfaces by returning from Consume; Run loops on that until ctx
+// is done.
+func (c *Consumer) Run(ctx context.Context, handle Handler) error {
+	consumerHandler := &groupHandler{handle: handle, logger: c.logger}
+
+	go func() {
+		for err := range c.group.Errors() {
+			c.logger.Error("Kafka consumer group error", zap.String("topic", c.topic), zap.Error(err))
+		}
+	}()
+
+	for {
+		if err := c.group.Consume(ctx, []string{c.topic}, consumerHandler); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("consumer group session ended: %w", err)
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// Close releases the consumer group's connections.
+func (c *Consumer) Close() error {
+	return c.group.Close()
+}
+
+// groupHandler adapts a Handler to sarama.ConsumerGroupHandler.
+type groupHandler struct {
+	handle Handler
+	logger *zap.Logger
+}
+
+func (h *groupHandler) Setup(sarama.ConsumerGroupSession) error   { return nil }
+func (h *groupHandler) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+
+func (h *groupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+			if err := h.handle(session.Context(), string(msg.Key), msg.Value); err != nil {
+				h.logger.Error("Kafka message handler failed",
+					zap.String("topic", msg.Topic), zap.String("key", string(msg.Key)), zap.Error(err))
+				continue
+			}
+			session.MarkMessage(msg, "")
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
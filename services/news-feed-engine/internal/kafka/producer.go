@@ -3,18 +3,55 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
 )
 
 // Producer wraps a Kafka producer for publishing messages
 type Producer struct {
-	producer sarama.SyncProducer
-	logger   *zap.Logger
+	producer   sarama.SyncProducer
+	client     sarama.Client
+	logger     *zap.Logger
+	serializer Serializer
+	// tracer creates kafka.produce spans and propagates trace context into
+	// message headers when set via WithTracerProvider. nil means tracing
+	// is disabled, matching Producer's pre-tracing behavior.
+	tracer *metrics.TracingProvider
+}
+
+// ProducerOption configures optional Producer behavior not every caller
+// needs, applied in NewProducer after its required arguments.
+type ProducerOption func(*Producer)
+
+// WithSerializer overrides the Serializer NewProducer uses to encode
+// Message.Value, e.g. to a ConfluentRegistrySerializer for schema
+// evolution. Defaults to JSONSerializer.
+func WithSerializer(s Serializer) ProducerOption {
+	return func(p *Producer) {
+		p.serializer = s
+	}
+}
+
+// WithTracerProvider enables OpenTelemetry tracing on NewProducer's
+// Publish/PublishBatch: every publish starts a kafka.produce span and
+// injects its trace context into kafkaMsg.Headers via
+// otel.GetTextMapPropagator(), so a consumer reading those headers can
+// reconstruct the trace the originating Gin request started. Defaults to
+// nil (no spans, no injected headers), matching Producer's behavior
+// before tracing support was added.
+func WithTracerProvider(tp *metrics.TracingProvider) ProducerOption {
+	return func(p *Producer) {
+		p.tracer = tp
+	}
 }
 
 // Message represents a Kafka message to be published
@@ -27,7 +64,7 @@ type Message struct {
 }
 
 // NewProducer creates a new Kafka producer
-func NewProducer(brokers []string, logger *zap.Logger) (*Producer, error) {
+func NewProducer(brokers []string, logger *zap.Logger, opts ...ProducerOption) (*Producer, error) {
 	config := sarama.NewConfig()
 	config.Producer.Return.Successes = true
 	config.Producer.Return.Errors = true
@@ -37,17 +74,32 @@ func NewProducer(brokers []string, logger *zap.Logger) (*Producer, error) {
 	config.Producer.Idempotent = true // Ensure exactly-once semantics
 	config.Net.MaxOpenRequests = 1    // Required for idempotent producer
 
-	producer, err := sarama.NewSyncProducer(brokers, config)
+	// Build the producer from an explicit client (rather than
+	// sarama.NewSyncProducer, which creates and hides its own client) so
+	// HealthCheck/Client can query cluster metadata directly.
+	client, err := sarama.NewClient(brokers, config)
 	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka client: %w", err)
+	}
+
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		client.Close()
 		return nil, fmt.Errorf("failed to create Kafka producer: %w", err)
 	}
 
 	logger.Info("Kafka producer created successfully", zap.Strings("brokers", brokers))
 
-	return &Producer{
-		producer: producer,
-		logger:   logger,
-	}, nil
+	p := &Producer{
+		producer:   producer,
+		client:     client,
+		logger:     logger,
+		serializer: JSONSerializer{},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p, nil
 }
 
 // NewNoopProducer returns a producer that performs no network operations.
@@ -59,7 +111,45 @@ func NewNoopProducer(logger *zap.Logger) *Producer {
 		logger = noop
 	}
 	logger.Info("Kafka producer running in noop mode")
-	return &Producer{producer: nil, logger: logger}
+	return &Producer{producer: nil, logger: logger, serializer: JSONSerializer{}}
+}
+
+// kafkaHeaderCarrier adapts a sarama.ProducerMessage's Headers to
+// propagation.TextMapCarrier so otel.GetTextMapPropagator().Inject can
+// write traceparent/tracestate straight into the headers sarama will send,
+// the same way otelbridge.RoundTripper injects into an http.Header.
+type kafkaHeaderCarrier struct {
+	msg *sarama.ProducerMessage
+}
+
+// Get implements propagation.TextMapCarrier.
+func (c kafkaHeaderCarrier) Get(key string) string {
+	for _, h := range c.msg.Headers {
+		if string(h.Key) == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// Set implements propagation.TextMapCarrier.
+func (c kafkaHeaderCarrier) Set(key, value string) {
+	for i, h := range c.msg.Headers {
+		if string(h.Key) == key {
+			c.msg.Headers[i].Value = []byte(value)
+			return
+		}
+	}
+	c.msg.Headers = append(c.msg.Headers, sarama.RecordHeader{Key: []byte(key), Value: []byte(value)})
+}
+
+// Keys implements propagation.TextMapCarrier.
+func (c kafkaHeaderCarrier) Keys() []string {
+	keys := make([]string, len(c.msg.Headers))
+	for i, h := range c.msg.Headers {
+		keys[i] = string(h.Key)
+	}
+	return keys
 }
 
 // Publish sends a message to Kafka
@@ -76,10 +166,11 @@ func (p *Producer) Publish(ctx context.Context, msg Message) error {
 		msg.Timestamp = time.Now()
 	}
 
-	// Serialize value to JSON
-	valueBytes, err := json.Marshal(msg.Value)
+	// Serialize value with the configured Serializer (JSONSerializer by
+	// default, or e.g. a ConfluentRegistrySerializer via WithSerializer)
+	valueBytes, err := p.serializer.Serialize(ctx, subjectOf(msg.Value), msg.Value)
 	if err != nil {
-		return fmt.Errorf("failed to marshal message value: %w", err)
+		return err
 	}
 
 	// Build Kafka message
@@ -101,9 +192,27 @@ func (p *Producer) Publish(ctx context.Context, msg Message) error {
 		}
 	}
 
+	var span trace.Span
+	if p.tracer != nil {
+		ctx, span = p.tracer.StartSpan(ctx, "kafka.produce", &metrics.SpanOptions{
+			SpanKind: trace.SpanKindProducer,
+			Attributes: map[string]interface{}{
+				"messaging.system":            "kafka",
+				"messaging.destination":       msg.Topic,
+				"messaging.kafka.message_key": msg.Key,
+			},
+		})
+		defer span.End()
+		otel.GetTextMapPropagator().Inject(ctx, kafkaHeaderCarrier{msg: kafkaMsg})
+	}
+
 	// Publish message
 	partition, offset, err := p.producer.SendMessage(kafkaMsg)
 	if err != nil {
+		if span != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
 		p.logger.Error("Failed to publish message to Kafka",
 			zap.String("topic", msg.Topic),
 			zap.String("key", msg.Key),
@@ -111,11 +220,12 @@ func (p *Producer) Publish(ctx context.Context, msg Message) error {
 		return fmt.Errorf("failed to publish message: %w", err)
 	}
 
-	p.logger.Debug("Message published successfully",
-		zap.String("topic", msg.Topic),
-		zap.String("key", msg.Key),
-		zap.Int32("partition", partition),
-		zap.Int64("offset", offset))
+	if span != nil {
+		span.SetAttributes(
+			attribute.Int("messaging.kafka.partition", int(partition)),
+			attribute.Int64("messaging.kafka.offset", offset),
+		)
+	}
 
 	return nil
 }
@@ -136,16 +246,32 @@ func (p *Producer) PublishBatch(ctx context.Context, messages []Message) error {
 
 	kafkaMessages := make([]*sarama.ProducerMessage, 0, len(messages))
 
+	var parentSpan trace.Span
+	if p.tracer != nil {
+		ctx, parentSpan = p.tracer.StartSpan(ctx, "kafka.produce_batch", &metrics.SpanOptions{
+			SpanKind: trace.SpanKindProducer,
+			Attributes: map[string]interface{}{
+				"messaging.system":              "kafka",
+				"messaging.batch.message_count": len(messages),
+			},
+		})
+		defer parentSpan.End()
+	}
+	// childSpans[i] corresponds to kafkaMessages[i], both appended in the
+	// same loop iteration, so partition/offset can be attributed back to
+	// the right span once SendMessages fills kafkaMessages in place.
+	childSpans := make([]trace.Span, 0, len(messages))
+
 	for _, msg := range messages {
 		// Set timestamp if not provided
 		if msg.Timestamp.IsZero() {
 			msg.Timestamp = time.Now()
 		}
 
-		// Serialize value to JSON
-		valueBytes, err := json.Marshal(msg.Value)
+		// Serialize value with the configured Serializer
+		valueBytes, err := p.serializer.Serialize(ctx, subjectOf(msg.Value), msg.Value)
 		if err != nil {
-			return fmt.Errorf("failed to marshal message value: %w", err)
+			return err
 		}
 
 		// Build Kafka message
@@ -167,11 +293,43 @@ func (p *Producer) PublishBatch(ctx context.Context, messages []Message) error {
 			}
 		}
 
+		if p.tracer != nil {
+			var span trace.Span
+			_, span = p.tracer.StartSpan(ctx, "kafka.produce", &metrics.SpanOptions{
+				SpanKind: trace.SpanKindProducer,
+				Attributes: map[string]interface{}{
+					"messaging.system":            "kafka",
+					"messaging.destination":       msg.Topic,
+					"messaging.kafka.message_key": msg.Key,
+				},
+			})
+			defer span.End()
+			otel.GetTextMapPropagator().Inject(ctx, kafkaHeaderCarrier{msg: kafkaMsg})
+			childSpans = append(childSpans, span)
+		}
+
 		kafkaMessages = append(kafkaMessages, kafkaMsg)
 	}
 
 	// Send batch
 	err := p.producer.SendMessages(kafkaMessages)
+
+	for i, span := range childSpans {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			continue
+		}
+		span.SetAttributes(
+			attribute.Int("messaging.kafka.partition", int(kafkaMessages[i].Partition)),
+			attribute.Int64("messaging.kafka.offset", kafkaMessages[i].Offset),
+		)
+	}
+	if parentSpan != nil && err != nil {
+		parentSpan.RecordError(err)
+		parentSpan.SetStatus(codes.Error, err.Error())
+	}
+
 	if err != nil {
 		p.logger.Error("Failed to publish batch to Kafka",
 			zap.Int("count", len(messages)),
@@ -179,9 +337,6 @@ func (p *Producer) PublishBatch(ctx context.Context, messages []Message) error {
 		return fmt.Errorf("failed to publish batch: %w", err)
 	}
 
-	p.logger.Debug("Batch published successfully",
-		zap.Int("count", len(messages)))
-
 	return nil
 }
 
@@ -197,6 +352,16 @@ func (p *Producer) Close() error {
 			}
 			return fmt.Errorf("failed to close producer: %w", err)
 		}
+		// NewSyncProducerFromClient doesn't take ownership of the client
+		// we passed it, so it falls to us to close it.
+		if p.client != nil {
+			if err := p.client.Close(); err != nil {
+				if p.logger != nil {
+					p.logger.Error("Failed to close Kafka client", zap.Error(err))
+				}
+				return fmt.Errorf("failed to close kafka client: %w", err)
+			}
+		}
 		if p.logger != nil {
 			p.logger.Info("Kafka producer closed successfully")
 		}
@@ -208,6 +373,33 @@ func (p *Producer) Close() error {
 	return nil
 }
 
+// Client returns the underlying sarama.Client, e.g. for a
+// metrics.KafkaChecker to probe broker/topic metadata directly. Returns
+// nil for a noop producer.
+func (p *Producer) Client() sarama.Client {
+	if p == nil {
+		return nil
+	}
+	return p.client
+}
+
+// HealthCheck probes real broker/topic connectivity - not just whether
+// the producer object exists - by refreshing metadata for topics with a
+// bounded timeout and verifying each has an available leader. See
+// metrics.KafkaChecker for the full probe, including the
+// under-replicated-partition distinction surfaced there as
+// HealthStatusDegraded rather than an outright failure.
+func (p *Producer) HealthCheck(ctx context.Context, topics ...string) error {
+	if p == nil || p.client == nil {
+		return fmt.Errorf("kafka client not initialized")
+	}
+	result := metrics.NewKafkaChecker(p.client, topics...).Check(ctx)
+	if result.Status == metrics.HealthStatusUnhealthy {
+		return fmt.Errorf("%s", result.Message)
+	}
+	return nil
+}
+
 // ContentIngestionMessage represents a content ingestion job message
 type ContentIngestionMessage struct {
 	TenantID    string                 `json:"tenant_id"`
@@ -217,8 +409,15 @@ type ContentIngestionMessage struct {
 	Priority    int                    `json:"priority"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
 	RequestedAt time.Time              `json:"requested_at"`
+	// Mode distinguishes a one-shot current-window ingest ("", "current")
+	// from a resumable full-history crawl ("historical"); see
+	// internal/ingestion.BackfillRunner.
+	Mode string `json:"mode,omitempty"`
 }
 
+// SchemaSubject implements SchemaSubjecter for ConfluentRegistrySerializer.
+func (ContentIngestionMessage) SchemaSubject() string { return "content-ingestion-value" }
+
 // ContentProcessingMessage represents a content processing job message
 type ContentProcessingMessage struct {
 	TenantID   string                 `json:"tenant_id"`
@@ -229,6 +428,9 @@ type ContentProcessingMessage struct {
 	QueuedAt   time.Time              `json:"queued_at"`
 }
 
+// SchemaSubject implements SchemaSubjecter for ConfluentRegistrySerializer.
+func (ContentProcessingMessage) SchemaSubject() string { return "content-processing-value" }
+
 // VideoGenerationMessage represents a video generation job message
 type VideoGenerationMessage struct {
 	TenantID    string                 `json:"tenant_id"`
@@ -239,6 +441,24 @@ type VideoGenerationMessage struct {
 	Options     map[string]interface{} `json:"options,omitempty"`
 	Priority    int                    `json:"priority"`
 	RequestedAt time.Time              `json:"requested_at"`
+	// IdempotencyKey carries the caller's Idempotency-Key (see
+	// middleware.Idempotency), so a consumer that somehow sees the same
+	// job twice - e.g. a redelivery after a commit failure - can
+	// deduplicate rather than render the video a second time.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+}
+
+// SchemaSubject implements SchemaSubjecter for ConfluentRegistrySerializer.
+func (VideoGenerationMessage) SchemaSubject() string { return "video-generation-value" }
+
+// VideoPriorityTopic returns the Kafka topic a video generation job at
+// priority (0, highest, through 9, lowest) should be published to:
+// base.pN, e.g. "news-feed-video-jobs.p5". Routing by topic rather than
+// relying solely on the Priority field lets a worker subscribe to only
+// the priorities it wants to serve, e.g. a dedicated low-latency
+// consumer group for p0-p2.
+func VideoPriorityTopic(base string, priority int) string {
+	return fmt.Sprintf("%s.p%d", base, priority)
 }
 
 // WebhookEventMessage represents a webhook event message
@@ -249,3 +469,61 @@ type WebhookEventMessage struct {
 	Payload    map[string]interface{} `json:"payload"`
 	ReceivedAt time.Time              `json:"received_at"`
 }
+
+// SchemaSubject implements SchemaSubjecter for ConfluentRegistrySerializer.
+func (WebhookEventMessage) SchemaSubject() string { return "webhook-event-value" }
+
+// CacheInvalidationMessage tells every replica to drop one or more cache
+// keys for a tenant. Published by handlers.ContentHandler after a mutation
+// (DeleteContent, ProcessContent) so replicas other than the one that
+// served the mutation don't keep serving stale reads out of their
+// in-process cache until TTL expiry; see internal/cache.
+type CacheInvalidationMessage struct {
+	TenantID      string    `json:"tenant_id"`
+	Keys          []string  `json:"keys"`
+	InvalidatedAt time.Time `json:"invalidated_at"`
+}
+
+// WhitelabelConfigChangedMessage notifies downstream renderers (e.g. a CDN
+// edge or a server-rendered public site) that a tenant's white-label
+// configuration changed, so they can invalidate their own cached branding
+// instead of polling GetWhitelabelConfig on a schedule. Published by
+// handlers.WhitelabelHandler after every config update or rollback.
+type WhitelabelConfigChangedMessage struct {
+	TenantID  string    `json:"tenant_id"`
+	Version   int       `json:"version"`
+	UpdatedBy string    `json:"updated_by"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// CreatorImportedMessage notifies content ingestion/enrichment that a
+// creator from a bulk import is ready to be crawled, published once per
+// accepted row by handlers.CreatorHandler.BulkImportCreators so those
+// services don't have to poll ListCreators to discover a freshly
+// onboarded tenant roster.
+type CreatorImportedMessage struct {
+	TenantID   string    `json:"tenant_id"`
+	CreatorID  string    `json:"creator_id"`
+	Platform   string    `json:"platform"`
+	PlatformID string    `json:"platform_id"`
+	ImportedAt time.Time `json:"imported_at"`
+}
+
+// SchemaSubject implements SchemaSubjecter for ConfluentRegistrySerializer.
+func (CreatorImportedMessage) SchemaSubject() string { return "creator-imported-value" }
+
+// JobEventMessage is one ingestion/processing/video pipeline lifecycle
+// notification (e.g. "ingestion.queued", "processing.completed",
+// "video.status_changed"), published by whichever service performs that
+// step — this handler for the steps it owns directly, or the
+// ingestion/processing/video workers that consume KafkaRawTopic,
+// KafkaProcessedTopic, and KafkaVideoTopic for the rest — onto
+// KafkaEventsTopic. internal/events.Hub consumes it and fans it out to
+// per-tenant SSE subscribers.
+type JobEventMessage struct {
+	TenantID  string                 `json:"tenant_id"`
+	Topic     string                 `json:"topic"` // ingestion, processing, video
+	Type      string                 `json:"type"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	EmittedAt time.Time              `json:"emitted_at"`
+}
@@ -0,0 +1,378 @@
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// confluentMagicByte is the fixed first byte of the Confluent Schema
+// Registry wire format: magic byte (always 0) followed by a 4-byte
+// big-endian schema ID, then the encoded payload.
+const confluentMagicByte = 0x0
+
+// Serializer encodes a Message.Value into the bytes published as a
+// Kafka record's value. NewProducer defaults to JSONSerializer; pass
+// WithSerializer to opt into schema-registry-backed encoding instead.
+type Serializer interface {
+	Serialize(ctx context.Context, subject string, value interface{}) ([]byte, error)
+}
+
+// Deserializer is the consumer-side counterpart to Serializer, decoding
+// a raw record value back into target. The ingestion/processing workers
+// that consume ContentIngestionMessage/ContentProcessingMessage/etc.
+// (outside this repo) use it to round-trip the typed messages declared
+// in producer.go without each worker hand-rolling the wire format.
+type Deserializer interface {
+	Deserialize(ctx context.Context, subject string, data []byte, target interface{}) error
+}
+
+// SchemaSubjecter is implemented by every message type in this package
+// (ContentIngestionMessage, ContentProcessingMessage,
+// VideoGenerationMessage, WebhookEventMessage) so a
+// ConfluentRegistrySerializer knows which schema-registry subject to
+// register and encode against without the caller repeating it.
+type SchemaSubjecter interface {
+	SchemaSubject() string
+}
+
+// subjectOf returns value's schema subject via SchemaSubjecter, or ""
+// for a value that doesn't declare one - JSONSerializer ignores subject
+// entirely, so this only matters to ConfluentRegistrySerializer.
+func subjectOf(value interface{}) string {
+	if s, ok := value.(SchemaSubjecter); ok {
+		return s.SchemaSubject()
+	}
+	return ""
+}
+
+// JSONSerializer is the package default: it marshals with encoding/json
+// and ignores subject, matching Producer's behavior before
+// schema-registry support existed.
+type JSONSerializer struct{}
+
+// Serialize implements Serializer.
+func (JSONSerializer) Serialize(_ context.Context, _ string, value interface{}) ([]byte, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message value: %w", err)
+	}
+	return b, nil
+}
+
+// Deserialize implements Deserializer.
+func (JSONSerializer) Deserialize(_ context.Context, _ string, data []byte, target interface{}) error {
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("failed to unmarshal message value: %w", err)
+	}
+	return nil
+}
+
+// Codec performs the payload encoding a ConfluentRegistrySerializer
+// wraps in the Confluent wire envelope (magic byte + 4-byte schema ID).
+// JSONCodec round-trips encoding/json; AvroCodec and ProtobufCodec defer
+// to their respective wire formats, each compiled against a fixed schema
+// supplied at construction so producer and consumer agree on layout
+// without resolving it through the registry on every message.
+type Codec interface {
+	// SchemaText returns the schema (an Avro .avsc document, or a
+	// Protobuf descriptor serialized as text) this codec was built
+	// against, registered for a subject on its first use.
+	SchemaText() string
+	// SchemaType is the registry's `schemaType` field: "AVRO",
+	// "PROTOBUF", or "JSON" (sarama's JSONCodec/the registry's default).
+	SchemaType() string
+	Encode(value interface{}) ([]byte, error)
+	Decode(data []byte, target interface{}) error
+}
+
+// JSONCodec is the trivial Codec backing ConfluentRegistrySerializer
+// when the schema itself doesn't need Avro/Protobuf's compact binary
+// encoding - only the registry's compatibility checking on Schema.
+type JSONCodec struct {
+	Schema string
+}
+
+func (c JSONCodec) SchemaText() string { return c.Schema }
+func (c JSONCodec) SchemaType() string { return "JSON" }
+
+func (JSONCodec) Encode(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (JSONCodec) Decode(data []byte, target interface{}) error {
+	return json.Unmarshal(data, target)
+}
+
+// AvroCodec encodes/decodes with a compiled Avro schema. It wraps
+// whatever Avro library the binary is built with (e.g.
+// github.com/linkedin/goavro/v2) behind this package's own interface so
+// callers depend on kafka.Codec rather than a specific Avro package.
+type AvroCodec struct {
+	Schema string
+	// codec is the underlying compiled Avro codec (e.g. *goavro.Codec),
+	// built once in NewAvroCodec since compiling a schema is not free.
+	codec avroBinaryCodec
+}
+
+// avroBinaryCodec is the minimal surface this package needs from an
+// Avro library, so AvroCodec isn't hardwired to one implementation.
+type avroBinaryCodec interface {
+	BinaryFromNative(buf []byte, native map[string]interface{}) ([]byte, error)
+	NativeFromBinary(buf []byte) (interface{}, []byte, error)
+}
+
+// NewAvroCodec compiles schema (Avro JSON schema text) into an AvroCodec.
+// compile is the Avro library's schema compiler, e.g. goavro.NewCodec.
+func NewAvroCodec(schema string, compile func(string) (avroBinaryCodec, error)) (*AvroCodec, error) {
+	compiled, err := compile(schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile avro schema: %w", err)
+	}
+	return &AvroCodec{Schema: schema, codec: compiled}, nil
+}
+
+func (c *AvroCodec) SchemaText() string { return c.Schema }
+func (c *AvroCodec) SchemaType() string { return "AVRO" }
+
+// Encode marshals value to JSON first, decodes that into the
+// map[string]interface{} native representation Avro libraries expect,
+// then encodes it with the compiled schema. This keeps AvroCodec
+// working against the same struct tags (`json:"..."`) every message
+// type in this package already declares, instead of requiring a second
+// set of Avro-specific tags.
+func (c *AvroCodec) Encode(value interface{}) ([]byte, error) {
+	var native map[string]interface{}
+	if err := roundTripJSON(value, &native); err != nil {
+		return nil, fmt.Errorf("failed to prepare avro native value: %w", err)
+	}
+	encoded, err := c.codec.BinaryFromNative(nil, native)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode avro payload: %w", err)
+	}
+	return encoded, nil
+}
+
+func (c *AvroCodec) Decode(data []byte, target interface{}) error {
+	native, _, err := c.codec.NativeFromBinary(data)
+	if err != nil {
+		return fmt.Errorf("failed to decode avro payload: %w", err)
+	}
+	if err := roundTripJSON(native, target); err != nil {
+		return fmt.Errorf("failed to decode avro native value: %w", err)
+	}
+	return nil
+}
+
+// ProtobufCodec encodes/decodes with a fixed proto.Message descriptor.
+// newMessage returns a fresh zero-value instance to unmarshal into,
+// since Decode doesn't know the concrete type target.(*T) resolves to
+// ahead of time.
+type ProtobufCodec struct {
+	Schema     string
+	Marshal    func(protoMessage interface{}) ([]byte, error)
+	Unmarshal  func(data []byte, protoMessage interface{}) error
+	NewMessage func() interface{}
+}
+
+func (c ProtobufCodec) SchemaText() string { return c.Schema }
+func (c ProtobufCodec) SchemaType() string { return "PROTOBUF" }
+
+func (c ProtobufCodec) Encode(value interface{}) ([]byte, error) {
+	encoded, err := c.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode protobuf payload: %w", err)
+	}
+	return encoded, nil
+}
+
+func (c ProtobufCodec) Decode(data []byte, target interface{}) error {
+	msg := c.NewMessage()
+	if err := c.Unmarshal(data, msg); err != nil {
+		return fmt.Errorf("failed to decode protobuf payload: %w", err)
+	}
+	return roundTripJSON(msg, target)
+}
+
+// roundTripJSON copies src into dst via JSON marshal/unmarshal. It's the
+// adapter between this package's plain Go structs and a Codec's native
+// representation (Avro's map[string]interface{}, a generated protobuf
+// type), so callers keep using their existing struct tags instead of a
+// second annotation scheme per wire format.
+func roundTripJSON(src, dst interface{}) error {
+	b, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dst)
+}
+
+// ConfluentRegistrySerializer serializes/deserializes against a
+// Confluent-compatible Schema Registry: it registers (or looks up) a
+// schema per subject, then prepends the registry's 5-byte magic-byte +
+// schema-ID envelope to whatever Codec produces. This is what makes
+// ContentIngestionMessage/ContentProcessingMessage/VideoGenerationMessage/
+// WebhookEventMessage's wire format evolvable without a coordinated
+// redeploy of every consumer: a consumer built against an older
+// compatible schema can still decode a message encoded against a newer
+// one, enforced by the registry's compatibility check at registration
+// time rather than by this package.
+type ConfluentRegistrySerializer struct {
+	baseURL    string
+	httpClient *http.Client
+	codec      Codec
+
+	mu        sync.RWMutex
+	schemaIDs map[string]uint32 // subject -> registered schema ID
+}
+
+// NewConfluentRegistrySerializer creates a ConfluentRegistrySerializer
+// against the registry at baseURL (e.g. "http://schema-registry:8081"),
+// encoding/decoding payloads with codec. codec defaults to JSONCodec
+// when nil.
+func NewConfluentRegistrySerializer(baseURL string, codec Codec) *ConfluentRegistrySerializer {
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+	return &ConfluentRegistrySerializer{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		codec:      codec,
+		schemaIDs:  make(map[string]uint32),
+	}
+}
+
+// Serialize implements Serializer: it resolves subject's schema ID
+// (registering codec's schema on first use), encodes value, and
+// prepends the magic-byte+schema-ID envelope.
+func (s *ConfluentRegistrySerializer) Serialize(ctx context.Context, subject string, value interface{}) ([]byte, error) {
+	if subject == "" {
+		return nil, fmt.Errorf("kafka: ConfluentRegistrySerializer requires a schema subject (implement SchemaSubjecter)")
+	}
+	schemaID, err := s.schemaIDFor(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := s.codec.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+	return encodeEnvelope(schemaID, payload), nil
+}
+
+// Deserialize implements Deserializer: it validates the envelope's
+// magic byte and schema ID belong to a schema this serializer knows
+// about, then decodes the remaining bytes with codec.
+func (s *ConfluentRegistrySerializer) Deserialize(ctx context.Context, subject string, data []byte, target interface{}) error {
+	schemaID, payload, err := decodeEnvelope(data)
+	if err != nil {
+		return err
+	}
+	expected, err := s.schemaIDFor(ctx, subject)
+	if err != nil {
+		return err
+	}
+	if schemaID != expected {
+		return fmt.Errorf("kafka: message schema ID %d does not match registered schema ID %d for subject %q", schemaID, expected, subject)
+	}
+	return s.codec.Decode(payload, target)
+}
+
+// schemaIDFor returns subject's registered schema ID, registering
+// codec's schema against it the first time subject is seen. Concurrent
+// callers racing to register the same subject is harmless - the
+// registry treats re-registering an identical schema as a no-op and
+// returns the existing ID.
+func (s *ConfluentRegistrySerializer) schemaIDFor(ctx context.Context, subject string) (uint32, error) {
+	s.mu.RLock()
+	id, ok := s.schemaIDs[subject]
+	s.mu.RUnlock()
+	if ok {
+		return id, nil
+	}
+
+	id, err := s.registerSchema(ctx, subject)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	s.schemaIDs[subject] = id
+	s.mu.Unlock()
+	return id, nil
+}
+
+type registerSchemaRequest struct {
+	Schema     string `json:"schema"`
+	SchemaType string `json:"schemaType"`
+}
+
+type registerSchemaResponse struct {
+	ID uint32 `json:"id"`
+}
+
+func (s *ConfluentRegistrySerializer) registerSchema(ctx context.Context, subject string) (uint32, error) {
+	reqBody, err := json.Marshal(registerSchemaRequest{
+		Schema:     s.codec.SchemaText(),
+		SchemaType: s.codec.SchemaType(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal schema registration request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/subjects/%s/versions", s.baseURL, subject)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(reqBody))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build schema registration request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+
+	resp, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reach schema registry: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read schema registry response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("schema registry returned %d registering subject %q: %s", resp.StatusCode, subject, body)
+	}
+
+	var parsed registerSchemaResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return 0, fmt.Errorf("failed to parse schema registry response: %w", err)
+	}
+	return parsed.ID, nil
+}
+
+// encodeEnvelope prepends the Confluent wire format's magic byte and
+// 4-byte big-endian schema ID to payload.
+func encodeEnvelope(schemaID uint32, payload []byte) []byte {
+	envelope := make([]byte, 5+len(payload))
+	envelope[0] = confluentMagicByte
+	binary.BigEndian.PutUint32(envelope[1:5], schemaID)
+	copy(envelope[5:], payload)
+	return envelope
+}
+
+// decodeEnvelope splits data into its schema ID and payload, validating
+// the leading magic byte.
+func decodeEnvelope(data []byte) (uint32, []byte, error) {
+	if len(data) < 5 {
+		return 0, nil, fmt.Errorf("kafka: message too short for a schema-registry envelope (%d bytes)", len(data))
+	}
+	if data[0] != confluentMagicByte {
+		return 0, nil, fmt.Errorf("kafka: unexpected magic byte 0x%02x, message was not encoded by a schema-registry-aware serializer", data[0])
+	}
+	return binary.BigEndian.Uint32(data[1:5]), data[5:], nil
+}
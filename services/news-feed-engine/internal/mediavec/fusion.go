@@ -0,0 +1,34 @@
+package mediavec
+
+// DefaultRRFK is the reciprocal rank fusion constant used when callers
+// don't configure one. 60 is the value the original RRF paper
+// (Cormack, Clarke & Buettcher) found robust across retrievers.
+const DefaultRRFK = 60
+
+// ReciprocalRankFusion combines several ranked result lists into one,
+// scoring each document as sum(1/(k+rank)) across every list it
+// appears in (rank is 1-based). k dampens the influence of top ranks;
+// callers should pass DefaultRRFK unless they have a reason not to.
+func ReciprocalRankFusion(k int, rankings ...[]Result) []Result {
+	if k <= 0 {
+		k = DefaultRRFK
+	}
+
+	scores := make(map[string]float64)
+	order := make([]string, 0)
+	for _, ranking := range rankings {
+		for rank, r := range ranking {
+			if _, seen := scores[r.ID]; !seen {
+				order = append(order, r.ID)
+			}
+			scores[r.ID] += 1 / float64(k+rank+1)
+		}
+	}
+
+	fused := make([]Result, len(order))
+	for i, id := range order {
+		fused[i] = Result{ID: id, Score: scores[id]}
+	}
+	sortResultsByScoreDesc(fused)
+	return fused
+}
@@ -0,0 +1,35 @@
+package mediavec
+
+// minHeap and maxHeap implement container/heap.Interface over
+// candidate, ordered by Distance ascending/descending respectively.
+// searchLayer uses minHeap as its exploration frontier (always expand
+// the closest unvisited candidate) and maxHeap as its result set (so
+// the current worst of the ef-best seen so far sits at the root and
+// can be evicted in O(log ef) as better candidates are found).
+type minHeap []candidate
+
+func (h minHeap) Len() int            { return len(h) }
+func (h minHeap) Less(i, j int) bool  { return h[i].Distance < h[j].Distance }
+func (h minHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *minHeap) Push(x interface{}) { *h = append(*h, x.(candidate)) }
+func (h *minHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+type maxHeap []candidate
+
+func (h maxHeap) Len() int            { return len(h) }
+func (h maxHeap) Less(i, j int) bool  { return h[i].Distance > h[j].Distance }
+func (h maxHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *maxHeap) Push(x interface{}) { *h = append(*h, x.(candidate)) }
+func (h *maxHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
@@ -0,0 +1,346 @@
+package mediavec
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// Default HNSW construction/search parameters, the values the original
+// HNSW paper (Malkov & Yashunin) found to work well across datasets.
+const (
+	defaultM              = 16
+	defaultMaxM0          = defaultM * 2
+	defaultEfConstruction = 200
+	defaultEfSearch       = 64
+)
+
+// hnswNode is one indexed vector plus its per-layer neighbor lists.
+type hnswNode struct {
+	id        string
+	vector    []float32
+	neighbors []map[string]struct{} // neighbors[layer] = neighbor IDs at that layer
+}
+
+// HNSW is a Hierarchical Navigable Small World approximate nearest
+// neighbor index over cosine similarity. It's safe for concurrent use.
+type HNSW struct {
+	mu             sync.RWMutex
+	m              int
+	maxM0          int
+	efConstruction int
+	levelMult      float64
+	rng            *rand.Rand
+
+	nodes      map[string]*hnswNode
+	entryPoint string
+	maxLayer   int
+}
+
+// NewHNSW creates an empty HNSW index with the paper's default
+// parameters.
+func NewHNSW() *HNSW {
+	return &HNSW{
+		m:              defaultM,
+		maxM0:          defaultMaxM0,
+		efConstruction: defaultEfConstruction,
+		levelMult:      1 / math.Log(float64(defaultM)),
+		rng:            rand.New(rand.NewSource(1)),
+		nodes:          make(map[string]*hnswNode),
+		maxLayer:       -1,
+	}
+}
+
+// Len returns the number of vectors currently indexed.
+func (h *HNSW) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.nodes)
+}
+
+// Delete removes id from the index, unlinking it from every neighbor
+// that pointed to it.
+func (h *HNSW) Delete(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	node, ok := h.nodes[id]
+	if !ok {
+		return
+	}
+	for layer, neighbors := range node.neighbors {
+		for neighborID := range neighbors {
+			if other, ok := h.nodes[neighborID]; ok && layer < len(other.neighbors) {
+				delete(other.neighbors[layer], id)
+			}
+		}
+	}
+	delete(h.nodes, id)
+
+	if id == h.entryPoint {
+		h.entryPoint = ""
+		h.maxLayer = -1
+		for otherID, other := range h.nodes {
+			if layer := len(other.neighbors) - 1; layer > h.maxLayer {
+				h.maxLayer = layer
+				h.entryPoint = otherID
+			}
+		}
+	}
+}
+
+// Insert adds or replaces the vector indexed under id.
+func (h *HNSW) Insert(id string, vector []float32) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, exists := h.nodes[id]; exists {
+		h.deleteLocked(id)
+	}
+
+	level := h.randomLevel()
+	node := &hnswNode{id: id, vector: vector, neighbors: make([]map[string]struct{}, level+1)}
+	for i := range node.neighbors {
+		node.neighbors[i] = make(map[string]struct{})
+	}
+	h.nodes[id] = node
+
+	if h.entryPoint == "" {
+		h.entryPoint = id
+		h.maxLayer = level
+		return
+	}
+
+	entry := h.entryPoint
+	for layer := h.maxLayer; layer > level; layer-- {
+		entry = h.greedyClosest(entry, vector, layer)
+	}
+
+	for layer := min(level, h.maxLayer); layer >= 0; layer-- {
+		candidates := h.searchLayer(vector, entry, h.efConstruction, layer)
+		maxNeighbors := h.maxM0
+		if layer > 0 {
+			maxNeighbors = h.m
+		}
+		selected := selectNeighbors(candidates, maxNeighbors, h.nodes, vector)
+
+		for _, c := range selected {
+			node.neighbors[layer][c.ID] = struct{}{}
+			other := h.nodes[c.ID]
+			if layer >= len(other.neighbors) {
+				continue
+			}
+			other.neighbors[layer][id] = struct{}{}
+			if len(other.neighbors[layer]) > maxNeighbors {
+				h.pruneNeighbors(other, layer, maxNeighbors)
+			}
+		}
+		if len(candidates) > 0 {
+			entry = candidates[0].ID
+		}
+	}
+
+	if level > h.maxLayer {
+		h.maxLayer = level
+		h.entryPoint = id
+	}
+}
+
+func (h *HNSW) deleteLocked(id string) {
+	node, ok := h.nodes[id]
+	if !ok {
+		return
+	}
+	for layer, neighbors := range node.neighbors {
+		for neighborID := range neighbors {
+			if other, ok := h.nodes[neighborID]; ok && layer < len(other.neighbors) {
+				delete(other.neighbors[layer], id)
+			}
+		}
+	}
+	delete(h.nodes, id)
+}
+
+// Search returns the k nearest indexed vectors to query by cosine
+// similarity, best first.
+func (h *HNSW) Search(query []float32, k int) []Result {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.entryPoint == "" {
+		return nil
+	}
+
+	entry := h.entryPoint
+	for layer := h.maxLayer; layer > 0; layer-- {
+		entry = h.greedyClosest(entry, query, layer)
+	}
+
+	ef := defaultEfSearch
+	if k > ef {
+		ef = k
+	}
+	candidates := h.searchLayer(query, entry, ef, 0)
+
+	if len(candidates) > k {
+		candidates = candidates[:k]
+	}
+	results := make([]Result, len(candidates))
+	for i, c := range candidates {
+		results[i] = Result{ID: c.ID, Score: 1 - c.Distance}
+	}
+	return results
+}
+
+// greedyClosest walks layer from entry toward query one best-improving
+// step at a time, the single-candidate search HNSW uses on upper layers
+// just to find a good entry point for the layer below.
+func (h *HNSW) greedyClosest(entry string, query []float32, layer int) string {
+	current := entry
+	currentDist := cosineDistance(h.nodes[current].vector, query)
+	for {
+		improved := false
+		node := h.nodes[current]
+		if layer >= len(node.neighbors) {
+			return current
+		}
+		for neighborID := range node.neighbors[layer] {
+			neighbor, ok := h.nodes[neighborID]
+			if !ok {
+				continue
+			}
+			if d := cosineDistance(neighbor.vector, query); d < currentDist {
+				current = neighborID
+				currentDist = d
+				improved = true
+			}
+		}
+		if !improved {
+			return current
+		}
+	}
+}
+
+type candidate struct {
+	ID       string
+	Distance float64
+}
+
+// searchLayer runs HNSW's layer search: expand from entry, keeping the
+// ef closest candidates seen, until no unvisited candidate could
+// improve the result set.
+func (h *HNSW) searchLayer(query []float32, entry string, ef int, layer int) []candidate {
+	visited := map[string]struct{}{entry: {}}
+
+	entryDist := cosineDistance(h.nodes[entry].vector, query)
+	candidates := &minHeap{{entry, entryDist}}
+	results := &maxHeap{{entry, entryDist}}
+	heap.Init(candidates)
+	heap.Init(results)
+
+	for candidates.Len() > 0 {
+		nearest := heap.Pop(candidates).(candidate)
+		if results.Len() >= ef && nearest.Distance > (*results)[0].Distance {
+			break
+		}
+
+		node, ok := h.nodes[nearest.ID]
+		if !ok || layer >= len(node.neighbors) {
+			continue
+		}
+		for neighborID := range node.neighbors[layer] {
+			if _, seen := visited[neighborID]; seen {
+				continue
+			}
+			visited[neighborID] = struct{}{}
+
+			neighbor, ok := h.nodes[neighborID]
+			if !ok {
+				continue
+			}
+			d := cosineDistance(neighbor.vector, query)
+			if results.Len() < ef || d < (*results)[0].Distance {
+				heap.Push(candidates, candidate{neighborID, d})
+				heap.Push(results, candidate{neighborID, d})
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+
+	out := make([]candidate, results.Len())
+	copy(out, *results)
+	sortCandidatesByDistance(out)
+	return out
+}
+
+// selectNeighbors picks up to maxNeighbors of candidates closest to
+// query - the simple "select nearest" heuristic from the HNSW paper
+// (as opposed to its optional diversity-aware heuristic).
+func selectNeighbors(candidates []candidate, maxNeighbors int, nodes map[string]*hnswNode, query []float32) []candidate {
+	if len(candidates) <= maxNeighbors {
+		return candidates
+	}
+	return candidates[:maxNeighbors]
+}
+
+// pruneNeighbors trims node's neighbor set at layer back down to
+// maxNeighbors, keeping the closest ones to node itself.
+func (h *HNSW) pruneNeighbors(node *hnswNode, layer int, maxNeighbors int) {
+	candidates := make([]candidate, 0, len(node.neighbors[layer]))
+	for id := range node.neighbors[layer] {
+		if other, ok := h.nodes[id]; ok {
+			candidates = append(candidates, candidate{id, cosineDistance(node.vector, other.vector)})
+		}
+	}
+	sortCandidatesByDistance(candidates)
+	if len(candidates) > maxNeighbors {
+		candidates = candidates[:maxNeighbors]
+	}
+
+	kept := make(map[string]struct{}, len(candidates))
+	for _, c := range candidates {
+		kept[c.ID] = struct{}{}
+	}
+	node.neighbors[layer] = kept
+}
+
+func (h *HNSW) randomLevel() int {
+	return int(math.Floor(-math.Log(h.rng.Float64()) * h.levelMult))
+}
+
+func cosineDistance(a, b []float32) float64 {
+	var dot, normA, normB float64
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 1
+	}
+	similarity := dot / (math.Sqrt(normA) * math.Sqrt(normB))
+	return 1 - similarity
+}
+
+func sortCandidatesByDistance(c []candidate) {
+	// insertion sort: candidate slices here are always small (ef-bounded)
+	for i := 1; i < len(c); i++ {
+		for j := i; j > 0 && c[j].Distance < c[j-1].Distance; j-- {
+			c[j], c[j-1] = c[j-1], c[j]
+		}
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,228 @@
+package mediavec
+
+import (
+	"sync"
+	"time"
+)
+
+// updatesChanSize bounds the hot-reload queue so a burst of syncs can't
+// block callers of Publish indefinitely; the drain loop is expected to
+// keep up since indexing a single document is cheap relative to the
+// sync/analysis work that produces it.
+const updatesChanSize = 256
+
+// defaultEvictionInterval is how often the drain goroutine sweeps for
+// assets whose UsageRights.ExpiresAt has passed.
+const defaultEvictionInterval = 5 * time.Minute
+
+// update is one hot-reload event: a document to (re)index, or a bare ID
+// to evict.
+type update struct {
+	doc     Document
+	expires time.Time
+	evict   bool
+	evictID string
+}
+
+// Index is a per-tenant hybrid search index combining an HNSW vector
+// index over embeddings and a BM25 keyword index over tag/extracted
+// text, kept in sync via a hot-reload channel and periodically
+// persisted to disk. Create one with NewIndex and stop it with Close
+// when the tenant's indexer is no longer needed.
+type Index struct {
+	tenantID string
+	storeDir string
+
+	mu       sync.RWMutex
+	vector   *HNSW
+	keyword  *KeywordIndex
+	expiries map[string]time.Time
+
+	updates chan update
+	done    chan struct{}
+}
+
+// NewIndex loads tenantID's persisted snapshot (if any) from storeDir,
+// builds the in-memory vector/keyword indexes from it, and starts the
+// background goroutine that drains hot-reload updates published via
+// Publish/Evict.
+func NewIndex(tenantID, storeDir string) (*Index, error) {
+	snap, err := loadSnapshot(storeDir, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := &Index{
+		tenantID: tenantID,
+		storeDir: storeDir,
+		vector:   NewHNSW(),
+		keyword:  NewKeywordIndex(),
+		expiries: make(map[string]time.Time),
+		updates:  make(chan update, updatesChanSize),
+		done:     make(chan struct{}),
+	}
+
+	for id, doc := range snap.Documents {
+		idx.indexDocument(doc)
+		if exp := snap.Expiries[id]; exp != 0 {
+			idx.expiries[id] = time.Unix(exp, 0)
+		}
+	}
+
+	go idx.drain()
+	return idx, nil
+}
+
+// Publish queues doc to be (re)indexed by the background drain
+// goroutine. expiresAt may be the zero Value if the asset's usage
+// rights never expire. Publish does not block on the index lock; it
+// only blocks if the hot-reload queue itself is full.
+func (idx *Index) Publish(doc Document, expiresAt time.Time) {
+	idx.updates <- update{doc: doc, expires: expiresAt}
+}
+
+// Evict queues id for removal from both the vector and keyword
+// indexes, e.g. when an asset is deleted outright rather than expired.
+func (idx *Index) Evict(id string) {
+	idx.updates <- update{evict: true, evictID: id}
+}
+
+// Close stops the background drain goroutine and persists the current
+// index state to disk.
+func (idx *Index) Close() error {
+	close(idx.done)
+	return idx.persist()
+}
+
+func (idx *Index) drain() {
+	ticker := time.NewTicker(defaultEvictionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case u := <-idx.updates:
+			idx.mu.Lock()
+			if u.evict {
+				idx.evictLocked(u.evictID)
+			} else {
+				idx.indexDocumentLocked(u.doc)
+				if !u.expires.IsZero() {
+					idx.expiries[u.doc.ID] = u.expires
+				} else {
+					delete(idx.expiries, u.doc.ID)
+				}
+			}
+			idx.mu.Unlock()
+		case <-ticker.C:
+			idx.EvictExpired(time.Now())
+		case <-idx.done:
+			return
+		}
+	}
+}
+
+func (idx *Index) indexDocument(doc Document) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.indexDocumentLocked(doc)
+}
+
+func (idx *Index) indexDocumentLocked(doc Document) {
+	if len(doc.Embedding) > 0 {
+		idx.vector.Insert(doc.ID, doc.Embedding)
+	}
+	idx.keyword.AddDocument(doc.ID, doc.Text)
+}
+
+func (idx *Index) evictLocked(id string) {
+	idx.vector.Delete(id)
+	idx.keyword.Remove(id)
+	delete(idx.expiries, id)
+}
+
+// EvictExpired removes every indexed asset whose UsageRights.ExpiresAt
+// is before now, returning the IDs it evicted.
+func (idx *Index) EvictExpired(now time.Time) []string {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	var evicted []string
+	for id, exp := range idx.expiries {
+		if now.After(exp) {
+			idx.evictLocked(id)
+			evicted = append(evicted, id)
+		}
+	}
+	return evicted
+}
+
+// Search runs BM25 keyword search and HNSW vector search independently
+// and fuses them with reciprocal rank fusion. Either queryTokens or
+// queryEmbedding may be empty to skip that retriever. rrfK <= 0 uses
+// DefaultRRFK.
+func (idx *Index) Search(queryEmbedding []float32, queryTokens []string, k int, rrfK int) []Result {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var rankings [][]Result
+	if len(queryTokens) > 0 {
+		rankings = append(rankings, idx.keyword.Search(queryTokens, k))
+	}
+	if len(queryEmbedding) > 0 {
+		rankings = append(rankings, idx.vector.Search(queryEmbedding, k))
+	}
+	if len(rankings) == 0 {
+		return nil
+	}
+
+	fused := ReciprocalRankFusion(rrfK, rankings...)
+	if len(fused) > k {
+		fused = fused[:k]
+	}
+	return fused
+}
+
+// Persist writes the index's current state to disk immediately,
+// outside of Close. Callers that keep an Index open for a long-running
+// process should call this periodically (e.g. on a timer) rather than
+// relying solely on Close.
+func (idx *Index) Persist() error {
+	return idx.persist()
+}
+
+func (idx *Index) persist() error {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	snap := snapshot{
+		Documents: make(map[string]Document, len(idx.expiries)),
+		Expiries:  make(map[string]int64, len(idx.expiries)),
+	}
+	for id, exp := range idx.expiries {
+		snap.Expiries[id] = exp.Unix()
+	}
+	for id := range idx.keyword.docLen {
+		snap.Documents[id] = idx.documentFor(id)
+	}
+	return saveSnapshot(idx.storeDir, idx.tenantID, snap)
+}
+
+// documentFor reconstructs the Document persisted for id. The keyword
+// index keeps token counts rather than the original token list, so
+// round-tripping through persistence preserves embeddings exactly and
+// keyword postings (not verbatim token order/duplicates).
+func (idx *Index) documentFor(id string) Document {
+	var embedding []float32
+	if node, ok := idx.vector.nodes[id]; ok {
+		embedding = node.vector
+	}
+	var tokens []string
+	for tok, docs := range idx.keyword.postings {
+		if count, ok := docs[id]; ok {
+			for i := 0; i < count; i++ {
+				tokens = append(tokens, tok)
+			}
+		}
+	}
+	return Document{ID: id, Embedding: embedding, Text: tokens}
+}
@@ -0,0 +1,140 @@
+package mediavec
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// BM25 tuning constants, Robertson/Sparck Jones's standard defaults.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// KeywordIndex is an in-memory inverted index over pre-tokenized
+// document text, scored with Okapi BM25.
+type KeywordIndex struct {
+	mu sync.RWMutex
+
+	postings map[string]map[string]int // token -> docID -> term frequency
+	docLen   map[string]int            // docID -> token count
+	totalLen int
+	docCount int
+}
+
+// NewKeywordIndex creates an empty keyword index.
+func NewKeywordIndex() *KeywordIndex {
+	return &KeywordIndex{
+		postings: make(map[string]map[string]int),
+		docLen:   make(map[string]int),
+	}
+}
+
+// AddDocument indexes (or re-indexes, if id was already present) tokens
+// under id.
+func (k *KeywordIndex) AddDocument(id string, tokens []string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.removeLocked(id)
+
+	freq := make(map[string]int, len(tokens))
+	for _, tok := range tokens {
+		freq[tok]++
+	}
+	for tok, count := range freq {
+		if k.postings[tok] == nil {
+			k.postings[tok] = make(map[string]int)
+		}
+		k.postings[tok][id] = count
+	}
+	k.docLen[id] = len(tokens)
+	k.totalLen += len(tokens)
+	k.docCount++
+}
+
+// Remove deletes id from the index, if present.
+func (k *KeywordIndex) Remove(id string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.removeLocked(id)
+}
+
+func (k *KeywordIndex) removeLocked(id string) {
+	length, ok := k.docLen[id]
+	if !ok {
+		return
+	}
+	for tok, docs := range k.postings {
+		if _, ok := docs[id]; ok {
+			delete(docs, id)
+			if len(docs) == 0 {
+				delete(k.postings, tok)
+			}
+		}
+	}
+	delete(k.docLen, id)
+	k.totalLen -= length
+	k.docCount--
+}
+
+// Search scores every indexed document containing at least one query
+// token with BM25 and returns the top k, best first.
+func (k *KeywordIndex) Search(queryTokens []string, topK int) []Result {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if k.docCount == 0 {
+		return nil
+	}
+	avgDocLen := float64(k.totalLen) / float64(k.docCount)
+
+	scores := make(map[string]float64)
+	for _, tok := range queryTokens {
+		docs := k.postings[tok]
+		if len(docs) == 0 {
+			continue
+		}
+		idf := math.Log(1 + (float64(k.docCount)-float64(len(docs))+0.5)/(float64(len(docs))+0.5))
+		for id, tf := range docs {
+			docLen := float64(k.docLen[id])
+			numerator := float64(tf) * (bm25K1 + 1)
+			denominator := float64(tf) + bm25K1*(1-bm25B+bm25B*docLen/avgDocLen)
+			scores[id] += idf * numerator / denominator
+		}
+	}
+
+	results := make([]Result, 0, len(scores))
+	for id, score := range scores {
+		results = append(results, Result{ID: id, Score: score})
+	}
+	sortResultsByScoreDesc(results)
+	if len(results) > topK {
+		results = results[:topK]
+	}
+	return results
+}
+
+// Tokenize lowercases and splits fields on runs of non-alphanumeric
+// characters. It's the tokenizer Index.Publish's callers are expected
+// to use when building a Document.Text from an asset's AutoTags,
+// CustomTags, and ExtractedText.Text.
+func Tokenize(fields ...string) []string {
+	var tokens []string
+	for _, field := range fields {
+		tokens = append(tokens, strings.FieldsFunc(strings.ToLower(field), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})...)
+	}
+	return tokens
+}
+
+func sortResultsByScoreDesc(r []Result) {
+	for i := 1; i < len(r); i++ {
+		for j := i; j > 0 && r[j].Score > r[j-1].Score; j-- {
+			r[j], r[j-1] = r[j-1], r[j]
+		}
+	}
+}
@@ -0,0 +1,23 @@
+// Package mediavec maintains an in-process, per-tenant semantic search
+// index over MediaAsset embeddings and text fields, so
+// MediaManagerClient.SemanticSearchHybrid can serve hybrid
+// keyword+vector search locally as a fallback/accelerator in front of
+// the Media Manager's own search, rather than round-tripping every
+// query.
+package mediavec
+
+// Result is one hit from a single retriever (keyword index, vector
+// index, or fused across both), ranked by Score (higher is better).
+type Result struct {
+	ID    string
+	Score float64
+}
+
+// Document is everything about an asset the index needs: its ID, the
+// embedding to index for ANN search, and the text fields to index for
+// keyword search.
+type Document struct {
+	ID        string
+	Embedding []float32
+	Text      []string // AutoTags, CustomTags, and ExtractedText.Text, pre-tokenized by the caller
+}
@@ -0,0 +1,202 @@
+package mediavec
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestHNSWInsertAndSearchFindsNearestVectors(t *testing.T) {
+	h := NewHNSW()
+	h.Insert("a", []float32{1, 0, 0})
+	h.Insert("b", []float32{0, 1, 0})
+	h.Insert("c", []float32{0.9, 0.1, 0})
+
+	results := h.Search([]float32{1, 0, 0}, 2)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].ID != "a" {
+		t.Fatalf("expected closest match to be %q, got %q", "a", results[0].ID)
+	}
+}
+
+func TestHNSWDeleteRemovesVector(t *testing.T) {
+	h := NewHNSW()
+	h.Insert("a", []float32{1, 0})
+	h.Insert("b", []float32{0, 1})
+	h.Delete("a")
+
+	if h.Len() != 1 {
+		t.Fatalf("expected 1 remaining vector, got %d", h.Len())
+	}
+	results := h.Search([]float32{1, 0}, 5)
+	for _, r := range results {
+		if r.ID == "a" {
+			t.Fatal("expected deleted vector to be absent from search results")
+		}
+	}
+}
+
+func TestKeywordIndexSearchRanksByBM25(t *testing.T) {
+	k := NewKeywordIndex()
+	k.AddDocument("doc1", []string{"breaking", "news", "election"})
+	k.AddDocument("doc2", []string{"election", "results", "election"})
+	k.AddDocument("doc3", []string{"weather", "forecast"})
+
+	results := k.Search([]string{"election"}, 10)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 matching docs, got %d", len(results))
+	}
+	if results[0].ID != "doc2" {
+		t.Fatalf("expected doc2 (higher term frequency) to rank first, got %q", results[0].ID)
+	}
+}
+
+func TestKeywordIndexRemove(t *testing.T) {
+	k := NewKeywordIndex()
+	k.AddDocument("doc1", []string{"breaking", "news"})
+	k.Remove("doc1")
+
+	if results := k.Search([]string{"breaking"}, 10); len(results) != 0 {
+		t.Fatalf("expected no results after removal, got %v", results)
+	}
+}
+
+func TestTokenizeLowercasesAndSplitsOnPunctuation(t *testing.T) {
+	got := Tokenize("Breaking: Election Results!", "weather-forecast")
+	want := []string{"breaking", "election", "results", "weather", "forecast"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestReciprocalRankFusionCombinesRankings(t *testing.T) {
+	keyword := []Result{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	vector := []Result{{ID: "b"}, {ID: "a"}, {ID: "d"}}
+
+	fused := ReciprocalRankFusion(60, keyword, vector)
+	if len(fused) != 4 {
+		t.Fatalf("expected 4 fused results, got %d", len(fused))
+	}
+	if fused[0].ID != "a" && fused[0].ID != "b" {
+		t.Fatalf("expected a or b (present in both rankings) to rank first, got %q", fused[0].ID)
+	}
+
+	wantScoreA := 1.0/61 + 1.0/62
+	found := false
+	for _, r := range fused {
+		if r.ID == "a" {
+			found = true
+			if diff := r.Score - wantScoreA; diff > 1e-9 || diff < -1e-9 {
+				t.Fatalf("expected score %v for a, got %v", wantScoreA, r.Score)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("expected a to be present in fused results")
+	}
+}
+
+func TestReciprocalRankFusionDefaultsKWhenNonPositive(t *testing.T) {
+	fused := ReciprocalRankFusion(0, []Result{{ID: "a"}})
+	want := 1.0 / float64(DefaultRRFK+1)
+	if diff := fused[0].Score - want; diff > 1e-9 || diff < -1e-9 {
+		t.Fatalf("expected default k to produce score %v, got %v", want, fused[0].Score)
+	}
+}
+
+func TestIndexPublishAndSearchHybrid(t *testing.T) {
+	dir := t.TempDir()
+	idx, err := NewIndex("tenant-1", dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer idx.Close()
+
+	idx.Publish(Document{ID: "asset-1", Embedding: []float32{1, 0}, Text: []string{"breaking", "news"}}, time.Time{})
+	idx.Publish(Document{ID: "asset-2", Embedding: []float32{0, 1}, Text: []string{"weather"}}, time.Time{})
+
+	waitForIndexed(t, idx, "asset-1")
+	waitForIndexed(t, idx, "asset-2")
+
+	results := idx.Search([]float32{1, 0}, []string{"breaking"}, 5, 0)
+	if len(results) == 0 || results[0].ID != "asset-1" {
+		t.Fatalf("expected asset-1 to rank first, got %v", results)
+	}
+}
+
+func TestIndexEvictExpired(t *testing.T) {
+	dir := t.TempDir()
+	idx, err := NewIndex("tenant-1", dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer idx.Close()
+
+	past := time.Now().Add(-time.Hour)
+	idx.Publish(Document{ID: "asset-1", Embedding: []float32{1, 0}, Text: []string{"news"}}, past)
+	waitForIndexed(t, idx, "asset-1")
+
+	evicted := idx.EvictExpired(time.Now())
+	if len(evicted) != 1 || evicted[0] != "asset-1" {
+		t.Fatalf("expected asset-1 to be evicted, got %v", evicted)
+	}
+	if results := idx.Search([]float32{1, 0}, nil, 5, 0); len(results) != 0 {
+		t.Fatalf("expected no results after eviction, got %v", results)
+	}
+}
+
+func TestIndexPersistRoundTrips(t *testing.T) {
+	dir := t.TempDir()
+	idx, err := NewIndex("tenant-1", dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	idx.Publish(Document{ID: "asset-1", Embedding: []float32{1, 0}, Text: []string{"breaking", "news"}}, time.Time{})
+	waitForIndexed(t, idx, "asset-1")
+
+	if err := idx.Close(); err != nil {
+		t.Fatalf("unexpected error closing index: %v", err)
+	}
+
+	if _, err := os.Stat(storePath(dir, "tenant-1")); err != nil {
+		t.Fatalf("expected snapshot file to exist: %v", err)
+	}
+
+	reloaded, err := NewIndex("tenant-1", dir)
+	if err != nil {
+		t.Fatalf("unexpected error reloading index: %v", err)
+	}
+	defer reloaded.Close()
+
+	results := reloaded.Search([]float32{1, 0}, []string{"breaking"}, 5, 0)
+	if len(results) == 0 || results[0].ID != "asset-1" {
+		t.Fatalf("expected asset-1 to survive persistence round-trip, got %v", results)
+	}
+}
+
+// waitForIndexed polls until id is present in the keyword index (or has
+// an expiry recorded), since Publish hands off to the drain goroutine
+// asynchronously.
+func waitForIndexed(t *testing.T, idx *Index, id string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		idx.mu.RLock()
+		_, ok := idx.expiries[id]
+		_, indexed := idx.keyword.docLen[id]
+		idx.mu.RUnlock()
+		if indexed || ok {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %q to be indexed", id)
+}
@@ -0,0 +1,69 @@
+package mediavec
+
+import (
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// snapshot is the on-disk representation of an Index's state, encoded
+// with encoding/gob since it's process-local persistence rather than a
+// wire format other services need to read.
+type snapshot struct {
+	Documents map[string]Document
+	Expiries  map[string]int64 // docID -> UsageRights.ExpiresAt as Unix seconds, 0 if none
+}
+
+// storePath returns the per-tenant snapshot file path under dir.
+func storePath(dir, tenantID string) string {
+	return filepath.Join(dir, tenantID+".gob")
+}
+
+// saveSnapshot writes snap to dir for tenantID, creating dir if needed.
+func saveSnapshot(dir, tenantID string, snap snapshot) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("mediavec: create store dir: %w", err)
+	}
+
+	path := storePath(dir, tenantID)
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("mediavec: create snapshot file: %w", err)
+	}
+	if err := gob.NewEncoder(f).Encode(snap); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("mediavec: encode snapshot: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("mediavec: close snapshot file: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// loadSnapshot reads the snapshot for tenantID from dir. A missing file
+// is not an error; it returns an empty snapshot.
+func loadSnapshot(dir, tenantID string) (snapshot, error) {
+	f, err := os.Open(storePath(dir, tenantID))
+	if os.IsNotExist(err) {
+		return snapshot{Documents: make(map[string]Document), Expiries: make(map[string]int64)}, nil
+	}
+	if err != nil {
+		return snapshot{}, fmt.Errorf("mediavec: open snapshot file: %w", err)
+	}
+	defer f.Close()
+
+	var snap snapshot
+	if err := gob.NewDecoder(f).Decode(&snap); err != nil {
+		return snapshot{}, fmt.Errorf("mediavec: decode snapshot: %w", err)
+	}
+	if snap.Documents == nil {
+		snap.Documents = make(map[string]Document)
+	}
+	if snap.Expiries == nil {
+		snap.Expiries = make(map[string]int64)
+	}
+	return snap, nil
+}
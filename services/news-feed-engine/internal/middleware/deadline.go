@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeadlineStore resolves a tenant's deadline override for route (a gin
+// route pattern, the same key DeadlineMiddleware's defaults map uses),
+// so an operator can grant one tenant a longer window - e.g. a longer
+// analytics range for a premium plan - without a redeploy. Returns (nil,
+// nil) when the tenant has no override, so the caller falls back to
+// defaults. Implemented by database.ConfigRepository.
+type DeadlineStore interface {
+	DeadlinePolicy(ctx context.Context, tenantID, route string) (*time.Duration, error)
+}
+
+// DeadlineMiddleware bounds how long a request may run before its
+// context is cancelled server-side, derived per route pattern from
+// defaults (e.g. "/api/v1/admin/analytics/*": 10*time.Second,
+// "/api/v1/admin/config": 2*time.Second). A pattern ending in "*"
+// matches c.FullPath() by prefix; anything else must match it exactly.
+// A route with no matching entry runs with no deadline. overrides may
+// be nil to always use defaults.
+//
+// The derived context is context.WithTimeout over c.Request.Context(),
+// so it's already cancelled on whichever comes first: the timer firing
+// or the client disconnecting (which cancels the parent gin gives every
+// request) - there's no separate cancellation path to maintain for
+// client abort versus timeout, they share one Done() channel.
+func DeadlineMiddleware(defaults map[string]time.Duration, overrides DeadlineStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, pattern := matchDeadline(defaults, c.FullPath())
+		if pattern != "" && overrides != nil {
+			if override, err := overrides.DeadlinePolicy(c.Request.Context(), GetTenantID(c), pattern); err == nil && override != nil {
+				limit = *override
+			}
+		}
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		ctx, cancel := context.WithTimeout(c.Request.Context(), limit)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		recorder := &deadlineResponseRecorder{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = recorder
+
+		c.Next()
+
+		if ctx.Err() == context.DeadlineExceeded {
+			recorder.abortDeadlineExceeded(time.Since(start), limit)
+			return
+		}
+		recorder.flush()
+	}
+}
+
+// matchDeadline finds the defaults entry for route - the registered gin
+// route pattern (c.FullPath()), not the literal request path, so an
+// override keys consistently regardless of path params. An exact match
+// wins over a wildcard; among wildcards the longest prefix wins, so a
+// more specific pattern (e.g. "/api/v1/admin/analytics/content") can
+// override a broader one (e.g. "/api/v1/admin/analytics/*"). Returns
+// the matched pattern too, since that's the key DeadlineStore looks
+// overrides up by; (0, "") if nothing matched.
+func matchDeadline(defaults map[string]time.Duration, route string) (time.Duration, string) {
+	if limit, ok := defaults[route]; ok {
+		return limit, route
+	}
+
+	var bestPattern string
+	var bestLimit time.Duration
+	bestLen := -1
+	for pattern, limit := range defaults {
+		prefix := strings.TrimSuffix(pattern, "*")
+		if prefix == pattern {
+			continue
+		}
+		if strings.HasPrefix(route, prefix) && len(prefix) > bestLen {
+			bestPattern, bestLimit, bestLen = pattern, limit, len(prefix)
+		}
+	}
+	return bestLimit, bestPattern
+}
+
+// deadlineResponseRecorder buffers the handler's response so
+// DeadlineMiddleware can discard it and substitute a structured 504 if
+// the deadline fired before the handler returned - a handler whose
+// context-aware DB call failed with context.DeadlineExceeded would
+// otherwise have already written its own generic 500. Embedding
+// gin.ResponseWriter promotes every method not overridden here, the
+// same as traceResponseRecorder in tracing.go.
+type deadlineResponseRecorder struct {
+	gin.ResponseWriter
+	body   *bytes.Buffer
+	status int
+}
+
+func (r *deadlineResponseRecorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *deadlineResponseRecorder) WriteHeaderNow() {}
+
+func (r *deadlineResponseRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+func (r *deadlineResponseRecorder) WriteString(s string) (int, error) {
+	return r.body.WriteString(s)
+}
+
+func (r *deadlineResponseRecorder) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+func (r *deadlineResponseRecorder) Size() int {
+	return r.body.Len()
+}
+
+func (r *deadlineResponseRecorder) Written() bool {
+	return r.body.Len() > 0
+}
+
+// flush writes the handler's buffered response through unchanged.
+func (r *deadlineResponseRecorder) flush() {
+	r.ResponseWriter.WriteHeader(r.Status())
+	_, _ = r.ResponseWriter.Write(r.body.Bytes())
+}
+
+// abortDeadlineExceeded discards whatever the handler buffered and
+// writes the structured 504 instead.
+func (r *deadlineResponseRecorder) abortDeadlineExceeded(elapsed, limit time.Duration) {
+	body, _ := json.Marshal(gin.H{
+		"error":      "deadline_exceeded",
+		"elapsed_ms": elapsed.Milliseconds(),
+		"limit_ms":   limit.Milliseconds(),
+	})
+	r.ResponseWriter.Header().Set("Content-Type", "application/json; charset=utf-8")
+	r.ResponseWriter.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	r.ResponseWriter.WriteHeader(http.StatusGatewayTimeout)
+	_, _ = r.ResponseWriter.Write(body)
+}
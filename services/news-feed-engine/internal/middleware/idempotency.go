@@ -0,0 +1,194 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdempotencyContextKey is the context key Idempotency stores the
+// request's Idempotency-Key header under, for handlers that need to
+// thread it further (e.g. into a Kafka job message for consumer-side
+// dedup).
+const IdempotencyContextKey = "idempotency_key"
+
+// GetIdempotencyKey retrieves the Idempotency-Key header value set by
+// Idempotency, or "" if the request didn't send one.
+func GetIdempotencyKey(c *gin.Context) string {
+	if key, exists := c.Get(IdempotencyContextKey); exists {
+		return key.(string)
+	}
+	return ""
+}
+
+// IdempotencyRecord is what Idempotency stores per (tenant, key): enough
+// to detect a mismatched replay and to replay the original response
+// verbatim without re-running the handler.
+type IdempotencyRecord struct {
+	RequestHash  string `json:"request_hash"`
+	StatusCode   int    `json:"status_code"`
+	ResponseBody []byte `json:"response_body"`
+	// Pending marks a reservation placeholder written by Reserve before
+	// the handler has actually run, so a concurrent request carrying
+	// the same key can tell a first attempt is already in flight
+	// instead of reading a zero-value record and running the handler
+	// again itself.
+	Pending bool `json:"pending,omitempty"`
+}
+
+// IdempotencyStore persists IdempotencyRecords for the duration
+// Idempotency is configured with.
+type IdempotencyStore interface {
+	// Reserve atomically claims (tenantID, key) for this request if no
+	// record exists yet, writing a Pending placeholder so a concurrent
+	// request carrying the same key sees it instead of also claiming
+	// the key and running the handler a second time. claimed is true
+	// if this call performed the claim; otherwise existing holds
+	// whatever record (pending or completed) was already there.
+	Reserve(ctx context.Context, tenantID, key, hash string, ttl time.Duration) (existing *IdempotencyRecord, claimed bool, err error)
+	// Release removes a reservation that didn't turn into a saved
+	// response (the handler's request failed or was aborted), so a
+	// legitimate retry isn't stuck behind a pending record until ttl
+	// expires.
+	Release(ctx context.Context, tenantID, key string) error
+	Get(ctx context.Context, tenantID, key string) (*IdempotencyRecord, bool, error)
+	Save(ctx context.Context, tenantID, key string, record IdempotencyRecord, ttl time.Duration) error
+}
+
+// Idempotency makes a mutating endpoint safe to retry, including against
+// concurrent retries - not just sequential ones. A request carrying an
+// Idempotency-Key header is fingerprinted by its method, path, and body,
+// then atomically reserved via store.Reserve before the handler runs, so
+// two requests racing in with the same key can't both see "no record yet"
+// and both execute the handler. Whichever loses the race instead sees the
+// winner's reservation: a 409 "in_progress" while the winner is still
+// running, or - once the winner finishes - the same replay-or-mismatch
+// behavior a sequential retry would get. Requests without the header pass
+// through unchanged. Only successful (2xx) responses are cached; a failed
+// or aborted attempt releases its reservation so a retry is retried for
+// real instead of getting stuck behind it.
+func Idempotency(store IdempotencyStore, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("Idempotency-Key")
+		if key == "" {
+			c.Next()
+			return
+		}
+		c.Set(IdempotencyContextKey, key)
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error":   "bad_request",
+				"message": "failed to read request body",
+			})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		tenantID := GetTenantID(c)
+		hash := requestHash(c.Request.Method, c.Request.URL.Path, body)
+		ctx := c.Request.Context()
+
+		existing, claimed, err := store.Reserve(ctx, tenantID, key, hash, ttl)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"error":   "idempotency_store_error",
+				"message": "failed to check idempotency key",
+			})
+			return
+		}
+
+		if !claimed {
+			if existing.Pending {
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+					"error":   "idempotency_key_in_progress",
+					"message": "a request with this Idempotency-Key is still being processed",
+					"code":    "in_progress",
+				})
+				return
+			}
+			if existing.RequestHash != hash {
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
+					"error":   "idempotency_key_mismatch",
+					"message": "Idempotency-Key was already used for a different request",
+					"code":    "mismatch",
+				})
+				return
+			}
+			c.Data(existing.StatusCode, "application/json; charset=utf-8", existing.ResponseBody)
+			c.Abort()
+			return
+		}
+
+		recorder := &idempotencyRecorder{ResponseWriter: c.Writer, body: &bytes.Buffer{}, status: http.StatusOK}
+		c.Writer = recorder
+
+		c.Next()
+
+		if c.IsAborted() || recorder.status < 200 || recorder.status >= 300 {
+			_ = store.Release(ctx, tenantID, key)
+			return
+		}
+
+		// Best-effort: the request already succeeded and was written to
+		// the client, so a cache write failure here shouldn't change the
+		// response - it just means the reservation lingers as Pending
+		// until ttl expires instead of being replayable.
+		_ = store.Save(ctx, tenantID, key, IdempotencyRecord{
+			RequestHash:  hash,
+			StatusCode:   recorder.status,
+			ResponseBody: recorder.body.Bytes(),
+		}, ttl)
+	}
+}
+
+// idempotencyRecorder tees the handler's response into a buffer (to
+// cache) while still writing it through to the real client.
+type idempotencyRecorder struct {
+	gin.ResponseWriter
+	body   *bytes.Buffer
+	status int
+}
+
+func (r *idempotencyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *idempotencyRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func requestHash(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// marshalRecord and unmarshalRecord are used by IdempotencyStore
+// implementations (see idempotency_store.go) to serialize IdempotencyRecord for a
+// byte-oriented backing store.
+func marshalRecord(record IdempotencyRecord) ([]byte, error) {
+	return json.Marshal(record)
+}
+
+func unmarshalRecord(raw []byte) (*IdempotencyRecord, error) {
+	var record IdempotencyRecord
+	if err := json.Unmarshal(raw, &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/cache"
+)
+
+// CacheIdempotencyStore adapts a cache.Store - the same Redis/LRU
+// backend already used for response caching - into an IdempotencyStore,
+// so idempotency records get the same TTL and replica-sharing behavior
+// without a separate storage system.
+type CacheIdempotencyStore struct {
+	store cache.Store
+}
+
+// NewCacheIdempotencyStore wraps store for use as an IdempotencyStore.
+func NewCacheIdempotencyStore(store cache.Store) *CacheIdempotencyStore {
+	return &CacheIdempotencyStore{store: store}
+}
+
+// Reserve implements IdempotencyStore.
+func (s *CacheIdempotencyStore) Reserve(ctx context.Context, tenantID, key, hash string, ttl time.Duration) (*IdempotencyRecord, bool, error) {
+	cacheKey := idempotencyCacheKey(tenantID, key)
+	raw, err := marshalRecord(IdempotencyRecord{RequestHash: hash, Pending: true})
+	if err != nil {
+		return nil, false, err
+	}
+
+	claimed, err := s.store.SetNX(ctx, cacheKey, raw, ttl)
+	if err != nil {
+		return nil, false, err
+	}
+	if claimed {
+		return nil, true, nil
+	}
+
+	existing, ok, err := s.Get(ctx, tenantID, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if !ok {
+		// Lost the SetNX race to a reservation that then expired before we
+		// could read it back; vanishingly rare, and safe to treat as a
+		// fresh claim rather than surfacing an error.
+		return nil, true, nil
+	}
+	return existing, false, nil
+}
+
+// Release implements IdempotencyStore.
+func (s *CacheIdempotencyStore) Release(ctx context.Context, tenantID, key string) error {
+	return s.store.Delete(ctx, idempotencyCacheKey(tenantID, key))
+}
+
+// Get implements IdempotencyStore.
+func (s *CacheIdempotencyStore) Get(ctx context.Context, tenantID, key string) (*IdempotencyRecord, bool, error) {
+	raw, ok, err := s.store.Get(ctx, idempotencyCacheKey(tenantID, key))
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	record, err := unmarshalRecord(raw)
+	if err != nil {
+		return nil, false, err
+	}
+	return record, true, nil
+}
+
+// Save implements IdempotencyStore.
+func (s *CacheIdempotencyStore) Save(ctx context.Context, tenantID, key string, record IdempotencyRecord, ttl time.Duration) error {
+	raw, err := marshalRecord(record)
+	if err != nil {
+		return err
+	}
+	return s.store.Set(ctx, idempotencyCacheKey(tenantID, key), raw, ttl)
+}
+
+func idempotencyCacheKey(tenantID, key string) string {
+	return "idempotency:" + tenantID + ":" + key
+}
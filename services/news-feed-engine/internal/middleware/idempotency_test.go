@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/cache"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func newIdempotencyRouter(t *testing.T, handlerCalls *int32, release chan struct{}) *gin.Engine {
+	t.Helper()
+	store := NewCacheIdempotencyStore(cache.NewLRUStore(1 << 20))
+	router := gin.New()
+	router.POST("/videos", Idempotency(store, time.Minute), func(c *gin.Context) {
+		atomic.AddInt32(handlerCalls, 1)
+		if release != nil {
+			<-release
+		}
+		c.JSON(http.StatusCreated, gin.H{"job_id": "job-1"})
+	})
+	return router
+}
+
+func TestIdempotencyConcurrentRequestsRunHandlerOnce(t *testing.T) {
+	var handlerCalls int32
+	release := make(chan struct{})
+	router := newIdempotencyRouter(t, &handlerCalls, release)
+
+	const concurrency = 10
+	codes := make([]int, concurrency)
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func(i int) {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/videos", strings.NewReader(`{"prompt":"cats"}`))
+			req.Header.Set("Idempotency-Key", "same-key")
+			router.ServeHTTP(w, req)
+			codes[i] = w.Code
+		}(i)
+	}
+
+	// Let every request reach the handler's atomic claim before any of
+	// them completes, so the race is actually exercised instead of the
+	// requests running sequentially.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&handlerCalls), "handler should run exactly once for concurrent requests sharing an Idempotency-Key")
+
+	var created, inProgress int
+	for _, code := range codes {
+		switch code {
+		case http.StatusCreated:
+			created++
+		case http.StatusConflict:
+			inProgress++
+		default:
+			t.Fatalf("unexpected status code %d", code)
+		}
+	}
+	assert.Equal(t, 1, created)
+	assert.Equal(t, concurrency-1, inProgress)
+}
+
+func TestIdempotencyReplaysCompletedResponse(t *testing.T) {
+	var handlerCalls int32
+	router := newIdempotencyRouter(t, &handlerCalls, nil)
+
+	do := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/videos", strings.NewReader(`{"prompt":"cats"}`))
+		req.Header.Set("Idempotency-Key", "replay-key")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := do()
+	assert.Equal(t, http.StatusCreated, first.Code)
+
+	second := do()
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&handlerCalls), "replay should not re-run the handler")
+}
+
+func TestIdempotencyRejectsMismatchedReplay(t *testing.T) {
+	var handlerCalls int32
+	router := newIdempotencyRouter(t, &handlerCalls, nil)
+
+	w1 := httptest.NewRecorder()
+	req1, _ := http.NewRequest(http.MethodPost, "/videos", strings.NewReader(`{"prompt":"cats"}`))
+	req1.Header.Set("Idempotency-Key", "mismatch-key")
+	router.ServeHTTP(w1, req1)
+	assert.Equal(t, http.StatusCreated, w1.Code)
+
+	w2 := httptest.NewRecorder()
+	req2, _ := http.NewRequest(http.MethodPost, "/videos", strings.NewReader(`{"prompt":"dogs"}`))
+	req2.Header.Set("Idempotency-Key", "mismatch-key")
+	router.ServeHTTP(w2, req2)
+	assert.Equal(t, http.StatusUnprocessableEntity, w2.Code)
+}
+
+func TestIdempotencyReleasesReservationOnHandlerFailure(t *testing.T) {
+	var handlerCalls int32
+	store := NewCacheIdempotencyStore(cache.NewLRUStore(1 << 20))
+	router := gin.New()
+	router.POST("/videos", Idempotency(store, time.Minute), func(c *gin.Context) {
+		n := atomic.AddInt32(&handlerCalls, 1)
+		if n == 1 {
+			c.AbortWithStatus(http.StatusBadGateway)
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"job_id": "job-1"})
+	})
+
+	do := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/videos", strings.NewReader(`{"prompt":"cats"}`))
+		req.Header.Set("Idempotency-Key", "retry-key")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := do()
+	assert.Equal(t, http.StatusBadGateway, first.Code)
+
+	second := do()
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&handlerCalls), "a failed attempt should release its reservation so a retry re-runs the handler")
+}
@@ -2,14 +2,19 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"net/http"
+	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/ratelimit"
 )
 
 // TenantContextKey is the context key for tenant ID
@@ -46,8 +51,90 @@ func GetTenantID(c *gin.Context) string {
 	return DefaultTenantID
 }
 
-// AuthMiddleware validates JWT tokens for protected routes
-func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
+// GetUserID retrieves the authenticated caller's user ID from the context,
+// as set by AuthMiddleware from the JWT's "sub" claim. Returns "" if the
+// request reached the handler without going through AuthMiddleware.
+func GetUserID(c *gin.Context) string {
+	if userID, exists := c.Get("user_id"); exists {
+		if id, ok := userID.(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// SessionContextKey is the context key for the authenticated caller's
+// session id, as set by AuthMiddleware from the JWT's "sid" claim.
+const SessionContextKey = "session_id"
+
+// GetSessionID retrieves the authenticated caller's session id from the
+// context, or "" if the request reached the handler without going
+// through AuthMiddleware or the token predates the session subsystem.
+func GetSessionID(c *gin.Context) string {
+	if sid, exists := c.Get(SessionContextKey); exists {
+		if s, ok := sid.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+// GetJTI retrieves the authenticated caller's access token id ("jti"
+// claim) from the context, or "" if absent.
+func GetJTI(c *gin.Context) string {
+	if jti, exists := c.Get("jti"); exists {
+		if s, ok := jti.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+// GetUserRoles retrieves the authenticated caller's roles as a typed
+// []string, normalizing both the []interface{} jwt.MapClaims decoding
+// produces and a directly-assigned []string into one shape so callers
+// don't need HasRole's type switch just to enumerate roles (e.g. for a
+// response body). Returns nil if the request never went through
+// AuthMiddleware or the claim was absent/malformed.
+func GetUserRoles(c *gin.Context) []string {
+	raw, exists := c.Get("user_roles")
+	if !exists {
+		return nil
+	}
+	switch roles := raw.(type) {
+	case []string:
+		return roles
+	case []interface{}:
+		out := make([]string, 0, len(roles))
+		for _, r := range roles {
+			if s, ok := r.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// SessionChecker lets AuthMiddleware reject a token whose session has
+// been terminated, without the middleware package depending on
+// auth.Manager or database.SessionRepository directly. Implemented by
+// auth.Manager.
+type SessionChecker interface {
+	// IsRevoked reports whether jti has been individually revoked (e.g.
+	// a leaked token rotated out from under its session).
+	IsRevoked(ctx context.Context, tenantID, jti string) (bool, error)
+	// SessionTerminated reports whether the session sid names has been
+	// revoked (logout, admin action) or has expired.
+	SessionTerminated(ctx context.Context, tenantID, sid string) (bool, error)
+}
+
+// AuthMiddleware validates JWT tokens for protected routes. When
+// sessions is non-nil, a token is additionally rejected if its "jti"
+// has been revoked or its "sid" names a terminated session; a token
+// with neither claim (predating the session subsystem) skips both
+// checks rather than being rejected outright.
+func AuthMiddleware(jwtSecret string, sessions SessionChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -72,19 +159,9 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 			return
 		}
 
-		tokenString := parts[1]
-
-		// Parse and validate token
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(jwtSecret), nil
-		})
-
-		if err != nil || !token.Valid {
+		if !authenticateBearerToken(c, parts[1], jwtSecret, sessions) {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error":  "🔑 Token invalid or expired",
+				"error":  "🔑 Token invalid, expired, or revoked",
 				"help":   "Please generate a new authentication token",
 				"action": "Request new token from /auth/login endpoint",
 				"docs":   "https://docs.elevatediq.ai/news-feed-api#authentication",
@@ -92,80 +169,152 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 			return
 		}
 
-		// Extract claims
-		if claims, ok := token.Claims.(jwt.MapClaims); ok {
-			c.Set("user_id", claims["sub"])
-			c.Set("user_email", claims["email"])
-			c.Set("user_roles", claims["roles"])
-		}
-
 		c.Next()
 	}
 }
 
-// RateLimiter implements a simple token bucket rate limiter
-func RateLimiter(maxRequests int, window time.Duration) gin.HandlerFunc {
-	type client struct {
-		count    int
-		lastSeen time.Time
-	}
-
-	var (
-		clients = make(map[string]*client)
-		mu      sync.Mutex
-	)
-
-	// Cleanup old entries periodically
-	go func() {
-		for {
-			time.Sleep(window)
-			mu.Lock()
-			for ip, c := range clients {
-				if time.Since(c.lastSeen) > window {
-					delete(clients, ip)
-				}
+// authenticateBearerToken validates tokenString - signature, expiry,
+// and (when sessions is non-nil) that its "jti" hasn't been revoked and
+// its "sid" doesn't name a terminated session - and on success stashes
+// user_id/user_email/user_roles/jti/session_id into c exactly as
+// AuthMiddleware always has. Shared with MTLSMiddleware's
+// cert_or_password fallback so both entry points enforce identical
+// token rules; only the HTTP error response differs.
+func authenticateBearerToken(c *gin.Context, tokenString, jwtSecret string, sessions SessionChecker) bool {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(jwtSecret), nil
+	})
+	if err != nil || !token.Valid {
+		return false
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return false
+	}
+
+	tenantID := GetTenantID(c)
+	jti, _ := claims["jti"].(string)
+	sid, _ := claims["sid"].(string)
+
+	if sessions != nil {
+		if jti != "" {
+			revoked, err := sessions.IsRevoked(c.Request.Context(), tenantID, jti)
+			if err != nil {
+				// Fail closed: unlike RateLimiter, which fails open
+				// because losing rate limiting is cheap, losing
+				// revocation enforcement means a logged-out or
+				// otherwise revoked token keeps working for as long as
+				// the session store is unreachable.
+				log.Printf("auth: failed to check token revocation for tenant %s: %v", tenantID, err)
+				return false
+			}
+			if revoked {
+				return false
+			}
+		}
+		if sid != "" {
+			terminated, err := sessions.SessionTerminated(c.Request.Context(), tenantID, sid)
+			if err != nil {
+				log.Printf("auth: failed to check session status for tenant %s: %v", tenantID, err)
+				return false
+			}
+			if terminated {
+				return false
 			}
-			mu.Unlock()
 		}
-	}()
+	}
 
-	return func(c *gin.Context) {
-		ip := c.ClientIP()
-		tenantID := GetTenantID(c)
-		key := tenantID + ":" + ip
+	c.Set("user_id", claims["sub"])
+	c.Set("user_email", claims["email"])
+	c.Set("user_roles", claims["roles"])
+	c.Set("jti", jti)
+	c.Set(SessionContextKey, sid)
+	return true
+}
+
+// HasRole reports whether the authenticated caller carries role among the
+// roles AuthMiddleware stashed into the context from the JWT's "roles"
+// claim. Returns false if the request never went through AuthMiddleware
+// or the claim was absent/malformed.
+func HasRole(c *gin.Context, role string) bool {
+	raw, exists := c.Get("user_roles")
+	if !exists {
+		return false
+	}
+
+	switch roles := raw.(type) {
+	case []interface{}:
+		for _, r := range roles {
+			if s, ok := r.(string); ok && s == role {
+				return true
+			}
+		}
+	case []string:
+		for _, s := range roles {
+			if s == role {
+				return true
+			}
+		}
+	}
 
-		mu.Lock()
-		defer mu.Unlock()
+	return false
+}
 
-		if clients[key] == nil {
-			clients[key] = &client{}
+// RequireRole aborts with 403 unless the authenticated caller carries
+// role; it must run after AuthMiddleware so "user_roles" is already in
+// the context.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasRole(c, role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error":   "forbidden",
+				"message": fmt.Sprintf("requires role %q", role),
+			})
+			return
 		}
+		c.Next()
+	}
+}
 
-		cl := clients[key]
+// RateLimiter enforces a ratelimit.RateLimitPolicy per (tenant, scope,
+// IP) using a Redis-backed sliding window (see ratelimit.HTTPLimiter),
+// so the limit survives restarts and holds across every replica rather
+// than just the process handling the request. scope namespaces the
+// policy - e.g. "public" vs "admin" - so a tenant can have a different
+// limit per route group.
+func RateLimiter(limiter *ratelimit.HTTPLimiter, scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ip := c.ClientIP()
+		tenantID := GetTenantID(c)
 
-		// Reset count if window has passed
-		if time.Since(cl.lastSeen) > window {
-			cl.count = 0
+		decision, err := limiter.Allow(c.Request.Context(), tenantID, scope, ip)
+		if err != nil {
+			// Fail open: a Redis outage shouldn't take down the API, it
+			// should just disable rate limiting until Redis recovers.
+			c.Next()
+			return
 		}
 
-		cl.count++
-		cl.lastSeen = time.Now()
+		c.Header("X-RateLimit-Limit", strconv.Itoa(decision.Policy.MaxRequests))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		c.Header("X-RateLimit-Reset", strconv.Itoa(int(decision.RetryAfter.Seconds())))
 
-		if cl.count > maxRequests {
+		if !decision.Allowed {
+			c.Header("Retry-After", strconv.Itoa(int(decision.RetryAfter.Seconds())))
 			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
 				"error":       "⏱️ Rate limit exceeded",
-				"message":     fmt.Sprintf("Maximum %d requests per %.0f seconds allowed", maxRequests, window.Seconds()),
-				"retry_after": int(window.Seconds()),
-				"reset_in":    fmt.Sprintf("%d seconds", int((time.Until(cl.lastSeen.Add(window))).Seconds())),
+				"message":     fmt.Sprintf("Maximum %d requests per %.0f seconds allowed", decision.Policy.MaxRequests, decision.Policy.Window.Seconds()),
+				"retry_after": int(decision.RetryAfter.Seconds()),
+				"policy_id":   scope,
 				"help":        "Please wait before retrying. Contact support to request higher limits",
 			})
 			return
 		}
 
-		// Set rate limit headers
-		c.Header("X-RateLimit-Limit", string(rune(maxRequests)))
-		c.Header("X-RateLimit-Remaining", string(rune(maxRequests-cl.count)))
-
 		c.Next()
 	}
 }
@@ -193,16 +342,16 @@ func RequestIDMiddleware() gin.HandlerFunc {
 	}
 }
 
+// generateRequestID returns a UUIDv7: time-ordered, so request IDs stay
+// sortable for log correlation, unlike the old
+// time.Now().Format(...)+randomString(8) scheme, whose randomString drew
+// every byte from the same time.Now().UnixNano() call and so produced 8
+// identical bytes - and collisions across concurrent requests - within
+// any nanosecond multiple requests shared.
 func generateRequestID() string {
-	// Simple implementation - in production use UUID
-	return time.Now().Format("20060102150405") + "-" + randomString(8)
-}
-
-func randomString(n int) string {
-	const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
-	b := make([]byte, n)
-	for i := range b {
-		b[i] = letters[time.Now().UnixNano()%int64(len(letters))]
+	id, err := uuid.NewV7()
+	if err != nil {
+		return uuid.NewString()
 	}
-	return string(b)
+	return id.String()
 }
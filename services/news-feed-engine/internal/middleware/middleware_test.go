@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSessionChecker struct {
+	revokedErr    error
+	terminatedErr error
+	revoked       bool
+	terminated    bool
+}
+
+func (f *fakeSessionChecker) IsRevoked(_ context.Context, _, _ string) (bool, error) {
+	return f.revoked, f.revokedErr
+}
+
+func (f *fakeSessionChecker) SessionTerminated(_ context.Context, _, _ string) (bool, error) {
+	return f.terminated, f.terminatedErr
+}
+
+func signTestToken(t *testing.T, secret string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("failed to sign test token: %v", err)
+	}
+	return signed
+}
+
+func newAuthRouter(secret string, sessions SessionChecker) *gin.Engine {
+	router := gin.New()
+	router.GET("/protected", AuthMiddleware(secret, sessions), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	return router
+}
+
+func TestAuthMiddlewareFailsClosedWhenRevocationCheckErrors(t *testing.T) {
+	const secret = "test-secret"
+	token := signTestToken(t, secret, jwt.MapClaims{"sub": "user-1", "jti": "jti-1"})
+
+	sessions := &fakeSessionChecker{revokedErr: errors.New("redis unavailable")}
+	router := newAuthRouter(secret, sessions)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "an unreachable session store must reject the request, not grant access")
+}
+
+func TestAuthMiddlewareFailsClosedWhenSessionCheckErrors(t *testing.T) {
+	const secret = "test-secret"
+	token := signTestToken(t, secret, jwt.MapClaims{"sub": "user-1", "sid": "sid-1"})
+
+	sessions := &fakeSessionChecker{terminatedErr: errors.New("redis unavailable")}
+	router := newAuthRouter(secret, sessions)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuthMiddlewareRejectsRevokedToken(t *testing.T) {
+	const secret = "test-secret"
+	token := signTestToken(t, secret, jwt.MapClaims{"sub": "user-1", "jti": "jti-1"})
+
+	sessions := &fakeSessionChecker{revoked: true}
+	router := newAuthRouter(secret, sessions)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuthMiddlewareAllowsValidUnrevokedToken(t *testing.T) {
+	const secret = "test-secret"
+	token := signTestToken(t, secret, jwt.MapClaims{"sub": "user-1", "jti": "jti-1", "sid": "sid-1"})
+
+	sessions := &fakeSessionChecker{}
+	router := newAuthRouter(secret, sessions)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
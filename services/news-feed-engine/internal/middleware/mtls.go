@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthType selects how MTLSMiddleware admits a request.
+type AuthType string
+
+const (
+	// AuthTypeNone disables authentication entirely for the group -
+	// only meant for a route that's already protected some other way
+	// (e.g. a signed webhook).
+	AuthTypeNone AuthType = "none"
+	// AuthTypePassword requires a valid bearer JWT, same as AuthMiddleware.
+	AuthTypePassword AuthType = "password"
+	// AuthTypeCert requires a client certificate; a bearer JWT is not
+	// accepted even if present.
+	AuthTypeCert AuthType = "cert"
+	// AuthTypeCertOrPassword accepts either a valid client certificate
+	// or a valid bearer JWT.
+	AuthTypeCertOrPassword AuthType = "cert_or_password"
+)
+
+// TLSAuthConfig configures MTLSMiddleware for one route group.
+type TLSAuthConfig struct {
+	Mode AuthType
+	// CAPool validates the client certificate's chain. Required when
+	// Mode is AuthTypeCert or AuthTypeCertOrPassword.
+	CAPool *x509.CertPool
+	// JWTSecret validates the bearer JWT fallback. Required when Mode
+	// is AuthTypePassword or AuthTypeCertOrPassword.
+	JWTSecret string
+	// Sessions is passed through to authenticateBearerToken for the
+	// JWT fallback, same as AuthMiddleware's sessions parameter.
+	Sessions SessionChecker
+}
+
+// ClientCertIdentity is what a client certificate's CN/SAN maps to: the
+// tenant and role MTLSMiddleware grants the request once the cert
+// itself has validated.
+type ClientCertIdentity struct {
+	TenantID string `json:"tenant_id"`
+	Role     string `json:"role"`
+}
+
+// ClientCertStore resolves the ClientCertMap configured for a tenant,
+// keyed by the identity (certificate CN, or first SAN DNS name if CN is
+// empty) MTLSMiddleware extracts from the presented certificate.
+// Implemented by database.ConfigRepository.
+type ClientCertStore interface {
+	ClientCertMap(ctx context.Context, tenantID string) (map[string]ClientCertIdentity, error)
+}
+
+// MTLSMiddleware enforces cfg.Mode for a route group. For AuthTypeCert
+// and AuthTypeCertOrPassword, the client certificate (populated by
+// Gin's TLS listener into c.Request.TLS.PeerCertificates once
+// ClientAuth is configured to request one) must chain to cfg.CAPool and
+// its identity must appear in the caller's tenant's ClientCertMap,
+// loaded from certs via the tenant already resolved by TenantMiddleware.
+func MTLSMiddleware(cfg TLSAuthConfig, certs ClientCertStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch cfg.Mode {
+		case AuthTypeNone:
+			c.Next()
+			return
+
+		case AuthTypePassword:
+			if !requireBearer(c, cfg) {
+				return
+			}
+			c.Next()
+			return
+
+		case AuthTypeCert:
+			if !tryClientCert(c, cfg, certs) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+					"error":   "client certificate required",
+					"message": "this endpoint requires mTLS; no valid client certificate was presented",
+				})
+				return
+			}
+			c.Next()
+			return
+
+		case AuthTypeCertOrPassword:
+			if tryClientCert(c, cfg, certs) {
+				c.Next()
+				return
+			}
+			if requireBearer(c, cfg) {
+				c.Next()
+				return
+			}
+			return
+
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"error":   "misconfigured",
+				"message": "unknown auth_type",
+			})
+		}
+	}
+}
+
+// requireBearer validates the request's bearer JWT, aborting with 401
+// on failure. Returns whether it succeeded, so AuthTypeCertOrPassword's
+// fallback can tell the caller already got a response.
+func requireBearer(c *gin.Context, cfg TLSAuthConfig) bool {
+	authHeader := c.GetHeader("Authorization")
+	parts := splitBearer(authHeader)
+	if parts == "" || !authenticateBearerToken(c, parts, cfg.JWTSecret, cfg.Sessions) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "a valid client certificate or bearer token is required",
+		})
+		return false
+	}
+	return true
+}
+
+func splitBearer(authHeader string) string {
+	const prefix = "Bearer "
+	if len(authHeader) <= len(prefix) || authHeader[:len(prefix)] != prefix {
+		return ""
+	}
+	return authHeader[len(prefix):]
+}
+
+// tryClientCert validates the request's client certificate against
+// cfg.CAPool and resolves it to a ClientCertIdentity via the caller's
+// tenant's ClientCertMap, stashing the resolved role/identity into c on
+// success. It never writes a response itself - AuthTypeCertOrPassword
+// needs to fall through to the bearer check on failure, and AuthTypeCert
+// reports its own error.
+func tryClientCert(c *gin.Context, cfg TLSAuthConfig, certs ClientCertStore) bool {
+	if c.Request.TLS == nil || len(c.Request.TLS.PeerCertificates) == 0 {
+		return false
+	}
+	cert := c.Request.TLS.PeerCertificates[0]
+
+	if cfg.CAPool != nil {
+		opts := x509.VerifyOptions{
+			Roots:         cfg.CAPool,
+			Intermediates: x509.NewCertPool(),
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		}
+		for _, intermediate := range c.Request.TLS.PeerCertificates[1:] {
+			opts.Intermediates.AddCert(intermediate)
+		}
+		if _, err := cert.Verify(opts); err != nil {
+			return false
+		}
+	}
+
+	identity := cert.Subject.CommonName
+	if identity == "" && len(cert.DNSNames) > 0 {
+		identity = cert.DNSNames[0]
+	}
+	if identity == "" {
+		return false
+	}
+
+	tenantID := GetTenantID(c)
+	certMap, err := certs.ClientCertMap(c.Request.Context(), tenantID)
+	if err != nil {
+		return false
+	}
+	mapped, ok := certMap[identity]
+	if !ok {
+		return false
+	}
+
+	c.Set("user_id", identity)
+	c.Set("user_roles", []string{mapped.Role})
+	c.Set("auth_method", "cert")
+	return true
+}
@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
+)
+
+// TraceContextKey is the context key for the current request's trace id.
+const TraceContextKey = "trace_id"
+
+// GetTraceID retrieves the current request's trace id, as set by
+// TracingMiddleware, or "" if the request never went through it (or
+// tracing is disabled, in which case every span carries the zero trace
+// id and this still returns "").
+func GetTraceID(c *gin.Context) string {
+	if id, exists := c.Get(TraceContextKey); exists {
+		if s, ok := id.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+// TracingMiddleware starts an OpenTelemetry server span per request,
+// extracting an inbound W3C traceparent/tracestate so a span continues
+// its caller's trace instead of starting a new one, and propagates the
+// span's context through c.Request.Context() so downstream repository
+// calls participate in the same trace. The response body of every error
+// (4xx/5xx) response is rewritten to include "trace_id", so a user's bug
+// report can be turned directly into a trace lookup.
+func TracingMiddleware(tp *metrics.TracingProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+
+		ctx, span := tp.StartSpan(ctx, route, &metrics.SpanOptions{
+			SpanKind: trace.SpanKindServer,
+			Attributes: map[string]interface{}{
+				"tenant.id":   GetTenantID(c),
+				"http.route":  route,
+				"http.method": c.Request.Method,
+			},
+		})
+		defer span.End()
+
+		traceID := ""
+		if sc := span.SpanContext(); sc.HasTraceID() {
+			traceID = sc.TraceID().String()
+		}
+		c.Set(TraceContextKey, traceID)
+		c.Request = c.Request.WithContext(ctx)
+
+		recorder := &traceResponseRecorder{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = recorder
+
+		c.Next()
+
+		status := recorder.Status()
+		span.SetAttributes(attribute.Int("http.status_code", status))
+		if status >= http.StatusInternalServerError {
+			tp.EndSpan(span, fmt.Errorf("http %d", status))
+		} else {
+			tp.EndSpan(span, nil)
+		}
+
+		recorder.flush(status, traceID)
+	}
+}
+
+// traceResponseRecorder buffers the handler's response instead of
+// writing it straight through, so TracingMiddleware can inject trace_id
+// into an error body before anything reaches the client. Embedding
+// gin.ResponseWriter promotes every method we don't override (Header,
+// Flush, Hijack, ...), so this stays correct across gin versions that
+// add to the interface.
+type traceResponseRecorder struct {
+	gin.ResponseWriter
+	body   *bytes.Buffer
+	status int
+}
+
+func (r *traceResponseRecorder) WriteHeader(status int) {
+	r.status = status
+}
+
+// WriteHeaderNow is gin's hook for committing the status line to the
+// wire early (e.g. before a long streaming write); buffering must
+// suppress it, or the original, not-yet-rewritten response commits
+// before flush runs.
+func (r *traceResponseRecorder) WriteHeaderNow() {}
+
+func (r *traceResponseRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+func (r *traceResponseRecorder) WriteString(s string) (int, error) {
+	return r.body.WriteString(s)
+}
+
+func (r *traceResponseRecorder) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+func (r *traceResponseRecorder) Size() int {
+	return r.body.Len()
+}
+
+func (r *traceResponseRecorder) Written() bool {
+	return r.body.Len() > 0
+}
+
+// flush injects trace_id into an error response's JSON body (leaving a
+// non-JSON or successful body untouched) and writes the final response
+// to the real client.
+func (r *traceResponseRecorder) flush(status int, traceID string) {
+	body := r.body.Bytes()
+
+	if status >= http.StatusBadRequest {
+		var payload map[string]interface{}
+		if err := json.Unmarshal(body, &payload); err == nil {
+			payload["trace_id"] = traceID
+			if rewritten, err := json.Marshal(payload); err == nil {
+				body = rewritten
+			}
+		}
+	}
+
+	r.ResponseWriter.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	r.ResponseWriter.WriteHeader(status)
+	_, _ = r.ResponseWriter.Write(body)
+}
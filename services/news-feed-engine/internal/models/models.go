@@ -4,6 +4,9 @@ package models
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,9 +20,40 @@ const (
 	PlatformTwitter  Platform = "twitter"
 	PlatformReddit   Platform = "reddit"
 	PlatformRSS      Platform = "rss"
+	PlatformBilibili Platform = "bilibili"
 	PlatformInternal Platform = "internal"
 )
 
+// platformHosts maps a platform to the hostnames ParsePlatform
+// recognizes in a content URL.
+var platformHosts = map[Platform][]string{
+	PlatformYouTube:  {"youtube.com", "www.youtube.com", "m.youtube.com", "youtu.be"},
+	PlatformTwitter:  {"twitter.com", "www.twitter.com", "x.com", "www.x.com"},
+	PlatformReddit:   {"reddit.com", "www.reddit.com", "old.reddit.com"},
+	PlatformBilibili: {"bilibili.com", "www.bilibili.com", "b23.tv"},
+}
+
+// ParsePlatform resolves rawURL's host to the Platform that owns it, so
+// ingestion code can dispatch on Platform without each caller
+// re-implementing the same host-matching.
+func ParsePlatform(rawURL string) (Platform, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid content URL %q: %w", rawURL, err)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for platform, hosts := range platformHosts {
+		for _, h := range hosts {
+			if host == h {
+				return platform, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no platform recognizes host %q", host)
+}
+
 // ContentType represents the type of content
 type ContentType string
 
@@ -62,6 +96,17 @@ const (
 	StatusFailed     ProcessingStatus = "failed"
 )
 
+// VerificationStatus represents the lifecycle of a creator
+// VerificationRequest
+type VerificationStatus string
+
+const (
+	VerificationStatusPending  VerificationStatus = "pending"
+	VerificationStatusApproved VerificationStatus = "approved"
+	VerificationStatusRejected VerificationStatus = "rejected"
+	VerificationStatusRevoked  VerificationStatus = "revoked"
+)
+
 // JSONB is a custom type for PostgreSQL JSONB columns
 type JSONB map[string]interface{}
 
@@ -118,6 +163,27 @@ type Creator struct {
 	UpdatedAt       time.Time   `json:"updated_at" db:"updated_at"`
 }
 
+// VerificationRequest represents a creator's request to be promoted to a
+// higher CreatorTier (platinum/gold in particular require reviewer
+// sign-off rather than the immediate mutation CreatorRepository.Verify
+// otherwise performs) along with the evidence backing it and, once
+// decided, the reviewer's identity and notes for the tenant's audit
+// trail.
+type VerificationRequest struct {
+	ID            uuid.UUID          `json:"id" db:"id"`
+	TenantID      string             `json:"tenant_id" db:"tenant_id"`
+	CreatorID     uuid.UUID          `json:"creator_id" db:"creator_id"`
+	RequestedTier CreatorTier        `json:"requested_tier" db:"requested_tier"`
+	EvidenceURLs  []string           `json:"evidence_urls,omitempty" db:"evidence_urls"`
+	RequestedBy   string             `json:"requested_by" db:"requested_by"`
+	Status        VerificationStatus `json:"status" db:"status"`
+	ReviewerID    string             `json:"reviewer_id,omitempty" db:"reviewer_id"`
+	ReviewerNotes string             `json:"reviewer_notes,omitempty" db:"reviewer_notes"`
+	DecidedAt     *time.Time         `json:"decided_at,omitempty" db:"decided_at"`
+	CreatedAt     time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at" db:"updated_at"`
+}
+
 // Content represents aggregated content from various sources
 type Content struct {
 	ID                uuid.UUID         `json:"id" db:"id"`
@@ -262,3 +328,71 @@ type VideoTemplate struct {
 	CreatedAt    time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
 }
+
+// UserPreferences represents one user's feed customization within a
+// tenant: landing view, feed menu ordering, sort, locale/theme,
+// moderation (muted creators/keywords), and pagination defaults. The
+// ranking/feed path (internal/preferences.Service) consumes this to
+// filter and cap what ContentHandler returns.
+type UserPreferences struct {
+	TenantID string `json:"tenant_id" db:"tenant_id"`
+	UserID   string `json:"user_id" db:"user_id"`
+	// DefaultHome names the feed view shown on landing, e.g. "trending",
+	// "subscriptions", "category:tech".
+	DefaultHome string `json:"default_home" db:"default_home"`
+	// FeedMenu orders the feed tabs/categories a user sees, front-to-back.
+	FeedMenu []string `json:"feed_menu" db:"feed_menu"`
+	// Sort is one of "published", "trending", "popular".
+	Sort              string      `json:"sort" db:"sort"`
+	Locale            string      `json:"locale" db:"locale"`
+	Theme             string      `json:"theme" db:"theme"`
+	Autoplay          bool        `json:"autoplay" db:"autoplay"`
+	EnabledCategories []string    `json:"enabled_categories" db:"enabled_categories"`
+	MutedCreators     []uuid.UUID `json:"muted_creators" db:"muted_creators"`
+	MutedKeywords     []string    `json:"muted_keywords" db:"muted_keywords"`
+	MaxResults        int         `json:"max_results" db:"max_results"`
+	Extra             JSONB       `json:"extra,omitempty" db:"extra"`
+	UpdatedAt         time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// AuditEvent records one mutation to tenant-owned state - a creator,
+// content item, tenant config, content source, or video template - for
+// compliance and incident review: who (ActorID/ActorType) did what
+// (Action) to which row (TargetType/TargetID), with the row's value
+// Before and After the change. Written by internal/audit.Logger
+// implementations and read back via database.AuditRepository.Query.
+type AuditEvent struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	TenantID   string    `json:"tenant_id" db:"tenant_id"`
+	ActorID    string    `json:"actor_id" db:"actor_id"`
+	ActorType  string    `json:"actor_type" db:"actor_type"`
+	Action     string    `json:"action" db:"action"`
+	TargetType string    `json:"target_type" db:"target_type"`
+	TargetID   string    `json:"target_id" db:"target_id"`
+	Before     JSONB     `json:"before,omitempty" db:"before"`
+	After      JSONB     `json:"after,omitempty" db:"after"`
+	IP         string    `json:"ip,omitempty" db:"ip"`
+	UserAgent  string    `json:"user_agent,omitempty" db:"user_agent"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// Session is one issued access/refresh token pair, tracked so a user can
+// see their active logins (GET /auth/sessions) and revoke any one of
+// them - or AuthMiddleware can reject a still-unexpired access token
+// whose session was terminated elsewhere. JTI is the access token's "jti"
+// claim; a session is looked up by it on every authenticated request, so
+// it's unique per tenant rather than globally to keep the lookup a
+// simple indexed equality match.
+type Session struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	TenantID  string     `json:"tenant_id" db:"tenant_id"`
+	UserID    string     `json:"user_id" db:"user_id"`
+	JTI       string     `json:"jti" db:"jti"`
+	Roles     []string   `json:"roles" db:"roles"`
+	UserAgent string     `json:"user_agent" db:"user_agent"`
+	IP        string     `json:"ip" db:"ip"`
+	LastSeen  time.Time  `json:"last_seen" db:"last_seen"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,235 @@
+// Package bilibili implements platform.Parser for Bilibili video
+// (BVID/AVID), UP-master space, and season/collection URLs.
+package bilibili
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/platform"
+)
+
+var (
+	bvidPattern   = regexp.MustCompile(`(?:bilibili\.com/video/|b23\.tv/)(BV[0-9A-Za-z]+)`)
+	avidPattern   = regexp.MustCompile(`bilibili\.com/video/av(\d+)`)
+	spacePattern  = regexp.MustCompile(`bilibili\.com/space/(\d+)`)
+	seasonPattern = regexp.MustCompile(`bilibili\.com/bangumi/play/ss(\d+)`)
+)
+
+// Parser resolves Bilibili URLs -- videos (BVID or legacy AVID), UP-master
+// spaces, and bangumi seasons/collections -- into Content/Creator the same
+// way the YouTube integration does, via Bilibili's public web API.
+type Parser struct {
+	httpClient *http.Client
+}
+
+// New creates a Bilibili Parser.
+func New() *Parser {
+	return &Parser{httpClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func init() {
+	platform.Register(models.PlatformBilibili, New())
+}
+
+// Match reports whether rawURL is a Bilibili video, space, or season URL.
+func (p *Parser) Match(rawURL string) bool {
+	return bvidPattern.MatchString(rawURL) || avidPattern.MatchString(rawURL) ||
+		spacePattern.MatchString(rawURL) || seasonPattern.MatchString(rawURL)
+}
+
+// Parse dispatches rawURL to the matching video, space, or season handler.
+func (p *Parser) Parse(ctx context.Context, rawURL string) (*models.Content, *models.Creator, error) {
+	if m := bvidPattern.FindStringSubmatch(rawURL); m != nil {
+		return p.parseVideo(ctx, "bvid", m[1])
+	}
+	if m := avidPattern.FindStringSubmatch(rawURL); m != nil {
+		return p.parseVideo(ctx, "aid", m[1])
+	}
+	if m := spacePattern.FindStringSubmatch(rawURL); m != nil {
+		creator, err := p.parseSpace(ctx, m[1])
+		return nil, creator, err
+	}
+	if m := seasonPattern.FindStringSubmatch(rawURL); m != nil {
+		return p.parseSeason(ctx, m[1])
+	}
+	return nil, nil, fmt.Errorf("bilibili: unrecognized URL %q", rawURL)
+}
+
+// videoViewResponse mirrors the fields of Bilibili's
+// /x/web-interface/view response this parser consumes.
+type videoViewResponse struct {
+	Code int `json:"code"`
+	Data struct {
+		BVID     string `json:"bvid"`
+		AID      int64  `json:"aid"`
+		Title    string `json:"title"`
+		Desc     string `json:"desc"`
+		Pic      string `json:"pic"`
+		Duration int    `json:"duration"`
+		Pubdate  int64  `json:"pubdate"`
+		Owner    struct {
+			Mid  int64  `json:"mid"`
+			Name string `json:"name"`
+			Face string `json:"face"`
+		} `json:"owner"`
+		Stat struct {
+			View     int64 `json:"view"`
+			Like     int64 `json:"like"`
+			Reply    int64 `json:"reply"`
+			Share    int64 `json:"share"`
+			Favorite int64 `json:"favorite"`
+		} `json:"stat"`
+	} `json:"data"`
+}
+
+func (p *Parser) parseVideo(ctx context.Context, idParam, idValue string) (*models.Content, *models.Creator, error) {
+	endpoint := fmt.Sprintf("https://api.bilibili.com/x/web-interface/view?%s=%s", idParam, idValue)
+
+	var resp videoViewResponse
+	if err := p.get(ctx, endpoint, &resp); err != nil {
+		return nil, nil, fmt.Errorf("bilibili: fetch video %s=%s: %w", idParam, idValue, err)
+	}
+	if resp.Code != 0 {
+		return nil, nil, fmt.Errorf("bilibili: video %s=%s returned code %d", idParam, idValue, resp.Code)
+	}
+
+	creator := &models.Creator{
+		Name:       resp.Data.Owner.Name,
+		Platform:   models.PlatformBilibili,
+		PlatformID: strconv.FormatInt(resp.Data.Owner.Mid, 10),
+		AvatarURL:  resp.Data.Owner.Face,
+	}
+
+	content := &models.Content{
+		Platform:          models.PlatformBilibili,
+		PlatformContentID: resp.Data.BVID,
+		ContentType:       models.ContentTypeVideo,
+		Title:             resp.Data.Title,
+		Description:       resp.Data.Desc,
+		OriginalURL:       fmt.Sprintf("https://www.bilibili.com/video/%s", resp.Data.BVID),
+		ThumbnailURL:      resp.Data.Pic,
+		ViewCount:         resp.Data.Stat.View,
+		LikeCount:         resp.Data.Stat.Like,
+		CommentCount:      resp.Data.Stat.Reply,
+		ShareCount:        resp.Data.Stat.Share,
+		PublishedAt:       time.Unix(resp.Data.Pubdate, 0),
+		Metadata: models.JSONB{
+			"duration_seconds": resp.Data.Duration,
+			"favorite_count":   resp.Data.Stat.Favorite,
+		},
+	}
+
+	return content, creator, nil
+}
+
+// spaceAccInfoResponse mirrors the fields of Bilibili's
+// /x/space/acc/info response this parser consumes.
+type spaceAccInfoResponse struct {
+	Code int `json:"code"`
+	Data struct {
+		Mid  int64  `json:"mid"`
+		Name string `json:"name"`
+		Face string `json:"face"`
+		Sign string `json:"sign"`
+	} `json:"data"`
+}
+
+func (p *Parser) parseSpace(ctx context.Context, mid string) (*models.Creator, error) {
+	endpoint := fmt.Sprintf("https://api.bilibili.com/x/space/acc/info?mid=%s", mid)
+
+	var resp spaceAccInfoResponse
+	if err := p.get(ctx, endpoint, &resp); err != nil {
+		return nil, fmt.Errorf("bilibili: fetch space mid=%s: %w", mid, err)
+	}
+	if resp.Code != 0 {
+		return nil, fmt.Errorf("bilibili: space mid=%s returned code %d", mid, resp.Code)
+	}
+
+	return &models.Creator{
+		Name:       resp.Data.Name,
+		Platform:   models.PlatformBilibili,
+		PlatformID: strconv.FormatInt(resp.Data.Mid, 10),
+		AvatarURL:  resp.Data.Face,
+		Bio:        resp.Data.Sign,
+	}, nil
+}
+
+// seasonViewResponse mirrors the fields of Bilibili's
+// /pgc/view/web/season response this parser consumes.
+type seasonViewResponse struct {
+	Code   int `json:"code"`
+	Result struct {
+		SeasonID int64  `json:"season_id"`
+		Title    string `json:"title"`
+		Evaluate string `json:"evaluate"`
+		Cover    string `json:"cover"`
+		Stat     struct {
+			Views    int64 `json:"views"`
+			Favorite int64 `json:"favorite"`
+			Reply    int64 `json:"reply"`
+			Share    int64 `json:"share"`
+		} `json:"stat"`
+	} `json:"result"`
+}
+
+func (p *Parser) parseSeason(ctx context.Context, seasonID string) (*models.Content, *models.Creator, error) {
+	endpoint := fmt.Sprintf("https://api.bilibili.com/pgc/view/web/season?season_id=%s", seasonID)
+
+	var resp seasonViewResponse
+	if err := p.get(ctx, endpoint, &resp); err != nil {
+		return nil, nil, fmt.Errorf("bilibili: fetch season_id=%s: %w", seasonID, err)
+	}
+	if resp.Code != 0 {
+		return nil, nil, fmt.Errorf("bilibili: season_id=%s returned code %d", seasonID, resp.Code)
+	}
+
+	content := &models.Content{
+		Platform:          models.PlatformBilibili,
+		PlatformContentID: strconv.FormatInt(resp.Result.SeasonID, 10),
+		ContentType:       models.ContentTypeVideo,
+		Title:             resp.Result.Title,
+		Description:       resp.Result.Evaluate,
+		OriginalURL:       fmt.Sprintf("https://www.bilibili.com/bangumi/play/ss%d", resp.Result.SeasonID),
+		ThumbnailURL:      resp.Result.Cover,
+		ViewCount:         resp.Result.Stat.Views,
+		CommentCount:      resp.Result.Stat.Reply,
+		ShareCount:        resp.Result.Stat.Share,
+		Metadata: models.JSONB{
+			"favorite_count": resp.Result.Stat.Favorite,
+		},
+	}
+
+	// A season/collection doesn't map to a single creator.
+	return content, nil, nil
+}
+
+func (p *Parser) get(ctx context.Context, endpoint string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return json.Unmarshal(body, out)
+}
@@ -0,0 +1,75 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+// maxIngestAge is how stale a platform's most-recently-ingested
+// ContentSource can be before RegisterPlatform's check reports degraded.
+const maxIngestAge = 24 * time.Hour
+
+// maxErrorRatio is the fraction of a platform's ContentSources allowed
+// to carry a LastError before RegisterPlatform's check reports degraded.
+const maxErrorRatio = 0.5
+
+// RegisterPlatform registers a health check named "platform:<platform>"
+// into registry that reports degraded when tenantID's ContentSources for
+// platform are erroring (more than maxErrorRatio have a non-empty
+// LastError) or entirely stale (none ingested within maxIngestAge),
+// combining both signals ContentSource already tracks.
+func RegisterPlatform(registry *metrics.HealthCheckRegistry, sources *database.SourceRepository, tenantID string, p models.Platform) error {
+	name := fmt.Sprintf("platform:%s", p)
+	return registry.Register(name, func(ctx context.Context) metrics.HealthCheckResult {
+		return checkPlatform(ctx, sources, tenantID, p, name)
+	})
+}
+
+func checkPlatform(ctx context.Context, sources *database.SourceRepository, tenantID string, p models.Platform, name string) metrics.HealthCheckResult {
+	list, err := sources.List(ctx, tenantID, database.SourceListOptions{Platform: string(p)})
+	if err != nil {
+		return metrics.HealthCheckResult{
+			Name:      name,
+			Status:    metrics.HealthStatusUnhealthy,
+			Message:   fmt.Sprintf("failed to list %s content sources: %v", p, err),
+			Timestamp: time.Now(),
+		}
+	}
+
+	if len(list) == 0 {
+		return metrics.HealthCheckResult{
+			Name:      name,
+			Status:    metrics.HealthStatusHealthy,
+			Message:   "no content sources configured",
+			Timestamp: time.Now(),
+		}
+	}
+
+	var errored, stale int
+	for _, source := range list {
+		if source.LastError != "" {
+			errored++
+		}
+		if source.LastIngested == nil || time.Since(*source.LastIngested) > maxIngestAge {
+			stale++
+		}
+	}
+
+	status := metrics.HealthStatusHealthy
+	if float64(errored)/float64(len(list)) > maxErrorRatio || stale == len(list) {
+		status = metrics.HealthStatusDegraded
+	}
+
+	return metrics.HealthCheckResult{
+		Name:      name,
+		Status:    status,
+		Message:   fmt.Sprintf("%d sources, %d erroring, %d stale", len(list), errored, stale),
+		Timestamp: time.Now(),
+	}
+}
@@ -0,0 +1,90 @@
+// Package platform decouples raw platform payload parsing (a video
+// page, a UP-master space, a season URL, ...) from ingestion. Each
+// platform (YouTube, Bilibili, ...) implements Parser in its own
+// subpackage and self-registers via init(), mirroring the
+// embeddings.Register driver-registration idiom; ingestion then
+// dispatches a ContentSource's URL to the matching Parser through the
+// package-level Registry instead of a hard-coded platform switch.
+package platform
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+// Parser extracts Content and its Creator from a single platform's raw
+// URL. A parser that can't resolve a creator for a given URL (e.g. a
+// season/collection page that doesn't map to one) may return a nil
+// *models.Creator.
+type Parser interface {
+	// Match reports whether url belongs to this parser's platform.
+	Match(url string) bool
+
+	// Parse resolves url into the Content it describes and the Creator
+	// that published it.
+	Parse(ctx context.Context, url string) (*models.Content, *models.Creator, error)
+}
+
+type registeredParser struct {
+	platform models.Platform
+	parser   Parser
+}
+
+// Registry dispatches a URL to the first registered Parser whose Match
+// reports true.
+type Registry struct {
+	mu      sync.RWMutex
+	parsers []registeredParser
+}
+
+// NewRegistry creates an empty parser Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds parser for platform.
+func (r *Registry) Register(platform models.Platform, parser Parser) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.parsers = append(r.parsers, registeredParser{platform: platform, parser: parser})
+}
+
+// Resolve finds the first registered Parser whose Match(url) is true.
+func (r *Registry) Resolve(url string) (Parser, models.Platform, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, rp := range r.parsers {
+		if rp.parser.Match(url) {
+			return rp.parser, rp.platform, true
+		}
+	}
+	return nil, "", false
+}
+
+// Parse resolves url to its platform's Parser and runs it.
+func (r *Registry) Parse(ctx context.Context, url string) (*models.Content, *models.Creator, error) {
+	parser, _, ok := r.Resolve(url)
+	if !ok {
+		return nil, nil, fmt.Errorf("platform: no parser registered for %q", url)
+	}
+	return parser.Parse(ctx, url)
+}
+
+// defaultRegistry is the registry each platform subpackage's init()
+// registers itself into.
+var defaultRegistry = NewRegistry()
+
+// Register adds parser for platform to the default Registry. Call this
+// from a platform subpackage's init().
+func Register(platform models.Platform, parser Parser) {
+	defaultRegistry.Register(platform, parser)
+}
+
+// Default returns the package-level Registry that init()-registered
+// parsers add themselves to.
+func Default() *Registry {
+	return defaultRegistry
+}
@@ -0,0 +1,95 @@
+package preferences
+
+import (
+	"strings"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+// sortColumns maps a UserPreferences.Sort value to the ContentRepository
+// column it drives, so the feed path can honor it without the handler
+// needing to know the mapping itself.
+var sortColumns = map[string]string{
+	"published": "published_at",
+	"trending":  "engagement_score",
+	"popular":   "view_count",
+}
+
+// ResolveListOptions overlays prefs onto opts: it caps Limit at
+// prefs.MaxResults and fills in SortBy from prefs.Sort when the caller
+// didn't request an explicit sort, without overriding filters (category,
+// platform, ...) the caller already set.
+func ResolveListOptions(prefs *models.UserPreferences, opts database.ListOptions) database.ListOptions {
+	if prefs == nil {
+		return opts
+	}
+
+	if prefs.MaxResults > 0 && (opts.Limit <= 0 || opts.Limit > prefs.MaxResults) {
+		opts.Limit = prefs.MaxResults
+	}
+	if opts.SortBy == "" {
+		if column, ok := sortColumns[prefs.Sort]; ok {
+			opts.SortBy = column
+		}
+	}
+	return opts
+}
+
+// FilterContent drops items from contents that prefs says this user
+// doesn't want to see - from a muted creator, matching a muted keyword
+// in the title, or outside EnabledCategories - and caps the result at
+// prefs.MaxResults. Order is preserved.
+func FilterContent(prefs *models.UserPreferences, contents []*models.Content) []*models.Content {
+	if prefs == nil {
+		return contents
+	}
+
+	mutedCreators := make(map[string]bool, len(prefs.MutedCreators))
+	for _, id := range prefs.MutedCreators {
+		mutedCreators[id.String()] = true
+	}
+
+	enabledCategories := make(map[string]bool, len(prefs.EnabledCategories))
+	for _, category := range prefs.EnabledCategories {
+		enabledCategories[category] = true
+	}
+
+	filtered := make([]*models.Content, 0, len(contents))
+	for _, item := range contents {
+		if item == nil {
+			continue
+		}
+		if mutedCreators[item.CreatorID.String()] {
+			continue
+		}
+		if len(enabledCategories) > 0 && !enabledCategories[item.Category] {
+			continue
+		}
+		if containsMutedKeyword(item.Title, prefs.MutedKeywords) {
+			continue
+		}
+
+		filtered = append(filtered, item)
+		if prefs.MaxResults > 0 && len(filtered) >= prefs.MaxResults {
+			break
+		}
+	}
+
+	return filtered
+}
+
+// containsMutedKeyword reports whether title contains any of keywords,
+// case-insensitively.
+func containsMutedKeyword(title string, keywords []string) bool {
+	lowerTitle := strings.ToLower(title)
+	for _, keyword := range keywords {
+		if keyword == "" {
+			continue
+		}
+		if strings.Contains(lowerTitle, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+// Package preferences resolves and applies per-user feed customization
+// (internal/models.UserPreferences) on top of the tenant-wide defaults
+// in internal/database.ConfigRepository, and exposes the filtering the
+// feed/ranking path needs to honor them: muted creators/keywords,
+// enabled categories, result caps, and sort order.
+package preferences
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+// defaultMaxResults bounds page size for a user with no MaxResults
+// preference set (zero value), matching ContentHandler.ListContent's own
+// fallback limit.
+const defaultMaxResults = 20
+
+// Service resolves UserPreferences (defaulting from TenantConfig for
+// users who have never saved their own) and applies partial updates to
+// them with optimistic concurrency.
+type Service struct {
+	prefs   *database.PreferencesRepository
+	configs *database.ConfigRepository
+}
+
+// NewService creates a Service backed by prefs and configs.
+func NewService(prefs *database.PreferencesRepository, configs *database.ConfigRepository) *Service {
+	return &Service{prefs: prefs, configs: configs}
+}
+
+// Get returns userID's preferences, or sane per-tenant defaults (derived
+// from ConfigRepository) if they've never saved any. The returned value
+// is never persisted implicitly - it's a read-only view until Patch is
+// called.
+func (s *Service) Get(ctx context.Context, tenantID, userID string) (*models.UserPreferences, error) {
+	existing, err := s.prefs.GetByUser(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+	return s.defaults(ctx, tenantID, userID)
+}
+
+// defaults builds a UserPreferences for a user with no saved row,
+// borrowing tenant-wide defaults (enabled categories, default voice's
+// locale is out of scope here, so just categories) from TenantConfig
+// when available, and falling back to package defaults entirely if the
+// tenant has no config of its own yet.
+func (s *Service) defaults(ctx context.Context, tenantID, userID string) (*models.UserPreferences, error) {
+	prefs := &models.UserPreferences{
+		TenantID:    tenantID,
+		UserID:      userID,
+		DefaultHome: "trending",
+		Sort:        "published",
+		Locale:      "en",
+		Theme:       "light",
+		MaxResults:  defaultMaxResults,
+	}
+
+	cfg, err := s.configs.Get(ctx, tenantID)
+	if err != nil {
+		// No tenant config yet either - package defaults above are all we
+		// have.
+		return prefs, nil
+	}
+
+	prefs.EnabledCategories = cfg.EnabledCategories
+	return prefs, nil
+}
+
+// Patch applies an RFC 7396 JSON merge patch to userID's current
+// preferences (or their defaults, if they have none yet) and persists
+// the result. ifMatch, when non-zero, must equal the stored row's
+// UpdatedAt or Patch returns database.ErrPreferencesVersionMismatch
+// without writing anything.
+func (s *Service) Patch(ctx context.Context, tenantID, userID string, patch map[string]interface{}, ifMatch time.Time) (*models.UserPreferences, error) {
+	current, err := s.Get(ctx, tenantID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load current preferences: %w", err)
+	}
+
+	if err := applyMergePatch(current, patch); err != nil {
+		return nil, fmt.Errorf("failed to apply preferences patch: %w", err)
+	}
+	current.TenantID = tenantID
+	current.UserID = userID
+
+	updatedAt, err := s.prefs.Save(ctx, current, ifMatch)
+	if err != nil {
+		return nil, err
+	}
+	current.UpdatedAt = updatedAt
+	return current, nil
+}
+
+// applyMergePatch merges patch onto prefs in place following RFC 7396
+// semantics: a key present with a null value removes that field (reverts
+// it to its zero value), any other present key replaces it wholesale.
+// UserPreferences has no nested objects besides Extra, so a shallow
+// merge is sufficient here.
+func applyMergePatch(prefs *models.UserPreferences, patch map[string]interface{}) error {
+	current, err := json.Marshal(prefs)
+	if err != nil {
+		return err
+	}
+
+	var merged map[string]interface{}
+	if err := json.Unmarshal(current, &merged); err != nil {
+		return err
+	}
+
+	for key, value := range patch {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = value
+	}
+
+	mergedJSON, err := json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+
+	// Unmarshal into a zero-valued struct rather than prefs directly, so
+	// a patch key deleted above (set to null) actually reverts that field
+	// to its zero value instead of leaving prefs' old value untouched -
+	// json.Unmarshal never zeroes a field absent from the input.
+	var result models.UserPreferences
+	if err := json.Unmarshal(mergedJSON, &result); err != nil {
+		return err
+	}
+	*prefs = result
+	return nil
+}
@@ -0,0 +1,91 @@
+package preferences
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+func TestApplyMergePatchReplacesAndClearsFields(t *testing.T) {
+	prefs := &models.UserPreferences{
+		TenantID:    "tenant-1",
+		UserID:      "user-1",
+		DefaultHome: "trending",
+		Locale:      "en",
+		MaxResults:  20,
+	}
+
+	err := applyMergePatch(prefs, map[string]interface{}{
+		"locale":       "fr",
+		"max_results":  float64(50),
+		"default_home": nil,
+	})
+	if err != nil {
+		t.Fatalf("applyMergePatch returned error: %v", err)
+	}
+
+	if prefs.Locale != "fr" {
+		t.Errorf("expected locale to be replaced, got %q", prefs.Locale)
+	}
+	if prefs.MaxResults != 50 {
+		t.Errorf("expected max_results to be replaced, got %d", prefs.MaxResults)
+	}
+	if prefs.DefaultHome != "" {
+		t.Errorf("expected default_home to be cleared by a null patch value, got %q", prefs.DefaultHome)
+	}
+	if prefs.TenantID != "tenant-1" || prefs.UserID != "user-1" {
+		t.Errorf("expected untouched fields to survive the merge, got tenant=%q user=%q", prefs.TenantID, prefs.UserID)
+	}
+}
+
+func TestFilterContentDropsMutedCreatorsKeywordsAndCategories(t *testing.T) {
+	mutedCreator := uuid.New()
+	keptCreator := uuid.New()
+
+	prefs := &models.UserPreferences{
+		MutedCreators:     []uuid.UUID{mutedCreator},
+		MutedKeywords:     []string{"spoiler"},
+		EnabledCategories: []string{"tech"},
+		MaxResults:        2,
+	}
+
+	contents := []*models.Content{
+		{CreatorID: mutedCreator, Category: "tech", Title: "muted creator"},
+		{CreatorID: keptCreator, Category: "sports", Title: "wrong category"},
+		{CreatorID: keptCreator, Category: "tech", Title: "contains a SPOILER warning"},
+		{CreatorID: keptCreator, Category: "tech", Title: "first keeper"},
+		{CreatorID: keptCreator, Category: "tech", Title: "second keeper"},
+	}
+
+	filtered := FilterContent(prefs, contents)
+
+	if len(filtered) != 2 {
+		t.Fatalf("expected MaxResults to cap at 2, got %d", len(filtered))
+	}
+	if filtered[0].Title != "first keeper" || filtered[1].Title != "second keeper" {
+		t.Errorf("expected only the two eligible items in order, got %+v", filtered)
+	}
+}
+
+func TestResolveListOptionsCapsLimitAndFillsSort(t *testing.T) {
+	prefs := &models.UserPreferences{MaxResults: 10, Sort: "trending"}
+
+	opts := ResolveListOptions(prefs, database.ListOptions{Limit: 50})
+	if opts.Limit != 10 {
+		t.Errorf("expected limit capped to MaxResults, got %d", opts.Limit)
+	}
+	if opts.SortBy != "engagement_score" {
+		t.Errorf("expected sort resolved from prefs.Sort, got %q", opts.SortBy)
+	}
+
+	opts = ResolveListOptions(prefs, database.ListOptions{Limit: 5, SortBy: "view_count"})
+	if opts.Limit != 5 {
+		t.Errorf("expected an already-smaller limit left alone, got %d", opts.Limit)
+	}
+	if opts.SortBy != "view_count" {
+		t.Errorf("expected an explicit sort left alone, got %q", opts.SortBy)
+	}
+}
@@ -0,0 +1,236 @@
+// Package process provides a shared lifecycle framework for the
+// long-running subsystems that make up the News Feed Engine (the HTTP
+// server, the Kafka producer, the metrics pusher, ingestion workers, ...).
+// Previously each of these was wired ad hoc in main: started in its own
+// goroutine, with no consistent shutdown ordering and no way for /ready
+// to reflect anything beyond a hardcoded map. Runner centralizes that.
+//
+// This project configures itself from environment variables and GCP
+// Secret Manager (see internal/config) rather than a CLI flag parser, so
+// unlike similar "process" frameworks elsewhere, Process has no
+// Provide/flags step - Init receives whatever configuration the caller
+// has already loaded.
+package process
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+// Process is a subsystem the Runner can start, run, health-check, and
+// shut down in a uniform way.
+type Process interface {
+	// Name identifies the process in logs and in the aggregated health
+	// report; it should be stable and unique within a Runner.
+	Name() string
+	// Init prepares the process to run (opening connections, warming
+	// caches, ...). Init is called for every registered process, in
+	// registration order, before any process's Run is started.
+	Init(ctx context.Context) error
+	// Run executes the process until ctx is cancelled or it encounters a
+	// fatal error. A Process that has no ongoing work (e.g. it only acts
+	// via Init) should block on <-ctx.Done() and return nil.
+	Run(ctx context.Context) error
+	// Shutdown releases resources acquired in Init/Run. It is called for
+	// every registered process, in reverse registration order, as soon as
+	// the Runner's context is cancelled or any one process's Run returns;
+	// the Runner does not wait for the other Runs to return first.
+	Shutdown(ctx context.Context) error
+	// HealthCheck reports whether the process is currently healthy. It
+	// must return quickly and must not block on network I/O longer than
+	// the caller's context would tolerate for a readiness probe.
+	HealthCheck() error
+}
+
+// FuncProcess adapts plain functions to the Process interface so simple
+// subsystems don't need a dedicated type. Any nil function is treated as
+// a no-op (Init/Shutdown), always-healthy (HealthCheck), or, for RunFn,
+// a Run that blocks until ctx is cancelled and returns nil.
+type FuncProcess struct {
+	NameFn     string
+	InitFn     func(ctx context.Context) error
+	RunFn      func(ctx context.Context) error
+	ShutdownFn func(ctx context.Context) error
+	HealthFn   func() error
+}
+
+// Name returns the process name.
+func (f *FuncProcess) Name() string { return f.NameFn }
+
+// Init runs InitFn, if set.
+func (f *FuncProcess) Init(ctx context.Context) error {
+	if f.InitFn == nil {
+		return nil
+	}
+	return f.InitFn(ctx)
+}
+
+// Run runs RunFn.
+func (f *FuncProcess) Run(ctx context.Context) error {
+	if f.RunFn == nil {
+		<-ctx.Done()
+		return nil
+	}
+	return f.RunFn(ctx)
+}
+
+// Shutdown runs ShutdownFn, if set.
+func (f *FuncProcess) Shutdown(ctx context.Context) error {
+	if f.ShutdownFn == nil {
+		return nil
+	}
+	return f.ShutdownFn(ctx)
+}
+
+// HealthCheck runs HealthFn, if set, and otherwise reports healthy.
+func (f *FuncProcess) HealthCheck() error {
+	if f.HealthFn == nil {
+		return nil
+	}
+	return f.HealthFn()
+}
+
+// Runner coordinates a fixed set of Processes: it initializes them in
+// registration order, runs them concurrently, and on shutdown tears them
+// down in reverse order so dependents (e.g. the HTTP server) stop before
+// their dependencies (e.g. the Kafka producer it publishes through).
+type Runner struct {
+	mu        sync.RWMutex
+	processes []Process
+	logger    *zap.Logger
+}
+
+// NewRunner creates a Runner. A nil logger falls back to a development
+// logger so callers in tests don't need to construct one.
+func NewRunner(logger *zap.Logger) *Runner {
+	if logger == nil {
+		logger, _ = zap.NewDevelopment()
+	}
+	return &Runner{logger: logger}
+}
+
+// Register adds a process to the runner. It must be called before Start.
+func (r *Runner) Register(p Process) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.processes = append(r.processes, p)
+}
+
+// Start initializes every registered process, runs them concurrently,
+// and blocks until ctx is cancelled (for example by a SIGTERM-derived
+// context from signal.NotifyContext) or one of them returns from Run
+// (with or without an error) - whichever happens first - then shuts
+// every process down.
+func (r *Runner) Start(ctx context.Context) error {
+	r.mu.RLock()
+	procs := make([]Process, len(r.processes))
+	copy(procs, r.processes)
+	r.mu.RUnlock()
+
+	for _, p := range procs {
+		r.logger.Info("initializing process", zap.String("process", p.Name()))
+		if err := p.Init(ctx); err != nil {
+			return fmt.Errorf("failed to initialize process %q: %w", p.Name(), err)
+		}
+	}
+
+	errCh := make(chan error, len(procs))
+	for _, p := range procs {
+		go func(p Process) {
+			r.logger.Info("starting process", zap.String("process", p.Name()))
+			if err := p.Run(ctx); err != nil {
+				errCh <- fmt.Errorf("process %q exited: %w", p.Name(), err)
+				return
+			}
+			errCh <- nil
+		}(p)
+	}
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		r.logger.Info("shutdown signal received, stopping processes")
+	case runErr = <-errCh:
+		if runErr != nil {
+			r.logger.Error("process exited unexpectedly, stopping the rest", zap.Error(runErr))
+		}
+	}
+
+	shutdownCtx := context.Background()
+	if err := r.shutdown(shutdownCtx, procs); err != nil && runErr == nil {
+		runErr = err
+	}
+
+	return runErr
+}
+
+// shutdown tears processes down in reverse registration order, collecting
+// (rather than stopping at) the first error so every process gets a
+// chance to release its resources.
+func (r *Runner) shutdown(ctx context.Context, procs []Process) error {
+	var firstErr error
+	for i := len(procs) - 1; i >= 0; i-- {
+		p := procs[i]
+		r.logger.Info("shutting down process", zap.String("process", p.Name()))
+		if err := p.Shutdown(ctx); err != nil {
+			r.logger.Error("process shutdown failed", zap.String("process", p.Name()), zap.Error(err))
+			if firstErr == nil {
+				firstErr = fmt.Errorf("process %q shutdown failed: %w", p.Name(), err)
+			}
+		}
+	}
+	return firstErr
+}
+
+// HealthReport runs HealthCheck on every registered process and returns
+// the per-process result, keyed by process name, for surfacing in a
+// readiness endpoint.
+func (r *Runner) HealthReport() map[string]error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	report := make(map[string]error, len(r.processes))
+	for _, p := range r.processes {
+		report[p.Name()] = p.HealthCheck()
+	}
+	return report
+}
+
+// AsHealthChecker adapts the runner's aggregated HealthReport into a
+// metrics.HealthChecker, so it can be registered alongside the
+// database/kafka checks already feeding the /ready endpoint and have
+// /ready reflect every managed process rather than a fixed dependency
+// list.
+func (r *Runner) AsHealthChecker(name string) metrics.HealthChecker {
+	return func(ctx context.Context) metrics.HealthCheckResult {
+		report := r.HealthReport()
+
+		failures := make([]string, 0)
+		for procName, err := range report {
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", procName, err))
+			}
+		}
+
+		if len(failures) == 0 {
+			return metrics.HealthCheckResult{
+				Name:      name,
+				Status:    metrics.HealthStatusHealthy,
+				Message:   fmt.Sprintf("%d processes healthy", len(report)),
+				Timestamp: time.Now(),
+			}
+		}
+
+		return metrics.HealthCheckResult{
+			Name:      name,
+			Status:    metrics.HealthStatusUnhealthy,
+			Message:   fmt.Sprintf("%d/%d processes unhealthy: %v", len(failures), len(report), failures),
+			Timestamp: time.Now(),
+		}
+	}
+}
@@ -0,0 +1,240 @@
+// Package ratelimit enforces per-tenant admission control for video
+// generation jobs: a concurrency budget (how many jobs a tenant may have
+// in flight at once) and a per-clock-hour job count limit, both backed
+// by Redis so every replica of the API enforces the same counters.
+//
+// Job completion happens in a separate render worker this service
+// doesn't run (see database.VideoRepository's ClaimNext/Complete/Fail,
+// called against the same Postgres database from outside this repo), so
+// there is no in-process signal to release a reservation when a job
+// finishes. Instead each reservation is stored with a score equal to its
+// expiry time and simply falls out of the count once that passes - the
+// same self-healing approach VideoRepository.ReapExpiredLeases takes for
+// a worker that died mid-render.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// maxPriority is the highest accepted VideoGenerationMessage priority;
+// priorities route to topics base.p0 (highest) through base.pN, see
+// kafka.VideoPriorityTopic.
+const maxPriority = 9
+
+// Quota bounds one tenant's video generation throughput.
+type Quota struct {
+	MaxInFlight int `json:"max_in_flight"`
+	MaxPerHour  int `json:"max_per_hour"`
+}
+
+// BudgetError is returned by Reserve when tenantID is over one of its
+// quotas. RetryAfter is a caller-facing hint, e.g. for a Retry-After
+// header.
+type BudgetError struct {
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (e *BudgetError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", e.Reason, e.RetryAfter.Round(time.Second))
+}
+
+// VideoLimiter enforces Quota for video generation job submission. The
+// zero value is not usable; construct with NewVideoLimiter.
+type VideoLimiter struct {
+	client        *redis.Client
+	defaultQuota  Quota
+	leaseDuration time.Duration
+}
+
+// NewVideoLimiter creates a VideoLimiter. leaseDuration bounds how long a
+// reservation counts against a tenant's in-flight budget, and should be
+// at least as long as a render realistically takes - config.VideoLeaseDuration
+// is the natural choice, since that's the same assumption the DB-side
+// worker lease already makes.
+func NewVideoLimiter(client *redis.Client, defaultQuota Quota, leaseDuration time.Duration) *VideoLimiter {
+	return &VideoLimiter{client: client, defaultQuota: defaultQuota, leaseDuration: leaseDuration}
+}
+
+func quotaKey(tenantID string) string    { return "videoq:quota:" + tenantID }
+func inFlightKey(tenantID string) string { return "videoq:inflight:" + tenantID }
+
+func priorityKey(tenantID string, priority int) string {
+	return fmt.Sprintf("videoq:priority:%s:%d", tenantID, priority)
+}
+
+func hourlyKey(tenantID string, bucket int64) string {
+	return fmt.Sprintf("videoq:hourly:%s:%d", tenantID, bucket)
+}
+
+// Quota returns tenantID's configured quota, falling back to the
+// default for any field an admin hasn't overridden via UpdateQuota.
+func (l *VideoLimiter) Quota(ctx context.Context, tenantID string) (Quota, error) {
+	quota := l.defaultQuota
+
+	values, err := l.client.HGetAll(ctx, quotaKey(tenantID)).Result()
+	if err != nil {
+		return Quota{}, fmt.Errorf("failed to load tenant quota: %w", err)
+	}
+	if v, ok := values["max_in_flight"]; ok {
+		if parsed, err := strconv.Atoi(v); err == nil {
+			quota.MaxInFlight = parsed
+		}
+	}
+	if v, ok := values["max_per_hour"]; ok {
+		if parsed, err := strconv.Atoi(v); err == nil {
+			quota.MaxPerHour = parsed
+		}
+	}
+	return quota, nil
+}
+
+// UpdateQuota overrides tenantID's quota at runtime. A nil field leaves
+// that half of the quota at its current value (the default, or a prior
+// override), so an admin can adjust just max_in_flight or max_per_hour
+// without needing to know the other.
+func (l *VideoLimiter) UpdateQuota(ctx context.Context, tenantID string, maxInFlight, maxPerHour *int) (Quota, error) {
+	fields := map[string]interface{}{}
+	if maxInFlight != nil {
+		fields["max_in_flight"] = *maxInFlight
+	}
+	if maxPerHour != nil {
+		fields["max_per_hour"] = *maxPerHour
+	}
+	if len(fields) > 0 {
+		if err := l.client.HSet(ctx, quotaKey(tenantID), fields).Err(); err != nil {
+			return Quota{}, fmt.Errorf("failed to update tenant quota: %w", err)
+		}
+	}
+	return l.Quota(ctx, tenantID)
+}
+
+// Reserve admits one new job for tenantID at priority (0-9) under its
+// current quota, returning a *BudgetError if either the concurrency
+// budget or the hourly rate limit is exhausted. A Quota field of 0 means
+// unlimited for that dimension.
+func (l *VideoLimiter) Reserve(ctx context.Context, tenantID string, priority int) error {
+	quota, err := l.Quota(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	inKey := inFlightKey(tenantID)
+	if err := l.client.ZRemRangeByScore(ctx, inKey, "-inf", strconv.FormatInt(now.Unix(), 10)).Err(); err != nil {
+		return fmt.Errorf("failed to prune expired reservations: %w", err)
+	}
+	inFlight, err := l.client.ZCard(ctx, inKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to count in-flight jobs: %w", err)
+	}
+	if quota.MaxInFlight > 0 && inFlight >= int64(quota.MaxInFlight) {
+		retryAfter := l.leaseDuration
+		if oldest, err := l.client.ZRangeWithScores(ctx, inKey, 0, 0).Result(); err == nil && len(oldest) == 1 {
+			if until := time.Unix(int64(oldest[0].Score), 0).Sub(now); until > 0 {
+				retryAfter = until
+			}
+		}
+		return &BudgetError{Reason: "tenant concurrency budget exhausted", RetryAfter: retryAfter}
+	}
+
+	bucket := now.Truncate(time.Hour).Unix()
+	hKey := hourlyKey(tenantID, bucket)
+	count, err := l.client.Incr(ctx, hKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to increment hourly counter: %w", err)
+	}
+	if count == 1 {
+		l.client.Expire(ctx, hKey, time.Hour)
+	}
+	if quota.MaxPerHour > 0 && count > int64(quota.MaxPerHour) {
+		l.client.Decr(ctx, hKey)
+		return &BudgetError{
+			Reason:     "tenant hourly job budget exhausted",
+			RetryAfter: time.Unix(bucket, 0).Add(time.Hour).Sub(now),
+		}
+	}
+
+	expiry := float64(now.Add(l.leaseDuration).Unix())
+	jobID := uuid.New().String()
+	pipe := l.client.Pipeline()
+	pipe.ZAdd(ctx, inKey, redis.Z{Score: expiry, Member: jobID})
+	pipe.ZAdd(ctx, priorityKey(tenantID, priority), redis.Z{Score: expiry, Member: jobID})
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to record reservation: %w", err)
+	}
+
+	return nil
+}
+
+// Status summarizes tenantID's current usage against its Quota, for
+// GetVideoQueue.
+type Status struct {
+	Quota         Quota         `json:"quota"`
+	InFlight      int64         `json:"in_flight"`
+	RemainingHour int64         `json:"remaining_this_hour"` // -1 means unlimited
+	PriorityDepth map[int]int64 `json:"priority_depth"`
+}
+
+// Status reports tenantID's current in-flight count, per-priority queue
+// depth (priorities 0-9, omitted when zero), and remaining hourly quota.
+func (l *VideoLimiter) Status(ctx context.Context, tenantID string) (*Status, error) {
+	quota, err := l.Quota(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	nowStr := strconv.FormatInt(now.Unix(), 10)
+
+	inKey := inFlightKey(tenantID)
+	if err := l.client.ZRemRangeByScore(ctx, inKey, "-inf", nowStr).Err(); err != nil {
+		return nil, fmt.Errorf("failed to prune expired reservations: %w", err)
+	}
+	inFlight, err := l.client.ZCard(ctx, inKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count in-flight jobs: %w", err)
+	}
+
+	depth := make(map[int]int64)
+	for p := 0; p <= maxPriority; p++ {
+		key := priorityKey(tenantID, p)
+		if err := l.client.ZRemRangeByScore(ctx, key, "-inf", nowStr).Err(); err != nil {
+			return nil, fmt.Errorf("failed to prune expired priority %d reservations: %w", p, err)
+		}
+		count, err := l.client.ZCard(ctx, key).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to count priority %d queue depth: %w", p, err)
+		}
+		if count > 0 {
+			depth[p] = count
+		}
+	}
+
+	bucket := now.Truncate(time.Hour).Unix()
+	used, err := l.client.Get(ctx, hourlyKey(tenantID, bucket)).Int64()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to read hourly counter: %w", err)
+	}
+	remaining := int64(-1)
+	if quota.MaxPerHour > 0 {
+		remaining = int64(quota.MaxPerHour) - used
+		if remaining < 0 {
+			remaining = 0
+		}
+	}
+
+	return &Status{
+		Quota:         quota,
+		InFlight:      inFlight,
+		RemainingHour: remaining,
+		PriorityDepth: depth,
+	}, nil
+}
@@ -0,0 +1,203 @@
+package resilience
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
+)
+
+// breakerState mirrors internal/breaker's Closed/Open/Half-Open
+// lifecycle; it's redefined here rather than imported because that
+// package is wired around metrics.HealthChecker polling, not per-request
+// outcomes from an http.RoundTripper.
+type breakerState int32
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+func (s breakerState) gaugeValue() int64 {
+	return int64(s)
+}
+
+// BreakerConfig tunes a hostBreaker's trip/recovery behavior.
+type BreakerConfig struct {
+	WindowSize       int
+	FailureRatio     float64
+	MinRequestVolume int
+	Cooldown         time.Duration
+}
+
+// defaultBreakerConfig matches internal/breaker's defaults so the two
+// packages behave consistently where their knobs overlap.
+var defaultBreakerConfig = BreakerConfig{
+	WindowSize:       50,
+	FailureRatio:     0.5,
+	MinRequestVolume: 10,
+	Cooldown:         time.Minute,
+}
+
+// hostBreaker is a per-host circuit breaker over a rolling window of
+// RoundTrip outcomes. While Open it fails fast (Allow returns false)
+// until Cooldown has elapsed, then allows a single Half-Open probe.
+type hostBreaker struct {
+	cfg BreakerConfig
+	now func() time.Time
+
+	state    atomic.Int32
+	openedAt atomic.Int64
+
+	cursor   atomic.Uint64
+	outcomes []int32
+
+	probeMu  sync.Mutex
+	inFlight bool
+
+	gauge *metrics.Gauge
+}
+
+func newHostBreaker(host string, cfg BreakerConfig, now func() time.Time) *hostBreaker {
+	if cfg.WindowSize <= 0 {
+		cfg.WindowSize = defaultBreakerConfig.WindowSize
+	}
+	if cfg.FailureRatio <= 0 {
+		cfg.FailureRatio = defaultBreakerConfig.FailureRatio
+	}
+	if cfg.MinRequestVolume <= 0 {
+		cfg.MinRequestVolume = defaultBreakerConfig.MinRequestVolume
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = defaultBreakerConfig.Cooldown
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &hostBreaker{
+		cfg:      cfg,
+		now:      now,
+		outcomes: make([]int32, cfg.WindowSize),
+		gauge:    metrics.RegisterGauge("resilience_circuit_breaker_state", map[string]string{"host": host}),
+	}
+}
+
+// Allow reports whether a request to this host may proceed right now,
+// and whether this call is the single permitted Half-Open probe (callers
+// that get probe=true must report its outcome via Record so a later
+// caller isn't left waiting on a probe that never resolves).
+func (b *hostBreaker) Allow() (allowed bool, probe bool) {
+	switch breakerState(b.state.Load()) {
+	case breakerOpen:
+		if b.now().Sub(b.openedSince()) < b.cfg.Cooldown {
+			return false, false
+		}
+		b.probeMu.Lock()
+		defer b.probeMu.Unlock()
+		if b.inFlight {
+			return false, false
+		}
+		b.state.CompareAndSwap(int32(breakerOpen), int32(breakerHalfOpen))
+		b.inFlight = true
+		return true, true
+	case breakerHalfOpen:
+		b.probeMu.Lock()
+		defer b.probeMu.Unlock()
+		if b.inFlight {
+			return false, false
+		}
+		b.inFlight = true
+		return true, true
+	default:
+		return true, false
+	}
+}
+
+// Record folds a RoundTrip outcome into the rolling window, resolving
+// an in-flight Half-Open probe if isProbe is set.
+func (b *hostBreaker) Record(success bool, isProbe bool) {
+	idx := b.cursor.Add(1) - 1
+	slot := int(idx % uint64(len(b.outcomes)))
+	if success {
+		atomic.StoreInt32(&b.outcomes[slot], 1)
+	} else {
+		atomic.StoreInt32(&b.outcomes[slot], -1)
+	}
+
+	if isProbe {
+		b.probeMu.Lock()
+		b.inFlight = false
+		b.probeMu.Unlock()
+
+		if success {
+			b.close()
+		} else {
+			b.open()
+		}
+		return
+	}
+
+	if breakerState(b.state.Load()) == breakerClosed {
+		requests, failures := b.counts()
+		if requests >= b.cfg.MinRequestVolume && float64(failures)/float64(requests) >= b.cfg.FailureRatio {
+			b.open()
+		}
+	}
+}
+
+func (b *hostBreaker) open() {
+	b.openedAt.Store(b.now().UnixNano())
+	b.state.Store(int32(breakerOpen))
+	b.gauge.Set(breakerOpen.gaugeValue())
+}
+
+func (b *hostBreaker) close() {
+	b.state.Store(int32(breakerClosed))
+	b.openedAt.Store(0)
+	for i := range b.outcomes {
+		atomic.StoreInt32(&b.outcomes[i], 0)
+	}
+	b.gauge.Set(breakerClosed.gaugeValue())
+}
+
+func (b *hostBreaker) openedSince() time.Time {
+	return time.Unix(0, b.openedAt.Load())
+}
+
+func (b *hostBreaker) counts() (requests, failures int) {
+	for i := range b.outcomes {
+		switch atomic.LoadInt32(&b.outcomes[i]) {
+		case 1:
+			requests++
+		case -1:
+			requests++
+			failures++
+		}
+	}
+	return requests, failures
+}
+
+// breakerSet holds one hostBreaker per host, created lazily.
+type breakerSet struct {
+	mu       sync.Mutex
+	cfg      BreakerConfig
+	now      func() time.Time
+	breakers map[string]*hostBreaker
+}
+
+func newBreakerSet(cfg BreakerConfig, now func() time.Time) *breakerSet {
+	return &breakerSet{cfg: cfg, now: now, breakers: make(map[string]*hostBreaker)}
+}
+
+func (s *breakerSet) forHost(host string) *hostBreaker {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.breakers[host]
+	if !ok {
+		b = newHostBreaker(host, s.cfg, s.now)
+		s.breakers[host] = b
+	}
+	return b
+}
@@ -0,0 +1,17 @@
+package resilience
+
+import "fmt"
+
+// CircuitOpenError is returned by Transport.RoundTrip when a host's
+// circuit breaker is Open and not yet due for a Half-Open probe.
+type CircuitOpenError struct {
+	Host string
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("resilience: circuit breaker open for host %q", e.Host)
+}
+
+func errCircuitOpen(host string) error {
+	return &CircuitOpenError{Host: host}
+}
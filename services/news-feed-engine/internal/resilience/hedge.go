@@ -0,0 +1,65 @@
+package resilience
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// hedgedResult carries one attempt's outcome back to whichever select
+// in doHedged is waiting on it.
+type hedgedResult struct {
+	resp *http.Response
+	err  error
+}
+
+// doHedged issues req via send, and if no response has arrived after
+// threshold, fires a second identical request concurrently (a "hedge"),
+// returning whichever attempt completes first. The loser's request
+// context is cancelled so it doesn't keep consuming upstream capacity
+// after the winner has already answered. Only safe for idempotent
+// requests - callers are responsible for only hedging GETs.
+func doHedged(req *http.Request, threshold time.Duration, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	primaryCtx, cancelPrimary := context.WithCancel(req.Context())
+	defer cancelPrimary()
+	primary := req.Clone(primaryCtx)
+
+	results := make(chan hedgedResult, 2)
+	go func() {
+		resp, err := send(primary)
+		results <- hedgedResult{resp, err}
+	}()
+
+	timer := time.NewTimer(threshold)
+	defer timer.Stop()
+
+	select {
+	case res := <-results:
+		return res.resp, res.err
+	case <-timer.C:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	hedgeCtx, cancelHedge := context.WithCancel(req.Context())
+	defer cancelHedge()
+	hedge := req.Clone(hedgeCtx)
+
+	go func() {
+		resp, err := send(hedge)
+		results <- hedgedResult{resp, err}
+	}()
+
+	// Whichever of the two attempts answers first wins; cancel the
+	// other so it stops holding a connection open for an answer nobody
+	// will use.
+	first := <-results
+	if first.err != nil {
+		select {
+		case second := <-results:
+			return second.resp, second.err
+		default:
+		}
+	}
+	return first.resp, first.err
+}
@@ -0,0 +1,125 @@
+package resilience
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimitConfig configures one endpoint group's token bucket: tokens
+// refill at RatePerSecond, up to a maximum of Burst held at once.
+type RateLimitConfig struct {
+	RatePerSecond float64
+	Burst         int
+}
+
+// tokenBucket is a minimal token-bucket limiter, standing in for
+// golang.org/x/time/rate.Limiter since this tree has no module/vendor
+// path to pull it in. Semantics match rate.Limiter's Wait: block until
+// a token is available or ctx is done, refilling continuously rather
+// than in discrete ticks.
+type tokenBucket struct {
+	mu         sync.Mutex
+	rate       float64 // tokens per second
+	burst      float64
+	tokens     float64
+	lastRefill time.Time
+	now        func() time.Time
+}
+
+func newTokenBucket(cfg RateLimitConfig, now func() time.Time) *tokenBucket {
+	if now == nil {
+		now = time.Now
+	}
+	return &tokenBucket{
+		rate:       cfg.RatePerSecond,
+		burst:      float64(cfg.Burst),
+		tokens:     float64(cfg.Burst),
+		lastRefill: now(),
+		now:        now,
+	}
+}
+
+// Wait blocks until a token is available, then consumes it.
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	for {
+		wait, ok := b.reserve()
+		if ok {
+			return nil
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve refills the bucket for elapsed time and, if a token is
+// already available, consumes it immediately (ok=true). Otherwise it
+// returns how long the caller should wait before trying again.
+func (b *tokenBucket) reserve() (wait time.Duration, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	b.lastRefill = now
+	if elapsed > 0 {
+		b.tokens += elapsed * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, true
+	}
+
+	if b.rate <= 0 {
+		return time.Second, false
+	}
+	shortfall := 1 - b.tokens
+	return time.Duration(shortfall / b.rate * float64(time.Second)), false
+}
+
+// limiterSet holds one tokenBucket per endpoint group, created lazily
+// from the configured RateLimitConfig map.
+type limiterSet struct {
+	mu       sync.Mutex
+	configs  map[string]RateLimitConfig
+	limiters map[string]*tokenBucket
+	now      func() time.Time
+}
+
+func newLimiterSet(configs map[string]RateLimitConfig, now func() time.Time) *limiterSet {
+	return &limiterSet{
+		configs:  configs,
+		limiters: make(map[string]*tokenBucket),
+		now:      now,
+	}
+}
+
+// Wait blocks on the named group's limiter, if one is configured. An
+// unconfigured group is unlimited.
+func (s *limiterSet) Wait(ctx context.Context, group string) error {
+	cfg, ok := s.configs[group]
+	if !ok {
+		return nil
+	}
+
+	s.mu.Lock()
+	b, ok := s.limiters[group]
+	if !ok {
+		b = newTokenBucket(cfg, s.now)
+		s.limiters[group] = b
+	}
+	s.mu.Unlock()
+
+	if err := b.Wait(ctx); err != nil {
+		return fmt.Errorf("resilience: rate limit wait for %q: %w", group, err)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package resilience
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func zeroJitter(n int64) int64 { return 0 }
+
+func TestBackoffWithJitterDoublesPerAttempt(t *testing.T) {
+	base := 100 * time.Millisecond
+	if got := backoffWithJitter(base, 0, 0, zeroJitter); got != base {
+		t.Fatalf("attempt 0: expected %v, got %v", base, got)
+	}
+	if got := backoffWithJitter(base, 2, 0, zeroJitter); got != 4*base {
+		t.Fatalf("attempt 2: expected %v, got %v", 4*base, got)
+	}
+}
+
+func TestBackoffWithJitterPrefersRetryAfter(t *testing.T) {
+	got := backoffWithJitter(time.Second, 5, 3*time.Second, zeroJitter)
+	if got != 3*time.Second {
+		t.Fatalf("expected Retry-After to win over computed backoff, got %v", got)
+	}
+}
+
+func TestParseRetryAfterSeconds(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+	if got := parseRetryAfter(resp); got != 2*time.Second {
+		t.Fatalf("expected 2s, got %v", got)
+	}
+}
+
+func TestParseRetryAfterMissingHeaderIsZero(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	if got := parseRetryAfter(resp); got != 0 {
+		t.Fatalf("expected 0, got %v", got)
+	}
+}
+
+func TestIsRetryableStatus(t *testing.T) {
+	cases := map[int]bool{200: false, 404: false, 429: true, 500: true, 503: true}
+	for status, want := range cases {
+		if got := isRetryableStatus(status); got != want {
+			t.Errorf("status %d: expected %v, got %v", status, want, got)
+		}
+	}
+}
+
+func TestHostBreakerTripsAfterFailureRatio(t *testing.T) {
+	b := newHostBreaker("example.com", BreakerConfig{WindowSize: 4, FailureRatio: 0.5, MinRequestVolume: 2, Cooldown: time.Hour}, nil)
+
+	allowed, _ := b.Allow()
+	if !allowed {
+		t.Fatalf("expected breaker to start closed")
+	}
+	b.Record(false, false)
+	b.Record(false, false)
+
+	if allowed, _ := b.Allow(); allowed {
+		t.Fatalf("expected breaker to be open after failure ratio exceeded")
+	}
+}
+
+func TestHostBreakerHalfOpenProbeRecovers(t *testing.T) {
+	now := time.Unix(0, 0)
+	clock := func() time.Time { return now }
+	b := newHostBreaker("example.com", BreakerConfig{WindowSize: 4, FailureRatio: 0.5, MinRequestVolume: 1, Cooldown: time.Minute}, clock)
+
+	b.Record(false, false)
+	if allowed, _ := b.Allow(); allowed {
+		t.Fatalf("expected breaker to be open")
+	}
+
+	now = now.Add(time.Hour)
+	allowed, probe := b.Allow()
+	if !allowed || !probe {
+		t.Fatalf("expected a single half-open probe to be allowed, got allowed=%v probe=%v", allowed, probe)
+	}
+	b.Record(true, true)
+
+	if allowed, _ := b.Allow(); !allowed {
+		t.Fatalf("expected breaker to close after a successful probe")
+	}
+}
+
+func TestTokenBucketRefillsOverTime(t *testing.T) {
+	now := time.Unix(0, 0)
+	clock := func() time.Time { return now }
+	bucket := newTokenBucket(RateLimitConfig{RatePerSecond: 1, Burst: 1}, clock)
+
+	if err := bucket.Wait(context.Background()); err != nil {
+		t.Fatalf("expected first token to be available immediately: %v", err)
+	}
+
+	if wait, ok := bucket.reserve(); ok || wait <= 0 {
+		t.Fatalf("expected bucket to be empty, got wait=%v ok=%v", wait, ok)
+	}
+
+	now = now.Add(time.Second)
+	if _, ok := bucket.reserve(); !ok {
+		t.Fatalf("expected a token to have refilled after 1s")
+	}
+}
+
+func TestLimiterSetIsUnlimitedForUnconfiguredGroup(t *testing.T) {
+	set := newLimiterSet(map[string]RateLimitConfig{"search": {RatePerSecond: 1, Burst: 1}}, nil)
+	if err := set.Wait(context.Background(), "default"); err != nil {
+		t.Fatalf("expected unconfigured group to be unlimited, got %v", err)
+	}
+}
+
+func TestDefaultEndpointGroupClassifiesKnownPaths(t *testing.T) {
+	cases := map[string]string{
+		"/api/v1/assets/123/analyze": "analyze",
+		"/api/v1/assets/search":      "search",
+		"/api/v1/uploads":            "upload",
+		"/api/v1/assets/123":         "default",
+	}
+	for path, want := range cases {
+		req := httptest.NewRequest(http.MethodGet, "https://media.example.com"+path, nil)
+		if got := DefaultEndpointGroup(req); got != want {
+			t.Errorf("path %s: expected group %q, got %q", path, want, got)
+		}
+	}
+}
+
+func TestDoHedgedReturnsPrimaryWhenFastEnough(t *testing.T) {
+	var hedgeCalls int32
+	req := httptest.NewRequest(http.MethodGet, "https://media.example.com/api/v1/assets", nil)
+
+	resp, err := doHedged(req, 50*time.Millisecond, func(r *http.Request) (*http.Response, error) {
+		if atomic.AddInt32(&hedgeCalls, 1) > 1 {
+			t.Fatalf("expected only the primary request to fire")
+		}
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected a successful response, got resp=%v err=%v", resp, err)
+	}
+}
+
+func TestDoHedgedFiresHedgeAfterThreshold(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "https://media.example.com/api/v1/assets", nil)
+	var calls int32
+
+	resp, err := doHedged(req, 5*time.Millisecond, func(r *http.Request) (*http.Response, error) {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 1 {
+			<-r.Context().Done()
+			return nil, r.Context().Err()
+		}
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the hedge response to win, got resp=%v err=%v", resp, err)
+	}
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Fatalf("expected both primary and hedge to fire, got %d calls", calls)
+	}
+}
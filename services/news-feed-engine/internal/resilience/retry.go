@@ -0,0 +1,74 @@
+package resilience
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultMaxRetries/defaultBaseBackoff mirror the embeddings package's
+// retry defaults: most transient upstream failures (429, 5xx) clear up
+// within a few seconds, so anything beyond this should surface to the
+// caller rather than keep a request blocked indefinitely.
+const (
+	defaultMaxRetries  = 4
+	defaultBaseBackoff = 250 * time.Millisecond
+)
+
+// isRetryableStatus reports whether an HTTP status is worth retrying:
+// 429 (rate limited) or any 5xx.
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// backoffWithJitter returns the delay before retry attempt (0-indexed),
+// doubling base each attempt and adding up to 50% jitter via randInt63n
+// so many concurrent callers backing off from the same upstream don't
+// retry in lockstep. A non-zero retryAfter (parsed from the prior
+// response's Retry-After header) takes precedence over the computed
+// backoff, since the upstream knows its own recovery time better than a
+// guess does.
+func backoffWithJitter(base time.Duration, attempt int, retryAfter time.Duration, randInt63n func(int64) int64) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+	delay := base * time.Duration(1<<uint(attempt))
+	jitter := time.Duration(randInt63n(int64(delay)/2 + 1))
+	return delay + jitter
+}
+
+// parseRetryAfter reads the Retry-After header, which may be either a
+// number of seconds or an HTTP-date. An unparseable or absent header
+// returns zero.
+func parseRetryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// sleepForRetry blocks for d or returns ctx's error if it's cancelled
+// first.
+func sleepForRetry(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,59 @@
+package resilience
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func httpStatusCodeAttr(code int) attribute.KeyValue {
+	return attribute.Int("http.status_code", code)
+}
+
+func httpResponseSizeAttr(size int64) attribute.KeyValue {
+	return attribute.Int64("http.response_size", size)
+}
+
+// startSpan starts a client span for req (a no-op returning a nil span
+// if no TracingProvider is configured), tagged with tenant_id and
+// asset_id so a trace can be filtered down to one tenant or asset
+// without grepping logs.
+func (t *Transport) startSpan(req *http.Request) (context.Context, trace.Span) {
+	if t.opts.TracingProvider == nil {
+		return req.Context(), nil
+	}
+
+	attrs := map[string]interface{}{
+		"http.method":   req.Method,
+		"net.peer.name": req.URL.Hostname(),
+	}
+	if tenantID := req.Header.Get(t.opts.TenantHeader); tenantID != "" {
+		attrs["tenant_id"] = tenantID
+	}
+	if match := assetIDPattern.FindStringSubmatch(req.URL.Path); len(match) == 2 {
+		attrs["asset_id"] = match[1]
+	}
+
+	return t.opts.TracingProvider.StartSpan(req.Context(), "http.client."+req.Method, &metrics.SpanOptions{
+		SpanKind:   trace.SpanKindClient,
+		Attributes: attrs,
+	})
+}
+
+// endSpan records resp's status and size (if any) and ends span. A nil
+// span (tracing disabled) is a no-op.
+func (t *Transport) endSpan(span trace.Span, resp *http.Response, err error) {
+	if span == nil {
+		return
+	}
+	if resp != nil {
+		span.SetAttributes(
+			httpStatusCodeAttr(resp.StatusCode),
+			httpResponseSizeAttr(resp.ContentLength),
+		)
+	}
+	t.opts.TracingProvider.EndSpan(span, err)
+}
@@ -0,0 +1,82 @@
+package rightsengine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// namedRestrictions are shorthand keywords content editors can put
+// straight into UsageRights.Restrictions instead of writing an
+// expression.
+var namedRestrictions = map[string]func(ctx UsageContext) (denied bool, reason string){
+	"no-commercial-use": func(ctx UsageContext) (bool, string) {
+		if ctx.UsageType == UsageCommercial {
+			return true, "asset is restricted to non-commercial use"
+		}
+		return false, ""
+	},
+	"editorial-only": func(ctx UsageContext) (bool, string) {
+		if ctx.UsageType != UsageEditorial {
+			return true, "asset is restricted to editorial use"
+		}
+		return false, ""
+	},
+	"attribution-required": func(ctx UsageContext) (bool, string) {
+		if !ctx.RequiresAttribution {
+			return true, "asset requires attribution to be requested explicitly"
+		}
+		return false, ""
+	},
+}
+
+// evaluateRestriction applies a single UsageRights.Restrictions entry
+// against ctx. Entries are either a named restriction (see
+// namedRestrictions) or a small expression of the form
+// "field == value" / "field != value" over ctx's fields
+// ("platform", "usage_type"), the minimal rego/CEL-style rule language
+// this engine supports without taking on an OPA or CEL dependency.
+func evaluateRestriction(restriction string, ctx UsageContext) (denied bool, reason string, err error) {
+	restriction = strings.TrimSpace(restriction)
+	if rule, ok := namedRestrictions[strings.ToLower(restriction)]; ok {
+		denied, reason = rule(ctx)
+		return denied, reason, nil
+	}
+
+	field, op, value, ok := parseExpression(restriction)
+	if !ok {
+		return false, "", fmt.Errorf("unrecognized restriction %q", restriction)
+	}
+
+	var actual string
+	switch field {
+	case "platform", "target_platform":
+		actual = ctx.TargetPlatform
+	case "usage_type":
+		actual = string(ctx.UsageType)
+	default:
+		return false, "", fmt.Errorf("unknown restriction field %q", field)
+	}
+
+	matches := actual == value
+	if op == "!=" {
+		matches = !matches
+	}
+	if matches {
+		return true, fmt.Sprintf("restriction %q matched (%s=%q)", restriction, field, actual), nil
+	}
+	return false, "", nil
+}
+
+// parseExpression splits "field op value" (op is "==" or "!=") into
+// its three parts, case-insensitively, tolerating surrounding
+// whitespace.
+func parseExpression(expr string) (field, op, value string, ok bool) {
+	for _, candidate := range []string{"!=", "=="} {
+		if idx := strings.Index(expr, candidate); idx >= 0 {
+			field = strings.ToLower(strings.TrimSpace(expr[:idx]))
+			value = strings.ToLower(strings.TrimSpace(expr[idx+len(candidate):]))
+			return field, candidate, value, field != "" && value != ""
+		}
+	}
+	return "", "", "", false
+}
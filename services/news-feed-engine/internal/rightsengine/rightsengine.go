@@ -0,0 +1,104 @@
+// Package rightsengine evaluates an asset's UsageRights against how a
+// caller wants to use it, so disallowed uses can be filtered out before
+// an asset is recommended or published rather than relied on to be
+// caught by a human reviewer.
+package rightsengine
+
+import (
+	"fmt"
+	"time"
+)
+
+// UsageType is the intended commercial posture of a use, matched
+// against Asset.Restrictions rules like "usage_type != commercial".
+type UsageType string
+
+const (
+	UsageCommercial UsageType = "commercial"
+	UsageEditorial  UsageType = "editorial"
+)
+
+// UsageContext describes how and when an asset is about to be used.
+type UsageContext struct {
+	TargetPlatform      string
+	UsageType           UsageType
+	RequiresAttribution bool
+	PublishAt           time.Time
+}
+
+// Asset is the subset of a media asset's rights metadata Evaluate
+// needs. It mirrors integrations.UsageRights rather than importing it,
+// so this package stays usable without depending on the rest of the
+// integrations package.
+type Asset struct {
+	ID                  string
+	AttributionRequired bool
+	AttributionText     string
+	ExpiresAt           time.Time
+	AllowedPlatforms    []string
+	Restrictions        []string
+}
+
+// Decision is the result of evaluating an Asset against a UsageContext.
+type Decision struct {
+	Allowed bool
+	Reason  string
+	// RequiredAttribution is the attribution text callers must display
+	// alongside the asset, set whenever attribution is required and the
+	// use is otherwise allowed.
+	RequiredAttribution string
+}
+
+// Evaluate decides whether asset may be used as described by ctx. It
+// checks license expiry against ctx.PublishAt, intersects
+// AllowedPlatforms with ctx.TargetPlatform, and runs every restriction
+// rule in Asset.Restrictions, denying on the first one that matches.
+func Evaluate(asset Asset, ctx UsageContext) (Decision, error) {
+	if !asset.ExpiresAt.IsZero() {
+		publishAt := ctx.PublishAt
+		if publishAt.IsZero() {
+			publishAt = time.Now()
+		}
+		if publishAt.After(asset.ExpiresAt) {
+			return Decision{
+				Allowed: false,
+				Reason:  fmt.Sprintf("license expired at %s, publish date is %s", asset.ExpiresAt.Format(time.RFC3339), publishAt.Format(time.RFC3339)),
+			}, nil
+		}
+	}
+
+	if len(asset.AllowedPlatforms) > 0 && ctx.TargetPlatform != "" && !contains(asset.AllowedPlatforms, ctx.TargetPlatform) {
+		return Decision{
+			Allowed: false,
+			Reason:  fmt.Sprintf("platform %q is not in the asset's allowed platforms %v", ctx.TargetPlatform, asset.AllowedPlatforms),
+		}, nil
+	}
+
+	for _, restriction := range asset.Restrictions {
+		denied, reason, err := evaluateRestriction(restriction, ctx)
+		if err != nil {
+			return Decision{}, fmt.Errorf("rightsengine: restriction %q: %w", restriction, err)
+		}
+		if denied {
+			return Decision{Allowed: false, Reason: reason}, nil
+		}
+	}
+
+	decision := Decision{Allowed: true}
+	if asset.AttributionRequired || ctx.RequiresAttribution {
+		decision.RequiredAttribution = asset.AttributionText
+		if decision.RequiredAttribution == "" {
+			decision.RequiredAttribution = "Attribution required"
+		}
+	}
+	return decision, nil
+}
+
+func contains(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package rightsengine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvaluateDeniesExpiredLicense(t *testing.T) {
+	asset := Asset{ID: "asset-1", ExpiresAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	ctx := UsageContext{PublishAt: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)}
+
+	decision, err := Evaluate(asset, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decision.Allowed {
+		t.Fatal("expected expired license to be denied")
+	}
+}
+
+func TestEvaluateAllowsUnexpiredLicense(t *testing.T) {
+	asset := Asset{ID: "asset-1", ExpiresAt: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)}
+	ctx := UsageContext{PublishAt: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)}
+
+	decision, err := Evaluate(asset, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !decision.Allowed {
+		t.Fatalf("expected license to be allowed, got reason %q", decision.Reason)
+	}
+}
+
+func TestEvaluateDeniesDisallowedPlatform(t *testing.T) {
+	asset := Asset{ID: "asset-1", AllowedPlatforms: []string{"instagram", "tiktok"}}
+	ctx := UsageContext{TargetPlatform: "twitter"}
+
+	decision, err := Evaluate(asset, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decision.Allowed {
+		t.Fatal("expected platform not in AllowedPlatforms to be denied")
+	}
+}
+
+func TestEvaluateAllowsPlatformInAllowedList(t *testing.T) {
+	asset := Asset{ID: "asset-1", AllowedPlatforms: []string{"instagram", "tiktok"}}
+	ctx := UsageContext{TargetPlatform: "tiktok"}
+
+	decision, err := Evaluate(asset, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !decision.Allowed {
+		t.Fatalf("expected allowed platform to pass, got reason %q", decision.Reason)
+	}
+}
+
+func TestEvaluateNamedRestrictionNoCommercialUse(t *testing.T) {
+	asset := Asset{ID: "asset-1", Restrictions: []string{"no-commercial-use"}}
+
+	decision, err := Evaluate(asset, UsageContext{UsageType: UsageCommercial})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decision.Allowed {
+		t.Fatal("expected commercial use to be denied by no-commercial-use restriction")
+	}
+
+	decision, err = Evaluate(asset, UsageContext{UsageType: UsageEditorial})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !decision.Allowed {
+		t.Fatalf("expected editorial use to pass no-commercial-use restriction, got reason %q", decision.Reason)
+	}
+}
+
+func TestEvaluateExpressionRestriction(t *testing.T) {
+	asset := Asset{ID: "asset-1", Restrictions: []string{"usage_type == commercial"}}
+
+	decision, err := Evaluate(asset, UsageContext{UsageType: UsageCommercial})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decision.Allowed {
+		t.Fatal("expected usage_type == commercial restriction to deny commercial use")
+	}
+}
+
+func TestEvaluateUnrecognizedRestrictionErrors(t *testing.T) {
+	asset := Asset{ID: "asset-1", Restrictions: []string{"not a real rule"}}
+
+	if _, err := Evaluate(asset, UsageContext{}); err == nil {
+		t.Fatal("expected an error for an unrecognized restriction")
+	}
+}
+
+func TestEvaluateSetsRequiredAttribution(t *testing.T) {
+	asset := Asset{ID: "asset-1", AttributionRequired: true, AttributionText: "Photo by Example"}
+
+	decision, err := Evaluate(asset, UsageContext{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decision.RequiredAttribution != "Photo by Example" {
+		t.Fatalf("expected attribution text to be carried through, got %q", decision.RequiredAttribution)
+	}
+}
@@ -0,0 +1,32 @@
+package secrets
+
+import (
+	"context"
+	"os"
+	"strings"
+)
+
+// EnvProvider resolves secrets from environment variables, mapping a
+// Secret Manager-style name (e.g. "news-feed-openai-api-key") to its
+// conventional env var (OPENAI_API_KEY) by stripping the "news-feed-"
+// prefix and upper-casing the remainder. It's the development default
+// and mirrors config's pre-existing loadSecretsFromEnv naming. An
+// explicit "@version" suffix is ignored, since environment variables
+// aren't versioned.
+type EnvProvider struct{}
+
+// NewEnvProvider creates an EnvProvider.
+func NewEnvProvider() EnvProvider {
+	return EnvProvider{}
+}
+
+// Get implements Provider.
+func (EnvProvider) Get(ctx context.Context, name string) (string, error) {
+	base, _ := splitPinnedVersion(name)
+	return os.Getenv(envVarName(base)), nil
+}
+
+func envVarName(name string) string {
+	name = strings.TrimPrefix(name, "news-feed-")
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
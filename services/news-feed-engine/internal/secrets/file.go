@@ -0,0 +1,33 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileProvider resolves secrets from individual files in dir, one file
+// per bare secret name (e.g. dir/news-feed-openai-api-key), trimming
+// surrounding whitespace the way a Kubernetes secret volume mount or a
+// Docker secret file commonly carries. An explicit "@version" suffix is
+// ignored, since files on disk aren't versioned.
+type FileProvider struct {
+	dir string
+}
+
+// NewFileProvider creates a FileProvider reading secret files from dir.
+func NewFileProvider(dir string) FileProvider {
+	return FileProvider{dir: dir}
+}
+
+// Get implements Provider.
+func (p FileProvider) Get(ctx context.Context, name string) (string, error) {
+	base, _ := splitPinnedVersion(name)
+	data, err := os.ReadFile(filepath.Join(p.dir, base))
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file for %s: %w", base, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
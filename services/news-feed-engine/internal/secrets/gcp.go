@@ -0,0 +1,180 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"go.uber.org/zap"
+)
+
+// defaultCacheTTL bounds how long CachedSecretManager trusts a cached
+// value before re-fetching it on the next Get.
+const defaultCacheTTL = 5 * time.Minute
+
+// defaultPollInterval is how often WatchRotation re-reads versions/latest
+// for every unpinned secret it has cached, if the caller passes <= 0.
+const defaultPollInterval = time.Minute
+
+type cacheEntry struct {
+	value     string
+	version   string
+	fetchedAt time.Time
+}
+
+// CachedSecretManager is a Provider backed by GCP Secret Manager that
+// caches each secret's value for ttl and can poll versions/latest for
+// rotation, publishing detected changes on Watch()'s channel so a long-
+// running process can swap credentials without restarting.
+type CachedSecretManager struct {
+	client    *secretmanager.Client
+	projectID string
+	ttl       time.Duration
+	logger    *zap.Logger
+
+	mu    sync.RWMutex
+	cache map[string]cacheEntry
+
+	changes   chan Change
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewCachedSecretManager creates a CachedSecretManager for projectID. A
+// ttl <= 0 falls back to defaultCacheTTL. A nil logger discards log
+// output, so callers that don't have one yet (config.Load runs before
+// the service logger exists) can still use it.
+func NewCachedSecretManager(ctx context.Context, projectID string, ttl time.Duration, logger *zap.Logger) (*CachedSecretManager, error) {
+	client, err := secretmanager.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create secret manager client: %w", err)
+	}
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &CachedSecretManager{
+		client:    client,
+		projectID: projectID,
+		ttl:       ttl,
+		logger:    logger,
+		cache:     make(map[string]cacheEntry),
+		changes:   make(chan Change, 16),
+		done:      make(chan struct{}),
+	}, nil
+}
+
+// Close stops any running WatchRotation loop and closes the underlying
+// Secret Manager client.
+func (m *CachedSecretManager) Close() error {
+	m.closeOnce.Do(func() { close(m.done) })
+	return m.client.Close()
+}
+
+// Get implements Provider, serving from cache when the entry is younger
+// than ttl and fetching versions/latest (or the pinned version in
+// name's "@version" suffix) otherwise.
+func (m *CachedSecretManager) Get(ctx context.Context, name string) (string, error) {
+	m.mu.RLock()
+	entry, ok := m.cache[name]
+	m.mu.RUnlock()
+	if ok && time.Since(entry.fetchedAt) < m.ttl {
+		return entry.value, nil
+	}
+
+	base, version := splitPinnedVersion(name)
+	value, resolvedVersion, err := m.access(ctx, base, version)
+	if err != nil {
+		return "", err
+	}
+
+	m.mu.Lock()
+	m.cache[name] = cacheEntry{value: value, version: resolvedVersion, fetchedAt: time.Now()}
+	m.mu.Unlock()
+	return value, nil
+}
+
+func (m *CachedSecretManager) access(ctx context.Context, base, version string) (value, resolvedVersion string, err error) {
+	if version == "" {
+		version = "latest"
+	}
+	fullName := fmt.Sprintf("projects/%s/secrets/%s/versions/%s", m.projectID, base, version)
+	result, err := m.client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{Name: fullName})
+	if err != nil {
+		return "", "", err
+	}
+	return string(result.Payload.Data), version, nil
+}
+
+// Watch implements Watchable.
+func (m *CachedSecretManager) Watch() <-chan Change {
+	return m.changes
+}
+
+// WatchRotation starts a background poll, every interval (defaulting to
+// defaultPollInterval), of every unpinned name currently cached against
+// versions/latest. Pinned names (an explicit "@version") are skipped,
+// since "latest" doesn't apply to them - a rollback pin is expected to
+// be changed by redeploying with a different pin, not rotated in place.
+// Stops when ctx is done or Close is called.
+func (m *CachedSecretManager) WatchRotation(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-m.done:
+				return
+			case <-ticker.C:
+				m.pollOnce(ctx)
+			}
+		}
+	}()
+}
+
+func (m *CachedSecretManager) pollOnce(ctx context.Context) {
+	m.mu.RLock()
+	names := make([]string, 0, len(m.cache))
+	for name := range m.cache {
+		if _, version := splitPinnedVersion(name); version == "" {
+			names = append(names, name)
+		}
+	}
+	m.mu.RUnlock()
+
+	for _, name := range names {
+		value, version, err := m.access(ctx, name, "")
+		if err != nil {
+			m.logger.Warn("Failed to poll secret for rotation", zap.String("secret", name), zap.Error(err))
+			continue
+		}
+
+		m.mu.Lock()
+		entry := m.cache[name]
+		changed := entry.value != value
+		if changed {
+			m.cache[name] = cacheEntry{value: value, version: version, fetchedAt: time.Now()}
+		}
+		m.mu.Unlock()
+
+		if !changed {
+			continue
+		}
+
+		select {
+		case m.changes <- Change{Name: name, Value: value, Version: version}:
+		default:
+			m.logger.Warn("Secret rotation channel full, dropping change notification", zap.String("secret", name))
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Package secrets provides pluggable secret resolution for config.Load
+// (GCP Secret Manager, environment variables, or plain files).
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Provider resolves a named secret to its current value. name may carry
+// an explicit pinned version as "name@version"; providers with no
+// concept of versioning ignore the suffix and resolve the bare name.
+type Provider interface {
+	Get(ctx context.Context, name string) (string, error)
+}
+
+// Watchable is implemented by providers that can report a secret's value
+// changing after it was first loaded, instead of making the caller poll.
+// Only CachedSecretManager implements it today.
+type Watchable interface {
+	Watch() <-chan Change
+}
+
+// Change describes a secret whose value moved since it was last loaded.
+type Change struct {
+	Name    string // the bare secret name, without an @version suffix
+	Value   string
+	Version string
+}
+
+// LoadAll fetches every name from provider concurrently and returns a
+// map of name to value.
+func LoadAll(ctx context.Context, provider Provider, names []string) (map[string]string, error) {
+	type result struct {
+		name  string
+		value string
+		err   error
+	}
+
+	results := make(chan result, len(names))
+	for _, name := range names {
+		name := name
+		go func() {
+			value, err := provider.Get(ctx, name)
+			results <- result{name: name, value: value, err: err}
+		}()
+	}
+
+	values := make(map[string]string, len(names))
+	var firstErr error
+	for range names {
+		r := <-results
+		if r.err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to access secret %s: %w", r.name, r.err)
+			}
+			continue
+		}
+		values[r.name] = r.value
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return values, nil
+}
+
+// splitPinnedVersion splits "name@version" into its parts. version is
+// "" when name carries no explicit pin.
+func splitPinnedVersion(name string) (base, version string) {
+	if idx := strings.LastIndex(name, "@"); idx != -1 {
+		return name[:idx], name[idx+1:]
+	}
+	return name, ""
+}
@@ -0,0 +1,71 @@
+package telemetry
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+)
+
+// installIdentity is the stable, non-PII identity this install reports
+// under, plus the salt hashID mixes into every tenant/creator ID before
+// it leaves the process. Both are generated once and persisted to disk
+// so restarts don't fragment one install's history into many, and so
+// the salt can't be recovered from the reported data alone.
+type installIdentity struct {
+	InstallID string `json:"install_id"`
+	Salt      string `json:"salt"`
+}
+
+// loadOrCreateInstallIdentity reads stateDir/install.json, creating it
+// with a freshly generated UUID and 32-byte random salt if absent.
+func loadOrCreateInstallIdentity(stateDir string) (installIdentity, error) {
+	path := filepath.Join(stateDir, "install.json")
+
+	if data, err := os.ReadFile(path); err == nil {
+		var id installIdentity
+		if err := json.Unmarshal(data, &id); err == nil && id.InstallID != "" && id.Salt != "" {
+			return id, nil
+		}
+	}
+
+	saltBytes := make([]byte, 32)
+	if _, err := rand.Read(saltBytes); err != nil {
+		return installIdentity{}, fmt.Errorf("failed to generate telemetry salt: %w", err)
+	}
+
+	id := installIdentity{
+		InstallID: uuid.NewString(),
+		Salt:      hex.EncodeToString(saltBytes),
+	}
+
+	if err := os.MkdirAll(stateDir, 0o700); err != nil {
+		return installIdentity{}, fmt.Errorf("failed to create telemetry state dir: %w", err)
+	}
+	data, err := json.Marshal(id)
+	if err != nil {
+		return installIdentity{}, fmt.Errorf("failed to marshal telemetry install identity: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return installIdentity{}, fmt.Errorf("failed to persist telemetry install identity: %w", err)
+	}
+
+	return id, nil
+}
+
+// hashID derives a stable, irreversible per-install pseudonym for id
+// (a tenant ID or creator UUID) by HMAC-SHA256'ing it with salt. The
+// same id always hashes to the same pseudonym within one install, so
+// aggregate counts stay consistent across reporting periods, but two
+// installs never produce a comparable value for the same id.
+func hashID(salt, id string) string {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(id))
+	return hex.EncodeToString(mac.Sum(nil))
+}
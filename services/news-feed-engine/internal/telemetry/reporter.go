@@ -0,0 +1,308 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Options configures an HTTPReporter.
+type Options struct {
+	// Endpoint is the HTTPS URL batches are POSTed to.
+	Endpoint string
+	// Interval is how often buffered events are flushed. Defaults to
+	// 1 hour; the actual delay between flushes is jittered by up to
+	// 10% so many installs started at the same time don't all report
+	// on the same schedule.
+	Interval time.Duration
+	// StateDir holds the persisted install identity and any
+	// buffered-but-unsent batches. Defaults to
+	// "/var/lib/news-feed-engine/telemetry".
+	StateDir string
+	// Stats supplies the distributional part of each report. May be
+	// nil, in which case batches only carry buffered events.
+	Stats StatsProvider
+	// Client is the HTTP client used to POST batches. Defaults to a
+	// 10-second-timeout client.
+	Client *http.Client
+}
+
+// Disabled reports whether the TELEMETRY_DISABLE environment variable
+// opts this process out of telemetry entirely. Callers should check
+// this before constructing an HTTPReporter rather than constructing one
+// and never starting it, so a disabled install never even generates an
+// install identity on disk.
+func Disabled() bool {
+	v := os.Getenv("TELEMETRY_DISABLE")
+	return v == "1" || v == "true"
+}
+
+// HTTPReporter buffers anonymized creator lifecycle events and
+// periodically ships them, along with an optional CreatorStats
+// snapshot, to Options.Endpoint as a single batched JSON POST. A failed
+// send is retried with exponential backoff; a batch that still fails is
+// buffered to disk and resent when the next Start runs, so a transient
+// outage at the collection endpoint doesn't lose data.
+type HTTPReporter struct {
+	opts     Options
+	identity installIdentity
+	client   *http.Client
+
+	mu      sync.Mutex
+	pending []event
+
+	cancel  context.CancelFunc
+	stopped chan struct{}
+	lastErr error
+}
+
+// NewHTTPReporter loads or creates this install's identity under
+// opts.StateDir and returns a reporter ready to Start. It does not
+// start the background flush loop or do any network I/O itself.
+func NewHTTPReporter(opts Options) (*HTTPReporter, error) {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Hour
+	}
+	if opts.StateDir == "" {
+		opts.StateDir = "/var/lib/news-feed-engine/telemetry"
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	identity, err := loadOrCreateInstallIdentity(opts.StateDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HTTPReporter{
+		opts:     opts,
+		identity: identity,
+		client:   opts.Client,
+	}, nil
+}
+
+var _ Reporter = (*HTTPReporter)(nil)
+
+func (r *HTTPReporter) record(e event) {
+	e.At = time.Now()
+	r.mu.Lock()
+	r.pending = append(r.pending, e)
+	r.mu.Unlock()
+}
+
+func (r *HTTPReporter) CreatorCreated(tenantID string, creatorID uuid.UUID, platform string) {
+	r.record(event{Type: "created", TenantHash: hashID(r.identity.Salt, tenantID), CreatorHash: hashID(r.identity.Salt, creatorID.String()), Platform: platform})
+}
+
+func (r *HTTPReporter) CreatorUpdated(tenantID string, creatorID uuid.UUID) {
+	r.record(event{Type: "updated", TenantHash: hashID(r.identity.Salt, tenantID), CreatorHash: hashID(r.identity.Salt, creatorID.String())})
+}
+
+func (r *HTTPReporter) CreatorDeleted(tenantID string, creatorID uuid.UUID) {
+	r.record(event{Type: "deleted", TenantHash: hashID(r.identity.Salt, tenantID), CreatorHash: hashID(r.identity.Salt, creatorID.String())})
+}
+
+func (r *HTTPReporter) CreatorVerified(tenantID string, creatorID uuid.UUID, tier string) {
+	r.record(event{Type: "verified", TenantHash: hashID(r.identity.Salt, tenantID), CreatorHash: hashID(r.identity.Salt, creatorID.String()), Tier: tier})
+}
+
+// Start begins the periodic flush loop in a background goroutine. It is
+// not safe to call twice without an intervening Stop.
+func (r *HTTPReporter) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	r.mu.Lock()
+	r.cancel = cancel
+	r.stopped = make(chan struct{})
+	r.mu.Unlock()
+
+	go func() {
+		defer close(r.stopped)
+
+		// Flush any batch a previous process failed to deliver before
+		// this run's events start accumulating on top of it.
+		r.flushBuffered(ctx)
+
+		for {
+			wait := jitter(r.opts.Interval, 0.10)
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				r.flushOnce(ctx)
+			}
+		}
+	}()
+}
+
+// Stop cancels the flush loop and waits for it to exit.
+func (r *HTTPReporter) Stop() {
+	r.mu.Lock()
+	cancel := r.cancel
+	stopped := r.stopped
+	r.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	if stopped != nil {
+		<-stopped
+	}
+}
+
+// LastError returns the error from the most recent failed send, if any.
+func (r *HTTPReporter) LastError() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lastErr
+}
+
+// flushOnce drains the pending events, attaches a stats snapshot if
+// configured, and sends the resulting batch with retries. A batch that
+// still fails after retries is buffered to disk for the next Start.
+func (r *HTTPReporter) flushOnce(ctx context.Context) {
+	r.mu.Lock()
+	events := r.pending
+	r.pending = nil
+	r.mu.Unlock()
+
+	if len(events) == 0 {
+		return
+	}
+
+	b := batch{InstallID: r.identity.InstallID, ReportedAt: time.Now(), Events: events}
+	if r.opts.Stats != nil {
+		if stats, err := r.opts.Stats(ctx); err == nil {
+			b.Stats = &stats
+		}
+	}
+
+	if err := r.sendWithRetry(ctx, b); err != nil {
+		r.mu.Lock()
+		r.lastErr = err
+		r.mu.Unlock()
+		r.bufferToDisk(b)
+	}
+}
+
+// flushBuffered sends every batch left on disk by a prior process
+// before this run starts accumulating new events, so an outage doesn't
+// silently grow an ever-larger backlog of files.
+func (r *HTTPReporter) flushBuffered(ctx context.Context) {
+	dir := r.bufferDir()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var b batch
+		if err := json.Unmarshal(data, &b); err != nil {
+			os.Remove(path)
+			continue
+		}
+		if err := r.sendWithRetry(ctx, b); err != nil {
+			r.mu.Lock()
+			r.lastErr = err
+			r.mu.Unlock()
+			continue
+		}
+		os.Remove(path)
+	}
+}
+
+// sendWithRetry POSTs body, retrying transient failures (network errors
+// and 5xx/429 responses) with exponential backoff and jitter, so many
+// installs that fail at once don't retry in lockstep.
+func (r *HTTPReporter) sendWithRetry(ctx context.Context, b batch) error {
+	const maxAttempts = 4
+	const baseDelay = 2 * time.Second
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		return fmt.Errorf("failed to marshal telemetry batch: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			delay := jitter(baseDelay*time.Duration(1<<uint(attempt-1)), 0.5)
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.opts.Endpoint, bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("failed to build telemetry request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := r.client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			lastErr = fmt.Errorf("telemetry endpoint returned status %d", resp.StatusCode)
+			continue
+		}
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("telemetry endpoint returned status %d", resp.StatusCode)
+		}
+		return nil
+	}
+	return lastErr
+}
+
+func (r *HTTPReporter) bufferDir() string {
+	return filepath.Join(r.opts.StateDir, "pending")
+}
+
+// bufferToDisk persists b so a later Start call can retry delivery.
+// Failures here are deliberately swallowed: disk buffering is itself a
+// best-effort fallback, and there is nowhere further to report the
+// error to.
+func (r *HTTPReporter) bufferToDisk(b batch) {
+	dir := r.bufferDir()
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return
+	}
+	data, err := json.Marshal(b)
+	if err != nil {
+		return
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%d.json", b.ReportedAt.UnixNano()))
+	_ = os.WriteFile(path, data, 0o600)
+}
+
+// jitter returns d scaled by a random factor in [1-frac, 1+frac].
+func jitter(d time.Duration, frac float64) time.Duration {
+	delta := (rand.Float64()*2 - 1) * frac
+	return time.Duration(float64(d) * (1 + delta))
+}
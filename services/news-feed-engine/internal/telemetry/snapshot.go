@@ -0,0 +1,46 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+)
+
+// event is one hashed, anonymized creator lifecycle record buffered by
+// HTTPReporter between flushes. TenantHash/CreatorHash are HMAC
+// digests (see hashID) - the raw IDs never leave CreatorRepository's
+// hook call.
+type event struct {
+	Type        string    `json:"type"` // "created", "updated", "deleted", "verified"
+	TenantHash  string    `json:"tenant_hash"`
+	CreatorHash string    `json:"creator_hash"`
+	Platform    string    `json:"platform,omitempty"`
+	Tier        string    `json:"tier,omitempty"`
+	At          time.Time `json:"at"`
+}
+
+// CreatorStats is the distributional half of a snapshot - the part that
+// can't be derived from buffered events alone, since it needs the full
+// current creator population. StatsProvider supplies it.
+type CreatorStats struct {
+	TotalCreators     int64            `json:"total_creators"`
+	ByPlatform        map[string]int64 `json:"by_platform"`
+	ByTier            map[string]int64 `json:"by_tier"`
+	VerifiedRatio     float64          `json:"verified_ratio"`
+	EngagementBuckets map[string]int64 `json:"engagement_buckets"` // bucket label (e.g. "0.0-0.1") -> count
+}
+
+// StatsProvider computes a CreatorStats snapshot across every tenant at
+// report time. HTTPReporter calls it once per interval from its
+// background goroutine, never from a CreatorRepository hook, so it's
+// fine for an implementation to run a real query.
+type StatsProvider func(ctx context.Context) (CreatorStats, error)
+
+// batch is the JSON body shipped to the telemetry endpoint and the
+// format buffered-to-disk batches are persisted in, so a resumed batch
+// round-trips through disk with no conversion step.
+type batch struct {
+	InstallID  string        `json:"install_id"`
+	ReportedAt time.Time     `json:"reported_at"`
+	Events     []event       `json:"events"`
+	Stats      *CreatorStats `json:"creator_stats,omitempty"`
+}
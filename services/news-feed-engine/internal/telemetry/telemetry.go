@@ -0,0 +1,34 @@
+// Package telemetry reports anonymized, aggregate usage metrics about the
+// creator/content subsystem (counts per platform, tier distribution,
+// verified ratio, engagement-rate histograms, create/update rates) to an
+// operator-configured collection endpoint. It never sees raw tenant or
+// creator identifiers - see Reporter and hashID - and is opt-in: the
+// zero value callers get from anywhere that doesn't explicitly construct
+// an HTTPReporter is NoopReporter, which does nothing.
+package telemetry
+
+import "github.com/google/uuid"
+
+// Reporter receives creator lifecycle events. Implementations must not
+// block the caller for any meaningful amount of time, since these hooks
+// run inline in CreatorRepository's request path; HTTPReporter only ever
+// appends the event to an in-memory buffer here and does its actual
+// network I/O from a background goroutine.
+type Reporter interface {
+	CreatorCreated(tenantID string, creatorID uuid.UUID, platform string)
+	CreatorUpdated(tenantID string, creatorID uuid.UUID)
+	CreatorDeleted(tenantID string, creatorID uuid.UUID)
+	CreatorVerified(tenantID string, creatorID uuid.UUID, tier string)
+}
+
+// NoopReporter discards every event. It is the default Reporter for
+// CreatorRepository so telemetry costs nothing for deployments that
+// never opt in.
+type NoopReporter struct{}
+
+func (NoopReporter) CreatorCreated(tenantID string, creatorID uuid.UUID, platform string) {}
+func (NoopReporter) CreatorUpdated(tenantID string, creatorID uuid.UUID)                  {}
+func (NoopReporter) CreatorDeleted(tenantID string, creatorID uuid.UUID)                  {}
+func (NoopReporter) CreatorVerified(tenantID string, creatorID uuid.UUID, tier string)    {}
+
+var _ Reporter = NoopReporter{}
@@ -0,0 +1,277 @@
+// Package tenantbundle exports a tenant's configuration, content
+// sources, and video templates as one portable document, and imports
+// one back into a (possibly different) tenant - turning tenant cloning,
+// staging-to-prod promotion, and disaster recovery into a single call
+// instead of walking each of ConfigRepository/SourceRepository/
+// TemplateRepository by hand. It's layered on those three the same way
+// preferences.Service layers on PreferencesRepository/ConfigRepository.
+//
+// A bundle is JSON only: this service has no YAML library vendored
+// anywhere (see internal/ingestion/scheduler.go for the equivalent
+// no-cron-parser situation), so adding YAML support would mean
+// introducing a new dependency rather than following an existing one.
+package tenantbundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+// Bundle is the portable shape Export produces and Import consumes.
+// Sources/Templates carry their full row (including ID) as exported,
+// but Import never trusts or requires the ID: rows are matched against
+// the target tenant by natural key (identifier for sources, name for
+// templates) via SourceRepository/TemplateRepository.BulkUpsert, so a
+// bundle exported from one tenant imports cleanly into another.
+type Bundle struct {
+	Config    *BundleConfig          `json:"config,omitempty"`
+	Sources   []models.ContentSource `json:"sources,omitempty"`
+	Templates []models.VideoTemplate `json:"templates,omitempty"`
+}
+
+// BundleConfig is models.TenantConfig with the tenant-specific identity
+// fields (ID, TenantID, CreatedAt, UpdatedAt) stripped, so it can be
+// replayed against any tenant via ConfigRepository.Update.
+type BundleConfig struct {
+	DisplayName       string       `json:"display_name"`
+	LogoURL           string       `json:"logo_url,omitempty"`
+	FaviconURL        string       `json:"favicon_url,omitempty"`
+	PrimaryColor      string       `json:"primary_color"`
+	SecondaryColor    string       `json:"secondary_color"`
+	AccentColor       string       `json:"accent_color"`
+	FontFamily        string       `json:"font_family"`
+	CustomCSS         string       `json:"custom_css,omitempty"`
+	CustomDomain      string       `json:"custom_domain,omitempty"`
+	EnabledPlatforms  []string     `json:"enabled_platforms"`
+	EnabledCategories []string     `json:"enabled_categories"`
+	DefaultVoiceID    string       `json:"default_voice_id"`
+	VideoWatermark    string       `json:"video_watermark,omitempty"`
+	AnalyticsID       string       `json:"analytics_id,omitempty"`
+	Settings          models.JSONB `json:"settings,omitempty"`
+	Active            bool         `json:"active"`
+}
+
+// Service exports/imports Bundles for a tenant.
+type Service struct {
+	configs   *database.ConfigRepository
+	sources   *database.SourceRepository
+	templates *database.TemplateRepository
+}
+
+// NewService creates a Service backed by configs, sources, and templates.
+func NewService(configs *database.ConfigRepository, sources *database.SourceRepository, templates *database.TemplateRepository) *Service {
+	return &Service{configs: configs, sources: sources, templates: templates}
+}
+
+// Export returns tenantID's config, sources, and templates as one JSON
+// document. A tenant with no saved config yet simply omits Config -
+// Sources/Templates are independent of whether tenant_configs has a row.
+func (s *Service) Export(ctx context.Context, tenantID string) ([]byte, error) {
+	bundle := Bundle{}
+
+	if cfg, err := s.configs.Get(ctx, tenantID); err == nil {
+		bundle.Config = &BundleConfig{
+			DisplayName: cfg.DisplayName, LogoURL: cfg.LogoURL, FaviconURL: cfg.FaviconURL,
+			PrimaryColor: cfg.PrimaryColor, SecondaryColor: cfg.SecondaryColor, AccentColor: cfg.AccentColor,
+			FontFamily: cfg.FontFamily, CustomCSS: cfg.CustomCSS, CustomDomain: cfg.CustomDomain,
+			EnabledPlatforms: cfg.EnabledPlatforms, EnabledCategories: cfg.EnabledCategories,
+			DefaultVoiceID: cfg.DefaultVoiceID, VideoWatermark: cfg.VideoWatermark,
+			AnalyticsID: cfg.AnalyticsID, Settings: cfg.Settings, Active: cfg.Active,
+		}
+	}
+
+	sources, err := s.sources.List(ctx, tenantID, database.SourceListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sources: %w", err)
+	}
+	bundle.Sources = sources
+
+	templates, err := s.templates.List(ctx, tenantID, database.TemplateListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	bundle.Templates = templates
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode bundle: %w", err)
+	}
+	return data, nil
+}
+
+// RowResult reports what Import did with one source or template row,
+// identified by its natural key (see Bundle). Status is one of
+// database.BulkUpsertStatus's values, plus "invalid" for a row Validate
+// rejected before it ever reached the database.
+type RowResult struct {
+	Kind       string `json:"kind"`
+	Identifier string `json:"identifier"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ImportResult is everything Import did (or, for a dry run, would do).
+type ImportResult struct {
+	ConfigUpdated bool        `json:"config_updated"`
+	Sources       []RowResult `json:"sources"`
+	Templates     []RowResult `json:"templates"`
+}
+
+// Import applies bundle to tenantID. With dryRun, every row is validated
+// and matched against existing rows the same way a real import would be,
+// but nothing is written - ImportResult reports what would have happened.
+func (s *Service) Import(ctx context.Context, tenantID string, bundle Bundle, actorID string, dryRun bool) (*ImportResult, error) {
+	result := &ImportResult{}
+
+	for _, issue := range validateBundle(bundle) {
+		kind, identifier := issue.kind, issue.identifier
+		switch kind {
+		case "source":
+			result.Sources = append(result.Sources, RowResult{Kind: kind, Identifier: identifier, Status: "invalid", Error: issue.reason})
+		case "template":
+			result.Templates = append(result.Templates, RowResult{Kind: kind, Identifier: identifier, Status: "invalid", Error: issue.reason})
+		}
+	}
+	invalidSources := make(map[string]bool, len(result.Sources))
+	for _, r := range result.Sources {
+		invalidSources[r.Identifier] = true
+	}
+	invalidTemplates := make(map[string]bool, len(result.Templates))
+	for _, r := range result.Templates {
+		invalidTemplates[r.Identifier] = true
+	}
+
+	validSources := make([]models.ContentSource, 0, len(bundle.Sources))
+	for _, src := range bundle.Sources {
+		if !invalidSources[src.Identifier] {
+			validSources = append(validSources, src)
+		}
+	}
+	validTemplates := make([]models.VideoTemplate, 0, len(bundle.Templates))
+	for _, tmpl := range bundle.Templates {
+		if !invalidTemplates[tmpl.Name] {
+			validTemplates = append(validTemplates, tmpl)
+		}
+	}
+
+	if bundle.Config != nil {
+		if !dryRun {
+			updates, err := bundleConfigUpdates(bundle.Config)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode config: %w", err)
+			}
+			if _, err := s.configs.Update(ctx, tenantID, updates, actorID); err != nil {
+				return nil, fmt.Errorf("failed to import config: %w", err)
+			}
+		}
+		result.ConfigUpdated = true
+	}
+
+	sourceResults, err := s.sources.BulkUpsert(ctx, tenantID, validSources, actorID, dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import sources: %w", err)
+	}
+	for _, r := range sourceResults {
+		result.Sources = append(result.Sources, RowResult{Kind: "source", Identifier: r.Identifier, Status: string(r.Status), Error: r.Error})
+	}
+
+	templateResults, err := s.templates.BulkUpsert(ctx, tenantID, validTemplates, actorID, dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import templates: %w", err)
+	}
+	for _, r := range templateResults {
+		result.Templates = append(result.Templates, RowResult{Kind: "template", Identifier: r.Identifier, Status: string(r.Status), Error: r.Error})
+	}
+
+	return result, nil
+}
+
+// bundleConfigUpdates round-trips cfg through JSON to get the same
+// map[string]interface{} shape (string/float64/bool/[]interface{}/
+// map[string]interface{}, per encoding/json's default decode) that
+// ConfigRepository.Update's tenantConfigUpdateParams expects from a
+// ShouldBindJSON'd request body - cfg's fields already share their json
+// tags with the tenant_configs columns those updates target.
+func bundleConfigUpdates(cfg *BundleConfig) (map[string]interface{}, error) {
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var updates map[string]interface{}
+	if err := json.Unmarshal(b, &updates); err != nil {
+		return nil, err
+	}
+	return updates, nil
+}
+
+// validationIssue is one row Validate rejected before Import ever
+// touched the database.
+type validationIssue struct {
+	kind       string // "source" | "template"
+	identifier string
+	reason     string
+}
+
+// validateBundle checks cron expressions, the required voice_id, and
+// in-bundle identifier/name uniqueness - everything Import can check
+// without a round trip to the database. It cannot validate that a
+// voice_id/avatar_id refers to a real provider voice or avatar: this
+// service has no voice/avatar catalog of its own (VoiceID and AvatarID
+// are opaque IDs passed through to whatever provider video.Renderer is
+// configured against), so only voice_id's presence is checked; avatar_id
+// is not checked at all.
+func validateBundle(bundle Bundle) []validationIssue {
+	var issues []validationIssue
+
+	seenIdentifiers := make(map[string]bool, len(bundle.Sources))
+	for _, src := range bundle.Sources {
+		if src.Identifier == "" || src.Name == "" || src.Platform == "" {
+			issues = append(issues, validationIssue{"source", src.Identifier, "name, platform, and identifier are required"})
+			continue
+		}
+		if seenIdentifiers[src.Identifier] {
+			issues = append(issues, validationIssue{"source", src.Identifier, "duplicate identifier within bundle"})
+			continue
+		}
+		seenIdentifiers[src.Identifier] = true
+
+		if src.IngestionCron != "" && !isWellFormedCron(src.IngestionCron) {
+			issues = append(issues, validationIssue{"source", src.Identifier, "ingestion_cron must have 5 space-separated fields"})
+		}
+	}
+
+	seenNames := make(map[string]bool, len(bundle.Templates))
+	for _, tmpl := range bundle.Templates {
+		if tmpl.Name == "" {
+			issues = append(issues, validationIssue{"template", tmpl.Name, "name is required"})
+			continue
+		}
+		if seenNames[tmpl.Name] {
+			issues = append(issues, validationIssue{"template", tmpl.Name, "duplicate name within bundle"})
+			continue
+		}
+		seenNames[tmpl.Name] = true
+
+		if tmpl.VoiceID == "" {
+			issues = append(issues, validationIssue{"template", tmpl.Name, "voice_id is required"})
+		}
+	}
+
+	return issues
+}
+
+// isWellFormedCron checks that expr has the 5 whitespace-separated
+// fields a cron expression requires (minute hour day-of-month month
+// day-of-week). It's a structural check only, not a real parser: this
+// service has no cron library vendored (see
+// internal/ingestion/scheduler.go's ParseAdaptiveScheduleConfig doc
+// comment), so field values (e.g. "13" for day-of-week) aren't checked
+// against their valid range.
+func isWellFormedCron(expr string) bool {
+	return len(strings.Fields(expr)) == 5
+}
@@ -0,0 +1,41 @@
+// Package transcript models a video's spoken-word transcript as a
+// sequence of timed cues and renders it into the subtitle/caption
+// formats VideoHandler.GetVideoTranscript exposes (SRT, WebVTT, JSON,
+// and plain text).
+package transcript
+
+import "time"
+
+// Word is one word's timing within a Cue, present when the generation
+// pipeline produced word-level alignment.
+type Word struct {
+	Start time.Duration `json:"start"`
+	End   time.Duration `json:"end"`
+	Text  string        `json:"text"`
+}
+
+// Cue is one subtitle/caption block: a speaker turn (or fragment of
+// one) spanning [Start, End) with optional per-word timing.
+type Cue struct {
+	Start   time.Duration `json:"start"`
+	End     time.Duration `json:"end"`
+	Speaker string        `json:"speaker,omitempty"`
+	Text    string        `json:"text"`
+	Words   []Word        `json:"words,omitempty"`
+}
+
+// Transcript is an ordered sequence of cues.
+type Transcript []Cue
+
+// MergeWords returns a copy of t with every cue's per-word timing
+// collapsed away, leaving only cue-level start/end/text. Used for
+// ?merge_words=true, where callers want caption-level timing without
+// the finer word-level detail.
+func (t Transcript) MergeWords() Transcript {
+	merged := make(Transcript, len(t))
+	for i, c := range t {
+		c.Words = nil
+		merged[i] = c
+	}
+	return merged
+}
@@ -0,0 +1,87 @@
+package transcript
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RenderSRT renders t as SubRip: sequentially numbered blocks with
+// HH:MM:SS,mmm --> HH:MM:SS,mmm timestamps separated by a blank line.
+func RenderSRT(t Transcript) string {
+	var b strings.Builder
+	for i, c := range t {
+		fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n\n", i+1, srtTimestamp(c.Start), srtTimestamp(c.End), cueText(c))
+	}
+	return b.String()
+}
+
+// RenderVTT renders t as WebVTT: a WEBVTT header, a numbered cue
+// identifier, HH:MM:SS.mmm --> HH:MM:SS.mmm timestamps, a NOTE block
+// whenever the speaker changes from the previous cue, and an inline
+// <v Speaker> voice tag around the cue text.
+func RenderVTT(t Transcript) string {
+	var b strings.Builder
+	b.WriteString("WEBVTT\n\n")
+	lastSpeaker := ""
+	for i, c := range t {
+		if c.Speaker != "" && c.Speaker != lastSpeaker {
+			fmt.Fprintf(&b, "NOTE speaker change: %s\n\n", c.Speaker)
+			lastSpeaker = c.Speaker
+		}
+		fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n\n", i+1, vttTimestamp(c.Start), vttTimestamp(c.End), vttCueText(c))
+	}
+	return b.String()
+}
+
+// RenderJSON renders t as the full cue model.
+func RenderJSON(t Transcript) ([]byte, error) {
+	return json.MarshalIndent(t, "", "  ")
+}
+
+// RenderText concatenates cue text with speaker prefixes, one cue per
+// line.
+func RenderText(t Transcript) string {
+	lines := make([]string, len(t))
+	for i, c := range t {
+		lines[i] = cueText(c)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func cueText(c Cue) string {
+	if c.Speaker == "" {
+		return c.Text
+	}
+	return fmt.Sprintf("%s: %s", c.Speaker, c.Text)
+}
+
+func vttCueText(c Cue) string {
+	if c.Speaker == "" {
+		return c.Text
+	}
+	return fmt.Sprintf("<v %s>%s</v>", c.Speaker, c.Text)
+}
+
+func srtTimestamp(d time.Duration) string {
+	return formatTimestamp(d, ",")
+}
+
+func vttTimestamp(d time.Duration) string {
+	return formatTimestamp(d, ".")
+}
+
+func formatTimestamp(d time.Duration, msSep string) string {
+	if d < 0 {
+		d = 0
+	}
+	h := d / time.Hour
+	d -= h * time.Hour
+	m := d / time.Minute
+	d -= m * time.Minute
+	s := d / time.Second
+	d -= s * time.Second
+	ms := d / time.Millisecond
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", h, m, s, msSep, ms)
+}
@@ -0,0 +1,112 @@
+package tts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// azureDefaultFormat is a 16kHz mono WAV, a safe input format for
+// ffmpeg's concat demuxer without further transcoding.
+const azureDefaultFormat = "riff-16khz-16bit-mono-pcm"
+
+// AzureEngine synthesizes speech via Azure Cognitive Services' Speech
+// REST API, posting SSML and writing the returned audio straight to
+// disk.
+type AzureEngine struct {
+	apiKey      string
+	region      string
+	format      string
+	httpClient  *http.Client
+	ffprobePath string
+	tmpDir      string
+}
+
+// NewAzureEngine creates an AzureEngine for the given Speech resource
+// key and region (e.g. "eastus"). format defaults to azureDefaultFormat
+// when empty.
+func NewAzureEngine(apiKey, region, format string) *AzureEngine {
+	if format == "" {
+		format = azureDefaultFormat
+	}
+	return &AzureEngine{
+		apiKey: apiKey,
+		region: region,
+		format: format,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		tmpDir: os.TempDir(),
+	}
+}
+
+func init() {
+	Register("azure", func(u *url.URL) (Engine, error) {
+		apiKey := u.Query().Get("key")
+		if apiKey == "" {
+			return nil, fmt.Errorf("tts: azure:// dsn requires key")
+		}
+		region := u.Query().Get("region")
+		if region == "" {
+			return nil, fmt.Errorf("tts: azure:// dsn requires region")
+		}
+		return NewAzureEngine(apiKey, region, u.Query().Get("format")), nil
+	})
+}
+
+// Synthesize posts an SSML document naming voiceID to Azure's
+// text-to-speech endpoint and writes the resulting audio to a temp
+// file, probing its duration with ffprobe before returning.
+func (e *AzureEngine) Synthesize(ctx context.Context, text, voiceID string) (string, time.Duration, error) {
+	if text == "" {
+		return "", 0, fmt.Errorf("tts: text cannot be empty")
+	}
+
+	ssml := fmt.Sprintf(
+		`<speak version="1.0" xml:lang="en-US"><voice name=%q>%s</voice></speak>`,
+		voiceID, escapeSSML(text),
+	)
+
+	endpoint := fmt.Sprintf("https://%s.tts.speech.microsoft.com/cognitiveservices/v1", e.region)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(ssml))
+	if err != nil {
+		return "", 0, fmt.Errorf("tts: failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/ssml+xml")
+	req.Header.Set("X-Microsoft-OutputFormat", e.format)
+	req.Header.Set("Ocp-Apim-Subscription-Key", e.apiKey)
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("tts: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("tts: azure request failed with status %d", resp.StatusCode)
+	}
+
+	out, err := os.CreateTemp(e.tmpDir, "tts-azure-*.wav")
+	if err != nil {
+		return "", 0, fmt.Errorf("tts: failed to create output file: %w", err)
+	}
+	defer out.Close()
+
+	if _, err := out.ReadFrom(resp.Body); err != nil {
+		os.Remove(out.Name())
+		return "", 0, fmt.Errorf("tts: failed to write audio: %w", err)
+	}
+
+	duration, err := probeDuration(ctx, e.ffprobePath, out.Name())
+	if err != nil {
+		os.Remove(out.Name())
+		return "", 0, fmt.Errorf("tts: failed to probe synthesized audio: %w", err)
+	}
+
+	return out.Name(), duration, nil
+}
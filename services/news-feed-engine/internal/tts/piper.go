@@ -0,0 +1,98 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// PiperEngine shells out to the piper CLI for fully offline,
+// self-hosted synthesis, an alternative to AzureEngine for deployments
+// that can't send script text to a third-party API.
+type PiperEngine struct {
+	binaryPath string
+	modelPath  string
+	tmpDir     string
+
+	// run executes piper with args and returns an error (carrying piper's
+	// stderr) if it fails, swapped
+	// out in tests so they don't need the real binary or voice model
+	// installed, mirroring integrations.YTDLPFetcher.run.
+	run func(ctx context.Context, args []string) error
+}
+
+// NewPiperEngine creates a PiperEngine that invokes binaryPath (typically
+// just "piper", resolved via PATH) against the ONNX voice model at
+// modelPath.
+func NewPiperEngine(binaryPath, modelPath string) *PiperEngine {
+	if binaryPath == "" {
+		binaryPath = "piper"
+	}
+	e := &PiperEngine{
+		binaryPath: binaryPath,
+		modelPath:  modelPath,
+		tmpDir:     os.TempDir(),
+	}
+	e.run = e.execCommand
+	return e
+}
+
+func init() {
+	Register("piper", func(u *url.URL) (Engine, error) {
+		if u.Path == "" {
+			return nil, fmt.Errorf("tts: piper:// dsn requires a model path")
+		}
+		return NewPiperEngine(u.Query().Get("bin"), u.Path), nil
+	})
+}
+
+// Synthesize pipes text into piper on stdin and writes the WAV it
+// produces to a temp file. voiceID is unused: piper's voice is fixed by
+// the model loaded at construction, so callers wanting a different voice
+// should Open a second piper:// DSN pointed at a different model.
+func (e *PiperEngine) Synthesize(ctx context.Context, text, voiceID string) (string, time.Duration, error) {
+	if text == "" {
+		return "", 0, fmt.Errorf("tts: text cannot be empty")
+	}
+
+	out, err := os.CreateTemp(e.tmpDir, "tts-piper-*.wav")
+	if err != nil {
+		return "", 0, fmt.Errorf("tts: failed to create output file: %w", err)
+	}
+	out.Close()
+
+	if err := e.run(ctx, []string{text, out.Name()}); err != nil {
+		os.Remove(out.Name())
+		return "", 0, err
+	}
+
+	duration, err := probeDuration(ctx, "", out.Name())
+	if err != nil {
+		os.Remove(out.Name())
+		return "", 0, fmt.Errorf("tts: failed to probe synthesized audio: %w", err)
+	}
+
+	return out.Name(), duration, nil
+}
+
+// execCommand is PiperEngine's default run implementation: args[0] is
+// the text to speak (piped via stdin), args[1] is the output WAV path.
+func (e *PiperEngine) execCommand(ctx context.Context, args []string) error {
+	text, outputPath := args[0], args[1]
+
+	cmd := exec.CommandContext(ctx, e.binaryPath, "--model", e.modelPath, "--output_file", outputPath)
+	cmd.Stdin = strings.NewReader(text)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("tts: piper failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
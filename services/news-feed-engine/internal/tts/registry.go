@@ -0,0 +1,49 @@
+package tts
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+)
+
+// Factory constructs an Engine from a DSN's parsed form. The scheme
+// ("azure", "piper", ...) selects the factory; Open passes the rest of
+// the DSN through unparsed so each engine can pull whatever query params
+// or path segments it needs, the same split embeddings.Factory makes.
+type Factory func(dsn *url.URL) (Engine, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+// Register adds factory under scheme for Open to dispatch to. Intended
+// to be called from each engine's init(); a duplicate registration is a
+// programming error worth panicking on rather than silently shadowing
+// one engine with another.
+func Register(scheme string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := registry[scheme]; exists {
+		panic(fmt.Sprintf("tts: Register called twice for scheme %q", scheme))
+	}
+	registry[scheme] = factory
+}
+
+// Open constructs an Engine from a URL-like DSN, e.g.
+// "azure://?key=...&region=eastus" or "piper:///path/to/voice.onnx".
+func Open(dsn string) (Engine, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("tts: invalid dsn %q: %w", dsn, err)
+	}
+
+	registryMu.RLock()
+	factory, ok := registry[u.Scheme]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("tts: no engine registered for scheme %q", u.Scheme)
+	}
+
+	return factory(u)
+}
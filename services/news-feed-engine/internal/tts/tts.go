@@ -0,0 +1,20 @@
+// Package tts synthesizes speech audio for video.LocalRenderer's
+// Script/IntroScript/OutroScript segments. Engines self-register under a
+// DSN scheme via Register, mirroring the embeddings.Register driver
+// idiom, so swapping Piper/Azure is a config change rather than a
+// code change.
+package tts
+
+import (
+	"context"
+	"time"
+)
+
+// Engine synthesizes text into an audio file on disk. Implementations
+// may shell out (Piper) or call a hosted API (Azure); either way the
+// caller owns the returned file and should remove it once done.
+type Engine interface {
+	// Synthesize renders text as voiceID and returns the path to the
+	// generated audio file and its duration.
+	Synthesize(ctx context.Context, text, voiceID string) (path string, duration time.Duration, err error)
+}
@@ -0,0 +1,52 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// escapeSSML escapes the characters SSML reserves for markup so
+// arbitrary script/summary text can be embedded as element content
+// without being parsed as (or breaking) XML.
+func escapeSSML(text string) string {
+	replacer := strings.NewReplacer(
+		"&", "&amp;",
+		"<", "&lt;",
+		">", "&gt;",
+		`"`, "&quot;",
+		"'", "&apos;",
+	)
+	return replacer.Replace(text)
+}
+
+// probeDuration shells out to ffprobe to measure path's audio duration,
+// since neither engine below otherwise knows how long the file it just
+// wrote actually plays for.
+func probeDuration(ctx context.Context, ffprobePath, path string) (time.Duration, error) {
+	if ffprobePath == "" {
+		ffprobePath = "ffprobe"
+	}
+	cmd := exec.CommandContext(ctx, ffprobePath,
+		"-v", "error",
+		"-show_entries", "format=duration",
+		"-of", "default=noprint_wrappers=1:nokey=1",
+		path,
+	)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return 0, fmt.Errorf("ffprobe failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(stdout.String()), 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse ffprobe duration: %w", err)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
@@ -0,0 +1,303 @@
+// Package validation checks admin-facing configuration payloads against
+// the JSON Schema documents this service publishes for them, so an admin
+// UI can render an edit form from the same schema a PATCH is checked
+// against.
+package validation
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// FieldError is one schema violation, identifying the offending field by
+// a JSON-pointer-ish path (e.g. "enabled_platforms[2]" or
+// "settings.items_per_page") rather than collapsing every violation in a
+// request into a single message.
+type FieldError struct {
+	Field string `json:"field"`
+	Error string `json:"error"`
+}
+
+var whitelabelPlatformEnum = map[string]bool{
+	"youtube": true, "twitter": true, "reddit": true, "rss": true, "mastodon": true,
+}
+
+var whitelabelLayoutEnum = map[string]bool{
+	"grid": true, "list": true, "magazine": true,
+}
+
+// WhitelabelConfig is the strongly-typed shape of a tenant's white-label
+// configuration, matching the tenant_configs columns
+// handlers.WhitelabelHandler.UpdateWhitelabelConfig can actually persist -
+// not the presentational-only fields GetWhitelabelConfig's unset-tenant
+// fallback also returns (social_links, footer_text, contact_email, ...),
+// which have no backing column and so were never genuinely settable.
+// UpdateWhitelabelConfig still binds requests into a
+// map[string]interface{}, since a partial update only sets a subset of
+// fields and a struct can't represent "field omitted" without every field
+// being a pointer; this type is the schema's canonical reference shape,
+// checked field-by-field by ValidateWhitelabelConfig.
+type WhitelabelConfig struct {
+	DisplayName       string             `json:"display_name"`
+	LogoURL           string             `json:"logo_url"`
+	FaviconURL        string             `json:"favicon_url"`
+	PrimaryColor      string             `json:"primary_color"`
+	SecondaryColor    string             `json:"secondary_color"`
+	AccentColor       string             `json:"accent_color"`
+	FontFamily        string             `json:"font_family"`
+	CustomCSS         string             `json:"custom_css,omitempty"`
+	CustomDomain      string             `json:"custom_domain,omitempty"`
+	EnabledPlatforms  []string           `json:"enabled_platforms"`
+	EnabledCategories []string           `json:"enabled_categories"`
+	DefaultVoiceID    string             `json:"default_voice_id"`
+	VideoWatermark    string             `json:"video_watermark,omitempty"`
+	AnalyticsID       string             `json:"analytics_id,omitempty"`
+	Active            bool               `json:"active"`
+	Settings          WhitelabelSettings `json:"settings,omitempty"`
+}
+
+// WhitelabelSettings is the shape of WhitelabelConfig.Settings.
+type WhitelabelSettings struct {
+	ShowCreatorProfiles  bool   `json:"show_creator_profiles"`
+	ShowEngagementStats  bool   `json:"show_engagement_stats"`
+	EnableComments       bool   `json:"enable_comments"`
+	EnableSharing        bool   `json:"enable_sharing"`
+	EnableBookmarks      bool   `json:"enable_bookmarks"`
+	DefaultContentLayout string `json:"default_content_layout"`
+	ItemsPerPage         int    `json:"items_per_page"`
+	VideoAutoplay        bool   `json:"video_autoplay"`
+}
+
+// WhitelabelConfigSchema is the JSON Schema document describing
+// WhitelabelConfig, served as-is by GET /whitelabel/schema.
+// ValidateWhitelabelConfig enforces the same rules by hand below rather
+// than through a generic JSON Schema evaluator - this service has exactly
+// one schema, so a full evaluator engine would be a lot of machinery for
+// one user.
+const WhitelabelConfigSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "WhitelabelConfig",
+  "type": "object",
+  "additionalProperties": false,
+  "properties": {
+    "display_name": {"type": "string", "minLength": 1, "maxLength": 120},
+    "logo_url": {"type": "string", "format": "uri"},
+    "favicon_url": {"type": "string", "format": "uri"},
+    "primary_color": {"type": "string", "pattern": "^#[0-9a-fA-F]{3}([0-9a-fA-F]{3})?$"},
+    "secondary_color": {"type": "string", "pattern": "^#[0-9a-fA-F]{3}([0-9a-fA-F]{3})?$"},
+    "accent_color": {"type": "string", "pattern": "^#[0-9a-fA-F]{3}([0-9a-fA-F]{3})?$"},
+    "font_family": {"type": "string", "minLength": 1, "maxLength": 200},
+    "custom_css": {"type": "string", "maxLength": 100000},
+    "custom_domain": {"type": "string", "maxLength": 255},
+    "enabled_platforms": {
+      "type": "array",
+      "items": {"type": "string", "enum": ["youtube", "twitter", "reddit", "rss", "mastodon"]}
+    },
+    "enabled_categories": {
+      "type": "array",
+      "items": {"type": "string", "minLength": 1}
+    },
+    "default_voice_id": {"type": "string", "minLength": 1},
+    "video_watermark": {"type": "string"},
+    "analytics_id": {"type": "string"},
+    "active": {"type": "boolean"},
+    "settings": {
+      "type": "object",
+      "properties": {
+        "show_creator_profiles": {"type": "boolean"},
+        "show_engagement_stats": {"type": "boolean"},
+        "enable_comments": {"type": "boolean"},
+        "enable_sharing": {"type": "boolean"},
+        "enable_bookmarks": {"type": "boolean"},
+        "default_content_layout": {"type": "string", "enum": ["grid", "list", "magazine"]},
+        "items_per_page": {"type": "integer", "minimum": 1, "maximum": 100},
+        "video_autoplay": {"type": "boolean"}
+      },
+      "additionalProperties": false
+    }
+  }
+}`
+
+// ValidateWhitelabelConfig checks a PATCH body for
+// handlers.WhitelabelHandler.UpdateWhitelabelConfig against
+// WhitelabelConfigSchema, returning one FieldError per violation rather
+// than stopping at the first so a form can highlight every bad field at
+// once.
+func ValidateWhitelabelConfig(fields map[string]interface{}) []FieldError {
+	var errs []FieldError
+
+	for field, value := range fields {
+		switch field {
+		case "display_name":
+			errs = append(errs, validateNonEmptyString(field, value, 120)...)
+		case "font_family":
+			errs = append(errs, validateNonEmptyString(field, value, 200)...)
+		case "logo_url", "favicon_url":
+			errs = append(errs, validateURLString(field, value)...)
+		case "custom_domain":
+			errs = append(errs, validateMaxLenString(field, value, 255)...)
+		case "custom_css":
+			errs = append(errs, validateMaxLenString(field, value, 100000)...)
+		case "default_voice_id":
+			if s, ok := value.(string); !ok {
+				errs = append(errs, FieldError{Field: field, Error: "must be a string"})
+			} else if s == "" {
+				errs = append(errs, FieldError{Field: field, Error: "must not be empty"})
+			}
+		case "video_watermark", "analytics_id":
+			errs = append(errs, validateTypeString(field, value)...)
+		case "primary_color", "secondary_color", "accent_color":
+			errs = append(errs, validateHexColor(field, value)...)
+		case "enabled_platforms":
+			errs = append(errs, validateEnumArray(field, value, whitelabelPlatformEnum)...)
+		case "enabled_categories":
+			errs = append(errs, validateStringArray(field, value)...)
+		case "active":
+			if _, ok := value.(bool); !ok {
+				errs = append(errs, FieldError{Field: field, Error: "must be a boolean"})
+			}
+		case "settings":
+			errs = append(errs, validateWhitelabelSettings(value)...)
+		default:
+			errs = append(errs, FieldError{Field: field, Error: "unknown field"})
+		}
+	}
+
+	return errs
+}
+
+func validateTypeString(field string, value interface{}) []FieldError {
+	if _, ok := value.(string); !ok {
+		return []FieldError{{Field: field, Error: "must be a string"}}
+	}
+	return nil
+}
+
+func validateNonEmptyString(field string, value interface{}, maxLen int) []FieldError {
+	s, ok := value.(string)
+	if !ok {
+		return []FieldError{{Field: field, Error: "must be a string"}}
+	}
+	if s == "" {
+		return []FieldError{{Field: field, Error: "must not be empty"}}
+	}
+	if len(s) > maxLen {
+		return []FieldError{{Field: field, Error: fmt.Sprintf("must be at most %d characters", maxLen)}}
+	}
+	return nil
+}
+
+func validateMaxLenString(field string, value interface{}, maxLen int) []FieldError {
+	s, ok := value.(string)
+	if !ok {
+		return []FieldError{{Field: field, Error: "must be a string"}}
+	}
+	if len(s) > maxLen {
+		return []FieldError{{Field: field, Error: fmt.Sprintf("must be at most %d characters", maxLen)}}
+	}
+	return nil
+}
+
+func validateURLString(field string, value interface{}) []FieldError {
+	s, ok := value.(string)
+	if !ok {
+		return []FieldError{{Field: field, Error: "must be a string"}}
+	}
+	if s == "" {
+		return nil
+	}
+	if parsed, err := url.Parse(s); err != nil || parsed.Scheme == "" && !isRelativePath(s) {
+		return []FieldError{{Field: field, Error: "must be a valid absolute or relative URL"}}
+	}
+	return nil
+}
+
+// isRelativePath allows "/assets/logo.png"-style defaults used throughout
+// this service's own fallback config (see GetWhitelabelConfig) through the
+// same validation a fully-qualified URL would pass.
+func isRelativePath(s string) bool {
+	return len(s) > 0 && s[0] == '/'
+}
+
+func validateHexColor(field string, value interface{}) []FieldError {
+	s, ok := value.(string)
+	if !ok || !isHexColor(s) {
+		return []FieldError{{Field: field, Error: "must be a hex color (#rgb or #rrggbb)"}}
+	}
+	return nil
+}
+
+func isHexColor(s string) bool {
+	if len(s) != 4 && len(s) != 7 {
+		return false
+	}
+	if s[0] != '#' {
+		return false
+	}
+	for _, c := range s[1:] {
+		if !((c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')) {
+			return false
+		}
+	}
+	return true
+}
+
+func validateEnumArray(field string, value interface{}, enum map[string]bool) []FieldError {
+	items, ok := value.([]interface{})
+	if !ok {
+		return []FieldError{{Field: field, Error: "must be an array"}}
+	}
+	var errs []FieldError
+	for i, item := range items {
+		s, ok := item.(string)
+		if !ok || !enum[s] {
+			errs = append(errs, FieldError{Field: fmt.Sprintf("%s[%d]", field, i), Error: "not in enum"})
+		}
+	}
+	return errs
+}
+
+func validateStringArray(field string, value interface{}) []FieldError {
+	items, ok := value.([]interface{})
+	if !ok {
+		return []FieldError{{Field: field, Error: "must be an array"}}
+	}
+	var errs []FieldError
+	for i, item := range items {
+		if s, ok := item.(string); !ok || s == "" {
+			errs = append(errs, FieldError{Field: fmt.Sprintf("%s[%d]", field, i), Error: "must be a non-empty string"})
+		}
+	}
+	return errs
+}
+
+func validateWhitelabelSettings(value interface{}) []FieldError {
+	settings, ok := value.(map[string]interface{})
+	if !ok {
+		return []FieldError{{Field: "settings", Error: "must be an object"}}
+	}
+
+	var errs []FieldError
+	for key, v := range settings {
+		path := "settings." + key
+		switch key {
+		case "show_creator_profiles", "show_engagement_stats", "enable_comments", "enable_sharing", "enable_bookmarks", "video_autoplay":
+			if _, ok := v.(bool); !ok {
+				errs = append(errs, FieldError{Field: path, Error: "must be a boolean"})
+			}
+		case "default_content_layout":
+			s, ok := v.(string)
+			if !ok || !whitelabelLayoutEnum[s] {
+				errs = append(errs, FieldError{Field: path, Error: "not in enum"})
+			}
+		case "items_per_page":
+			n, ok := v.(float64)
+			if !ok || n != float64(int(n)) || n < 1 || n > 100 {
+				errs = append(errs, FieldError{Field: path, Error: "must be an integer between 1 and 100"})
+			}
+		default:
+			errs = append(errs, FieldError{Field: path, Error: "unknown field"})
+		}
+	}
+	return errs
+}
@@ -0,0 +1,48 @@
+package video
+
+import "sync"
+
+// RenderCanceler tracks the in-flight context.CancelFunc for each job
+// LocalRenderer is currently rendering, keyed by VideoSummary ID, so
+// something outside the render goroutine (an admin API, Worker reacting
+// to VideoRepository.Cancel) can stop the underlying ffmpeg subprocess
+// without reaching into LocalRenderer's internals.
+type RenderCanceler struct {
+	mu      sync.Mutex
+	cancels map[string]func()
+}
+
+// NewRenderCanceler creates an empty RenderCanceler.
+func NewRenderCanceler() *RenderCanceler {
+	return &RenderCanceler{cancels: make(map[string]func())}
+}
+
+// track registers cancel under jobID and returns a release func that
+// must be called once the job finishes, so a completed job's slot
+// doesn't linger and get confused for a still-running one.
+func (rc *RenderCanceler) track(jobID string, cancel func()) (release func()) {
+	rc.mu.Lock()
+	rc.cancels[jobID] = cancel
+	rc.mu.Unlock()
+
+	return func() {
+		rc.mu.Lock()
+		delete(rc.cancels, jobID)
+		rc.mu.Unlock()
+	}
+}
+
+// Cancel stops the render running for jobID, if any, by cancelling its
+// context - which in turn kills the ffmpeg subprocess started with
+// exec.CommandContext. It returns false if no render for jobID is
+// currently tracked.
+func (rc *RenderCanceler) Cancel(jobID string) bool {
+	rc.mu.Lock()
+	cancel, ok := rc.cancels[jobID]
+	rc.mu.Unlock()
+	if !ok {
+		return false
+	}
+	cancel()
+	return true
+}
@@ -0,0 +1,67 @@
+package video
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
+)
+
+// FFmpegChecker verifies the ffmpeg binary LocalRenderer depends on is
+// actually present and runnable, so a missing/misconfigured binary
+// shows up in /health instead of surfacing only as every render failing.
+type FFmpegChecker struct {
+	ffmpegPath string
+}
+
+// NewFFmpegChecker creates a checker for ffmpegPath ("ffmpeg", resolved
+// via PATH, if empty).
+func NewFFmpegChecker(ffmpegPath string) *FFmpegChecker {
+	if ffmpegPath == "" {
+		ffmpegPath = "ffmpeg"
+	}
+	return &FFmpegChecker{ffmpegPath: ffmpegPath}
+}
+
+// Check runs "ffmpeg -version" and reports unhealthy if it can't be
+// executed or exits non-zero.
+func (c *FFmpegChecker) Check(ctx context.Context) metrics.HealthCheckResult {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, c.ffmpegPath, "-version")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return metrics.HealthCheckResult{
+			Name:      "ffmpeg",
+			Status:    metrics.HealthStatusUnhealthy,
+			Message:   fmt.Sprintf("ffmpeg -version failed: %v: %s", err, strings.TrimSpace(stderr.String())),
+			Timestamp: time.Now(),
+		}
+	}
+
+	version := strings.SplitN(stdout.String(), "\n", 2)[0]
+	return metrics.HealthCheckResult{
+		Name:      "ffmpeg",
+		Status:    metrics.HealthStatusHealthy,
+		Message:   strings.TrimSpace(version),
+		Timestamp: time.Now(),
+	}
+}
+
+// CreateFFmpegHealthChecker creates a health checker function for
+// ffmpegPath, following the vendored metrics.Create<X>HealthChecker
+// convention.
+func CreateFFmpegHealthChecker(ffmpegPath string) metrics.HealthChecker {
+	checker := NewFFmpegChecker(ffmpegPath)
+	return func(ctx context.Context) metrics.HealthCheckResult {
+		return checker.Check(ctx)
+	}
+}
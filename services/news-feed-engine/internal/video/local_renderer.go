@@ -0,0 +1,314 @@
+package video
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/tts"
+)
+
+// defaultLoudnormI is the integrated-loudness target LocalRenderer mixes
+// VideoTemplate.MusicTrack to, per the request's -18 LUFS spec.
+const defaultLoudnormI = -18.0
+
+// defaultFrameRate is the frame rate LocalRenderer encodes its
+// thumbnail-slideshow segments at; thumbnails are static images, so
+// anything low keeps encode time down without a visible quality cost.
+const defaultFrameRate = 25
+
+// resolutionDims maps a VideoTemplate.Resolution label to ffmpeg scale
+// dimensions, defaulting to 720p for an empty or unrecognized value.
+var resolutionDims = map[string]string{
+	"720p":  "1280x720",
+	"1080p": "1920x1080",
+	"4k":    "3840x2160",
+}
+
+// LocalRenderer implements Renderer by stitching TTS narration, a
+// thumbnail slideshow, optional background music, and an optional
+// watermark together with ffmpeg, as a self-hosted alternative to
+// routing every VideoSummary through a third-party rendering API.
+type LocalRenderer struct {
+	tts        tts.Engine
+	outputDir  string
+	ffmpegPath string
+	loudnormI  float64
+	httpClient *http.Client
+	canceler   *RenderCanceler
+}
+
+// NewLocalRenderer creates a LocalRenderer that synthesizes narration
+// via engine and writes finished videos under outputDir.
+func NewLocalRenderer(engine tts.Engine, outputDir string) *LocalRenderer {
+	return &LocalRenderer{
+		tts:        engine,
+		outputDir:  outputDir,
+		ffmpegPath: "ffmpeg",
+		loudnormI:  defaultLoudnormI,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		canceler:   NewRenderCanceler(),
+	}
+}
+
+// WithFFmpegPath overrides the ffmpeg binary invoked for every stage of
+// the pipeline. Defaults to "ffmpeg", resolved via PATH.
+func (r *LocalRenderer) WithFFmpegPath(path string) *LocalRenderer {
+	r.ffmpegPath = path
+	return r
+}
+
+// WithLoudnorm overrides the integrated-loudness target (LUFS)
+// VideoTemplate.MusicTrack is normalized to before mixing.
+func (r *LocalRenderer) WithLoudnorm(lufs float64) *LocalRenderer {
+	r.loudnormI = lufs
+	return r
+}
+
+// Canceler exposes the RenderCanceler tracking this renderer's in-flight
+// jobs, so a caller (Worker, an admin handler) can cancel one by ID.
+func (r *LocalRenderer) Canceler() *RenderCanceler {
+	return r.canceler
+}
+
+// narrationSegment pairs synthesized text with its source field, purely
+// for error messages.
+type narrationSegment struct {
+	source string
+	text   string
+}
+
+// Render synthesizes narration for the template's intro/outro and the
+// summary's script, builds a thumbnail slideshow timed to each
+// segment's narration, optionally mixes in background music and
+// overlays a watermark, and writes the result under r.outputDir.
+func (r *LocalRenderer) Render(ctx context.Context, job RenderJob) (RenderResult, error) {
+	start := time.Now()
+	jobID := job.Summary.ID.String()
+
+	renderCtx, cancel := context.WithCancel(ctx)
+	release := r.canceler.track(jobID, cancel)
+	defer release()
+	defer cancel()
+
+	workDir, err := os.MkdirTemp("", "video-render-"+jobID+"-*")
+	if err != nil {
+		return RenderResult{}, fmt.Errorf("video: failed to create work dir: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	segments := []narrationSegment{}
+	if job.Template.IntroScript != "" {
+		segments = append(segments, narrationSegment{"intro_script", job.Template.IntroScript})
+	}
+	segments = append(segments, narrationSegment{"script", job.Summary.Script})
+	if job.Template.OutroScript != "" {
+		segments = append(segments, narrationSegment{"outro_script", job.Template.OutroScript})
+	}
+
+	thumbPath, err := r.prepareBackground(renderCtx, job.Content.ThumbnailURL, workDir)
+	if err != nil {
+		return RenderResult{}, err
+	}
+
+	dims := resolutionDims[job.Template.Resolution]
+	if dims == "" {
+		dims = resolutionDims["720p"]
+	}
+
+	var narrationFiles, segmentFiles []string
+	var totalDuration time.Duration
+	for i, seg := range segments {
+		audioPath, duration, err := r.tts.Synthesize(renderCtx, seg.text, job.Summary.VoiceID)
+		if err != nil {
+			return RenderResult{}, fmt.Errorf("video: failed to synthesize %s: %w", seg.source, err)
+		}
+		defer os.Remove(audioPath)
+		narrationFiles = append(narrationFiles, audioPath)
+		totalDuration += duration
+
+		segPath := filepath.Join(workDir, fmt.Sprintf("seg-%d.mp4", i))
+		inputFormat := []string{"-loop", "1"}
+		if job.Content.ThumbnailURL == "" {
+			// thumbPath is prepareBackground's lavfi color source, not a file.
+			inputFormat = []string{"-f", "lavfi"}
+		}
+		args := append([]string{"-y"}, inputFormat...)
+		args = append(args, "-t", fmt.Sprintf("%.3f", duration.Seconds()), "-i", thumbPath,
+			"-vf", fmt.Sprintf("scale=%s", dims), "-r", strconv.Itoa(defaultFrameRate),
+			"-pix_fmt", "yuv420p", segPath,
+		)
+		if err := r.runFFmpeg(renderCtx, args...); err != nil {
+			return RenderResult{}, fmt.Errorf("video: failed to render %s segment: %w", seg.source, err)
+		}
+		segmentFiles = append(segmentFiles, segPath)
+	}
+
+	narrationPath := filepath.Join(workDir, "narration.wav")
+	if err := r.concat(renderCtx, workDir, "narration-list.txt", narrationFiles, narrationPath); err != nil {
+		return RenderResult{}, fmt.Errorf("video: failed to concatenate narration: %w", err)
+	}
+
+	slideshowPath := filepath.Join(workDir, "slideshow.mp4")
+	if err := r.concat(renderCtx, workDir, "video-list.txt", segmentFiles, slideshowPath); err != nil {
+		return RenderResult{}, fmt.Errorf("video: failed to concatenate slideshow: %w", err)
+	}
+
+	outputDuration := job.Template.Duration
+	if outputDuration <= 0 {
+		outputDuration = int(totalDuration.Seconds())
+	}
+
+	outputPath := filepath.Join(r.outputDir, jobID+".mp4")
+	if err := r.assemble(renderCtx, job, workDir, slideshowPath, narrationPath, outputDuration, outputPath); err != nil {
+		return RenderResult{}, err
+	}
+
+	info, err := os.Stat(outputPath)
+	if err != nil {
+		return RenderResult{}, fmt.Errorf("video: failed to stat rendered output: %w", err)
+	}
+
+	return RenderResult{
+		VideoURL:       outputPath,
+		ThumbnailURL:   job.Content.ThumbnailURL,
+		Duration:       outputDuration,
+		FileSize:       info.Size(),
+		Format:         "mp4",
+		GenerationTime: int(time.Since(start).Seconds()),
+	}, nil
+}
+
+// assemble runs the final ffmpeg pass over the silent slideshow and the
+// narration track, mixing in VideoTemplate.MusicTrack (loudness
+// normalized to r.loudnormI LUFS) and overlaying VideoTemplate.WatermarkURL
+// when either is set.
+func (r *LocalRenderer) assemble(ctx context.Context, job RenderJob, workDir, slideshowPath, narrationPath string, outputDuration int, outputPath string) error {
+	args := []string{"-y", "-i", slideshowPath, "-i", narrationPath}
+	nextInput := 2
+
+	musicIdx := -1
+	if job.Template.MusicTrack != "" {
+		musicPath, err := r.download(ctx, job.Template.MusicTrack, filepath.Join(workDir, "music"+filepath.Ext(job.Template.MusicTrack)))
+		if err != nil {
+			return fmt.Errorf("video: failed to fetch music track: %w", err)
+		}
+		args = append(args, "-i", musicPath)
+		musicIdx = nextInput
+		nextInput++
+	}
+
+	watermarkIdx := -1
+	if job.Template.WatermarkURL != "" {
+		watermarkPath, err := r.download(ctx, job.Template.WatermarkURL, filepath.Join(workDir, "watermark"+filepath.Ext(job.Template.WatermarkURL)))
+		if err != nil {
+			return fmt.Errorf("video: failed to fetch watermark: %w", err)
+		}
+		args = append(args, "-i", watermarkPath)
+		watermarkIdx = nextInput
+		nextInput++
+	}
+
+	var filters []string
+	videoMap, audioMap := "0:v", "1:a"
+
+	if musicIdx >= 0 {
+		filters = append(filters, fmt.Sprintf("[%d:a]loudnorm=I=%.1f:TP=-1.5:LRA=11[music]", musicIdx, r.loudnormI))
+		filters = append(filters, "[1:a][music]amix=inputs=2:duration=first:dropout_transition=2[aout]")
+		audioMap = "[aout]"
+	}
+	if watermarkIdx >= 0 {
+		filters = append(filters, fmt.Sprintf("[0:v][%d:v]overlay=W-w-10:H-h-10[vout]", watermarkIdx))
+		videoMap = "[vout]"
+	}
+	if len(filters) > 0 {
+		args = append(args, "-filter_complex", strings.Join(filters, ";"))
+	}
+
+	args = append(args,
+		"-map", videoMap, "-map", audioMap,
+		"-t", strconv.Itoa(outputDuration),
+		"-c:v", "libx264", "-c:a", "aac", "-shortest",
+		outputPath,
+	)
+
+	return r.runFFmpeg(ctx, args...)
+}
+
+// concat writes a concat-demuxer list file under workDir naming files in
+// order and runs ffmpeg's concat demuxer over it into outputPath,
+// copying streams rather than re-encoding since every input segment was
+// already encoded by this same pipeline.
+func (r *LocalRenderer) concat(ctx context.Context, workDir, listName string, files []string, outputPath string) error {
+	var list strings.Builder
+	for _, f := range files {
+		fmt.Fprintf(&list, "file '%s'\n", f)
+	}
+	listPath := filepath.Join(workDir, listName)
+	if err := os.WriteFile(listPath, []byte(list.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write concat list: %w", err)
+	}
+
+	return r.runFFmpeg(ctx, "-y", "-f", "concat", "-safe", "0", "-i", listPath, "-c", "copy", outputPath)
+}
+
+// prepareBackground downloads thumbnailURL into workDir for use as the
+// slideshow's still image, falling back to a solid color source when
+// Content has no thumbnail at all.
+func (r *LocalRenderer) prepareBackground(ctx context.Context, thumbnailURL, workDir string) (string, error) {
+	if thumbnailURL == "" {
+		return "color=c=black:s=1280x720", nil
+	}
+	return r.download(ctx, thumbnailURL, filepath.Join(workDir, "thumbnail"+filepath.Ext(thumbnailURL)))
+}
+
+// download fetches url into destPath.
+func (r *LocalRenderer) download(ctx context.Context, url, destPath string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request for %s: %w", url, err)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch %s: status %d", url, resp.StatusCode)
+	}
+
+	out, err := os.Create(destPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", destPath, err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", destPath, err)
+	}
+	return destPath, nil
+}
+
+// runFFmpeg invokes r.ffmpegPath with args under ctx, so cancelling ctx
+// (via RenderCanceler.Cancel) kills the subprocess outright instead of
+// leaving it to finish on its own.
+func (r *LocalRenderer) runFFmpeg(ctx context.Context, args ...string) error {
+	cmd := exec.CommandContext(ctx, r.ffmpegPath, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("ffmpeg failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
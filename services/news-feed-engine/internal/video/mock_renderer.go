@@ -0,0 +1,26 @@
+package video
+
+import "context"
+
+// MockRenderer implements Renderer without touching ffmpeg or tts, for
+// tests exercising Worker's claim/complete/fail bookkeeping in
+// isolation. RenderFn defaults to returning a canned success result.
+type MockRenderer struct {
+	RenderFn func(ctx context.Context, job RenderJob) (RenderResult, error)
+}
+
+// Render delegates to RenderFn, or a canned success result if RenderFn
+// is nil.
+func (m *MockRenderer) Render(ctx context.Context, job RenderJob) (RenderResult, error) {
+	if m.RenderFn != nil {
+		return m.RenderFn(ctx, job)
+	}
+	return RenderResult{
+		VideoURL:       "mock://video/" + job.Summary.ID.String(),
+		ThumbnailURL:   job.Content.ThumbnailURL,
+		Duration:       job.Template.Duration,
+		FileSize:       1,
+		Format:         "mp4",
+		GenerationTime: 0,
+	}, nil
+}
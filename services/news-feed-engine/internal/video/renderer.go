@@ -0,0 +1,42 @@
+// Package video composes VideoSummary output locally with ffmpeg, an
+// alternative to routing every render through a third-party video API.
+// LocalRenderer is the only implementation today; Renderer exists so a
+// hosted fallback (or MockRenderer in tests) can stand in without
+// Worker caring which one it's driving.
+package video
+
+import (
+	"context"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+// RenderJob bundles everything a Renderer needs to produce one
+// VideoSummary: the source Content (for ThumbnailURL), the VideoSummary
+// row being rendered (for Script/VoiceID/AvatarID), and the
+// VideoTemplate governing intro/outro script, resolution, duration,
+// music, and watermark.
+type RenderJob struct {
+	Content  models.Content
+	Summary  models.VideoSummary
+	Template models.VideoTemplate
+}
+
+// RenderResult carries the fields Worker writes back onto the
+// VideoSummary row once a Renderer finishes.
+type RenderResult struct {
+	VideoURL       string
+	ThumbnailURL   string
+	Duration       int
+	FileSize       int64
+	Format         string
+	GenerationTime int
+}
+
+// Renderer produces a finished video for job. Implementations should
+// respect ctx cancellation promptly - RenderCanceler.Cancel works by
+// cancelling a job's context, and a Renderer that ignores it leaves an
+// orphaned subprocess running.
+type Renderer interface {
+	Render(ctx context.Context, job RenderJob) (RenderResult, error)
+}
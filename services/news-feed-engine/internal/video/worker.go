@@ -0,0 +1,203 @@
+package video
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/database"
+	"github.com/kushin77/elevatedIQ/services/news-feed-engine/internal/models"
+)
+
+// defaultLeaseDuration bounds how long a claimed VideoRepository job is
+// held before VideoRepository.ReapExpiredLeases returns it to pending,
+// so a worker that dies mid-render doesn't strand the job forever.
+const defaultLeaseDuration = 15 * time.Minute
+
+// defaultPollInterval is how often Worker checks VideoRepository for a
+// claimable job when the queue is empty.
+const defaultPollInterval = 5 * time.Second
+
+// defaultHeartbeatInterval is how often Worker extends its lease on the
+// job it's currently rendering.
+const defaultHeartbeatInterval = time.Minute
+
+// Worker drains VideoRepository's pending-job queue, rendering each one
+// with a Renderer and recording the outcome back onto the VideoSummary
+// row, so VideoQueueStats (Queued/Processing/Completed/Failed) reflects
+// this pipeline's actual progress rather than a count nothing updates.
+type Worker struct {
+	id        string
+	videos    *database.VideoRepository
+	content   *database.ContentRepository
+	templates *database.TemplateRepository
+	renderer  Renderer
+	logger    *zap.Logger
+
+	pollInterval      time.Duration
+	leaseDuration     time.Duration
+	heartbeatInterval time.Duration
+}
+
+// NewWorker creates a Worker identified by id (recorded as VideoRepository's
+// worker_id, so stuck jobs can be traced to the process that held them).
+func NewWorker(id string, videos *database.VideoRepository, content *database.ContentRepository, templates *database.TemplateRepository, renderer Renderer, logger *zap.Logger) *Worker {
+	return &Worker{
+		id:                id,
+		videos:            videos,
+		content:           content,
+		templates:         templates,
+		renderer:          renderer,
+		logger:            logger,
+		pollInterval:      defaultPollInterval,
+		leaseDuration:     defaultLeaseDuration,
+		heartbeatInterval: defaultHeartbeatInterval,
+	}
+}
+
+// WithPollInterval overrides how often Worker checks for a claimable job
+// when the queue was last found empty.
+func (w *Worker) WithPollInterval(interval time.Duration) *Worker {
+	w.pollInterval = interval
+	return w
+}
+
+// WithLeaseDuration overrides how long a claimed job is held before
+// VideoRepository.ReapExpiredLeases reclaims it.
+func (w *Worker) WithLeaseDuration(d time.Duration) *Worker {
+	w.leaseDuration = d
+	return w
+}
+
+// Run polls tenantID's queue until ctx is cancelled, rendering one job
+// at a time. A poll that finds nothing claimable waits pollInterval
+// before trying again rather than busy-looping.
+func (w *Worker) Run(ctx context.Context, tenantID string) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		job, err := w.videos.ClaimNext(ctx, tenantID, w.id, w.leaseDuration)
+		if err != nil {
+			w.logger.Warn("failed to claim video job", zap.Error(err))
+			if !sleep(ctx, w.pollInterval) {
+				return ctx.Err()
+			}
+			continue
+		}
+		if job == nil {
+			if !sleep(ctx, w.pollInterval) {
+				return ctx.Err()
+			}
+			continue
+		}
+
+		w.renderOne(ctx, tenantID, *job)
+	}
+}
+
+// renderOne builds the RenderJob for summary, heartbeats the lease while
+// the renderer works, and records the outcome via Complete/Fail.
+func (w *Worker) renderOne(ctx context.Context, tenantID string, summary models.VideoSummary) {
+	stopHeartbeat := w.heartbeat(ctx, summary.ID)
+	defer stopHeartbeat()
+
+	job, err := w.buildRenderJob(ctx, tenantID, summary)
+	if err != nil {
+		w.fail(ctx, summary.ID, err, false)
+		return
+	}
+
+	result, err := w.renderer.Render(ctx, job)
+	if err != nil {
+		// A context cancellation (RenderCanceler.Cancel, or ctx itself
+		// being cancelled) isn't a transient failure worth retrying - it
+		// was asked for.
+		retryable := ctx.Err() == nil
+		w.fail(ctx, summary.ID, err, retryable)
+		return
+	}
+
+	if err := w.videos.Complete(ctx, summary.ID, result.VideoURL, result.ThumbnailURL, result.Duration, result.FileSize, result.Format, result.GenerationTime); err != nil {
+		w.logger.Error("failed to record completed video", zap.Error(err), zap.String("video_id", summary.ID.String()))
+	}
+}
+
+// buildRenderJob loads the Content a VideoSummary was generated from and
+// resolves the VideoTemplate to render it with. VideoSummary has no
+// persisted template reference, so the tenant's default active template
+// is used; a tenant with no default gets its first active template.
+func (w *Worker) buildRenderJob(ctx context.Context, tenantID string, summary models.VideoSummary) (RenderJob, error) {
+	content, err := w.content.GetByID(ctx, tenantID, summary.ContentID)
+	if err != nil {
+		return RenderJob{}, fmt.Errorf("failed to load content %s: %w", summary.ContentID, err)
+	}
+
+	active := true
+	list, err := w.templates.List(ctx, tenantID, database.TemplateListOptions{Active: &active})
+	if err != nil {
+		return RenderJob{}, fmt.Errorf("failed to load video templates: %w", err)
+	}
+	if len(list) == 0 {
+		return RenderJob{}, fmt.Errorf("no active video template configured for tenant %s", tenantID)
+	}
+
+	template := list[0]
+	for _, t := range list {
+		if t.IsDefault {
+			template = t
+			break
+		}
+	}
+
+	return RenderJob{Content: *content, Summary: summary, Template: template}, nil
+}
+
+// heartbeat extends the job's lease on heartbeatInterval until the
+// returned stop func is called, so a render that outlasts one lease
+// window doesn't get reaped out from under it.
+func (w *Worker) heartbeat(ctx context.Context, videoID uuid.UUID) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(w.heartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := w.videos.Heartbeat(ctx, videoID, w.id, w.leaseDuration); err != nil {
+					w.logger.Warn("failed to extend video render lease", zap.Error(err), zap.String("video_id", videoID.String()))
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+func (w *Worker) fail(ctx context.Context, videoID uuid.UUID, err error, retryable bool) {
+	w.logger.Error("video render failed", zap.Error(err), zap.String("video_id", videoID.String()), zap.Bool("retryable", retryable))
+	if failErr := w.videos.Fail(ctx, videoID, err.Error(), retryable); failErr != nil {
+		w.logger.Error("failed to record video failure", zap.Error(failErr), zap.String("video_id", videoID.String()))
+	}
+}
+
+// sleep waits for d or ctx cancellation, returning false in the latter
+// case so callers can stop looping immediately instead of completing
+// the wait first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,328 @@
+// Package webhooks provides a generic outbound webhook subscription
+// manager: tenants subscribe a URL to a set of event types, and the
+// manager signs, delivers, and retries events against those URLs.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// EventType identifies the kind of event being dispatched to subscribers.
+type EventType string
+
+const (
+	EventContentPublished EventType = "content.published"
+	EventContentUpdated   EventType = "content.updated"
+	EventVideoReady       EventType = "video.ready"
+	EventCreatorVerified  EventType = "creator.verified"
+)
+
+// Subscription represents a tenant's registration to receive webhook
+// events at a URL, signed with a per-subscription secret.
+type Subscription struct {
+	ID        string      `json:"id"`
+	TenantID  string      `json:"tenant_id"`
+	URL       string      `json:"url"`
+	Secret    string      `json:"-"`
+	Events    []EventType `json:"events"`
+	Active    bool        `json:"active"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// Event is a single payload dispatched to matching subscriptions.
+type Event struct {
+	ID         string                 `json:"id"`
+	Type       EventType              `json:"type"`
+	TenantID   string                 `json:"tenant_id"`
+	Payload    map[string]interface{} `json:"payload"`
+	OccurredAt time.Time              `json:"occurred_at"`
+}
+
+// Delivery records the outcome of attempting to deliver an event to a
+// single subscription.
+type Delivery struct {
+	SubscriptionID string
+	EventID        string
+	Attempts       int
+	StatusCode     int
+	Success        bool
+	Error          string
+	DeliveredAt    time.Time
+}
+
+// Manager owns the set of subscriptions and dispatches events to them,
+// retrying failed deliveries with exponential backoff.
+type Manager struct {
+	mu            sync.RWMutex
+	subscriptions map[string]*Subscription
+
+	client     *http.Client
+	logger     *zap.Logger
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+}
+
+// NewManager creates a webhook subscription manager. maxRetries is the
+// number of retry attempts after the initial delivery (so a value of 5
+// means up to 6 total attempts).
+func NewManager(logger *zap.Logger, maxRetries int) *Manager {
+	if logger == nil {
+		logger, _ = zap.NewDevelopment()
+	}
+	if maxRetries <= 0 {
+		maxRetries = 5
+	}
+	return &Manager{
+		subscriptions: make(map[string]*Subscription),
+		client:        &http.Client{Timeout: 10 * time.Second},
+		logger:        logger,
+		maxRetries:    maxRetries,
+		baseDelay:     500 * time.Millisecond,
+		maxDelay:      2 * time.Minute,
+	}
+}
+
+// Subscribe registers a new subscription and returns it with a generated
+// ID and secret.
+func (m *Manager) Subscribe(tenantID, url string, events []EventType) (*Subscription, error) {
+	if url == "" {
+		return nil, fmt.Errorf("webhook url cannot be empty")
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("at least one event type must be specified")
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	sub := &Subscription{
+		ID:        uuid.New().String(),
+		TenantID:  tenantID,
+		URL:       url,
+		Secret:    secret,
+		Events:    events,
+		Active:    true,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	m.mu.Lock()
+	m.subscriptions[sub.ID] = sub
+	m.mu.Unlock()
+
+	return sub, nil
+}
+
+// Unsubscribe removes a subscription.
+func (m *Manager) Unsubscribe(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.subscriptions[id]; !exists {
+		return fmt.Errorf("subscription %q not found", id)
+	}
+	delete(m.subscriptions, id)
+	return nil
+}
+
+// ListSubscriptions returns all active subscriptions for a tenant.
+func (m *Manager) ListSubscriptions(tenantID string) []*Subscription {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	subs := make([]*Subscription, 0)
+	for _, sub := range m.subscriptions {
+		if sub.TenantID == tenantID {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+// Dispatch delivers an event to every active subscription matching its
+// tenant and event type, retrying each delivery independently. It
+// returns once all deliveries (including retries) have completed or the
+// context is cancelled.
+func (m *Manager) Dispatch(ctx context.Context, event Event) []Delivery {
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+
+	matches := m.matchingSubscriptions(event)
+
+	deliveries := make([]Delivery, 0, len(matches))
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	for _, sub := range matches {
+		wg.Add(1)
+		go func(sub *Subscription) {
+			defer wg.Done()
+			d := m.deliverWithRetry(ctx, sub, event)
+			mu.Lock()
+			deliveries = append(deliveries, d)
+			mu.Unlock()
+		}(sub)
+	}
+
+	wg.Wait()
+	return deliveries
+}
+
+func (m *Manager) matchingSubscriptions(event Event) []*Subscription {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	matches := make([]*Subscription, 0)
+	for _, sub := range m.subscriptions {
+		if !sub.Active || sub.TenantID != event.TenantID {
+			continue
+		}
+		for _, et := range sub.Events {
+			if et == event.Type {
+				matches = append(matches, sub)
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// deliverWithRetry POSTs the event to the subscription's URL, retrying
+// with exponential backoff and jitter on failure (non-2xx response or
+// transport error) up to m.maxRetries times.
+func (m *Manager) deliverWithRetry(ctx context.Context, sub *Subscription, event Event) Delivery {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return Delivery{
+			SubscriptionID: sub.ID,
+			EventID:        event.ID,
+			Error:          fmt.Sprintf("failed to marshal event: %v", err),
+			DeliveredAt:    time.Now().UTC(),
+		}
+	}
+
+	var lastErr error
+	var lastStatus int
+
+	for attempt := 0; attempt <= m.maxRetries; attempt++ {
+		if attempt > 0 {
+			delay := m.backoffDelay(attempt)
+			select {
+			case <-ctx.Done():
+				return Delivery{
+					SubscriptionID: sub.ID,
+					EventID:        event.ID,
+					Attempts:       attempt,
+					Error:          ctx.Err().Error(),
+					DeliveredAt:    time.Now().UTC(),
+				}
+			case <-time.After(delay):
+			}
+		}
+
+		status, err := m.send(ctx, sub, event.ID, body)
+		lastStatus = status
+		lastErr = err
+
+		if err == nil && status >= 200 && status < 300 {
+			return Delivery{
+				SubscriptionID: sub.ID,
+				EventID:        event.ID,
+				Attempts:       attempt + 1,
+				StatusCode:     status,
+				Success:        true,
+				DeliveredAt:    time.Now().UTC(),
+			}
+		}
+
+		m.logger.Warn("webhook delivery attempt failed",
+			zap.String("subscription_id", sub.ID),
+			zap.String("event_id", event.ID),
+			zap.Int("attempt", attempt+1),
+			zap.Int("status", status),
+			zap.Error(err))
+	}
+
+	errMsg := ""
+	if lastErr != nil {
+		errMsg = lastErr.Error()
+	}
+	return Delivery{
+		SubscriptionID: sub.ID,
+		EventID:        event.ID,
+		Attempts:       m.maxRetries + 1,
+		StatusCode:     lastStatus,
+		Success:        false,
+		Error:          errMsg,
+		DeliveredAt:    time.Now().UTC(),
+	}
+}
+
+// backoffDelay computes an exponential backoff with full jitter, capped
+// at m.maxDelay: base * 2^(attempt-1), randomized into [0, cap].
+func (m *Manager) backoffDelay(attempt int) time.Duration {
+	cap := float64(m.baseDelay) * math.Pow(2, float64(attempt-1))
+	if cap > float64(m.maxDelay) {
+		cap = float64(m.maxDelay)
+	}
+	return time.Duration(rand.Int63n(int64(cap) + 1))
+}
+
+// send performs a single HTTP delivery attempt, signing the request with
+// an HMAC-SHA256 signature over "<timestamp>.<body>" and a delivery
+// timestamp header, mirroring the scheme subscribers use with
+// VerifySignature to authenticate inbound events and reject replays.
+func (m *Manager) send(ctx context.Context, sub *Subscription, eventID string, body []byte) (int, error) {
+	timestamp := time.Now().Unix()
+	signature := Sign(sub.Secret, body, timestamp)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Id", eventID)
+	req.Header.Set("X-Webhook-Timestamp", fmt.Sprintf("%d", timestamp))
+	req.Header.Set("X-Webhook-Signature", signature)
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+// Sign computes the HMAC-SHA256 signature used to authenticate a
+// webhook delivery, over the string "<timestamp>.<body>".
+func Sign(secret string, body []byte, timestamp int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.", timestamp)))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func generateSecret() (string, error) {
+	return "whsec_" + uuid.New().String(), nil
+}
@@ -0,0 +1,97 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrSignatureMismatch is returned when the computed signature does not
+// match the one supplied by the sender.
+var ErrSignatureMismatch = fmt.Errorf("webhook signature mismatch")
+
+// ErrTimestampOutOfRange is returned when the delivery timestamp is
+// further from the current time than the configured tolerance, which
+// would otherwise let an intercepted request be replayed indefinitely.
+var ErrTimestampOutOfRange = fmt.Errorf("webhook timestamp outside tolerance window")
+
+// ErrReplayedDelivery is returned when a delivery with the same event ID
+// has already been verified, meaning the sender is retrying a delivery
+// the receiver already processed, or a captured request is being
+// replayed.
+var ErrReplayedDelivery = fmt.Errorf("webhook delivery already processed")
+
+// VerifySignature checks that signature is the HMAC-SHA256 of
+// "<timestamp>.<body>" under secret, and that timestamp falls within
+// tolerance of now. It does not perform replay-nonce tracking; combine
+// with a NonceStore for end-to-end replay protection.
+func VerifySignature(secret string, body []byte, timestamp int64, signature string, tolerance time.Duration) error {
+	expected := Sign(secret, body, timestamp)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrSignatureMismatch
+	}
+
+	delivered := time.Unix(timestamp, 0)
+	if diff := time.Since(delivered); diff > tolerance || diff < -tolerance {
+		return ErrTimestampOutOfRange
+	}
+
+	return nil
+}
+
+// NonceStore tracks recently-seen webhook event IDs so a delivery (or a
+// captured and replayed copy of one) is only ever processed once. Seen
+// IDs are evicted after ttl so the store does not grow unbounded.
+type NonceStore struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+	ttl  time.Duration
+}
+
+// NewNonceStore creates a NonceStore that remembers event IDs for ttl.
+func NewNonceStore(ttl time.Duration) *NonceStore {
+	if ttl <= 0 {
+		ttl = 10 * time.Minute
+	}
+	return &NonceStore{
+		seen: make(map[string]time.Time),
+		ttl:  ttl,
+	}
+}
+
+// CheckAndRemember returns ErrReplayedDelivery if eventID has already
+// been recorded and not yet expired; otherwise it records eventID and
+// returns nil. Expired entries are swept opportunistically.
+func (s *NonceStore) CheckAndRemember(eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if seenAt, ok := s.seen[eventID]; ok && now.Sub(seenAt) <= s.ttl {
+		return ErrReplayedDelivery
+	}
+
+	s.seen[eventID] = now
+	s.sweepLocked(now)
+	return nil
+}
+
+// sweepLocked removes expired entries. Must be called with s.mu held.
+func (s *NonceStore) sweepLocked(now time.Time) {
+	for id, seenAt := range s.seen {
+		if now.Sub(seenAt) > s.ttl {
+			delete(s.seen, id)
+		}
+	}
+}
+
+// VerifyDelivery combines signature verification with replay protection:
+// it rejects stale or mis-signed requests via VerifySignature, then
+// rejects previously-seen event IDs via store.
+func VerifyDelivery(store *NonceStore, secret string, body []byte, timestamp int64, signature, eventID string, tolerance time.Duration) error {
+	if err := VerifySignature(secret, body, timestamp, signature, tolerance); err != nil {
+		return err
+	}
+	return store.CheckAndRemember(eventID)
+}
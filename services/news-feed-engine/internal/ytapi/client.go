@@ -0,0 +1,811 @@
+// Package ytapi centralizes every outbound call this service makes to
+// the YouTube Data API - channels, videos, playlistItems, search,
+// captions, and commentThreads - behind typed methods on Client, so no
+// other package constructs a googleapis.com URL directly. It charges
+// each call against a QuotaTracker using the API's documented
+// per-endpoint unit costs, pools multiple API keys with round-robin
+// failover when one is exhausted, and retries 5xx responses with
+// exponential backoff.
+//
+// integrations.YouTubeIntegration wraps a Client for all Data API
+// access; its PubSubHubbub subscription management is unrelated to the
+// Data API (it talks to pubsubhubbub.appspot.com) and isn't quota-metered
+// here.
+package ytapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Channel is a YouTube channel's identifying and statistical metadata.
+type Channel struct {
+	ID              string `json:"id"`
+	Title           string `json:"title"`
+	Description     string `json:"description"`
+	CustomURL       string `json:"custom_url"`
+	ThumbnailURL    string `json:"thumbnail_url"`
+	SubscriberCount int64  `json:"subscriber_count"`
+	VideoCount      int64  `json:"video_count"`
+	ViewCount       int64  `json:"view_count"`
+}
+
+// Video is a YouTube video's metadata and statistics. Everything through
+// LiveBroadcastContent comes from the Data API and is always populated by
+// GetVideoDetails; the fields from Chapters onward are never set by this
+// package - they're filled in by integrations.YTDLPFetcher's optional
+// enrichment pass for data the Data API can't cheaply provide, and stay
+// zero-valued when that enrichment is disabled.
+type Video struct {
+	ID           string    `json:"id"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	ChannelID    string    `json:"channel_id"`
+	ChannelTitle string    `json:"channel_title"`
+	PublishedAt  time.Time `json:"published_at"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	// Duration is the raw ISO 8601 string (e.g. "PT1H2M3S") the Data API
+	// returns in contentDetails.duration. DurationSeconds is the same
+	// value parsed by ParseISO8601Duration, for callers that need to
+	// filter or sort on it.
+	Duration        string   `json:"duration"`
+	DurationSeconds int64    `json:"duration_seconds"`
+	ViewCount       int64    `json:"view_count"`
+	LikeCount       int64    `json:"like_count"`
+	CommentCount    int64    `json:"comment_count"`
+	Tags            []string `json:"tags"`
+	// LiveBroadcastContent is snippet.liveBroadcastContent from the Data
+	// API: "live", "upcoming", or "none". See IsLive and IsPremiere.
+	LiveBroadcastContent string `json:"live_broadcast_content,omitempty"`
+
+	Chapters        []Chapter `json:"chapters,omitempty"`
+	Category        string    `json:"category,omitempty"`
+	IsAgeRestricted bool      `json:"is_age_restricted,omitempty"`
+	IsUnavailable   bool      `json:"is_unavailable,omitempty"`
+	LiveStatus      string    `json:"live_status,omitempty"`
+	Transcript      string    `json:"transcript,omitempty"`
+}
+
+// Chapter is one named segment of a video's timeline, as reported by
+// yt-dlp's chapter extraction (the Data API has no equivalent).
+type Chapter struct {
+	Title string        `json:"title"`
+	Start time.Duration `json:"start"`
+	End   time.Duration `json:"end"`
+}
+
+// CaptionTrack is one entry from captions.list for a video.
+type CaptionTrack struct {
+	ID           string `json:"id"`
+	Language     string `json:"language"`
+	TrackKind    string `json:"track_kind"`
+	IsAutoSynced bool   `json:"is_auto_synced"`
+}
+
+// SearchResult is one item from search.list, covering the video/channel/
+// playlist kinds the service cares about.
+type SearchResult struct {
+	Kind         string    `json:"kind"` // "video", "channel", or "playlist"
+	ID           string    `json:"id"`
+	ChannelID    string    `json:"channel_id"`
+	ChannelTitle string    `json:"channel_title"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	PublishedAt  time.Time `json:"published_at"`
+}
+
+// CommentThread is one top-level comment (and its reply count) from
+// commentThreads.list for a video.
+type CommentThread struct {
+	ID              string    `json:"id"`
+	VideoID         string    `json:"video_id"`
+	AuthorName      string    `json:"author_name"`
+	AuthorChannelID string    `json:"author_channel_id"`
+	TextDisplay     string    `json:"text_display"`
+	LikeCount       int64     `json:"like_count"`
+	ReplyCount      int64     `json:"reply_count"`
+	PublishedAt     time.Time `json:"published_at"`
+}
+
+// Client performs quota-tracked, key-pooled calls against the YouTube
+// Data API. The zero value is not usable; construct with NewClient.
+type Client struct {
+	keys       []string
+	nextKey    uint32
+	httpClient *http.Client
+	quota      *QuotaTracker
+	logger     *zap.Logger
+
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+}
+
+// NewClient creates a Client backed by apiKeys, pooled with round-robin
+// failover on a 403 quotaExceeded/rateLimitExceeded response. dailyQuotaBudget
+// caps each key's unit consumption per UTC day (0 means unlimited). At
+// least one key is required; NewClient panics otherwise since a Client
+// with no keys can never make a request.
+func NewClient(apiKeys []string, dailyQuotaBudget int, logger *zap.Logger) *Client {
+	if len(apiKeys) == 0 {
+		panic("ytapi: NewClient requires at least one API key")
+	}
+	return &Client{
+		keys:       apiKeys,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		quota:      NewQuotaTracker(dailyQuotaBudget),
+		logger:     logger,
+		maxRetries: 3,
+		baseDelay:  250 * time.Millisecond,
+		maxDelay:   5 * time.Second,
+	}
+}
+
+// Quota exposes the Client's QuotaTracker, e.g. for a health/admin
+// endpoint to report remaining budget per pooled key.
+func (c *Client) Quota() *QuotaTracker {
+	return c.quota
+}
+
+// GetChannel retrieves channel information by channel ID.
+func (c *Client) GetChannel(ctx context.Context, channelID string) (*Channel, error) {
+	params := url.Values{
+		"part": {"snippet,statistics"},
+		"id":   {channelID},
+	}
+
+	body, err := c.doRequest(ctx, EndpointChannels, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	var result struct {
+		Items []struct {
+			ID      string `json:"id"`
+			Snippet struct {
+				Title       string `json:"title"`
+				Description string `json:"description"`
+				CustomURL   string `json:"customUrl"`
+				Thumbnails  struct {
+					High struct {
+						URL string `json:"url"`
+					} `json:"high"`
+				} `json:"thumbnails"`
+			} `json:"snippet"`
+			Statistics struct {
+				SubscriberCount string `json:"subscriberCount"`
+				VideoCount      string `json:"videoCount"`
+				ViewCount       string `json:"viewCount"`
+			} `json:"statistics"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse channel response: %w", err)
+	}
+	if len(result.Items) == 0 {
+		return nil, fmt.Errorf("channel not found: %s", channelID)
+	}
+
+	item := result.Items[0]
+	return &Channel{
+		ID:              item.ID,
+		Title:           item.Snippet.Title,
+		Description:     item.Snippet.Description,
+		CustomURL:       item.Snippet.CustomURL,
+		ThumbnailURL:    item.Snippet.Thumbnails.High.URL,
+		SubscriberCount: parseInt64(item.Statistics.SubscriberCount),
+		VideoCount:      parseInt64(item.Statistics.VideoCount),
+		ViewCount:       parseInt64(item.Statistics.ViewCount),
+	}, nil
+}
+
+// uploadsPlaylistID looks up the uploads playlist backing channelID, the
+// entry point GetChannelVideos/GetChannelVideosPage page through.
+func (c *Client) uploadsPlaylistID(ctx context.Context, channelID string) (string, error) {
+	params := url.Values{
+		"part": {"contentDetails"},
+		"id":   {channelID},
+	}
+
+	body, err := c.doRequest(ctx, EndpointChannels, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to get channel details: %w", err)
+	}
+
+	var result struct {
+		Items []struct {
+			ContentDetails struct {
+				RelatedPlaylists struct {
+					Uploads string `json:"uploads"`
+				} `json:"relatedPlaylists"`
+			} `json:"contentDetails"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to parse channel details: %w", err)
+	}
+	if len(result.Items) == 0 {
+		return "", fmt.Errorf("channel not found: %s", channelID)
+	}
+	return result.Items[0].ContentDetails.RelatedPlaylists.Uploads, nil
+}
+
+// playlistItemsPage is the shared parsing path for one page of a
+// playlist's videos, used by both GetChannelVideos and
+// GetChannelVideosPage.
+func (c *Client) playlistItemsPage(ctx context.Context, playlistID string, maxResults int, pageToken string) ([]Video, string, error) {
+	params := url.Values{
+		"part":       {"snippet"},
+		"playlistId": {playlistID},
+		"maxResults": {fmt.Sprintf("%d", maxResults)},
+	}
+	if pageToken != "" {
+		params.Set("pageToken", pageToken)
+	}
+
+	body, err := c.doRequest(ctx, EndpointPlaylistItems, params)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get playlist items: %w", err)
+	}
+
+	var result struct {
+		NextPageToken string `json:"nextPageToken"`
+		Items         []struct {
+			Snippet struct {
+				ResourceID struct {
+					VideoID string `json:"videoId"`
+				} `json:"resourceId"`
+				Title        string    `json:"title"`
+				Description  string    `json:"description"`
+				ChannelID    string    `json:"channelId"`
+				ChannelTitle string    `json:"channelTitle"`
+				PublishedAt  time.Time `json:"publishedAt"`
+				Thumbnails   struct {
+					High struct {
+						URL string `json:"url"`
+					} `json:"high"`
+				} `json:"thumbnails"`
+			} `json:"snippet"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, "", fmt.Errorf("failed to parse playlist items: %w", err)
+	}
+
+	videos := make([]Video, 0, len(result.Items))
+	for _, item := range result.Items {
+		videos = append(videos, Video{
+			ID:           item.Snippet.ResourceID.VideoID,
+			Title:        item.Snippet.Title,
+			Description:  item.Snippet.Description,
+			ChannelID:    item.Snippet.ChannelID,
+			ChannelTitle: item.Snippet.ChannelTitle,
+			PublishedAt:  item.Snippet.PublishedAt,
+			ThumbnailURL: item.Snippet.Thumbnails.High.URL,
+		})
+	}
+	return videos, result.NextPageToken, nil
+}
+
+// GetChannelVideos retrieves channelID's recent uploads, enriched with
+// duration and statistics, optionally filtered to videos published at
+// or after publishedAfter.
+func (c *Client) GetChannelVideos(ctx context.Context, channelID string, maxResults int, publishedAfter *time.Time) ([]Video, error) {
+	playlistID, err := c.uploadsPlaylistID(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	page, _, err := c.playlistItemsPage(ctx, playlistID, maxResults, "")
+	if err != nil {
+		return nil, err
+	}
+
+	videos := make([]Video, 0, len(page))
+	for _, v := range page {
+		if publishedAfter != nil && v.PublishedAt.Before(*publishedAfter) {
+			continue
+		}
+		videos = append(videos, v)
+	}
+
+	if len(videos) > 0 {
+		videos, err = c.enrichVideoDetails(ctx, videos)
+		if err != nil {
+			c.logger.Warn("Failed to enrich video details", zap.Error(err))
+		}
+	}
+
+	return videos, nil
+}
+
+// GetChannelVideosPage retrieves one page of channelID's uploads
+// playlist, starting at pageToken (empty for the first page), returning
+// the videos on that page and the token for the next one ("" once
+// exhausted). Unlike GetChannelVideos this does not enrich
+// durations/stats or filter by date, since historical backfill callers
+// page through the full history and only need enough metadata to
+// enqueue a per-item ingestion job.
+func (c *Client) GetChannelVideosPage(ctx context.Context, channelID, pageToken string) ([]Video, string, error) {
+	return c.GetChannelVideosPageSized(ctx, channelID, pageToken, 50)
+}
+
+// GetChannelVideosPageSized is GetChannelVideosPage with a caller-chosen
+// page size (the API caps maxResults at 50; values <= 0 fall back to 50),
+// for callers like BackfillChannel that make page size configurable per
+// job rather than always requesting the maximum.
+func (c *Client) GetChannelVideosPageSized(ctx context.Context, channelID, pageToken string, maxResults int) ([]Video, string, error) {
+	if maxResults <= 0 {
+		maxResults = 50
+	}
+	playlistID, err := c.uploadsPlaylistID(ctx, channelID)
+	if err != nil {
+		return nil, "", err
+	}
+	return c.playlistItemsPage(ctx, playlistID, maxResults, pageToken)
+}
+
+// GetVideoDetails retrieves detailed information for a single video.
+func (c *Client) GetVideoDetails(ctx context.Context, videoID string) (*Video, error) {
+	params := url.Values{
+		"part": {"snippet,contentDetails,statistics"},
+		"id":   {videoID},
+	}
+
+	body, err := c.doRequest(ctx, EndpointVideos, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get video details: %w", err)
+	}
+
+	var result struct {
+		Items []struct {
+			ID      string `json:"id"`
+			Snippet struct {
+				Title                string    `json:"title"`
+				Description          string    `json:"description"`
+				ChannelID            string    `json:"channelId"`
+				ChannelTitle         string    `json:"channelTitle"`
+				PublishedAt          time.Time `json:"publishedAt"`
+				Tags                 []string  `json:"tags"`
+				LiveBroadcastContent string    `json:"liveBroadcastContent"`
+				Thumbnails           struct {
+					High struct {
+						URL string `json:"url"`
+					} `json:"high"`
+				} `json:"thumbnails"`
+			} `json:"snippet"`
+			ContentDetails struct {
+				Duration string `json:"duration"`
+			} `json:"contentDetails"`
+			Statistics struct {
+				ViewCount    string `json:"viewCount"`
+				LikeCount    string `json:"likeCount"`
+				CommentCount string `json:"commentCount"`
+			} `json:"statistics"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse video details: %w", err)
+	}
+	if len(result.Items) == 0 {
+		return nil, fmt.Errorf("video not found: %s", videoID)
+	}
+
+	item := result.Items[0]
+	durationSeconds, err := ParseISO8601Duration(item.ContentDetails.Duration)
+	if err != nil {
+		c.logger.Warn("failed to parse video duration", zap.String("video_id", item.ID), zap.Error(err))
+	}
+	return &Video{
+		ID:                   item.ID,
+		Title:                item.Snippet.Title,
+		Description:          item.Snippet.Description,
+		ChannelID:            item.Snippet.ChannelID,
+		ChannelTitle:         item.Snippet.ChannelTitle,
+		PublishedAt:          item.Snippet.PublishedAt,
+		ThumbnailURL:         item.Snippet.Thumbnails.High.URL,
+		Duration:             item.ContentDetails.Duration,
+		DurationSeconds:      durationSeconds,
+		ViewCount:            parseInt64(item.Statistics.ViewCount),
+		LikeCount:            parseInt64(item.Statistics.LikeCount),
+		CommentCount:         parseInt64(item.Statistics.CommentCount),
+		Tags:                 item.Snippet.Tags,
+		LiveBroadcastContent: item.Snippet.LiveBroadcastContent,
+	}, nil
+}
+
+// enrichVideoDetails adds duration and statistics to a list of videos
+// already populated from playlistItems/search, batching them into a
+// single videos.list call.
+func (c *Client) enrichVideoDetails(ctx context.Context, videos []Video) ([]Video, error) {
+	videoIDs := ""
+	for i, v := range videos {
+		if i > 0 {
+			videoIDs += ","
+		}
+		videoIDs += v.ID
+	}
+
+	params := url.Values{
+		"part": {"snippet,contentDetails,statistics"},
+		"id":   {videoIDs},
+	}
+
+	body, err := c.doRequest(ctx, EndpointVideos, params)
+	if err != nil {
+		return videos, err
+	}
+
+	var result struct {
+		Items []struct {
+			ID      string `json:"id"`
+			Snippet struct {
+				LiveBroadcastContent string `json:"liveBroadcastContent"`
+			} `json:"snippet"`
+			ContentDetails struct {
+				Duration string `json:"duration"`
+			} `json:"contentDetails"`
+			Statistics struct {
+				ViewCount    string `json:"viewCount"`
+				LikeCount    string `json:"likeCount"`
+				CommentCount string `json:"commentCount"`
+			} `json:"statistics"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return videos, err
+	}
+
+	type videoDetails struct {
+		Duration             string
+		LiveBroadcastContent string
+		ViewCount            string
+		LikeCount            string
+		CommentCount         string
+	}
+	details := make(map[string]videoDetails, len(result.Items))
+	for _, item := range result.Items {
+		details[item.ID] = videoDetails{
+			Duration:             item.ContentDetails.Duration,
+			LiveBroadcastContent: item.Snippet.LiveBroadcastContent,
+			ViewCount:            item.Statistics.ViewCount,
+			LikeCount:            item.Statistics.LikeCount,
+			CommentCount:         item.Statistics.CommentCount,
+		}
+	}
+
+	for i := range videos {
+		d, ok := details[videos[i].ID]
+		if !ok {
+			continue
+		}
+		videos[i].Duration = d.Duration
+		if durationSeconds, err := ParseISO8601Duration(d.Duration); err == nil {
+			videos[i].DurationSeconds = durationSeconds
+		} else {
+			c.logger.Warn("failed to parse video duration", zap.String("video_id", videos[i].ID), zap.Error(err))
+		}
+		videos[i].LiveBroadcastContent = d.LiveBroadcastContent
+		videos[i].ViewCount = parseInt64(d.ViewCount)
+		videos[i].LikeCount = parseInt64(d.LikeCount)
+		videos[i].CommentCount = parseInt64(d.CommentCount)
+	}
+
+	return videos, nil
+}
+
+// ListCaptions retrieves the available caption tracks for a video.
+// Downloading the actual caption content requires OAuth2, which this
+// Client doesn't hold, so only track metadata is returned.
+func (c *Client) ListCaptions(ctx context.Context, videoID string) ([]CaptionTrack, error) {
+	params := url.Values{
+		"part":    {"snippet"},
+		"videoId": {videoID},
+	}
+
+	body, err := c.doRequest(ctx, EndpointCaptions, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get captions: %w", err)
+	}
+
+	var result struct {
+		Items []struct {
+			ID      string `json:"id"`
+			Snippet struct {
+				Language     string `json:"language"`
+				TrackKind    string `json:"trackKind"`
+				IsAutoSynced bool   `json:"isAutoSynced"`
+			} `json:"snippet"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse captions response: %w", err)
+	}
+
+	tracks := make([]CaptionTrack, 0, len(result.Items))
+	for _, item := range result.Items {
+		tracks = append(tracks, CaptionTrack{
+			ID:           item.ID,
+			Language:     item.Snippet.Language,
+			TrackKind:    item.Snippet.TrackKind,
+			IsAutoSynced: item.Snippet.IsAutoSynced,
+		})
+	}
+	return tracks, nil
+}
+
+// SearchVideos runs a search.list query scoped to video results,
+// returning the page of matches and the token for the next page ("" once
+// exhausted). search is the Data API's most expensive endpoint by far
+// (unitCosts[EndpointSearch]), so callers should prefer a more targeted
+// endpoint (e.g. GetChannelVideosPage) when one fits.
+func (c *Client) SearchVideos(ctx context.Context, query string, maxResults int, pageToken string) ([]SearchResult, string, error) {
+	params := url.Values{
+		"part":       {"snippet"},
+		"q":          {query},
+		"type":       {"video"},
+		"maxResults": {fmt.Sprintf("%d", maxResults)},
+	}
+	if pageToken != "" {
+		params.Set("pageToken", pageToken)
+	}
+
+	body, err := c.doRequest(ctx, EndpointSearch, params)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to search videos: %w", err)
+	}
+
+	var result struct {
+		NextPageToken string `json:"nextPageToken"`
+		Items         []struct {
+			ID struct {
+				Kind    string `json:"kind"`
+				VideoID string `json:"videoId"`
+			} `json:"id"`
+			Snippet struct {
+				ChannelID    string    `json:"channelId"`
+				ChannelTitle string    `json:"channelTitle"`
+				Title        string    `json:"title"`
+				Description  string    `json:"description"`
+				PublishedAt  time.Time `json:"publishedAt"`
+				Thumbnails   struct {
+					High struct {
+						URL string `json:"url"`
+					} `json:"high"`
+				} `json:"thumbnails"`
+			} `json:"snippet"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, "", fmt.Errorf("failed to parse search response: %w", err)
+	}
+
+	results := make([]SearchResult, 0, len(result.Items))
+	for _, item := range result.Items {
+		results = append(results, SearchResult{
+			Kind:         "video",
+			ID:           item.ID.VideoID,
+			ChannelID:    item.Snippet.ChannelID,
+			ChannelTitle: item.Snippet.ChannelTitle,
+			Title:        item.Snippet.Title,
+			Description:  item.Snippet.Description,
+			ThumbnailURL: item.Snippet.Thumbnails.High.URL,
+			PublishedAt:  item.Snippet.PublishedAt,
+		})
+	}
+	return results, result.NextPageToken, nil
+}
+
+// ListCommentThreads retrieves up to maxResults top-level comment
+// threads for a video, newest relevance order as returned by the API.
+func (c *Client) ListCommentThreads(ctx context.Context, videoID string, maxResults int) ([]CommentThread, error) {
+	params := url.Values{
+		"part":       {"snippet"},
+		"videoId":    {videoID},
+		"maxResults": {fmt.Sprintf("%d", maxResults)},
+	}
+
+	body, err := c.doRequest(ctx, EndpointCommentThreads, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comment threads: %w", err)
+	}
+
+	var result struct {
+		Items []struct {
+			ID      string `json:"id"`
+			Snippet struct {
+				TopLevelComment struct {
+					Snippet struct {
+						AuthorDisplayName string `json:"authorDisplayName"`
+						AuthorChannelID   struct {
+							Value string `json:"value"`
+						} `json:"authorChannelId"`
+						TextDisplay string    `json:"textDisplay"`
+						LikeCount   int64     `json:"likeCount"`
+						PublishedAt time.Time `json:"publishedAt"`
+					} `json:"snippet"`
+				} `json:"topLevelComment"`
+				TotalReplyCount int64 `json:"totalReplyCount"`
+			} `json:"snippet"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse comment threads response: %w", err)
+	}
+
+	threads := make([]CommentThread, 0, len(result.Items))
+	for _, item := range result.Items {
+		top := item.Snippet.TopLevelComment.Snippet
+		threads = append(threads, CommentThread{
+			ID:              item.ID,
+			VideoID:         videoID,
+			AuthorName:      top.AuthorDisplayName,
+			AuthorChannelID: top.AuthorChannelID.Value,
+			TextDisplay:     top.TextDisplay,
+			LikeCount:       top.LikeCount,
+			ReplyCount:      item.Snippet.TotalReplyCount,
+			PublishedAt:     top.PublishedAt,
+		})
+	}
+	return threads, nil
+}
+
+// failoverError signals doRequest that the pooled key it was charged
+// against returned a quota-related 403 and should be rotated out in
+// favor of the next one, rather than surfaced to the caller.
+type failoverError struct {
+	reason string
+}
+
+func (e *failoverError) Error() string {
+	return fmt.Sprintf("youtube data api pooled key rejected: %s", e.reason)
+}
+
+// doRequest dispatches one YouTube Data API call for endpoint, charging
+// its documented unit cost against a pooled key selected round-robin. If
+// that key is over budget (QuotaTracker) or the API rejects it with a
+// 403 quotaExceeded/rateLimitExceeded, the reservation is released and
+// the next pooled key is tried, until one succeeds or the pool is
+// exhausted.
+func (c *Client) doRequest(ctx context.Context, endpoint Endpoint, params url.Values) ([]byte, error) {
+	start := int(atomic.AddUint32(&c.nextKey, 1) - 1)
+
+	var lastErr error
+	for i := 0; i < len(c.keys); i++ {
+		key := c.keys[(start+i)%len(c.keys)]
+
+		if err := c.quota.Reserve(key, endpoint); err != nil {
+			lastErr = err
+			continue
+		}
+
+		body, err := c.callWithRetry(ctx, endpoint, params, key)
+		if err == nil {
+			return body, nil
+		}
+
+		var fe *failoverError
+		if errors.As(err, &fe) {
+			c.quota.Release(key, endpoint)
+			if c.logger != nil {
+				c.logger.Warn("ytapi: pooled key rejected, failing over",
+					zap.String("endpoint", string(endpoint)), zap.String("reason", fe.reason))
+			}
+			lastErr = err
+			continue
+		}
+
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("ytapi: %s request failed on all %d pooled key(s): %w", endpoint, len(c.keys), lastErr)
+}
+
+// callWithRetry performs one Data API call against apiKey, retrying 5xx
+// responses with exponential backoff up to c.maxRetries attempts. A 403
+// quotaExceeded/rateLimitExceeded response returns a *failoverError
+// instead of being retried, since retrying against the same key would
+// just fail again.
+func (c *Client) callWithRetry(ctx context.Context, endpoint Endpoint, params url.Values, apiKey string) ([]byte, error) {
+	keyed := url.Values{}
+	for k, v := range params {
+		keyed[k] = v
+	}
+	keyed.Set("key", apiKey)
+	apiURL := fmt.Sprintf("https://www.googleapis.com/youtube/v3/%s?%s", endpoint, keyed.Encode())
+
+	var lastErr error
+	for attempt := 1; attempt <= c.maxRetries; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			c.sleepBackoff(attempt)
+			continue
+		}
+
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			return nil, readErr
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusOK:
+			return body, nil
+		case resp.StatusCode == http.StatusForbidden:
+			if reason := apiErrorReason(body); reason == "quotaExceeded" || reason == "rateLimitExceeded" {
+				return nil, &failoverError{reason: reason}
+			}
+			return nil, fmt.Errorf("youtube data api error: %s - %s", resp.Status, string(body))
+		case resp.StatusCode >= 500:
+			lastErr = fmt.Errorf("youtube data api error: %s - %s", resp.Status, string(body))
+			c.sleepBackoff(attempt)
+		default:
+			return nil, fmt.Errorf("youtube data api error: %s - %s", resp.Status, string(body))
+		}
+	}
+
+	return nil, lastErr
+}
+
+// sleepBackoff blocks for backoffDelay(attempt), unless it's the last
+// attempt, in which case there's no point waiting before giving up.
+func (c *Client) sleepBackoff(attempt int) {
+	if attempt >= c.maxRetries {
+		return
+	}
+	time.Sleep(c.backoffDelay(attempt))
+}
+
+// backoffDelay computes an exponential backoff with full jitter, capped
+// at c.maxDelay: base * 2^(attempt-1), randomized into [0, cap]. Mirrors
+// webhooks.Manager.backoffDelay.
+func (c *Client) backoffDelay(attempt int) time.Duration {
+	delay := float64(c.baseDelay) * float64(uint(1)<<uint(attempt-1))
+	if delay > float64(c.maxDelay) {
+		delay = float64(c.maxDelay)
+	}
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// apiErrorReason extracts the first error reason (e.g. "quotaExceeded")
+// from a YouTube Data API error response body, or "" if it doesn't
+// parse as one.
+func apiErrorReason(body []byte) string {
+	var parsed struct {
+		Error struct {
+			Errors []struct {
+				Reason string `json:"reason"`
+			} `json:"errors"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil || len(parsed.Error.Errors) == 0 {
+		return ""
+	}
+	return parsed.Error.Errors[0].Reason
+}
+
+// parseInt64 parses a YouTube Data API statistics field, which the API
+// represents as a string, defaulting to 0 on a parse failure rather than
+// erroring the whole response over one missing count.
+func parseInt64(s string) int64 {
+	var result int64
+	fmt.Sscanf(s, "%d", &result)
+	return result
+}
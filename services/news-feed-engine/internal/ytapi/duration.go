@@ -0,0 +1,70 @@
+package ytapi
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// iso8601DurationRe matches the ISO 8601 duration grammar YouTube's
+// contentDetails.duration uses: P[n]Y[n]M[n]D[T[n]H[n]M[n]S]. Every
+// component is optional; PT0S (a live stream with no fixed length) is
+// valid and matches with every group empty but the seconds group ("0").
+var iso8601DurationRe = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+const (
+	secondsPerMinute = 60
+	secondsPerHour   = 60 * secondsPerMinute
+	secondsPerDay    = 24 * secondsPerHour
+	// secondsPerMonth and secondsPerYear are nominal approximations
+	// (30 and 365 days) for the rare case a duration includes a year or
+	// month component - YouTube video lengths never do in practice, but
+	// the grammar allows it and we don't want to error out on it.
+	secondsPerMonth = 30 * secondsPerDay
+	secondsPerYear  = 365 * secondsPerDay
+)
+
+// ParseISO8601Duration parses an ISO 8601 duration string (as returned in
+// contentDetails.duration, e.g. "PT1H2M3S" or "P1DT2H30M15S") into total
+// seconds. "PT0S" parses to 0, which GetVideoDetails/enrichVideoDetails
+// use as one of the two signals (together with LiveBroadcastContent) that
+// a video is an ongoing live stream.
+func ParseISO8601Duration(s string) (int64, error) {
+	groups := iso8601DurationRe.FindStringSubmatch(s)
+	if groups == nil {
+		return 0, fmt.Errorf("invalid ISO 8601 duration: %q", s)
+	}
+
+	var total int64
+	multipliers := [6]int64{secondsPerYear, secondsPerMonth, secondsPerDay, secondsPerHour, secondsPerMinute, 1}
+	for i, group := range groups[1:] {
+		if group == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(group, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid ISO 8601 duration: %q", s)
+		}
+		total += n * multipliers[i]
+	}
+	return total, nil
+}
+
+// IsShort reports whether v is a YouTube Short by duration (60 seconds or
+// less). A zero duration (PT0S, an ongoing live stream) is not a Short.
+func (v Video) IsShort() bool {
+	return v.DurationSeconds > 0 && v.DurationSeconds <= 60
+}
+
+// IsLive reports whether v is a live stream currently broadcasting:
+// contentDetails.duration is "PT0S" (DurationSeconds == 0) and
+// snippet.liveBroadcastContent is "live".
+func (v Video) IsLive() bool {
+	return v.DurationSeconds == 0 && v.LiveBroadcastContent == "live"
+}
+
+// IsPremiere reports whether v is scheduled to premiere but hasn't
+// started broadcasting yet (snippet.liveBroadcastContent == "upcoming").
+func (v Video) IsPremiere() bool {
+	return v.LiveBroadcastContent == "upcoming"
+}
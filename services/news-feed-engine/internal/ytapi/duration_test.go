@@ -0,0 +1,66 @@
+package ytapi
+
+import "testing"
+
+func TestParseISO8601Duration(t *testing.T) {
+	cases := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{"PT1H2M3S", 3723, false},
+		{"PT0S", 0, false},
+		{"PT15M", 900, false},
+		{"P1DT2H30M15S", 95415, false},
+		{"P1Y", 365 * secondsPerDay, false},
+		{"garbage", 0, true},
+		{"", 0, true},
+	}
+
+	for _, c := range cases {
+		got, err := ParseISO8601Duration(c.input)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("ParseISO8601Duration(%q): expected error, got nil", c.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseISO8601Duration(%q): unexpected error: %v", c.input, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("ParseISO8601Duration(%q) = %d, want %d", c.input, got, c.want)
+		}
+	}
+}
+
+func TestVideoIsShort(t *testing.T) {
+	if !(Video{DurationSeconds: 45}).IsShort() {
+		t.Error("expected 45s video to be a Short")
+	}
+	if (Video{DurationSeconds: 61}).IsShort() {
+		t.Error("expected 61s video to not be a Short")
+	}
+	if (Video{DurationSeconds: 0, LiveBroadcastContent: "live"}).IsShort() {
+		t.Error("expected a live stream (0s duration) to not be a Short")
+	}
+}
+
+func TestVideoIsLive(t *testing.T) {
+	if !(Video{DurationSeconds: 0, LiveBroadcastContent: "live"}).IsLive() {
+		t.Error("expected live broadcast with 0s duration to be live")
+	}
+	if (Video{DurationSeconds: 0, LiveBroadcastContent: "none"}).IsLive() {
+		t.Error("expected a completed 0s-duration video without live content to not be live")
+	}
+}
+
+func TestVideoIsPremiere(t *testing.T) {
+	if !(Video{LiveBroadcastContent: "upcoming"}).IsPremiere() {
+		t.Error("expected upcoming broadcast to be a premiere")
+	}
+	if (Video{LiveBroadcastContent: "live"}).IsPremiere() {
+		t.Error("expected a video already live to not be a premiere")
+	}
+}
@@ -0,0 +1,153 @@
+package ytapi
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
+)
+
+// Endpoint identifies a YouTube Data API resource/method, used both as
+// the request path segment and as the QuotaTracker cost-table key.
+type Endpoint string
+
+const (
+	EndpointChannels       Endpoint = "channels"
+	EndpointVideos         Endpoint = "videos"
+	EndpointPlaylistItems  Endpoint = "playlistItems"
+	EndpointSearch         Endpoint = "search"
+	EndpointCaptions       Endpoint = "captions"
+	EndpointCommentThreads Endpoint = "commentThreads"
+)
+
+// unitCosts is the documented quota cost per call for each endpoint:
+// https://developers.google.com/youtube/v3/determine_quota_cost
+var unitCosts = map[Endpoint]int{
+	EndpointChannels:       1,
+	EndpointVideos:         1,
+	EndpointPlaylistItems:  1,
+	EndpointSearch:         100,
+	EndpointCaptions:       50,
+	EndpointCommentThreads: 1,
+}
+
+// ErrQuotaExceeded is returned by QuotaTracker.Reserve, and bubbles up
+// through Client's typed methods, when charging a request would push an
+// API key over its configured daily budget.
+var ErrQuotaExceeded = fmt.Errorf("youtube data api daily quota exceeded")
+
+// dailyCounter tracks one API key's unit consumption for a single UTC day.
+type dailyCounter struct {
+	day   string // YYYY-MM-DD, UTC
+	units int
+}
+
+// QuotaTracker enforces a per-key daily unit budget against the YouTube
+// Data API's documented per-endpoint costs. The zero value is not
+// usable; construct with NewQuotaTracker. Counters reset naturally at
+// UTC midnight, since a stale dailyCounter.day is simply replaced on the
+// next Reserve - there is no background sweep.
+type QuotaTracker struct {
+	dailyBudget int
+
+	mu       sync.Mutex
+	counters map[string]*dailyCounter
+	consumed map[Endpoint]*metrics.Counter
+}
+
+// NewQuotaTracker creates a QuotaTracker allowing up to dailyBudget units
+// per API key per UTC day. A dailyBudget of 0 means unlimited, tracked
+// for metrics purposes only.
+func NewQuotaTracker(dailyBudget int) *QuotaTracker {
+	return &QuotaTracker{
+		dailyBudget: dailyBudget,
+		counters:    make(map[string]*dailyCounter),
+		consumed:    make(map[Endpoint]*metrics.Counter),
+	}
+}
+
+// Reserve charges endpoint's documented unit cost against apiKey's daily
+// counter, returning ErrQuotaExceeded without charging anything if doing
+// so would exceed dailyBudget. On success, the consumed units are also
+// recorded against the endpoint's Prometheus counter.
+func (q *QuotaTracker) Reserve(apiKey string, endpoint Endpoint) error {
+	cost := unitCost(endpoint)
+	today := time.Now().UTC().Format("2006-01-02")
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	counter, exists := q.counters[apiKey]
+	if !exists || counter.day != today {
+		counter = &dailyCounter{day: today}
+		q.counters[apiKey] = counter
+	}
+
+	if q.dailyBudget > 0 && counter.units+cost > q.dailyBudget {
+		return ErrQuotaExceeded
+	}
+	counter.units += cost
+	q.metricFor(endpoint).Add(int64(cost))
+	return nil
+}
+
+// Release credits cost back to apiKey's counter for today, for callers
+// that reserved optimistically but the request never reached the API -
+// e.g. it failed over to a different pooled key after a 403
+// quotaExceeded response.
+func (q *QuotaTracker) Release(apiKey string, endpoint Endpoint) {
+	cost := unitCost(endpoint)
+	today := time.Now().UTC().Format("2006-01-02")
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	counter, exists := q.counters[apiKey]
+	if !exists || counter.day != today {
+		return
+	}
+	counter.units -= cost
+	if counter.units < 0 {
+		counter.units = 0
+	}
+}
+
+// Remaining reports apiKey's unused budget for today, or -1 if no budget
+// is configured.
+func (q *QuotaTracker) Remaining(apiKey string) int {
+	if q.dailyBudget <= 0 {
+		return -1
+	}
+	today := time.Now().UTC().Format("2006-01-02")
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	counter, exists := q.counters[apiKey]
+	if !exists || counter.day != today {
+		return q.dailyBudget
+	}
+	return q.dailyBudget - counter.units
+}
+
+// metricFor lazily registers and returns the cumulative units-consumed
+// counter for endpoint. Must be called with q.mu held.
+func (q *QuotaTracker) metricFor(endpoint Endpoint) *metrics.Counter {
+	if c, ok := q.consumed[endpoint]; ok {
+		return c
+	}
+	c := metrics.RegisterCounter("ytapi_quota_units_consumed_total_"+string(endpoint), map[string]string{"endpoint": string(endpoint)})
+	q.consumed[endpoint] = c
+	return c
+}
+
+// unitCost looks up endpoint's documented cost, defaulting to 1 for an
+// endpoint this table doesn't know about rather than letting it through
+// for free.
+func unitCost(endpoint Endpoint) int {
+	if cost, ok := unitCosts[endpoint]; ok {
+		return cost
+	}
+	return 1
+}
@@ -3,9 +3,14 @@ package metrics
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/IBM/sarama"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -94,10 +99,169 @@ func CreateRedisHealthChecker(client *redis.Client) HealthChecker {
 	}
 }
 
-// ServiceAvailabilityChecker creates a health checker for checking upstream service availability
+// KafkaChecker creates a health checker for a Kafka cluster. Unlike
+// checking that a producer object merely exists, it probes real
+// broker/topic connectivity via a sarama.Client.
+type KafkaChecker struct {
+	client sarama.Client
+	topics []string
+}
+
+// NewKafkaChecker creates a new Kafka health checker for client, probing
+// the given topics (if any) on every Check.
+func NewKafkaChecker(client sarama.Client, topics ...string) *KafkaChecker {
+	return &KafkaChecker{client: client, topics: topics}
+}
+
+// Check refreshes metadata for the configured topics with a bounded
+// timeout and verifies at least one broker is reachable and every topic
+// has an available leader on every partition. A partition with fewer
+// in-sync replicas than its replication factor degrades the result
+// rather than failing it outright.
+func (kc *KafkaChecker) Check(ctx context.Context) HealthCheckResult {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if kc.client == nil || kc.client.Closed() {
+		return HealthCheckResult{
+			Name:      "kafka",
+			Status:    HealthStatusUnhealthy,
+			Message:   "kafka client not initialized",
+			Timestamp: time.Now(),
+		}
+	}
+
+	refreshErr := make(chan error, 1)
+	go func() { refreshErr <- kc.client.RefreshMetadata(kc.topics...) }()
+	select {
+	case err := <-refreshErr:
+		if err != nil {
+			return HealthCheckResult{
+				Name:      "kafka",
+				Status:    HealthStatusUnhealthy,
+				Message:   fmt.Sprintf("failed to refresh metadata: %v", err),
+				Timestamp: time.Now(),
+			}
+		}
+	case <-ctx.Done():
+		return HealthCheckResult{
+			Name:      "kafka",
+			Status:    HealthStatusUnhealthy,
+			Message:   "timed out refreshing kafka metadata",
+			Timestamp: time.Now(),
+		}
+	}
+
+	if len(kc.client.Brokers()) == 0 {
+		return HealthCheckResult{
+			Name:      "kafka",
+			Status:    HealthStatusUnhealthy,
+			Message:   "no reachable brokers",
+			Timestamp: time.Now(),
+		}
+	}
+
+	degraded := false
+	for _, topic := range kc.topics {
+		partitions, err := kc.client.Partitions(topic)
+		if err != nil {
+			return HealthCheckResult{
+				Name:      "kafka",
+				Status:    HealthStatusUnhealthy,
+				Message:   fmt.Sprintf("topic %q: %v", topic, err),
+				Timestamp: time.Now(),
+			}
+		}
+		for _, partition := range partitions {
+			leader, err := kc.client.Leader(topic, partition)
+			if err != nil || leader == nil {
+				return HealthCheckResult{
+					Name:      "kafka",
+					Status:    HealthStatusUnhealthy,
+					Message:   fmt.Sprintf("topic %q partition %d has no available leader", topic, partition),
+					Timestamp: time.Now(),
+				}
+			}
+			replicas, err := kc.client.Replicas(topic, partition)
+			if err != nil {
+				return HealthCheckResult{
+					Name:      "kafka",
+					Status:    HealthStatusUnhealthy,
+					Message:   fmt.Sprintf("topic %q partition %d: %v", topic, partition, err),
+					Timestamp: time.Now(),
+				}
+			}
+			inSync, err := kc.client.InSyncReplicas(topic, partition)
+			if err != nil {
+				return HealthCheckResult{
+					Name:      "kafka",
+					Status:    HealthStatusUnhealthy,
+					Message:   fmt.Sprintf("topic %q partition %d: %v", topic, partition, err),
+					Timestamp: time.Now(),
+				}
+			}
+			if len(inSync) < len(replicas) {
+				degraded = true
+			}
+		}
+	}
+
+	if degraded {
+		return HealthCheckResult{
+			Name:      "kafka",
+			Status:    HealthStatusDegraded,
+			Message:   "one or more partitions under-replicated",
+			Timestamp: time.Now(),
+		}
+	}
+
+	return HealthCheckResult{
+		Name:      "kafka",
+		Status:    HealthStatusHealthy,
+		Message:   fmt.Sprintf("%d broker(s) reachable", len(kc.client.Brokers())),
+		Timestamp: time.Now(),
+	}
+}
+
+// CreateKafkaHealthChecker creates a health checker function for Kafka.
+func CreateKafkaHealthChecker(client sarama.Client, topics ...string) HealthChecker {
+	checker := NewKafkaChecker(client, topics...)
+	return func(ctx context.Context) HealthCheckResult {
+		return checker.Check(ctx)
+	}
+}
+
+// Circuit breaker defaults for ServiceAvailabilityChecker, overridable
+// per-instance via WithThresholds/WithHTTPClient.
+const (
+	defaultProbeTimeout   = 3 * time.Second
+	defaultSlowThreshold  = 1500 * time.Millisecond
+	defaultDegradeAfter   = 3
+	defaultUnhealthyAfter = 5
+	defaultCooldown       = 30 * time.Second
+)
+
+// ServiceAvailabilityChecker creates a health checker for checking
+// upstream service availability. It wraps the raw probe result in a
+// lightweight circuit breaker: a run of slow-but-successful responses
+// trips it to HealthStatusDegraded, a run of outright failures trips it
+// to HealthStatusUnhealthy, and it resets once cooldown has elapsed
+// since the last bad result.
 type ServiceAvailabilityChecker struct {
 	serviceName string
 	endpoint    string
+	client      *http.Client
+	timeout     time.Duration
+
+	slowThreshold  time.Duration
+	degradeAfter   int
+	unhealthyAfter int
+	cooldown       time.Duration
+
+	mu                  sync.Mutex
+	consecutiveSlow     int
+	consecutiveFailures int
+	openedAt            time.Time
 }
 
 // NewServiceAvailabilityChecker creates a new service availability checker
@@ -105,39 +269,121 @@ func NewServiceAvailabilityChecker(serviceName, endpoint string) *ServiceAvailab
 	return &ServiceAvailabilityChecker{
 		serviceName: serviceName,
 		endpoint:    endpoint,
+		client: &http.Client{
+			Timeout: defaultProbeTimeout,
+			Transport: &http.Transport{
+				MaxIdleConns:        10,
+				MaxIdleConnsPerHost: 10,
+				IdleConnTimeout:     90 * time.Second,
+			},
+		},
+		timeout:        defaultProbeTimeout,
+		slowThreshold:  defaultSlowThreshold,
+		degradeAfter:   defaultDegradeAfter,
+		unhealthyAfter: defaultUnhealthyAfter,
+		cooldown:       defaultCooldown,
 	}
 }
 
+// WithHTTPClient overrides the checker's HTTP client, e.g. to share
+// connection pooling with other outbound callers to the same service.
+func (sac *ServiceAvailabilityChecker) WithHTTPClient(client *http.Client) *ServiceAvailabilityChecker {
+	sac.client = client
+	return sac
+}
+
+// WithThresholds overrides the circuit breaker's slow-response and
+// failure thresholds, the request timeout, and the cooldown after which
+// a tripped breaker resets, in place of the package defaults.
+func (sac *ServiceAvailabilityChecker) WithThresholds(timeout, slowThreshold time.Duration, degradeAfter, unhealthyAfter int, cooldown time.Duration) *ServiceAvailabilityChecker {
+	sac.timeout = timeout
+	sac.slowThreshold = slowThreshold
+	sac.degradeAfter = degradeAfter
+	sac.unhealthyAfter = unhealthyAfter
+	sac.cooldown = cooldown
+	return sac
+}
+
 // Check performs a service availability check
 func (sac *ServiceAvailabilityChecker) Check(ctx context.Context) HealthCheckResult {
-	// Set timeout for the health check
-	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, sac.timeout)
 	defer cancel()
 
-	result, err := healthCheckEndpoint(ctx, sac.endpoint)
+	start := time.Now()
+	probeStatus, message, err := healthCheckEndpoint(ctx, sac.client, sac.endpoint)
+	elapsed := time.Since(start)
 	if err != nil {
-		return HealthCheckResult{
-			Name:      sac.serviceName,
-			Status:    HealthStatusUnhealthy,
-			Message:   err.Error(),
-			Timestamp: time.Now(),
-		}
+		probeStatus = HealthStatusUnhealthy
+		message = err.Error()
 	}
 
-	if result {
-		return HealthCheckResult{
-			Name:      sac.serviceName,
-			Status:    HealthStatusHealthy,
-			Message:   "Available",
-			Timestamp: time.Now(),
-		}
+	status := probeStatus
+	if breakerStatus := sac.recordResult(probeStatus, elapsed); statusRank(breakerStatus) > statusRank(status) {
+		status = breakerStatus
+		message = fmt.Sprintf("circuit breaker open (%s): %s", breakerStatus, message)
 	}
 
 	return HealthCheckResult{
 		Name:      sac.serviceName,
-		Status:    HealthStatusDegraded,
-		Message:   "Service returned non-healthy status",
+		Status:    status,
+		Message:   message,
 		Timestamp: time.Now(),
+		Duration:  elapsed,
+	}
+}
+
+// recordResult folds one probe outcome into the rolling failure/slow
+// counts and returns the status the breaker implies on its own -
+// independent of what the raw probe just reported. Counts reset once
+// cooldown has elapsed since the breaker last saw a bad result.
+func (sac *ServiceAvailabilityChecker) recordResult(probeStatus HealthStatus, elapsed time.Duration) HealthStatus {
+	sac.mu.Lock()
+	defer sac.mu.Unlock()
+
+	now := time.Now()
+	if !sac.openedAt.IsZero() && now.Sub(sac.openedAt) >= sac.cooldown {
+		sac.consecutiveFailures = 0
+		sac.consecutiveSlow = 0
+		sac.openedAt = time.Time{}
+	}
+
+	switch {
+	case probeStatus == HealthStatusUnhealthy:
+		sac.consecutiveFailures++
+		sac.consecutiveSlow = 0
+	case elapsed >= sac.slowThreshold:
+		sac.consecutiveSlow++
+		sac.consecutiveFailures = 0
+	default:
+		sac.consecutiveFailures = 0
+		sac.consecutiveSlow = 0
+		sac.openedAt = time.Time{}
+		return HealthStatusHealthy
+	}
+
+	if sac.openedAt.IsZero() {
+		sac.openedAt = now
+	}
+
+	if sac.consecutiveFailures >= sac.unhealthyAfter {
+		return HealthStatusUnhealthy
+	}
+	if sac.consecutiveSlow >= sac.degradeAfter {
+		return HealthStatusDegraded
+	}
+	return HealthStatusHealthy
+}
+
+// statusRank orders HealthStatus by severity so two statuses can be
+// compared and the worse one kept.
+func statusRank(s HealthStatus) int {
+	switch s {
+	case HealthStatusUnhealthy:
+		return 2
+	case HealthStatusDegraded:
+		return 1
+	default:
+		return 0
 	}
 }
 
@@ -149,15 +395,94 @@ func CreateServiceAvailabilityChecker(serviceName, endpoint string) HealthChecke
 	}
 }
 
-// healthCheckEndpoint checks if a health endpoint returns a healthy status
-func healthCheckEndpoint(ctx context.Context, endpoint string) (bool, error) {
-	// This is a placeholder - in production, use http.Client with proper timeout
-	// For now, just return true if we can create a context
-	select {
-	case <-ctx.Done():
-		return false, ctx.Err()
+// probeResponse is the optional JSON body shape a health endpoint may
+// return; when present, its status field takes precedence over the
+// HTTP status code classification in healthCheckEndpoint.
+type probeResponse struct {
+	Status string `json:"status"`
+}
+
+// healthCheckEndpoint issues a GET against endpoint and classifies the
+// result: 2xx is healthy, 429/503 is degraded (the service is shedding
+// load but still up), and any other non-2xx or transport error is
+// unhealthy. A response body decoding to {"status":"UP|DOWN|DEGRADED"}
+// overrides the status-code classification.
+func healthCheckEndpoint(ctx context.Context, client *http.Client, endpoint string) (HealthStatus, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return HealthStatusUnhealthy, "", err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return HealthStatusUnhealthy, "", err
+	}
+	defer resp.Body.Close()
+
+	var body probeResponse
+	if json.NewDecoder(resp.Body).Decode(&body) == nil {
+		switch strings.ToUpper(body.Status) {
+		case "UP":
+			return HealthStatusHealthy, "Available", nil
+		case "DEGRADED":
+			return HealthStatusDegraded, "Service reported degraded status", nil
+		case "DOWN":
+			return HealthStatusUnhealthy, "Service reported down status", nil
+		}
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable:
+		return HealthStatusDegraded, fmt.Sprintf("Endpoint returned status %d", resp.StatusCode), nil
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return HealthStatusHealthy, "Available", nil
+	case resp.StatusCode >= 500:
+		return HealthStatusUnhealthy, fmt.Sprintf("Endpoint returned status %d", resp.StatusCode), nil
 	default:
-		return true, nil
+		return HealthStatusDegraded, fmt.Sprintf("Endpoint returned status %d", resp.StatusCode), nil
+	}
+}
+
+// CachingChecker memoizes an underlying HealthChecker's result for TTL,
+// so an expensive check (e.g. an upstream service probe) isn't re-run on
+// every scrape when something like a Kubernetes readiness probe hits
+// /readyz every second or so.
+type CachingChecker struct {
+	name    string
+	checker HealthChecker
+	ttl     time.Duration
+
+	mu        sync.Mutex
+	cached    HealthCheckResult
+	fetchedAt time.Time
+}
+
+// NewCachingChecker creates a new TTL-memoizing wrapper around checker.
+func NewCachingChecker(name string, checker HealthChecker, ttl time.Duration) *CachingChecker {
+	return &CachingChecker{name: name, checker: checker, ttl: ttl}
+}
+
+// Check returns the memoized result if it is younger than ttl,
+// otherwise runs checker and caches the fresh result.
+func (cc *CachingChecker) Check(ctx context.Context) HealthCheckResult {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if !cc.fetchedAt.IsZero() && time.Since(cc.fetchedAt) < cc.ttl {
+		return cc.cached
+	}
+
+	cc.cached = cc.checker(ctx)
+	cc.fetchedAt = time.Now()
+	return cc.cached
+}
+
+// CreateCachingHealthChecker creates a health checker function that
+// memoizes checker's result for ttl.
+func CreateCachingHealthChecker(name string, checker HealthChecker, ttl time.Duration) HealthChecker {
+	cc := NewCachingChecker(name, checker, ttl)
+	return func(ctx context.Context) HealthCheckResult {
+		return cc.Check(ctx)
 	}
 }
 
@@ -32,13 +32,91 @@ type HealthChecker func(ctx context.Context) HealthCheckResult
 type HealthCheckRegistry struct {
 	checks map[string]HealthChecker
 	mu     sync.RWMutex
+
+	// services maps a check name to the service label it was registered
+	// under via RegisterForService, so CheckService can aggregate every
+	// check belonging to one logical service (e.g. several probes that
+	// together represent "database").
+	services map[string]string
+
+	// defs and defsMu back RegisterDefinition: the declarative,
+	// Consul-style sibling of Register in health_definitions.go. Kept
+	// separate from checks/mu so the plain function-checker path above
+	// stays exactly as simple as it was before definitions existed.
+	defs   map[string]*registeredDefinition
+	defsMu sync.Mutex
+
+	// maintenanceState backs EnableNodeMaintenance/EnableServiceMaintenance
+	// in health_maintenance.go. Lazily initialized via maintenanceOnce so
+	// a HealthCheckRegistry built before maintenance mode existed (e.g.
+	// zero-valued in a test) still works.
+	maintenanceOnce  sync.Once
+	maintenanceState *maintenanceState
 }
 
 // NewHealthCheckRegistry creates a new health check registry
 func NewHealthCheckRegistry() *HealthCheckRegistry {
 	return &HealthCheckRegistry{
-		checks: make(map[string]HealthChecker),
+		checks:   make(map[string]HealthChecker),
+		services: make(map[string]string),
+		defs:     make(map[string]*registeredDefinition),
+	}
+}
+
+// RegisterForService registers checker under name, same as Register,
+// and additionally tags it as belonging to service so CheckService can
+// report an aggregate status for every check registered under that
+// service label.
+func (hcr *HealthCheckRegistry) RegisterForService(service, name string, checker HealthChecker) error {
+	if service == "" {
+		return fmt.Errorf("service label cannot be empty")
+	}
+	if err := hcr.Register(name, checker); err != nil {
+		return err
+	}
+
+	hcr.mu.Lock()
+	hcr.services[name] = service
+	hcr.mu.Unlock()
+	return nil
+}
+
+// CheckService runs every check registered under service (via
+// RegisterForService) and returns their results. Returns an error if no
+// check is registered under that service label.
+func (hcr *HealthCheckRegistry) CheckService(ctx context.Context, service string) ([]HealthCheckResult, error) {
+	hcr.mu.RLock()
+	var names []string
+	for name, svc := range hcr.services {
+		if svc == service {
+			names = append(names, name)
+		}
+	}
+	hcr.mu.RUnlock()
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("service %q has no registered checks", service)
+	}
+
+	results := make([]HealthCheckResult, 0, len(names)+1)
+	for _, name := range names {
+		result, err := hcr.CheckOne(ctx, name)
+		if err != nil {
+			continue
+		}
+		results = append(results, result)
 	}
+
+	if reason, ok := hcr.ServiceMaintenanceReason(service); ok {
+		results = append(results, HealthCheckResult{
+			Name:      serviceMaintenanceKey(service),
+			Status:    HealthStatusUnhealthy,
+			Message:   "service in maintenance: " + reason,
+			Timestamp: time.Now(),
+		})
+	}
+
+	return results, nil
 }
 
 // Register registers a new health check
@@ -58,16 +136,27 @@ func (hcr *HealthCheckRegistry) Register(name string, checker HealthChecker) err
 	return nil
 }
 
-// Unregister removes a health check
+// Unregister removes a health check, stopping its background probe
+// goroutine first if it was registered via RegisterDefinition.
 func (hcr *HealthCheckRegistry) Unregister(name string) error {
 	hcr.mu.Lock()
-	defer hcr.mu.Unlock()
-
 	if _, exists := hcr.checks[name]; !exists {
+		hcr.mu.Unlock()
 		return fmt.Errorf("health check %q not found", name)
 	}
-
 	delete(hcr.checks, name)
+	delete(hcr.services, name)
+	hcr.mu.Unlock()
+
+	hcr.defsMu.Lock()
+	if rd, ok := hcr.defs[name]; ok {
+		if rd.cancel != nil {
+			rd.cancel()
+		}
+		delete(hcr.defs, name)
+	}
+	hcr.defsMu.Unlock()
+
 	return nil
 }
 
@@ -146,13 +235,24 @@ type HealthReport struct {
 	Description string
 }
 
-// GenerateHealthReport generates a comprehensive health report
+// GenerateHealthReport generates a comprehensive health report. If node
+// maintenance is active (see EnableNodeMaintenance), the report is
+// forced to HealthStatusUnhealthy with the operator's reason regardless
+// of what the underlying checks report.
 func (hcr *HealthCheckRegistry) GenerateHealthReport(ctx context.Context) *HealthReport {
 	results := hcr.CheckAll(ctx)
-	status := hcr.OverallStatus(ctx)
+
+	if reason, ok := hcr.NodeMaintenanceReason(); ok {
+		return &HealthReport{
+			Status:      HealthStatusUnhealthy,
+			Timestamp:   time.Now(),
+			Checks:      results,
+			Description: "node in maintenance: " + reason,
+		}
+	}
 
 	return &HealthReport{
-		Status:    status,
+		Status:    hcr.OverallStatus(ctx),
 		Timestamp: time.Now(),
 		Checks:    results,
 	}
@@ -0,0 +1,372 @@
+package metrics
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HealthCheckKind identifies which kind of probe a HealthCheckDefinition
+// describes - the Consul-style declarative counterpart to Register's
+// plain HealthChecker function.
+type HealthCheckKind int
+
+const (
+	// HealthCheckKindFunction wraps an ordinary HealthChecker, letting it
+	// opt into interval-based background polling and deregistration
+	// without the caller writing that scheduling logic itself.
+	HealthCheckKindFunction HealthCheckKind = iota
+	// HealthCheckKindHTTP probes an HTTP(S) endpoint.
+	HealthCheckKindHTTP
+	// HealthCheckKindTCP probes TCP connect-ability.
+	HealthCheckKindTCP
+	// HealthCheckKindTTL is never actively probed; an external system
+	// pushes its status via HealthCheckRegistry.UpdateTTL, and the check
+	// goes critical on its own once the pushed status is older than its
+	// TTL.Duration.
+	HealthCheckKindTTL
+)
+
+// HTTPCheckDefinition describes an HTTP probe: a GET (or Method) request
+// to URL, healthy when the response status matches ExpectedStatus (or
+// falls in the 2xx range if ExpectedStatus is zero).
+type HTTPCheckDefinition struct {
+	URL            string
+	Method         string
+	Headers        map[string]string
+	TLSSkipVerify  bool
+	ExpectedStatus int
+}
+
+// TCPCheckDefinition describes a TCP probe: healthy when a connection to
+// Address (host:port) succeeds within the check's TimeoutDuration.
+type TCPCheckDefinition struct {
+	Address string
+}
+
+// TTLCheckDefinition describes a TTL (push-based) check: Duration is how
+// long a status pushed via UpdateTTL stays valid before the check is
+// treated as critical with an "TTL expired" message.
+type TTLCheckDefinition struct {
+	Duration time.Duration
+}
+
+// HealthCheckDefinition is the declarative, Consul-style description of
+// a health check: an HTTP probe, a TCP probe, a TTL check, or an
+// existing function-style HealthChecker, plus the scheduling
+// information RegisterDefinition needs to run it unattended - on
+// IntervalDuration, bounded by TimeoutDuration, deregistering itself if
+// it stays critical past DeregisterCriticalServiceAfterDuration.
+type HealthCheckDefinition struct {
+	Name string
+	Kind HealthCheckKind
+
+	// Checker backs HealthCheckKindFunction.
+	Checker HealthChecker
+	// HTTP backs HealthCheckKindHTTP.
+	HTTP *HTTPCheckDefinition
+	// TCP backs HealthCheckKindTCP.
+	TCP *TCPCheckDefinition
+	// TTL backs HealthCheckKindTTL.
+	TTL *TTLCheckDefinition
+
+	// IntervalDuration, if positive, runs the probe on a background
+	// goroutine every interval, caching the latest result for CheckOne/
+	// CheckAll to serve instead of probing inline. Zero means probe
+	// on demand, synchronously, the same as a plain Register checker.
+	IntervalDuration time.Duration
+	// TimeoutDuration bounds each HTTP/TCP probe attempt. Defaults to
+	// 5 seconds if zero.
+	TimeoutDuration time.Duration
+	// DeregisterCriticalServiceAfterDuration, if positive, unregisters
+	// the check once it has been continuously critical for at least
+	// that long, mirroring Consul's deregister_critical_service_after.
+	DeregisterCriticalServiceAfterDuration time.Duration
+}
+
+// registeredDefinition is the live state RegisterDefinition keeps
+// alongside the HealthChecker it installs into HealthCheckRegistry.checks:
+// the definition itself, the background goroutine's cancel func (nil
+// for on-demand checks), the most recent cached result, how long the
+// check has been continuously critical, and - for TTL checks - the
+// last status pushed via UpdateTTL.
+type registeredDefinition struct {
+	def    HealthCheckDefinition
+	cancel context.CancelFunc
+
+	mu            sync.Mutex
+	cached        HealthCheckResult
+	criticalSince time.Time
+
+	ttlStatus   HealthStatus
+	ttlMessage  string
+	ttlPushedAt time.Time
+}
+
+// RegisterDefinition registers a Consul-style HealthCheckDefinition. The
+// resulting check is reachable through every existing
+// HealthCheckRegistry method (CheckOne, CheckAll, OverallStatus,
+// GenerateHealthReport) exactly like one registered via Register; the
+// only difference is how its result gets computed - inline on demand if
+// def.IntervalDuration is zero, or from a background goroutine's cache
+// otherwise.
+func (hcr *HealthCheckRegistry) RegisterDefinition(def HealthCheckDefinition) error {
+	if def.Name == "" {
+		return fmt.Errorf("health check name cannot be empty")
+	}
+	rd := &registeredDefinition{def: def}
+
+	probe, err := buildProbe(def, rd)
+	if err != nil {
+		return err
+	}
+
+	var checker HealthChecker
+	if def.IntervalDuration > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		rd.cancel = cancel
+		checker = func(context.Context) HealthCheckResult {
+			rd.mu.Lock()
+			defer rd.mu.Unlock()
+			return rd.cached
+		}
+		go hcr.pollDefinition(ctx, def.Name, rd, probe)
+	} else {
+		checker = func(ctx context.Context) HealthCheckResult {
+			result := probe(ctx)
+			hcr.recordDefinitionResult(def.Name, rd, result)
+			return result
+		}
+	}
+
+	if err := hcr.Register(def.Name, checker); err != nil {
+		if rd.cancel != nil {
+			rd.cancel()
+		}
+		return err
+	}
+
+	hcr.defsMu.Lock()
+	hcr.defs[def.Name] = rd
+	hcr.defsMu.Unlock()
+	return nil
+}
+
+// UpdateTTL pushes a status for the TTL check registered as name, the
+// way an external system reports its own liveness instead of being
+// actively probed. Returns an error if name isn't a registered TTL
+// check.
+func (hcr *HealthCheckRegistry) UpdateTTL(name string, status HealthStatus, output string) error {
+	hcr.defsMu.Lock()
+	rd, ok := hcr.defs[name]
+	hcr.defsMu.Unlock()
+	if !ok || rd.def.Kind != HealthCheckKindTTL {
+		return fmt.Errorf("health check %q is not a registered TTL check", name)
+	}
+
+	rd.mu.Lock()
+	rd.ttlStatus = status
+	rd.ttlMessage = output
+	rd.ttlPushedAt = time.Now()
+	rd.mu.Unlock()
+	return nil
+}
+
+// pollDefinition runs probe on def's interval until ctx is cancelled
+// (by Unregister) or the check deregisters itself for staying critical
+// past its DeregisterCriticalServiceAfterDuration.
+func (hcr *HealthCheckRegistry) pollDefinition(ctx context.Context, name string, rd *registeredDefinition, probe HealthChecker) {
+	ticker := time.NewTicker(rd.def.IntervalDuration)
+	defer ticker.Stop()
+
+	hcr.recordDefinitionResult(name, rd, probe(ctx))
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if hcr.recordDefinitionResult(name, rd, probe(ctx)) {
+				return
+			}
+		}
+	}
+}
+
+// recordDefinitionResult caches result and, once the check has been
+// continuously critical for at least
+// def.DeregisterCriticalServiceAfterDuration, unregisters it. Returns
+// true if it deregistered the check, so a caller running its own poll
+// loop knows to stop.
+func (hcr *HealthCheckRegistry) recordDefinitionResult(name string, rd *registeredDefinition, result HealthCheckResult) bool {
+	rd.mu.Lock()
+	rd.cached = result
+	if result.Status == HealthStatusUnhealthy {
+		if rd.criticalSince.IsZero() {
+			rd.criticalSince = time.Now()
+		}
+	} else {
+		rd.criticalSince = time.Time{}
+	}
+
+	deregister := rd.def.DeregisterCriticalServiceAfterDuration > 0 &&
+		!rd.criticalSince.IsZero() &&
+		time.Since(rd.criticalSince) >= rd.def.DeregisterCriticalServiceAfterDuration
+	rd.mu.Unlock()
+
+	if deregister {
+		_ = hcr.Unregister(name)
+	}
+	return deregister
+}
+
+// buildProbe turns a HealthCheckDefinition into the HealthChecker that
+// actually performs its probe, independent of whether it'll be driven
+// by a background poll loop or called on demand. rd is only read by the
+// TTL case, which has no active probe of its own - it just reports
+// whatever was last pushed to rd via UpdateTTL.
+func buildProbe(def HealthCheckDefinition, rd *registeredDefinition) (HealthChecker, error) {
+	timeout := def.TimeoutDuration
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	switch def.Kind {
+	case HealthCheckKindFunction:
+		if def.Checker == nil {
+			return nil, fmt.Errorf("health check %q: function check requires a Checker", def.Name)
+		}
+		return def.Checker, nil
+	case HealthCheckKindHTTP:
+		if def.HTTP == nil || def.HTTP.URL == "" {
+			return nil, fmt.Errorf("health check %q: HTTP check requires a URL", def.Name)
+		}
+		return httpProbe(def.Name, *def.HTTP, timeout), nil
+	case HealthCheckKindTCP:
+		if def.TCP == nil || def.TCP.Address == "" {
+			return nil, fmt.Errorf("health check %q: TCP check requires an Address", def.Name)
+		}
+		return tcpProbe(def.Name, *def.TCP, timeout), nil
+	case HealthCheckKindTTL:
+		if def.TTL == nil || def.TTL.Duration <= 0 {
+			return nil, fmt.Errorf("health check %q: TTL check requires a positive Duration", def.Name)
+		}
+		return ttlProbe(def.Name, *def.TTL, rd), nil
+	default:
+		return nil, fmt.Errorf("health check %q: unknown kind %d", def.Name, def.Kind)
+	}
+}
+
+// httpProbe builds the HealthChecker for an HTTP check definition.
+func httpProbe(name string, spec HTTPCheckDefinition, timeout time.Duration) HealthChecker {
+	client := &http.Client{Timeout: timeout}
+	if spec.TLSSkipVerify {
+		client.Transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	}
+	method := spec.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	return func(ctx context.Context) HealthCheckResult {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		start := time.Now()
+		req, err := http.NewRequestWithContext(ctx, method, spec.URL, nil)
+		if err != nil {
+			return HealthCheckResult{Name: name, Status: HealthStatusUnhealthy, Message: err.Error(), Timestamp: time.Now()}
+		}
+		for key, value := range spec.Headers {
+			req.Header.Set(key, value)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return HealthCheckResult{Name: name, Status: HealthStatusUnhealthy, Message: err.Error(), Timestamp: time.Now(), Duration: time.Since(start)}
+		}
+		defer resp.Body.Close()
+
+		healthy := spec.ExpectedStatus != 0 && resp.StatusCode == spec.ExpectedStatus
+		healthy = healthy || (spec.ExpectedStatus == 0 && resp.StatusCode >= 200 && resp.StatusCode < 300)
+		if !healthy {
+			return HealthCheckResult{
+				Name:      name,
+				Status:    HealthStatusUnhealthy,
+				Message:   fmt.Sprintf("unexpected status %d", resp.StatusCode),
+				Timestamp: time.Now(),
+				Duration:  time.Since(start),
+			}
+		}
+
+		return HealthCheckResult{
+			Name:      name,
+			Status:    HealthStatusHealthy,
+			Message:   fmt.Sprintf("%s %s -> %d", method, spec.URL, resp.StatusCode),
+			Timestamp: time.Now(),
+			Duration:  time.Since(start),
+		}
+	}
+}
+
+// tcpProbe builds the HealthChecker for a TCP check definition.
+func tcpProbe(name string, spec TCPCheckDefinition, timeout time.Duration) HealthChecker {
+	return func(ctx context.Context) HealthCheckResult {
+		start := time.Now()
+		dialer := net.Dialer{Timeout: timeout}
+		conn, err := dialer.DialContext(ctx, "tcp", spec.Address)
+		if err != nil {
+			return HealthCheckResult{
+				Name:      name,
+				Status:    HealthStatusUnhealthy,
+				Message:   err.Error(),
+				Timestamp: time.Now(),
+				Duration:  time.Since(start),
+			}
+		}
+		_ = conn.Close()
+
+		return HealthCheckResult{
+			Name:      name,
+			Status:    HealthStatusHealthy,
+			Message:   fmt.Sprintf("connected to %s", spec.Address),
+			Timestamp: time.Now(),
+			Duration:  time.Since(start),
+		}
+	}
+}
+
+// ttlProbe builds the HealthChecker for a TTL check definition, reading
+// rd's pushed state rather than calling out to anything.
+func ttlProbe(name string, spec TTLCheckDefinition, rd *registeredDefinition) HealthChecker {
+	return func(context.Context) HealthCheckResult {
+		rd.mu.Lock()
+		defer rd.mu.Unlock()
+
+		if rd.ttlPushedAt.IsZero() {
+			return HealthCheckResult{
+				Name:      name,
+				Status:    HealthStatusUnhealthy,
+				Message:   "no status has been pushed yet",
+				Timestamp: time.Now(),
+			}
+		}
+		if time.Since(rd.ttlPushedAt) > spec.Duration {
+			return HealthCheckResult{
+				Name:      name,
+				Status:    HealthStatusUnhealthy,
+				Message:   fmt.Sprintf("TTL expired: no update since %s", rd.ttlPushedAt.Format(time.RFC3339)),
+				Timestamp: time.Now(),
+			}
+		}
+		return HealthCheckResult{
+			Name:      name,
+			Status:    rd.ttlStatus,
+			Message:   rd.ttlMessage,
+			Timestamp: time.Now(),
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestHistogramMinMaxHandlesZeroAndNegative guards against the previous
+// "0 means unset" sentinel bug: observing only zero or negative values
+// used to leave Min() stuck at 0.
+func TestHistogramMinMaxHandlesZeroAndNegative(t *testing.T) {
+	h := NewHistogram("test_negative", []int64{10, 100}, nil)
+
+	h.Observe(-5)
+	h.Observe(0)
+	h.Observe(-20)
+
+	if got := h.Min(); got != -20 {
+		t.Fatalf("expected Min() = -20, got %d", got)
+	}
+	if got := h.Max(); got != 0 {
+		t.Fatalf("expected Max() = 0, got %d", got)
+	}
+}
+
+func TestHistogramBucketCounts(t *testing.T) {
+	h := NewHistogram("test_buckets", []int64{10, 100, 1000}, nil)
+
+	for _, v := range []int64{5, 50, 50, 500, 5000} {
+		h.Observe(v)
+	}
+
+	bounds, counts := h.BucketCounts()
+	expectedBounds := []int64{10, 100, 1000}
+	expectedCounts := []int64{1, 3, 4}
+
+	for i := range expectedBounds {
+		if bounds[i] != expectedBounds[i] {
+			t.Fatalf("bucket %d: expected bound %d, got %d", i, expectedBounds[i], bounds[i])
+		}
+		if counts[i] != expectedCounts[i] {
+			t.Fatalf("bucket %d: expected count %d, got %d", i, expectedCounts[i], counts[i])
+		}
+	}
+	if h.Count() != 5 {
+		t.Fatalf("expected total count 5, got %d", h.Count())
+	}
+}
+
+func TestHistogramQuantileApproximatesMedian(t *testing.T) {
+	h := NewHistogram("test_quantile", nil, nil)
+
+	for i := int64(1); i <= 1000; i++ {
+		h.Observe(i)
+	}
+
+	p50 := h.Quantile(0.5)
+	if p50 < 450 || p50 > 550 {
+		t.Fatalf("expected p50 near 500, got %f", p50)
+	}
+}
+
+// BenchmarkHistogramObserve verifies Observe stays cheap at steady
+// state: no raw-value slice growth, just a handful of atomic ops per
+// bucket plus an amortized t-digest insert.
+func BenchmarkHistogramObserve(b *testing.B) {
+	h := NewHistogram("bench_observe", nil, nil)
+	values := make([]int64, 1024)
+	for i := range values {
+		values[i] = rand.Int63n(10000)
+	}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		h.Observe(values[i%len(values)])
+	}
+}
+
+func BenchmarkTimerObserve(b *testing.B) {
+	timer := NewTimer("bench_timer", nil)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		timer.Start()
+		timer.Stop()
+	}
+}
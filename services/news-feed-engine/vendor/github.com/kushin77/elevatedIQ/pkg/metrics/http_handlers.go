@@ -3,14 +3,30 @@ package metrics
 import (
 	"context"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// Drainer reports whether a service has begun draining (see
+// ServiceHealthChecks.Drain), so GetReadinessHandler can report
+// not-ready during a graceful shutdown's load-balancer-deregistration
+// window while GetLivenessHandler keeps reporting alive.
+type Drainer interface {
+	Drained() bool
+}
+
 // HealthCheckHandler represents an HTTP handler for health checks
 type HealthCheckHandler struct {
 	registry *HealthCheckRegistry
+	drainer  Drainer
+}
+
+// SetDrainer wires d into the handler so GetReadinessHandler consults
+// it. The default (nil) means the handler never reports draining.
+func (hch *HealthCheckHandler) SetDrainer(d Drainer) {
+	hch.drainer = d
 }
 
 // NewHealthCheckHandler creates a new health check handler
@@ -105,6 +121,22 @@ func (hch *HealthCheckHandler) GetLivenessHandler() gin.HandlerFunc {
 // GetReadinessHandler returns the readiness probe handler
 func (hch *HealthCheckHandler) GetReadinessHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if hch.drainer != nil && hch.drainer.Drained() {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"status": "not-ready",
+				"reason": "draining",
+			})
+			return
+		}
+
+		if reason, ok := hch.registry.NodeMaintenanceReason(); ok {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"status": "not-ready",
+				"reason": "node in maintenance: " + reason,
+			})
+			return
+		}
+
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
@@ -170,6 +202,107 @@ func (hch *HealthCheckHandler) GetCheckHandler(checkName string) gin.HandlerFunc
 	}
 }
 
+// ServiceHealthResponse is the aggregate result GetServiceHandler
+// returns for every check registered under one service label: the
+// worst status across those checks plus the message from whichever
+// check produced it, alongside every individual check result.
+type ServiceHealthResponse struct {
+	Service string                           `json:"service"`
+	Status  string                           `json:"status"`
+	Message string                           `json:"message,omitempty"`
+	Checks  map[string]HealthCheckResultJSON `json:"checks"`
+}
+
+// GetServiceHandler returns a handler aggregating every check
+// registered under service (via HealthCheckRegistry.RegisterForService)
+// into one status: 200 if every check is healthy, 429 if the worst is
+// degraded, 503 if the worst is unhealthy. This gives a load balancer
+// or service mesh one URL per service to probe instead of needing to
+// know each underlying check's name, unlike GetCheckHandler.
+func (hch *HealthCheckHandler) GetServiceHandler(service string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		results, err := hch.registry.CheckService(ctx, service)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+
+		response := ServiceHealthResponse{
+			Service: service,
+			Status:  string(HealthStatusHealthy),
+			Checks:  make(map[string]HealthCheckResultJSON, len(results)),
+		}
+
+		worst := HealthStatusHealthy
+		for _, result := range results {
+			response.Checks[result.Name] = HealthCheckResultJSON{
+				Name:      result.Name,
+				Status:    string(result.Status),
+				Message:   result.Message,
+				Timestamp: result.Timestamp,
+				Duration:  result.Duration.Milliseconds(),
+			}
+			if statusRank(result.Status) > statusRank(worst) {
+				worst = result.Status
+				response.Message = result.Message
+			}
+		}
+		response.Status = string(worst)
+
+		statusCode := http.StatusOK
+		switch worst {
+		case HealthStatusUnhealthy:
+			statusCode = http.StatusServiceUnavailable
+		case HealthStatusDegraded:
+			statusCode = http.StatusTooManyRequests
+		}
+
+		c.JSON(statusCode, response)
+	}
+}
+
+// GetMaintenanceHandler toggles node-wide maintenance mode via
+// ?enable=true&reason=..., so an on-call engineer can drain a pod for
+// investigation, or a rolling deploy can quiesce a node before
+// shutdown, without editing Kubernetes manifests. Defaults to enabling
+// if ?enable is omitted or unparseable.
+func (hch *HealthCheckHandler) GetMaintenanceHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		enable, _ := strconv.ParseBool(c.DefaultQuery("enable", "true"))
+		reason := c.Query("reason")
+
+		if enable {
+			hch.registry.EnableNodeMaintenance(reason)
+		} else {
+			hch.registry.DisableNodeMaintenance()
+		}
+
+		c.JSON(http.StatusOK, gin.H{"node_maintenance": enable, "reason": reason})
+	}
+}
+
+// GetServiceMaintenanceHandler toggles maintenance mode for a single
+// service label (see HealthCheckRegistry.RegisterForService) via
+// ?enable=true&reason=..., overriding only that service's
+// GetServiceHandler aggregate rather than the whole node.
+func (hch *HealthCheckHandler) GetServiceMaintenanceHandler(service string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		enable, _ := strconv.ParseBool(c.DefaultQuery("enable", "true"))
+		reason := c.Query("reason")
+
+		if enable {
+			hch.registry.EnableServiceMaintenance(service, reason)
+		} else {
+			hch.registry.DisableServiceMaintenance(service)
+		}
+
+		c.JSON(http.StatusOK, gin.H{"service": service, "maintenance": enable, "reason": reason})
+	}
+}
+
 // RegisterHealthCheckRoutes registers health check routes to a gin engine
 func RegisterHealthCheckRoutes(router *gin.Engine, handler *HealthCheckHandler) {
 	router.GET("/health", handler.GetHealthCheckHandler())
@@ -179,6 +312,15 @@ func RegisterHealthCheckRoutes(router *gin.Engine, handler *HealthCheckHandler)
 		name := c.Param("name")
 		handler.GetCheckHandler(name)(c)
 	})
+	router.GET("/health/service/:name", func(c *gin.Context) {
+		name := c.Param("name")
+		handler.GetServiceHandler(name)(c)
+	})
+	router.PUT("/health/maintenance", handler.GetMaintenanceHandler())
+	router.PUT("/health/maintenance/:service", func(c *gin.Context) {
+		service := c.Param("service")
+		handler.GetServiceMaintenanceHandler(service)(c)
+	})
 }
 
 // RegisterHealthCheckRoutesOnGroup registers health check routes to a gin router group
@@ -190,6 +332,15 @@ func RegisterHealthCheckRoutesOnGroup(group *gin.RouterGroup, handler *HealthChe
 		name := c.Param("name")
 		handler.GetCheckHandler(name)(c)
 	})
+	group.GET("/service/:name", func(c *gin.Context) {
+		name := c.Param("name")
+		handler.GetServiceHandler(name)(c)
+	})
+	group.PUT("/maintenance", handler.GetMaintenanceHandler())
+	group.PUT("/maintenance/:service", func(c *gin.Context) {
+		service := c.Param("service")
+		handler.GetServiceMaintenanceHandler(service)(c)
+	})
 }
 
 // MetricsResponse represents metrics data
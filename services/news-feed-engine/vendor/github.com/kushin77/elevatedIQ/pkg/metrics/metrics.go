@@ -2,6 +2,8 @@ package metrics
 
 import (
 	"context"
+	"math"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -31,6 +33,7 @@ type MetricValue struct {
 // Counter represents a monotonically increasing metric
 type Counter struct {
 	name   string
+	help   string
 	value  atomic.Int64
 	labels map[string]string
 	mu     sync.RWMutex
@@ -47,6 +50,14 @@ func NewCounter(name string, labels map[string]string) *Counter {
 	}
 }
 
+// SetHelp sets the HELP text exporters should emit alongside this
+// counter. Left empty, exporters fall back to a generic description.
+func (c *Counter) SetHelp(help string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.help = help
+}
+
 // Increment adds 1 to the counter
 func (c *Counter) Increment() {
 	c.value.Add(1)
@@ -70,6 +81,7 @@ func (c *Counter) Reset() {
 // Gauge represents a metric that can go up or down
 type Gauge struct {
 	name   string
+	help   string
 	value  atomic.Int64
 	labels map[string]string
 	mu     sync.RWMutex
@@ -86,6 +98,14 @@ func NewGauge(name string, labels map[string]string) *Gauge {
 	}
 }
 
+// SetHelp sets the HELP text exporters should emit alongside this gauge.
+// Left empty, exporters fall back to a generic description.
+func (g *Gauge) SetHelp(help string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.help = help
+}
+
 // Set sets the gauge to a specific value (as int64)
 func (g *Gauge) Set(value int64) {
 	g.value.Store(value)
@@ -115,65 +135,98 @@ func (g *Gauge) Value() int64 {
 	return g.value.Load()
 }
 
+// DefaultBuckets is the bucket boundary set histograms fall back to when
+// the caller doesn't specify one, mirroring the Prometheus client
+// libraries' default (.005s .. 10s latency buckets), scaled from seconds
+// to milliseconds since Histogram.Observe takes int64 and this package's
+// timers already record durations in milliseconds.
+var DefaultBuckets = []int64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
 // Histogram represents the distribution of values
 type Histogram struct {
-	name    string
-	values  []int64
-	buckets []int64
-	count   atomic.Int64
-	sum     atomic.Int64
-	min     atomic.Int64
-	max     atomic.Int64
-	labels  map[string]string
-	mu      sync.RWMutex
-}
-
-// NewHistogram creates a new histogram with specified buckets
+	name         string
+	help         string
+	buckets      []int64        // sorted upper bounds, "le" semantics
+	bucketCounts []atomic.Int64 // cumulative count per bucket, index-aligned with buckets
+	count        atomic.Int64
+	sum          atomic.Int64
+	minBits      atomic.Uint64 // math.Float64bits, initialized to +Inf
+	maxBits      atomic.Uint64 // math.Float64bits, initialized to -Inf
+	digest       *tDigest
+	labels       map[string]string
+	mu           sync.RWMutex
+}
+
+// NewHistogram creates a new histogram with specified buckets. Buckets
+// are sorted ascending so bucketCounts can be accumulated in a single
+// pass during Observe.
 func NewHistogram(name string, buckets []int64, labels map[string]string) *Histogram {
 	if labels == nil {
 		labels = make(map[string]string)
 	}
 	if len(buckets) == 0 {
-		buckets = []int64{1, 10, 100, 1000, 10000}
+		buckets = DefaultBuckets
 	}
-	return &Histogram{
-		name:    name,
-		buckets: buckets,
-		labels:  labels,
-		min:     atomic.Int64{},
-		max:     atomic.Int64{},
+	sorted := make([]int64, len(buckets))
+	copy(sorted, buckets)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	h := &Histogram{
+		name:         name,
+		buckets:      sorted,
+		bucketCounts: make([]atomic.Int64, len(sorted)),
+		labels:       labels,
+		digest:       newTDigest(100),
 	}
+	h.minBits.Store(math.Float64bits(math.Inf(1)))
+	h.maxBits.Store(math.Float64bits(math.Inf(-1)))
+	return h
 }
 
-// Observe records a new observation in the histogram
+// Observe records a new observation in the histogram. It only touches
+// atomics and, for the bucket it falls into and above, a handful of
+// atomic adds - no slice growth, so memory stays O(#buckets) regardless
+// of how many observations have been recorded.
 func (h *Histogram) Observe(value int64) {
-	h.mu.Lock()
-	h.values = append(h.values, value)
-	h.mu.Unlock()
-
 	h.count.Add(1)
 	h.sum.Add(value)
 
-	// Update min and max
+	for i, bound := range h.buckets {
+		if value <= bound {
+			h.bucketCounts[i].Add(1)
+		}
+	}
+
+	h.updateMin(float64(value))
+	h.updateMax(float64(value))
+
+	h.digest.Add(float64(value))
+}
+
+// updateMin performs a sentinel-free CAS loop so zero and negative
+// observations are tracked correctly (the previous implementation used 0
+// as a "no value yet" sentinel, which broke on non-positive inputs).
+func (h *Histogram) updateMin(value float64) {
 	for {
-		currentMin := h.min.Load()
-		if currentMin == 0 || value < currentMin {
-			if h.min.CompareAndSwap(currentMin, value) {
-				break
-			}
-		} else {
-			break
+		cur := h.minBits.Load()
+		if value >= math.Float64frombits(cur) {
+			return
+		}
+		if h.minBits.CompareAndSwap(cur, math.Float64bits(value)) {
+			return
 		}
 	}
+}
 
+// updateMax is the mirror of updateMin for the running maximum.
+func (h *Histogram) updateMax(value float64) {
 	for {
-		currentMax := h.max.Load()
-		if value > currentMax {
-			if h.max.CompareAndSwap(currentMax, value) {
-				break
-			}
-		} else {
-			break
+		cur := h.maxBits.Load()
+		if value <= math.Float64frombits(cur) {
+			return
+		}
+		if h.maxBits.CompareAndSwap(cur, math.Float64bits(value)) {
+			return
 		}
 	}
 }
@@ -197,23 +250,50 @@ func (h *Histogram) Mean() float64 {
 	return float64(h.Sum()) / float64(count)
 }
 
-// Min returns the minimum observed value
+// Min returns the minimum observed value, or 0 if no observations have
+// been recorded yet.
 func (h *Histogram) Min() int64 {
-	return h.min.Load()
+	if h.Count() == 0 {
+		return 0
+	}
+	return int64(math.Float64frombits(h.minBits.Load()))
 }
 
-// Max returns the maximum observed value
+// Max returns the maximum observed value, or 0 if no observations have
+// been recorded yet.
 func (h *Histogram) Max() int64 {
-	return h.max.Load()
+	if h.Count() == 0 {
+		return 0
+	}
+	return int64(math.Float64frombits(h.maxBits.Load()))
+}
+
+// Quantile returns a streaming estimate of the value at quantile q (for
+// example 0.5, 0.95, 0.99), backed by a t-digest sketch so accuracy does
+// not depend on retaining every observation.
+func (h *Histogram) Quantile(q float64) float64 {
+	return h.digest.Quantile(q)
+}
+
+// SetHelp sets the HELP text exporters should emit alongside this
+// histogram. Left empty, exporters fall back to a generic description.
+func (h *Histogram) SetHelp(help string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.help = help
 }
 
 // Timer measures elapsed time
 type Timer struct {
-	name      string
-	histogram *Histogram
-	labels    map[string]string
-	startTime time.Time
-	running   atomic.Bool
+	name        string
+	histogram   *Histogram
+	labels      map[string]string
+	startTime   time.Time
+	running     atomic.Bool
+	maxDuration atomic.Int64 // nanoseconds; 0 means unset
+
+	mu               sync.Mutex
+	resultHistograms map[string]*Histogram
 }
 
 // NewTimer creates a new timer
@@ -256,9 +336,11 @@ func (t *Timer) Count() int64 {
 	return t.histogram.Count()
 }
 
-// Context returns a context with timer information
+// Context attaches the timer to ctx under a typed key (see FromContext)
+// rather than the bare "timer" string previously used, so lookups can't
+// collide with another package's context values of the same name.
 func (t *Timer) Context(ctx context.Context) context.Context {
-	return context.WithValue(ctx, "timer", t)
+	return context.WithValue(ctx, timerCtxKey{}, t)
 }
 
 // MetricsRegistry manages all metrics
@@ -380,6 +462,9 @@ type HistogramSnapshot struct {
 	Min   int64
 	Max   int64
 	Mean  float64
+	P50   float64
+	P95   float64
+	P99   float64
 }
 
 // TimerSnapshot represents a snapshot of timer data
@@ -388,6 +473,9 @@ type TimerSnapshot struct {
 	Mean  float64
 	Min   int64
 	Max   int64
+	P50   float64
+	P95   float64
+	P99   float64
 }
 
 // Snapshot captures all current metric values
@@ -418,6 +506,9 @@ func (mr *MetricsRegistry) Snapshot() *Snapshot {
 			Min:   histogram.Min(),
 			Max:   histogram.Max(),
 			Mean:  histogram.Mean(),
+			P50:   histogram.Quantile(0.5),
+			P95:   histogram.Quantile(0.95),
+			P99:   histogram.Quantile(0.99),
 		}
 	}
 
@@ -427,6 +518,9 @@ func (mr *MetricsRegistry) Snapshot() *Snapshot {
 			Mean:  timer.Mean(),
 			Min:   timer.histogram.Min(),
 			Max:   timer.histogram.Max(),
+			P50:   timer.histogram.Quantile(0.5),
+			P95:   timer.histogram.Quantile(0.95),
+			P99:   timer.histogram.Quantile(0.99),
 		}
 	}
 
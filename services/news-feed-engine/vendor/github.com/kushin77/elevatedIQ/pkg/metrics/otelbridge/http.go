@@ -0,0 +1,79 @@
+package otelbridge
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RoundTripper wraps an http.RoundTripper with a client span per request:
+// it injects W3C trace headers via the global propagator and records
+// http.status_code/http.method/net.peer.name.
+type RoundTripper struct {
+	next http.RoundTripper
+	tp   *metrics.TracingProvider
+}
+
+// NewRoundTripper wraps next (http.DefaultTransport if nil) so every
+// request it sends carries a client span and propagation headers.
+func NewRoundTripper(tp *metrics.TracingProvider, next http.RoundTripper) *RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &RoundTripper{next: next, tp: tp}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rt *RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := rt.tp.StartSpan(req.Context(), "http.client."+req.Method, &metrics.SpanOptions{
+		SpanKind: trace.SpanKindClient,
+		Attributes: map[string]interface{}{
+			"http.method":   req.Method,
+			"net.peer.name": req.URL.Hostname(),
+		},
+	})
+	defer span.End()
+
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := rt.next.RoundTrip(req)
+	if err != nil {
+		rt.tp.EndSpan(span, err)
+		return resp, err
+	}
+
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+	rt.tp.EndSpan(span, nil)
+	return resp, nil
+}
+
+// Middleware extracts trace context from an inbound request's headers and
+// starts a server span named by route, wrapping next.
+func Middleware(tp *metrics.TracingProvider, route string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		name := route
+		if name == "" {
+			name = fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+		}
+
+		ctx, span := tp.StartSpan(ctx, name, &metrics.SpanOptions{
+			SpanKind: trace.SpanKindServer,
+			Attributes: map[string]interface{}{
+				"http.method": r.Method,
+				"http.target": r.URL.Path,
+			},
+		})
+		defer span.End()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+		tp.EndSpan(span, nil)
+	})
+}
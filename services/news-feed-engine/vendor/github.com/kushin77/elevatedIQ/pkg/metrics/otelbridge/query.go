@@ -0,0 +1,52 @@
+// Package otelbridge gives every existing call site distributed tracing
+// "for free": a database query wrapper, an outbound http.RoundTripper, and
+// an inbound http.Handler middleware, all built on top of
+// metrics.TracingProvider so they share its exporter configuration rather
+// than standing up a second tracer.
+package otelbridge
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/kushin77/elevatedIQ/pkg/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// QueryHook traces database calls made through it. It is a thin wrapper
+// rather than a driver-level interceptor because this project accesses
+// Postgres through sqlx/database-sql directly (see internal/database)
+// instead of an ORM with its own hook point such as bun.QueryHook.
+type QueryHook struct {
+	tp *metrics.TracingProvider
+}
+
+// NewQueryHook creates a QueryHook that starts spans via tp.
+func NewQueryHook(tp *metrics.TracingProvider) *QueryHook {
+	return &QueryHook{tp: tp}
+}
+
+// Trace runs query (an ExecContext/QueryContext/QueryRowContext call)
+// inside a "db.system"/"db.statement" span, recording rows affected (for
+// exec-style calls; pass -1 when the call has no result, e.g. QueryContext)
+// and any error.
+func (h *QueryHook) Trace(ctx context.Context, statement string, query func(ctx context.Context) (sql.Result, error)) (sql.Result, error) {
+	ctx, span := h.tp.StartSpan(ctx, "db.query", &metrics.SpanOptions{
+		SpanKind: trace.SpanKindClient,
+		Attributes: map[string]interface{}{
+			"db.system":    "postgresql",
+			"db.statement": statement,
+		},
+	})
+	defer span.End()
+
+	result, err := query(ctx)
+	if err == nil && result != nil {
+		if affected, raErr := result.RowsAffected(); raErr == nil {
+			span.SetAttributes(attribute.Int64("db.rows_affected", affected))
+		}
+	}
+	h.tp.EndSpan(span, err)
+	return result, err
+}
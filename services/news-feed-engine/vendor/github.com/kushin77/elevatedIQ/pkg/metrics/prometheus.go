@@ -2,10 +2,17 @@ package metrics
 
 import (
 	"fmt"
+	"net/http"
+	"sort"
 	"strings"
 	"time"
 )
 
+// openMetricsContentType is the exposition format content type required
+// by the OpenMetrics spec (https://openmetrics.io), as opposed to the
+// legacy Prometheus text format prometheusContentType names.
+const openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
 // PrometheusExporter converts metrics to Prometheus format
 type PrometheusExporter struct {
 	registry  *MetricsRegistry
@@ -71,56 +78,72 @@ func (pe *PrometheusExporter) formatLabels(labels map[string]string) string {
 	return "{" + strings.Join(parts, ",") + "}"
 }
 
-// ExportMetrics exports all metrics in Prometheus format
+// ExportMetrics renders the registry as OpenMetrics text exposition
+// format (https://openmetrics.io): one HELP/TYPE pair per distinct
+// metric, cumulative `_bucket{le="..."}` series (plus the mandatory
+// `+Inf` bucket) for histograms, and a trailing `# EOF` marker. It
+// walks the live registry rather than a Snapshot so it can reach each
+// metric's own labels and HELP text - a Snapshot only carries values.
 func (pe *PrometheusExporter) ExportMetrics() string {
-	snapshot := pe.registry.Snapshot()
-	output := strings.Builder{}
-
-	// Export counters
-	output.WriteString("# HELP counters Counters (total increments)\n")
-	output.WriteString("# TYPE counters counter\n")
-	for name, value := range snapshot.Counters {
-		fullName := pe.buildFullMetricName(name, "total")
-		output.WriteString(fmt.Sprintf("%s %d %d\n", fullName, value, snapshot.Timestamp.UnixMilli()))
+	pe.registry.mu.RLock()
+	counters := make([]*Counter, 0, len(pe.registry.counters))
+	for _, c := range pe.registry.counters {
+		counters = append(counters, c)
+	}
+	gauges := make([]*Gauge, 0, len(pe.registry.gauges))
+	for _, g := range pe.registry.gauges {
+		gauges = append(gauges, g)
 	}
+	histograms := make([]*Histogram, 0, len(pe.registry.histograms))
+	for _, h := range pe.registry.histograms {
+		histograms = append(histograms, h)
+	}
+	timers := make([]*Timer, 0, len(pe.registry.timers))
+	for _, t := range pe.registry.timers {
+		timers = append(timers, t)
+	}
+	pe.registry.mu.RUnlock()
 
-	output.WriteString("\n")
+	sort.Slice(counters, func(i, j int) bool { return counters[i].name < counters[j].name })
+	sort.Slice(gauges, func(i, j int) bool { return gauges[i].name < gauges[j].name })
+	sort.Slice(histograms, func(i, j int) bool { return histograms[i].name < histograms[j].name })
+	sort.Slice(timers, func(i, j int) bool { return timers[i].name < timers[j].name })
 
-	// Export gauges
-	output.WriteString("# HELP gauges Gauges (can go up or down)\n")
-	output.WriteString("# TYPE gauges gauge\n")
-	for name, value := range snapshot.Gauges {
-		fullName := pe.buildFullMetricName(name, "")
-		output.WriteString(fmt.Sprintf("%s %d %d\n", fullName, value, snapshot.Timestamp.UnixMilli()))
-	}
+	var b strings.Builder
 
-	output.WriteString("\n")
+	for _, c := range counters {
+		name := pe.buildFullMetricName(c.name, "total")
+		fmt.Fprintf(&b, "# HELP %s %s\n", name, promHelp(c.Help(), "Counter metric", c.name))
+		fmt.Fprintf(&b, "# TYPE %s counter\n", name)
+		fmt.Fprintf(&b, "%s%s %d\n", name, promLabels(c.Labels(), nil), c.Value())
+	}
 
-	// Export histograms
-	output.WriteString("# HELP histograms Histograms\n")
-	output.WriteString("# TYPE histograms histogram\n")
-	for name, hist := range snapshot.Histograms {
-		fullName := pe.buildFullMetricName(name, "")
-		output.WriteString(fmt.Sprintf("%s_count %d %d\n", fullName, hist.Count, snapshot.Timestamp.UnixMilli()))
-		output.WriteString(fmt.Sprintf("%s_sum %d %d\n", fullName, hist.Sum, snapshot.Timestamp.UnixMilli()))
-		output.WriteString(fmt.Sprintf("%s_min %d %d\n", fullName, hist.Min, snapshot.Timestamp.UnixMilli()))
-		output.WriteString(fmt.Sprintf("%s_max %d %d\n", fullName, hist.Max, snapshot.Timestamp.UnixMilli()))
+	for _, g := range gauges {
+		name := pe.buildFullMetricName(g.name, "")
+		fmt.Fprintf(&b, "# HELP %s %s\n", name, promHelp(g.Help(), "Gauge metric", g.name))
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", name)
+		fmt.Fprintf(&b, "%s%s %d\n", name, promLabels(g.Labels(), nil), g.Value())
 	}
 
-	output.WriteString("\n")
+	for _, h := range histograms {
+		writeHistogram(&b, pe.buildFullMetricName(h.name, ""), h.name, h.Help(), h.Labels(), h)
+	}
 
-	// Export timers
-	output.WriteString("# HELP timers Timers (duration measurements)\n")
-	output.WriteString("# TYPE timers histogram\n")
-	for name, timer := range snapshot.Timers {
-		fullName := pe.buildFullMetricName(name, "")
-		output.WriteString(fmt.Sprintf("%s_count %d %d\n", fullName, timer.Count, snapshot.Timestamp.UnixMilli()))
-		output.WriteString(fmt.Sprintf("%s_mean %f %d\n", fullName, timer.Mean, snapshot.Timestamp.UnixMilli()))
-		output.WriteString(fmt.Sprintf("%s_min %d %d\n", fullName, timer.Min, snapshot.Timestamp.UnixMilli()))
-		output.WriteString(fmt.Sprintf("%s_max %d %d\n", fullName, timer.Max, snapshot.Timestamp.UnixMilli()))
+	for _, t := range timers {
+		writeHistogram(&b, pe.buildFullMetricName(t.name, ""), t.name, t.Help(), t.Labels(), t.histogram)
 	}
 
-	return output.String()
+	b.WriteString("# EOF\n")
+
+	return b.String()
+}
+
+// ServeHTTP renders the registry as OpenMetrics text and writes it with
+// the content type OpenMetrics scrapers require, so a PrometheusExporter
+// can be mounted directly as an http.Handler (e.g. at /metrics).
+func (pe *PrometheusExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", openMetricsContentType)
+	fmt.Fprint(w, pe.ExportMetrics())
 }
 
 // ExportMetricsText exports metrics in simple text format
@@ -0,0 +1,244 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// prometheusContentType is the exposition format content type expected by
+// Prometheus scrapers (and most OTLP-compatible agents that speak the
+// text format).
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// BucketCounts returns the configured bucket boundaries and the
+// cumulative ("le") observation count recorded against each, suitable
+// for rendering as Prometheus histogram _bucket series. Reading is O(#buckets)
+// regardless of how many observations have been made.
+func (h *Histogram) BucketCounts() ([]int64, []int64) {
+	bounds := make([]int64, len(h.buckets))
+	copy(bounds, h.buckets)
+
+	counts := make([]int64, len(h.bucketCounts))
+	for i := range h.bucketCounts {
+		counts[i] = h.bucketCounts[i].Load()
+	}
+
+	return bounds, counts
+}
+
+// Labels returns a copy of the labels associated with the counter.
+func (c *Counter) Labels() map[string]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make(map[string]string, len(c.labels))
+	for k, v := range c.labels {
+		out[k] = v
+	}
+	return out
+}
+
+// Help returns the counter's HELP text, or "" if none was set.
+func (c *Counter) Help() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.help
+}
+
+// Labels returns a copy of the labels associated with the gauge.
+func (g *Gauge) Labels() map[string]string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	out := make(map[string]string, len(g.labels))
+	for k, v := range g.labels {
+		out[k] = v
+	}
+	return out
+}
+
+// Help returns the gauge's HELP text, or "" if none was set.
+func (g *Gauge) Help() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.help
+}
+
+// Labels returns a copy of the labels associated with the histogram.
+func (h *Histogram) Labels() map[string]string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	out := make(map[string]string, len(h.labels))
+	for k, v := range h.labels {
+		out[k] = v
+	}
+	return out
+}
+
+// Help returns the histogram's HELP text, or "" if none was set.
+func (h *Histogram) Help() string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.help
+}
+
+// Labels returns a copy of the labels associated with the timer.
+func (t *Timer) Labels() map[string]string {
+	out := make(map[string]string, len(t.labels))
+	for k, v := range t.labels {
+		out[k] = v
+	}
+	return out
+}
+
+// Help returns the timer's underlying histogram's HELP text, or "" if
+// none was set.
+func (t *Timer) Help() string {
+	return t.histogram.Help()
+}
+
+// promName sanitizes a metric name so it is a valid Prometheus identifier.
+func promName(name string) string {
+	replacer := strings.NewReplacer(" ", "_", "-", "_", ".", "_", ":", "_")
+	sanitized := replacer.Replace(name)
+	if sanitized == "" {
+		return "unknown"
+	}
+	return sanitized
+}
+
+// promLabels renders a label set in Prometheus curly-brace notation,
+// merging in any extra labels supplied by the caller (e.g. exporter-wide
+// constant labels).
+func promLabels(metricLabels, extra map[string]string) string {
+	merged := make(map[string]string, len(metricLabels)+len(extra))
+	for k, v := range extra {
+		merged[k] = v
+	}
+	for k, v := range metricLabels {
+		merged[k] = v
+	}
+	if len(merged) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(merged))
+	for k := range merged {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		v := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(merged[k])
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, k, v))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// PrometheusHandler renders the registry in Prometheus/OpenMetrics text
+// exposition format, suitable for mounting at /metrics and scraping
+// directly. Unlike PrometheusExporter.ExportMetrics, it emits one
+// HELP/TYPE pair per distinct metric name and serializes each metric's
+// own labels rather than grouping everything under a single "counters"
+// family.
+func PrometheusHandler(registry *MetricsRegistry) http.Handler {
+	if registry == nil {
+		registry = GlobalRegistry
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", prometheusContentType)
+
+		var b strings.Builder
+
+		registry.mu.RLock()
+		counters := make([]*Counter, 0, len(registry.counters))
+		for _, c := range registry.counters {
+			counters = append(counters, c)
+		}
+		gauges := make([]*Gauge, 0, len(registry.gauges))
+		for _, g := range registry.gauges {
+			gauges = append(gauges, g)
+		}
+		histograms := make([]*Histogram, 0, len(registry.histograms))
+		for _, h := range registry.histograms {
+			histograms = append(histograms, h)
+		}
+		timers := make([]*Timer, 0, len(registry.timers))
+		for _, t := range registry.timers {
+			timers = append(timers, t)
+		}
+		registry.mu.RUnlock()
+
+		sort.Slice(counters, func(i, j int) bool { return counters[i].name < counters[j].name })
+		sort.Slice(gauges, func(i, j int) bool { return gauges[i].name < gauges[j].name })
+		sort.Slice(histograms, func(i, j int) bool { return histograms[i].name < histograms[j].name })
+		sort.Slice(timers, func(i, j int) bool { return timers[i].name < timers[j].name })
+
+		for _, c := range counters {
+			name := promName(c.name) + "_total"
+			fmt.Fprintf(&b, "# HELP %s %s\n", name, promHelp(c.Help(), "Counter metric", c.name))
+			fmt.Fprintf(&b, "# TYPE %s counter\n", name)
+			fmt.Fprintf(&b, "%s%s %d\n", name, promLabels(c.Labels(), nil), c.Value())
+		}
+
+		for _, g := range gauges {
+			name := promName(g.name)
+			fmt.Fprintf(&b, "# HELP %s %s\n", name, promHelp(g.Help(), "Gauge metric", g.name))
+			fmt.Fprintf(&b, "# TYPE %s gauge\n", name)
+			fmt.Fprintf(&b, "%s%s %d\n", name, promLabels(g.Labels(), nil), g.Value())
+		}
+
+		for _, h := range histograms {
+			writeHistogram(&b, promName(h.name), h.name, h.Help(), h.Labels(), h)
+		}
+
+		for _, t := range timers {
+			writeHistogram(&b, promName(t.name), t.name, t.Help(), t.Labels(), t.histogram)
+		}
+
+		fmt.Fprint(w, b.String())
+	})
+}
+
+// promHelp returns the HELP text to emit for a metric: the user-supplied
+// help if one was set via SetHelp, otherwise a generic fallback
+// description naming the metric, matching this package's pre-HELP-text
+// behavior.
+func promHelp(help, fallbackPrefix, rawName string) string {
+	if help != "" {
+		return help
+	}
+	return fmt.Sprintf("%s %s", fallbackPrefix, rawName)
+}
+
+// writeHistogram renders one histogram family, including cumulative
+// bucket series, _sum and _count.
+func writeHistogram(b *strings.Builder, name, rawName, help string, labels map[string]string, h *Histogram) {
+	bounds, counts := h.BucketCounts()
+
+	fmt.Fprintf(b, "# HELP %s %s\n", name, promHelp(help, "Histogram metric", rawName))
+	fmt.Fprintf(b, "# TYPE %s histogram\n", name)
+
+	for i, bound := range bounds {
+		le := strconv.FormatInt(bound, 10)
+		bucketLabels := make(map[string]string, len(labels)+1)
+		for k, v := range labels {
+			bucketLabels[k] = v
+		}
+		bucketLabels["le"] = le
+		fmt.Fprintf(b, "%s_bucket%s %d\n", name, promLabels(bucketLabels, nil), counts[i])
+	}
+
+	infLabels := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		infLabels[k] = v
+	}
+	infLabels["le"] = "+Inf"
+	fmt.Fprintf(b, "%s_bucket%s %d\n", name, promLabels(infLabels, nil), h.Count())
+
+	fmt.Fprintf(b, "%s_sum%s %d\n", name, promLabels(labels, nil), h.Sum())
+	fmt.Fprintf(b, "%s_count%s %d\n", name, promLabels(labels, nil), h.Count())
+}
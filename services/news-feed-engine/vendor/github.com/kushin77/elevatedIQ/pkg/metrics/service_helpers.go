@@ -2,6 +2,8 @@ package metrics
 
 import (
 	"context"
+	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -13,6 +15,8 @@ type ServiceHealthChecks struct {
 	registry *HealthCheckRegistry
 	db       interface{} // Can be *sql.DB or other DB types
 	cache    interface{} // Can be *redis.Client or other cache types
+
+	drained atomic.Bool
 }
 
 // NewServiceHealthChecks creates a new service health checks instance
@@ -54,12 +58,64 @@ func (shc *ServiceHealthChecks) CreateLivenessHandler() gin.HandlerFunc {
 	return handler.GetLivenessHandler()
 }
 
-// CreateReadinessHandler creates a Gin handler for readiness probes
+// CreateReadinessHandler creates a Gin handler for readiness probes,
+// wired to report not-ready while shc is draining (see Drain).
 func (shc *ServiceHealthChecks) CreateReadinessHandler() gin.HandlerFunc {
 	handler := NewHealthCheckHandler(shc.registry)
+	handler.SetDrainer(shc)
 	return handler.GetReadinessHandler()
 }
 
+// CreateDrainHandler creates a Gin handler for POST /health/drain: an
+// operator- or shutdown-hook-triggered request that flips readiness to
+// not-ready ahead of an actual shutdown, without affecting liveness.
+func (shc *ServiceHealthChecks) CreateDrainHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		shc.Drain()
+		c.JSON(http.StatusOK, gin.H{"draining": true})
+	}
+}
+
+// Drain flips the service into drain mode: the readiness handler starts
+// reporting 503 not-ready, while the liveness handler keeps reporting
+// 200 so Kubernetes doesn't kill the pod mid-shutdown - only the load
+// balancer should stop routing new traffic to it. Safe to call more
+// than once or concurrently.
+func (shc *ServiceHealthChecks) Drain() {
+	shc.drained.Store(true)
+}
+
+// Drained reports whether Drain has been called.
+func (shc *ServiceHealthChecks) Drained() bool {
+	return shc.drained.Load()
+}
+
+// ShutdownHook returns a shutdown function - matching the
+// func(ctx context.Context) error signature process.Process.Shutdown
+// (and its FuncProcess.ShutdownFn adapter) use - that drains the
+// service and then waits preStopDelay, or until ctx is cancelled,
+// whichever comes first, before returning. Registering a process with
+// this as its ShutdownFn immediately after the HTTP server process
+// gives the load balancer time to notice the now-failing readiness
+// probe before the server process's own Shutdown (which runs next, in
+// reverse registration order) stops accepting connections - mirroring
+// the leadership-transfer-then-shutdown pattern Consul-style clustered
+// agents use, instead of readiness and liveness flipping at the same
+// instant and in-flight requests getting reset.
+func (shc *ServiceHealthChecks) ShutdownHook(preStopDelay time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		shc.Drain()
+		if preStopDelay <= 0 {
+			return nil
+		}
+		select {
+		case <-time.After(preStopDelay):
+		case <-ctx.Done():
+		}
+		return nil
+	}
+}
+
 // CreateMetricsHandler creates a Gin handler for metrics
 func (shc *ServiceHealthChecks) CreateMetricsHandler() gin.HandlerFunc {
 	handler := NewHealthCheckHandler(shc.registry)
@@ -79,14 +135,22 @@ func (shc *ServiceHealthChecks) RegisterDefaultChecks(serviceName string) error
 	})
 }
 
-// RegisterRoutes registers all health check routes with a Gin engine
+// RegisterRoutes registers all health check routes, plus POST
+// /health/drain, with a Gin engine.
 func (shc *ServiceHealthChecks) RegisterRoutes(router *gin.Engine) {
-	RegisterHealthCheckRoutes(router, NewHealthCheckHandler(shc.registry))
+	handler := NewHealthCheckHandler(shc.registry)
+	handler.SetDrainer(shc)
+	RegisterHealthCheckRoutes(router, handler)
+	router.POST("/health/drain", shc.CreateDrainHandler())
 }
 
-// RegisterRoutesOnGroup registers health check routes on a router group
+// RegisterRoutesOnGroup registers health check routes, plus POST
+// /drain, on a router group.
 func (shc *ServiceHealthChecks) RegisterRoutesOnGroup(group *gin.RouterGroup) {
-	RegisterHealthCheckRoutesOnGroup(group, NewHealthCheckHandler(shc.registry))
+	handler := NewHealthCheckHandler(shc.registry)
+	handler.SetDrainer(shc)
+	RegisterHealthCheckRoutesOnGroup(group, handler)
+	group.POST("/drain", shc.CreateDrainHandler())
 }
 
 // InitializeTracingProvider initializes the global tracing provider
@@ -0,0 +1,125 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+)
+
+// centroid is a single cluster of merged observations in the t-digest.
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// tDigest is a simplified streaming quantile sketch based on Ted
+// Dunning's t-digest. It trades a small amount of accuracy for bounded
+// memory that does not grow with the number of observations, which is
+// what lets Histogram.Observe stay O(#buckets) instead of retaining
+// every value.
+type tDigest struct {
+	mu          sync.Mutex
+	compression float64
+	centroids   []centroid
+	totalWeight float64
+	unmerged    int
+}
+
+// newTDigest creates a t-digest with the given compression factor.
+// Higher compression keeps more centroids (more accuracy, more memory);
+// 100 is a common default that keeps relative error low at the tails.
+func newTDigest(compression float64) *tDigest {
+	if compression <= 0 {
+		compression = 100
+	}
+	return &tDigest{compression: compression}
+}
+
+// Add records a single observation. Centroids are merged lazily every
+// 2*compression insertions rather than on every call, so the amortized
+// cost of Add is a slice append plus an occasional O(n log n) compress.
+func (d *tDigest) Add(value float64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.centroids = append(d.centroids, centroid{mean: value, weight: 1})
+	d.totalWeight++
+	d.unmerged++
+
+	if d.unmerged >= int(d.compression)*2 {
+		d.compress()
+	}
+}
+
+// compress sorts and merges adjacent centroids so their combined weight
+// never exceeds the size implied by the t-digest's scale function. Must
+// be called with d.mu held.
+func (d *tDigest) compress() {
+	sort.Slice(d.centroids, func(i, j int) bool { return d.centroids[i].mean < d.centroids[j].mean })
+
+	merged := make([]centroid, 0, len(d.centroids))
+	var cum float64
+
+	for _, c := range d.centroids {
+		if len(merged) == 0 {
+			merged = append(merged, c)
+			cum = c.weight
+			continue
+		}
+
+		last := &merged[len(merged)-1]
+		q := (cum + last.weight/2) / d.totalWeight
+		maxWeight := 4 * d.totalWeight * q * (1 - q) / d.compression
+
+		if last.weight+c.weight <= maxWeight {
+			newWeight := last.weight + c.weight
+			last.mean = (last.mean*last.weight + c.mean*c.weight) / newWeight
+			last.weight = newWeight
+		} else {
+			merged = append(merged, c)
+		}
+		cum += c.weight
+	}
+
+	d.centroids = merged
+	d.unmerged = 0
+}
+
+// Quantile returns an estimate of the value at quantile q (0 <= q <= 1),
+// interpolating linearly between the two nearest centroid means. It
+// returns 0 if no observations have been recorded yet.
+func (d *tDigest) Quantile(q float64) float64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.centroids) == 0 {
+		return 0
+	}
+
+	d.compress()
+	if len(d.centroids) == 1 {
+		return d.centroids[0].mean
+	}
+
+	target := q * d.totalWeight
+	var cum float64
+
+	for i, c := range d.centroids {
+		if cum+c.weight >= target || i == len(d.centroids)-1 {
+			if i == 0 {
+				return c.mean
+			}
+			prev := d.centroids[i-1]
+			frac := (target - cum) / c.weight
+			if frac < 0 {
+				frac = 0
+			}
+			if frac > 1 {
+				frac = 1
+			}
+			return prev.mean + frac*(c.mean-prev.mean)
+		}
+		cum += c.weight
+	}
+
+	return d.centroids[len(d.centroids)-1].mean
+}
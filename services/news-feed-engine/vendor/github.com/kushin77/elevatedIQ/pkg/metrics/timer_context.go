@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"context"
+	"time"
+)
+
+// timerCtxKey is an unexported type so values stored under it can't
+// collide with context values set by other packages, unlike the bare
+// "timer" string key the original Timer.Context used.
+type timerCtxKey struct{}
+
+// FromContext retrieves the Timer previously attached via Timer.Context,
+// or nil if none is present.
+func FromContext(ctx context.Context) *Timer {
+	t, _ := ctx.Value(timerCtxKey{}).(*Timer)
+	return t
+}
+
+// WithMaxDuration configures a maximum duration for Time: if the
+// function passed to Time has not returned within d, its context is
+// cancelled so the caller has a chance to abort. It returns the timer so
+// it can be chained with NewTimer. A duration <= 0 disables the limit.
+func (t *Timer) WithMaxDuration(d time.Duration) *Timer {
+	t.maxDuration.Store(int64(d))
+	return t
+}
+
+// Time starts the timer, invokes fn with a context derived from ctx
+// (cancelled early if a max duration was configured via
+// WithMaxDuration), stops the timer once fn returns, and records the
+// observation whether or not fn returned an error. The observation is
+// recorded both in the timer's aggregate histogram and in a per-result
+// histogram retrievable via ResultHistogram("ok") / ResultHistogram("error"),
+// mirroring the deadline-timer pattern net.Conn uses for read/write
+// timeouts.
+func (t *Timer) Time(ctx context.Context, fn func(context.Context) error) error {
+	runCtx := ctx
+	if d := time.Duration(t.maxDuration.Load()); d > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
+	}
+
+	t.Start()
+	err := fn(runCtx)
+	duration := t.Stop()
+
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	t.recordResult(result, duration)
+
+	return err
+}
+
+// recordResult observes duration into the per-result histogram for
+// result ("ok" or "error"), creating it on first use. The aggregate
+// histogram (Count/Mean/...) already saw the observation via Stop().
+func (t *Timer) recordResult(result string, duration time.Duration) {
+	hist := t.resultHistogram(result)
+	hist.Observe(duration.Milliseconds())
+}
+
+// resultHistogram returns (creating if necessary) the histogram used to
+// track observations for a given Time() outcome.
+func (t *Timer) resultHistogram(result string) *Histogram {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.resultHistograms == nil {
+		t.resultHistograms = make(map[string]*Histogram)
+	}
+
+	hist, ok := t.resultHistograms[result]
+	if !ok {
+		labels := make(map[string]string, len(t.labels)+1)
+		for k, v := range t.labels {
+			labels[k] = v
+		}
+		labels["result"] = result
+		hist = NewHistogram(t.name, nil, labels)
+		t.resultHistograms[result] = hist
+	}
+	return hist
+}
+
+// ResultHistogram returns the histogram of durations for Time() calls
+// that finished with the given result ("ok" or "error"), or nil if Time
+// has never been called with that result.
+func (t *Timer) ResultHistogram(result string) *Histogram {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.resultHistograms == nil {
+		return nil
+	}
+	return t.resultHistograms[result]
+}
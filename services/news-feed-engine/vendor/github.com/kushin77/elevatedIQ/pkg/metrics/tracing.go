@@ -9,6 +9,11 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
@@ -22,6 +27,21 @@ type TracingConfig struct {
 	JaegerEndpoint string
 	Enabled        bool
 	SampleRate     float64
+
+	// Exporter selects the span exporter: "otlp-grpc", "otlp-http",
+	// "jaeger", "stdout", or "none" (default if empty, same as Enabled=false).
+	Exporter string
+	// Headers are sent with every export request (e.g. API-key headers
+	// required by a managed OTLP collector).
+	Headers map[string]string
+	// Insecure disables TLS for otlp-grpc/otlp-http exporters.
+	Insecure bool
+	// BatchTimeout bounds how long finished spans wait before being
+	// flushed; zero uses the SDK default (5s).
+	BatchTimeout time.Duration
+	// MaxQueueSize bounds the batch span processor's queue; zero uses the
+	// SDK default (2048).
+	MaxQueueSize int
 }
 
 // TracingProvider manages OpenTelemetry tracing
@@ -32,9 +52,8 @@ type TracingProvider struct {
 }
 
 // NewTracingProvider creates a new tracing provider
-// Note: For production use with Jaeger, implement the exporter separately
 func NewTracingProvider(config TracingConfig) (*TracingProvider, error) {
-	if !config.Enabled {
+	if !config.Enabled || config.Exporter == "none" || config.Exporter == "" {
 		return &TracingProvider{
 			tracer: otel.Tracer("disabled"),
 		}, nil
@@ -52,13 +71,35 @@ func NewTracingProvider(config TracingConfig) (*TracingProvider, error) {
 		return nil, fmt.Errorf("failed to create resource: %w", err)
 	}
 
-	// Create trace provider with no-op exporter for testing
-	// In production, add a Jaeger exporter via go.opentelemetry.io/otel/exporters/jaeger
+	exporter, err := newSpanExporter(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s span exporter: %w", config.Exporter, err)
+	}
+
+	batchOpts := []tracesdk.BatchSpanProcessorOption{}
+	if config.BatchTimeout > 0 {
+		batchOpts = append(batchOpts, tracesdk.WithBatchTimeout(config.BatchTimeout))
+	}
+	if config.MaxQueueSize > 0 {
+		batchOpts = append(batchOpts, tracesdk.WithMaxQueueSize(config.MaxQueueSize))
+	}
+
+	sampleRate := config.SampleRate
+	if sampleRate <= 0 {
+		sampleRate = 1.0
+	}
+
 	tp := tracesdk.NewTracerProvider(
 		tracesdk.WithResource(res),
+		tracesdk.WithSpanProcessor(tracesdk.NewBatchSpanProcessor(exporter, batchOpts...)),
+		tracesdk.WithSampler(tracesdk.ParentBased(tracesdk.TraceIDRatioBased(sampleRate))),
 	)
 
 	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
 
 	return &TracingProvider{
 		tracer: tp.Tracer(config.ServiceName),
@@ -68,6 +109,43 @@ func NewTracingProvider(config TracingConfig) (*TracingProvider, error) {
 	}, nil
 }
 
+// newSpanExporter builds the configured exporter. Each constructor is
+// synchronous at startup (connections are established lazily on first
+// export by the gRPC/HTTP clients), matching how the rest of this package
+// fails fast on misconfiguration rather than silently dropping spans.
+func newSpanExporter(config TracingConfig) (tracesdk.SpanExporter, error) {
+	switch config.Exporter {
+	case "otlp-grpc":
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.JaegerEndpoint)}
+		if config.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		if len(config.Headers) > 0 {
+			opts = append(opts, otlptracegrpc.WithHeaders(config.Headers))
+		}
+		return otlptracegrpc.New(context.Background(), opts...)
+
+	case "otlp-http":
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.JaegerEndpoint)}
+		if config.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		if len(config.Headers) > 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(config.Headers))
+		}
+		return otlptracehttp.New(context.Background(), opts...)
+
+	case "jaeger":
+		return jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(config.JaegerEndpoint)))
+
+	case "stdout":
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+
+	default:
+		return nil, fmt.Errorf("unknown exporter %q", config.Exporter)
+	}
+}
+
 // Shutdown shuts down the tracing provider
 func (tp *TracingProvider) Shutdown(ctx context.Context) error {
 	tp.mu.Lock()
@@ -85,6 +163,13 @@ type SpanOptions struct {
 	Attributes  map[string]interface{}
 	StartTime   time.Time
 	RecordError bool
+	// SpanKind classifies the span (server, client, producer, consumer,
+	// internal). Defaults to trace.SpanKindInternal, matching the SDK's
+	// own default, if left as the zero value.
+	SpanKind trace.SpanKind
+	// Links attaches causal links to other spans (e.g. a consumer span
+	// linking back to the producer span that enqueued the message).
+	Links []trace.Link
 }
 
 // StartSpan starts a new span
@@ -96,23 +181,50 @@ func (tp *TracingProvider) StartSpan(ctx context.Context, name string, opts *Spa
 		opts = &SpanOptions{}
 	}
 
-	spanOpts := []trace.SpanStartOption{}
+	spanOpts := []trace.SpanStartOption{trace.WithSpanKind(opts.SpanKind)}
 
 	if !opts.StartTime.IsZero() {
 		spanOpts = append(spanOpts, trace.WithTimestamp(opts.StartTime))
 	}
+	if len(opts.Links) > 0 {
+		spanOpts = append(spanOpts, trace.WithLinks(opts.Links...))
+	}
 
 	ctx, span := tp.tracer.Start(ctx, name, spanOpts...)
 
 	if opts.Attributes != nil {
-		for k, v := range opts.Attributes {
-			span.SetAttributes(attribute.String(k, fmt.Sprintf("%v", v)))
-		}
+		span.SetAttributes(attributeKeyValues(opts.Attributes)...)
 	}
 
 	return ctx, span
 }
 
+// attributeKeyValues converts a loosely-typed attribute map into typed
+// attribute.KeyValues, preserving int/int64/float64/bool/string rather than
+// stringifying everything (which previously lost numeric/boolean type
+// information for anything that read span attributes back out, e.g.
+// exporters that bucket by numeric value).
+func attributeKeyValues(attrs map[string]interface{}) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for k, v := range attrs {
+		switch val := v.(type) {
+		case string:
+			kvs = append(kvs, attribute.String(k, val))
+		case bool:
+			kvs = append(kvs, attribute.Bool(k, val))
+		case int:
+			kvs = append(kvs, attribute.Int(k, val))
+		case int64:
+			kvs = append(kvs, attribute.Int64(k, val))
+		case float64:
+			kvs = append(kvs, attribute.Float64(k, val))
+		default:
+			kvs = append(kvs, attribute.String(k, fmt.Sprintf("%v", val)))
+		}
+	}
+	return kvs
+}
+
 // EndSpan ends a span
 func (tp *TracingProvider) EndSpan(span trace.Span, err error) {
 	if err != nil {
@@ -166,9 +278,29 @@ func ExtractSpanContext(span trace.Span) *SpanContext {
 	}
 }
 
-// InjectSpanContext injects span context into a context
+// InjectHeaders writes ctx's span context into carrier (e.g. an outbound
+// HTTP request's headers) using the global propagator, so a downstream
+// service can continue the same trace. This replaces the previous
+// InjectSpanContext no-op now that a real propagator is registered by
+// NewTracingProvider.
+func InjectHeaders(ctx context.Context, carrier propagation.TextMapCarrier) {
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+}
+
+// ExtractHeaders reads a span context out of carrier (e.g. an inbound HTTP
+// request's headers) using the global propagator, returning a context that
+// continues that trace.
+func ExtractHeaders(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+// InjectSpanContext injects span context into a context.
+//
+// Deprecated: SpanContext only carries IDs, not the propagator state
+// needed to continue a trace across process boundaries. Use
+// InjectHeaders/ExtractHeaders against an http.Header (via
+// propagation.HeaderCarrier) instead.
 func InjectSpanContext(ctx context.Context, spanCtx *SpanContext) context.Context {
-	// This is typically handled by OpenTelemetry's context propagation
 	return ctx
 }
 